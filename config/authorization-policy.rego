@@ -0,0 +1,22 @@
+# Authorization policies of the public APIs evaluated by the OPA engine, see docs/authorization.md
+package kas_fleet_manager.authz
+
+# Organisations where only the organisation admins can create standard kafka instances, e.g. {"13640203"}
+standard_kafka_org_admin_organisations := set()
+
+# The decision of the built-in rules applies unless a rule below denies the action
+decision = input.default_decision {
+	not denied_standard_kafka_creation
+}
+
+decision = {"allowed": false, "reason": "only the organisation admins can create standard kafka instances"} {
+	denied_standard_kafka_creation
+}
+
+denied_standard_kafka_creation {
+	input.action == "create"
+	input.resource.type == "kafka"
+	input.resource.attributes.instance_type == "standard"
+	standard_kafka_org_admin_organisations[input.user.organisation_id]
+	not input.user.is_org_admin
+}
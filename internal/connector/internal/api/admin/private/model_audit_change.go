@@ -0,0 +1,16 @@
+/*
+ * Connector Service Fleet Manager Admin APIs
+ *
+ * Connector Service Fleet Manager Admin is a Rest API to manage connector clusters.
+ *
+ * API version: 0.0.3
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// AuditChange struct for AuditChange
+type AuditChange struct {
+	Old *interface{} `json:"old,omitempty"`
+	New *interface{} `json:"new,omitempty"`
+}
@@ -0,0 +1,21 @@
+/*
+ * Connector Service Fleet Manager Admin APIs
+ *
+ * Connector Service Fleet Manager Admin is a Rest API to manage connector clusters.
+ *
+ * API version: 0.0.3
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// AuthorizationDecision The decision of the authorizer on an action of the actor
+type AuthorizationDecision struct {
+	// The engine of the decision, default or opa
+	Engine       string `json:"engine"`
+	Action       string `json:"action"`
+	ResourceType string `json:"resource_type"`
+	ResourceId   string `json:"resource_id,omitempty"`
+	Allowed      bool   `json:"allowed"`
+	Reason       string `json:"reason,omitempty"`
+}
@@ -0,0 +1,35 @@
+/*
+ * Connector Service Fleet Manager Admin APIs
+ *
+ * Connector Service Fleet Manager Admin is a Rest API to manage connector clusters.
+ *
+ * API version: 0.0.3
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+import (
+	"time"
+)
+
+// ConnectorCatalogSource The sync status of a connector catalog source
+type ConnectorCatalogSource struct {
+	Id   string `json:"id,omitempty"`
+	Kind string `json:"kind,omitempty"`
+	Href string `json:"href,omitempty"`
+	// the directory or URL of the source
+	Name string `json:"name"`
+	// dir, http or oci
+	SourceKind string `json:"source_kind"`
+	// sha256 digest of the content of the source when it was last synced successfully
+	Digest string `json:"digest,omitempty"`
+	// number of connector types in the source
+	Entries int32 `json:"entries"`
+	// true if the signature of the content of the source has been verified
+	Verified bool `json:"verified"`
+	// the error of the last sync, if it failed
+	Error         string     `json:"error,omitempty"`
+	LastSyncAt    time.Time  `json:"last_sync_at,omitempty"`
+	LastSuccessAt *time.Time `json:"last_success_at,omitempty"`
+}
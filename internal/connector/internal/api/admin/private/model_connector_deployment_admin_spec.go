@@ -23,4 +23,6 @@ type ConnectorDeploymentAdminSpec struct {
 	OperatorId    string                 `json:"operator_id,omitempty"`
 	DesiredState  ConnectorDesiredState  `json:"desired_state,omitempty"`
 	ShardMetadata map[string]interface{} `json:"shard_metadata,omitempty"`
+	// the revision of the connector configuration that is deployed.
+	ConfigRevision int64 `json:"config_revision,omitempty"`
 }
@@ -16,4 +16,6 @@ type ConnectorDeploymentAdminStatus struct {
 	ShardMetadata   ConnectorDeploymentAdminStatusShardMetadata `json:"shard_metadata,omitempty"`
 	Operators       ConnectorDeploymentAdminStatusOperators     `json:"operators,omitempty"`
 	Conditions      []MetaV1Condition                           `json:"conditions,omitempty"`
+	// the revision of the connector configuration the status refers to.
+	ConfigRevision int64 `json:"config_revision,omitempty"`
 }
@@ -0,0 +1,36 @@
+/*
+ * Connector Service Fleet Manager Admin APIs
+ *
+ * Connector Service Fleet Manager Admin is a Rest API to manage connector clusters.
+ *
+ * API version: 0.0.3
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+import (
+	"time"
+)
+
+// ConnectorUpgrade An upgrade of a connector deployment applied under an upgrade policy
+type ConnectorUpgrade struct {
+	Id           string    `json:"id,omitempty"`
+	Kind         string    `json:"kind,omitempty"`
+	Href         string    `json:"href,omitempty"`
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	ModifiedAt   time.Time `json:"modified_at,omitempty"`
+	DeploymentId string    `json:"deployment_id"`
+	ConnectorId  string    `json:"connector_id"`
+	NamespaceId  string    `json:"namespace_id"`
+	// channel or operator
+	UpgradeKind         string `json:"upgrade_kind"`
+	FromShardMetadataId int64  `json:"from_shard_metadata_id,omitempty"`
+	ToShardMetadataId   int64  `json:"to_shard_metadata_id,omitempty"`
+	FromOperatorId      string `json:"from_operator_id,omitempty"`
+	ToOperatorId        string `json:"to_operator_id,omitempty"`
+	// in_progress, succeeded or rolled_back
+	State string `json:"state"`
+	// the reason the upgrade was rolled back
+	Error string `json:"error,omitempty"`
+}
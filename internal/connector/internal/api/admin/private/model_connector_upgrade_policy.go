@@ -0,0 +1,34 @@
+/*
+ * Connector Service Fleet Manager Admin APIs
+ *
+ * Connector Service Fleet Manager Admin is a Rest API to manage connector clusters.
+ *
+ * API version: 0.0.3
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+import (
+	"time"
+)
+
+// ConnectorUpgradePolicy struct for ConnectorUpgradePolicy
+type ConnectorUpgradePolicy struct {
+	Id          string                     `json:"id,omitempty"`
+	Kind        string                     `json:"kind,omitempty"`
+	Href        string                     `json:"href,omitempty"`
+	CreatedAt   time.Time                  `json:"created_at,omitempty"`
+	ModifiedAt  time.Time                  `json:"modified_at,omitempty"`
+	NamespaceId string                     `json:"namespace_id,omitempty"`
+	ConnectorId string                     `json:"connector_id,omitempty"`
+	Type        ConnectorUpgradePolicyType `json:"type"`
+	// days of the week of the maintenance window, e.g. sat, sun. Every day if empty
+	WindowDays []string `json:"window_days,omitempty"`
+	// hour of the day in UTC the maintenance window starts at
+	WindowStartHour int32 `json:"window_start_hour,omitempty"`
+	// duration of the maintenance window in hours
+	WindowDurationHours int32 `json:"window_duration_hours,omitempty"`
+	// maximum number of deployments of a namespace being upgraded at the same time
+	MaxConcurrentUpgrades int32 `json:"max_concurrent_upgrades,omitempty"`
+}
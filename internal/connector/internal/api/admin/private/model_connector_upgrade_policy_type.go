@@ -0,0 +1,20 @@
+/*
+ * Connector Service Fleet Manager Admin APIs
+ *
+ * Connector Service Fleet Manager Admin is a Rest API to manage connector clusters.
+ *
+ * API version: 0.0.3
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// ConnectorUpgradePolicyType the model 'ConnectorUpgradePolicyType'
+type ConnectorUpgradePolicyType string
+
+// List of ConnectorUpgradePolicyType
+const (
+	CONNECTORUPGRADEPOLICYTYPE_MANUAL             ConnectorUpgradePolicyType = "manual"
+	CONNECTORUPGRADEPOLICYTYPE_AUTOMATIC          ConnectorUpgradePolicyType = "automatic"
+	CONNECTORUPGRADEPOLICYTYPE_MAINTENANCE_WINDOW ConnectorUpgradePolicyType = "maintenance_window"
+)
@@ -60,9 +60,11 @@ type Connector struct {
 	ConnectorSpec   api.JSON `gorm:"type:jsonb"`
 	DesiredState    ConnectorDesiredState
 	Channel         string
-	Kafka           KafkaConnectionSettings          `gorm:"embedded;embeddedPrefix:kafka_"`
-	SchemaRegistry  SchemaRegistryConnectionSettings `gorm:"embedded;embeddedPrefix:schema_registry_"`
-	ServiceAccount  ServiceAccount                   `gorm:"embedded;embeddedPrefix:service_account_"`
+	// RestartGeneration is bumped to ask the agent to restart the connector
+	RestartGeneration int64                            `gorm:"not null;default:0"`
+	Kafka             KafkaConnectionSettings          `gorm:"embedded;embeddedPrefix:kafka_"`
+	SchemaRegistry    SchemaRegistryConnectionSettings `gorm:"embedded;embeddedPrefix:schema_registry_"`
+	ServiceAccount    ServiceAccount                   `gorm:"embedded;embeddedPrefix:service_account_"`
 
 	Status ConnectorStatus `gorm:"foreignKey:ID"`
 }
@@ -103,6 +105,8 @@ type ConnectorDeployment struct {
 	AllowUpgrade             bool
 	Status                   ConnectorDeploymentStatus `gorm:"foreignKey:ID;references:ID"`
 	Annotations              []ConnectorAnnotation     `gorm:"foreignKey:ConnectorID;references:ConnectorID"`
+	// ConfigRevision is the revision of the connector configuration that is deployed
+	ConfigRevision int64
 }
 
 type ConnectorDeploymentList []ConnectorDeployment
@@ -114,6 +118,8 @@ type ConnectorDeploymentStatus struct {
 	Conditions       api.JSON `gorm:"type:jsonb"`
 	Operators        api.JSON `gorm:"type:jsonb"`
 	UpgradeAvailable bool
+	// ConfigRevision is the revision of the connector configuration the status was reported for
+	ConfigRevision int64
 }
 
 type KafkaConnectionSettings struct {
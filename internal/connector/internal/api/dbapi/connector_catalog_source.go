@@ -0,0 +1,23 @@
+package dbapi
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+)
+
+// ConnectorCatalogSource is the sync status of a connector catalog source, a local directory, an HTTP(S) index or an OCI artifact
+type ConnectorCatalogSource struct {
+	db.Model
+	Name     string
+	Kind     string
+	Digest   string
+	Entries  int
+	Verified bool
+	// Error is the error of the last sync, if it failed
+	Error         string
+	LastSyncAt    time.Time
+	LastSuccessAt *time.Time
+}
+
+type ConnectorCatalogSourceList []*ConnectorCatalogSource
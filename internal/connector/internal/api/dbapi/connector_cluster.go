@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db/dbencryption"
 )
 
 type ConnectorClusterPhaseEnum string
@@ -30,7 +31,7 @@ type ConnectorCluster struct {
 	OrganisationId string
 	Name           string
 	ClientId       string
-	ClientSecret   string
+	ClientSecret   dbencryption.EncryptedString
 	Annotations    []ConnectorClusterAnnotation `gorm:"foreignKey:ConnectorClusterID;references:ID"`
 	Status         ConnectorClusterStatus       `gorm:"embedded;embeddedPrefix:status_"`
 }
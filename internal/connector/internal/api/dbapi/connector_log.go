@@ -0,0 +1,19 @@
+package dbapi
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+)
+
+// ConnectorLogLine is a log line of a connector deployment, pushed by the agent with the deployment status
+type ConnectorLogLine struct {
+	db.Model
+	ConnectorID  string `gorm:"index"`
+	DeploymentID string `gorm:"index"`
+	Pod          string
+	Timestamp    time.Time
+	Message      string
+}
+
+type ConnectorLogLineList []*ConnectorLogLine
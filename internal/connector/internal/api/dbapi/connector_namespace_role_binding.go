@@ -0,0 +1,70 @@
+package dbapi
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+)
+
+// ConnectorNamespaceRole is the role granted on a namespace, each role includes the permissions of the previous ones
+type ConnectorNamespaceRole string
+
+const (
+	// ConnectorNamespaceRoleViewer can see the namespace and its connectors
+	ConnectorNamespaceRoleViewer ConnectorNamespaceRole = "viewer"
+	// ConnectorNamespaceRoleEditor can also create, update and delete connectors in the namespace
+	ConnectorNamespaceRoleEditor ConnectorNamespaceRole = "editor"
+	// ConnectorNamespaceRoleAdmin can also manage the role bindings of the namespace
+	ConnectorNamespaceRoleAdmin ConnectorNamespaceRole = "admin"
+)
+
+var ValidConnectorNamespaceRoles = []string{
+	string(ConnectorNamespaceRoleViewer),
+	string(ConnectorNamespaceRoleEditor),
+	string(ConnectorNamespaceRoleAdmin),
+}
+
+// Includes returns true if the role includes the permissions of the other role
+func (r ConnectorNamespaceRole) Includes(other ConnectorNamespaceRole) bool {
+	return r.rank() >= other.rank() && other.rank() > 0
+}
+
+func (r ConnectorNamespaceRole) rank() int {
+	switch r {
+	case ConnectorNamespaceRoleViewer:
+		return 1
+	case ConnectorNamespaceRoleEditor:
+		return 2
+	case ConnectorNamespaceRoleAdmin:
+		return 3
+	default:
+		return 0
+	}
+}
+
+type ConnectorNamespaceSubjectKind string
+
+const (
+	ConnectorNamespaceSubjectUser           ConnectorNamespaceSubjectKind = "user"
+	ConnectorNamespaceSubjectGroup          ConnectorNamespaceSubjectKind = "group"
+	ConnectorNamespaceSubjectServiceAccount ConnectorNamespaceSubjectKind = "service_account"
+	ConnectorNamespaceSubjectOrganisation   ConnectorNamespaceSubjectKind = "organisation"
+)
+
+var ValidConnectorNamespaceSubjectKinds = []string{
+	string(ConnectorNamespaceSubjectUser),
+	string(ConnectorNamespaceSubjectGroup),
+	string(ConnectorNamespaceSubjectServiceAccount),
+	string(ConnectorNamespaceSubjectOrganisation),
+}
+
+// ConnectorNamespaceRoleBinding grants a role on a namespace to a user, a group, a service account or all the users of an organisation,
+// in addition to the namespace tenant user or organisation
+type ConnectorNamespaceRoleBinding struct {
+	db.Model
+	NamespaceId string                        `gorm:"not null;uniqueIndex:idx_connector_namespace_role_bindings_subject"`
+	SubjectKind ConnectorNamespaceSubjectKind `gorm:"not null;uniqueIndex:idx_connector_namespace_role_bindings_subject"`
+	Subject     string                        `gorm:"not null;uniqueIndex:idx_connector_namespace_role_bindings_subject"`
+	Role        ConnectorNamespaceRole        `gorm:"not null"`
+	CreatedBy   string
+}
+
+type ConnectorNamespaceRoleBindingList []*ConnectorNamespaceRoleBinding
@@ -0,0 +1,41 @@
+package dbapi
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ConnectorResourceUsage is the sum of the resource requests and limits of the pods of a set of connectors
+type ConnectorResourceUsage struct {
+	MemoryRequests resource.Quantity
+	MemoryLimits   resource.Quantity
+	CPURequests    resource.Quantity
+	CPULimits      resource.Quantity
+}
+
+// Add adds count times the resources of other to the usage
+func (u *ConnectorResourceUsage) Add(other ConnectorResourceUsage, count int64) {
+	add := func(q *resource.Quantity, o resource.Quantity) {
+		if !o.IsZero() {
+			q.Add(*resource.NewMilliQuantity(o.MilliValue()*count, o.Format))
+		}
+	}
+	add(&u.MemoryRequests, other.MemoryRequests)
+	add(&u.MemoryLimits, other.MemoryLimits)
+	add(&u.CPURequests, other.CPURequests)
+	add(&u.CPULimits, other.CPULimits)
+}
+
+// ConnectorTypeUsage is the resource usage of the connectors of a type and channel in a namespace
+type ConnectorTypeUsage struct {
+	ConnectorTypeId string
+	Channel         string
+	Connectors      int64
+	ConnectorResourceUsage
+}
+
+// ConnectorNamespaceUsage is the resource usage of the connectors in a namespace that aren't stopped or deleted
+type ConnectorNamespaceUsage struct {
+	Connectors int64
+	ConnectorResourceUsage
+	ConnectorTypes []ConnectorTypeUsage
+}
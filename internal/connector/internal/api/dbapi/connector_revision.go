@@ -0,0 +1,29 @@
+package dbapi
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+)
+
+// ConnectorRevision is an immutable snapshot of the configuration of a connector that has been deployed.
+// Secrets are only kept as vault references, as they are in the connector itself.
+type ConnectorRevision struct {
+	db.Model
+	ConnectorID string `gorm:"uniqueIndex:idx_connector_revisions_connector_id_revision"`
+	// Revision is incremented for every configuration change of the connector, starting at 1
+	Revision int64 `gorm:"uniqueIndex:idx_connector_revisions_connector_id_revision"`
+	// ConnectorVersion is the version of the connector the revision was recorded from
+	ConnectorVersion int64
+
+	ConnectorTypeId          string
+	Channel                  string
+	ConnectorShardMetadataID int64
+	ConnectorSpec            api.JSON                         `gorm:"type:jsonb"`
+	Kafka                    KafkaConnectionSettings          `gorm:"embedded;embeddedPrefix:kafka_"`
+	SchemaRegistry           SchemaRegistryConnectionSettings `gorm:"embedded;embeddedPrefix:schema_registry_"`
+	ServiceAccount           ServiceAccount                   `gorm:"embedded;embeddedPrefix:service_account_"`
+	// SecretRefs are the vault references used by the revision, they are kept in the vault as long as the revision exists
+	SecretRefs api.JSON `gorm:"type:jsonb"`
+}
+
+type ConnectorRevisionList []*ConnectorRevision
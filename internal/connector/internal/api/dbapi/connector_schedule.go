@@ -0,0 +1,33 @@
+package dbapi
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+)
+
+type ConnectorScheduleState string
+
+const (
+	// ConnectorSchedulePending the stop window has not started yet
+	ConnectorSchedulePending ConnectorScheduleState = "pending"
+	// ConnectorScheduleActive the stop window has started and the connector is kept stopped until it ends
+	ConnectorScheduleActive ConnectorScheduleState = "active"
+	// ConnectorScheduleCompleted the stop window has ended
+	ConnectorScheduleCompleted ConnectorScheduleState = "completed"
+)
+
+// ConnectorSchedule is a window during which a connector is stopped. The connector is stopped at StopAt and started again at StartAt
+type ConnectorSchedule struct {
+	db.Model
+	ConnectorID string `gorm:"index"`
+	StopAt      time.Time
+	StartAt     time.Time
+	State       ConnectorScheduleState
+	// ConnectorStopped is true when the connector was stopped by the schedule, and so has to be started again when the window ends
+	ConnectorStopped bool
+	// Error is the reason of the last failure to stop or start the connector
+	Error string
+}
+
+type ConnectorScheduleList []*ConnectorSchedule
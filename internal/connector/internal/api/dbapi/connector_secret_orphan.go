@@ -0,0 +1,18 @@
+package dbapi
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+)
+
+// ConnectorSecretOrphan tracks a vault secret whose owning resource doesn't exist or doesn't reference it anymore,
+// the ID is the name of the secret. The secret is deleted once it has been orphaned for longer than the grace period.
+type ConnectorSecretOrphan struct {
+	db.Model
+	OwningResource string
+	Reason         string
+	DetectedAt     time.Time
+}
+
+type ConnectorSecretOrphanList []*ConnectorSecretOrphan
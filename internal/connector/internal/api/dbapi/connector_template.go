@@ -0,0 +1,67 @@
+package dbapi
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+)
+
+type ConnectorTemplateParameterType string
+
+const (
+	ConnectorTemplateParameterString  ConnectorTemplateParameterType = "string"
+	ConnectorTemplateParameterInteger ConnectorTemplateParameterType = "integer"
+	ConnectorTemplateParameterNumber  ConnectorTemplateParameterType = "number"
+	ConnectorTemplateParameterBoolean ConnectorTemplateParameterType = "boolean"
+)
+
+var ValidConnectorTemplateParameterTypes = []string{
+	string(ConnectorTemplateParameterString),
+	string(ConnectorTemplateParameterInteger),
+	string(ConnectorTemplateParameterNumber),
+	string(ConnectorTemplateParameterBoolean),
+}
+
+// ConnectorTemplate is an organisation scoped blueprint of connectors of a connector type.
+// Its connector spec is partial, and string values can reference declared parameters with ${name} placeholders.
+type ConnectorTemplate struct {
+	db.Model
+	Name           string
+	Description    string
+	Owner          string
+	OrganisationId string `gorm:"index"`
+
+	ConnectorTypeId string
+	Channel         string
+	ConnectorSpec   api.JSON `gorm:"type:jsonb"`
+	// Parameters is the json array of the ConnectorTemplateParameter declared by the template
+	Parameters api.JSON `gorm:"type:jsonb"`
+	// Version is incremented for every update of the template, starting at 1
+	Version int64
+}
+
+type ConnectorTemplateList []*ConnectorTemplate
+
+type ConnectorTemplateParameter struct {
+	Name        string                         `json:"name"`
+	Description string                         `json:"description,omitempty"`
+	Type        ConnectorTemplateParameterType `json:"type"`
+	Required    bool                           `json:"required,omitempty"`
+	Default     interface{}                    `json:"default,omitempty"`
+}
+
+// ConnectorTemplateRender records the template version and the parameter values a connector was created from,
+// a connector needs re-rendering when its template version is older than the current version of the template
+type ConnectorTemplateRender struct {
+	ConnectorID     string `gorm:"primaryKey"`
+	TemplateID      string `gorm:"index"`
+	TemplateVersion int64
+	Parameters      api.JSON `gorm:"type:jsonb"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	// Owner of the connector, read from the connectors table
+	Owner string `gorm:"->;-:migration"`
+}
+
+type ConnectorTemplateRenderList []*ConnectorTemplateRender
@@ -0,0 +1,83 @@
+package dbapi
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+)
+
+type ConnectorUpgradePolicyType string
+
+const (
+	// ConnectorUpgradePolicyManual upgrades are only applied by admins
+	ConnectorUpgradePolicyManual ConnectorUpgradePolicyType = "manual"
+	// ConnectorUpgradePolicyAutomatic upgrades are applied as soon as they are available
+	ConnectorUpgradePolicyAutomatic ConnectorUpgradePolicyType = "automatic"
+	// ConnectorUpgradePolicyMaintenanceWindow upgrades are applied as soon as they are available during the maintenance window
+	ConnectorUpgradePolicyMaintenanceWindow ConnectorUpgradePolicyType = "maintenance_window"
+)
+
+var ValidConnectorUpgradePolicyTypes = []string{
+	string(ConnectorUpgradePolicyManual),
+	string(ConnectorUpgradePolicyAutomatic),
+	string(ConnectorUpgradePolicyMaintenanceWindow),
+}
+
+// ConnectorUpgradePolicy defines how the upgrades of the deployments of a namespace or of a connector are applied.
+// The policy of a connector takes precedence over the policy of its namespace, upgrades are manual without a policy.
+type ConnectorUpgradePolicy struct {
+	db.Model
+	NamespaceID *string `gorm:"uniqueIndex"`
+	ConnectorID *string `gorm:"uniqueIndex"`
+	Type        ConnectorUpgradePolicyType
+	// WindowDays are the days of the week of the maintenance window, e.g. "sat,sun", every day if empty
+	WindowDays string
+	// WindowStartHour is the hour of the day in UTC the maintenance window starts at
+	WindowStartHour int
+	// WindowDurationHours is the duration of the maintenance window
+	WindowDurationHours int
+	// MaxConcurrentUpgrades is the maximum number of deployments of a namespace being upgraded at the same time
+	MaxConcurrentUpgrades int
+}
+
+type ConnectorUpgradePolicyList []*ConnectorUpgradePolicy
+
+type ConnectorUpgradeKind string
+
+const (
+	ConnectorUpgradeChannel  ConnectorUpgradeKind = "channel"
+	ConnectorUpgradeOperator ConnectorUpgradeKind = "operator"
+)
+
+type ConnectorUpgradeState string
+
+const (
+	// ConnectorUpgradeInProgress the upgrade has been applied and the agent hasn't reported a healthy deployment yet
+	ConnectorUpgradeInProgress ConnectorUpgradeState = "in_progress"
+	// ConnectorUpgradeSucceeded the agent reported a healthy deployment after the upgrade
+	ConnectorUpgradeSucceeded ConnectorUpgradeState = "succeeded"
+	// ConnectorUpgradeRolledBack the deployment failed after the upgrade, or didn't become healthy in time, and was rolled back
+	ConnectorUpgradeRolledBack ConnectorUpgradeState = "rolled_back"
+)
+
+// ConnectorUpgrade records an upgrade of a deployment applied by the upgrade worker
+type ConnectorUpgrade struct {
+	db.Model
+	DeploymentID string `gorm:"index"`
+	ConnectorID  string
+	NamespaceID  string `gorm:"index"`
+	Kind         ConnectorUpgradeKind
+	// FromShardMetadataID and ToShardMetadataID are set for channel upgrades
+	FromShardMetadataID int64
+	ToShardMetadataID   int64
+	// FromOperatorID and ToOperatorID are set for operator upgrades
+	FromOperatorID string
+	ToOperatorID   string
+	// DeploymentVersion is the version of the deployment after the upgrade, older statuses don't reflect the upgrade
+	DeploymentVersion int64
+	State             ConnectorUpgradeState `gorm:"index"`
+	StartedAt         time.Time
+	Error             string
+}
+
+type ConnectorUpgradeList []*ConnectorUpgrade
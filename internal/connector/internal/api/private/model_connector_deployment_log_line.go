@@ -0,0 +1,21 @@
+/*
+ * Connector Service Fleet Manager Private APIs
+ *
+ * Connector Service Fleet Manager apis that are used by internal services.
+ *
+ * API version: 0.0.3
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+import (
+	"time"
+)
+
+// ConnectorDeploymentLogLine A log line of a connector deployment
+type ConnectorDeploymentLogLine struct {
+	Timestamp time.Time `json:"timestamp,omitempty"`
+	Pod       string    `json:"pod,omitempty"`
+	Message   string    `json:"message,omitempty"`
+}
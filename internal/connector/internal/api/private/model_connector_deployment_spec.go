@@ -23,4 +23,8 @@ type ConnectorDeploymentSpec struct {
 	OperatorId    string                 `json:"operator_id,omitempty"`
 	DesiredState  ConnectorDesiredState  `json:"desired_state,omitempty"`
 	ShardMetadata map[string]interface{} `json:"shard_metadata,omitempty"`
+	// a generation that is bumped every time a restart of the connector is requested, the connector must be restarted when it changes.
+	RestartGeneration int64 `json:"restart_generation,omitempty"`
+	// the revision of the connector configuration, it must be reported back in the deployment status.
+	ConfigRevision int64 `json:"config_revision,omitempty"`
 }
@@ -15,4 +15,8 @@ type ConnectorDeploymentStatus struct {
 	ResourceVersion int64                              `json:"resource_version,omitempty"`
 	Operators       ConnectorDeploymentStatusOperators `json:"operators,omitempty"`
 	Conditions      []MetaV1Condition                  `json:"conditions,omitempty"`
+	// the revision of the connector configuration the status refers to.
+	ConfigRevision int64 `json:"config_revision,omitempty"`
+	// the most recent log lines of the connector since the previous status update.
+	Logs []ConnectorDeploymentLogLine `json:"logs,omitempty"`
 }
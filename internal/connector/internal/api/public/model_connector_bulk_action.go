@@ -0,0 +1,22 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// ConnectorBulkAction the model 'ConnectorBulkAction'
+type ConnectorBulkAction string
+
+// List of ConnectorBulkAction
+const (
+	CONNECTORBULKACTION_STOP            ConnectorBulkAction = "stop"
+	CONNECTORBULKACTION_START           ConnectorBulkAction = "start"
+	CONNECTORBULKACTION_DELETE          ConnectorBulkAction = "delete"
+	CONNECTORBULKACTION_UPGRADE_CHANNEL ConnectorBulkAction = "upgrade_channel"
+)
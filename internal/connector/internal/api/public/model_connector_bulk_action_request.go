@@ -0,0 +1,18 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// ConnectorBulkActionRequest struct for ConnectorBulkActionRequest
+type ConnectorBulkActionRequest struct {
+	Action ConnectorBulkAction `json:"action"`
+	// Search criteria of the connectors the action is applied to, with the same syntax as the search parameter of the connectors list
+	Search string `json:"search"`
+}
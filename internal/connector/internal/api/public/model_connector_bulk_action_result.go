@@ -0,0 +1,26 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// ConnectorBulkActionResult struct for ConnectorBulkActionResult
+type ConnectorBulkActionResult struct {
+	Kind   string              `json:"kind"`
+	Action ConnectorBulkAction `json:"action"`
+	// the number of connectors matched by the search
+	Total int32 `json:"total"`
+	// the number of connectors the action has been applied to
+	Succeeded int32 `json:"succeeded"`
+	// the number of connectors the action didn't need to be applied to
+	Skipped int32 `json:"skipped"`
+	// the number of connectors the action failed to be applied to
+	Failed int32                           `json:"failed"`
+	Items  []ConnectorBulkActionResultItem `json:"items"`
+}
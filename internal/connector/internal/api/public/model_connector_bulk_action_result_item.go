@@ -0,0 +1,20 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// ConnectorBulkActionResultItem The outcome of a bulk action for a connector
+type ConnectorBulkActionResultItem struct {
+	ConnectorId string `json:"connector_id"`
+	// Values: [succeeded, skipped, failed]
+	Result string `json:"result"`
+	// the reason of the failure
+	Error string `json:"error,omitempty"`
+}
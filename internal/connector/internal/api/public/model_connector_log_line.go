@@ -0,0 +1,23 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+import (
+	"time"
+)
+
+// ConnectorLogLine A log line of a connector
+type ConnectorLogLine struct {
+	Timestamp time.Time `json:"timestamp,omitempty"`
+	// The pod the log line was emitted by
+	Pod     string `json:"pod,omitempty"`
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,17 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// ConnectorMetricsRangeQuery struct for ConnectorMetricsRangeQuery
+type ConnectorMetricsRangeQuery struct {
+	Metric map[string]string        `json:"metric,omitempty"`
+	Values []ConnectorMetricsValues `json:"values,omitempty"`
+}
@@ -0,0 +1,18 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// ConnectorMetricsRangeQueryList struct for ConnectorMetricsRangeQueryList
+type ConnectorMetricsRangeQueryList struct {
+	Kind  string                       `json:"kind,omitempty"`
+	Id    string                       `json:"id,omitempty"`
+	Items []ConnectorMetricsRangeQuery `json:"items,omitempty"`
+}
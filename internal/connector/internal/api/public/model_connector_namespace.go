@@ -24,10 +24,11 @@ type ConnectorNamespace struct {
 	ModifiedAt time.Time `json:"modified_at,omitempty"`
 	Name       string    `json:"name"`
 	// Name-value string annotations for resource
-	Annotations     map[string]string       `json:"annotations,omitempty"`
-	ResourceVersion int64                   `json:"resource_version"`
-	Quota           ConnectorNamespaceQuota `json:"quota,omitempty"`
-	ClusterId       string                  `json:"cluster_id"`
+	Annotations     map[string]string        `json:"annotations,omitempty"`
+	ResourceVersion int64                    `json:"resource_version"`
+	Quota           ConnectorNamespaceQuota  `json:"quota,omitempty"`
+	Usage           *ConnectorNamespaceUsage `json:"usage,omitempty"`
+	ClusterId       string                   `json:"cluster_id"`
 	// Namespace expiration timestamp in RFC 3339 format
 	Expiration string                   `json:"expiration,omitempty"`
 	Tenant     ConnectorNamespaceTenant `json:"tenant"`
@@ -0,0 +1,21 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// ConnectorNamespaceRole the model 'ConnectorNamespaceRole'
+type ConnectorNamespaceRole string
+
+// List of ConnectorNamespaceRole
+const (
+	CONNECTORNAMESPACEROLE_VIEWER ConnectorNamespaceRole = "viewer"
+	CONNECTORNAMESPACEROLE_EDITOR ConnectorNamespaceRole = "editor"
+	CONNECTORNAMESPACEROLE_ADMIN  ConnectorNamespaceRole = "admin"
+)
@@ -0,0 +1,30 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+import (
+	"time"
+)
+
+// ConnectorNamespaceRoleBinding struct for ConnectorNamespaceRoleBinding
+type ConnectorNamespaceRoleBinding struct {
+	Id          string                        `json:"id,omitempty"`
+	Kind        string                        `json:"kind,omitempty"`
+	Href        string                        `json:"href,omitempty"`
+	NamespaceId string                        `json:"namespace_id,omitempty"`
+	SubjectKind ConnectorNamespaceSubjectKind `json:"subject_kind"`
+	// the user name, group name, service account client id or organisation id the role is granted to
+	Subject    string                 `json:"subject"`
+	Role       ConnectorNamespaceRole `json:"role"`
+	CreatedBy  string                 `json:"created_by,omitempty"`
+	CreatedAt  time.Time              `json:"created_at,omitempty"`
+	ModifiedAt time.Time              `json:"modified_at,omitempty"`
+}
@@ -0,0 +1,19 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// ConnectorNamespaceRoleBindingRequest A role granted on a connector namespace
+type ConnectorNamespaceRoleBindingRequest struct {
+	SubjectKind ConnectorNamespaceSubjectKind `json:"subject_kind"`
+	// the user name, group name, service account client id or organisation id the role is granted to
+	Subject string                 `json:"subject"`
+	Role    ConnectorNamespaceRole `json:"role"`
+}
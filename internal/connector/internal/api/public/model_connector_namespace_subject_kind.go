@@ -0,0 +1,22 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// ConnectorNamespaceSubjectKind the model 'ConnectorNamespaceSubjectKind'
+type ConnectorNamespaceSubjectKind string
+
+// List of ConnectorNamespaceSubjectKind
+const (
+	CONNECTORNAMESPACESUBJECTKIND_USER            ConnectorNamespaceSubjectKind = "user"
+	CONNECTORNAMESPACESUBJECTKIND_GROUP           ConnectorNamespaceSubjectKind = "group"
+	CONNECTORNAMESPACESUBJECTKIND_SERVICE_ACCOUNT ConnectorNamespaceSubjectKind = "service_account"
+	CONNECTORNAMESPACESUBJECTKIND_ORGANISATION    ConnectorNamespaceSubjectKind = "organisation"
+)
@@ -0,0 +1,26 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// ConnectorNamespaceTypeUsage Resources used by the connectors of a type and channel in a namespace
+type ConnectorNamespaceTypeUsage struct {
+	ConnectorTypeId string `json:"connector_type_id"`
+	Channel         string `json:"channel"`
+	Connectors      int32  `json:"connectors"`
+	// Memory quota for limits or requests
+	MemoryRequests string `json:"memory_requests,omitempty"`
+	// Memory quota for limits or requests
+	MemoryLimits string `json:"memory_limits,omitempty"`
+	// CPU quota for limits or requests
+	CpuRequests string `json:"cpu_requests,omitempty"`
+	// CPU quota for limits or requests
+	CpuLimits string `json:"cpu_limits,omitempty"`
+}
@@ -0,0 +1,26 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// ConnectorNamespaceUsage Resources used by the connectors in the namespace that aren't stopped
+type ConnectorNamespaceUsage struct {
+	Connectors int32 `json:"connectors"`
+	// Memory quota for limits or requests
+	MemoryRequests string `json:"memory_requests,omitempty"`
+	// Memory quota for limits or requests
+	MemoryLimits string `json:"memory_limits,omitempty"`
+	// CPU quota for limits or requests
+	CpuRequests string `json:"cpu_requests,omitempty"`
+	// CPU quota for limits or requests
+	CpuLimits string `json:"cpu_limits,omitempty"`
+	// Resources used per connector type and channel
+	ConnectorTypes []ConnectorNamespaceTypeUsage `json:"connector_types,omitempty"`
+}
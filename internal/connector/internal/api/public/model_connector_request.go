@@ -23,4 +23,8 @@ type ConnectorRequest struct {
 	ServiceAccount ServiceAccount                   `json:"service_account"`
 	SchemaRegistry SchemaRegistryConnectionSettings `json:"schema_registry,omitempty"`
 	Connector      map[string]interface{}           `json:"connector"`
+	// the id of the connector template the connector is created from
+	TemplateId string `json:"template_id,omitempty"`
+	// the values of the connector template parameters
+	TemplateParameters map[string]interface{} `json:"template_parameters,omitempty"`
 }
@@ -0,0 +1,33 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+import (
+	"time"
+)
+
+// ConnectorRevision struct for ConnectorRevision
+type ConnectorRevision struct {
+	Id        string    `json:"id,omitempty"`
+	Kind      string    `json:"kind,omitempty"`
+	Href      string    `json:"href,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// the revision number, incremented for every configuration change of the connector
+	Revision int64 `json:"revision"`
+	// the resource version of the connector the revision was recorded from
+	ResourceVersion int64                            `json:"resource_version,omitempty"`
+	ConnectorTypeId string                           `json:"connector_type_id"`
+	Channel         Channel                          `json:"channel,omitempty"`
+	Kafka           KafkaConnectionSettings          `json:"kafka"`
+	ServiceAccount  ServiceAccount                   `json:"service_account"`
+	SchemaRegistry  SchemaRegistryConnectionSettings `json:"schema_registry,omitempty"`
+	Connector       map[string]interface{}           `json:"connector"`
+}
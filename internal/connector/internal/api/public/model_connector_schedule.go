@@ -0,0 +1,30 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+import (
+	"time"
+)
+
+// ConnectorSchedule struct for ConnectorSchedule
+type ConnectorSchedule struct {
+	Id        string    `json:"id,omitempty"`
+	Kind      string    `json:"kind,omitempty"`
+	Href      string    `json:"href,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// when the connector is stopped
+	StopAt time.Time `json:"stop_at"`
+	// when the connector is started again
+	StartAt time.Time              `json:"start_at"`
+	State   ConnectorScheduleState `json:"state"`
+	// the reason of the last failure to stop or start the connector
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,23 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+import (
+	"time"
+)
+
+// ConnectorScheduleRequest A window during which the connector is stopped
+type ConnectorScheduleRequest struct {
+	// when the connector is stopped
+	StopAt time.Time `json:"stop_at"`
+	// when the connector is started again
+	StartAt time.Time `json:"start_at"`
+}
@@ -0,0 +1,21 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// ConnectorScheduleState the model 'ConnectorScheduleState'
+type ConnectorScheduleState string
+
+// List of ConnectorScheduleState
+const (
+	CONNECTORSCHEDULESTATE_PENDING   ConnectorScheduleState = "pending"
+	CONNECTORSCHEDULESTATE_ACTIVE    ConnectorScheduleState = "active"
+	CONNECTORSCHEDULESTATE_COMPLETED ConnectorScheduleState = "completed"
+)
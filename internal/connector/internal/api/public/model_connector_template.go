@@ -0,0 +1,34 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+import (
+	"time"
+)
+
+// ConnectorTemplate struct for ConnectorTemplate
+type ConnectorTemplate struct {
+	Id              string    `json:"id,omitempty"`
+	Kind            string    `json:"kind,omitempty"`
+	Href            string    `json:"href,omitempty"`
+	Owner           string    `json:"owner,omitempty"`
+	CreatedAt       time.Time `json:"created_at,omitempty"`
+	ModifiedAt      time.Time `json:"modified_at,omitempty"`
+	Name            string    `json:"name"`
+	Description     string    `json:"description,omitempty"`
+	ConnectorTypeId string    `json:"connector_type_id"`
+	Channel         Channel   `json:"channel,omitempty"`
+	// the partial connector spec, string values can reference parameters as ${name}
+	Connector  map[string]interface{}       `json:"connector,omitempty"`
+	Parameters []ConnectorTemplateParameter `json:"parameters,omitempty"`
+	// the version of the template, incremented on every update
+	Version int64 `json:"version"`
+}
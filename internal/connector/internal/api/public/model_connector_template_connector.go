@@ -0,0 +1,23 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// ConnectorTemplateConnector A connector created from a connector template
+type ConnectorTemplateConnector struct {
+	ConnectorId string `json:"connector_id"`
+	// the version of the template the connector was created from
+	TemplateVersion int64 `json:"template_version"`
+	// true if the template was updated after the connector was created, and the connector should be re-rendered
+	Outdated bool `json:"outdated"`
+	// the template parameter values the connector was created with, only returned for the connectors of the user.
+	// The values of parameters bound to secret fields are never returned.
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
@@ -0,0 +1,19 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// ConnectorTemplateConnectorList struct for ConnectorTemplateConnectorList
+type ConnectorTemplateConnectorList struct {
+	Kind string `json:"kind,omitempty"`
+	// The id of the connector template
+	Id    string                       `json:"id,omitempty"`
+	Items []ConnectorTemplateConnector `json:"items"`
+}
@@ -0,0 +1,22 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// ConnectorTemplateParameter A parameter of a connector template, referenced as ${name} in the template connector spec
+type ConnectorTemplateParameter struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// the type of the parameter value, one of string, integer, number and boolean
+	Type     string `json:"type"`
+	Required bool   `json:"required,omitempty"`
+	// the value of the parameter when a connector doesn't set it
+	Default interface{} `json:"default,omitempty"`
+}
@@ -0,0 +1,22 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// ConnectorTemplateRequest struct for ConnectorTemplateRequest
+type ConnectorTemplateRequest struct {
+	Name            string  `json:"name"`
+	Description     string  `json:"description,omitempty"`
+	ConnectorTypeId string  `json:"connector_type_id"`
+	Channel         Channel `json:"channel,omitempty"`
+	// the partial connector spec, string values can reference parameters as ${name}
+	Connector  map[string]interface{}       `json:"connector,omitempty"`
+	Parameters []ConnectorTemplateParameter `json:"parameters,omitempty"`
+}
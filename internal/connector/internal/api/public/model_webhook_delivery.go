@@ -0,0 +1,38 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+import (
+	"time"
+)
+
+// WebhookDelivery A delivery of an event to a webhook subscription
+type WebhookDelivery struct {
+	Id             string `json:"id,omitempty"`
+	SubscriptionId string `json:"subscription_id,omitempty"`
+	// The id of the event, the same for all the deliveries of an event
+	EventId    string `json:"event_id,omitempty"`
+	EventType  string `json:"event_type,omitempty"`
+	ResourceId string `json:"resource_id,omitempty"`
+	// The posted event
+	Payload        map[string]interface{} `json:"payload,omitempty"`
+	Status         string                 `json:"status,omitempty"`
+	Attempts       int32                  `json:"attempts,omitempty"`
+	NextAttemptAt  time.Time              `json:"next_attempt_at,omitempty"`
+	LastAttemptAt  time.Time              `json:"last_attempt_at,omitempty"`
+	LastStatusCode int32                  `json:"last_status_code,omitempty"`
+	LastError      string                 `json:"last_error,omitempty"`
+	DeliveredAt    time.Time              `json:"delivered_at,omitempty"`
+	// The id of the delivery this delivery redelivers
+	RedeliveryOf string    `json:"redelivery_of,omitempty"`
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	UpdatedAt    time.Time `json:"updated_at,omitempty"`
+}
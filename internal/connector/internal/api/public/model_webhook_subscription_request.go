@@ -0,0 +1,20 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// WebhookSubscriptionRequest struct for WebhookSubscriptionRequest
+type WebhookSubscriptionRequest struct {
+	// An https URL the events are posted to
+	Url        string   `json:"url,omitempty"`
+	EventTypes []string `json:"event_types,omitempty"`
+	// The secret of the signature of the deliveries, generated when it isn't set on creation
+	Secret string `json:"secret,omitempty"`
+}
@@ -14,6 +14,7 @@ func NewVaultCommand(env *environments.Env) *cobra.Command {
 
 	// add sub-commands
 	cmd.AddCommand(NewListCommand(env))
+	cmd.AddCommand(NewGCCommand(env))
 
 	return cmd
 }
@@ -0,0 +1,75 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/golang/glog"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+func NewGCCommand(env *environments.Env) *cobra.Command {
+	var dryRun bool
+	var gracePeriod time.Duration
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Delete orphaned connector secrets",
+		Long: "Delete the vault secrets of connectors that don't exist anymore, " +
+			"or that aren't referenced by their connector anymore, once they have been orphaned for longer than the grace period",
+
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			err := env.CreateServices()
+			if err != nil {
+				glog.Fatalf("Unable to initialize environment: %s", err.Error())
+			}
+		},
+
+		Run: func(cmd *cobra.Command, args []string) {
+			env.MustInvoke(func(gcService services.ConnectorSecretsGCService, connectorsConfig *config.ConnectorsConfig) {
+				if !cmd.Flags().Changed("grace-period") {
+					gracePeriod = connectorsConfig.ConnectorSecretsGCGracePeriod
+				}
+				runGC(gcService, dryRun, gracePeriod)
+			})
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", true, "Only list the orphaned secrets, use --dry-run=false to delete them")
+	cmd.Flags().DurationVar(&gracePeriod, "grace-period", 0, "Time a secret must have been orphaned for before it's deleted, defaults to --connector-secrets-gc-grace-period")
+	return cmd
+}
+
+func runGC(gcService services.ConnectorSecretsGCService, dryRun bool, gracePeriod time.Duration) {
+	result, err := gcService.CollectOrphanedSecrets(context.Background(), services.SecretsGCOptions{
+		DryRun:      dryRun,
+		GracePeriod: gracePeriod,
+		Now:         time.Now(),
+	})
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Secret Key", "Owning Resource", "Reason", "Detected At", "Action"})
+	for _, orphan := range result.Orphaned {
+		action := "keep until grace period expires"
+		switch {
+		case orphan.Deleted:
+			action = "deleted"
+		case orphan.Expired && dryRun:
+			action = "delete (dry run)"
+		case orphan.Expired:
+			action = "delete failed"
+		}
+		table.Append([]string{orphan.Name, orphan.OwningResource, orphan.Reason, orphan.DetectedAt.Format(time.RFC3339), action})
+	}
+	table.Render()
+	fmt.Printf("scanned %d secrets, found %d orphaned secrets, deleted %d, failed to delete %d\n",
+		result.Scanned, len(result.Orphaned), result.Deleted, result.Failed)
+}
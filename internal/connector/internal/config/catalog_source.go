@@ -0,0 +1,256 @@
+package config
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+)
+
+const (
+	CatalogSourceKindDir  = "dir"
+	CatalogSourceKindHTTP = "http"
+	CatalogSourceKindOCI  = "oci"
+
+	// catalogSourceTimeout is the timeout of the requests made to remote catalog sources
+	catalogSourceTimeout = 30 * time.Second
+)
+
+// ConnectorCatalogSource is a source of connector catalog entries, such as a local directory,
+// an HTTP(S) index file or an OCI artifact
+type ConnectorCatalogSource interface {
+	// Name identifies the source, it's the directory or the URL of the source
+	Name() string
+	Kind() string
+	// Fetch reads the catalog entries of the source and verifies their checksum and signature if required
+	Fetch(ctx context.Context) (*ConnectorCatalogSnapshot, error)
+}
+
+// ConnectorCatalogIndex is the content of an HTTP(S) catalog index file or of an OCI catalog artifact
+type ConnectorCatalogIndex struct {
+	ConnectorTypes []ConnectorCatalogEntry `json:"connector_types"`
+	// ConnectorMetadata overrides the connector metadata read from the local metadata directories
+	ConnectorMetadata []ConnectorMetadata `json:"connector_metadata,omitempty"`
+}
+
+// ConnectorCatalogSnapshot is the content of a catalog source when it was fetched
+type ConnectorCatalogSnapshot struct {
+	// Digest is the sha256 digest of the content of the source
+	Digest   string
+	Entries  []ConnectorCatalogEntry
+	Metadata []ConnectorMetadata
+	// Verified is true if the signature of the content has been verified
+	Verified bool
+}
+
+// ConnectorCatalogSourceStatus is the result of the last fetch of a catalog source
+type ConnectorCatalogSourceStatus struct {
+	Name     string
+	Kind     string
+	Digest   string
+	Entries  int
+	Verified bool
+	Error    error
+}
+
+// ConnectorCatalog is the connector catalog loaded from all the catalog sources
+type ConnectorCatalog struct {
+	Entries   []ConnectorCatalogEntry
+	Checksums map[string]string
+	Sources   []ConnectorCatalogSourceStatus
+}
+
+// NewConnectorCatalogSource creates a catalog source from a directory or a URL.
+// Supported URLs are file:// directories, http(s):// index files, optionally pinned with a #sha256=<digest> fragment,
+// and oci:// artifacts referenced by tag or digest, oci+http:// is accepted for registries that don't use TLS.
+// If publicKey isn't nil, the content of remote sources must be signed with the matching private key.
+func NewConnectorCatalogSource(source string, publicKey crypto.PublicKey) (ConnectorCatalogSource, error) {
+	u, err := url.Parse(source)
+	if err != nil || u.Scheme == "" {
+		return &dirCatalogSource{dir: shared.BuildFullFilePath(source)}, nil
+	}
+
+	client := &http.Client{Timeout: catalogSourceTimeout}
+	switch u.Scheme {
+	case "file":
+		return &dirCatalogSource{dir: u.Path}, nil
+	case "http", "https":
+		var sum string
+		if u.Fragment != "" {
+			if !strings.HasPrefix(u.Fragment, "sha256=") {
+				return nil, fmt.Errorf("unsupported checksum %q in connector catalog source %s", u.Fragment, source)
+			}
+			sum = strings.TrimPrefix(u.Fragment, "sha256=")
+			u.Fragment = ""
+		}
+		return &httpCatalogSource{url: u.String(), sha256: sum, publicKey: publicKey, client: client}, nil
+	case "oci", "oci+http":
+		ref, err := parseOCIReference(u)
+		if err != nil {
+			return nil, fmt.Errorf("invalid connector catalog source %s: %s", source, err)
+		}
+		return &ociCatalogSource{name: source, ref: ref, publicKey: publicKey, client: client}, nil
+	default:
+		return nil, fmt.Errorf("unsupported connector catalog source %s", source)
+	}
+}
+
+// LoadCatalog fetches the catalog entries from all the catalog sources, merges them with the connector metadata
+// and computes their checksums. The returned catalog always holds the status of the sources, even on error.
+func (c *ConnectorsConfig) LoadCatalog(ctx context.Context) (*ConnectorCatalog, error) {
+	catalog := &ConnectorCatalog{
+		Checksums: make(map[string]string),
+	}
+
+	// read metadata first to merge with catalog next
+	connectorMetadata, err := c.readConnectorMetadata()
+	if err != nil {
+		return catalog, err
+	}
+	unusedMetadata := make(map[string]bool, len(connectorMetadata))
+	for id := range connectorMetadata {
+		unusedMetadata[id] = true
+	}
+
+	sources, err := c.catalogSources()
+	if err != nil {
+		return catalog, err
+	}
+
+	// fetch all sources, so that the status of all of them is known
+	snapshots := make([]*ConnectorCatalogSnapshot, len(sources))
+	var fetchErr error
+	for i, source := range sources {
+		status := ConnectorCatalogSourceStatus{
+			Name: source.Name(),
+			Kind: source.Kind(),
+		}
+		snapshot, err := source.Fetch(ctx)
+		if err != nil {
+			status.Error = err
+			if fetchErr == nil {
+				fetchErr = fmt.Errorf("error listing connector catalogs in %s: %s", source.Name(), err)
+			}
+		} else {
+			status.Digest = snapshot.Digest
+			status.Entries = len(snapshot.Entries)
+			status.Verified = snapshot.Verified
+			snapshots[i] = snapshot
+		}
+		catalog.Sources = append(catalog.Sources, status)
+	}
+	if fetchErr != nil {
+		return catalog, fetchErr
+	}
+
+	typesLoaded := map[string]string{}
+	for i, snapshot := range snapshots {
+		name := sources[i].Name()
+
+		// metadata in a source overrides the local metadata for the connectors of that source
+		sourceMetadata := make(map[string]ConnectorMetadata, len(snapshot.Metadata))
+		for _, m := range snapshot.Metadata {
+			sourceMetadata[m.ConnectorTypeId] = m
+		}
+
+		for _, entry := range snapshot.Entries {
+			// set catalog metadata from metadata config read earlier
+			id := entry.ConnectorType.Id
+			meta, found := sourceMetadata[id]
+			if !found {
+				meta, found = connectorMetadata[id]
+			}
+			if !found {
+				return catalog, fmt.Errorf("error listing connector catalogs in %s: missing metadata for connector %s", name, id)
+			}
+			delete(unusedMetadata, id)
+			entry.ConnectorType.FeaturedRank = meta.FeaturedRank
+			entry.ConnectorType.Labels = meta.Labels
+			entry.ConnectorType.Annotations = meta.Annotations
+
+			// compute checksum for catalog entry to look for updates
+			sum, err := checksum(entry)
+			if err != nil {
+				return catalog, fmt.Errorf("error computing checksum for connector %s in %s: %s", id, name, err)
+			}
+
+			// the same connector type may be shipped by more than one source as long as it's identical
+			if prev, found := typesLoaded[id]; found {
+				if catalog.Checksums[id] == sum {
+					continue
+				}
+				return catalog, fmt.Errorf("connector type '%s' defined in '%s' and '%s'", id, name, prev)
+			}
+
+			catalog.Checksums[id] = sum
+			typesLoaded[id] = name
+			catalog.Entries = append(catalog.Entries, entry)
+		}
+	}
+
+	// check if there are any unused metadata entries left
+	if len(unusedMetadata) > 0 {
+		ids := make([]string, 0, len(unusedMetadata))
+		for id := range unusedMetadata {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		return catalog, fmt.Errorf("found %d unrecognized connector metadata with ids: %s", len(ids), ids)
+	}
+
+	sort.Slice(catalog.Entries, func(i, j int) bool {
+		return catalog.Entries[i].ConnectorType.Id < catalog.Entries[j].ConnectorType.Id
+	})
+
+	return catalog, nil
+}
+
+// catalogSources returns the local catalog directories followed by the configured catalog sources
+func (c *ConnectorsConfig) catalogSources() ([]ConnectorCatalogSource, error) {
+	var publicKey crypto.PublicKey
+	if c.ConnectorCatalogPublicKeyFile != "" {
+		var err error
+		if publicKey, err = readPublicKey(shared.BuildFullFilePath(c.ConnectorCatalogPublicKeyFile)); err != nil {
+			return nil, err
+		}
+	}
+
+	sources := make([]ConnectorCatalogSource, 0, len(c.ConnectorCatalogDirs)+len(c.ConnectorCatalogSources))
+	for _, dir := range c.ConnectorCatalogDirs {
+		sources = append(sources, &dirCatalogSource{dir: shared.BuildFullFilePath(dir)})
+	}
+	for _, s := range c.ConnectorCatalogSources {
+		source, err := NewConnectorCatalogSource(s, publicKey)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
+
+// parseCatalogIndex unmarshals a catalog index file
+func parseCatalogIndex(buf []byte) (*ConnectorCatalogIndex, error) {
+	var index ConnectorCatalogIndex
+	if err := json.Unmarshal(buf, &index); err != nil {
+		return nil, fmt.Errorf("error unmarshaling catalog index: %s", err)
+	}
+	for _, entry := range index.ConnectorTypes {
+		if entry.ConnectorType.Id == "" {
+			return nil, fmt.Errorf("catalog index contains a connector type without id")
+		}
+	}
+	return &index, nil
+}
+
+func sha256Digest(buf []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(buf))
+}
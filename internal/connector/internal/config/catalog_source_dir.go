@@ -0,0 +1,126 @@
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/files"
+	"github.com/golang/glog"
+)
+
+var _ ConnectorCatalogSource = &dirCatalogSource{}
+
+// dirCatalogSource reads catalog entries from the json files in a local directory, one entry per file
+type dirCatalogSource struct {
+	dir string
+}
+
+func (s *dirCatalogSource) Name() string {
+	return s.dir
+}
+
+func (s *dirCatalogSource) Kind() string {
+	return CatalogSourceKindDir
+}
+
+func (s *dirCatalogSource) Fetch(_ context.Context) (*ConnectorCatalogSnapshot, error) {
+	snapshot := &ConnectorCatalogSnapshot{}
+	typesLoaded := map[string]string{}
+	checksums := map[string]string{}
+
+	err := files.Walk(s.dir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// skip over hidden files and dirs..
+		if info.IsDir() || strings.HasPrefix(path, ".") {
+			return nil
+		}
+
+		glog.Infof("loading connectors from file %s", path)
+
+		// Read the file
+		buf, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading catalog file %s: %s", path, err)
+		}
+
+		entry := ConnectorCatalogEntry{}
+		err = json.Unmarshal(buf, &entry)
+		if err != nil {
+			return fmt.Errorf("error unmarshaling catalog file %s: %s", path, err)
+		}
+
+		// compute checksum for catalog entry to look for duplicates
+		id := entry.ConnectorType.Id
+		sum, err := checksum(entry)
+		if err != nil {
+			return fmt.Errorf("error computing checksum for catalog file %s: %s", path, err)
+		}
+
+		// when walking directories with symlink such as what kubernetes does when mounting
+		// a volume from a configmap where the actual files are double-symlinked from some
+		// random named path so this method is invoked twice or more, but it is not actually
+		// always possible to reliably determine if files have been already processed, so we
+		// first check if:
+		//
+		// - the previous file and the new file are the same (os.SameFile)
+		// - the file has already been processed
+		// - the previous file checksum is the same
+		//
+		// if any of the above condition is true, we assume the previous file and the current
+		// one are actually the same, so we can safely ignore it
+
+		if prev, found := typesLoaded[id]; found {
+			prevInfo, prevErr := os.Lstat(prev)
+			if prevErr != nil {
+				return nil
+			}
+
+			if os.SameFile(info, prevInfo) {
+				return nil
+			}
+			if typesLoaded[id] == path {
+				return nil
+			}
+			if checksums[id] == sum {
+				return nil
+			}
+
+			return fmt.Errorf("connector type '%s' defined in '%s' and '%s'", id, path, prev)
+		}
+
+		checksums[id] = sum
+		typesLoaded[id] = path
+
+		snapshot.Entries = append(snapshot.Entries, entry)
+
+		glog.Infof("loaded connector %s from file %s", id, path)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the digest of a directory is computed from the checksums of its entries, so that it doesn't depend on the walk order
+	ids := make([]string, 0, len(checksums))
+	for id := range checksums {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	h := sha256.New()
+	for _, id := range ids {
+		_, _ = fmt.Fprintf(h, "%s=%s\n", id, checksums[id])
+	}
+	snapshot.Digest = fmt.Sprintf("%x", h.Sum(nil))
+
+	return snapshot, nil
+}
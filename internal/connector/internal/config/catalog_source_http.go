@@ -0,0 +1,97 @@
+package config
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+)
+
+// maxCatalogIndexSize limits the size of the catalog index files and artifacts read from remote sources
+const maxCatalogIndexSize = 64 << 20
+
+var _ ConnectorCatalogSource = &httpCatalogSource{}
+
+// httpCatalogSource reads catalog entries from a json index file served over HTTP(S).
+// The index can be pinned with its sha256 digest, and its signature is read from the same URL with a .sig suffix.
+type httpCatalogSource struct {
+	url       string
+	sha256    string
+	publicKey crypto.PublicKey
+	client    *http.Client
+}
+
+func (s *httpCatalogSource) Name() string {
+	return s.url
+}
+
+func (s *httpCatalogSource) Kind() string {
+	return CatalogSourceKindHTTP
+}
+
+func (s *httpCatalogSource) Fetch(ctx context.Context) (*ConnectorCatalogSnapshot, error) {
+	buf, err := s.get(ctx, s.url)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := sha256Digest(buf)
+	if s.sha256 != "" && s.sha256 != digest {
+		return nil, fmt.Errorf("catalog index checksum mismatch, expected sha256 %s but got %s", s.sha256, digest)
+	}
+
+	verified := false
+	if s.publicKey != nil {
+		signature, err := s.get(ctx, s.url+".sig")
+		if err != nil {
+			return nil, fmt.Errorf("error reading catalog index signature: %s", err)
+		}
+		if err := verifySignature(s.publicKey, buf, string(signature)); err != nil {
+			return nil, fmt.Errorf("error verifying catalog index signature: %s", err)
+		}
+		verified = true
+	}
+
+	index, err := parseCatalogIndex(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConnectorCatalogSnapshot{
+		Digest:   digest,
+		Entries:  index.ConnectorTypes,
+		Metadata: index.ConnectorMetadata,
+		Verified: verified,
+	}, nil
+}
+
+func (s *httpCatalogSource) get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer shared.CloseQuietly(resp.Body)()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d reading %s", resp.StatusCode, url)
+	}
+	return readLimited(resp.Body)
+}
+
+func readLimited(r io.Reader) ([]byte, error) {
+	buf, err := io.ReadAll(io.LimitReader(r, maxCatalogIndexSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) > maxCatalogIndexSize {
+		return nil, fmt.Errorf("catalog content exceeds %d bytes", maxCatalogIndexSize)
+	}
+	return buf, nil
+}
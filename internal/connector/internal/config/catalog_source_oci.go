@@ -0,0 +1,290 @@
+package config
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+)
+
+const (
+	// ConnectorCatalogIndexMediaType is the media type of the OCI artifact layer holding a catalog index
+	ConnectorCatalogIndexMediaType = "application/vnd.connector-catalog.index.v1+json"
+
+	ociManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+	// cosignSignatureAnnotation is the layer annotation holding a cosign signature of the layer's payload
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+)
+
+var _ ConnectorCatalogSource = &ociCatalogSource{}
+
+// ociCatalogSource reads catalog entries from an OCI artifact whose index layer has the ConnectorCatalogIndexMediaType.
+// Referencing the artifact by digest pins its content, and signatures are read from the cosign signature tag of the artifact.
+type ociCatalogSource struct {
+	name      string
+	ref       ociReference
+	publicKey crypto.PublicKey
+	client    *http.Client
+	// token is the bearer token issued by the registry for anonymous pulls
+	token string
+}
+
+type ociReference struct {
+	baseURL    string
+	repository string
+	// reference is a tag or a digest
+	reference string
+}
+
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type ociManifest struct {
+	MediaType string          `json:"mediaType"`
+	Layers    []ociDescriptor `json:"layers"`
+}
+
+// cosignPayload is the simple signing payload signed by cosign for a container image or an artifact
+type cosignPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+func parseOCIReference(u *url.URL) (ociReference, error) {
+	scheme := "https"
+	if u.Scheme == "oci+http" {
+		scheme = "http"
+	}
+	ref := ociReference{
+		baseURL: fmt.Sprintf("%s://%s", scheme, u.Host),
+	}
+
+	path := strings.TrimPrefix(u.Path, "/")
+	if i := strings.Index(path, "@"); i >= 0 {
+		ref.repository, ref.reference = path[:i], path[i+1:]
+		if !strings.HasPrefix(ref.reference, "sha256:") {
+			return ref, fmt.Errorf("unsupported digest %s", ref.reference)
+		}
+	} else if i := strings.LastIndex(path, ":"); i >= 0 && !strings.Contains(path[i:], "/") {
+		ref.repository, ref.reference = path[:i], path[i+1:]
+	} else {
+		ref.repository, ref.reference = path, "latest"
+	}
+	if u.Host == "" || ref.repository == "" || ref.reference == "" {
+		return ref, fmt.Errorf("missing registry or repository")
+	}
+	return ref, nil
+}
+
+func (s *ociCatalogSource) Name() string {
+	return s.name
+}
+
+func (s *ociCatalogSource) Kind() string {
+	return CatalogSourceKindOCI
+}
+
+func (s *ociCatalogSource) Fetch(ctx context.Context) (*ConnectorCatalogSnapshot, error) {
+	manifest, digest, err := s.getManifest(ctx, s.ref.reference)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(s.ref.reference, "sha256:") && s.ref.reference != digest {
+		return nil, fmt.Errorf("catalog artifact checksum mismatch, expected %s but got %s", s.ref.reference, digest)
+	}
+
+	var layer *ociDescriptor
+	for i := range manifest.Layers {
+		if manifest.Layers[i].MediaType == ConnectorCatalogIndexMediaType {
+			layer = &manifest.Layers[i]
+			break
+		}
+	}
+	if layer == nil {
+		return nil, fmt.Errorf("catalog artifact %s has no %s layer", digest, ConnectorCatalogIndexMediaType)
+	}
+	buf, err := s.getBlob(ctx, layer.Digest)
+	if err != nil {
+		return nil, err
+	}
+
+	verified := false
+	if s.publicKey != nil {
+		if err := s.verify(ctx, digest); err != nil {
+			return nil, fmt.Errorf("error verifying catalog artifact signature: %s", err)
+		}
+		verified = true
+	}
+
+	index, err := parseCatalogIndex(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConnectorCatalogSnapshot{
+		Digest:   strings.TrimPrefix(digest, "sha256:"),
+		Entries:  index.ConnectorTypes,
+		Metadata: index.ConnectorMetadata,
+		Verified: verified,
+	}, nil
+}
+
+// verify looks for a cosign signature of the artifact with the given manifest digest signed with the public key
+func (s *ociCatalogSource) verify(ctx context.Context, digest string) error {
+	manifest, _, err := s.getManifest(ctx, strings.Replace(digest, ":", "-", 1)+".sig")
+	if err != nil {
+		return err
+	}
+	for _, layer := range manifest.Layers {
+		signature, ok := layer.Annotations[cosignSignatureAnnotation]
+		if !ok {
+			continue
+		}
+		payload, err := s.getBlob(ctx, layer.Digest)
+		if err != nil {
+			return err
+		}
+		if err := verifySignature(s.publicKey, payload, signature); err != nil {
+			continue
+		}
+		var p cosignPayload
+		if err := json.Unmarshal(payload, &p); err != nil {
+			continue
+		}
+		if p.Critical.Image.DockerManifestDigest == digest {
+			return nil
+		}
+	}
+	return fmt.Errorf("no valid signature found for %s", digest)
+}
+
+// getManifest returns a manifest of the repository along with its digest
+func (s *ociCatalogSource) getManifest(ctx context.Context, reference string) (*ociManifest, string, error) {
+	buf, err := s.get(ctx, fmt.Sprintf("/v2/%s/manifests/%s", s.ref.repository, reference), ociManifestMediaType)
+	if err != nil {
+		return nil, "", err
+	}
+	var manifest ociManifest
+	if err := json.Unmarshal(buf, &manifest); err != nil {
+		return nil, "", fmt.Errorf("error unmarshaling manifest %s: %s", reference, err)
+	}
+	return &manifest, "sha256:" + sha256Digest(buf), nil
+}
+
+// getBlob returns a blob of the repository after checking its digest
+func (s *ociCatalogSource) getBlob(ctx context.Context, digest string) ([]byte, error) {
+	if !strings.HasPrefix(digest, "sha256:") {
+		return nil, fmt.Errorf("unsupported digest %s", digest)
+	}
+	buf, err := s.get(ctx, fmt.Sprintf("/v2/%s/blobs/%s", s.ref.repository, digest), "")
+	if err != nil {
+		return nil, err
+	}
+	if actual := "sha256:" + sha256Digest(buf); actual != digest {
+		return nil, fmt.Errorf("blob checksum mismatch, expected %s but got %s", digest, actual)
+	}
+	return buf, nil
+}
+
+func (s *ociCatalogSource) get(ctx context.Context, path string, accept string) ([]byte, error) {
+	resp, err := s.do(ctx, path, accept)
+	if err != nil {
+		return nil, err
+	}
+	// registries use bearer tokens even for anonymous pulls
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		shared.CloseQuietly(resp.Body)()
+		if err := s.authenticate(ctx, challenge); err != nil {
+			return nil, err
+		}
+		if resp, err = s.do(ctx, path, accept); err != nil {
+			return nil, err
+		}
+	}
+	defer shared.CloseQuietly(resp.Body)()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d reading %s", resp.StatusCode, path)
+	}
+	return readLimited(resp.Body)
+}
+
+func (s *ociCatalogSource) do(ctx context.Context, path string, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.ref.baseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	return s.client.Do(req)
+}
+
+// authenticate requests an anonymous pull token from the realm of a bearer challenge
+func (s *ociCatalogSource) authenticate(ctx context.Context, challenge string) error {
+	if !strings.HasPrefix(challenge, "Bearer ") {
+		return fmt.Errorf("unsupported registry authentication challenge %q", challenge)
+	}
+	params := map[string]string{}
+	for _, param := range strings.Split(strings.TrimPrefix(challenge, "Bearer "), ",") {
+		if k, v, ok := strings.Cut(strings.TrimSpace(param), "="); ok {
+			params[k] = strings.Trim(v, `"`)
+		}
+	}
+	realm, err := url.Parse(params["realm"])
+	if err != nil || params["realm"] == "" {
+		return fmt.Errorf("invalid registry authentication realm %q", params["realm"])
+	}
+	query := realm.Query()
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull", s.ref.repository)
+	}
+	query.Set("scope", scope)
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer shared.CloseQuietly(resp.Body)()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d requesting registry token", resp.StatusCode)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return fmt.Errorf("error decoding registry token: %s", err)
+	}
+	s.token = token.Token
+	if s.token == "" {
+		s.token = token.AccessToken
+	}
+	return nil
+}
@@ -0,0 +1,391 @@
+package config
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/public"
+	"github.com/onsi/gomega"
+)
+
+func testCatalogIndex(ids ...string) []byte {
+	index := ConnectorCatalogIndex{}
+	for _, id := range ids {
+		index.ConnectorTypes = append(index.ConnectorTypes, ConnectorCatalogEntry{
+			ConnectorType: public.ConnectorType{Id: id, Name: id},
+			Channels: map[string]ConnectorChannelConfig{
+				"stable": {ShardMetadata: map[string]interface{}{"connector_revision": 1}},
+			},
+		})
+		index.ConnectorMetadata = append(index.ConnectorMetadata, ConnectorMetadata{ConnectorTypeId: id, Labels: []string{"remote"}})
+	}
+	buf, _ := json.Marshal(index)
+	return buf
+}
+
+func signPayload(key *ecdsa.PrivateKey, payload []byte) string {
+	digest := sha256.Sum256(payload)
+	sig, _ := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	return base64.StdEncoding.EncodeToString(sig)
+}
+
+func Test_httpCatalogSource_Fetch(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	otherKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	index := testCatalogIndex("remote_sink_0.1")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/index.json":
+			_, _ = w.Write(index)
+		case "/index.json.sig":
+			_, _ = w.Write([]byte(signPayload(key, index)))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		source       string
+		publicKey    crypto.PublicKey
+		wantErr      string
+		wantVerified bool
+	}{
+		{
+			name:   "unsigned index",
+			source: server.URL + "/index.json",
+		},
+		{
+			name:   "index pinned by checksum",
+			source: server.URL + "/index.json#sha256=" + sha256Digest(index),
+		},
+		{
+			name:    "index with wrong checksum",
+			source:  server.URL + "/index.json#sha256=" + sha256Digest([]byte("other")),
+			wantErr: "catalog index checksum mismatch",
+		},
+		{
+			name:         "signed index",
+			source:       server.URL + "/index.json",
+			publicKey:    &key.PublicKey,
+			wantVerified: true,
+		},
+		{
+			name:      "index signed with another key",
+			source:    server.URL + "/index.json",
+			publicKey: &otherKey.PublicKey,
+			wantErr:   "invalid signature",
+		},
+		{
+			name:    "missing index",
+			source:  server.URL + "/missing.json",
+			wantErr: "unexpected status 404",
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			source, err := NewConnectorCatalogSource(tt.source, tt.publicKey)
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(source.Kind()).To(gomega.Equal(CatalogSourceKindHTTP))
+
+			snapshot, err := source.Fetch(context.Background())
+			if tt.wantErr != "" {
+				g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(snapshot.Digest).To(gomega.Equal(sha256Digest(index)))
+			g.Expect(snapshot.Verified).To(gomega.Equal(tt.wantVerified))
+			g.Expect(snapshot.Entries).To(gomega.HaveLen(1))
+			g.Expect(snapshot.Metadata).To(gomega.HaveLen(1))
+		})
+	}
+}
+
+// fakeRegistry serves an OCI catalog artifact and its cosign signature, requiring an anonymous bearer token
+type fakeRegistry struct {
+	manifests map[string][]byte
+	blobs     map[string][]byte
+}
+
+func (r *fakeRegistry) addBlob(buf []byte) string {
+	digest := "sha256:" + sha256Digest(buf)
+	r.blobs[digest] = buf
+	return digest
+}
+
+func (r *fakeRegistry) addManifest(reference string, layers ...ociDescriptor) string {
+	buf, _ := json.Marshal(ociManifest{MediaType: ociManifestMediaType, Layers: layers})
+	digest := "sha256:" + sha256Digest(buf)
+	r.manifests[reference] = buf
+	r.manifests[digest] = buf
+	return digest
+}
+
+func (r *fakeRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/token" {
+		_, _ = w.Write([]byte(`{"token":"anonymous"}`))
+		return
+	}
+	if req.Header.Get("Authorization") != "Bearer anonymous" {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="http://%s/token",service="registry"`, req.Host))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if ref := strings.TrimPrefix(req.URL.Path, "/v2/connectors/catalog/manifests/"); ref != req.URL.Path {
+		if buf, ok := r.manifests[ref]; ok {
+			_, _ = w.Write(buf)
+			return
+		}
+	}
+	if digest := strings.TrimPrefix(req.URL.Path, "/v2/connectors/catalog/blobs/"); digest != req.URL.Path {
+		if buf, ok := r.blobs[digest]; ok {
+			_, _ = w.Write(buf)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNotFound)
+}
+
+func Test_ociCatalogSource_Fetch(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	otherKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	registry := &fakeRegistry{manifests: map[string][]byte{}, blobs: map[string][]byte{}}
+	index := testCatalogIndex("remote_sink_0.1", "remote_source_0.1")
+	indexDigest := registry.addBlob(index)
+	digest := registry.addManifest("v1", ociDescriptor{MediaType: ConnectorCatalogIndexMediaType, Digest: indexDigest, Size: int64(len(index))})
+
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"connectors/catalog"},"image":{"docker-manifest-digest":"%s"},"type":"cosign container image signature"}}`, digest))
+	payloadDigest := registry.addBlob(payload)
+	registry.addManifest(strings.Replace(digest, ":", "-", 1)+".sig", ociDescriptor{
+		MediaType:   "application/vnd.dev.cosign.simplesigning.v1+json",
+		Digest:      payloadDigest,
+		Size:        int64(len(payload)),
+		Annotations: map[string]string{cosignSignatureAnnotation: signPayload(key, payload)},
+	})
+	registry.addManifest("no-index", ociDescriptor{MediaType: "application/octet-stream", Digest: indexDigest, Size: int64(len(index))})
+
+	server := httptest.NewServer(registry)
+	defer server.Close()
+	base := "oci+http://" + strings.TrimPrefix(server.URL, "http://") + "/connectors/catalog"
+
+	tests := []struct {
+		name         string
+		source       string
+		publicKey    crypto.PublicKey
+		wantErr      string
+		wantVerified bool
+	}{
+		{
+			name:   "artifact referenced by tag",
+			source: base + ":v1",
+		},
+		{
+			name:   "artifact referenced by digest",
+			source: base + "@" + digest,
+		},
+		{
+			name:    "artifact with wrong digest",
+			source:  base + "@sha256:" + sha256Digest([]byte("other")),
+			wantErr: "unexpected status 404",
+		},
+		{
+			name:    "artifact without index layer",
+			source:  base + ":no-index",
+			wantErr: "has no " + ConnectorCatalogIndexMediaType + " layer",
+		},
+		{
+			name:         "signed artifact",
+			source:       base + ":v1",
+			publicKey:    &key.PublicKey,
+			wantVerified: true,
+		},
+		{
+			name:      "artifact signed with another key",
+			source:    base + ":v1",
+			publicKey: &otherKey.PublicKey,
+			wantErr:   "no valid signature found",
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			source, err := NewConnectorCatalogSource(tt.source, tt.publicKey)
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(source.Kind()).To(gomega.Equal(CatalogSourceKindOCI))
+
+			snapshot, err := source.Fetch(context.Background())
+			if tt.wantErr != "" {
+				g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect("sha256:" + snapshot.Digest).To(gomega.Equal(digest))
+			g.Expect(snapshot.Verified).To(gomega.Equal(tt.wantVerified))
+			g.Expect(snapshot.Entries).To(gomega.HaveLen(2))
+		})
+	}
+}
+
+func Test_parseOCIReference(t *testing.T) {
+	tests := []struct {
+		source  string
+		want    ociReference
+		wantErr bool
+	}{
+		{
+			source: "oci://quay.io/org/catalog:1.0",
+			want:   ociReference{baseURL: "https://quay.io", repository: "org/catalog", reference: "1.0"},
+		},
+		{
+			source: "oci+http://localhost:5000/catalog",
+			want:   ociReference{baseURL: "http://localhost:5000", repository: "catalog", reference: "latest"},
+		},
+		{
+			source: "oci://quay.io/org/catalog@sha256:abc",
+			want:   ociReference{baseURL: "https://quay.io", repository: "org/catalog", reference: "sha256:abc"},
+		},
+		{
+			source:  "oci://quay.io/org/catalog@md5:abc",
+			wantErr: true,
+		},
+		{
+			source:  "oci://quay.io",
+			wantErr: true,
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.source, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			_, err := NewConnectorCatalogSource(tt.source, nil)
+			if tt.wantErr {
+				g.Expect(err).ToNot(gomega.BeNil())
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			source, _ := NewConnectorCatalogSource(tt.source, nil)
+			g.Expect(source.(*ociCatalogSource).ref).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func TestConnectorsConfig_LoadCatalog(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	keyBytes, _ := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	keyFile := path.Join(t.TempDir(), "catalog.pub")
+	g := gomega.NewWithT(t)
+	g.Expect(os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: keyBytes}), 0600)).To(gomega.Succeed())
+
+	remote := testCatalogIndex("remote_sink_0.1")
+	conflicting := testCatalogIndex("log_sink_0.1")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var buf []byte
+		switch strings.TrimSuffix(r.URL.Path, ".sig") {
+		case "/remote.json":
+			buf = remote
+		case "/conflicting.json":
+			buf = conflicting
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, ".sig") {
+			buf = []byte(signPayload(key, buf))
+		}
+		_, _ = w.Write(buf)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		sources       []string
+		publicKeyFile string
+		wantErr       string
+		wantIds       []string
+		wantStatuses  int
+	}{
+		{
+			name:         "local and remote sources",
+			sources:      []string{server.URL + "/remote.json"},
+			wantIds:      []string{"aws-sqs-source-v1alpha1", "log_sink_0.1", "remote_sink_0.1"},
+			wantStatuses: 2,
+		},
+		{
+			name:          "signed remote source",
+			sources:       []string{server.URL + "/remote.json"},
+			publicKeyFile: keyFile,
+			wantIds:       []string{"aws-sqs-source-v1alpha1", "log_sink_0.1", "remote_sink_0.1"},
+			wantStatuses:  2,
+		},
+		{
+			name:         "connector type defined in two sources",
+			sources:      []string{server.URL + "/conflicting.json"},
+			wantErr:      "connector type 'log_sink_0.1' defined in",
+			wantStatuses: 2,
+		},
+		{
+			name:         "unreachable source",
+			sources:      []string{server.URL + "/missing.json"},
+			wantErr:      "error listing connector catalogs in " + server.URL + "/missing.json",
+			wantStatuses: 2,
+		},
+		{
+			name:    "unsupported source",
+			sources: []string{"ftp://example.com/catalog"},
+			wantErr: "unsupported connector catalog source",
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			c := &ConnectorsConfig{
+				ConnectorCatalogDirs:          []string{"./internal/connector/test/integration/resources/connector-catalog"},
+				ConnectorMetadataDirs:         []string{"./internal/connector/test/integration/resources/connector-metadata"},
+				ConnectorCatalogSources:       tt.sources,
+				ConnectorCatalogPublicKeyFile: tt.publicKeyFile,
+			}
+
+			catalog, err := c.LoadCatalog(context.Background())
+			g.Expect(catalog.Sources).To(gomega.HaveLen(tt.wantStatuses))
+			if tt.wantErr != "" {
+				g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+
+			var ids []string
+			for _, entry := range catalog.Entries {
+				ids = append(ids, entry.ConnectorType.Id)
+				g.Expect(catalog.Checksums).To(gomega.HaveKey(entry.ConnectorType.Id))
+			}
+			g.Expect(ids).To(gomega.Equal(tt.wantIds))
+			g.Expect(catalog.Entries[2].ConnectorType.Labels).To(gomega.Equal([]string{"remote"}))
+			g.Expect(catalog.Sources[1].Verified).To(gomega.Equal(tt.publicKeyFile != ""))
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+	"github.com/spf13/pflag"
+)
+
+// ConnectorMetricsConfig is the configuration of the observatorium instance the connector metrics are read from
+type ConnectorMetricsConfig struct {
+	ObservatoriumURL string
+	AuthToken        string
+	AuthTokenFile    string
+	Timeout          time.Duration
+	Insecure         bool
+	EnableMock       bool
+}
+
+func NewConnectorMetricsConfig() *ConnectorMetricsConfig {
+	return &ConnectorMetricsConfig{
+		Timeout: 240 * time.Second,
+	}
+}
+
+func (c *ConnectorMetricsConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&c.ObservatoriumURL, "connector-metrics-observatorium-url", c.ObservatoriumURL, "Observatorium metrics API URL connector metrics are read from, connector metrics are disabled when empty")
+	fs.StringVar(&c.AuthTokenFile, "connector-metrics-auth-token-file", c.AuthTokenFile, "File containing the token used to authenticate to the observatorium metrics API")
+	fs.DurationVar(&c.Timeout, "connector-metrics-timeout", c.Timeout, "Timeout of connector metrics queries")
+	fs.BoolVar(&c.Insecure, "connector-metrics-insecure", c.Insecure, "Skip TLS verification of the observatorium metrics API")
+	fs.BoolVar(&c.EnableMock, "connector-metrics-enable-mock", c.EnableMock, "Use a mock observatorium client for connector metrics")
+}
+
+func (c *ConnectorMetricsConfig) ReadFiles() error {
+	if c.AuthToken == "" && c.AuthTokenFile != "" {
+		return shared.ReadFileValueString(c.AuthTokenFile, &c.AuthToken)
+	}
+	return nil
+}
+
+// Enabled returns true if connector metrics can be queried
+func (c *ConnectorMetricsConfig) Enabled() bool {
+	return c.EnableMock || c.ObservatoriumURL != ""
+}
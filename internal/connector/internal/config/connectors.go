@@ -1,13 +1,13 @@
 package config
 
 import (
+	"context"
 	"crypto/sha1"
 	"encoding/json"
 	"fmt"
 	"io/fs"
-	"os"
-	"sort"
 	"strings"
+	"sync"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/files"
 
@@ -29,6 +29,15 @@ type ConnectorsConfig struct {
 	ConnectorMetadataDirs               []string                `json:"connector_metadata"`
 	CatalogEntries                      []ConnectorCatalogEntry `json:"connector_type_urls"`
 	CatalogChecksums                    map[string]string       `json:"connector_catalog_checksums"`
+	ConnectorUpgradeHealthTimeout       time.Duration           `json:"connector_upgrade_health_timeout"`
+	ConnectorSecretsGCInterval          time.Duration           `json:"connector_secrets_gc_interval"`
+	ConnectorSecretsGCGracePeriod       time.Duration           `json:"connector_secrets_gc_grace_period"`
+	ConnectorSecretsGCDryRun            bool                    `json:"connector_secrets_gc_dry_run"`
+	ConnectorCatalogSources             []string                `json:"connector_catalog_sources"`
+	ConnectorCatalogSyncInterval        time.Duration           `json:"connector_catalog_sync_interval"`
+	ConnectorCatalogPublicKeyFile       string                  `json:"connector_catalog_public_key_file"`
+	ConnectorLogTailLines               int                     `json:"connector_log_tail_lines"`
+	catalogMutex                        sync.RWMutex
 }
 
 var _ environments.ConfigModule = &ConnectorsConfig{}
@@ -51,45 +60,59 @@ type ConnectorMetadata struct {
 
 func NewConnectorsConfig() *ConnectorsConfig {
 	return &ConnectorsConfig{
-		CatalogChecksums: make(map[string]string),
+		CatalogChecksums:              make(map[string]string),
+		ConnectorUpgradeHealthTimeout: 15 * time.Minute,
+		ConnectorSecretsGCInterval:    time.Hour,
+		ConnectorSecretsGCGracePeriod: 24 * time.Hour,
+		ConnectorCatalogSyncInterval:  5 * time.Minute,
+		ConnectorLogTailLines:         500,
 	}
 }
 
 func (c *ConnectorsConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringArrayVar(&c.ConnectorCatalogDirs, "connector-catalog", c.ConnectorCatalogDirs, "Directory containing connector catalog entries")
+	fs.StringArrayVar(&c.ConnectorCatalogSources, "connector-catalog-source", c.ConnectorCatalogSources, "Connector catalog source, a file:// directory, an http(s):// index file optionally pinned with #sha256=<digest>, or an oci:// artifact. Sources must be reachable at startup")
+	fs.DurationVar(&c.ConnectorCatalogSyncInterval, "connector-catalog-sync-interval", c.ConnectorCatalogSyncInterval, "Interval between syncs of the connector catalog from its directories and sources, 0 disables the syncs")
+	fs.StringVar(&c.ConnectorCatalogPublicKeyFile, "connector-catalog-public-key-file", c.ConnectorCatalogPublicKeyFile, "PEM encoded public key verifying the signatures of remote connector catalog sources, remote sources must be signed when set")
+	fs.IntVar(&c.ConnectorLogTailLines, "connector-log-tail-lines", c.ConnectorLogTailLines, "Maximum number of log lines pushed by agents that are kept for every connector, 0 disables connector logs")
 	fs.StringArrayVar(&c.ConnectorMetadataDirs, "connector-metadata", c.ConnectorMetadataDirs, "Directory containing connector metadata configuration files")
 	fs.DurationVar(&c.ConnectorEvalDuration, "connector-eval-duration", c.ConnectorEvalDuration, "Connector eval duration in golang duration format")
 	fs.StringArrayVar(&c.ConnectorEvalOrganizations, "connector-eval-organizations", c.ConnectorEvalOrganizations, "Connector eval organization IDs")
 	fs.BoolVar(&c.ConnectorNamespaceLifecycleAPI, "connector-namespace-lifecycle-api", c.ConnectorNamespaceLifecycleAPI, "Enable APIs to create, update, delete non-eval Namespaces")
+	fs.DurationVar(&c.ConnectorUpgradeHealthTimeout, "connector-upgrade-health-timeout", c.ConnectorUpgradeHealthTimeout, "Time given to an automatically upgraded connector deployment to become healthy before the upgrade is rolled back")
+	fs.DurationVar(&c.ConnectorSecretsGCInterval, "connector-secrets-gc-interval", c.ConnectorSecretsGCInterval, "Interval between scans of the vault for orphaned connector secrets, 0 disables the scans")
+	fs.DurationVar(&c.ConnectorSecretsGCGracePeriod, "connector-secrets-gc-grace-period", c.ConnectorSecretsGCGracePeriod, "Time a connector secret must have been orphaned for before it's deleted from the vault")
+	fs.BoolVar(&c.ConnectorSecretsGCDryRun, "connector-secrets-gc-dry-run", c.ConnectorSecretsGCDryRun, "Only report orphaned connector secrets instead of deleting them from the vault")
 	fs.BoolVar(&c.ConnectorEnableUnassignedConnectors, "connector-enable-unassigned-connectors", c.ConnectorEnableUnassignedConnectors, "Enable support for 'unassigned' state for Connectors")
 }
 
 func (c *ConnectorsConfig) ReadFiles() error {
-	// read metadata first to merge with catalog next
-	connectorMetadata, err := c.readConnectorMetadata()
+	// remote catalog sources are read at startup too, so that the catalog is complete before types are reconciled
+	catalog, err := c.LoadCatalog(context.Background())
+	c.SetCatalog(catalog.Entries, catalog.Checksums)
 	if err != nil {
 		return err
 	}
 
-	// read catalogs and merge metadata, removing entries from the map
-	err = c.readConnectorCatalog(connectorMetadata)
-	if err != nil {
-		return err
-	}
+	entries, _ := c.Catalog()
+	glog.Infof("loaded %d connector types", len(entries))
 
-	// check if there are any unused metadata entries left
-	remainingIds := len(connectorMetadata)
-	if remainingIds > 0 {
-		ids := make([]string, 0, remainingIds)
-		for id := range connectorMetadata {
-			ids = append(ids, id)
-		}
-		return fmt.Errorf("found %d unrecognized connector metadata with ids: %s", remainingIds, ids)
-	}
+	return nil
+}
 
-	glog.Infof("loaded %d connector types", len(c.CatalogEntries))
+// Catalog returns the connector catalog entries and their checksums
+func (c *ConnectorsConfig) Catalog() ([]ConnectorCatalogEntry, map[string]string) {
+	c.catalogMutex.RLock()
+	defer c.catalogMutex.RUnlock()
+	return c.CatalogEntries, c.CatalogChecksums
+}
 
-	return nil
+// SetCatalog replaces the connector catalog entries and their checksums, after a catalog source sync
+func (c *ConnectorsConfig) SetCatalog(entries []ConnectorCatalogEntry, checksums map[string]string) {
+	c.catalogMutex.Lock()
+	defer c.catalogMutex.Unlock()
+	c.CatalogEntries = entries
+	c.CatalogChecksums = checksums
 }
 
 func (c *ConnectorsConfig) readConnectorMetadata() (connectorMetadata map[string]ConnectorMetadata, err error) {
@@ -129,110 +152,6 @@ func (c *ConnectorsConfig) readConnectorMetadata() (connectorMetadata map[string
 	return
 }
 
-func (c *ConnectorsConfig) readConnectorCatalog(connectorMetadata map[string]ConnectorMetadata) (err error) {
-	typesLoaded := map[string]string{}
-	for _, dir := range c.ConnectorCatalogDirs {
-		dir = shared.BuildFullFilePath(dir)
-
-		err = files.Walk(dir, func(path string, info fs.FileInfo, err error) error {
-			if err != nil {
-				return err
-			}
-
-			// skip over hidden files and dirs..
-			if info.IsDir() || strings.HasPrefix(path, ".") {
-				return nil
-			}
-
-			glog.Infof("loading connectors from file %s", path)
-
-			// Read the file
-			buf, err := os.ReadFile(path)
-			if err != nil {
-				return fmt.Errorf("error reading catalog file %s: %s", path, err)
-			}
-
-			entry := ConnectorCatalogEntry{}
-			err = json.Unmarshal(buf, &entry)
-			if err != nil {
-				return fmt.Errorf("error unmarshaling catalog file %s: %s", path, err)
-			}
-
-			// set catalog metadata from metadata config read earlier
-			id := entry.ConnectorType.Id
-			if meta, found := connectorMetadata[id]; found {
-				entry.ConnectorType.FeaturedRank = meta.FeaturedRank
-				entry.ConnectorType.Labels = meta.Labels
-				entry.ConnectorType.Annotations = meta.Annotations
-			} else {
-				return fmt.Errorf("missing metadata for connector %s", id)
-			}
-
-			// compute checksum for catalog entry to look for updates
-			sum, err := checksum(entry)
-			if err != nil {
-				return fmt.Errorf("error computing checksum for catalog file %s: %s", path, err)
-			}
-
-			// when walking directories with symlink such as what kubernetes does when mounting
-			// a volume from a configmap where the actual files are double-symlinked from some
-			// random named path so this method is invoked twice or more, but it is not actually
-			// always possible to reliably determine if files have been already processed, so we
-			// first check if:
-			//
-			// - the previous file and the new file are the same (os.SameFile)
-			// - the file has already been processed
-			// - the previous file checksum is the same
-			//
-			// if any of the above condition is true, we assume the previous file and the current
-			// one are actually the same, so we can safely ignore it
-
-			if prev, found := typesLoaded[id]; found {
-				prevInfo, prevErr := os.Lstat(prev)
-				if prevErr != nil {
-					return nil
-				}
-
-				if os.SameFile(info, prevInfo) {
-					return nil
-				}
-				if typesLoaded[id] == path {
-					return nil
-				}
-				if c.CatalogChecksums[id] == sum {
-					return nil
-				}
-
-				return fmt.Errorf("connector type '%s' defined in '%s' and '%s'", id, path, prev)
-			}
-
-			c.CatalogChecksums[id] = sum
-			typesLoaded[id] = path
-
-			c.CatalogEntries = append(c.CatalogEntries, entry)
-
-			glog.Infof("loaded connector %s from file %s", id, path)
-
-			return nil
-		})
-
-		if err != nil {
-			return fmt.Errorf("error listing connector catalogs in %s: %s", dir, err)
-		}
-	}
-
-	// remove all processed metadata entries
-	for _, entry := range c.CatalogEntries {
-		delete(connectorMetadata, entry.ConnectorType.Id)
-	}
-
-	sort.Slice(c.CatalogEntries, func(i, j int) bool {
-		return c.CatalogEntries[i].ConnectorType.Id < c.CatalogEntries[j].ConnectorType.Id
-	})
-
-	return nil
-}
-
 func checksum(spec interface{}) (string, error) {
 	h := sha1.New()
 	err := json.NewEncoder(h).Encode(spec)
@@ -9,6 +9,14 @@ type NamespaceQuota struct {
 	CPULimits      string `yaml:"cpu-limits,omitempty"`
 }
 
+// ConnectorResources has the resource requests and limits of the pods of a connector
+type ConnectorResources struct {
+	MemoryRequests string `json:"memory_requests,omitempty"`
+	MemoryLimits   string `json:"memory_limits,omitempty"`
+	CPURequests    string `json:"cpu_requests,omitempty"`
+	CPULimits      string `json:"cpu_limits,omitempty"`
+}
+
 // Quotas has limits for various resource types, e.g. namespaces
 // other resource limits can be added in the future,
 // e.g clusters with limits on namespaces, connectors with limits on catalogs, etc.
@@ -8,6 +8,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
 	"github.com/spf13/pflag"
 	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 type ConnectorsQuotaConfig struct {
@@ -15,6 +16,8 @@ type ConnectorsQuotaConfig struct {
 	ConnectorsQuotaConfigFile    string
 	EvalNamespaceQuotaProfile    string
 	DefaultNamespaceQuotaProfile string
+	// DefaultConnectorResources is the footprint of connectors whose shard metadata doesn't declare resources
+	DefaultConnectorResources ConnectorResources
 }
 
 func NewConnectorsQuotaConfig() *ConnectorsQuotaConfig {
@@ -30,6 +33,10 @@ func (c *ConnectorsQuotaConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&c.ConnectorsQuotaConfigFile, "connectors-quota-config-file", c.ConnectorsQuotaConfigFile, "Connectors quota configuration file")
 	fs.StringVar(&c.EvalNamespaceQuotaProfile, "connectors-eval-namespace-quota-profile", c.EvalNamespaceQuotaProfile, "Connectors quota profile name for evaluation namespaces")
 	fs.StringVar(&c.DefaultNamespaceQuotaProfile, "default-eval-namespace-quota-profile", c.DefaultNamespaceQuotaProfile, "Connectors quota profile name for default namespace")
+	fs.StringVar(&c.DefaultConnectorResources.CPURequests, "connectors-default-cpu-requests", c.DefaultConnectorResources.CPURequests, "CPU requests of connectors whose shard metadata doesn't declare resources")
+	fs.StringVar(&c.DefaultConnectorResources.CPULimits, "connectors-default-cpu-limits", c.DefaultConnectorResources.CPULimits, "CPU limits of connectors whose shard metadata doesn't declare resources")
+	fs.StringVar(&c.DefaultConnectorResources.MemoryRequests, "connectors-default-memory-requests", c.DefaultConnectorResources.MemoryRequests, "Memory requests of connectors whose shard metadata doesn't declare resources")
+	fs.StringVar(&c.DefaultConnectorResources.MemoryLimits, "connectors-default-memory-limits", c.DefaultConnectorResources.MemoryLimits, "Memory limits of connectors whose shard metadata doesn't declare resources")
 }
 
 func (c *ConnectorsQuotaConfig) ReadFiles() (err error) {
@@ -45,6 +52,18 @@ func (c *ConnectorsQuotaConfig) ReadFiles() (err error) {
 			err = fmt.Errorf("configuration file '%s' is missing default namespace quota profile '%s'",
 				c.ConnectorsQuotaConfigFile, c.DefaultNamespaceQuotaProfile)
 		}
+
+		for name, profile := range c.connectorsQuotaMap {
+			quota := profile.NamespaceQuota
+			if qerr := validateQuantities(quota.MemoryRequests, quota.MemoryLimits, quota.CPURequests, quota.CPULimits); qerr != nil {
+				err = fmt.Errorf("configuration file '%s' has invalid namespace quota in profile '%s': %s",
+					c.ConnectorsQuotaConfigFile, name, qerr)
+			}
+		}
+		resources := c.DefaultConnectorResources
+		if qerr := validateQuantities(resources.MemoryRequests, resources.MemoryLimits, resources.CPURequests, resources.CPULimits); qerr != nil {
+			err = fmt.Errorf("invalid default connector resources: %s", qerr)
+		}
 	} else if os.IsNotExist(err) {
 		err = fmt.Errorf("configuration file for connectors-quota-config-file not found: '%s'", c.ConnectorsQuotaConfigFile)
 	} else {
@@ -54,6 +73,19 @@ func (c *ConnectorsQuotaConfig) ReadFiles() (err error) {
 	return err
 }
 
+// validateQuantities checks that non empty quotas are valid kubernetes quantities
+func validateQuantities(quantities ...string) error {
+	for _, q := range quantities {
+		if q == "" {
+			continue
+		}
+		if _, err := resource.ParseQuantity(q); err != nil {
+			return fmt.Errorf("'%s': %s", q, err)
+		}
+	}
+	return nil
+}
+
 // Read the contents of file into the quota list config
 func readQuotaConfigFile(file string, val ConnectorsQuotaProfileMap) error {
 	fileContents, err := shared.ReadFile(file)
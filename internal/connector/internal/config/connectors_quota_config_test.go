@@ -40,6 +40,17 @@ const quotaConfigFileNoEval = `
 - profile-name: default-profile
 `
 
+const quotaConfigFileBadQuantity = `
+---
+- profile-name: default-profile
+- profile-name: evaluation-profile
+  quotas:
+    namespace-quota:
+      connectors: 4
+      memory-requests: "1 gigabyte"
+
+`
+
 func TestConnectorsQuotaConfig_ReadFiles(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -76,6 +87,27 @@ func TestConnectorsQuotaConfig_ReadFiles(t *testing.T) {
 			},
 			err: "is missing evaluation namespace quota profile",
 		},
+		{
+			name: "quotaConfigFileBadQuantity",
+			config: ConnectorsQuotaConfig{
+				connectorsQuotaMap:           make(ConnectorsQuotaProfileMap),
+				ConnectorsQuotaConfigFile:    createFile(t, []byte(quotaConfigFileBadQuantity)),
+				EvalNamespaceQuotaProfile:    profiles.EvaluationProfileName,
+				DefaultNamespaceQuotaProfile: profiles.DefaultProfileName,
+			},
+			err: "has invalid namespace quota in profile 'evaluation-profile'",
+		},
+		{
+			name: "badDefaultConnectorResources",
+			config: ConnectorsQuotaConfig{
+				connectorsQuotaMap:           make(ConnectorsQuotaProfileMap),
+				ConnectorsQuotaConfigFile:    createFile(t, []byte(quotaConfigFileOk)),
+				EvalNamespaceQuotaProfile:    profiles.EvaluationProfileName,
+				DefaultNamespaceQuotaProfile: profiles.DefaultProfileName,
+				DefaultConnectorResources:    ConnectorResources{CPURequests: "half"},
+			},
+			err: "invalid default connector resources",
+		},
 	}
 
 	for i := range tests {
@@ -25,5 +25,9 @@ func NewDevelopmentEnvLoader() environments.EnvLoader {
 		"admin-api-sso-base-url":     "http://127.0.0.1:8180",
 		"admin-api-sso-endpoint-uri": "/auth/realms/rhoas-kafka-sre",
 		"admin-api-sso-realm":        "rhoas-kafka-sre",
+
+		// the webhook deliveries can be tested with a local http receiver
+		"webhook-allow-insecure-urls":    "true",
+		"webhook-allow-private-networks": "true",
 	}
 }
@@ -18,27 +18,30 @@ func NewIntegrationEnvLoader() environments.EnvLoader {
 
 func (b IntegrationEnvLoader) Defaults() map[string]string {
 	return map[string]string{
-		"v":                          "0",
-		"logtostderr":                "true",
-		"ocm-base-url":               "https://api-integration.6943.hive-integration.openshiftapps.com",
-		"enable-https":               "false",
-		"enable-metrics-https":       "false",
-		"enable-terms-acceptance":    "false",
-		"ocm-debug":                  "false",
-		"enable-ocm-mock":            "true",
-		"ocm-mock-mode":              ocm.MockModeEmulateServer,
-		"enable-sentry":              "false",
-		"enable-deny-list":           "true",
-		"sso-provider-type":          "mas_sso",
-		"enable-access-list":         "false",
-		"mas-sso-base-url":           "http://127.0.0.1:8180",
-		"redhat-sso-base-url":        "https://sso.stage.redhat.com",
-		"mas-sso-realm":              "rhoas",
-		"connector-eval-duration":    "48h",
-		"osd-idp-mas-sso-realm":      "rhoas-kafka-sre",
-		"admin-api-sso-base-url":     "http://127.0.0.1:8180",
-		"admin-api-sso-endpoint-uri": "/auth/realms/rhoas-kafka-sre",
-		"admin-api-sso-realm":        "rhoas-kafka-sre",
+		"v":                              "0",
+		"logtostderr":                    "true",
+		"ocm-base-url":                   "https://api-integration.6943.hive-integration.openshiftapps.com",
+		"enable-https":                   "false",
+		"enable-metrics-https":           "false",
+		"enable-terms-acceptance":        "false",
+		"ocm-debug":                      "false",
+		"enable-ocm-mock":                "true",
+		"ocm-mock-mode":                  ocm.MockModeEmulateServer,
+		"enable-sentry":                  "false",
+		"webhook-allow-insecure-urls":    "true",
+		"webhook-allow-private-networks": "true",
+		"enable-deny-list":               "true",
+		"sso-provider-type":              "mas_sso",
+		"enable-access-list":             "false",
+		"mas-sso-base-url":               "http://127.0.0.1:8180",
+		"redhat-sso-base-url":            "https://sso.stage.redhat.com",
+		"mas-sso-realm":                  "rhoas",
+		"connector-eval-duration":        "48h",
+		"connector-metrics-enable-mock":  "true",
+		"osd-idp-mas-sso-realm":          "rhoas-kafka-sre",
+		"admin-api-sso-base-url":         "http://127.0.0.1:8180",
+		"admin-api-sso-endpoint-uri":     "/auth/realms/rhoas-kafka-sre",
+		"admin-api-sso-realm":            "rhoas-kafka-sre",
 	}
 }
 
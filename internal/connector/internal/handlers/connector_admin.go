@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -21,8 +22,10 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
 	"github.com/goava/di"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	coreservices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
 	"github.com/gorilla/mux"
 )
 
@@ -36,6 +39,9 @@ type ConnectorAdminHandler struct {
 	QuotaConfig           *config.ConnectorsQuotaConfig
 	ConnectorCluster      *ConnectorClusterHandler //TODO: eventually move deployment handling into a deployment service
 	ConnectorTypesService services.ConnectorTypesService
+	RevisionsService      services.ConnectorRevisionsService
+	UpgradesService       services.ConnectorUpgradesService
+	CatalogSourcesService services.ConnectorCatalogSourcesService
 }
 
 type operator struct {
@@ -229,6 +235,9 @@ func (h *ConnectorAdminHandler) GetConnectorNamespace(writer http.ResponseWriter
 			if serviceError != nil {
 				return nil, serviceError
 			}
+			if err := auth.CheckAdminResource(request.Context(), namespaceAdminResource(namespace)); err != nil {
+				return nil, err
+			}
 			return presenters.PresentPrivateConnectorNamespace(namespace, h.QuotaConfig), nil
 		},
 	}
@@ -245,6 +254,15 @@ func (h *ConnectorAdminHandler) DeleteConnectorNamespace(writer http.ResponseWri
 		Action: func() (i interface{}, serviceError *errors.ServiceError) {
 
 			ctx := request.Context()
+			if auth.IsAdminResourceRestricted(ctx) {
+				namespace, err := h.NamespaceService.Get(ctx, namespaceId)
+				if err != nil {
+					return nil, err
+				}
+				if err := auth.CheckAdminResource(ctx, namespaceAdminResource(namespace)); err != nil {
+					return nil, err
+				}
+			}
 			if parseBoolParam(request.URL.Query().Get("force")) {
 				// set namespace status to deleted
 				namespace, err := h.NamespaceService.Get(ctx, namespaceId)
@@ -354,6 +372,9 @@ func (h *ConnectorAdminHandler) GetConnector(writer http.ResponseWriter, request
 			if serviceError != nil {
 				return nil, serviceError
 			}
+			if err := auth.CheckAdminResource(request.Context(), connectorAdminResource(&connector.Connector)); err != nil {
+				return nil, err
+			}
 			return presenters.PresentConnectorAdminView(connector)
 		},
 	}
@@ -373,6 +394,11 @@ func (h *ConnectorAdminHandler) PatchConnector(writer http.ResponseWriter, reque
 		handlers.Handle(writer, request, nil, http.StatusBadRequest)
 	}
 
+	if err := h.checkConnectorAdminResource(request.Context(), mux.Vars(request)["connector_id"]); err != nil {
+		shared.HandleError(request, writer, err)
+		return
+	}
+
 	r := io.NopCloser(strings.NewReader(fmt.Sprintf("{\"desired_state\": \"%s\"}", rConnector.DesiredState)))
 	request.Body = r
 
@@ -382,6 +408,7 @@ func (h *ConnectorAdminHandler) PatchConnector(writer http.ResponseWriter, reque
 		namespaceService:      h.NamespaceService,
 		authZService:          h.AuthZService,
 		connectorsConfig:      h.ConnectorsConfig,
+		revisionsService:      h.RevisionsService,
 	}.Patch(writer, request)
 }
 
@@ -393,6 +420,10 @@ func (h *ConnectorAdminHandler) DeleteConnector(writer http.ResponseWriter, requ
 		},
 		Action: func() (i interface{}, serviceError *errors.ServiceError) {
 
+			if err := h.checkConnectorAdminResource(request.Context(), connectorId); err != nil {
+				return nil, err
+			}
+
 			// check force flag to force deletion of connector and deployments
 			if parseBoolParam(request.URL.Query().Get("force")) {
 				serviceError = h.ConnectorsService.ForceDelete(request.Context(), connectorId)
@@ -601,6 +632,17 @@ func (h *ConnectorAdminHandler) PatchConnectorDeployment(writer http.ResponseWri
 			if existingDeployment.ClusterID != clusterId {
 				return nil, coreservices.HandleGetError(`Connector existingDeployment`, `id`, deploymentId, gorm.ErrRecordNotFound)
 			}
+			if err := auth.CheckAdminResource(request.Context(), map[string]string{
+				auth.AdminResourceClusterId:   existingDeployment.ClusterID,
+				auth.AdminResourceNamespaceId: existingDeployment.NamespaceID,
+			}); err != nil {
+				return nil, err
+			}
+
+			before, serviceError := presenters.PresentConnectorDeploymentAdminView(existingDeployment, clusterId)
+			if serviceError != nil {
+				return nil, serviceError
+			}
 
 			// Handle the fields that support being updated...
 			var updatedDeployment dbapi.ConnectorDeployment
@@ -646,13 +688,54 @@ func (h *ConnectorAdminHandler) PatchConnectorDeployment(writer http.ResponseWri
 			if serviceError != nil {
 				return nil, serviceError
 			}
-			return presenters.PresentConnectorDeploymentAdminView(existingDeployment, clusterId)
+			after, serviceError := presenters.PresentConnectorDeploymentAdminView(existingDeployment, clusterId)
+			if serviceError != nil {
+				return nil, serviceError
+			}
+			auth.RecordAuditDiff(request.Context(), before, after)
+			return after, nil
 		},
 	}
 
 	handlers.Handle(writer, request, &cfg, http.StatusAccepted)
 }
 
+// checkConnectorAdminResource checks that the admin policies of the user allow access to a connector,
+// the connector is only loaded when the admin policies of the user restrict the accessible resources
+func (h *ConnectorAdminHandler) checkConnectorAdminResource(ctx context.Context, connectorId string) *errors.ServiceError {
+	if !auth.IsAdminResourceRestricted(ctx) {
+		return nil
+	}
+	connector, err := h.ConnectorsService.Get(ctx, connectorId)
+	if err != nil {
+		return err
+	}
+	return auth.CheckAdminResource(ctx, connectorAdminResource(&connector.Connector))
+}
+
+func connectorAdminResource(connector *dbapi.Connector) map[string]string {
+	resource := map[string]string{
+		auth.AdminResourceOrganisationId: connector.OrganisationId,
+		auth.AdminResourceCloudProvider:  connector.CloudProvider,
+		auth.AdminResourceRegion:         connector.Region,
+	}
+	if connector.NamespaceId != nil {
+		resource[auth.AdminResourceNamespaceId] = *connector.NamespaceId
+	}
+	return resource
+}
+
+func namespaceAdminResource(namespace *dbapi.ConnectorNamespace) map[string]string {
+	resource := map[string]string{
+		auth.AdminResourceClusterId:   namespace.ClusterId,
+		auth.AdminResourceNamespaceId: namespace.ID,
+	}
+	if namespace.TenantOrganisationId != nil {
+		resource[auth.AdminResourceOrganisationId] = *namespace.TenantOrganisationId
+	}
+	return resource
+}
+
 func (h *ConnectorAdminHandler) isEvalOrg(id string) bool {
 	for _, eid := range h.ConnectorsConfig.ConnectorEvalOrganizations {
 		if id == eid {
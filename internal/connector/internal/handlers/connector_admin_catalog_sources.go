@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	coreservices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/gorilla/mux"
+)
+
+// catalog source ids are hex encoded sha1 digests of the source names
+const maxCatalogSourceIdLength = 40
+
+func (h *ConnectorAdminHandler) ListConnectorCatalogSources(writer http.ResponseWriter, request *http.Request) {
+	listArgs := coreservices.NewListArguments(request.URL.Query())
+	cfg := handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+
+			sources, paging, err := h.CatalogSourcesService.List(request.Context(), listArgs)
+			if err != nil {
+				return nil, err
+			}
+
+			result := private.ConnectorCatalogSourceList{
+				Kind:  "ConnectorCatalogSourceList",
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+			}
+
+			result.Items = make([]private.ConnectorCatalogSource, len(sources))
+			for i, source := range sources {
+				result.Items[i] = presenters.PresentConnectorCatalogSource(source)
+			}
+
+			return result, nil
+		},
+	}
+
+	handlers.HandleList(writer, request, &cfg)
+}
+
+func (h *ConnectorAdminHandler) GetConnectorCatalogSource(writer http.ResponseWriter, request *http.Request) {
+	sourceId := mux.Vars(request)["source_id"]
+	cfg := handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("source_id", &sourceId, handlers.MinLen(1), handlers.MaxLen(maxCatalogSourceIdLength)),
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			source, serviceError := h.CatalogSourcesService.Get(request.Context(), sourceId)
+			if serviceError != nil {
+				return nil, serviceError
+			}
+			return presenters.PresentConnectorCatalogSource(source), nil
+		},
+	}
+
+	handlers.HandleGet(writer, request, &cfg)
+}
@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	coreservices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/gorilla/mux"
+)
+
+func (h *ConnectorAdminHandler) GetNamespaceUpgradePolicy(writer http.ResponseWriter, request *http.Request) {
+	namespaceId := mux.Vars(request)["namespace_id"]
+	cfg := handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("namespace_id", &namespaceId, handlers.MinLen(1), handlers.MaxLen(maxConnectorNamespaceIdLength)),
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			policy, serviceError := h.UpgradesService.GetPolicy(request.Context(), namespaceId, "")
+			if serviceError != nil {
+				return nil, serviceError
+			}
+			return presenters.PresentConnectorUpgradePolicy(policy), nil
+		},
+	}
+
+	handlers.HandleGet(writer, request, &cfg)
+}
+
+func (h *ConnectorAdminHandler) PutNamespaceUpgradePolicy(writer http.ResponseWriter, request *http.Request) {
+	namespaceId := mux.Vars(request)["namespace_id"]
+	var resource private.ConnectorUpgradePolicyRequest
+	cfg := handlers.HandlerConfig{
+		MarshalInto: &resource,
+		Validate: []handlers.Validate{
+			handlers.Validation("namespace_id", &namespaceId, handlers.MinLen(1), handlers.MaxLen(maxConnectorNamespaceIdLength)),
+			validateConnectorUpgradePolicyRequest(&resource),
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			ctx := request.Context()
+			if _, err := h.NamespaceService.Get(ctx, namespaceId); err != nil {
+				return nil, err
+			}
+			policy := presenters.ConvertConnectorUpgradePolicyRequest(namespaceId, "", resource)
+			if err := h.UpgradesService.SavePolicy(ctx, policy); err != nil {
+				return nil, err
+			}
+			return presenters.PresentConnectorUpgradePolicy(policy), nil
+		},
+	}
+
+	handlers.Handle(writer, request, &cfg, http.StatusOK)
+}
+
+func (h *ConnectorAdminHandler) DeleteNamespaceUpgradePolicy(writer http.ResponseWriter, request *http.Request) {
+	namespaceId := mux.Vars(request)["namespace_id"]
+	cfg := handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("namespace_id", &namespaceId, handlers.MinLen(1), handlers.MaxLen(maxConnectorNamespaceIdLength)),
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			return nil, h.UpgradesService.DeletePolicy(request.Context(), namespaceId, "")
+		},
+	}
+
+	handlers.HandleDelete(writer, request, &cfg, http.StatusNoContent)
+}
+
+func (h *ConnectorAdminHandler) GetConnectorUpgradePolicy(writer http.ResponseWriter, request *http.Request) {
+	connectorId := mux.Vars(request)["connector_id"]
+	cfg := handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("connector_id", &connectorId, handlers.MinLen(1), handlers.MaxLen(maxConnectorIdLength)),
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			policy, serviceError := h.UpgradesService.GetPolicy(request.Context(), "", connectorId)
+			if serviceError != nil {
+				return nil, serviceError
+			}
+			return presenters.PresentConnectorUpgradePolicy(policy), nil
+		},
+	}
+
+	handlers.HandleGet(writer, request, &cfg)
+}
+
+func (h *ConnectorAdminHandler) PutConnectorUpgradePolicy(writer http.ResponseWriter, request *http.Request) {
+	connectorId := mux.Vars(request)["connector_id"]
+	var resource private.ConnectorUpgradePolicyRequest
+	cfg := handlers.HandlerConfig{
+		MarshalInto: &resource,
+		Validate: []handlers.Validate{
+			handlers.Validation("connector_id", &connectorId, handlers.MinLen(1), handlers.MaxLen(maxConnectorIdLength)),
+			validateConnectorUpgradePolicyRequest(&resource),
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			ctx := request.Context()
+			if _, err := h.ConnectorsService.Get(ctx, connectorId); err != nil {
+				return nil, err
+			}
+			policy := presenters.ConvertConnectorUpgradePolicyRequest("", connectorId, resource)
+			if err := h.UpgradesService.SavePolicy(ctx, policy); err != nil {
+				return nil, err
+			}
+			return presenters.PresentConnectorUpgradePolicy(policy), nil
+		},
+	}
+
+	handlers.Handle(writer, request, &cfg, http.StatusOK)
+}
+
+func (h *ConnectorAdminHandler) DeleteConnectorUpgradePolicy(writer http.ResponseWriter, request *http.Request) {
+	connectorId := mux.Vars(request)["connector_id"]
+	cfg := handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("connector_id", &connectorId, handlers.MinLen(1), handlers.MaxLen(maxConnectorIdLength)),
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			return nil, h.UpgradesService.DeletePolicy(request.Context(), "", connectorId)
+		},
+	}
+
+	handlers.HandleDelete(writer, request, &cfg, http.StatusNoContent)
+}
+
+func (h *ConnectorAdminHandler) ListConnectorUpgrades(writer http.ResponseWriter, request *http.Request) {
+	listArgs := coreservices.NewListArguments(request.URL.Query())
+	cfg := handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+
+			upgrades, paging, err := h.UpgradesService.ListUpgrades(request.Context(), listArgs)
+			if err != nil {
+				return nil, err
+			}
+
+			result := private.ConnectorUpgradeList{
+				Kind:  "ConnectorUpgradeList",
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+			}
+
+			result.Items = make([]private.ConnectorUpgrade, len(upgrades))
+			for i, upgrade := range upgrades {
+				result.Items[i] = presenters.PresentConnectorUpgrade(upgrade)
+			}
+
+			return result, nil
+		},
+	}
+
+	handlers.HandleList(writer, request, &cfg)
+}
+
+func (h *ConnectorAdminHandler) GetConnectorUpgrade(writer http.ResponseWriter, request *http.Request) {
+	upgradeId := mux.Vars(request)["upgrade_id"]
+	cfg := handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("upgrade_id", &upgradeId, handlers.MinLen(1), handlers.MaxLen(maxConnectorIdLength)),
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			upgrade, serviceError := h.UpgradesService.GetUpgrade(request.Context(), upgradeId)
+			if serviceError != nil {
+				return nil, serviceError
+			}
+			return presenters.PresentConnectorUpgrade(upgrade), nil
+		},
+	}
+
+	handlers.HandleGet(writer, request, &cfg)
+}
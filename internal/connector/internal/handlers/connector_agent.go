@@ -384,8 +384,14 @@ func (h *ConnectorClusterHandler) UpdateDeploymentStatus(w http.ResponseWriter,
 				return nil, serr
 			}
 			converted.ID = deploymentId
-			err := h.Service.UpdateConnectorDeploymentStatus(ctx, converted)
-			return nil, err
+			if err := h.Service.UpdateConnectorDeploymentStatus(ctx, converted); err != nil {
+				return nil, err
+			}
+			// the status is already stored, failing here would make the agent push the same log lines again
+			if err := h.Logs.AppendDeploymentLogs(ctx, deploymentId, presenters.ConvertConnectorDeploymentLogLines(resource.Logs)); err != nil {
+				glog.Errorf("failed to store log lines of connector deployment %s: %v", deploymentId, err)
+			}
+			return nil, nil
 		},
 	}
 	handlers.Handle(w, r, cfg, http.StatusNoContent)
@@ -7,7 +7,9 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/authz"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/ocm"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db/dbencryption"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/server"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
 	"github.com/golang/glog"
@@ -43,6 +45,7 @@ type ConnectorClusterHandler struct {
 	ServerConfig       *server.ServerConfig
 	AuthZ              authz.AuthZService
 	QuotaConfig        *config.ConnectorsQuotaConfig
+	Logs               services.ConnectorLogsService
 }
 
 func NewConnectorClusterHandler(handler ConnectorClusterHandler) *ConnectorClusterHandler {
@@ -60,7 +63,7 @@ func (h *ConnectorClusterHandler) Create(w http.ResponseWriter, r *http.Request)
 			handlers.Validation("name", &resource.Name, handlers.WithDefault("New Cluster"),
 				handlers.MinLen(1), handlers.MaxLen(maxConnectorClusterNameLength)),
 			validateCreateAnnotations(resource.Annotations),
-			user.AuthorizedOrgAdmin(),
+			user.AuthorizedCreateCluster(),
 		},
 		Action: func() (interface{}, *errors.ServiceError) {
 
@@ -73,7 +76,7 @@ func (h *ConnectorClusterHandler) Create(w http.ResponseWriter, r *http.Request)
 				return nil, errors.GeneralError("failed to create service account for connector cluster %s due to error: %v", convResource.ID, err)
 			}
 			convResource.ClientId = acc.ClientID
-			convResource.ClientSecret = acc.ClientSecret
+			convResource.ClientSecret = dbencryption.EncryptedString(acc.ClientSecret)
 
 			if err = h.Service.Create(r.Context(), convResource); err != nil {
 				// deregister service account on creation error
@@ -124,7 +127,7 @@ func (h *ConnectorClusterHandler) Update(w http.ResponseWriter, r *http.Request)
 		MarshalInto: &resource,
 		Validate: []handlers.Validate{
 			handlers.Validation("connector_cluster_id", &connectorClusterId,
-				handlers.MinLen(1), handlers.MaxLen(maxConnectorClusterIdLength), user.AuthorizedClusterAdmin()),
+				handlers.MinLen(1), handlers.MaxLen(maxConnectorClusterIdLength), user.AuthorizedClusterAdmin(auth.ActionUpdate)),
 			handlers.Validation("name", &resource.Name,
 				handlers.MinLen(1), handlers.MaxLen(maxConnectorClusterNameLength)),
 		},
@@ -163,7 +166,7 @@ func (h *ConnectorClusterHandler) Delete(w http.ResponseWriter, r *http.Request)
 	cfg := &handlers.HandlerConfig{
 		Validate: []handlers.Validate{
 			handlers.Validation("connector_cluster_id", &connectorClusterId,
-				handlers.MinLen(1), handlers.MaxLen(maxConnectorClusterIdLength), user.AuthorizedClusterAdmin()),
+				handlers.MinLen(1), handlers.MaxLen(maxConnectorClusterIdLength), user.AuthorizedClusterAdmin(auth.ActionDelete)),
 		},
 		Action: func() (i interface{}, serviceError *errors.ServiceError) {
 			err := h.Service.Delete(r.Context(), connectorClusterId)
@@ -211,7 +214,8 @@ func (h *ConnectorClusterHandler) GetAddonParameters(w http.ResponseWriter, r *h
 
 	cfg := &handlers.HandlerConfig{
 		Validate: []handlers.Validate{
-			handlers.Validation("connector_cluster_id", &connectorClusterId, handlers.MinLen(1), handlers.MaxLen(maxConnectorClusterIdLength), user.AuthorizedClusterAdmin()),
+			// the addon parameters hold the credentials of the cluster, they're only given to the users that can update it
+			handlers.Validation("connector_cluster_id", &connectorClusterId, handlers.MinLen(1), handlers.MaxLen(maxConnectorClusterIdLength), user.AuthorizedClusterAdmin(auth.ActionUpdate)),
 		},
 		Action: func() (i interface{}, serviceError *errors.ServiceError) {
 
@@ -270,7 +274,7 @@ func (o *ConnectorClusterHandler) buildAddonParams(cluster *dbapi.ConnectorClust
 		},
 		{
 			Id:    "client-secret",
-			Value: cluster.ClientSecret,
+			Value: string(cluster.ClientSecret),
 		},
 	}
 	return p
@@ -281,7 +285,7 @@ func (o *ConnectorClusterHandler) buildTokenURL(cluster *dbapi.ConnectorCluster)
 	if err != nil {
 		return "", err
 	}
-	u.User = url.UserPassword(cluster.ClientId, cluster.ClientSecret)
+	u.User = url.UserPassword(cluster.ClientId, string(cluster.ClientSecret))
 	return u.String(), nil
 }
 
@@ -0,0 +1,538 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/phase"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/vault"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/gorilla/mux"
+)
+
+const (
+	// maxBulkActionConnectors is the maximum number of connectors a bulk action can be applied to at once
+	maxBulkActionConnectors = 500
+
+	bulkActionSucceeded = "succeeded"
+	bulkActionSkipped   = "skipped"
+	bulkActionFailed    = "failed"
+)
+
+var validBulkActions = []string{
+	string(public.CONNECTORBULKACTION_STOP),
+	string(public.CONNECTORBULKACTION_START),
+	string(public.CONNECTORBULKACTION_DELETE),
+	string(public.CONNECTORBULKACTION_UPGRADE_CHANNEL),
+}
+
+// ConnectorLifecycleHandler handles the connector operations that don't edit the connector configuration:
+// restart, scheduled stop windows, bulk actions and rollback to previous revisions
+type ConnectorLifecycleHandler struct {
+	connectorsService         services.ConnectorsService
+	connectorTypesService     services.ConnectorTypesService
+	namespaceService          services.ConnectorNamespaceService
+	connectorClusterService   services.ConnectorClusterService
+	connectorSchedulesService services.ConnectorSchedulesService
+	connectorRevisionsService services.ConnectorRevisionsService
+	bindingsService           services.ConnectorNamespaceRoleBindingsService
+	vaultService              vault.VaultService
+}
+
+func NewConnectorLifecycleHandler(connectorsService services.ConnectorsService, connectorTypesService services.ConnectorTypesService,
+	namespaceService services.ConnectorNamespaceService, connectorClusterService services.ConnectorClusterService,
+	connectorSchedulesService services.ConnectorSchedulesService, connectorRevisionsService services.ConnectorRevisionsService,
+	bindingsService services.ConnectorNamespaceRoleBindingsService, vaultService vault.VaultService) *ConnectorLifecycleHandler {
+	return &ConnectorLifecycleHandler{
+		connectorsService:         connectorsService,
+		connectorTypesService:     connectorTypesService,
+		namespaceService:          namespaceService,
+		connectorClusterService:   connectorClusterService,
+		connectorSchedulesService: connectorSchedulesService,
+		connectorRevisionsService: connectorRevisionsService,
+		bindingsService:           bindingsService,
+		vaultService:              vaultService,
+	}
+}
+
+// Restart asks the agent to restart a running connector without changing its configuration
+func (h ConnectorLifecycleHandler) Restart(w http.ResponseWriter, r *http.Request) {
+	connectorId := mux.Vars(r)["connector_id"]
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("connector_id", &connectorId, handlers.MinLen(1), handlers.MaxLen(maxConnectorIdLength)),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			resource, err := h.connectorsService.Get(ctx, connectorId)
+			if err != nil {
+				return nil, err
+			}
+			if err := h.bindingsService.CheckConnectorRole(ctx, &resource.Connector, dbapi.ConnectorNamespaceRoleEditor); err != nil {
+				return nil, err
+			}
+			if resource.NamespaceId == nil || resource.DesiredState != dbapi.ConnectorReady {
+				return nil, errors.BadRequest("connector %s must be assigned to a namespace and in desired state %s to be restarted",
+					connectorId, dbapi.ConnectorReady)
+			}
+
+			if err := h.connectorsService.Restart(ctx, connectorId); err != nil {
+				return nil, err
+			}
+
+			// read it back to get the updated version
+			resource, err = h.connectorsService.Get(ctx, connectorId)
+			if err != nil {
+				return nil, err
+			}
+			ct, err := h.connectorTypesService.Get(resource.ConnectorTypeId)
+			if err != nil {
+				return nil, errors.BadRequest("invalid connector type id: %s", resource.ConnectorTypeId)
+			}
+			if err := stripSecretReferences(&resource.Connector, ct); err != nil {
+				return nil, err
+			}
+
+			return presenters.PresentConnectorWithError(resource)
+		},
+	}
+
+	// return 202 status accepted
+	handlers.Handle(w, r, cfg, http.StatusAccepted)
+}
+
+func (h ConnectorLifecycleHandler) ListSchedules(w http.ResponseWriter, r *http.Request) {
+	connectorId := mux.Vars(r)["connector_id"]
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("connector_id", &connectorId, handlers.MinLen(1), handlers.MaxLen(maxConnectorIdLength)),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			if _, err := h.connectorsService.Get(ctx, connectorId); err != nil {
+				return nil, err
+			}
+
+			schedules, err := h.connectorSchedulesService.List(ctx, connectorId)
+			if err != nil {
+				return nil, err
+			}
+
+			resourceList := public.ConnectorScheduleList{
+				Kind:  "ConnectorScheduleList",
+				Page:  1,
+				Size:  int32(len(schedules)),
+				Total: int32(len(schedules)),
+				Items: []public.ConnectorSchedule{},
+			}
+			for _, schedule := range schedules {
+				resourceList.Items = append(resourceList.Items, presenters.PresentConnectorSchedule(schedule))
+			}
+
+			return resourceList, nil
+		},
+	}
+
+	handlers.HandleList(w, r, cfg)
+}
+
+// CreateSchedule creates a window during which the connector is stopped
+func (h ConnectorLifecycleHandler) CreateSchedule(w http.ResponseWriter, r *http.Request) {
+	connectorId := mux.Vars(r)["connector_id"]
+	var resource public.ConnectorScheduleRequest
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &resource,
+		Validate: []handlers.Validate{
+			handlers.Validation("connector_id", &connectorId, handlers.MinLen(1), handlers.MaxLen(maxConnectorIdLength)),
+			validateConnectorScheduleRequest(&resource),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			connector, err := h.connectorsService.Get(ctx, connectorId)
+			if err != nil {
+				return nil, err
+			}
+			if err := h.bindingsService.CheckConnectorRole(ctx, &connector.Connector, dbapi.ConnectorNamespaceRoleEditor); err != nil {
+				return nil, err
+			}
+			if connector.DesiredState == dbapi.ConnectorDeleted {
+				return nil, errors.BadRequest("connector %s is being deleted", connectorId)
+			}
+
+			schedule := presenters.ConvertConnectorScheduleRequest(connectorId, resource)
+			if err := h.connectorSchedulesService.Create(ctx, schedule); err != nil {
+				return nil, err
+			}
+
+			return presenters.PresentConnectorSchedule(schedule), nil
+		},
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusCreated)
+}
+
+func (h ConnectorLifecycleHandler) DeleteSchedule(w http.ResponseWriter, r *http.Request) {
+	connectorId := mux.Vars(r)["connector_id"]
+	scheduleId := mux.Vars(r)["schedule_id"]
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("connector_id", &connectorId, handlers.MinLen(1), handlers.MaxLen(maxConnectorIdLength)),
+			handlers.Validation("schedule_id", &scheduleId, handlers.MinLen(1), handlers.MaxLen(maxConnectorIdLength)),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			connector, err := h.connectorsService.Get(ctx, connectorId)
+			if err != nil {
+				return nil, err
+			}
+			if err := h.bindingsService.CheckConnectorRole(ctx, &connector.Connector, dbapi.ConnectorNamespaceRoleEditor); err != nil {
+				return nil, err
+			}
+			return nil, h.connectorSchedulesService.Delete(ctx, connectorId, scheduleId)
+		},
+	}
+
+	handlers.HandleDelete(w, r, cfg, http.StatusNoContent)
+}
+
+// BulkAction applies a stop, start, delete or channel upgrade to all the connectors matched by a search query
+func (h ConnectorLifecycleHandler) BulkAction(w http.ResponseWriter, r *http.Request) {
+	var resource public.ConnectorBulkActionRequest
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &resource,
+		Validate: []handlers.Validate{
+			handlers.Validation("action", (*string)(&resource.Action), handlers.IsOneOf(validBulkActions...)),
+			handlers.Validation("search", &resource.Search, handlers.MinLen(1)),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			listArgs := coreServices.NewListArguments(url.Values{})
+			listArgs.Search = resource.Search
+			listArgs.Size = maxBulkActionConnectors
+			connectors, paging, err := h.connectorsService.List(ctx, listArgs, "")
+			if err != nil {
+				return nil, err
+			}
+			if paging.Total > maxBulkActionConnectors {
+				return nil, errors.BadRequest("search matches %d connectors, a bulk action can be applied to at most %d connectors",
+					paging.Total, maxBulkActionConnectors)
+			}
+
+			result := public.ConnectorBulkActionResult{
+				Kind:   "ConnectorBulkActionResult",
+				Action: resource.Action,
+				Total:  int32(len(connectors)),
+				Items:  []public.ConnectorBulkActionResultItem{},
+			}
+			for _, connector := range connectors {
+				item := public.ConnectorBulkActionResultItem{
+					ConnectorId: connector.ID,
+				}
+				applied, err := h.applyBulkAction(ctx, resource.Action, &connector.Connector)
+				switch {
+				case err != nil:
+					item.Result = bulkActionFailed
+					item.Error = err.Reason
+					result.Failed++
+				case applied:
+					item.Result = bulkActionSucceeded
+					result.Succeeded++
+				default:
+					item.Result = bulkActionSkipped
+					result.Skipped++
+				}
+				result.Items = append(result.Items, item)
+			}
+
+			return result, nil
+		},
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
+
+// applyBulkAction applies the action to the connector, it returns false if the action didn't need to be applied
+func (h ConnectorLifecycleHandler) applyBulkAction(ctx context.Context, action public.ConnectorBulkAction, connector *dbapi.Connector) (bool, *errors.ServiceError) {
+	// the search matches all the visible connectors, including connectors in namespaces the user can only view
+	if err := h.bindingsService.CheckConnectorRole(ctx, connector, dbapi.ConnectorNamespaceRoleEditor); err != nil {
+		return false, err
+	}
+	switch action {
+	case public.CONNECTORBULKACTION_STOP:
+		return h.performConnectorOperation(ctx, connector, phase.StopConnector)
+	case public.CONNECTORBULKACTION_START:
+		return h.performConnectorOperation(ctx, connector, phase.RestartConnector)
+	case public.CONNECTORBULKACTION_DELETE:
+		if connector.DesiredState == dbapi.ConnectorDeleted {
+			return false, nil
+		}
+		if err := HandleConnectorDelete(ctx, h.connectorsService, h.namespaceService, connector.ID); err != nil {
+			return false, err
+		}
+		return true, nil
+	case public.CONNECTORBULKACTION_UPGRADE_CHANNEL:
+		return h.upgradeConnectorChannel(ctx, connector)
+	default:
+		return false, errors.BadRequest("unsupported bulk action %s", action)
+	}
+}
+
+func (h ConnectorLifecycleHandler) performConnectorOperation(ctx context.Context, connector *dbapi.Connector, operation phase.ConnectorOperation) (bool, *errors.ServiceError) {
+	if connector.NamespaceId == nil {
+		return false, errors.BadRequest("connector %s is not assigned to a namespace", connector.ID)
+	}
+	namespace, err := h.namespaceService.Get(ctx, *connector.NamespaceId)
+	if err != nil {
+		return false, err
+	}
+	return phase.PerformConnectorOperation(namespace, connector, operation, func(connector *dbapi.Connector) *errors.ServiceError {
+		if err := h.connectorsService.SaveStatus(ctx, connector.Status); err != nil {
+			return err
+		}
+		return h.connectorsService.Update(ctx, connector)
+	})
+}
+
+// upgradeConnectorChannel moves the deployment of the connector to the latest shard metadata revision of its channel
+func (h ConnectorLifecycleHandler) upgradeConnectorChannel(ctx context.Context, connector *dbapi.Connector) (bool, *errors.ServiceError) {
+	deployment, err := h.connectorClusterService.GetDeploymentByConnectorId(ctx, connector.ID)
+	if err != nil {
+		if err.Is404() {
+			// not deployed yet, it will be deployed with the latest revision
+			return false, nil
+		}
+		return false, err
+	}
+	if deployment.ConnectorShardMetadata.LatestRevision == nil {
+		return false, nil
+	}
+
+	latest, err := h.connectorTypesService.GetLatestConnectorShardMetadata(deployment.ConnectorShardMetadata.ConnectorTypeId, deployment.ConnectorShardMetadata.Channel)
+	if err != nil {
+		return false, err
+	}
+	if err := h.connectorClusterService.UpdateDeployment(&dbapi.ConnectorDeployment{
+		Model: db.Model{
+			ID: deployment.ID,
+		},
+		ConnectorShardMetadataID: latest.ID,
+	}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (h ConnectorLifecycleHandler) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	connectorId := mux.Vars(r)["connector_id"]
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("connector_id", &connectorId, handlers.MinLen(1), handlers.MaxLen(maxConnectorIdLength)),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			if _, err := h.connectorsService.Get(ctx, connectorId); err != nil {
+				return nil, err
+			}
+
+			revisions, err := h.connectorRevisionsService.List(ctx, connectorId)
+			if err != nil {
+				return nil, err
+			}
+
+			resourceList := public.ConnectorRevisionList{
+				Kind:  "ConnectorRevisionList",
+				Page:  1,
+				Size:  int32(len(revisions)),
+				Total: int32(len(revisions)),
+				Items: []public.ConnectorRevision{},
+			}
+			for _, revision := range revisions {
+				item, err := h.presentRevision(revision)
+				if err != nil {
+					return nil, err
+				}
+				resourceList.Items = append(resourceList.Items, item)
+			}
+
+			return resourceList, nil
+		},
+	}
+
+	handlers.HandleList(w, r, cfg)
+}
+
+func (h ConnectorLifecycleHandler) GetRevision(w http.ResponseWriter, r *http.Request) {
+	connectorId := mux.Vars(r)["connector_id"]
+	revisionParam := mux.Vars(r)["revision"]
+	var revisionNumber int64
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("connector_id", &connectorId, handlers.MinLen(1), handlers.MaxLen(maxConnectorIdLength)),
+			validateRevision(revisionParam, &revisionNumber),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			if _, err := h.connectorsService.Get(ctx, connectorId); err != nil {
+				return nil, err
+			}
+
+			revision, err := h.connectorRevisionsService.Get(ctx, connectorId, revisionNumber)
+			if err != nil {
+				return nil, err
+			}
+			return h.presentRevision(revision)
+		},
+	}
+
+	handlers.HandleGet(w, r, cfg)
+}
+
+// Rollback restores the configuration of a connector, and the shard metadata of its deployment, from a previous revision.
+// The restored configuration is recorded as a new revision when it's deployed.
+func (h ConnectorLifecycleHandler) Rollback(w http.ResponseWriter, r *http.Request) {
+	connectorId := mux.Vars(r)["connector_id"]
+	revisionParam := r.URL.Query().Get("revision")
+	var revisionNumber int64
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("connector_id", &connectorId, handlers.MinLen(1), handlers.MaxLen(maxConnectorIdLength)),
+			validateRevision(revisionParam, &revisionNumber),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			resource, err := h.connectorsService.Get(ctx, connectorId)
+			if err != nil {
+				return nil, err
+			}
+			if err := h.bindingsService.CheckConnectorRole(ctx, &resource.Connector, dbapi.ConnectorNamespaceRoleEditor); err != nil {
+				return nil, err
+			}
+			if resource.DesiredState == dbapi.ConnectorDeleted {
+				return nil, errors.BadRequest("connector %s is being deleted", connectorId)
+			}
+
+			revision, err := h.connectorRevisionsService.Get(ctx, connectorId, revisionNumber)
+			if err != nil {
+				return nil, err
+			}
+			if revision.ConnectorTypeId != resource.ConnectorTypeId {
+				return nil, errors.BadRequest("revision %d of connector %s has a different connector type %s",
+					revisionNumber, connectorId, revision.ConnectorTypeId)
+			}
+			ct, err := h.connectorTypesService.Get(resource.ConnectorTypeId)
+			if err != nil {
+				return nil, errors.BadRequest("invalid connector type id: %s", resource.ConnectorTypeId)
+			}
+
+			connector := &resource.Connector
+			if err := ValidateConnectorOperation(ctx, h.namespaceService, connector, phase.UpdateConnector,
+				func(connector *dbapi.Connector) *errors.ServiceError {
+					return nil
+				}); err != nil {
+				return nil, err
+			}
+
+			// the replaced configuration may never have been deployed, and not be recorded in any revision
+			originalSecrets, gerr := services.GetConnectorSecretRefs(connector, ct)
+			if gerr != nil {
+				return nil, errors.GeneralError("could not get existing secrets: %v", gerr)
+			}
+
+			connector.ConnectorSpec = revision.ConnectorSpec
+			connector.Channel = revision.Channel
+			connector.Kafka = revision.Kafka
+			connector.SchemaRegistry = revision.SchemaRegistry
+			connector.ServiceAccount = dbapi.ServiceAccount{
+				ClientId:        revision.ServiceAccount.ClientId,
+				ClientSecretRef: revision.ServiceAccount.ClientSecretRef,
+			}
+
+			// update connector phase as for any other update
+			if connector.Status.Phase != dbapi.ConnectorStatusPhaseAssigning {
+				connector.Status.Phase = phase.ConnectorStartingPhase[phase.UpdateConnector]
+				if err := h.connectorsService.SaveStatus(ctx, connector.Status); err != nil {
+					return nil, err
+				}
+			}
+			if err := h.connectorsService.Update(ctx, connector); err != nil {
+				return nil, err
+			}
+
+			// secrets of the revisions are kept to be able to rollback again
+			revisionSecrets, err := h.connectorRevisionsService.SecretRefs(ctx, connectorId)
+			if err != nil {
+				return nil, err
+			}
+			staleSecrets := StringListSubtract(originalSecrets, revisionSecrets...)
+			if len(staleSecrets) > 0 {
+				_ = db.AddPostCommitAction(ctx, func() {
+					for _, s := range staleSecrets {
+						if err := h.vaultService.DeleteSecretString(s); err != nil {
+							logger.Logger.Errorf("failed to delete vault secret key '%s': %v", s, err)
+						}
+					}
+				})
+			}
+
+			// deploy the revision with the connector shard metadata it was deployed with
+			if revision.ConnectorShardMetadataID != 0 {
+				deployment, err := h.connectorClusterService.GetDeploymentByConnectorId(ctx, connectorId)
+				if err != nil && !err.Is404() {
+					return nil, err
+				}
+				if err == nil && deployment.ConnectorShardMetadataID != revision.ConnectorShardMetadataID {
+					if err := h.connectorClusterService.UpdateDeployment(&dbapi.ConnectorDeployment{
+						Model: db.Model{
+							ID: deployment.ID,
+						},
+						ConnectorShardMetadataID: revision.ConnectorShardMetadataID,
+					}); err != nil {
+						return nil, err
+					}
+				}
+			}
+
+			// read it back to get the updated version
+			resource, err = h.connectorsService.Get(ctx, connectorId)
+			if err != nil {
+				return nil, err
+			}
+			if err := stripSecretReferences(&resource.Connector, ct); err != nil {
+				return nil, err
+			}
+
+			return presenters.PresentConnectorWithError(resource)
+		},
+	}
+
+	// return 202 status accepted
+	handlers.Handle(w, r, cfg, http.StatusAccepted)
+}
+
+// presentRevision presents a revision without the references to its secrets
+func (h ConnectorLifecycleHandler) presentRevision(revision *dbapi.ConnectorRevision) (public.ConnectorRevision, *errors.ServiceError) {
+	ct, err := h.connectorTypesService.Get(revision.ConnectorTypeId)
+	if err != nil {
+		return public.ConnectorRevision{}, errors.GeneralError("invalid connector type id: %s", revision.ConnectorTypeId)
+	}
+	connector := dbapi.Connector{
+		ConnectorSpec:  revision.ConnectorSpec,
+		ServiceAccount: revision.ServiceAccount,
+	}
+	if err := stripSecretReferences(&connector, ct); err != nil {
+		return public.ConnectorRevision{}, err
+	}
+	stripped := *revision
+	stripped.ConnectorSpec = connector.ConnectorSpec
+	stripped.ServiceAccount = connector.ServiceAccount
+	return presenters.PresentConnectorRevision(&stripped)
+}
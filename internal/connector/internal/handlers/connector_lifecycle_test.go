@@ -0,0 +1,418 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/vault"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/gorilla/mux"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func testConnector(id string, desiredState dbapi.ConnectorDesiredState) *dbapi.ConnectorWithConditions {
+	namespaceId := "namespace"
+	connector := &dbapi.ConnectorWithConditions{}
+	connector.ID = id
+	connector.NamespaceId = &namespaceId
+	connector.ConnectorTypeId = "connector-type"
+	connector.DesiredState = desiredState
+	connector.Status.Phase = dbapi.ConnectorStatusPhaseReady
+	return connector
+}
+
+func testNamespaceService() *services.ConnectorNamespaceServiceMock {
+	return &services.ConnectorNamespaceServiceMock{
+		GetFunc: func(ctx context.Context, namespaceID string) (*dbapi.ConnectorNamespace, *errors.ServiceError) {
+			namespace := &dbapi.ConnectorNamespace{}
+			namespace.ID = namespaceID
+			namespace.Status.Phase = dbapi.ConnectorNamespacePhaseReady
+			return namespace, nil
+		},
+	}
+}
+
+func testBindingsService(forbidden ...string) *services.ConnectorNamespaceRoleBindingsServiceMock {
+	return &services.ConnectorNamespaceRoleBindingsServiceMock{
+		CheckConnectorRoleFunc: func(ctx context.Context, connector *dbapi.Connector, role dbapi.ConnectorNamespaceRole) *errors.ServiceError {
+			for _, id := range forbidden {
+				if connector.ID == id {
+					return errors.Forbidden("user is not an editor of the namespace of connector %s", id)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func Test_ConnectorLifecycleHandler_BulkAction(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		connectors     dbapi.ConnectorWithConditionsList
+		total          int
+		forbidden      []string
+		wantStatusCode int
+		wantResult     map[string]string
+		wantUpdated    []string
+	}{
+		{
+			name: "should stop the running connectors and skip the stopped connectors",
+			body: `{"action": "stop", "search": "name like 'nightly%'"}`,
+			connectors: dbapi.ConnectorWithConditionsList{
+				testConnector("ready", dbapi.ConnectorReady),
+				testConnector("stopped", dbapi.ConnectorStopped),
+			},
+			wantStatusCode: http.StatusOK,
+			wantResult:     map[string]string{"ready": bulkActionSucceeded, "stopped": bulkActionSkipped},
+			wantUpdated:    []string{"ready"},
+		},
+		{
+			name: "should start the stopped connectors",
+			body: `{"action": "start", "search": "name like 'nightly%'"}`,
+			connectors: dbapi.ConnectorWithConditionsList{
+				testConnector("stopped", dbapi.ConnectorStopped),
+			},
+			wantStatusCode: http.StatusOK,
+			wantResult:     map[string]string{"stopped": bulkActionSucceeded},
+			wantUpdated:    []string{"stopped"},
+		},
+		{
+			name: "should fail the connectors the user can't edit without failing the others",
+			body: `{"action": "stop", "search": "name like 'nightly%'"}`,
+			connectors: dbapi.ConnectorWithConditionsList{
+				testConnector("ready", dbapi.ConnectorReady),
+				testConnector("viewer-only", dbapi.ConnectorReady),
+			},
+			forbidden:      []string{"viewer-only"},
+			wantStatusCode: http.StatusOK,
+			wantResult:     map[string]string{"ready": bulkActionSucceeded, "viewer-only": bulkActionFailed},
+			wantUpdated:    []string{"ready"},
+		},
+		{
+			name:           "should reject a search matching too many connectors",
+			body:           `{"action": "stop", "search": "name like '%'"}`,
+			total:          maxBulkActionConnectors + 1,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should reject an unknown action",
+			body:           `{"action": "pause", "search": "name like 'nightly%'"}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should require a search",
+			body:           `{"action": "stop"}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			var updated []string
+			connectorsService := &services.ConnectorsServiceMock{
+				ListFunc: func(ctx context.Context, listArgs *coreServices.ListArguments, clusterId string) (dbapi.ConnectorWithConditionsList, *api.PagingMeta, *errors.ServiceError) {
+					total := tt.total
+					if total == 0 {
+						total = len(tt.connectors)
+					}
+					return tt.connectors, &api.PagingMeta{Page: 1, Size: len(tt.connectors), Total: total}, nil
+				},
+				SaveStatusFunc: func(ctx context.Context, resource dbapi.ConnectorStatus) *errors.ServiceError {
+					return nil
+				},
+				UpdateFunc: func(ctx context.Context, resource *dbapi.Connector) *errors.ServiceError {
+					updated = append(updated, resource.ID)
+					return nil
+				},
+			}
+			handler := NewConnectorLifecycleHandler(connectorsService, nil, testNamespaceService(), nil, nil, nil, testBindingsService(tt.forbidden...), nil)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/connector_mgmt/v1/kafka_connectors/bulk", strings.NewReader(tt.body))
+			rw := httptest.NewRecorder()
+			handler.BulkAction(rw, req)
+
+			g.Expect(rw.Code).To(gomega.Equal(tt.wantStatusCode))
+			g.Expect(updated).To(gomega.Equal(tt.wantUpdated))
+			if tt.wantStatusCode != http.StatusOK {
+				return
+			}
+			var result public.ConnectorBulkActionResult
+			g.Expect(json.Unmarshal(rw.Body.Bytes(), &result)).To(gomega.Succeed())
+			g.Expect(result.Total).To(gomega.Equal(int32(len(tt.connectors))))
+			results := map[string]string{}
+			for _, item := range result.Items {
+				results[item.ConnectorId] = item.Result
+			}
+			g.Expect(results).To(gomega.Equal(tt.wantResult))
+		})
+	}
+}
+
+func Test_ConnectorLifecycleHandler_Restart(t *testing.T) {
+	tests := []struct {
+		name           string
+		desiredState   dbapi.ConnectorDesiredState
+		forbidden      bool
+		wantStatusCode int
+	}{
+		{
+			name:           "should restart a running connector",
+			desiredState:   dbapi.ConnectorReady,
+			wantStatusCode: http.StatusAccepted,
+		},
+		{
+			name:           "should not restart a stopped connector",
+			desiredState:   dbapi.ConnectorStopped,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should only let the namespace editors restart a connector",
+			desiredState:   dbapi.ConnectorReady,
+			forbidden:      true,
+			wantStatusCode: http.StatusForbidden,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			connectorsService := &services.ConnectorsServiceMock{
+				GetFunc: func(ctx context.Context, id string) (*dbapi.ConnectorWithConditions, *errors.ServiceError) {
+					return testConnector(id, tt.desiredState), nil
+				},
+				RestartFunc: func(ctx context.Context, id string) *errors.ServiceError {
+					return nil
+				},
+			}
+			connectorTypesService := &services.ConnectorTypesServiceMock{
+				GetFunc: func(id string) (*dbapi.ConnectorType, *errors.ServiceError) {
+					return &dbapi.ConnectorType{JsonSchema: api.JSON(`{}`)}, nil
+				},
+			}
+			var forbidden []string
+			if tt.forbidden {
+				forbidden = []string{"connector"}
+			}
+			handler := NewConnectorLifecycleHandler(connectorsService, connectorTypesService, nil, nil, nil, nil, testBindingsService(forbidden...), nil)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/connector_mgmt/v1/kafka_connectors/connector/restart", nil)
+			req = mux.SetURLVars(req, map[string]string{"connector_id": "connector"})
+			rw := httptest.NewRecorder()
+			handler.Restart(rw, req)
+
+			g.Expect(rw.Code).To(gomega.Equal(tt.wantStatusCode))
+			g.Expect(len(connectorsService.RestartCalls()) == 1).To(gomega.Equal(tt.wantStatusCode == http.StatusAccepted))
+		})
+	}
+}
+
+func Test_ConnectorLifecycleHandler_CreateSchedule(t *testing.T) {
+	now := time.Now()
+	window := func(stopAt time.Time, startAt time.Time) string {
+		return fmt.Sprintf(`{"stop_at": "%s", "start_at": "%s"}`, stopAt.Format(time.RFC3339), startAt.Format(time.RFC3339))
+	}
+
+	tests := []struct {
+		name           string
+		body           string
+		desiredState   dbapi.ConnectorDesiredState
+		wantStatusCode int
+	}{
+		{
+			name:           "should create a window",
+			body:           window(now.Add(time.Hour), now.Add(2*time.Hour)),
+			desiredState:   dbapi.ConnectorReady,
+			wantStatusCode: http.StatusCreated,
+		},
+		{
+			name:           "should reject a window ending before it starts",
+			body:           window(now.Add(2*time.Hour), now.Add(time.Hour)),
+			desiredState:   dbapi.ConnectorReady,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should reject a window ending in the past",
+			body:           window(now.Add(-2*time.Hour), now.Add(-time.Hour)),
+			desiredState:   dbapi.ConnectorReady,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should reject a window of a connector being deleted",
+			body:           window(now.Add(time.Hour), now.Add(2*time.Hour)),
+			desiredState:   dbapi.ConnectorDeleted,
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			connectorsService := &services.ConnectorsServiceMock{
+				GetFunc: func(ctx context.Context, id string) (*dbapi.ConnectorWithConditions, *errors.ServiceError) {
+					return testConnector(id, tt.desiredState), nil
+				},
+			}
+			schedulesService := &services.ConnectorSchedulesServiceMock{
+				CreateFunc: func(ctx context.Context, schedule *dbapi.ConnectorSchedule) *errors.ServiceError {
+					g.Expect(schedule.ConnectorID).To(gomega.Equal("connector"))
+					schedule.ID = "schedule"
+					return nil
+				},
+			}
+			handler := NewConnectorLifecycleHandler(connectorsService, nil, nil, nil, schedulesService, nil, testBindingsService(), nil)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/connector_mgmt/v1/kafka_connectors/connector/schedules", strings.NewReader(tt.body))
+			req = mux.SetURLVars(req, map[string]string{"connector_id": "connector"})
+			rw := httptest.NewRecorder()
+			handler.CreateSchedule(rw, req)
+
+			g.Expect(rw.Code).To(gomega.Equal(tt.wantStatusCode))
+			g.Expect(len(schedulesService.CreateCalls()) == 1).To(gomega.Equal(tt.wantStatusCode == http.StatusCreated))
+		})
+	}
+}
+
+func Test_ConnectorLifecycleHandler_Rollback(t *testing.T) {
+	tests := []struct {
+		name             string
+		revisionSecrets  []string
+		shardMetadataID  int64
+		wantStatusCode   int
+		wantSecrets      []string
+		wantDeploymentID int64
+	}{
+		{
+			name:            "should delete the secrets of a configuration that was never deployed",
+			revisionSecrets: []string{"revision-sa"},
+			wantStatusCode:  http.StatusAccepted,
+			wantSecrets:     []string{"revision-sa"},
+		},
+		{
+			name:            "should keep the secrets of a configuration recorded in a revision",
+			revisionSecrets: []string{"revision-sa", "current-sa"},
+			wantStatusCode:  http.StatusAccepted,
+			wantSecrets:     []string{"revision-sa", "current-sa"},
+		},
+		{
+			name:             "should deploy the revision with its shard metadata",
+			revisionSecrets:  []string{"revision-sa", "current-sa"},
+			shardMetadataID:  2,
+			wantStatusCode:   http.StatusAccepted,
+			wantSecrets:      []string{"revision-sa", "current-sa"},
+			wantDeploymentID: 2,
+		},
+		{
+			name:           "should reject a revision that doesn't exist",
+			wantStatusCode: http.StatusNotFound,
+			wantSecrets:    []string{"revision-sa", "current-sa"},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().WithQuery(`select txid_current()`).WithReply([]map[string]interface{}{{"txid_current": 1}})
+
+			vaultService, err := vault.NewTmpVaultService()
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(vaultService.SetSecretString("revision-sa", "value", "connector")).To(gomega.Succeed())
+			g.Expect(vaultService.SetSecretString("current-sa", "value", "connector")).To(gomega.Succeed())
+
+			var updated *dbapi.Connector
+			connectorsService := &services.ConnectorsServiceMock{
+				GetFunc: func(ctx context.Context, id string) (*dbapi.ConnectorWithConditions, *errors.ServiceError) {
+					connector := testConnector(id, dbapi.ConnectorReady)
+					connector.ServiceAccount = dbapi.ServiceAccount{ClientId: "client", ClientSecretRef: "current-sa"}
+					return connector, nil
+				},
+				SaveStatusFunc: func(ctx context.Context, resource dbapi.ConnectorStatus) *errors.ServiceError {
+					return nil
+				},
+				UpdateFunc: func(ctx context.Context, resource *dbapi.Connector) *errors.ServiceError {
+					updated = resource
+					return nil
+				},
+			}
+			connectorTypesService := &services.ConnectorTypesServiceMock{
+				GetFunc: func(id string) (*dbapi.ConnectorType, *errors.ServiceError) {
+					return &dbapi.ConnectorType{JsonSchema: api.JSON(`{}`)}, nil
+				},
+			}
+			revisionsService := &services.ConnectorRevisionsServiceMock{
+				GetFunc: func(ctx context.Context, connectorID string, revision int64) (*dbapi.ConnectorRevision, *errors.ServiceError) {
+					if tt.revisionSecrets == nil {
+						return nil, errors.NotFound("revision %d of connector %s not found", revision, connectorID)
+					}
+					return &dbapi.ConnectorRevision{
+						ConnectorID:              connectorID,
+						Revision:                 revision,
+						ConnectorTypeId:          "connector-type",
+						ConnectorShardMetadataID: tt.shardMetadataID,
+						ServiceAccount:           dbapi.ServiceAccount{ClientId: "client", ClientSecretRef: "revision-sa"},
+					}, nil
+				},
+				SecretRefsFunc: func(ctx context.Context, connectorID string) ([]string, *errors.ServiceError) {
+					return tt.revisionSecrets, nil
+				},
+			}
+			var deployment *dbapi.ConnectorDeployment
+			clusterService := &services.ConnectorClusterServiceMock{
+				GetDeploymentByConnectorIdFunc: func(ctx context.Context, connectorID string) (dbapi.ConnectorDeployment, *errors.ServiceError) {
+					return dbapi.ConnectorDeployment{Model: db.Model{ID: "deployment"}, ConnectorShardMetadataID: 3}, nil
+				},
+				UpdateDeploymentFunc: func(resource *dbapi.ConnectorDeployment) *errors.ServiceError {
+					deployment = resource
+					return nil
+				},
+			}
+			handler := NewConnectorLifecycleHandler(connectorsService, connectorTypesService, testNamespaceService(), clusterService,
+				nil, revisionsService, testBindingsService(), vaultService)
+
+			ctx, err := db.NewMockConnectionFactory(nil).NewContext(context.Background())
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			req := httptest.NewRequest(http.MethodPost, "/api/connector_mgmt/v1/kafka_connectors/connector/rollback?revision=1", nil)
+			req = mux.SetURLVars(req.WithContext(ctx), map[string]string{"connector_id": "connector"})
+			rw := httptest.NewRecorder()
+			handler.Rollback(rw, req)
+			g.Expect(db.Resolve(ctx)).To(gomega.Succeed())
+
+			g.Expect(rw.Code).To(gomega.Equal(tt.wantStatusCode))
+			var remaining []string
+			g.Expect(vaultService.ForEachSecret(func(name string, owningResource string) bool {
+				remaining = append(remaining, name)
+				return true
+			})).To(gomega.Succeed())
+			g.Expect(remaining).To(gomega.ConsistOf(tt.wantSecrets))
+			if tt.wantStatusCode != http.StatusAccepted {
+				g.Expect(updated).To(gomega.BeNil())
+				return
+			}
+			g.Expect(updated.ServiceAccount.ClientSecretRef).To(gomega.Equal("revision-sa"))
+			if tt.wantDeploymentID == 0 {
+				g.Expect(deployment).To(gomega.BeNil())
+				return
+			}
+			g.Expect(deployment.ConnectorShardMetadataID).To(gomega.Equal(tt.wantDeploymentID))
+		})
+	}
+}
@@ -32,10 +32,11 @@ func init() {
 
 type ConnectorNamespaceHandler struct {
 	di.Inject
-	Bus          signalbus.SignalBus
-	Service      services.ConnectorNamespaceService
-	AuthZService authz.AuthZService
-	QuotaConfig  *config.ConnectorsQuotaConfig
+	Bus             signalbus.SignalBus
+	Service         services.ConnectorNamespaceService
+	AuthZService    authz.AuthZService
+	QuotaConfig     *config.ConnectorsQuotaConfig
+	BindingsService services.ConnectorNamespaceRoleBindingsService
 }
 
 func NewConnectorNamespaceHandler(handler ConnectorNamespaceHandler) *ConnectorNamespaceHandler {
@@ -67,10 +68,13 @@ func (h *ConnectorNamespaceHandler) Create(w http.ResponseWriter, r *http.Reques
 			userID := user.UserId()
 			organisationId := user.OrgId()
 
-			// validate that it's an org admin user for organization tenant namespace
+			// validate that the user can create organization tenant namespaces, i.e. is an org admin
 			if resource.Kind == public.CONNECTORNAMESPACETENANTKIND_ORGANISATION {
-				if !user.IsOrgAdmin() {
-					return nil, errors.Unauthorized("user not authorized")
+				if err := auth.RequireAuthorization(ctx, auth.ActionCreate, auth.AuthorizationResource{
+					Type:           auth.ResourceOrganisationConnectorNamespace,
+					OrganisationId: organisationId,
+				}, errors.ErrorUnauthorized, "user not authorized"); err != nil {
+					return nil, err
 				}
 			}
 
@@ -145,7 +149,13 @@ func (h *ConnectorNamespaceHandler) Get(w http.ResponseWriter, r *http.Request)
 			if err != nil {
 				return nil, err
 			}
-			return presenters.PresentConnectorNamespace(resource, h.QuotaConfig), nil
+			usage, err := h.Service.GetNamespaceUsage(connectorNamespaceId)
+			if err != nil {
+				return nil, err
+			}
+			result := presenters.PresentConnectorNamespace(resource, h.QuotaConfig)
+			result.Usage = presenters.PresentConnectorNamespaceUsage(usage)
+			return result, nil
 		},
 	}
 	handlers.HandleGet(w, r, cfg)
@@ -223,20 +233,7 @@ func (h *ConnectorNamespaceHandler) List(w http.ResponseWriter, r *http.Request)
 			ctx := r.Context()
 			listArgs := coreservices.NewListArguments(r.URL.Query())
 
-			user, err := h.AuthZService.GetUser(ctx)
-			if err != nil {
-				return nil, err
-			}
-			userId := user.UserId()
-			organisationId := user.OrgId()
-			userQuery := fmt.Sprintf("tenant_user_id = '%s' OR tenant_organisation_id = '%s'", userId, organisationId)
-			if len(listArgs.Search) == 0 {
-				listArgs.Search = userQuery
-			} else {
-				listArgs.Search = fmt.Sprintf("%s AND (%s)", listArgs.Search, userQuery)
-			}
-
-			resources, paging, serviceError := h.Service.List(ctx, nil, listArgs, 0)
+			resources, paging, serviceError := h.Service.ListForUser(ctx, listArgs)
 			if serviceError != nil {
 				return nil, serviceError
 			}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	"github.com/gorilla/mux"
+)
+
+const (
+	maxRoleBindingIdLength      = 32
+	maxRoleBindingSubjectLength = 255
+)
+
+// ListRoleBindings returns the role bindings of a namespace, only namespace admins can see them
+func (h *ConnectorNamespaceHandler) ListRoleBindings(w http.ResponseWriter, r *http.Request) {
+	namespaceId := mux.Vars(r)["connector_namespace_id"]
+	user := h.AuthZService.GetValidationUser(r.Context())
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("connector_namespace_id", &namespaceId,
+				handlers.MinLen(1), handlers.MaxLen(maxConnectorNamespaceIdLength), user.AuthorizedNamespaceAdmin()),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			bindings, err := h.BindingsService.List(r.Context(), namespaceId)
+			if err != nil {
+				return nil, err
+			}
+
+			resourceList := public.ConnectorNamespaceRoleBindingList{
+				Kind:  "ConnectorNamespaceRoleBindingList",
+				Page:  1,
+				Size:  int32(len(bindings)),
+				Total: int32(len(bindings)),
+				Items: make([]public.ConnectorNamespaceRoleBinding, 0, len(bindings)),
+			}
+			for _, binding := range bindings {
+				resourceList.Items = append(resourceList.Items, presenters.PresentConnectorNamespaceRoleBinding(binding))
+			}
+			return resourceList, nil
+		},
+	}
+
+	handlers.HandleList(w, r, cfg)
+}
+
+// CreateRoleBinding grants a role on a namespace to a user, a group, a service account or an organisation
+func (h *ConnectorNamespaceHandler) CreateRoleBinding(w http.ResponseWriter, r *http.Request) {
+	namespaceId := mux.Vars(r)["connector_namespace_id"]
+	user := h.AuthZService.GetValidationUser(r.Context())
+
+	var resource public.ConnectorNamespaceRoleBindingRequest
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &resource,
+		Validate: []handlers.Validate{
+			handlers.Validation("connector_namespace_id", &namespaceId,
+				handlers.MinLen(1), handlers.MaxLen(maxConnectorNamespaceIdLength), user.AuthorizedNamespaceAdmin()),
+			handlers.Validation("subject_kind", (*string)(&resource.SubjectKind), handlers.IsOneOf(dbapi.ValidConnectorNamespaceSubjectKinds...)),
+			handlers.Validation("subject", &resource.Subject, handlers.MinLen(1), handlers.MaxLen(maxRoleBindingSubjectLength)),
+			handlers.Validation("role", (*string)(&resource.Role), handlers.IsOneOf(dbapi.ValidConnectorNamespaceRoles...)),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			binding := presenters.ConvertConnectorNamespaceRoleBindingRequest(namespaceId, resource)
+			binding.CreatedBy = user.UserId()
+			if err := h.BindingsService.Create(r.Context(), binding); err != nil {
+				return nil, err
+			}
+			return presenters.PresentConnectorNamespaceRoleBinding(binding), nil
+		},
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusCreated)
+}
+
+func (h *ConnectorNamespaceHandler) GetRoleBinding(w http.ResponseWriter, r *http.Request) {
+	namespaceId := mux.Vars(r)["connector_namespace_id"]
+	bindingId := mux.Vars(r)["binding_id"]
+	user := h.AuthZService.GetValidationUser(r.Context())
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("connector_namespace_id", &namespaceId,
+				handlers.MinLen(1), handlers.MaxLen(maxConnectorNamespaceIdLength), user.AuthorizedNamespaceAdmin()),
+			handlers.Validation("binding_id", &bindingId, handlers.MinLen(1), handlers.MaxLen(maxRoleBindingIdLength)),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			binding, err := h.BindingsService.Get(r.Context(), namespaceId, bindingId)
+			if err != nil {
+				return nil, err
+			}
+			return presenters.PresentConnectorNamespaceRoleBinding(binding), nil
+		},
+	}
+
+	handlers.HandleGet(w, r, cfg)
+}
+
+// UpdateRoleBinding changes the role of a binding, the subject of a binding can't be changed
+func (h *ConnectorNamespaceHandler) UpdateRoleBinding(w http.ResponseWriter, r *http.Request) {
+	namespaceId := mux.Vars(r)["connector_namespace_id"]
+	bindingId := mux.Vars(r)["binding_id"]
+	user := h.AuthZService.GetValidationUser(r.Context())
+
+	var resource public.ConnectorNamespaceRoleBindingRequest
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &resource,
+		Validate: []handlers.Validate{
+			handlers.Validation("connector_namespace_id", &namespaceId,
+				handlers.MinLen(1), handlers.MaxLen(maxConnectorNamespaceIdLength), user.AuthorizedNamespaceAdmin()),
+			handlers.Validation("binding_id", &bindingId, handlers.MinLen(1), handlers.MaxLen(maxRoleBindingIdLength)),
+			handlers.Validation("role", (*string)(&resource.Role), handlers.IsOneOf(dbapi.ValidConnectorNamespaceRoles...)),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			binding, err := h.BindingsService.Get(r.Context(), namespaceId, bindingId)
+			if err != nil {
+				return nil, err
+			}
+			if (resource.SubjectKind != "" && string(resource.SubjectKind) != string(binding.SubjectKind)) ||
+				(resource.Subject != "" && resource.Subject != binding.Subject) {
+				return nil, errors.BadRequest("the subject of role binding %s can't be changed", bindingId)
+			}
+
+			binding.Role = dbapi.ConnectorNamespaceRole(resource.Role)
+			if err := h.BindingsService.Update(r.Context(), binding); err != nil {
+				return nil, err
+			}
+			return presenters.PresentConnectorNamespaceRoleBinding(binding), nil
+		},
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusAccepted)
+}
+
+func (h *ConnectorNamespaceHandler) DeleteRoleBinding(w http.ResponseWriter, r *http.Request) {
+	namespaceId := mux.Vars(r)["connector_namespace_id"]
+	bindingId := mux.Vars(r)["binding_id"]
+	user := h.AuthZService.GetValidationUser(r.Context())
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("connector_namespace_id", &namespaceId,
+				handlers.MinLen(1), handlers.MaxLen(maxConnectorNamespaceIdLength), user.AuthorizedNamespaceAdmin()),
+			handlers.Validation("binding_id", &bindingId, handlers.MinLen(1), handlers.MaxLen(maxRoleBindingIdLength)),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			return nil, h.BindingsService.Delete(r.Context(), namespaceId, bindingId)
+		},
+	}
+
+	handlers.HandleDelete(w, r, cfg, http.StatusNoContent)
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/authz"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/observatorium"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	"github.com/goava/di"
+	"github.com/gorilla/mux"
+)
+
+const (
+	// defaultConnectorLogLines is the number of log lines returned when the lines query parameter isn't set
+	defaultConnectorLogLines = 100
+
+	maxConnectorMetricsDuration = 4320  // minutes
+	maxConnectorMetricsInterval = 10800 // seconds
+)
+
+// ConnectorObservabilityHandler returns the metrics and the logs of connectors
+type ConnectorObservabilityHandler struct {
+	di.Inject
+	AuthZ          authz.AuthZService
+	MetricsService services.ConnectorMetricsService
+	LogsService    services.ConnectorLogsService
+}
+
+func NewConnectorObservabilityHandler(handler ConnectorObservabilityHandler) *ConnectorObservabilityHandler {
+	return &handler
+}
+
+func (h *ConnectorObservabilityHandler) GetMetricsByRangeQuery(w http.ResponseWriter, r *http.Request) {
+	connectorId := mux.Vars(r)["connector_id"]
+	query := r.URL.Query()
+	user := h.AuthZ.GetValidationUser(r.Context())
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("connector_id", &connectorId, handlers.MinLen(1), handlers.MaxLen(maxConnectorIdLength),
+				user.AuthorizedConnectorUser()),
+			validateOptionalIntQueryParam(query, "duration", 1, maxConnectorMetricsDuration),
+			validateOptionalIntQueryParam(query, "interval", 1, maxConnectorMetricsInterval),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			params := observatorium.MetricsReqParams{
+				ResultType: observatorium.RangeQuery,
+			}
+			extractConnectorMetricsQueryParams(query, &params)
+
+			metrics := &observatorium.ConnectorMetrics{}
+			if err := h.MetricsService.GetMetricsByConnectorId(r.Context(), metrics, connectorId, params); err != nil {
+				return nil, err
+			}
+			items, err := presenters.PresentConnectorMetricsByRangeQuery(metrics)
+			if err != nil {
+				return nil, err
+			}
+			return public.ConnectorMetricsRangeQueryList{
+				Kind:  "ConnectorMetricsRangeQueryList",
+				Id:    connectorId,
+				Items: items,
+			}, nil
+		},
+	}
+	handlers.HandleGet(w, r, cfg)
+}
+
+// GetLogs returns the most recent log lines of a connector
+func (h *ConnectorObservabilityHandler) GetLogs(w http.ResponseWriter, r *http.Request) {
+	connectorId := mux.Vars(r)["connector_id"]
+	query := r.URL.Query()
+	user := h.AuthZ.GetValidationUser(r.Context())
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("connector_id", &connectorId, handlers.MinLen(1), handlers.MaxLen(maxConnectorIdLength),
+				user.AuthorizedConnectorUser()),
+			validateOptionalIntQueryParam(query, "lines", 1, int64(h.LogsService.MaxLines())),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			lines := defaultConnectorLogLines
+			if value := query.Get("lines"); value != "" {
+				// already validated
+				lines, _ = strconv.Atoi(value)
+			}
+
+			logs, err := h.LogsService.Tail(r.Context(), connectorId, lines)
+			if err != nil {
+				return nil, err
+			}
+			return presenters.PresentConnectorLogLines(connectorId, logs), nil
+		},
+	}
+	handlers.HandleGet(w, r, cfg)
+}
+
+func validateOptionalIntQueryParam(queryParams url.Values, field string, min int64, max int64) handlers.Validate {
+	return func() *errors.ServiceError {
+		value := queryParams.Get(field)
+		if value == "" {
+			return nil
+		}
+		num, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || num < min || num > max {
+			return errors.FailedToParseQueryParms("bad request, query parameter '%s' must be an integer between %d and %d: '%s'", field, min, max, value)
+		}
+		return nil
+	}
+}
+
+func extractConnectorMetricsQueryParams(queryParams url.Values, q *observatorium.MetricsReqParams) {
+	q.FillDefaults()
+	if dur := queryParams.Get("duration"); dur != "" {
+		if num, err := strconv.ParseInt(dur, 10, 64); err == nil {
+			q.Start = q.End.Add(-time.Duration(num) * time.Minute)
+		}
+	}
+	if step := queryParams.Get("interval"); step != "" {
+		if num, err := strconv.Atoi(step); err == nil {
+			q.Step = time.Duration(num) * time.Second
+		}
+	}
+	if filters, ok := queryParams["filters"]; ok && len(filters) > 0 {
+		q.Filters = filters
+	}
+}
@@ -9,7 +9,7 @@ import (
 	"github.com/spyzhov/ajson"
 )
 
-const OwningResourcePrefix = "/v1/connector/"
+const OwningResourcePrefix = vault.ConnectorOwningResourcePrefix
 
 func stripSecretReferences(resource *dbapi.Connector, ct *dbapi.ConnectorType) *errors.ServiceError {
 	// clear out secrets..
@@ -90,38 +90,3 @@ func moveSecretsToVault(resource *dbapi.Connector, ct *dbapi.ConnectorType, vaul
 	}
 	return nil
 }
-
-func getSecretRefs(resource *dbapi.Connector, ct *dbapi.ConnectorType) (result []string, err error) {
-
-	if resource.ServiceAccount.ClientSecretRef != "" {
-		result = append(result, resource.ServiceAccount.ClientSecretRef)
-	}
-
-	// find the existing secrets...
-	if len(resource.ConnectorSpec) != 0 {
-		_, err = secrets.ModifySecrets(ct.JsonSchema, resource.ConnectorSpec, func(node *ajson.Node) error {
-			if node.Type() != ajson.Object {
-				return nil
-			}
-			ref, err := node.GetKey("ref")
-			if err != nil {
-				return nil
-			}
-			key, err := ref.GetString()
-			if err != nil {
-				return nil
-			}
-			result = append(result, key)
-			return nil
-		})
-		if err != nil {
-			switch err := err.(type) {
-			case *errors.ServiceError:
-				return result, err
-			default:
-				return result, errors.GeneralError("could not store connectors secrets in the vault: %v", err.Error())
-			}
-		}
-	}
-	return
-}
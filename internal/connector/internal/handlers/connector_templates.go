@@ -0,0 +1,289 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/authz"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+	"github.com/goava/di"
+	"github.com/gorilla/mux"
+)
+
+const maxConnectorTemplateIdLength = 32
+
+// ConnectorTemplatesHandler manages the organisation connector templates
+type ConnectorTemplatesHandler struct {
+	di.Inject
+	AuthZ                 authz.AuthZService
+	TemplatesService      services.ConnectorTemplatesService
+	ConnectorTypesService services.ConnectorTypesService
+}
+
+func NewConnectorTemplatesHandler(handler ConnectorTemplatesHandler) *ConnectorTemplatesHandler {
+	return &handler
+}
+
+func (h *ConnectorTemplatesHandler) Create(w http.ResponseWriter, r *http.Request) {
+	user := h.AuthZ.GetValidationUser(r.Context())
+
+	var resource public.ConnectorTemplateRequest
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &resource,
+		Validate: []handlers.Validate{
+			handlers.Validation("name", &resource.Name, handlers.MinLen(1), handlers.MaxLen(100)),
+			handlers.Validation("connector_type_id", &resource.ConnectorTypeId, handlers.MinLen(1), handlers.MaxLen(maxConnectorTypeIdLength)),
+			handlers.Validation("channel", (*string)(&resource.Channel), handlers.WithDefault("stable"), handlers.MaxLen(40)),
+			validateConnectorTemplateRequest(h.ConnectorTypesService, &resource),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			template, err := presenters.ConvertConnectorTemplateRequest(api.NewID(), resource)
+			if err != nil {
+				return nil, err
+			}
+			template.Owner = user.UserId()
+			template.OrganisationId = user.OrgId()
+
+			if err := h.TemplatesService.Create(r.Context(), template); err != nil {
+				return nil, err
+			}
+			return presenters.PresentConnectorTemplate(template)
+		},
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusCreated)
+}
+
+func (h *ConnectorTemplatesHandler) Get(w http.ResponseWriter, r *http.Request) {
+	templateId := mux.Vars(r)["template_id"]
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("template_id", &templateId, handlers.MinLen(1), handlers.MaxLen(maxConnectorTemplateIdLength)),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			template, err := h.TemplatesService.Get(r.Context(), templateId)
+			if err != nil {
+				return nil, err
+			}
+			return presenters.PresentConnectorTemplate(template)
+		},
+	}
+
+	handlers.HandleGet(w, r, cfg)
+}
+
+func (h *ConnectorTemplatesHandler) List(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			listArgs := coreServices.NewListArguments(r.URL.Query())
+			templates, paging, err := h.TemplatesService.List(r.Context(), listArgs)
+			if err != nil {
+				return nil, err
+			}
+
+			resourceList := public.ConnectorTemplateList{
+				Kind:  "ConnectorTemplateList",
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: make([]public.ConnectorTemplate, 0, len(templates)),
+			}
+			for _, template := range templates {
+				converted, err := presenters.PresentConnectorTemplate(template)
+				if err != nil {
+					return nil, err
+				}
+				resourceList.Items = append(resourceList.Items, converted)
+			}
+			return resourceList, nil
+		},
+	}
+
+	handlers.HandleList(w, r, cfg)
+}
+
+// Update replaces a template and increments its version, the connector type of a template can't be changed
+func (h *ConnectorTemplatesHandler) Update(w http.ResponseWriter, r *http.Request) {
+	templateId := mux.Vars(r)["template_id"]
+
+	var resource public.ConnectorTemplateRequest
+	var existing *dbapi.ConnectorTemplate
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &resource,
+		Validate: []handlers.Validate{
+			handlers.Validation("template_id", &templateId, handlers.MinLen(1), handlers.MaxLen(maxConnectorTemplateIdLength)),
+			func() (err *errors.ServiceError) {
+				existing, err = h.TemplatesService.Get(r.Context(), templateId)
+				return err
+			},
+			handlers.Validation("name", &resource.Name, handlers.MinLen(1), handlers.MaxLen(100)),
+			handlers.Validation("connector_type_id", &resource.ConnectorTypeId, handlers.MinLen(1), handlers.MaxLen(maxConnectorTypeIdLength)),
+			func() *errors.ServiceError {
+				if resource.ConnectorTypeId != existing.ConnectorTypeId {
+					return errors.BadRequest("connector_type_id of connector template %s can't be changed", templateId)
+				}
+				return nil
+			},
+			handlers.Validation("channel", (*string)(&resource.Channel), handlers.WithDefault("stable"), handlers.MaxLen(40)),
+			validateConnectorTemplateRequest(h.ConnectorTypesService, &resource),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			template, err := presenters.ConvertConnectorTemplateRequest(templateId, resource)
+			if err != nil {
+				return nil, err
+			}
+			template.CreatedAt = existing.CreatedAt
+			template.Owner = existing.Owner
+			template.OrganisationId = existing.OrganisationId
+			template.Version = existing.Version
+
+			if err := h.TemplatesService.Update(r.Context(), template); err != nil {
+				return nil, err
+			}
+
+			// read it back to get the update time
+			template, err = h.TemplatesService.Get(r.Context(), templateId)
+			if err != nil {
+				return nil, err
+			}
+			return presenters.PresentConnectorTemplate(template)
+		},
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusAccepted)
+}
+
+func (h *ConnectorTemplatesHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	templateId := mux.Vars(r)["template_id"]
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("template_id", &templateId, handlers.MinLen(1), handlers.MaxLen(maxConnectorTemplateIdLength)),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			return nil, h.TemplatesService.Delete(r.Context(), templateId)
+		},
+	}
+
+	handlers.HandleDelete(w, r, cfg, http.StatusNoContent)
+}
+
+// ListConnectors returns the connectors created from a template, and whether they need re-rendering
+func (h *ConnectorTemplatesHandler) ListConnectors(w http.ResponseWriter, r *http.Request) {
+	templateId := mux.Vars(r)["template_id"]
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("template_id", &templateId, handlers.MinLen(1), handlers.MaxLen(maxConnectorTemplateIdLength)),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			template, err := h.TemplatesService.Get(r.Context(), templateId)
+			if err != nil {
+				return nil, err
+			}
+			renders, err := h.TemplatesService.ListRenders(r.Context(), templateId)
+			if err != nil {
+				return nil, err
+			}
+			return presenters.PresentConnectorTemplateConnectors(template, renders)
+		},
+	}
+
+	handlers.HandleGet(w, r, cfg)
+}
+
+// validateConnectorTemplateRequest checks the template channel and parameters. The template spec is partial, so it isn't
+// validated against the connector type schema, but secret fields can only be set to a parameter without a default value,
+// so that templates never store secrets.
+func validateConnectorTemplateRequest(connectorTypesService services.ConnectorTypesService, resource *public.ConnectorTemplateRequest) handlers.Validate {
+	return func() *errors.ServiceError {
+		ct, err := connectorTypesService.Get(resource.ConnectorTypeId)
+		if err != nil {
+			return errors.BadRequest("invalid connector type id: %s", resource.ConnectorTypeId)
+		}
+		if !arrays.Contains(ct.ChannelNames(), string(resource.Channel)) {
+			return errors.BadRequest("channel is not valid. Must be one of: %s", strings.Join(ct.ChannelNames(), ", "))
+		}
+
+		parameters := make([]dbapi.ConnectorTemplateParameter, len(resource.Parameters))
+		for i, p := range resource.Parameters {
+			parameters[i] = dbapi.ConnectorTemplateParameter{
+				Name:    p.Name,
+				Type:    dbapi.ConnectorTemplateParameterType(p.Type),
+				Default: p.Default,
+			}
+		}
+		if err := services.ValidateConnectorTemplateParameters(parameters, resource.Connector); err != nil {
+			return err
+		}
+
+		if len(resource.Connector) == 0 {
+			return nil
+		}
+		spec, merr := json.Marshal(resource.Connector)
+		if merr != nil {
+			return errors.BadRequest("invalid connector spec: %v", merr)
+		}
+		secretParameters, err := services.GetConnectorTemplateSecretParameters(ct.JsonSchema, spec)
+		if err != nil {
+			return err
+		}
+		for _, p := range parameters {
+			if secretParameters[p.Name] && p.Default != nil {
+				return errors.BadRequest("parameter %q is used by a secret field and can't have a default value", p.Name)
+			}
+		}
+		return nil
+	}
+}
+
+// applyConnectorTemplate renders the template of a connector request in the request connector spec.
+// The connector spec of the request is merged over the rendered template spec, and the result is then
+// validated against the connector type schema like any other connector request.
+func applyConnectorTemplate(r *http.Request, templatesService services.ConnectorTemplatesService, resource *public.ConnectorRequest,
+	template **dbapi.ConnectorTemplate, parameters *map[string]interface{}) handlers.Validate {
+	return func() *errors.ServiceError {
+		if resource.TemplateId == "" {
+			if len(resource.TemplateParameters) != 0 {
+				return errors.BadRequest("template_parameters can only be set with a template_id")
+			}
+			return nil
+		}
+		if len(resource.TemplateId) > maxConnectorTemplateIdLength {
+			return errors.MaximumFieldLengthExceeded("template_id is not valid. Maximum length %d is required", maxConnectorTemplateIdLength)
+		}
+
+		t, err := templatesService.Get(r.Context(), resource.TemplateId)
+		if err != nil {
+			if err.Is404() {
+				return errors.BadRequest("connector template %s not found", resource.TemplateId)
+			}
+			return err
+		}
+		if resource.ConnectorTypeId == "" {
+			resource.ConnectorTypeId = t.ConnectorTypeId
+		} else if resource.ConnectorTypeId != t.ConnectorTypeId {
+			return errors.BadRequest("connector_type_id %s doesn't match the connector type %s of connector template %s",
+				resource.ConnectorTypeId, t.ConnectorTypeId, t.ID)
+		}
+		if resource.Channel == "" {
+			resource.Channel = public.Channel(t.Channel)
+		}
+
+		spec, values, err := services.RenderConnectorTemplate(t, resource.TemplateParameters, resource.Connector)
+		if err != nil {
+			return err
+		}
+		resource.Connector = spec
+		*template = t
+		*parameters = values
+		return nil
+	}
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
 	"k8s.io/apimachinery/pkg/util/validation"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
 
+	admin "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/admin/private"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/public"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
@@ -111,3 +114,59 @@ func validatePatchAnnotations(patched, existing map[string]string) handlers.Vali
 		return nil
 	}
 }
+
+func validateConnectorScheduleRequest(resource *public.ConnectorScheduleRequest) handlers.Validate {
+	return func() *errors.ServiceError {
+		if resource.StopAt.IsZero() || resource.StartAt.IsZero() {
+			return errors.BadRequest("stop_at and start_at are required")
+		}
+		if !resource.StartAt.After(resource.StopAt) {
+			return errors.BadRequest("start_at must be after stop_at")
+		}
+		if !resource.StartAt.After(time.Now()) {
+			return errors.BadRequest("start_at must be in the future")
+		}
+		return nil
+	}
+}
+
+func validateRevision(value string, revision *int64) handlers.Validate {
+	return func() *errors.ServiceError {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || n < 1 {
+			return errors.BadRequest("revision must be a positive integer, got %q", value)
+		}
+		*revision = n
+		return nil
+	}
+}
+
+func validateConnectorUpgradePolicyRequest(resource *admin.ConnectorUpgradePolicyRequest) handlers.Validate {
+	return func() *errors.ServiceError {
+		if !arrays.Contains(dbapi.ValidConnectorUpgradePolicyTypes, string(resource.Type)) {
+			return errors.BadRequest("type must be one of %v", dbapi.ValidConnectorUpgradePolicyTypes)
+		}
+		if resource.MaxConcurrentUpgrades < 0 {
+			return errors.BadRequest("max_concurrent_upgrades must not be negative")
+		}
+		if resource.Type != admin.CONNECTORUPGRADEPOLICYTYPE_MAINTENANCE_WINDOW {
+			if len(resource.WindowDays) > 0 || resource.WindowStartHour != 0 || resource.WindowDurationHours != 0 {
+				return errors.BadRequest("window_days, window_start_hour and window_duration_hours are only valid for type %s",
+					dbapi.ConnectorUpgradePolicyMaintenanceWindow)
+			}
+			return nil
+		}
+		for _, day := range resource.WindowDays {
+			if !arrays.Contains(services.WeekDays, day) {
+				return errors.BadRequest("window_days must be one of %v, got %q", services.WeekDays, day)
+			}
+		}
+		if resource.WindowStartHour < 0 || resource.WindowStartHour > 23 {
+			return errors.BadRequest("window_start_hour must be between 0 and 23")
+		}
+		if resource.WindowDurationHours < 1 || resource.WindowDurationHours > 24 {
+			return errors.BadRequest("window_duration_hours must be between 1 and 24")
+		}
+		return nil
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
 	jsonpatch "github.com/evanphx/json-patch"
@@ -45,6 +46,8 @@ type ConnectorsHandler struct {
 	vaultService          vault.VaultService
 	authZService          authz.AuthZService
 	connectorsConfig      *config.ConnectorsConfig
+	revisionsService      services.ConnectorRevisionsService
+	templatesService      services.ConnectorTemplatesService
 }
 
 // this is an initial guess at what operation is being performed in update
@@ -56,7 +59,8 @@ var stateToOperationsMap = map[public.ConnectorDesiredState]phase.ConnectorOpera
 
 func NewConnectorsHandler(connectorsService services.ConnectorsService, connectorTypesService services.ConnectorTypesService,
 	namespaceService services.ConnectorNamespaceService, vaultService vault.VaultService, authZService authz.AuthZService,
-	connectorsConfig *config.ConnectorsConfig) *ConnectorsHandler {
+	connectorsConfig *config.ConnectorsConfig, revisionsService services.ConnectorRevisionsService,
+	templatesService services.ConnectorTemplatesService) *ConnectorsHandler {
 	return &ConnectorsHandler{
 		connectorsService:     connectorsService,
 		connectorTypesService: connectorTypesService,
@@ -64,6 +68,8 @@ func NewConnectorsHandler(connectorsService services.ConnectorsService, connecto
 		vaultService:          vaultService,
 		authZService:          authZService,
 		connectorsConfig:      connectorsConfig,
+		revisionsService:      revisionsService,
+		templatesService:      templatesService,
 	}
 }
 
@@ -72,11 +78,14 @@ func (h ConnectorsHandler) Create(w http.ResponseWriter, r *http.Request) {
 	user := h.authZService.GetValidationUser(r.Context())
 
 	var resource public.ConnectorRequest
+	var template *dbapi.ConnectorTemplate
+	var templateParameters map[string]interface{}
 	cfg := &handlers.HandlerConfig{
 
 		MarshalInto: &resource,
 		Validate: []handlers.Validate{
 			handlers.ValidateAsyncEnabled(r, "creating connector"),
+			applyConnectorTemplate(r, h.templatesService, &resource, &template, &templateParameters),
 			handlers.Validation("channel", (*string)(&resource.Channel), handlers.WithDefault("stable"), handlers.MaxLen(40)),
 			handlers.Validation("name", &resource.Name, handlers.WithDefault("New Connector"), handlers.MinLen(1), handlers.MaxLen(100)),
 			handlers.Validation("kafka.id", &resource.Kafka.Id, handlers.MinLen(1), handlers.MaxLen(maxKafkaNameLength)),
@@ -87,7 +96,7 @@ func (h ConnectorsHandler) Create(w http.ResponseWriter, r *http.Request) {
 			handlers.Validation("desired_state", (*string)(&resource.DesiredState), handlers.WithDefault("ready"), handlers.IsOneOf(dbapi.ValidDesiredStates...)),
 			validateConnectorRequest(h.connectorTypesService, &resource),
 			handlers.Validation("namespace_id", &resource.NamespaceId,
-				handlers.MaxLen(maxConnectorNamespaceIdLength), user.AuthorizedNamespaceUser(errors.ErrorBadRequest), user.ValidateNamespaceConnectorQuota()),
+				handlers.MaxLen(maxConnectorNamespaceIdLength), user.AuthorizedNamespaceEditor(errors.ErrorBadRequest), user.ValidateNamespaceConnectorQuota()),
 			validateCreateAnnotations(resource.Annotations),
 		},
 
@@ -121,6 +130,12 @@ func (h ConnectorsHandler) Create(w http.ResponseWriter, r *http.Request) {
 			if err := ValidateConnectorOperation(r.Context(), h.namespaceService, convResource, phase.CreateConnector); err != nil {
 				return nil, err
 			}
+			if convResource.NamespaceId != nil && *convResource.NamespaceId != "" && services.ConnectorRunsPods(convResource.DesiredState) {
+				if err := h.namespaceService.CheckConnectorResourceQuota(*convResource.NamespaceId,
+					convResource.ConnectorTypeId, convResource.Channel, ""); err != nil {
+					return nil, err
+				}
+			}
 
 			err = moveSecretsToVault(convResource, ct, h.vaultService, true)
 			if err != nil {
@@ -130,6 +145,11 @@ func (h ConnectorsHandler) Create(w http.ResponseWriter, r *http.Request) {
 			if svcErr := h.connectorsService.Create(r.Context(), convResource); svcErr != nil {
 				return nil, svcErr
 			}
+			if template != nil {
+				if svcErr := h.templatesService.RecordRender(r.Context(), convResource.ID, template, templateParameters); svcErr != nil {
+					glog.Errorf("failed to record connector template %s of connector %s: %v", template.ID, convResource.ID, svcErr)
+				}
+			}
 
 			if err := stripSecretReferences(convResource, ct); err != nil {
 				return nil, err
@@ -147,10 +167,12 @@ func (h ConnectorsHandler) Patch(w http.ResponseWriter, r *http.Request) {
 
 	connectorId := mux.Vars(r)["connector_id"]
 	contentType := r.Header.Get("Content-Type")
+	user := h.authZService.GetValidationUser(r.Context())
 
 	cfg := &handlers.HandlerConfig{
 		Validate: []handlers.Validate{
-			handlers.Validation("connector_id", &connectorId, handlers.MinLen(1), handlers.MaxLen(maxConnectorIdLength)),
+			handlers.Validation("connector_id", &connectorId, handlers.MinLen(1), handlers.MaxLen(maxConnectorIdLength),
+				user.AuthorizedConnectorEditor()),
 			handlers.Validation("Content-Type header", &contentType, handlers.IsOneOf(APPLICATION_JSON, JSON_PATCH, MERGE_PATCH)),
 		},
 		Action: func() (interface{}, *errors.ServiceError) {
@@ -170,7 +192,7 @@ func (h ConnectorsHandler) Patch(w http.ResponseWriter, r *http.Request) {
 				return nil, errors.BadRequest("invalid connector type id: %s", resource.ConnectorTypeId)
 			}
 
-			originalSecrets, err := getSecretRefs(&dbresource.Connector, ct)
+			originalSecrets, err := services.GetConnectorSecretRefs(&dbresource.Connector, ct)
 			if err != nil {
 				return nil, errors.GeneralError("could not get existing secrets: %v", err)
 			}
@@ -250,7 +272,6 @@ func (h ConnectorsHandler) Patch(w http.ResponseWriter, r *http.Request) {
 			}
 
 			// revalidate
-			user := h.authZService.GetValidationUser(r.Context())
 			validates := []handlers.Validate{
 				handlers.Validation("name", &resource.Name, handlers.MinLen(1), handlers.MaxLen(100)),
 				handlers.Validation("connector_type_id", &resource.ConnectorTypeId, handlers.MinLen(1), handlers.MaxLen(maxConnectorTypeIdLength)),
@@ -262,7 +283,7 @@ func (h ConnectorsHandler) Patch(w http.ResponseWriter, r *http.Request) {
 
 			// Don't validate user's tenancy in admin api calls
 			if strings.Compare(r.URL.Path, fmt.Sprintf("%s/%s", "/api/connector_mgmt/v1/admin/kafka_connectors", connectorId)) != 0 {
-				validates = append(validates, handlers.Validation("namespace_id", &resource.NamespaceId, handlers.MaxLen(maxConnectorNamespaceIdLength), user.AuthorizedNamespaceUser(errors.ErrorBadRequest)))
+				validates = append(validates, handlers.Validation("namespace_id", &resource.NamespaceId, handlers.MaxLen(maxConnectorNamespaceIdLength), user.AuthorizedNamespaceEditor(errors.ErrorBadRequest)))
 			}
 
 			for _, v := range validates {
@@ -277,6 +298,15 @@ func (h ConnectorsHandler) Patch(w http.ResponseWriter, r *http.Request) {
 				return nil, svcErr
 			}
 
+			// check the namespace cpu and memory quota when the connector starts using namespace resources
+			if p.NamespaceId != nil && *p.NamespaceId != "" && services.ConnectorRunsPods(p.DesiredState) &&
+				(originalResource.NamespaceId != resource.NamespaceId ||
+					!services.ConnectorRunsPods(dbapi.ConnectorDesiredState(originalResource.DesiredState))) {
+				if svcErr := h.namespaceService.CheckConnectorResourceQuota(*p.NamespaceId, p.ConnectorTypeId, p.Channel, p.ID); svcErr != nil {
+					return nil, svcErr
+				}
+			}
+
 			svcErr = moveSecretsToVault(p, ct, h.vaultService, false)
 			if svcErr != nil {
 				return nil, svcErr
@@ -297,12 +327,18 @@ func (h ConnectorsHandler) Patch(w http.ResponseWriter, r *http.Request) {
 				return nil, serr
 			}
 
-			newSecrets, err := getSecretRefs(p, ct)
+			newSecrets, err := services.GetConnectorSecretRefs(p, ct)
 			if err != nil {
 				return nil, errors.GeneralError("could not get existing secrets: %v", err)
 			}
 
-			staleSecrets := StringListSubtract(originalSecrets, newSecrets...)
+			// secrets of previous revisions are kept to be able to rollback
+			revisionSecrets, serr := h.revisionsService.SecretRefs(r.Context(), connectorId)
+			if serr != nil {
+				return nil, serr
+			}
+
+			staleSecrets := StringListSubtract(originalSecrets, append(newSecrets, revisionSecrets...)...)
 			if len(staleSecrets) > 0 {
 				_ = db.AddPostCommitAction(r.Context(), func() {
 					for _, s := range staleSecrets {
@@ -318,6 +354,8 @@ func (h ConnectorsHandler) Patch(w http.ResponseWriter, r *http.Request) {
 				return nil, err
 			}
 
+			auth.RecordAuditDiff(r.Context(), originalResource, resource)
+
 			return presenters.PresentConnector(p)
 		},
 	}
@@ -484,9 +522,11 @@ func (h ConnectorsHandler) Get(w http.ResponseWriter, r *http.Request) {
 // Delete is the handler for deleting a connector
 func (h ConnectorsHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	connectorId := mux.Vars(r)["connector_id"]
+	user := h.authZService.GetValidationUser(r.Context())
 	cfg := &handlers.HandlerConfig{
 		Validate: []handlers.Validate{
-			handlers.Validation("connector_id", &connectorId, handlers.MinLen(1), handlers.MaxLen(maxConnectorIdLength)),
+			handlers.Validation("connector_id", &connectorId, handlers.MinLen(1), handlers.MaxLen(maxConnectorIdLength),
+				user.AuthorizedConnectorEditor()),
 		},
 		Action: func() (interface{}, *errors.ServiceError) {
 
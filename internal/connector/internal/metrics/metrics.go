@@ -15,6 +15,10 @@ const (
 	VaultServiceSuccessCount = "vault_service_success_count"
 	VaultServiceFailureCount = "vault_service_failure_count"
 	VaultServiceErrorsCount  = "vault_service_errors_count"
+
+	ConnectorSecretsGCOrphanedCount = "connector_secrets_gc_orphaned_count"
+	ConnectorSecretsGCDeletedCount  = "connector_secrets_gc_deleted_count"
+	ConnectorSecretsGCFailureCount  = "connector_secrets_gc_failure_count"
 )
 
 var VaultServiceMetricsLabels = []string{
@@ -81,6 +85,43 @@ func IncreaseVaultServiceErrorsCount(operationType string) {
 
 // #### Metrics for Vault Service - End ####
 
+// #### Metrics for Connector Secrets GC ####
+
+var connectorSecretsGCOrphanedCountMetric = prometheus.NewGauge(
+	prometheus.GaugeOpts{
+		Subsystem: CosFleetManager,
+		Name:      ConnectorSecretsGCOrphanedCount,
+		Help:      "number of orphaned connector secrets found in the vault by the last scan",
+	})
+
+func SetConnectorSecretsGCOrphanedCount(count int) {
+	connectorSecretsGCOrphanedCountMetric.Set(float64(count))
+}
+
+var connectorSecretsGCDeletedCountMetric = prometheus.NewCounter(
+	prometheus.CounterOpts{
+		Subsystem: CosFleetManager,
+		Name:      ConnectorSecretsGCDeletedCount,
+		Help:      "count of orphaned connector secrets deleted from the vault",
+	})
+
+func IncreaseConnectorSecretsGCDeletedCount() {
+	connectorSecretsGCDeletedCountMetric.Inc()
+}
+
+var connectorSecretsGCFailureCountMetric = prometheus.NewCounter(
+	prometheus.CounterOpts{
+		Subsystem: CosFleetManager,
+		Name:      ConnectorSecretsGCFailureCount,
+		Help:      "count of orphaned connector secrets that failed to be deleted from the vault",
+	})
+
+func IncreaseConnectorSecretsGCFailureCount() {
+	connectorSecretsGCFailureCountMetric.Inc()
+}
+
+// #### Metrics for Connector Secrets GC - End ####
+
 // register the metric(s)
 func init() {
 	// metrics for vault service
@@ -88,6 +129,11 @@ func init() {
 	prometheus.MustRegister(vaultServiceSuccessCountMetric)
 	prometheus.MustRegister(vaultServiceFailureCountMetric)
 	prometheus.MustRegister(vaultServiceErrorsCountMetric)
+
+	// metrics for connector secrets gc
+	prometheus.MustRegister(connectorSecretsGCOrphanedCountMetric)
+	prometheus.MustRegister(connectorSecretsGCDeletedCountMetric)
+	prometheus.MustRegister(connectorSecretsGCFailureCountMetric)
 }
 
 // ResetMetricsForVaultService will reset the metrics related to Vault Service requests
@@ -99,7 +145,13 @@ func ResetMetricsForVaultService() {
 	vaultServiceErrorsCountMetric.Reset()
 }
 
+// ResetMetricsForConnectorSecretsGC will reset the metrics related to the connector secrets GC
+func ResetMetricsForConnectorSecretsGC() {
+	connectorSecretsGCOrphanedCountMetric.Set(0)
+}
+
 // Reset the metrics we have defined. It is mainly used for testing.
 func Reset() {
 	ResetMetricsForVaultService()
+	ResetMetricsForConnectorSecretsGC()
 }
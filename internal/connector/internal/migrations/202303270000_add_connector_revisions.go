@@ -0,0 +1,46 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+func addConnectorRevisions(migrationId string) *gormigrate.Migration {
+	type ConnectorRevision struct {
+		db.Model
+		ConnectorID                string `gorm:"uniqueIndex:idx_connector_revisions_connector_id_revision"`
+		Revision                   int64  `gorm:"uniqueIndex:idx_connector_revisions_connector_id_revision"`
+		ConnectorVersion           int64
+		ConnectorTypeId            string
+		Channel                    string
+		ConnectorShardMetadataID   int64
+		ConnectorSpec              api.JSON `gorm:"type:jsonb"`
+		KafkaID                    string
+		KafkaBootstrapServer       string
+		SchemaRegistryID           string
+		SchemaRegistryUrl          string
+		ServiceAccountClientId     string
+		ServiceAccountClientSecret string
+		SecretRefs                 api.JSON `gorm:"type:jsonb"`
+	}
+
+	type ConnectorDeployment struct {
+		ConfigRevision int64
+	}
+
+	type ConnectorDeploymentStatus struct {
+		ConfigRevision int64
+	}
+
+	return db.CreateMigrationFromActions(migrationId,
+		db.CreateTableAction(&ConnectorRevision{}),
+		db.AddTableColumnsAction(&ConnectorDeployment{}),
+		db.AddTableColumnsAction(&ConnectorDeploymentStatus{}),
+	)
+}
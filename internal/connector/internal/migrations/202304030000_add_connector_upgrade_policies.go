@@ -0,0 +1,67 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addConnectorUpgradePolicies(migrationId string) *gormigrate.Migration {
+	type ConnectorUpgradePolicy struct {
+		db.Model
+		NamespaceID           *string `gorm:"uniqueIndex"`
+		ConnectorID           *string `gorm:"uniqueIndex"`
+		Type                  string
+		WindowDays            string
+		WindowStartHour       int
+		WindowDurationHours   int
+		MaxConcurrentUpgrades int
+	}
+
+	type ConnectorUpgrade struct {
+		db.Model
+		DeploymentID        string `gorm:"index"`
+		ConnectorID         string
+		NamespaceID         string `gorm:"index"`
+		Kind                string
+		FromShardMetadataID int64
+		ToShardMetadataID   int64
+		FromOperatorID      string
+		ToOperatorID        string
+		DeploymentVersion   int64
+		State               string `gorm:"index"`
+		StartedAt           time.Time
+		Error               string
+	}
+
+	type LeaderLease struct {
+		db.Model
+		Leader    string
+		LeaseType string
+		Expires   *time.Time
+	}
+
+	return db.CreateMigrationFromActions(migrationId,
+		db.CreateTableAction(&ConnectorUpgradePolicy{}),
+		db.CreateTableAction(&ConnectorUpgrade{}),
+		db.FuncAction(func(tx *gorm.DB) error {
+			now := time.Now().Add(-time.Minute) //set to a expired time
+			return tx.Create(&api.LeaderLease{
+				Expires:   &now,
+				LeaseType: "connector_upgrade",
+			}).Error
+		}, func(tx *gorm.DB) error {
+			// The leader lease table may have already been dropped, by the kafka migration rollback, ignore error
+			_ = tx.Where("lease_type = ?", "connector_upgrade").Delete(&LeaderLease{})
+			return nil
+		}),
+	)
+}
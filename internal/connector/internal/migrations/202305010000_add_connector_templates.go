@@ -0,0 +1,43 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+func addConnectorTemplates(migrationId string) *gormigrate.Migration {
+	type ConnectorTemplate struct {
+		db.Model
+		Name            string
+		Description     string
+		Owner           string
+		OrganisationId  string `gorm:"index"`
+		ConnectorTypeId string
+		Channel         string
+		ConnectorSpec   api.JSON `gorm:"type:jsonb"`
+		Parameters      api.JSON `gorm:"type:jsonb"`
+		Version         int64
+	}
+
+	type ConnectorTemplateRender struct {
+		ConnectorID     string `gorm:"primaryKey"`
+		TemplateID      string `gorm:"index"`
+		TemplateVersion int64
+		Parameters      api.JSON `gorm:"type:jsonb"`
+		CreatedAt       time.Time
+		UpdatedAt       time.Time
+	}
+
+	return db.CreateMigrationFromActions(migrationId,
+		db.CreateTableAction(&ConnectorTemplate{}),
+		db.CreateTableAction(&ConnectorTemplateRender{}),
+	)
+}
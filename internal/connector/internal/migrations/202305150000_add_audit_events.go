@@ -0,0 +1,76 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addAuditEvents(migrationId string) *gormigrate.Migration {
+	type AuditEvent struct {
+		ID             string    `gorm:"primaryKey"`
+		CreatedAt      time.Time `gorm:"index"`
+		Service        string    `gorm:"index"`
+		Actor          string    `gorm:"index"`
+		OrganisationId string    `gorm:"index"`
+		Claims         []byte    `gorm:"type:jsonb"`
+		Method         string
+		Route          string
+		Path           string
+		ResourceId     string `gorm:"index"`
+		RequestBody    []byte `gorm:"type:jsonb"`
+		Diff           []byte `gorm:"type:jsonb"`
+		StatusCode     int
+		Outcome        string
+		OperationId    string `gorm:"index"`
+		RemoteAddr     string
+	}
+
+	type LeaderLease struct {
+		db.Model
+		Leader    string
+		LeaseType string
+		Expires   *time.Time
+	}
+
+	return db.CreateMigrationFromActions(migrationId,
+		db.FuncAction(func(tx *gorm.DB) error {
+			// The audit_events table is shared with the kas-fleet-manager, which may have already created it
+			if err := tx.AutoMigrate(&AuditEvent{}); err != nil {
+				return err
+			}
+			// the audit events are append only, they can only be deleted by the retention workers once expired
+			return tx.Exec(`
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+	RAISE EXCEPTION 'audit events are append only';
+END;
+$$ LANGUAGE plpgsql;
+DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events;
+CREATE TRIGGER audit_events_append_only BEFORE UPDATE ON audit_events FOR EACH ROW EXECUTE PROCEDURE audit_events_append_only();
+`).Error
+		}, func(tx *gorm.DB) error {
+			// The audit_events table is shared with the kas-fleet-manager, it's dropped by the kafka migration rollback
+			return nil
+		}),
+		db.FuncAction(func(tx *gorm.DB) error {
+			now := time.Now().Add(-time.Minute) //set to a expired time
+			return tx.Create(&api.LeaderLease{
+				Expires:   &now,
+				LeaseType: "connector_audit_events_retention",
+			}).Error
+		}, func(tx *gorm.DB) error {
+			// The leader lease table may have already been dropped, by the kafka migration rollback, ignore error
+			_ = tx.Where("lease_type = ?", "connector_audit_events_retention").Delete(&LeaderLease{})
+			return nil
+		}),
+	)
+}
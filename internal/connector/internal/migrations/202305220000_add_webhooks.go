@@ -0,0 +1,75 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addWebhooks(migrationId string) *gormigrate.Migration {
+	type WebhookSubscription struct {
+		db.Model
+		Service        string `gorm:"index"`
+		OrganisationId string `gorm:"index"`
+		Owner          string
+		Url            string
+		EventTypes     []byte `gorm:"type:jsonb"`
+		Secret         string
+	}
+
+	type WebhookDelivery struct {
+		ID             string `gorm:"primaryKey"`
+		CreatedAt      time.Time
+		UpdatedAt      time.Time
+		Service        string `gorm:"index"`
+		SubscriptionId string `gorm:"index"`
+		EventId        string
+		EventType      string
+		ResourceId     string
+		Payload        []byte `gorm:"type:jsonb"`
+		Status         string `gorm:"index"`
+		Attempts       int
+		NextAttemptAt  *time.Time `gorm:"index"`
+		LastAttemptAt  *time.Time
+		LastStatusCode int
+		LastError      string
+		DeliveredAt    *time.Time
+		RedeliveryOf   string
+	}
+
+	type LeaderLease struct {
+		db.Model
+		Leader    string
+		LeaseType string
+		Expires   *time.Time
+	}
+
+	return db.CreateMigrationFromActions(migrationId,
+		db.FuncAction(func(tx *gorm.DB) error {
+			// The webhook tables are shared with the kas-fleet-manager, which may have already created them
+			return tx.AutoMigrate(&WebhookSubscription{}, &WebhookDelivery{})
+		}, func(tx *gorm.DB) error {
+			// The webhook tables are shared with the kas-fleet-manager, they're dropped by the kafka migration rollback
+			return nil
+		}),
+		db.FuncAction(func(tx *gorm.DB) error {
+			now := time.Now().Add(-time.Minute) //set to a expired time
+			return tx.Create(&api.LeaderLease{
+				Expires:   &now,
+				LeaseType: "connector_webhook_deliveries",
+			}).Error
+		}, func(tx *gorm.DB) error {
+			// The leader lease table may have already been dropped, by the kafka migration rollback, ignore error
+			_ = tx.Where("lease_type = ?", "connector_webhook_deliveries").Delete(&LeaderLease{})
+			return nil
+		}),
+	)
+}
@@ -0,0 +1,44 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addApiTokens(migrationId string) *gormigrate.Migration {
+	type ApiToken struct {
+		db.Model
+		Name           string
+		Kind           string
+		OrganisationId string `gorm:"index"`
+		Owner          string
+		AccountId      string
+		Scopes         []byte `gorm:"type:jsonb"`
+		TokenHash      string `gorm:"uniqueIndex"`
+		TokenPrefix    string
+		ExpiresAt      time.Time
+		LastUsedAt     *time.Time
+	}
+
+	return db.CreateMigrationFromActions(migrationId,
+		db.FuncAction(func(tx *gorm.DB) error {
+			// The api_tokens table is shared with the kas-fleet-manager, which may have already created it
+			return tx.AutoMigrate(&ApiToken{})
+		}, func(tx *gorm.DB) error {
+			// The api_tokens table is shared with the kas-fleet-manager, it's dropped by the last service rolling back its migration
+			applied, err := db.IsMigrationApplied(tx, "migrations", "20230612100000")
+			if err != nil || applied {
+				return err
+			}
+			return tx.Migrator().DropTable(&ApiToken{})
+		}),
+	)
+}
@@ -50,6 +50,18 @@ var migrations = []*gormigrate.Migration{
 	renameNamespaceProfileAnnotations("202211280000"),
 	addOrgIDAnnotations("202212050000"),
 	addConnectorTypeDeprecated("202301180000"),
+	addConnectorRestartAndSchedules("202303200000"),
+	addConnectorRevisions("202303270000"),
+	addConnectorUpgradePolicies("202304030000"),
+	addConnectorSecretOrphans("202304100000"),
+	addConnectorCatalogSources("202304170000"),
+	addConnectorLogLines("202304240000"),
+	addConnectorTemplates("202305010000"),
+	addConnectorNamespaceRoleBindings("202305080000"),
+	addAuditEvents("202305150000"),
+	addWebhooks("202305220000"),
+	addAuditEventsAuthorization("202306070000"),
+	addApiTokens("202306120000"),
 }
 
 func New(dbConfig *db.DatabaseConfig) (*db.Migration, func(), error) {
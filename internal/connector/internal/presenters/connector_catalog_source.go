@@ -0,0 +1,23 @@
+package presenters
+
+import (
+	admin "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+)
+
+func PresentConnectorCatalogSource(from *dbapi.ConnectorCatalogSource) admin.ConnectorCatalogSource {
+	reference := PresentReference(from.ID, from)
+	return admin.ConnectorCatalogSource{
+		Id:            reference.Id,
+		Kind:          reference.Kind,
+		Href:          reference.Href,
+		Name:          from.Name,
+		SourceKind:    from.Kind,
+		Digest:        from.Digest,
+		Entries:       int32(from.Entries),
+		Verified:      from.Verified,
+		Error:         from.Error,
+		LastSyncAt:    from.LastSyncAt,
+		LastSuccessAt: from.LastSuccessAt,
+	}
+}
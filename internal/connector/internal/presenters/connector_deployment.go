@@ -77,13 +77,16 @@ func PresentConnectorDeployment(from dbapi.ConnectorDeployment, resolvedSecrets
 				ClientId:     presentedConnector.ServiceAccount.ClientId,
 				ClientSecret: presentedConnector.ServiceAccount.ClientSecret,
 			},
-			ConnectorTypeId: presentedConnector.ConnectorTypeId,
+			ConnectorTypeId:   presentedConnector.ConnectorTypeId,
+			RestartGeneration: from.Connector.RestartGeneration,
+			ConfigRevision:    from.ConfigRevision,
 		},
 		Status: private.ConnectorDeploymentStatus{
 			Phase:           private.ConnectorState(from.Status.Phase),
 			ResourceVersion: from.Status.Version,
 			Conditions:      conditions,
 			Operators:       operators,
+			ConfigRevision:  from.Status.ConfigRevision,
 		},
 	}, nil
 }
@@ -144,6 +147,7 @@ func PresentConnectorDeploymentAdminView(from dbapi.ConnectorDeployment, cluster
 			OperatorId:               fromPresentedConnectorDeployment.Spec.OperatorId,
 			DesiredState:             admin.ConnectorDesiredState(fromPresentedConnectorDeployment.Spec.DesiredState),
 			ShardMetadata:            fromPresentedConnectorDeployment.Spec.ShardMetadata,
+			ConfigRevision:           fromPresentedConnectorDeployment.Spec.ConfigRevision,
 		},
 
 		Status: admin.ConnectorDeploymentAdminStatus{
@@ -161,8 +165,9 @@ func PresentConnectorDeploymentAdminView(from dbapi.ConnectorDeployment, cluster
 					Version: fromPresentedConnectorDeployment.Status.Operators.Available.Version,
 				},
 			},
-			Conditions:    conditions,
-			ShardMetadata: deploymentAdminStatusShardMetadata,
+			Conditions:     conditions,
+			ShardMetadata:  deploymentAdminStatusShardMetadata,
+			ConfigRevision: fromPresentedConnectorDeployment.Status.ConfigRevision,
 		},
 	}
 
@@ -187,5 +192,6 @@ func ConvertConnectorDeploymentStatus(from private.ConnectorDeploymentStatus) (d
 		Conditions:       conditions,
 		Operators:        operators,
 		UpgradeAvailable: from.Operators.Available.Id != "" && from.Operators.Available.Id != from.Operators.Assigned.Id,
+		ConfigRevision:   from.ConfigRevision,
 	}, nil
 }
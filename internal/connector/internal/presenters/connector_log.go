@@ -0,0 +1,35 @@
+package presenters
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/public"
+)
+
+func ConvertConnectorDeploymentLogLines(from []private.ConnectorDeploymentLogLine) dbapi.ConnectorLogLineList {
+	lines := make(dbapi.ConnectorLogLineList, len(from))
+	for i, line := range from {
+		lines[i] = &dbapi.ConnectorLogLine{
+			Pod:       line.Pod,
+			Timestamp: line.Timestamp,
+			Message:   line.Message,
+		}
+	}
+	return lines
+}
+
+func PresentConnectorLogLines(connectorId string, from dbapi.ConnectorLogLineList) public.ConnectorLogLineList {
+	result := public.ConnectorLogLineList{
+		Kind:  "ConnectorLogLineList",
+		Id:    connectorId,
+		Items: make([]public.ConnectorLogLine, len(from)),
+	}
+	for i, line := range from {
+		result.Items[i] = public.ConnectorLogLine{
+			Timestamp: line.Timestamp,
+			Pod:       line.Pod,
+			Message:   line.Message,
+		}
+	}
+	return result
+}
@@ -0,0 +1,52 @@
+package presenters
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/observatorium"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	pmod "github.com/prometheus/common/model"
+)
+
+// connectorMetricsLabels are the labels of connector metrics returned to users, other labels describe the data plane cluster
+var connectorMetricsLabels = map[string]bool{
+	"__name__":                 true,
+	"cos_bf2_org_connector_id": true,
+	"pod":                      true,
+	"container":                true,
+	"routeId":                  true,
+	"connector":                true,
+	"task":                     true,
+}
+
+func PresentConnectorMetricsByRangeQuery(metrics *observatorium.ConnectorMetrics) ([]public.ConnectorMetricsRangeQuery, *errors.ServiceError) {
+	out := []public.ConnectorMetricsRangeQuery{}
+	for _, m := range *metrics {
+		if m.Err != nil {
+			return nil, errors.GeneralError("error in metric %s: %v", m.Matrix, m.Err)
+		}
+		for _, s := range m.Matrix {
+			out = append(out, presentConnectorSampleStream(s))
+		}
+	}
+	return out, nil
+}
+
+func presentConnectorSampleStream(from *pmod.SampleStream) public.ConnectorMetricsRangeQuery {
+	labelSet := make(map[string]string, len(from.Metric))
+	for k, v := range from.Metric {
+		if connectorMetricsLabels[string(k)] {
+			labelSet[string(k)] = string(v)
+		}
+	}
+	values := make([]public.ConnectorMetricsValues, len(from.Values))
+	for i, v := range from.Values {
+		values[i] = public.ConnectorMetricsValues{
+			Timestamp: int64(v.Timestamp),
+			Value:     float64(v.Value),
+		}
+	}
+	return public.ConnectorMetricsRangeQuery{
+		Metric: labelSet,
+		Values: values,
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/json"
 	"strings"
 	"time"
@@ -354,3 +355,34 @@ func ConvertNamespaceAnnotations(namespaceId string, annotations map[string]stri
 
 	return result
 }
+
+func PresentConnectorNamespaceUsage(usage *dbapi.ConnectorNamespaceUsage) *public.ConnectorNamespaceUsage {
+	result := &public.ConnectorNamespaceUsage{
+		Connectors:     int32(usage.Connectors),
+		MemoryRequests: quantityString(usage.MemoryRequests),
+		MemoryLimits:   quantityString(usage.MemoryLimits),
+		CpuRequests:    quantityString(usage.CPURequests),
+		CpuLimits:      quantityString(usage.CPULimits),
+		ConnectorTypes: make([]public.ConnectorNamespaceTypeUsage, len(usage.ConnectorTypes)),
+	}
+	for i, u := range usage.ConnectorTypes {
+		result.ConnectorTypes[i] = public.ConnectorNamespaceTypeUsage{
+			ConnectorTypeId: u.ConnectorTypeId,
+			Channel:         u.Channel,
+			Connectors:      int32(u.Connectors),
+			MemoryRequests:  quantityString(u.MemoryRequests),
+			MemoryLimits:    quantityString(u.MemoryLimits),
+			CpuRequests:     quantityString(u.CPURequests),
+			CpuLimits:       quantityString(u.CPULimits),
+		}
+	}
+	return result
+}
+
+// quantityString returns an empty string for zero quantities, i.e. resources that aren't accounted
+func quantityString(q resource.Quantity) string {
+	if q.IsZero() {
+		return ""
+	}
+	return q.String()
+}
@@ -0,0 +1,36 @@
+package presenters
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+)
+
+func ConvertConnectorNamespaceRoleBindingRequest(namespaceId string, from public.ConnectorNamespaceRoleBindingRequest) *dbapi.ConnectorNamespaceRoleBinding {
+	return &dbapi.ConnectorNamespaceRoleBinding{
+		Model: db.Model{
+			ID: api.NewID(),
+		},
+		NamespaceId: namespaceId,
+		SubjectKind: dbapi.ConnectorNamespaceSubjectKind(from.SubjectKind),
+		Subject:     from.Subject,
+		Role:        dbapi.ConnectorNamespaceRole(from.Role),
+	}
+}
+
+func PresentConnectorNamespaceRoleBinding(from *dbapi.ConnectorNamespaceRoleBinding) public.ConnectorNamespaceRoleBinding {
+	reference := PresentReference(from.ID, from)
+	return public.ConnectorNamespaceRoleBinding{
+		Id:          reference.Id,
+		Kind:        reference.Kind,
+		Href:        reference.Href,
+		NamespaceId: from.NamespaceId,
+		SubjectKind: public.ConnectorNamespaceSubjectKind(from.SubjectKind),
+		Subject:     from.Subject,
+		Role:        public.ConnectorNamespaceRole(from.Role),
+		CreatedBy:   from.CreatedBy,
+		CreatedAt:   from.CreatedAt,
+		ModifiedAt:  from.UpdatedAt,
+	}
+}
@@ -0,0 +1,41 @@
+package presenters
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+)
+
+// PresentConnectorRevision presents a revision, its secrets must have been stripped already
+func PresentConnectorRevision(from *dbapi.ConnectorRevision) (public.ConnectorRevision, *errors.ServiceError) {
+	var spec map[string]interface{}
+	if len(from.ConnectorSpec) != 0 {
+		if err := from.ConnectorSpec.Unmarshal(&spec); err != nil {
+			return public.ConnectorRevision{}, errors.GeneralError("invalid connector spec: %v", err)
+		}
+	}
+
+	reference := PresentReference(from.ID, from)
+	return public.ConnectorRevision{
+		Id:              reference.Id,
+		Kind:            reference.Kind,
+		Href:            reference.Href,
+		CreatedAt:       from.CreatedAt,
+		Revision:        from.Revision,
+		ResourceVersion: from.ConnectorVersion,
+		ConnectorTypeId: from.ConnectorTypeId,
+		Channel:         public.Channel(from.Channel),
+		Kafka: public.KafkaConnectionSettings{
+			Id:  from.Kafka.KafkaID,
+			Url: from.Kafka.BootstrapServer,
+		},
+		ServiceAccount: public.ServiceAccount{
+			ClientId: from.ServiceAccount.ClientId,
+		},
+		SchemaRegistry: public.SchemaRegistryConnectionSettings{
+			Id:  from.SchemaRegistry.SchemaRegistryID,
+			Url: from.SchemaRegistry.Url,
+		},
+		Connector: spec,
+	}, nil
+}
@@ -0,0 +1,33 @@
+package presenters
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+)
+
+func ConvertConnectorScheduleRequest(connectorID string, from public.ConnectorScheduleRequest) *dbapi.ConnectorSchedule {
+	return &dbapi.ConnectorSchedule{
+		Model: db.Model{
+			ID: api.NewID(),
+		},
+		ConnectorID: connectorID,
+		StopAt:      from.StopAt.UTC(),
+		StartAt:     from.StartAt.UTC(),
+	}
+}
+
+func PresentConnectorSchedule(from *dbapi.ConnectorSchedule) public.ConnectorSchedule {
+	reference := PresentReference(from.ID, from)
+	return public.ConnectorSchedule{
+		Id:        reference.Id,
+		Kind:      reference.Kind,
+		Href:      reference.Href,
+		CreatedAt: from.CreatedAt,
+		StopAt:    from.StopAt,
+		StartAt:   from.StartAt,
+		State:     public.ConnectorScheduleState(from.State),
+		Error:     from.Error,
+	}
+}
@@ -0,0 +1,107 @@
+package presenters
+
+import (
+	"encoding/json"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+)
+
+func ConvertConnectorTemplateRequest(id string, from public.ConnectorTemplateRequest) (*dbapi.ConnectorTemplate, *errors.ServiceError) {
+	spec, err := json.Marshal(from.Connector)
+	if err != nil {
+		return nil, errors.BadRequest("invalid connector spec: %v", err)
+	}
+	parameters := make([]dbapi.ConnectorTemplateParameter, len(from.Parameters))
+	for i, p := range from.Parameters {
+		parameters[i] = dbapi.ConnectorTemplateParameter{
+			Name:        p.Name,
+			Description: p.Description,
+			Type:        dbapi.ConnectorTemplateParameterType(p.Type),
+			Required:    p.Required,
+			Default:     p.Default,
+		}
+	}
+	params, err := json.Marshal(parameters)
+	if err != nil {
+		return nil, errors.BadRequest("invalid template parameters: %v", err)
+	}
+
+	return &dbapi.ConnectorTemplate{
+		Model: db.Model{
+			ID: id,
+		},
+		Name:            from.Name,
+		Description:     from.Description,
+		ConnectorTypeId: from.ConnectorTypeId,
+		Channel:         string(from.Channel),
+		ConnectorSpec:   spec,
+		Parameters:      params,
+	}, nil
+}
+
+func PresentConnectorTemplate(from *dbapi.ConnectorTemplate) (public.ConnectorTemplate, *errors.ServiceError) {
+	var spec map[string]interface{}
+	if len(from.ConnectorSpec) != 0 {
+		if err := from.ConnectorSpec.Unmarshal(&spec); err != nil {
+			return public.ConnectorTemplate{}, errors.GeneralError("invalid connector spec of connector template %s: %v", from.ID, err)
+		}
+	}
+	var parameters []dbapi.ConnectorTemplateParameter
+	if len(from.Parameters) != 0 {
+		if err := from.Parameters.Unmarshal(&parameters); err != nil {
+			return public.ConnectorTemplate{}, errors.GeneralError("invalid parameters of connector template %s: %v", from.ID, err)
+		}
+	}
+
+	reference := PresentReference(from.ID, from)
+	result := public.ConnectorTemplate{
+		Id:              reference.Id,
+		Kind:            reference.Kind,
+		Href:            reference.Href,
+		Owner:           from.Owner,
+		CreatedAt:       from.CreatedAt,
+		ModifiedAt:      from.UpdatedAt,
+		Name:            from.Name,
+		Description:     from.Description,
+		ConnectorTypeId: from.ConnectorTypeId,
+		Channel:         public.Channel(from.Channel),
+		Connector:       spec,
+		Version:         from.Version,
+	}
+	for _, p := range parameters {
+		result.Parameters = append(result.Parameters, public.ConnectorTemplateParameter{
+			Name:        p.Name,
+			Description: p.Description,
+			Type:        string(p.Type),
+			Required:    p.Required,
+			Default:     p.Default,
+		})
+	}
+	return result, nil
+}
+
+func PresentConnectorTemplateConnectors(template *dbapi.ConnectorTemplate, renders dbapi.ConnectorTemplateRenderList) (public.ConnectorTemplateConnectorList, *errors.ServiceError) {
+	result := public.ConnectorTemplateConnectorList{
+		Kind:  "ConnectorTemplateConnectorList",
+		Id:    template.ID,
+		Items: make([]public.ConnectorTemplateConnector, 0, len(renders)),
+	}
+	for _, render := range renders {
+		var parameters map[string]interface{}
+		if len(render.Parameters) != 0 {
+			if err := render.Parameters.Unmarshal(&parameters); err != nil {
+				return result, errors.GeneralError("invalid template parameters of connector %s: %v", render.ConnectorID, err)
+			}
+		}
+		result.Items = append(result.Items, public.ConnectorTemplateConnector{
+			ConnectorId:     render.ConnectorID,
+			TemplateVersion: render.TemplateVersion,
+			Outdated:        render.TemplateVersion < template.Version,
+			Parameters:      parameters,
+		})
+	}
+	return result, nil
+}
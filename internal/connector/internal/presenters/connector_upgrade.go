@@ -0,0 +1,71 @@
+package presenters
+
+import (
+	"strings"
+
+	admin "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+)
+
+func ConvertConnectorUpgradePolicyRequest(namespaceID string, connectorID string, from admin.ConnectorUpgradePolicyRequest) *dbapi.ConnectorUpgradePolicy {
+	policy := &dbapi.ConnectorUpgradePolicy{
+		Type:                  dbapi.ConnectorUpgradePolicyType(from.Type),
+		WindowDays:            strings.Join(from.WindowDays, ","),
+		WindowStartHour:       int(from.WindowStartHour),
+		WindowDurationHours:   int(from.WindowDurationHours),
+		MaxConcurrentUpgrades: int(from.MaxConcurrentUpgrades),
+	}
+	if namespaceID != "" {
+		policy.NamespaceID = &namespaceID
+	}
+	if connectorID != "" {
+		policy.ConnectorID = &connectorID
+	}
+	return policy
+}
+
+func PresentConnectorUpgradePolicy(from *dbapi.ConnectorUpgradePolicy) admin.ConnectorUpgradePolicy {
+	reference := PresentReference(from.ID, from)
+	policy := admin.ConnectorUpgradePolicy{
+		Id:                    reference.Id,
+		Kind:                  reference.Kind,
+		Href:                  reference.Href,
+		CreatedAt:             from.CreatedAt,
+		ModifiedAt:            from.UpdatedAt,
+		Type:                  admin.ConnectorUpgradePolicyType(from.Type),
+		WindowStartHour:       int32(from.WindowStartHour),
+		WindowDurationHours:   int32(from.WindowDurationHours),
+		MaxConcurrentUpgrades: int32(from.MaxConcurrentUpgrades),
+	}
+	if from.NamespaceID != nil {
+		policy.NamespaceId = *from.NamespaceID
+	}
+	if from.ConnectorID != nil {
+		policy.ConnectorId = *from.ConnectorID
+	}
+	if from.WindowDays != "" {
+		policy.WindowDays = strings.Split(from.WindowDays, ",")
+	}
+	return policy
+}
+
+func PresentConnectorUpgrade(from *dbapi.ConnectorUpgrade) admin.ConnectorUpgrade {
+	reference := PresentReference(from.ID, from)
+	return admin.ConnectorUpgrade{
+		Id:                  reference.Id,
+		Kind:                reference.Kind,
+		Href:                reference.Href,
+		CreatedAt:           from.CreatedAt,
+		ModifiedAt:          from.UpdatedAt,
+		DeploymentId:        from.DeploymentID,
+		ConnectorId:         from.ConnectorID,
+		NamespaceId:         from.NamespaceID,
+		UpgradeKind:         string(from.Kind),
+		FromShardMetadataId: from.FromShardMetadataID,
+		ToShardMetadataId:   from.ToShardMetadataID,
+		FromOperatorId:      from.FromOperatorID,
+		ToOperatorId:        from.ToOperatorID,
+		State:               string(from.State),
+		Error:               from.Error,
+	}
+}
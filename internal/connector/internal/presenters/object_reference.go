@@ -23,8 +23,22 @@ const (
 	KindConnectorDeploymentAdminView = "ConnectorDeploymentAdminView"
 	// KindConnectorNamespace is a string identifier for the type dbapi.ConnectorNamespace
 	KindConnectorNamespace = "ConnectorNamespace"
+	// KindConnectorNamespaceRoleBinding is a string identifier for the type dbapi.ConnectorNamespaceRoleBinding
+	KindConnectorNamespaceRoleBinding = "ConnectorNamespaceRoleBinding"
+	// KindConnectorRevision is a string identifier for the type dbapi.ConnectorRevision
+	KindConnectorRevision = "ConnectorRevision"
+	// KindConnectorSchedule is a string identifier for the type dbapi.ConnectorSchedule
+	KindConnectorSchedule = "ConnectorSchedule"
+	// KindConnectorTemplate is a string identifier for the type dbapi.ConnectorTemplate
+	KindConnectorTemplate = "ConnectorTemplate"
+	// KindConnectorUpgrade is a string identifier for the type dbapi.ConnectorUpgrade
+	KindConnectorUpgrade = "ConnectorUpgrade"
+	// KindConnectorUpgradePolicy is a string identifier for the type dbapi.ConnectorUpgradePolicy
+	KindConnectorUpgradePolicy = "ConnectorUpgradePolicy"
 	// KindConnectorType is a string identifier for the type dbapi.ConnectorType
 	KindConnectorType = "ConnectorType"
+	// KindConnectorCatalogSource is a string identifier for the type dbapi.ConnectorCatalogSource
+	KindConnectorCatalogSource = "ConnectorCatalogSource"
 	// ConnectorTypeAdminView is a string identifier for the type admin.ConnectorTypeAdminView
 	ConnectorTypeAdminView = "ConnectorTypeAdminView"
 	// KindError is a string identifier for the type api.ServiceError
@@ -49,8 +63,22 @@ func objectKind(i interface{}) string {
 		return KindConnectorDeploymentAdminView
 	case dbapi.ConnectorNamespace, *dbapi.ConnectorNamespace:
 		return KindConnectorNamespace
+	case dbapi.ConnectorNamespaceRoleBinding, *dbapi.ConnectorNamespaceRoleBinding:
+		return KindConnectorNamespaceRoleBinding
+	case dbapi.ConnectorRevision, *dbapi.ConnectorRevision:
+		return KindConnectorRevision
+	case dbapi.ConnectorSchedule, *dbapi.ConnectorSchedule:
+		return KindConnectorSchedule
+	case dbapi.ConnectorTemplate, *dbapi.ConnectorTemplate:
+		return KindConnectorTemplate
+	case dbapi.ConnectorUpgrade, *dbapi.ConnectorUpgrade:
+		return KindConnectorUpgrade
+	case dbapi.ConnectorUpgradePolicy, *dbapi.ConnectorUpgradePolicy:
+		return KindConnectorUpgradePolicy
 	case dbapi.ConnectorType, *dbapi.ConnectorType:
 		return KindConnectorType
+	case dbapi.ConnectorCatalogSource, *dbapi.ConnectorCatalogSource:
+		return KindConnectorCatalogSource
 	case admin.ConnectorTypeAdminView:
 		return ConnectorTypeAdminView
 	case errors.ServiceError, *errors.ServiceError:
@@ -82,7 +110,39 @@ func objectPath(id string, obj interface{}) string {
 		return fmt.Sprintf("/api/connector_mgmt/v1/admin/kafka_connector_clusters/%s/deployments/%s", obj.Spec.ClusterId, id)
 	case dbapi.ConnectorNamespace, *dbapi.ConnectorNamespace:
 		return fmt.Sprintf("/api/connector_mgmt/v1/kafka_connector_namespaces/%s", id)
+	case dbapi.ConnectorNamespaceRoleBinding:
+		return fmt.Sprintf("/api/connector_mgmt/v1/kafka_connector_namespaces/%s/role_bindings/%s", obj.NamespaceId, id)
+	case *dbapi.ConnectorNamespaceRoleBinding:
+		return fmt.Sprintf("/api/connector_mgmt/v1/kafka_connector_namespaces/%s/role_bindings/%s", obj.NamespaceId, id)
+	case dbapi.ConnectorRevision:
+		return fmt.Sprintf("/api/connector_mgmt/v1/kafka_connectors/%s/revisions/%d", obj.ConnectorID, obj.Revision)
+	case *dbapi.ConnectorRevision:
+		return fmt.Sprintf("/api/connector_mgmt/v1/kafka_connectors/%s/revisions/%d", obj.ConnectorID, obj.Revision)
+	case dbapi.ConnectorSchedule:
+		return fmt.Sprintf("/api/connector_mgmt/v1/kafka_connectors/%s/schedules/%s", obj.ConnectorID, id)
+	case *dbapi.ConnectorSchedule:
+		return fmt.Sprintf("/api/connector_mgmt/v1/kafka_connectors/%s/schedules/%s", obj.ConnectorID, id)
+	case dbapi.ConnectorTemplate, *dbapi.ConnectorTemplate:
+		return fmt.Sprintf("/api/connector_mgmt/v1/kafka_connector_templates/%s", id)
+	case dbapi.ConnectorUpgrade, *dbapi.ConnectorUpgrade:
+		return fmt.Sprintf("/api/connector_mgmt/v1/admin/kafka_connector_upgrades/%s", id)
+	case dbapi.ConnectorUpgradePolicy:
+		return upgradePolicyPath(&obj)
+	case *dbapi.ConnectorUpgradePolicy:
+		return upgradePolicyPath(obj)
+	case dbapi.ConnectorCatalogSource, *dbapi.ConnectorCatalogSource:
+		return fmt.Sprintf("/api/connector_mgmt/v1/admin/kafka_connector_catalog_sources/%s", id)
 	default:
 		return ""
 	}
 }
+
+func upgradePolicyPath(policy *dbapi.ConnectorUpgradePolicy) string {
+	if policy.ConnectorID != nil {
+		return fmt.Sprintf("/api/connector_mgmt/v1/admin/kafka_connectors/%s/upgrade_policy", *policy.ConnectorID)
+	}
+	if policy.NamespaceID != nil {
+		return fmt.Sprintf("/api/connector_mgmt/v1/admin/kafka_connector_namespaces/%s/upgrade_policy", *policy.NamespaceID)
+	}
+	return ""
+}
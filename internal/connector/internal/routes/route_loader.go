@@ -15,6 +15,8 @@ import (
 	kerrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	coreHandlers "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/server"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/audit"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
 	"github.com/goava/di"
 	gorillaHandlers "github.com/gorilla/handlers"
@@ -26,19 +28,25 @@ import (
 
 type options struct {
 	di.Inject
-	ConnectorsConfig          *config.ConnectorsConfig
-	ServerConfig              *server.ServerConfig
-	ErrorsHandler             *coreHandlers.ErrorHandler
-	AuthorizeMiddleware       *acl.AccessControlListMiddleware
-	KeycloakService           sso.KafkaKeycloakService
-	AuthAgentService          auth.AuthAgentService
-	ConnectorAdminHandler     *handlers.ConnectorAdminHandler
-	ConnectorTypesHandler     *handlers.ConnectorTypesHandler
-	ConnectorsHandler         *handlers.ConnectorsHandler
-	ConnectorClusterHandler   *handlers.ConnectorClusterHandler
-	ConnectorNamespaceHandler *handlers.ConnectorNamespaceHandler
-	DB                        *db.ConnectionFactory
-	AdminRoleAuthZConfig      *auth.AdminRoleAuthZConfig
+	ConnectorsConfig              *config.ConnectorsConfig
+	ServerConfig                  *server.ServerConfig
+	ErrorsHandler                 *coreHandlers.ErrorHandler
+	AuthorizeMiddleware           *acl.AccessControlListMiddleware
+	KeycloakService               sso.KafkaKeycloakService
+	AuthAgentService              auth.AuthAgentService
+	ConnectorAdminHandler         *handlers.ConnectorAdminHandler
+	ConnectorTypesHandler         *handlers.ConnectorTypesHandler
+	ConnectorsHandler             *handlers.ConnectorsHandler
+	ConnectorLifecycleHandler     *handlers.ConnectorLifecycleHandler
+	ConnectorClusterHandler       *handlers.ConnectorClusterHandler
+	ConnectorNamespaceHandler     *handlers.ConnectorNamespaceHandler
+	ConnectorObservabilityHandler *handlers.ConnectorObservabilityHandler
+	ConnectorTemplatesHandler     *handlers.ConnectorTemplatesHandler
+	DB                            *db.ConnectionFactory
+	AdminRoleAuthZConfig          *auth.AdminRoleAuthZConfig
+	AuditEventsService            audit.AuditEventsService
+	WebhookService                webhooks.WebhookService
+	WebhookConfig                 *webhooks.WebhookConfig
 }
 
 func NewRouteLoader(s options) environments.RouteLoader {
@@ -49,6 +57,7 @@ func (s *options) AddRoutes(mainRouter *mux.Router) error {
 
 	authorizeMiddleware := s.AuthorizeMiddleware.Authorize
 	requireOrgID := auth.NewRequireOrgIDMiddleware().RequireOrgID(kerrors.ErrorUnauthenticated)
+	auditTrail := auth.NewAuditTrailMiddleware(s.AuditEventsService, audit.ServiceConnectors)
 
 	openAPIDefinitions, err := shared.LoadOpenAPISpecFromYAML(openapicontents.ConnectorMgmtOpenAPIYAMLBytes())
 	if err != nil {
@@ -60,6 +69,14 @@ func (s *options) AddRoutes(mainRouter *mux.Router) error {
 
 	//  /api/connector_mgmt/v1
 	apiV1Router := apiRouter.PathPrefix("/v1").Subrouter()
+	// the API tokens are only allowed on the routes with the scopes they're given
+	apiV1Router.Use(auth.RequireApiTokenScopes(
+		auth.ApiTokenScopeRule{PathPrefix: "/api/connector_mgmt/v1/kafka_connectors", Read: auth.ApiTokenScopeConnectorsRead, Write: auth.ApiTokenScopeConnectorsWrite},
+		auth.ApiTokenScopeRule{PathPrefix: "/api/connector_mgmt/v1/kafka_connector_namespaces", Read: auth.ApiTokenScopeConnectorsRead, Write: auth.ApiTokenScopeConnectorsWrite},
+		auth.ApiTokenScopeRule{PathPrefix: "/api/connector_mgmt/v1/kafka_connector_clusters", Read: auth.ApiTokenScopeConnectorsRead, Write: auth.ApiTokenScopeConnectorsWrite},
+		auth.ApiTokenScopeRule{PathPrefix: "/api/connector_mgmt/v1/kafka_connector_templates", Read: auth.ApiTokenScopeConnectorsRead, Write: auth.ApiTokenScopeConnectorsWrite},
+		auth.ApiTokenScopeRule{PathPrefix: "/api/connector_mgmt/v1/kafka_connector_types", Read: auth.ApiTokenScopeConnectorsRead},
+	))
 
 	//  /api/connector_mgmt/v1/openapi
 	apiV1Router.HandleFunc("/openapi", coreHandlers.NewOpenAPIHandler(openAPIDefinitions).Get).Methods(http.MethodGet)
@@ -92,9 +109,20 @@ func (s *options) AddRoutes(mainRouter *mux.Router) error {
 	apiV1ConnectorsRouter := apiV1Router.PathPrefix("/kafka_connectors").Subrouter()
 	apiV1ConnectorsRouter.HandleFunc("", s.ConnectorsHandler.Create).Methods(http.MethodPost)
 	apiV1ConnectorsRouter.HandleFunc("", s.ConnectorsHandler.List).Methods(http.MethodGet)
+	apiV1ConnectorsRouter.HandleFunc("/bulk", s.ConnectorLifecycleHandler.BulkAction).Methods(http.MethodPost)
 	apiV1ConnectorsRouter.HandleFunc("/{connector_id}", s.ConnectorsHandler.Get).Methods(http.MethodGet)
 	apiV1ConnectorsRouter.HandleFunc("/{connector_id}", s.ConnectorsHandler.Patch).Methods(http.MethodPatch)
 	apiV1ConnectorsRouter.HandleFunc("/{connector_id}", s.ConnectorsHandler.Delete).Methods(http.MethodDelete)
+	apiV1ConnectorsRouter.HandleFunc("/{connector_id}/restart", s.ConnectorLifecycleHandler.Restart).Methods(http.MethodPost)
+	apiV1ConnectorsRouter.HandleFunc("/{connector_id}/schedules", s.ConnectorLifecycleHandler.ListSchedules).Methods(http.MethodGet)
+	apiV1ConnectorsRouter.HandleFunc("/{connector_id}/schedules", s.ConnectorLifecycleHandler.CreateSchedule).Methods(http.MethodPost)
+	apiV1ConnectorsRouter.HandleFunc("/{connector_id}/schedules/{schedule_id}", s.ConnectorLifecycleHandler.DeleteSchedule).Methods(http.MethodDelete)
+	apiV1ConnectorsRouter.HandleFunc("/{connector_id}/revisions", s.ConnectorLifecycleHandler.ListRevisions).Methods(http.MethodGet)
+	apiV1ConnectorsRouter.HandleFunc("/{connector_id}/revisions/{revision}", s.ConnectorLifecycleHandler.GetRevision).Methods(http.MethodGet)
+	apiV1ConnectorsRouter.HandleFunc("/{connector_id}/rollback", s.ConnectorLifecycleHandler.Rollback).Methods(http.MethodPost)
+	apiV1ConnectorsRouter.HandleFunc("/{connector_id}/metrics/query_range", s.ConnectorObservabilityHandler.GetMetricsByRangeQuery).Methods(http.MethodGet)
+	apiV1ConnectorsRouter.HandleFunc("/{connector_id}/logs", s.ConnectorObservabilityHandler.GetLogs).Methods(http.MethodGet)
+	apiV1ConnectorsRouter.Use(auditTrail.AuditMutations())
 	apiV1ConnectorsRouter.Use(authorizeMiddleware)
 	apiV1ConnectorsRouter.Use(requireOrgID)
 
@@ -112,6 +140,7 @@ func (s *options) AddRoutes(mainRouter *mux.Router) error {
 	apiV1ConnectorClustersRouter.HandleFunc("/{connector_cluster_id}", s.ConnectorClusterHandler.Delete).Methods(http.MethodDelete)
 	apiV1ConnectorClustersRouter.HandleFunc("/{connector_cluster_id}/addon_parameters", s.ConnectorClusterHandler.GetAddonParameters).Methods(http.MethodGet)
 	apiV1ConnectorClustersRouter.HandleFunc("/{connector_cluster_id}/namespaces", s.ConnectorClusterHandler.GetNamespaces).Methods(http.MethodGet)
+	apiV1ConnectorClustersRouter.Use(auditTrail.AuditMutations())
 	apiV1ConnectorClustersRouter.Use(authorizeMiddleware)
 	apiV1ConnectorClustersRouter.Use(requireOrgID)
 
@@ -125,6 +154,11 @@ func (s *options) AddRoutes(mainRouter *mux.Router) error {
 	apiV1ConnectorNamespacesRouter.HandleFunc("", s.ConnectorNamespaceHandler.List).Methods(http.MethodGet)
 	apiV1ConnectorNamespacesRouter.HandleFunc("/eval", s.ConnectorNamespaceHandler.CreateEvaluation).Methods(http.MethodPost)
 	apiV1ConnectorNamespacesRouter.HandleFunc("/{connector_namespace_id}", s.ConnectorNamespaceHandler.Get).Methods(http.MethodGet)
+	apiV1ConnectorNamespacesRouter.HandleFunc("/{connector_namespace_id}/role_bindings", s.ConnectorNamespaceHandler.ListRoleBindings).Methods(http.MethodGet)
+	apiV1ConnectorNamespacesRouter.HandleFunc("/{connector_namespace_id}/role_bindings", s.ConnectorNamespaceHandler.CreateRoleBinding).Methods(http.MethodPost)
+	apiV1ConnectorNamespacesRouter.HandleFunc("/{connector_namespace_id}/role_bindings/{binding_id}", s.ConnectorNamespaceHandler.GetRoleBinding).Methods(http.MethodGet)
+	apiV1ConnectorNamespacesRouter.HandleFunc("/{connector_namespace_id}/role_bindings/{binding_id}", s.ConnectorNamespaceHandler.UpdateRoleBinding).Methods(http.MethodPut)
+	apiV1ConnectorNamespacesRouter.HandleFunc("/{connector_namespace_id}/role_bindings/{binding_id}", s.ConnectorNamespaceHandler.DeleteRoleBinding).Methods(http.MethodDelete)
 	if s.ConnectorsConfig.ConnectorNamespaceLifecycleAPI {
 		apiV1ConnectorNamespacesRouter.HandleFunc("", s.ConnectorNamespaceHandler.Create).Methods(http.MethodPost)
 		apiV1ConnectorNamespacesRouter.HandleFunc("/{connector_namespace_id}", s.ConnectorNamespaceHandler.Update).Methods(http.MethodPatch)
@@ -134,9 +168,46 @@ func (s *options) AddRoutes(mainRouter *mux.Router) error {
 		apiV1ConnectorNamespacesRouter.HandleFunc("/{connector_namespace_id}", api.SendMethodNotAllowed).Methods(http.MethodPatch)
 		apiV1ConnectorNamespacesRouter.HandleFunc("/{connector_namespace_id}", api.SendMethodNotAllowed).Methods(http.MethodDelete)
 	}
+	apiV1ConnectorNamespacesRouter.Use(auditTrail.AuditMutations())
 	apiV1ConnectorNamespacesRouter.Use(authorizeMiddleware)
 	apiV1ConnectorNamespacesRouter.Use(requireOrgID)
 
+	//  /api/connector_mgmt/v1/kafka_connector_templates
+	v1Collections = append(v1Collections, api.CollectionMetadata{
+		ID:   "kafka_connector_templates",
+		Kind: "ConnectorTemplateList",
+	})
+
+	apiV1ConnectorTemplatesRouter := apiV1Router.PathPrefix("/kafka_connector_templates").Subrouter()
+	apiV1ConnectorTemplatesRouter.HandleFunc("", s.ConnectorTemplatesHandler.Create).Methods(http.MethodPost)
+	apiV1ConnectorTemplatesRouter.HandleFunc("", s.ConnectorTemplatesHandler.List).Methods(http.MethodGet)
+	apiV1ConnectorTemplatesRouter.HandleFunc("/{template_id}", s.ConnectorTemplatesHandler.Get).Methods(http.MethodGet)
+	apiV1ConnectorTemplatesRouter.HandleFunc("/{template_id}", s.ConnectorTemplatesHandler.Update).Methods(http.MethodPut)
+	apiV1ConnectorTemplatesRouter.HandleFunc("/{template_id}", s.ConnectorTemplatesHandler.Delete).Methods(http.MethodDelete)
+	apiV1ConnectorTemplatesRouter.HandleFunc("/{template_id}/connectors", s.ConnectorTemplatesHandler.ListConnectors).Methods(http.MethodGet)
+	apiV1ConnectorTemplatesRouter.Use(auditTrail.AuditMutations())
+	apiV1ConnectorTemplatesRouter.Use(authorizeMiddleware)
+	apiV1ConnectorTemplatesRouter.Use(requireOrgID)
+
+	//  /api/connector_mgmt/v1/webhooks
+	v1Collections = append(v1Collections, api.CollectionMetadata{
+		ID:   "webhooks",
+		Kind: "WebhookSubscriptionList",
+	})
+
+	webhooksHandler := coreHandlers.NewWebhooksHandler(webhooks.ServiceConnectors, "/api/connector_mgmt/v1", s.WebhookService, s.WebhookConfig)
+	apiV1WebhooksRouter := apiV1Router.PathPrefix("/webhooks").Subrouter()
+	apiV1WebhooksRouter.HandleFunc("", webhooksHandler.Create).Methods(http.MethodPost)
+	apiV1WebhooksRouter.HandleFunc("", webhooksHandler.List).Methods(http.MethodGet)
+	apiV1WebhooksRouter.HandleFunc("/{id}", webhooksHandler.Get).Methods(http.MethodGet)
+	apiV1WebhooksRouter.HandleFunc("/{id}", webhooksHandler.Update).Methods(http.MethodPatch)
+	apiV1WebhooksRouter.HandleFunc("/{id}", webhooksHandler.Delete).Methods(http.MethodDelete)
+	apiV1WebhooksRouter.HandleFunc("/{id}/deliveries", webhooksHandler.ListDeliveries).Methods(http.MethodGet)
+	apiV1WebhooksRouter.HandleFunc("/{id}/deliveries/{delivery_id}/redeliver", webhooksHandler.Redeliver).Methods(http.MethodPost)
+	apiV1WebhooksRouter.Use(auditTrail.AuditMutations())
+	apiV1WebhooksRouter.Use(authorizeMiddleware)
+	apiV1WebhooksRouter.Use(requireOrgID)
+
 	// This section adds the API's accessed by the connector agent...
 	{
 		//  /api/connector_mgmt/v1/kafka_connector_clusters/{id}
@@ -154,29 +225,45 @@ func (s *options) AddRoutes(mainRouter *mux.Router) error {
 	// This section adds APIs accessed by connector admins
 	adminRouter := apiV1Router.PathPrefix("/admin").Subrouter()
 	adminRouter.Use(auth.NewRequireIssuerMiddleware().RequireIssuer([]string{s.KeycloakService.GetConfig().AdminAPISSORealm.ValidIssuerURI}, kerrors.ErrorNotFound))
-	adminRouter.Use(auth.NewRolesAuthzMiddleware(s.AdminRoleAuthZConfig).RequireRolesForMethods(kerrors.ErrorNotFound))
-	adminRouter.Use(auth.NewAuditLogMiddleware().AuditLog(kerrors.ErrorNotFound))
+	// requests are audited before the roles are checked, so that the denied requests are recorded too
+	adminRouter.Use(auditTrail.AuditLog(kerrors.ErrorNotFound))
+	// the routes whose handlers check the resource selectors of the admin policies are registered with CheckAdminResources
+	rolesAuthzMiddleware := auth.NewRolesAuthzMiddleware(s.AdminRoleAuthZConfig)
+	adminRouter.Use(rolesAuthzMiddleware.RequireRolesForMethods(kerrors.ErrorNotFound))
 	adminRouter.HandleFunc("/kafka_connector_clusters", s.ConnectorAdminHandler.ListConnectorClusters).Methods(http.MethodGet)
 	adminRouter.HandleFunc("/kafka_connector_clusters/{connector_cluster_id}", s.ConnectorAdminHandler.GetConnectorCluster).Methods(http.MethodGet)
 	adminRouter.HandleFunc("/kafka_connector_clusters/{connector_cluster_id}/namespaces", s.ConnectorAdminHandler.GetClusterNamespaces).Methods(http.MethodGet)
 	adminRouter.HandleFunc("/kafka_connector_clusters/{connector_cluster_id}/connectors", s.ConnectorAdminHandler.GetClusterConnectors).Methods(http.MethodGet)
 	adminRouter.HandleFunc("/kafka_connector_clusters/{connector_cluster_id}/deployments", s.ConnectorAdminHandler.GetClusterDeployments).Methods(http.MethodGet)
 	adminRouter.HandleFunc("/kafka_connector_clusters/{connector_cluster_id}/deployments/{deployment_id}", s.ConnectorAdminHandler.GetConnectorDeployment).Methods(http.MethodGet)
-	adminRouter.HandleFunc("/kafka_connector_clusters/{connector_cluster_id}/deployments/{deployment_id}", s.ConnectorAdminHandler.PatchConnectorDeployment).Methods(http.MethodPatch)
+	rolesAuthzMiddleware.CheckAdminResources(adminRouter.HandleFunc("/kafka_connector_clusters/{connector_cluster_id}/deployments/{deployment_id}", s.ConnectorAdminHandler.PatchConnectorDeployment).Methods(http.MethodPatch))
 	adminRouter.HandleFunc("/kafka_connector_namespaces", s.ConnectorAdminHandler.GetConnectorNamespaces).Methods(http.MethodGet)
 	adminRouter.HandleFunc("/kafka_connector_namespaces", s.ConnectorAdminHandler.CreateConnectorNamespace).Methods(http.MethodPost)
-	adminRouter.HandleFunc("/kafka_connector_namespaces/{namespace_id}", s.ConnectorAdminHandler.GetConnectorNamespace).Methods(http.MethodGet)
-	adminRouter.HandleFunc("/kafka_connector_namespaces/{namespace_id}", s.ConnectorAdminHandler.DeleteConnectorNamespace).Methods(http.MethodDelete)
+	rolesAuthzMiddleware.CheckAdminResources(adminRouter.HandleFunc("/kafka_connector_namespaces/{namespace_id}", s.ConnectorAdminHandler.GetConnectorNamespace).Methods(http.MethodGet))
+	rolesAuthzMiddleware.CheckAdminResources(adminRouter.HandleFunc("/kafka_connector_namespaces/{namespace_id}", s.ConnectorAdminHandler.DeleteConnectorNamespace).Methods(http.MethodDelete))
 	adminRouter.HandleFunc("/kafka_connector_namespaces/{namespace_id}/connectors", s.ConnectorAdminHandler.GetNamespaceConnectors).Methods(http.MethodGet)
 	adminRouter.HandleFunc("/kafka_connector_namespaces/{namespace_id}/deployments", s.ConnectorAdminHandler.GetNamespaceDeployments).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/kafka_connector_namespaces/{namespace_id}/upgrade_policy", s.ConnectorAdminHandler.GetNamespaceUpgradePolicy).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/kafka_connector_namespaces/{namespace_id}/upgrade_policy", s.ConnectorAdminHandler.PutNamespaceUpgradePolicy).Methods(http.MethodPut)
+	adminRouter.HandleFunc("/kafka_connector_namespaces/{namespace_id}/upgrade_policy", s.ConnectorAdminHandler.DeleteNamespaceUpgradePolicy).Methods(http.MethodDelete)
 	//TODO: add, to consistency with the {connector_cluster_id}/ counterparts
 	//adminRouter.HandleFunc("/kafka_connector_namespaces/{namespace_id}/deployments/{deployment_id}", s.ConnectorAdminHandler.GetNamespaceDeployment).Methods(http.MethodGet)
 	//adminRouter.HandleFunc("/kafka_connector_namespaces/{namespace_id}/deployments/{deployment_id}", s.ConnectorAdminHandler.PatchCNamespaceDeployment).Methods(http.MethodPatch)
-	adminRouter.HandleFunc("/kafka_connectors/{connector_id}", s.ConnectorAdminHandler.GetConnector).Methods(http.MethodGet)
-	adminRouter.HandleFunc("/kafka_connectors/{connector_id}", s.ConnectorAdminHandler.DeleteConnector).Methods(http.MethodDelete)
-	adminRouter.HandleFunc("/kafka_connectors/{connector_id}", s.ConnectorAdminHandler.PatchConnector).Methods(http.MethodPatch)
+	rolesAuthzMiddleware.CheckAdminResources(adminRouter.HandleFunc("/kafka_connectors/{connector_id}", s.ConnectorAdminHandler.GetConnector).Methods(http.MethodGet))
+	rolesAuthzMiddleware.CheckAdminResources(adminRouter.HandleFunc("/kafka_connectors/{connector_id}", s.ConnectorAdminHandler.DeleteConnector).Methods(http.MethodDelete))
+	rolesAuthzMiddleware.CheckAdminResources(adminRouter.HandleFunc("/kafka_connectors/{connector_id}", s.ConnectorAdminHandler.PatchConnector).Methods(http.MethodPatch))
+	adminRouter.HandleFunc("/kafka_connectors/{connector_id}/upgrade_policy", s.ConnectorAdminHandler.GetConnectorUpgradePolicy).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/kafka_connectors/{connector_id}/upgrade_policy", s.ConnectorAdminHandler.PutConnectorUpgradePolicy).Methods(http.MethodPut)
+	adminRouter.HandleFunc("/kafka_connectors/{connector_id}/upgrade_policy", s.ConnectorAdminHandler.DeleteConnectorUpgradePolicy).Methods(http.MethodDelete)
+	adminRouter.HandleFunc("/kafka_connector_upgrades", s.ConnectorAdminHandler.ListConnectorUpgrades).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/kafka_connector_upgrades/{upgrade_id}", s.ConnectorAdminHandler.GetConnectorUpgrade).Methods(http.MethodGet)
 	adminRouter.HandleFunc("/kafka_connector_types", s.ConnectorAdminHandler.ListConnectorTypes).Methods(http.MethodGet)
 	adminRouter.HandleFunc("/kafka_connector_types/{connector_type_id}", s.ConnectorAdminHandler.GetConnectorType).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/kafka_connector_catalog_sources", s.ConnectorAdminHandler.ListConnectorCatalogSources).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/kafka_connector_catalog_sources/{source_id}", s.ConnectorAdminHandler.GetConnectorCatalogSource).Methods(http.MethodGet)
+	auditEventsHandler := coreHandlers.NewAuditEventsHandler(audit.ServiceConnectors, s.AuditEventsService)
+	adminRouter.HandleFunc("/audit_events", auditEventsHandler.List).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/audit_events/export", auditEventsHandler.Export).Methods(http.MethodGet)
 
 	v1Metadata := api.VersionMetadata{
 		ID:          "v1",
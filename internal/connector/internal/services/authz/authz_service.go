@@ -3,6 +3,7 @@ package authz
 import (
 	"context"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
@@ -36,17 +37,20 @@ type authZService struct {
 	clusterService   services.ConnectorClusterService
 	namespaceService services.ConnectorNamespaceService
 	connectorService services.ConnectorsService
+	bindingsService  services.ConnectorNamespaceRoleBindingsService
 }
 
 func NewAuthZService(
 	clusterService services.ConnectorClusterService,
 	namespaceService services.ConnectorNamespaceService,
 	connectorService services.ConnectorsService,
+	bindingsService services.ConnectorNamespaceRoleBindingsService,
 ) *authZService {
 	return &authZService{
 		clusterService:   clusterService,
 		namespaceService: namespaceService,
 		connectorService: connectorService,
+		bindingsService:  bindingsService,
 	}
 }
 
@@ -77,18 +81,21 @@ func (s *authZService) GetValidationUser(ctx context.Context) *ValidationUser {
 	}
 }
 
-func (u *ValidationUser) AuthorizedOrgAdmin() handlers.Validate {
+// AuthorizedCreateCluster checks that the user can create connector clusters for their organisation
+func (u *ValidationUser) AuthorizedCreateCluster() handlers.Validate {
 	return func() (err *errors.ServiceError) {
 		if u.err != nil {
 			err = u.err
-		} else if !u.IsOrgAdmin() {
-			err = unauthorizedError
+		} else {
+			err = auth.RequireAuthorization(u.ctx, auth.ActionCreate, auth.AuthorizationResource{Type: auth.ResourceConnectorCluster},
+				errors.ErrorUnauthorized, "user not authorized")
 		}
 		return err
 	}
 }
 
-func (u *ValidationUser) AuthorizedClusterAdmin() handlers.ValidateOption {
+// AuthorizedClusterAdmin checks that the user can perform the action on a connector cluster of their organisation
+func (u *ValidationUser) AuthorizedClusterAdmin(action string) handlers.ValidateOption {
 	return func(field string, value *string) (err *errors.ServiceError) {
 		if u.err != nil {
 			err = u.err
@@ -98,8 +105,9 @@ func (u *ValidationUser) AuthorizedClusterAdmin() handlers.ValidateOption {
 			} else {
 				if u.OrgId() != orgID {
 					err = errors.NotFound("connector cluster with id='%s' not found", *value)
-				} else if !u.IsOrgAdmin() {
-					err = unauthorizedError
+				} else {
+					err = auth.RequireAuthorization(u.ctx, action, auth.AuthorizationResource{Type: auth.ResourceConnectorCluster, Id: *value, OrganisationId: orgID},
+						errors.ErrorUnauthorized, "user not authorized")
 				}
 			}
 		}
@@ -124,41 +132,60 @@ func (u *ValidationUser) AuthorizedClusterUser() handlers.ValidateOption {
 	}
 }
 
+// AuthorizedNamespaceAdmin checks that the user is a tenant or has the admin role on the namespace
 func (u *ValidationUser) AuthorizedNamespaceAdmin() handlers.ValidateOption {
+	return u.authorizedNamespaceRole(dbapi.ConnectorNamespaceRoleAdmin, errors.ErrorNotFound)
+}
+
+// AuthorizedNamespaceUser checks that the namespace is visible to the user, as a tenant or with any role
+func (u *ValidationUser) AuthorizedNamespaceUser(errorCode errors.ServiceErrorCode) handlers.ValidateOption {
+	return u.authorizedNamespaceRole(dbapi.ConnectorNamespaceRoleViewer, errorCode)
+}
+
+// AuthorizedNamespaceEditor checks that the user can create and update connectors in the namespace
+func (u *ValidationUser) AuthorizedNamespaceEditor(errorCode errors.ServiceErrorCode) handlers.ValidateOption {
+	return u.authorizedNamespaceRole(dbapi.ConnectorNamespaceRoleEditor, errorCode)
+}
+
+func (u *ValidationUser) authorizedNamespaceRole(role dbapi.ConnectorNamespaceRole, errorCode errors.ServiceErrorCode) handlers.ValidateOption {
 	return func(field string, value *string) (err *errors.ServiceError) {
 		if u.err != nil {
 			err = u.err
-		} else {
-			if namespace, serr := u.service.namespaceService.GetNamespaceTenant(*value); serr != nil {
-				err = serr
-			} else {
-				if namespace.TenantUserId != nil && u.UserId() != *namespace.TenantUserId {
-					err = errors.NotFound("Connector namespace with id='%s' not found", *value)
-				} else if namespace.TenantOrganisationId != nil &&
-					!(u.IsOrgAdmin() && u.OrgId() == *namespace.TenantOrganisationId) {
-					err = unauthorizedError
-				}
+		} else if value != nil && len(*value) > 0 {
+			if actual, serr := u.service.bindingsService.GetNamespaceRole(u.ctx, *value); serr != nil {
+				err = errors.New(errorCode, serr.Reason)
+			} else if actual == "" {
+				err = errors.New(errorCode, "Connector namespace with id='%s' not found", *value)
+			} else if !actual.Includes(role) {
+				err = unauthorizedError
 			}
 		}
 		return err
 	}
 }
 
-func (u *ValidationUser) AuthorizedNamespaceUser(errorCode errors.ServiceErrorCode) handlers.ValidateOption {
+// AuthorizedConnectorUser checks that the connector is visible to the user, i.e. owned by the user or by the user's organisation,
+// or in a namespace shared with the user
+func (u *ValidationUser) AuthorizedConnectorUser() handlers.ValidateOption {
 	return func(field string, value *string) (err *errors.ServiceError) {
 		if u.err != nil {
 			err = u.err
 		} else {
-			if value != nil && len(*value) > 0 {
-				if namespace, serr := u.service.namespaceService.GetNamespaceTenant(*value); serr != nil {
-					err = errors.New(errorCode, serr.Reason)
-				} else {
-					if (namespace.TenantUserId != nil && u.UserId() != *namespace.TenantUserId) ||
-						(namespace.TenantOrganisationId != nil && u.OrgId() != *namespace.TenantOrganisationId) {
-						err = errors.New(errorCode, "Connector namespace with id='%s' not found", *value)
-					}
-				}
-			}
+			_, err = u.service.connectorService.Get(u.ctx, *value)
+		}
+		return err
+	}
+}
+
+// AuthorizedConnectorEditor checks that the user can update and delete the connector
+func (u *ValidationUser) AuthorizedConnectorEditor() handlers.ValidateOption {
+	return func(field string, value *string) (err *errors.ServiceError) {
+		if u.err != nil {
+			err = u.err
+		} else if connector, serr := u.service.connectorService.Get(u.ctx, *value); serr != nil {
+			err = serr
+		} else {
+			err = u.service.bindingsService.CheckConnectorRole(u.ctx, &connector.Connector, dbapi.ConnectorNamespaceRoleEditor)
 		}
 		return err
 	}
@@ -188,10 +215,6 @@ func (u *ValidationUser) ValidateNamespaceConnectorQuota() handlers.ValidateOpti
 	}
 }
 
-func (u *User) IsOrgAdmin() bool {
-	return u.claims.IsOrgAdmin()
-}
-
 func (u *User) IsAdmin() bool {
 	return auth.GetIsAdminFromContext(u.ctx)
 }
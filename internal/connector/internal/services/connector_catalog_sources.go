@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/queryparser"
+	"gorm.io/gorm"
+)
+
+type ConnectorCatalogSourcesService interface {
+	// SaveStatus records the result of a catalog sync for every source and removes the sources that aren't configured anymore
+	SaveStatus(ctx context.Context, statuses []config.ConnectorCatalogSourceStatus, now time.Time) *errors.ServiceError
+	List(ctx context.Context, listArgs *services.ListArguments) (dbapi.ConnectorCatalogSourceList, *api.PagingMeta, *errors.ServiceError)
+	Get(ctx context.Context, id string) (*dbapi.ConnectorCatalogSource, *errors.ServiceError)
+}
+
+var _ ConnectorCatalogSourcesService = &connectorCatalogSourcesService{}
+
+type connectorCatalogSourcesService struct {
+	connectionFactory *db.ConnectionFactory
+}
+
+func NewConnectorCatalogSourcesService(connectionFactory *db.ConnectionFactory) *connectorCatalogSourcesService {
+	return &connectorCatalogSourcesService{
+		connectionFactory: connectionFactory,
+	}
+}
+
+// CatalogSourceID returns the id of a catalog source, derived from its name so that it's stable across syncs
+func CatalogSourceID(name string) string {
+	return fmt.Sprintf("%x", sha1.Sum([]byte(name)))
+}
+
+func GetValidCatalogSourceColumns() []string {
+	return []string{"name", "kind", "digest", "verified", "error"}
+}
+
+func (k *connectorCatalogSourcesService) SaveStatus(ctx context.Context, statuses []config.ConnectorCatalogSourceStatus, now time.Time) *errors.ServiceError {
+	ids := make([]string, 0, len(statuses))
+	if err := k.connectionFactory.New().Transaction(func(dbConn *gorm.DB) error {
+		for _, status := range statuses {
+			id := CatalogSourceID(status.Name)
+			ids = append(ids, id)
+
+			var source dbapi.ConnectorCatalogSource
+			if err := dbConn.Where("id = ?", id).First(&source).Error; err != nil {
+				if !services.IsRecordNotFoundError(err) {
+					return err
+				}
+				source.ID = id
+			}
+
+			source.Name = status.Name
+			source.Kind = status.Kind
+			source.LastSyncAt = now
+			if status.Error != nil {
+				// keep the digest of the last successful sync
+				source.Error = status.Error.Error()
+			} else {
+				source.Digest = status.Digest
+				source.Entries = status.Entries
+				source.Verified = status.Verified
+				source.Error = ""
+				source.LastSuccessAt = &now
+			}
+			if err := dbConn.Save(&source).Error; err != nil {
+				return err
+			}
+		}
+
+		query := dbConn.Unscoped()
+		if len(ids) > 0 {
+			query = query.Where("id NOT IN ?", ids)
+		} else {
+			query = query.Where("1 = 1")
+		}
+		return query.Delete(&dbapi.ConnectorCatalogSource{}).Error
+	}); err != nil {
+		return errors.GeneralError("failed to save connector catalog source status: %v", err)
+	}
+	return nil
+}
+
+func (k *connectorCatalogSourcesService) List(ctx context.Context, listArgs *services.ListArguments) (dbapi.ConnectorCatalogSourceList, *api.PagingMeta, *errors.ServiceError) {
+	var resourceList dbapi.ConnectorCatalogSourceList
+	dbConn := k.connectionFactory.New()
+	pagingMeta := &api.PagingMeta{
+		Page: listArgs.Page,
+		Size: listArgs.Size,
+	}
+
+	// Apply search query
+	if len(listArgs.Search) > 0 {
+		queryParser := coreServices.NewQueryParser(GetValidCatalogSourceColumns()...)
+		searchDbQuery, err := queryParser.Parse(listArgs.Search)
+		if err != nil {
+			return resourceList, pagingMeta, errors.NewWithCause(errors.ErrorFailedToParseSearch, err, "unable to list connector catalog sources: %s", err.Error())
+		}
+		dbConn = dbConn.Where(searchDbQuery.Query, searchDbQuery.Values...)
+	}
+
+	total := int64(pagingMeta.Total)
+	dbConn.Session(&gorm.Session{}).Model(&resourceList).Count(&total)
+	pagingMeta.Total = int(total)
+	if pagingMeta.Size > pagingMeta.Total {
+		pagingMeta.Size = pagingMeta.Total
+	}
+	dbConn = dbConn.Offset((pagingMeta.Page - 1) * pagingMeta.Size).Limit(pagingMeta.Size)
+
+	if err := dbConn.Order("name").Find(&resourceList).Error; err != nil {
+		return resourceList, pagingMeta, errors.GeneralError("unable to list connector catalog sources: %v", err)
+	}
+	return resourceList, pagingMeta, nil
+}
+
+func (k *connectorCatalogSourcesService) Get(ctx context.Context, id string) (*dbapi.ConnectorCatalogSource, *errors.ServiceError) {
+	var source dbapi.ConnectorCatalogSource
+	if err := k.connectionFactory.New().Where("id = ?", id).First(&source).Error; err != nil {
+		return nil, services.HandleGetError("Connector catalog source", "id", id, err)
+	}
+	return &source, nil
+}
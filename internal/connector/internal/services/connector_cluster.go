@@ -14,15 +14,18 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db/dbencryption"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
 	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/queryparser"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
 	"github.com/golang/glog"
 	"gorm.io/gorm"
 )
 
+//go:generate moq -out connector_cluster_moq.go . ConnectorClusterService
 type ConnectorClusterService interface {
 	Create(ctx context.Context, resource *dbapi.ConnectorCluster) *errors.ServiceError
 	Get(ctx context.Context, id string) (*dbapi.ConnectorCluster, *errors.ServiceError)
@@ -58,11 +61,13 @@ type connectorClusterService struct {
 	keycloakService           sso.KafkaKeycloakService
 	connectorsService         ConnectorsService
 	connectorNamespaceService ConnectorNamespaceService
+	webhookService            webhooks.WebhookService
 }
 
 func NewConnectorClusterService(connectionFactory *db.ConnectionFactory, bus signalbus.SignalBus, vaultService vault.VaultService,
 	connectorTypesService ConnectorTypesService, connectorsService ConnectorsService,
-	keycloakService sso.KafkaKeycloakService, connectorNamespaceService ConnectorNamespaceService) *connectorClusterService {
+	keycloakService sso.KafkaKeycloakService, connectorNamespaceService ConnectorNamespaceService,
+	webhookService webhooks.WebhookService) *connectorClusterService {
 	return &connectorClusterService{
 		connectionFactory:         connectionFactory,
 		bus:                       bus,
@@ -71,6 +76,7 @@ func NewConnectorClusterService(connectionFactory *db.ConnectionFactory, bus sig
 		connectorsService:         connectorsService,
 		keycloakService:           keycloakService,
 		connectorNamespaceService: connectorNamespaceService,
+		webhookService:            webhookService,
 	}
 }
 
@@ -152,10 +158,13 @@ func filterClusterToOwnerOrOrg(ctx context.Context, dbConn *gorm.DB) (*gorm.DB,
 	}
 
 	orgId, _ := claims.GetOrgId()
-	filterByOrganisationId := auth.GetFilterByOrganisationFromContext(ctx)
+	decision, serr := auth.AuthorizeList(ctx, auth.ResourceConnectorCluster)
+	if serr != nil {
+		return dbConn, serr
+	}
 
 	// filter by organisationId if a user is part of an organisation and is not allowed as a service account
-	if filterByOrganisationId {
+	if decision.FilterByOrganisation {
 		// include namespaces where either user or organisation is the tenant
 		dbConn = dbConn.Where("organisation_id = ?", orgId)
 	} else {
@@ -496,7 +505,7 @@ func (k *connectorClusterService) UpdateConnectorDeploymentStatus(ctx context.Co
 
 	// lets get the connector id of the deployment..
 	deployment := dbapi.ConnectorDeployment{}
-	if err := dbConn.Unscoped().Select("connector_id", "deleted_at").
+	if err := dbConn.Unscoped().Select("connector_id", "deleted_at", "version", "config_revision").
 		Where("id = ?", deploymentStatus.ID).
 		First(&deployment).Error; err != nil {
 		return services.HandleGetError("Connector deployment", "id", deploymentStatus.ID, err)
@@ -505,6 +514,11 @@ func (k *connectorClusterService) UpdateConnectorDeploymentStatus(ctx context.Co
 		return services.HandleGoneError("Connector deployment", "id", deploymentStatus.ID)
 	}
 
+	// agents that don't report the configuration revision report the status of the current deployment version
+	if deploymentStatus.ConfigRevision == 0 && deploymentStatus.Version == deployment.Version {
+		deploymentStatus.ConfigRevision = deployment.ConfigRevision
+	}
+
 	if err := dbConn.Model(&deploymentStatus).Where("id = ? and version <= ?", deploymentStatus.ID, deploymentStatus.Version).Save(&deploymentStatus).Error; err != nil {
 		return errors.Conflict("failed to update deployment status: %s, probably a stale deployment status version was used: %d", err.Error(), deploymentStatus.Version)
 	}
@@ -523,6 +537,7 @@ func (k *connectorClusterService) UpdateConnectorDeploymentStatus(ctx context.Co
 		return services.HandleGetError("Connector", "id", deployment.ConnectorID, err)
 	}
 
+	previousPhase := connectorStatus.Phase
 	connectorStatus.Phase = deploymentStatus.Phase
 	if deploymentStatus.Phase == dbapi.ConnectorStatusPhaseDeleted {
 		// we don't need the deployment anymore...
@@ -531,14 +546,59 @@ func (k *connectorClusterService) UpdateConnectorDeploymentStatus(ctx context.Co
 		}
 	}
 
-	// update the connector status
-	if err := dbConn.Where("id = ?", deployment.ConnectorID).Updates(&connectorStatus).Error; err != nil {
-		return services.HandleUpdateError("Connector status", err)
+	// update the connector status, the webhook event of a failed connector is added to the outbox in the same transaction
+	if err := dbConn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ?", deployment.ConnectorID).Updates(&connectorStatus).Error; err != nil {
+			return services.HandleUpdateError("Connector status", err)
+		}
+		if connectorStatus.Phase == dbapi.ConnectorStatusPhaseFailed && previousPhase != dbapi.ConnectorStatusPhaseFailed {
+			if err := k.enqueueConnectorFailedWebhookEvent(tx, deployment.ConnectorID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return errors.ToServiceError(err)
 	}
 
 	return nil
 }
 
+// connectorWebhookData is the connector sent in the webhook deliveries
+type connectorWebhookData struct {
+	Id              string  `json:"id"`
+	Name            string  `json:"name"`
+	Owner           string  `json:"owner"`
+	NamespaceId     *string `json:"namespace_id,omitempty"`
+	ConnectorTypeId string  `json:"connector_type_id"`
+	DesiredState    string  `json:"desired_state"`
+	State           string  `json:"state"`
+}
+
+func (k *connectorClusterService) enqueueConnectorFailedWebhookEvent(dbConn *gorm.DB, connectorID string) *errors.ServiceError {
+	connector := dbapi.Connector{}
+	if err := dbConn.Select("id", "name", "owner", "organisation_id", "namespace_id", "connector_type_id", "desired_state").
+		Where("id = ?", connectorID).
+		First(&connector).Error; err != nil {
+		return services.HandleGetError("Connector", "id", connectorID, err)
+	}
+
+	return k.webhookService.Enqueue(dbConn, webhooks.Event{
+		Type:           webhooks.EventConnectorFailed,
+		OrganisationId: connector.OrganisationId,
+		ResourceId:     connector.ID,
+		Data: connectorWebhookData{
+			Id:              connector.ID,
+			Name:            connector.Name,
+			Owner:           connector.Owner,
+			NamespaceId:     connector.NamespaceId,
+			ConnectorTypeId: connector.ConnectorTypeId,
+			DesiredState:    string(connector.DesiredState),
+			State:           string(dbapi.ConnectorStatusPhaseFailed),
+		},
+	})
+}
+
 func (k *connectorClusterService) FindAvailableNamespace(owner string, orgID string, namespaceID *string) (*dbapi.ConnectorNamespace, *errors.ServiceError) {
 	dbConn := k.connectionFactory.New()
 	var namespaces dbapi.ConnectorNamespaceList
@@ -686,7 +746,7 @@ func (k *connectorClusterService) ResetServiceAccount(ctx context.Context, clust
 	if serr != nil {
 		return serr
 	}
-	cluster.ClientSecret = secret
+	cluster.ClientSecret = dbencryption.EncryptedString(secret)
 
 	if err := k.connectionFactory.New().UpdateColumns(dbapi.ConnectorCluster{
 		Model:        db.Model{ID: cluster.ID},
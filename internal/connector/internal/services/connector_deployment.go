@@ -22,5 +22,11 @@ func deleteConnectorDeployment(dbConn *gorm.DB, id string) *errors.ServiceError
 			return err
 		}
 	}
+	if err := dbConn.Unscoped().Where("deployment_id = ?", id).Delete(&dbapi.ConnectorLogLine{}).Error; err != nil {
+		err := services.HandleDeleteError("ConnectorLogLine", "deployment_id", id, err)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
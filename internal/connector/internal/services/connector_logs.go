@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"gorm.io/gorm"
+)
+
+// maxConnectorLogMessageLength is the maximum length of a log line message, longer messages are truncated
+const maxConnectorLogMessageLength = 4096
+
+// ConnectorLogsService keeps the most recent log lines of connectors.
+// The default implementation stores the log lines pushed by agents with the deployment status,
+// a different log source can be plugged in by providing another implementation of the interface.
+type ConnectorLogsService interface {
+	// AppendDeploymentLogs stores the log lines of a deployment, the oldest log lines of the connector over the configured limit are dropped
+	AppendDeploymentLogs(ctx context.Context, deploymentId string, lines dbapi.ConnectorLogLineList) *errors.ServiceError
+	// Tail returns the last log lines of a connector, oldest first
+	Tail(ctx context.Context, connectorId string, lines int) (dbapi.ConnectorLogLineList, *errors.ServiceError)
+	// MaxLines returns the maximum number of log lines kept for a connector, 0 if logs are disabled
+	MaxLines() int
+}
+
+var _ ConnectorLogsService = &connectorLogsService{}
+
+type connectorLogsService struct {
+	connectionFactory *db.ConnectionFactory
+	connectorsConfig  *config.ConnectorsConfig
+}
+
+func NewConnectorLogsService(connectionFactory *db.ConnectionFactory, connectorsConfig *config.ConnectorsConfig) *connectorLogsService {
+	return &connectorLogsService{
+		connectionFactory: connectionFactory,
+		connectorsConfig:  connectorsConfig,
+	}
+}
+
+func (k *connectorLogsService) MaxLines() int {
+	return k.connectorsConfig.ConnectorLogTailLines
+}
+
+func (k *connectorLogsService) AppendDeploymentLogs(ctx context.Context, deploymentId string, lines dbapi.ConnectorLogLineList) *errors.ServiceError {
+	maxLines := k.MaxLines()
+	if maxLines <= 0 || len(lines) == 0 {
+		return nil
+	}
+	if len(lines) > maxLines {
+		lines = lines[len(lines)-maxLines:]
+	}
+
+	dbConn := k.connectionFactory.New()
+	var deployment dbapi.ConnectorDeployment
+	if err := dbConn.Select("connector_id").Where("id = ?", deploymentId).First(&deployment).Error; err != nil {
+		return services.HandleGetError("Connector deployment", "id", deploymentId, err)
+	}
+
+	for _, line := range lines {
+		line.ID = api.NewID()
+		line.ConnectorID = deployment.ConnectorID
+		line.DeploymentID = deploymentId
+		if len(line.Message) > maxConnectorLogMessageLength {
+			line.Message = line.Message[:maxConnectorLogMessageLength]
+		}
+	}
+
+	if err := dbConn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&lines).Error; err != nil {
+			return err
+		}
+		// drop the oldest log lines of the connector over the limit
+		kept := tx.Model(&dbapi.ConnectorLogLine{}).Select("id").
+			Where("connector_id = ?", deployment.ConnectorID).
+			Order("timestamp desc, id desc").Limit(maxLines)
+		return tx.Unscoped().Where("connector_id = ? AND id NOT IN (?)", deployment.ConnectorID, kept).
+			Delete(&dbapi.ConnectorLogLine{}).Error
+	}); err != nil {
+		return errors.GeneralError("failed to store log lines of connector deployment %s: %v", deploymentId, err)
+	}
+	return nil
+}
+
+func (k *connectorLogsService) Tail(ctx context.Context, connectorId string, lines int) (dbapi.ConnectorLogLineList, *errors.ServiceError) {
+	var result dbapi.ConnectorLogLineList
+	if maxLines := k.MaxLines(); lines > maxLines {
+		lines = maxLines
+	}
+	if lines <= 0 {
+		return result, nil
+	}
+
+	if err := k.connectionFactory.New().Where("connector_id = ?", connectorId).
+		Order("timestamp desc, id desc").Limit(lines).Find(&result).Error; err != nil {
+		return nil, errors.GeneralError("failed to read log lines of connector %s: %v", connectorId, err)
+	}
+
+	// oldest first
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+	return result, nil
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_connectorLogsService_Tail(t *testing.T) {
+	now := time.Now()
+	rows := []map[string]interface{}{
+		{"id": "3", "connector_id": "c1", "timestamp": now, "message": "third"},
+		{"id": "2", "connector_id": "c1", "timestamp": now.Add(-time.Second), "message": "second"},
+		{"id": "1", "connector_id": "c1", "timestamp": now.Add(-2 * time.Second), "message": "first"},
+	}
+
+	tests := []struct {
+		name      string
+		maxLines  int
+		lines     int
+		wantLimit string
+		want      []string
+	}{
+		{
+			name:      "returns the last log lines oldest first",
+			maxLines:  500,
+			lines:     3,
+			wantLimit: "LIMIT 3",
+			want:      []string{"first", "second", "third"},
+		},
+		{
+			name:      "lines are capped to the configured maximum",
+			maxLines:  50,
+			lines:     100,
+			wantLimit: "LIMIT 50",
+			want:      []string{"first", "second", "third"},
+		},
+		{
+			name:     "logs disabled",
+			maxLines: 0,
+			lines:    100,
+			want:     []string{},
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			mocket.Catcher.Reset()
+			var query string
+			mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "connector_log_lines"`).
+				WithCallback(func(q string, _ []driver.NamedValue) { query = q }).
+				WithReply(rows)
+
+			connectorsConfig := config.NewConnectorsConfig()
+			connectorsConfig.ConnectorLogTailLines = tt.maxLines
+			k := NewConnectorLogsService(db.NewMockConnectionFactory(nil), connectorsConfig)
+
+			result, err := k.Tail(context.Background(), "c1", tt.lines)
+			g.Expect(err).To(gomega.BeNil())
+			messages := []string{}
+			for _, line := range result {
+				messages = append(messages, line.Message)
+			}
+			g.Expect(messages).To(gomega.Equal(tt.want))
+			g.Expect(strings.Contains(query, tt.wantLimit)).To(gomega.BeTrue())
+		})
+	}
+}
+
+func Test_connectorLogsService_AppendDeploymentLogs(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	mocket.Catcher.Reset()
+	mocket.Catcher.NewMock().WithQuery(`SELECT "connector_id" FROM "connector_deployments"`).
+		WithReply([]map[string]interface{}{{"connector_id": "c1"}})
+	var inserted []interface{}
+	mocket.Catcher.NewMock().WithQuery(`INSERT INTO "connector_log_lines"`).
+		WithCallback(func(_ string, args []driver.NamedValue) {
+			for _, arg := range args {
+				inserted = append(inserted, arg.Value)
+			}
+		})
+	deleteMock := mocket.Catcher.NewMock().WithQuery(`DELETE FROM "connector_log_lines" WHERE connector_id = $1 AND id NOT IN (SELECT "id" FROM "connector_log_lines"`)
+
+	connectorsConfig := config.NewConnectorsConfig()
+	connectorsConfig.ConnectorLogTailLines = 2
+	k := NewConnectorLogsService(db.NewMockConnectionFactory(nil), connectorsConfig)
+
+	lines := dbapi.ConnectorLogLineList{
+		{Message: "dropped"},
+		{Message: "kept"},
+		{Message: strings.Repeat("x", maxConnectorLogMessageLength+10)},
+	}
+	g.Expect(k.AppendDeploymentLogs(context.Background(), "d1", lines)).To(gomega.BeNil())
+	g.Expect(inserted).ToNot(gomega.ContainElement("dropped"))
+	g.Expect(inserted).To(gomega.ContainElement("kept"))
+	g.Expect(inserted).To(gomega.ContainElement(strings.Repeat("x", maxConnectorLogMessageLength)))
+	g.Expect(inserted).To(gomega.ContainElement("c1"))
+	g.Expect(deleteMock.Triggered).To(gomega.BeTrue())
+}
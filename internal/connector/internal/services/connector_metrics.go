@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/observatorium"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+)
+
+type ConnectorMetricsService interface {
+	// GetMetricsByConnectorId queries the observatorium for the metrics of the pods of a connector
+	GetMetricsByConnectorId(ctx context.Context, metrics *observatorium.ConnectorMetrics, connectorId string, query observatorium.MetricsReqParams) *errors.ServiceError
+}
+
+var _ ConnectorMetricsService = &connectorMetricsService{}
+
+type connectorMetricsService struct {
+	metricsConfig *config.ConnectorMetricsConfig
+	clientOnce    sync.Once
+	client        *observatorium.Client
+	clientErr     error
+}
+
+func NewConnectorMetricsService(metricsConfig *config.ConnectorMetricsConfig) *connectorMetricsService {
+	return &connectorMetricsService{
+		metricsConfig: metricsConfig,
+	}
+}
+
+func (k *connectorMetricsService) GetMetricsByConnectorId(ctx context.Context, metrics *observatorium.ConnectorMetrics, connectorId string, query observatorium.MetricsReqParams) *errors.ServiceError {
+	if !k.metricsConfig.Enabled() {
+		return errors.New(errors.ErrorNotImplemented, "connector metrics are not enabled")
+	}
+	client, err := k.observatoriumClient()
+	if err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to create observatorium client")
+	}
+	if err := client.Service.GetConnectorMetrics(metrics, connectorId, &query); err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to retrieve metrics")
+	}
+	return nil
+}
+
+// observatoriumClient creates the observatorium client on first use, no client is created while connector metrics are disabled
+func (k *connectorMetricsService) observatoriumClient() (*observatorium.Client, error) {
+	k.clientOnce.Do(func() {
+		clientConfig := &observatorium.Configuration{
+			BaseURL:   k.metricsConfig.ObservatoriumURL,
+			AuthToken: k.metricsConfig.AuthToken,
+			Timeout:   k.metricsConfig.Timeout,
+			Insecure:  k.metricsConfig.Insecure,
+		}
+		if k.metricsConfig.EnableMock {
+			k.client, k.clientErr = observatorium.NewClientMock(clientConfig)
+		} else {
+			k.client, k.clientErr = observatorium.NewClient(clientConfig)
+		}
+	})
+	return k.client, k.clientErr
+}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"gorm.io/gorm"
+)
+
+//go:generate moq -out connector_namespace_role_bindings_moq.go . ConnectorNamespaceRoleBindingsService
+
+// ConnectorNamespaceRoleBindingsService manages the roles granted on namespaces, and resolves the role of users on namespaces.
+// The tenant user of a namespace and the admins of its tenant organisation are namespace admins,
+// the other users of the tenant organisation are namespace editors.
+type ConnectorNamespaceRoleBindingsService interface {
+	Create(ctx context.Context, binding *dbapi.ConnectorNamespaceRoleBinding) *errors.ServiceError
+	Get(ctx context.Context, namespaceId string, bindingId string) (*dbapi.ConnectorNamespaceRoleBinding, *errors.ServiceError)
+	List(ctx context.Context, namespaceId string) (dbapi.ConnectorNamespaceRoleBindingList, *errors.ServiceError)
+	Update(ctx context.Context, binding *dbapi.ConnectorNamespaceRoleBinding) *errors.ServiceError
+	Delete(ctx context.Context, namespaceId string, bindingId string) *errors.ServiceError
+	// GetNamespaceRole returns the highest role of the user of the context on a namespace, or an empty role if the namespace isn't visible to the user
+	GetNamespaceRole(ctx context.Context, namespaceId string) (dbapi.ConnectorNamespaceRole, *errors.ServiceError)
+	// CheckConnectorRole checks that the user of the context has a role on the namespace of a connector,
+	// connectors without a namespace are only accessible to their owner and organisation
+	CheckConnectorRole(ctx context.Context, connector *dbapi.Connector, role dbapi.ConnectorNamespaceRole) *errors.ServiceError
+}
+
+var _ ConnectorNamespaceRoleBindingsService = &connectorNamespaceRoleBindingsService{}
+
+type connectorNamespaceRoleBindingsService struct {
+	connectionFactory *db.ConnectionFactory
+}
+
+func NewConnectorNamespaceRoleBindingsService(connectionFactory *db.ConnectionFactory) *connectorNamespaceRoleBindingsService {
+	return &connectorNamespaceRoleBindingsService{
+		connectionFactory: connectionFactory,
+	}
+}
+
+// namespaceSubjects are the subjects the user of a request can be granted namespace roles as
+type namespaceSubjects struct {
+	UserId   string
+	OrgId    string
+	ClientId string
+	Groups   []string
+	OrgAdmin bool
+}
+
+func namespaceSubjectsFromContext(ctx context.Context) (namespaceSubjects, *errors.ServiceError) {
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil {
+		return namespaceSubjects{}, errors.Unauthenticated("user not authenticated")
+	}
+	userId, _ := claims.GetUsername()
+	if userId == "" {
+		return namespaceSubjects{}, errors.Unauthenticated("user not authenticated")
+	}
+	orgId, _ := claims.GetOrgId()
+	clientId, _ := claims.GetClientID()
+	return namespaceSubjects{
+		UserId:   userId,
+		OrgId:    orgId,
+		ClientId: clientId,
+		Groups:   claims.GetGroups(),
+		OrgAdmin: claims.IsOrgAdmin(),
+	}, nil
+}
+
+// boundNamespaces returns a query of the ids of the namespaces bound to the subjects with at least the given role
+func boundNamespaces(dbConn *gorm.DB, subjects namespaceSubjects, role dbapi.ConnectorNamespaceRole) *gorm.DB {
+	conditions := []string{"(subject_kind = ? AND subject = ?)"}
+	values := []interface{}{dbapi.ConnectorNamespaceSubjectUser, subjects.UserId}
+	if subjects.OrgId != "" {
+		conditions = append(conditions, "(subject_kind = ? AND subject = ?)")
+		values = append(values, dbapi.ConnectorNamespaceSubjectOrganisation, subjects.OrgId)
+	}
+	if subjects.ClientId != "" {
+		conditions = append(conditions, "(subject_kind = ? AND subject = ?)")
+		values = append(values, dbapi.ConnectorNamespaceSubjectServiceAccount, subjects.ClientId)
+	}
+	if len(subjects.Groups) != 0 && subjects.OrgId != "" {
+		// group names are only unique within an organisation, so group bindings only apply to the namespaces of the organisation
+		conditions = append(conditions, "(subject_kind = ? AND subject IN ? AND namespace_id IN (?))")
+		values = append(values, dbapi.ConnectorNamespaceSubjectGroup, subjects.Groups,
+			dbConn.Session(&gorm.Session{NewDB: true}).Table("connector_namespaces").Select("id").
+				Where("tenant_organisation_id = ?", subjects.OrgId))
+	}
+
+	var roles []string
+	for _, r := range dbapi.ValidConnectorNamespaceRoles {
+		if dbapi.ConnectorNamespaceRole(r).Includes(role) {
+			roles = append(roles, r)
+		}
+	}
+	return dbConn.Model(&dbapi.ConnectorNamespaceRoleBinding{}).Select("namespace_id").
+		Where(strings.Join(conditions, " OR "), values...).
+		Where("role IN ?", roles)
+}
+
+// visibleNamespaces returns a query of the ids of the namespaces visible to the subjects, as tenants or with a role binding
+func visibleNamespaces(factory *db.ConnectionFactory, subjects namespaceSubjects) *gorm.DB {
+	return factory.New().Table("connector_namespaces").Select("id").
+		Where("deleted_at IS NULL AND (tenant_user_id = ? OR tenant_organisation_id = ? OR id IN (?))",
+			subjects.UserId, subjects.OrgId, boundNamespaces(factory.New(), subjects, dbapi.ConnectorNamespaceRoleViewer))
+}
+
+func (k *connectorNamespaceRoleBindingsService) Create(ctx context.Context, binding *dbapi.ConnectorNamespaceRoleBinding) *errors.ServiceError {
+	if err := k.connectionFactory.New().Create(binding).Error; err != nil {
+		return services.HandleCreateError("Connector namespace role binding", err)
+	}
+	return nil
+}
+
+func (k *connectorNamespaceRoleBindingsService) Get(ctx context.Context, namespaceId string, bindingId string) (*dbapi.ConnectorNamespaceRoleBinding, *errors.ServiceError) {
+	var binding dbapi.ConnectorNamespaceRoleBinding
+	if err := k.connectionFactory.New().Where("id = ? AND namespace_id = ?", bindingId, namespaceId).
+		First(&binding).Error; err != nil {
+		return nil, services.HandleGetError("Connector namespace role binding", "id", bindingId, err)
+	}
+	return &binding, nil
+}
+
+func (k *connectorNamespaceRoleBindingsService) List(ctx context.Context, namespaceId string) (dbapi.ConnectorNamespaceRoleBindingList, *errors.ServiceError) {
+	var bindings dbapi.ConnectorNamespaceRoleBindingList
+	if err := k.connectionFactory.New().Where("namespace_id = ?", namespaceId).
+		Order("subject_kind, subject").Find(&bindings).Error; err != nil {
+		return nil, errors.GeneralError("failed to list role bindings of connector namespace %s: %v", namespaceId, err)
+	}
+	return bindings, nil
+}
+
+func (k *connectorNamespaceRoleBindingsService) Update(ctx context.Context, binding *dbapi.ConnectorNamespaceRoleBinding) *errors.ServiceError {
+	if err := k.connectionFactory.New().Model(binding).Select("role").Updates(binding).Error; err != nil {
+		return services.HandleUpdateError("Connector namespace role binding", err)
+	}
+	return nil
+}
+
+func (k *connectorNamespaceRoleBindingsService) Delete(ctx context.Context, namespaceId string, bindingId string) *errors.ServiceError {
+	result := k.connectionFactory.New().Unscoped().Where("id = ? AND namespace_id = ?", bindingId, namespaceId).
+		Delete(&dbapi.ConnectorNamespaceRoleBinding{})
+	if result.Error != nil {
+		return services.HandleDeleteError("Connector namespace role binding", "id", bindingId, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.NotFound("Connector namespace role binding with id='%s' not found", bindingId)
+	}
+	return nil
+}
+
+func (k *connectorNamespaceRoleBindingsService) GetNamespaceRole(ctx context.Context, namespaceId string) (dbapi.ConnectorNamespaceRole, *errors.ServiceError) {
+	if auth.GetIsAdminFromContext(ctx) {
+		return dbapi.ConnectorNamespaceRoleAdmin, nil
+	}
+	subjects, serr := namespaceSubjectsFromContext(ctx)
+	if serr != nil {
+		return "", serr
+	}
+
+	dbConn := k.connectionFactory.New()
+	var namespace dbapi.ConnectorNamespace
+	if err := dbConn.Where("id = ?", namespaceId).
+		Select("id", "tenant_user_id", "tenant_organisation_id").First(&namespace).Error; err != nil {
+		return "", services.HandleGetError("Connector namespace", "id", namespaceId, err)
+	}
+	if namespace.TenantUserId != nil && *namespace.TenantUserId == subjects.UserId {
+		return dbapi.ConnectorNamespaceRoleAdmin, nil
+	}
+
+	var role dbapi.ConnectorNamespaceRole
+	if namespace.TenantOrganisationId != nil && subjects.OrgId != "" && *namespace.TenantOrganisationId == subjects.OrgId {
+		if subjects.OrgAdmin {
+			return dbapi.ConnectorNamespaceRoleAdmin, nil
+		}
+		role = dbapi.ConnectorNamespaceRoleEditor
+	}
+
+	var bound []string
+	if err := boundNamespaces(dbConn, subjects, dbapi.ConnectorNamespaceRoleViewer).Select("role").
+		Where("namespace_id = ?", namespaceId).Find(&bound).Error; err != nil {
+		return "", services.HandleGetError("Connector namespace role binding", "namespace_id", namespaceId, err)
+	}
+	for _, r := range bound {
+		if dbapi.ConnectorNamespaceRole(r).Includes(role) || role == "" {
+			role = dbapi.ConnectorNamespaceRole(r)
+		}
+	}
+	return role, nil
+}
+
+func (k *connectorNamespaceRoleBindingsService) CheckConnectorRole(ctx context.Context, connector *dbapi.Connector, role dbapi.ConnectorNamespaceRole) *errors.ServiceError {
+	if connector.NamespaceId == nil || *connector.NamespaceId == "" {
+		if auth.GetIsAdminFromContext(ctx) {
+			return nil
+		}
+		subjects, serr := namespaceSubjectsFromContext(ctx)
+		if serr != nil {
+			return serr
+		}
+		if connector.Owner != subjects.UserId && (subjects.OrgId == "" || connector.OrganisationId != subjects.OrgId) {
+			return errors.NotFound("Connector with id='%s' not found", connector.ID)
+		}
+		return nil
+	}
+
+	actual, serr := k.GetNamespaceRole(ctx, *connector.NamespaceId)
+	if serr != nil {
+		return serr
+	}
+	if actual == "" {
+		return errors.NotFound("Connector with id='%s' not found", connector.ID)
+	}
+	if !actual.Includes(role) {
+		return errors.Forbidden("the %s role on connector namespace %s is required, the user has the %s role", role, *connector.NamespaceId, actual)
+	}
+	return nil
+}
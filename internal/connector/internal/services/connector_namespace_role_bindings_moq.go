@@ -0,0 +1,401 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package services
+
+import (
+	"context"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"sync"
+)
+
+// Ensure, that ConnectorNamespaceRoleBindingsServiceMock does implement ConnectorNamespaceRoleBindingsService.
+// If this is not the case, regenerate this file with moq.
+var _ ConnectorNamespaceRoleBindingsService = &ConnectorNamespaceRoleBindingsServiceMock{}
+
+// ConnectorNamespaceRoleBindingsServiceMock is a mock implementation of ConnectorNamespaceRoleBindingsService.
+//
+//	func TestSomethingThatUsesConnectorNamespaceRoleBindingsService(t *testing.T) {
+//
+//		// make and configure a mocked ConnectorNamespaceRoleBindingsService
+//		mockedConnectorNamespaceRoleBindingsService := &ConnectorNamespaceRoleBindingsServiceMock{
+//			CheckConnectorRoleFunc: func(ctx context.Context, connector *dbapi.Connector, role dbapi.ConnectorNamespaceRole) *errors.ServiceError {
+//				panic("mock out the CheckConnectorRole method")
+//			},
+//			CreateFunc: func(ctx context.Context, binding *dbapi.ConnectorNamespaceRoleBinding) *errors.ServiceError {
+//				panic("mock out the Create method")
+//			},
+//			DeleteFunc: func(ctx context.Context, namespaceId string, bindingId string) *errors.ServiceError {
+//				panic("mock out the Delete method")
+//			},
+//			GetFunc: func(ctx context.Context, namespaceId string, bindingId string) (*dbapi.ConnectorNamespaceRoleBinding, *errors.ServiceError) {
+//				panic("mock out the Get method")
+//			},
+//			GetNamespaceRoleFunc: func(ctx context.Context, namespaceId string) (dbapi.ConnectorNamespaceRole, *errors.ServiceError) {
+//				panic("mock out the GetNamespaceRole method")
+//			},
+//			ListFunc: func(ctx context.Context, namespaceId string) (dbapi.ConnectorNamespaceRoleBindingList, *errors.ServiceError) {
+//				panic("mock out the List method")
+//			},
+//			UpdateFunc: func(ctx context.Context, binding *dbapi.ConnectorNamespaceRoleBinding) *errors.ServiceError {
+//				panic("mock out the Update method")
+//			},
+//		}
+//
+//		// use mockedConnectorNamespaceRoleBindingsService in code that requires ConnectorNamespaceRoleBindingsService
+//		// and then make assertions.
+//
+//	}
+type ConnectorNamespaceRoleBindingsServiceMock struct {
+	// CheckConnectorRoleFunc mocks the CheckConnectorRole method.
+	CheckConnectorRoleFunc func(ctx context.Context, connector *dbapi.Connector, role dbapi.ConnectorNamespaceRole) *errors.ServiceError
+
+	// CreateFunc mocks the Create method.
+	CreateFunc func(ctx context.Context, binding *dbapi.ConnectorNamespaceRoleBinding) *errors.ServiceError
+
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(ctx context.Context, namespaceId string, bindingId string) *errors.ServiceError
+
+	// GetFunc mocks the Get method.
+	GetFunc func(ctx context.Context, namespaceId string, bindingId string) (*dbapi.ConnectorNamespaceRoleBinding, *errors.ServiceError)
+
+	// GetNamespaceRoleFunc mocks the GetNamespaceRole method.
+	GetNamespaceRoleFunc func(ctx context.Context, namespaceId string) (dbapi.ConnectorNamespaceRole, *errors.ServiceError)
+
+	// ListFunc mocks the List method.
+	ListFunc func(ctx context.Context, namespaceId string) (dbapi.ConnectorNamespaceRoleBindingList, *errors.ServiceError)
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(ctx context.Context, binding *dbapi.ConnectorNamespaceRoleBinding) *errors.ServiceError
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CheckConnectorRole holds details about calls to the CheckConnectorRole method.
+		CheckConnectorRole []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Connector is the connector argument value.
+			Connector *dbapi.Connector
+			// Role is the role argument value.
+			Role dbapi.ConnectorNamespaceRole
+		}
+		// Create holds details about calls to the Create method.
+		Create []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Binding is the binding argument value.
+			Binding *dbapi.ConnectorNamespaceRoleBinding
+		}
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// NamespaceId is the namespaceId argument value.
+			NamespaceId string
+			// BindingId is the bindingId argument value.
+			BindingId string
+		}
+		// Get holds details about calls to the Get method.
+		Get []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// NamespaceId is the namespaceId argument value.
+			NamespaceId string
+			// BindingId is the bindingId argument value.
+			BindingId string
+		}
+		// GetNamespaceRole holds details about calls to the GetNamespaceRole method.
+		GetNamespaceRole []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// NamespaceId is the namespaceId argument value.
+			NamespaceId string
+		}
+		// List holds details about calls to the List method.
+		List []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// NamespaceId is the namespaceId argument value.
+			NamespaceId string
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Binding is the binding argument value.
+			Binding *dbapi.ConnectorNamespaceRoleBinding
+		}
+	}
+	lockCheckConnectorRole sync.RWMutex
+	lockCreate             sync.RWMutex
+	lockDelete             sync.RWMutex
+	lockGet                sync.RWMutex
+	lockGetNamespaceRole   sync.RWMutex
+	lockList               sync.RWMutex
+	lockUpdate             sync.RWMutex
+}
+
+// CheckConnectorRole calls CheckConnectorRoleFunc.
+func (mock *ConnectorNamespaceRoleBindingsServiceMock) CheckConnectorRole(ctx context.Context, connector *dbapi.Connector, role dbapi.ConnectorNamespaceRole) *errors.ServiceError {
+	if mock.CheckConnectorRoleFunc == nil {
+		panic("ConnectorNamespaceRoleBindingsServiceMock.CheckConnectorRoleFunc: method is nil but ConnectorNamespaceRoleBindingsService.CheckConnectorRole was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Connector *dbapi.Connector
+		Role      dbapi.ConnectorNamespaceRole
+	}{
+		Ctx:       ctx,
+		Connector: connector,
+		Role:      role,
+	}
+	mock.lockCheckConnectorRole.Lock()
+	mock.calls.CheckConnectorRole = append(mock.calls.CheckConnectorRole, callInfo)
+	mock.lockCheckConnectorRole.Unlock()
+	return mock.CheckConnectorRoleFunc(ctx, connector, role)
+}
+
+// CheckConnectorRoleCalls gets all the calls that were made to CheckConnectorRole.
+// Check the length with:
+//
+//	len(mockedConnectorNamespaceRoleBindingsService.CheckConnectorRoleCalls())
+func (mock *ConnectorNamespaceRoleBindingsServiceMock) CheckConnectorRoleCalls() []struct {
+	Ctx       context.Context
+	Connector *dbapi.Connector
+	Role      dbapi.ConnectorNamespaceRole
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Connector *dbapi.Connector
+		Role      dbapi.ConnectorNamespaceRole
+	}
+	mock.lockCheckConnectorRole.RLock()
+	calls = mock.calls.CheckConnectorRole
+	mock.lockCheckConnectorRole.RUnlock()
+	return calls
+}
+
+// Create calls CreateFunc.
+func (mock *ConnectorNamespaceRoleBindingsServiceMock) Create(ctx context.Context, binding *dbapi.ConnectorNamespaceRoleBinding) *errors.ServiceError {
+	if mock.CreateFunc == nil {
+		panic("ConnectorNamespaceRoleBindingsServiceMock.CreateFunc: method is nil but ConnectorNamespaceRoleBindingsService.Create was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Binding *dbapi.ConnectorNamespaceRoleBinding
+	}{
+		Ctx:     ctx,
+		Binding: binding,
+	}
+	mock.lockCreate.Lock()
+	mock.calls.Create = append(mock.calls.Create, callInfo)
+	mock.lockCreate.Unlock()
+	return mock.CreateFunc(ctx, binding)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedConnectorNamespaceRoleBindingsService.CreateCalls())
+func (mock *ConnectorNamespaceRoleBindingsServiceMock) CreateCalls() []struct {
+	Ctx     context.Context
+	Binding *dbapi.ConnectorNamespaceRoleBinding
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Binding *dbapi.ConnectorNamespaceRoleBinding
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
+
+// Delete calls DeleteFunc.
+func (mock *ConnectorNamespaceRoleBindingsServiceMock) Delete(ctx context.Context, namespaceId string, bindingId string) *errors.ServiceError {
+	if mock.DeleteFunc == nil {
+		panic("ConnectorNamespaceRoleBindingsServiceMock.DeleteFunc: method is nil but ConnectorNamespaceRoleBindingsService.Delete was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		NamespaceId string
+		BindingId   string
+	}{
+		Ctx:         ctx,
+		NamespaceId: namespaceId,
+		BindingId:   bindingId,
+	}
+	mock.lockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	mock.lockDelete.Unlock()
+	return mock.DeleteFunc(ctx, namespaceId, bindingId)
+}
+
+// DeleteCalls gets all the calls that were made to Delete.
+// Check the length with:
+//
+//	len(mockedConnectorNamespaceRoleBindingsService.DeleteCalls())
+func (mock *ConnectorNamespaceRoleBindingsServiceMock) DeleteCalls() []struct {
+	Ctx         context.Context
+	NamespaceId string
+	BindingId   string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		NamespaceId string
+		BindingId   string
+	}
+	mock.lockDelete.RLock()
+	calls = mock.calls.Delete
+	mock.lockDelete.RUnlock()
+	return calls
+}
+
+// Get calls GetFunc.
+func (mock *ConnectorNamespaceRoleBindingsServiceMock) Get(ctx context.Context, namespaceId string, bindingId string) (*dbapi.ConnectorNamespaceRoleBinding, *errors.ServiceError) {
+	if mock.GetFunc == nil {
+		panic("ConnectorNamespaceRoleBindingsServiceMock.GetFunc: method is nil but ConnectorNamespaceRoleBindingsService.Get was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		NamespaceId string
+		BindingId   string
+	}{
+		Ctx:         ctx,
+		NamespaceId: namespaceId,
+		BindingId:   bindingId,
+	}
+	mock.lockGet.Lock()
+	mock.calls.Get = append(mock.calls.Get, callInfo)
+	mock.lockGet.Unlock()
+	return mock.GetFunc(ctx, namespaceId, bindingId)
+}
+
+// GetCalls gets all the calls that were made to Get.
+// Check the length with:
+//
+//	len(mockedConnectorNamespaceRoleBindingsService.GetCalls())
+func (mock *ConnectorNamespaceRoleBindingsServiceMock) GetCalls() []struct {
+	Ctx         context.Context
+	NamespaceId string
+	BindingId   string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		NamespaceId string
+		BindingId   string
+	}
+	mock.lockGet.RLock()
+	calls = mock.calls.Get
+	mock.lockGet.RUnlock()
+	return calls
+}
+
+// GetNamespaceRole calls GetNamespaceRoleFunc.
+func (mock *ConnectorNamespaceRoleBindingsServiceMock) GetNamespaceRole(ctx context.Context, namespaceId string) (dbapi.ConnectorNamespaceRole, *errors.ServiceError) {
+	if mock.GetNamespaceRoleFunc == nil {
+		panic("ConnectorNamespaceRoleBindingsServiceMock.GetNamespaceRoleFunc: method is nil but ConnectorNamespaceRoleBindingsService.GetNamespaceRole was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		NamespaceId string
+	}{
+		Ctx:         ctx,
+		NamespaceId: namespaceId,
+	}
+	mock.lockGetNamespaceRole.Lock()
+	mock.calls.GetNamespaceRole = append(mock.calls.GetNamespaceRole, callInfo)
+	mock.lockGetNamespaceRole.Unlock()
+	return mock.GetNamespaceRoleFunc(ctx, namespaceId)
+}
+
+// GetNamespaceRoleCalls gets all the calls that were made to GetNamespaceRole.
+// Check the length with:
+//
+//	len(mockedConnectorNamespaceRoleBindingsService.GetNamespaceRoleCalls())
+func (mock *ConnectorNamespaceRoleBindingsServiceMock) GetNamespaceRoleCalls() []struct {
+	Ctx         context.Context
+	NamespaceId string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		NamespaceId string
+	}
+	mock.lockGetNamespaceRole.RLock()
+	calls = mock.calls.GetNamespaceRole
+	mock.lockGetNamespaceRole.RUnlock()
+	return calls
+}
+
+// List calls ListFunc.
+func (mock *ConnectorNamespaceRoleBindingsServiceMock) List(ctx context.Context, namespaceId string) (dbapi.ConnectorNamespaceRoleBindingList, *errors.ServiceError) {
+	if mock.ListFunc == nil {
+		panic("ConnectorNamespaceRoleBindingsServiceMock.ListFunc: method is nil but ConnectorNamespaceRoleBindingsService.List was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		NamespaceId string
+	}{
+		Ctx:         ctx,
+		NamespaceId: namespaceId,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc(ctx, namespaceId)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedConnectorNamespaceRoleBindingsService.ListCalls())
+func (mock *ConnectorNamespaceRoleBindingsServiceMock) ListCalls() []struct {
+	Ctx         context.Context
+	NamespaceId string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		NamespaceId string
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// Update calls UpdateFunc.
+func (mock *ConnectorNamespaceRoleBindingsServiceMock) Update(ctx context.Context, binding *dbapi.ConnectorNamespaceRoleBinding) *errors.ServiceError {
+	if mock.UpdateFunc == nil {
+		panic("ConnectorNamespaceRoleBindingsServiceMock.UpdateFunc: method is nil but ConnectorNamespaceRoleBindingsService.Update was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Binding *dbapi.ConnectorNamespaceRoleBinding
+	}{
+		Ctx:     ctx,
+		Binding: binding,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	return mock.UpdateFunc(ctx, binding)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedConnectorNamespaceRoleBindingsService.UpdateCalls())
+func (mock *ConnectorNamespaceRoleBindingsServiceMock) UpdateCalls() []struct {
+	Ctx     context.Context
+	Binding *dbapi.ConnectorNamespaceRoleBinding
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Binding *dbapi.ConnectorNamespaceRoleBinding
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_connectorNamespaceRoleBindingsService_GetNamespaceRole(t *testing.T) {
+	tests := []struct {
+		name      string
+		claims    jwt.MapClaims
+		namespace map[string]interface{}
+		bound     []map[string]interface{}
+		wantRole  dbapi.ConnectorNamespaceRole
+	}{
+		{
+			name:      "tenant user is admin",
+			claims:    jwt.MapClaims{"username": "alice", "org_id": "org1"},
+			namespace: map[string]interface{}{"id": "ns1", "tenant_user_id": "alice"},
+			wantRole:  dbapi.ConnectorNamespaceRoleAdmin,
+		},
+		{
+			name:      "tenant organisation admin is admin",
+			claims:    jwt.MapClaims{"username": "bob", "org_id": "org1", "is_org_admin": true},
+			namespace: map[string]interface{}{"id": "ns1", "tenant_organisation_id": "org1"},
+			wantRole:  dbapi.ConnectorNamespaceRoleAdmin,
+		},
+		{
+			name:      "tenant organisation member is editor unless bound to a higher role",
+			claims:    jwt.MapClaims{"username": "bob", "org_id": "org1"},
+			namespace: map[string]interface{}{"id": "ns1", "tenant_organisation_id": "org1"},
+			bound:     []map[string]interface{}{{"role": "viewer"}},
+			wantRole:  dbapi.ConnectorNamespaceRoleEditor,
+		},
+		{
+			name:      "highest bound role of another organisation user",
+			claims:    jwt.MapClaims{"username": "carol", "org_id": "org2", "groups": []interface{}{"ops"}},
+			namespace: map[string]interface{}{"id": "ns1", "tenant_organisation_id": "org1"},
+			bound:     []map[string]interface{}{{"role": "viewer"}, {"role": "admin"}, {"role": "editor"}},
+			wantRole:  dbapi.ConnectorNamespaceRoleAdmin,
+		},
+		{
+			name:      "namespace isn't visible without bindings",
+			claims:    jwt.MapClaims{"username": "carol", "org_id": "org2"},
+			namespace: map[string]interface{}{"id": "ns1", "tenant_organisation_id": "org1"},
+			wantRole:  "",
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().WithQuery(`SELECT "id","tenant_user_id","tenant_organisation_id" FROM "connector_namespaces"`).
+				WithReply([]map[string]interface{}{tt.namespace})
+			mocket.Catcher.NewMock().WithQuery(`SELECT "role" FROM "connector_namespace_role_bindings"`).
+				WithReply(tt.bound)
+
+			ctx := auth.SetTokenInContext(context.Background(), &jwt.Token{Claims: tt.claims})
+			k := NewConnectorNamespaceRoleBindingsService(db.NewMockConnectionFactory(nil))
+			role, err := k.GetNamespaceRole(ctx, "ns1")
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(role).To(gomega.Equal(tt.wantRole))
+		})
+	}
+}
+
+func Test_connectorNamespaceRoleBindingsService_CheckConnectorRole(t *testing.T) {
+	g := gomega.NewWithT(t)
+	mocket.Catcher.Reset()
+	mocket.Catcher.NewMock().WithQuery(`SELECT "id","tenant_user_id","tenant_organisation_id" FROM "connector_namespaces"`).
+		WithReply([]map[string]interface{}{{"id": "ns1", "tenant_organisation_id": "org1"}})
+	mocket.Catcher.NewMock().WithQuery(`SELECT "role" FROM "connector_namespace_role_bindings"`).
+		WithReply([]map[string]interface{}{{"role": "viewer"}})
+
+	ctx := auth.SetTokenInContext(context.Background(), &jwt.Token{Claims: jwt.MapClaims{"username": "carol", "org_id": "org2"}})
+	k := NewConnectorNamespaceRoleBindingsService(db.NewMockConnectionFactory(nil))
+	namespaceId := "ns1"
+	connector := &dbapi.Connector{NamespaceId: &namespaceId}
+	connector.ID = "c1"
+
+	g.Expect(k.CheckConnectorRole(ctx, connector, dbapi.ConnectorNamespaceRoleViewer)).To(gomega.BeNil())
+	err := k.CheckConnectorRole(ctx, connector, dbapi.ConnectorNamespaceRoleEditor)
+	g.Expect(err).ToNot(gomega.BeNil())
+	g.Expect(err.Reason).To(gomega.ContainSubstring("the editor role on connector namespace ns1 is required"))
+}
+
+func Test_connectorNamespaceRoleBindingsService_GetNamespaceRole_GroupBindings(t *testing.T) {
+	tests := []struct {
+		name       string
+		claims     jwt.MapClaims
+		wantQuery  string
+		wantArgs   []interface{}
+		wantGroups bool
+	}{
+		{
+			name:       "group bindings only match the namespaces of the organisation of the user",
+			claims:     jwt.MapClaims{"username": "carol", "org_id": "org2", "groups": []interface{}{"ops"}},
+			wantQuery:  `(subject_kind = $5 AND subject IN ($6) AND namespace_id IN (SELECT id FROM "connector_namespaces" WHERE tenant_organisation_id = $7))`,
+			wantArgs:   []interface{}{"group", "ops", "org2"},
+			wantGroups: true,
+		},
+		{
+			name:   "group bindings don't match users without an organisation",
+			claims: jwt.MapClaims{"username": "carol", "groups": []interface{}{"ops"}},
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().WithQuery(`SELECT "id","tenant_user_id","tenant_organisation_id" FROM "connector_namespaces"`).
+				WithReply([]map[string]interface{}{{"id": "ns1", "tenant_organisation_id": "org1"}})
+			var query string
+			var args []interface{}
+			mocket.Catcher.NewMock().WithQuery(`SELECT "role" FROM "connector_namespace_role_bindings"`).
+				WithCallback(func(q string, namedArgs []driver.NamedValue) {
+					query = q
+					for _, arg := range namedArgs {
+						args = append(args, arg.Value)
+					}
+				})
+
+			ctx := auth.SetTokenInContext(context.Background(), &jwt.Token{Claims: tt.claims})
+			k := NewConnectorNamespaceRoleBindingsService(db.NewMockConnectionFactory(nil))
+			role, err := k.GetNamespaceRole(ctx, "ns1")
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(role).To(gomega.BeEmpty())
+			if tt.wantGroups {
+				g.Expect(query).To(gomega.ContainSubstring(tt.wantQuery))
+				g.Expect(args).To(gomega.ContainElements(tt.wantArgs...))
+			} else {
+				g.Expect(args).ToNot(gomega.ContainElement(string(dbapi.ConnectorNamespaceSubjectGroup)))
+			}
+		})
+	}
+}
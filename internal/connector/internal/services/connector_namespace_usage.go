@@ -0,0 +1,180 @@
+package services
+
+import (
+	"encoding/json"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/profiles"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"gorm.io/gorm"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// connector desired states that don't run any pods, and don't use namespace resources
+var nonRunningDesiredStates = []dbapi.ConnectorDesiredState{dbapi.ConnectorStopped, dbapi.ConnectorDeleted}
+
+// shardMetadataResources are the resource requests and limits declared in connector shard metadata, e.g.
+//
+//	"resources": {"requests": {"cpu": "500m", "memory": "512Mi"}, "limits": {"cpu": "1", "memory": "1Gi"}}
+type shardMetadataResources struct {
+	Resources *struct {
+		Requests map[string]string `json:"requests"`
+		Limits   map[string]string `json:"limits"`
+	} `json:"resources"`
+}
+
+// ConnectorRunsPods returns true if a connector in the given desired state uses namespace resources
+func ConnectorRunsPods(desiredState dbapi.ConnectorDesiredState) bool {
+	for _, s := range nonRunningDesiredStates {
+		if desiredState == s {
+			return false
+		}
+	}
+	return true
+}
+
+func (k *connectorNamespaceService) GetNamespaceUsage(namespaceId string) (*dbapi.ConnectorNamespaceUsage, *errors.ServiceError) {
+	return k.namespaceUsage(k.connectionFactory.New(), namespaceId, "")
+}
+
+func (k *connectorNamespaceService) CheckConnectorResourceQuota(namespaceId string, connectorTypeId string, channel string, connectorId string) *errors.ServiceError {
+	dbConn := k.connectionFactory.New()
+	quota, err := k.namespaceQuota(dbConn, namespaceId)
+	if err != nil {
+		return err
+	}
+	if quota.MemoryRequests == "" && quota.MemoryLimits == "" && quota.CPURequests == "" && quota.CPULimits == "" {
+		return nil
+	}
+
+	usage, err := k.namespaceUsage(dbConn, namespaceId, connectorId)
+	if err != nil {
+		return err
+	}
+	footprint, err := k.connectorFootprint(dbConn, connectorTypeId, channel)
+	if err != nil {
+		return err
+	}
+	usage.Add(footprint, 1)
+
+	for _, check := range []struct {
+		name  string
+		quota string
+		used  resource.Quantity
+	}{
+		{"memory requests", quota.MemoryRequests, usage.MemoryRequests},
+		{"memory limits", quota.MemoryLimits, usage.MemoryLimits},
+		{"cpu requests", quota.CPURequests, usage.CPURequests},
+		{"cpu limits", quota.CPULimits, usage.CPULimits},
+	} {
+		if check.quota == "" {
+			continue
+		}
+		limit, perr := resource.ParseQuantity(check.quota)
+		if perr != nil {
+			return errors.FailedToCheckQuota("invalid %s quota %q for Connector namespace with id %s: %s", check.name, check.quota, namespaceId, perr)
+		}
+		if check.used.Cmp(limit) > 0 {
+			return errors.InsufficientQuotaError("the connector would exceed the namespace %s quota: %s used of %s", check.name, check.used.String(), limit.String())
+		}
+	}
+	return nil
+}
+
+// namespaceQuota returns the quota of the profile of a namespace
+func (k *connectorNamespaceService) namespaceQuota(dbConn *gorm.DB, namespaceId string) (config.NamespaceQuota, *errors.ServiceError) {
+	var profileName string
+	if err := dbConn.Model(&dbapi.ConnectorNamespaceAnnotation{}).
+		Where("namespace_id = ? AND key = ?", namespaceId, profiles.AnnotationProfileKey).
+		Select("value").First(&profileName).Error; err != nil {
+		return config.NamespaceQuota{}, errors.FailedToCheckQuota("error reading Connector namespace annotation with namespace id %s: %s", namespaceId, err)
+	}
+	quota, _ := k.quotaConfig.GetNamespaceQuota(profileName)
+	return quota, nil
+}
+
+// namespaceUsage computes the resources used by the running connectors in a namespace, excluding the connector with id excludeConnectorId
+func (k *connectorNamespaceService) namespaceUsage(dbConn *gorm.DB, namespaceId string, excludeConnectorId string) (*dbapi.ConnectorNamespaceUsage, *errors.ServiceError) {
+	var counts []struct {
+		ConnectorTypeId string
+		Channel         string
+		Count           int64
+	}
+	query := dbConn.Model(&dbapi.Connector{}).
+		Select("connector_type_id, channel, count(*) AS count").
+		Where("namespace_id = ? AND desired_state NOT IN ?", namespaceId, nonRunningDesiredStates)
+	if excludeConnectorId != "" {
+		query = query.Where("id <> ?", excludeConnectorId)
+	}
+	if err := query.Group("connector_type_id, channel").Order("connector_type_id, channel").Scan(&counts).Error; err != nil {
+		return nil, services.HandleGetError("Connector", "namespace_id", namespaceId, err)
+	}
+
+	usage := &dbapi.ConnectorNamespaceUsage{
+		ConnectorTypes: make([]dbapi.ConnectorTypeUsage, 0, len(counts)),
+	}
+	for _, c := range counts {
+		footprint, err := k.connectorFootprint(dbConn, c.ConnectorTypeId, c.Channel)
+		if err != nil {
+			return nil, err
+		}
+		typeUsage := dbapi.ConnectorTypeUsage{
+			ConnectorTypeId: c.ConnectorTypeId,
+			Channel:         c.Channel,
+			Connectors:      c.Count,
+		}
+		typeUsage.Add(footprint, c.Count)
+		usage.Add(footprint, c.Count)
+		usage.Connectors += c.Count
+		usage.ConnectorTypes = append(usage.ConnectorTypes, typeUsage)
+	}
+	return usage, nil
+}
+
+// connectorFootprint returns the resources of a connector, declared in the latest shard metadata of its type and channel,
+// or the default connector resources if the shard metadata doesn't declare any
+func (k *connectorNamespaceService) connectorFootprint(dbConn *gorm.DB, connectorTypeId string, channel string) (dbapi.ConnectorResourceUsage, *errors.ServiceError) {
+	defaults := k.quotaConfig.DefaultConnectorResources
+	requests := map[string]string{"cpu": defaults.CPURequests, "memory": defaults.MemoryRequests}
+	limits := map[string]string{"cpu": defaults.CPULimits, "memory": defaults.MemoryLimits}
+
+	var shardMetadata dbapi.ConnectorShardMetadata
+	if err := dbConn.Where(dbapi.ConnectorShardMetadata{ConnectorTypeId: connectorTypeId, Channel: channel}).
+		Order("revision desc").First(&shardMetadata).Error; err != nil {
+		if !services.IsRecordNotFoundError(err) {
+			return dbapi.ConnectorResourceUsage{}, errors.FailedToCheckQuota("error reading shard metadata of connector type %s and channel %s: %s", connectorTypeId, channel, err)
+		}
+	} else {
+		var declared shardMetadataResources
+		if err := json.Unmarshal(shardMetadata.ShardMetadata, &declared); err != nil {
+			return dbapi.ConnectorResourceUsage{}, errors.FailedToCheckQuota("error reading resources of connector type %s and channel %s: %s", connectorTypeId, channel, err)
+		}
+		if declared.Resources != nil {
+			requests, limits = declared.Resources.Requests, declared.Resources.Limits
+		}
+	}
+
+	var footprint dbapi.ConnectorResourceUsage
+	for _, q := range []struct {
+		value  string
+		target *resource.Quantity
+	}{
+		{requests["memory"], &footprint.MemoryRequests},
+		{limits["memory"], &footprint.MemoryLimits},
+		{requests["cpu"], &footprint.CPURequests},
+		{limits["cpu"], &footprint.CPULimits},
+	} {
+		if q.value == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(q.value)
+		if err != nil {
+			return footprint, errors.FailedToCheckQuota("invalid resources of connector type %s and channel %s: '%s': %s",
+				connectorTypeId, channel, q.value, err)
+		}
+		*q.target = quantity
+	}
+	return footprint, nil
+}
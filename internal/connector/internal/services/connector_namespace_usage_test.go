@@ -0,0 +1,124 @@
+package services
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/profiles"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+const usageTestQuotaConfig = `
+---
+- profile-name: default-profile
+- profile-name: evaluation-profile
+  quotas:
+    namespace-quota:
+      connectors: 4
+      memory-requests: "1Gi"
+      cpu-requests: "1"
+`
+
+func Test_connectorNamespaceService_CheckConnectorResourceQuota(t *testing.T) {
+	g := gomega.NewWithT(t)
+	quotaFile := path.Join(t.TempDir(), "quota.yaml")
+	g.Expect(os.WriteFile(quotaFile, []byte(usageTestQuotaConfig), 0600)).To(gomega.Succeed())
+
+	resources := []byte(`{"connector_revision": 1, "resources": {"requests": {"cpu": "250m", "memory": "256Mi"}, "limits": {"cpu": "500m", "memory": "512Mi"}}}`)
+
+	tests := []struct {
+		name           string
+		profile        string
+		counts         []map[string]interface{}
+		shardMetadata  []byte
+		defaults       config.ConnectorResources
+		wantErr        string
+		wantCPU        string
+		wantMemory     string
+		wantConnectors int64
+	}{
+		{
+			name:           "connector fits in the namespace quota",
+			profile:        profiles.EvaluationProfileName,
+			counts:         []map[string]interface{}{{"connector_type_id": "log_sink_0.1", "channel": "stable", "count": 2}},
+			shardMetadata:  resources,
+			wantCPU:        "500m",
+			wantMemory:     "512Mi",
+			wantConnectors: 2,
+		},
+		{
+			name:           "connector exceeds the namespace memory quota",
+			profile:        profiles.EvaluationProfileName,
+			counts:         []map[string]interface{}{{"connector_type_id": "log_sink_0.1", "channel": "stable", "count": 4}},
+			shardMetadata:  resources,
+			wantErr:        "the connector would exceed the namespace memory requests quota: 1280Mi used of 1Gi",
+			wantCPU:        "1",
+			wantMemory:     "1Gi",
+			wantConnectors: 4,
+		},
+		{
+			name:           "shard metadata without resources uses the default footprint",
+			profile:        profiles.EvaluationProfileName,
+			counts:         []map[string]interface{}{{"connector_type_id": "log_sink_0.1", "channel": "stable", "count": 1}},
+			shardMetadata:  []byte(`{"connector_revision": 1}`),
+			defaults:       config.ConnectorResources{CPURequests: "1"},
+			wantErr:        "the connector would exceed the namespace cpu requests quota: 2 used of 1",
+			wantCPU:        "1",
+			wantConnectors: 1,
+		},
+		{
+			name:           "namespace without cpu and memory quota",
+			profile:        profiles.DefaultProfileName,
+			counts:         []map[string]interface{}{{"connector_type_id": "log_sink_0.1", "channel": "stable", "count": 10}},
+			shardMetadata:  resources,
+			wantCPU:        "2500m",
+			wantMemory:     "2560Mi",
+			wantConnectors: 10,
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			quotaConfig := config.NewConnectorsQuotaConfig()
+			quotaConfig.ConnectorsQuotaConfigFile = quotaFile
+			quotaConfig.DefaultConnectorResources = tt.defaults
+			g.Expect(quotaConfig.ReadFiles()).To(gomega.Succeed())
+
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().WithQuery(`SELECT "value" FROM "connector_namespace_annotations"`).
+				WithReply([]map[string]interface{}{{"value": tt.profile}})
+			mocket.Catcher.NewMock().WithQuery(`SELECT connector_type_id, channel, count(*) AS count FROM "connectors"`).
+				WithReply(tt.counts)
+			mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "connector_shard_metadata"`).
+				WithReply([]map[string]interface{}{{"id": 1, "connector_type_id": "log_sink_0.1", "channel": "stable", "revision": 1, "shard_metadata": tt.shardMetadata}})
+
+			k := NewConnectorNamespaceService(db.NewMockConnectionFactory(nil), config.NewConnectorsConfig(), quotaConfig, nil, &webhooks.WebhookServiceMock{})
+
+			usage, err := k.GetNamespaceUsage("ns1")
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(usage.Connectors).To(gomega.Equal(tt.wantConnectors))
+			g.Expect(usage.ConnectorTypes).To(gomega.HaveLen(1))
+			if tt.wantCPU != "" {
+				g.Expect(usage.CPURequests.String()).To(gomega.Equal(tt.wantCPU))
+			}
+			if tt.wantMemory != "" {
+				g.Expect(usage.MemoryRequests.String()).To(gomega.Equal(tt.wantMemory))
+			}
+
+			err = k.CheckConnectorResourceQuota("ns1", "log_sink_0.1", "stable", "")
+			if tt.wantErr != "" {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Reason).To(gomega.ContainSubstring(tt.wantErr))
+			} else {
+				g.Expect(err).To(gomega.BeNil())
+			}
+		})
+	}
+}
@@ -23,14 +23,18 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/queryparser"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
 	"gorm.io/gorm"
 )
 
+//go:generate moq -out connector_namespaces_moq.go . ConnectorNamespaceService
 type ConnectorNamespaceService interface {
 	Create(ctx context.Context, request *dbapi.ConnectorNamespace) *errors.ServiceError
 	Update(ctx context.Context, request *dbapi.ConnectorNamespace) *errors.ServiceError
 	Get(ctx context.Context, namespaceID string) (*dbapi.ConnectorNamespace, *errors.ServiceError)
 	List(ctx context.Context, clusterIDs []string, listArguments *services.ListArguments, gtVersion int64) (dbapi.ConnectorNamespaceList, *api.PagingMeta, *errors.ServiceError)
+	// ListForUser lists the namespaces visible to the user of the context, as tenant user or organisation, or with a role binding
+	ListForUser(ctx context.Context, listArguments *services.ListArguments) (dbapi.ConnectorNamespaceList, *api.PagingMeta, *errors.ServiceError)
 	Delete(ctx context.Context, namespaceId string) *errors.ServiceError
 	SetEvalClusterId(request *dbapi.ConnectorNamespace) *errors.ServiceError
 	CreateDefaultNamespace(ctx context.Context, connectorCluster *dbapi.ConnectorCluster) *errors.ServiceError
@@ -42,6 +46,11 @@ type ConnectorNamespaceService interface {
 	ReconcileDeletedNamespaces(ctx context.Context) (int64, *errors.ServiceError)
 	GetNamespaceTenant(namespaceId string) (*dbapi.ConnectorNamespace, *errors.ServiceError)
 	CheckConnectorQuota(namespaceId string) *errors.ServiceError
+	// CheckConnectorResourceQuota checks that the namespace has enough cpu and memory quota left to run a connector of the given type and channel,
+	// connectorId is the id of an existing connector whose resources must not be accounted twice, or empty for new connectors
+	CheckConnectorResourceQuota(namespaceId string, connectorTypeId string, channel string, connectorId string) *errors.ServiceError
+	// GetNamespaceUsage returns the resources used by the running connectors of a namespace
+	GetNamespaceUsage(namespaceId string) (*dbapi.ConnectorNamespaceUsage, *errors.ServiceError)
 	CanCreateEvalNamespace(userId string) *errors.ServiceError
 	GetEmptyDeletingNamespaces(clusterId string) (dbapi.ConnectorNamespaceList, *errors.ServiceError)
 }
@@ -53,6 +62,7 @@ type connectorNamespaceService struct {
 	connectorsConfig  *config.ConnectorsConfig
 	quotaConfig       *config.ConnectorsQuotaConfig
 	bus               signalbus.SignalBus
+	webhookService    webhooks.WebhookService
 }
 
 func init() {
@@ -61,12 +71,13 @@ func init() {
 }
 
 func NewConnectorNamespaceService(factory *db.ConnectionFactory, config *config.ConnectorsConfig,
-	quotaConfig *config.ConnectorsQuotaConfig, bus signalbus.SignalBus) *connectorNamespaceService {
+	quotaConfig *config.ConnectorsQuotaConfig, bus signalbus.SignalBus, webhookService webhooks.WebhookService) *connectorNamespaceService {
 	return &connectorNamespaceService{
 		connectionFactory: factory,
 		connectorsConfig:  config,
 		quotaConfig:       quotaConfig,
 		bus:               bus,
+		webhookService:    webhookService,
 	}
 }
 
@@ -241,6 +252,23 @@ func GetValidNamespaceColumns() []string {
 }
 
 func (k *connectorNamespaceService) List(ctx context.Context, clusterIDs []string, listArguments *services.ListArguments, gtVersion int64) (dbapi.ConnectorNamespaceList, *api.PagingMeta, *errors.ServiceError) {
+	dbConn := k.connectionFactory.New()
+	if len(clusterIDs) != 0 {
+		dbConn = dbConn.Where("cluster_id IN ?", clusterIDs)
+	}
+	return k.list(dbConn, listArguments, gtVersion)
+}
+
+func (k *connectorNamespaceService) ListForUser(ctx context.Context, listArguments *services.ListArguments) (dbapi.ConnectorNamespaceList, *api.PagingMeta, *errors.ServiceError) {
+	subjects, err := namespaceSubjectsFromContext(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	dbConn := k.connectionFactory.New().Where("connector_namespaces.id IN (?)", visibleNamespaces(k.connectionFactory, subjects))
+	return k.list(dbConn, listArguments, 0)
+}
+
+func (k *connectorNamespaceService) list(dbConn *gorm.DB, listArguments *services.ListArguments, gtVersion int64) (dbapi.ConnectorNamespaceList, *api.PagingMeta, *errors.ServiceError) {
 	if err := listArguments.Validate(GetValidNamespaceColumns()); err != nil {
 		return nil, nil, errors.NewWithCause(errors.ErrorMalformedRequest, err, "Unable to list connector namespace requests: %s", err.Error())
 	}
@@ -251,10 +279,7 @@ func (k *connectorNamespaceService) List(ctx context.Context, clusterIDs []strin
 		Size:  listArguments.Size,
 		Total: 0,
 	}
-	dbConn := k.connectionFactory.New().Model(&resourceList)
-	if len(clusterIDs) != 0 {
-		dbConn = dbConn.Where("cluster_id IN ?", clusterIDs)
-	}
+	dbConn = dbConn.Model(&resourceList)
 
 	// Apply search query
 	if len(listArguments.Search) > 0 {
@@ -539,16 +564,25 @@ func (k *connectorNamespaceService) deleteNamespaceConnectors(ctx context.Contex
 func (k *connectorNamespaceService) ReconcileExpiredNamespaces(ctx context.Context) (int64, *errors.ServiceError) {
 	var count int64
 	if err := k.connectionFactory.New().Transaction(func(dbConn *gorm.DB) error {
+		now := time.Now()
+		deletedPhases := []string{string(dbapi.ConnectorNamespacePhaseDeleting), string(dbapi.ConnectorNamespacePhaseDeleted)}
+		events, err := k.expiredNamespacesWebhookEvents(dbConn, now, deletedPhases)
+		if err != nil {
+			return err
+		}
+
 		// delete all expired namespaces and their connectors
-		var err *errors.ServiceError
-		count, err = k.DeleteNamespaces(ctx, dbConn,
-			"expiration < ? AND status_phase NOT IN ?", time.Now(),
-			[]string{string(dbapi.ConnectorNamespacePhaseDeleting), string(dbapi.ConnectorNamespacePhaseDeleted)})
+		count, err = k.DeleteNamespaces(ctx, dbConn, "expiration < ? AND status_phase NOT IN ?", now, deletedPhases)
 		if err != nil {
 			if !err.Is404() {
 				return services.HandleUpdateError("Connector namespace", err)
 			}
 		}
+
+		// the webhook events are added to the outbox in the transaction deleting the namespaces
+		if err := k.webhookService.Enqueue(dbConn, events...); err != nil {
+			return err
+		}
 		return nil
 	}); err != nil {
 		return 0, services.HandleUpdateError(`Connector namespace`, err)
@@ -556,6 +590,59 @@ func (k *connectorNamespaceService) ReconcileExpiredNamespaces(ctx context.Conte
 	return count, nil
 }
 
+// connectorNamespaceWebhookData is the connector namespace sent in the webhook deliveries
+type connectorNamespaceWebhookData struct {
+	Id         string     `json:"id"`
+	Name       string     `json:"name"`
+	Owner      string     `json:"owner"`
+	ClusterId  string     `json:"cluster_id"`
+	Expiration *time.Time `json:"expiration,omitempty"`
+}
+
+// expiredNamespacesWebhookEvents returns the webhook events of the namespaces expired at the given time,
+// the organisation of a namespace of a user tenant is the organisation of its connectors, if it has any
+func (k *connectorNamespaceService) expiredNamespacesWebhookEvents(dbConn *gorm.DB, now time.Time, deletedPhases []string) ([]webhooks.Event, *errors.ServiceError) {
+	var namespaces dbapi.ConnectorNamespaceList
+	if err := dbConn.Select("id", "name", "owner", "cluster_id", "expiration", "tenant_organisation_id").
+		Where("expiration < ? AND status_phase NOT IN ?", now, deletedPhases).
+		Find(&namespaces).Error; err != nil {
+		return nil, services.HandleGetError("Connector namespace", "expiration", now, err)
+	}
+
+	events := make([]webhooks.Event, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		var organisationId string
+		if namespace.TenantOrganisationId != nil {
+			organisationId = *namespace.TenantOrganisationId
+		} else {
+			var organisationIds []string
+			if err := dbConn.Model(&dbapi.Connector{}).
+				Where("namespace_id = ? AND organisation_id <> ''", namespace.ID).
+				Limit(1).
+				Pluck("organisation_id", &organisationIds).Error; err != nil {
+				return nil, services.HandleGetError("Connector", "namespace_id", namespace.ID, err)
+			}
+			if len(organisationIds) == 0 {
+				continue
+			}
+			organisationId = organisationIds[0]
+		}
+		events = append(events, webhooks.Event{
+			Type:           webhooks.EventConnectorNamespaceExpired,
+			OrganisationId: organisationId,
+			ResourceId:     namespace.ID,
+			Data: connectorNamespaceWebhookData{
+				Id:         namespace.ID,
+				Name:       namespace.Name,
+				Owner:      namespace.Owner,
+				ClusterId:  namespace.ClusterId,
+				Expiration: namespace.Expiration,
+			},
+		})
+	}
+	return events, nil
+}
+
 func (k *connectorNamespaceService) ReconcileUnusedDeletingNamespaces(_ context.Context) (int64, *errors.ServiceError) {
 	var count int64
 	if err := k.connectionFactory.New().Transaction(func(dbConn *gorm.DB) error {
@@ -661,6 +748,16 @@ func (k *connectorNamespaceService) ReconcileDeletedNamespaces(_ context.Context
 			count = 0
 			return services.HandleDeleteError("Connector namespace", "id", namespaceIds, err)
 		}
+		if err := dbConn.Where("namespace_id IN ?", namespaceIds).
+			Delete(&dbapi.ConnectorUpgradePolicy{}).Error; err != nil {
+			count = 0
+			return services.HandleDeleteError("Connector upgrade policy", "namespace_id", namespaceIds, err)
+		}
+		if err := dbConn.Unscoped().Where("namespace_id IN ?", namespaceIds).
+			Delete(&dbapi.ConnectorNamespaceRoleBinding{}).Error; err != nil {
+			count = 0
+			return services.HandleDeleteError("Connector namespace role binding", "namespace_id", namespaceIds, err)
+		}
 
 		return nil
 
@@ -687,14 +784,10 @@ func (k *connectorNamespaceService) GetNamespaceTenant(namespaceId string) (*dba
 
 func (k *connectorNamespaceService) CheckConnectorQuota(namespaceId string) *errors.ServiceError {
 	dbConn := k.connectionFactory.New()
-	var profileName string
-	var quota config.NamespaceQuota
-	if err := dbConn.Model(&dbapi.ConnectorNamespaceAnnotation{}).
-		Where("namespace_id = ? AND key = ?", namespaceId, profiles.AnnotationProfileKey).
-		Select("value").First(&profileName).Error; err != nil {
-		return errors.FailedToCheckQuota("error reading Connector namespace annotation with namespace id %s: %s", namespaceId, err)
-	}
-	quota, _ = k.quotaConfig.GetNamespaceQuota(profileName)
+	quota, err := k.namespaceQuota(dbConn, namespaceId)
+	if err != nil {
+		return err
+	}
 	if quota.Connectors > 0 {
 		// get number of connectors using this namespace
 		var count int64
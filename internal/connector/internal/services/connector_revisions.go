@@ -0,0 +1,270 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/vault"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/secrets"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+	"github.com/spyzhov/ajson"
+)
+
+// MaxConnectorRevisions is the number of revisions kept for every connector, older revisions and their secrets are removed
+const MaxConnectorRevisions = 10
+
+//go:generate moq -out connector_revisions_moq.go . ConnectorRevisionsService
+type ConnectorRevisionsService interface {
+	// Record stores the configuration of the connector deployed with the given shard metadata as a new revision,
+	// unless it's the same configuration as the latest revision, which is returned instead
+	Record(ctx context.Context, connector *dbapi.Connector, shardMetadataID int64) (*dbapi.ConnectorRevision, *errors.ServiceError)
+	List(ctx context.Context, connectorID string) (dbapi.ConnectorRevisionList, *errors.ServiceError)
+	Get(ctx context.Context, connectorID string, revision int64) (*dbapi.ConnectorRevision, *errors.ServiceError)
+	// SecretRefs returns the vault references used by the revisions of a connector, they must not be removed from the vault
+	SecretRefs(ctx context.Context, connectorID string) ([]string, *errors.ServiceError)
+}
+
+var _ ConnectorRevisionsService = &connectorRevisionsService{}
+
+type connectorRevisionsService struct {
+	connectionFactory     *db.ConnectionFactory
+	connectorTypesService ConnectorTypesService
+	vaultService          vault.VaultService
+}
+
+func NewConnectorRevisionsService(connectionFactory *db.ConnectionFactory, connectorTypesService ConnectorTypesService,
+	vaultService vault.VaultService) *connectorRevisionsService {
+	return &connectorRevisionsService{
+		connectionFactory:     connectionFactory,
+		connectorTypesService: connectorTypesService,
+		vaultService:          vaultService,
+	}
+}
+
+func (k *connectorRevisionsService) Record(ctx context.Context, connector *dbapi.Connector, shardMetadataID int64) (*dbapi.ConnectorRevision, *errors.ServiceError) {
+	dbConn := k.connectionFactory.New()
+
+	ct, serr := k.connectorTypesService.Get(connector.ConnectorTypeId)
+	if serr != nil {
+		return nil, serr
+	}
+	refs, err := GetConnectorSecretRefs(connector, ct)
+	if err != nil {
+		return nil, errors.GeneralError("could not get secrets of connector %s: %v", connector.ID, err)
+	}
+	secretRefs, err := json.Marshal(refs)
+	if err != nil {
+		return nil, errors.GeneralError("could not marshal secrets of connector %s: %v", connector.ID, err)
+	}
+
+	revision := &dbapi.ConnectorRevision{
+		Model: db.Model{
+			ID: api.NewID(),
+		},
+		ConnectorID:              connector.ID,
+		Revision:                 1,
+		ConnectorVersion:         connector.Version,
+		ConnectorTypeId:          connector.ConnectorTypeId,
+		Channel:                  connector.Channel,
+		ConnectorShardMetadataID: shardMetadataID,
+		ConnectorSpec:            connector.ConnectorSpec,
+		Kafka:                    connector.Kafka,
+		SchemaRegistry:           connector.SchemaRegistry,
+		ServiceAccount: dbapi.ServiceAccount{
+			ClientId:        connector.ServiceAccount.ClientId,
+			ClientSecretRef: connector.ServiceAccount.ClientSecretRef,
+		},
+		SecretRefs: secretRefs,
+	}
+
+	var latest dbapi.ConnectorRevisionList
+	if err := dbConn.Where("connector_id = ?", connector.ID).Order("revision desc").Limit(1).Find(&latest).Error; err != nil {
+		return nil, errors.GeneralError("failed to get latest revision of connector %s: %v", connector.ID, err)
+	}
+	if len(latest) > 0 {
+		if SameConnectorRevisionConfig(latest[0], revision) {
+			return latest[0], nil
+		}
+		revision.Revision = latest[0].Revision + 1
+	}
+
+	if err := dbConn.Create(revision).Error; err != nil {
+		return nil, services.HandleCreateError("Connector revision", err)
+	}
+
+	if err := k.prune(ctx, connector.ID, revision.Revision-MaxConnectorRevisions); err != nil {
+		return nil, err
+	}
+
+	return revision, nil
+}
+
+// prune removes the revisions of a connector up to and including the given revision,
+// together with the secrets that are not used by the remaining revisions
+func (k *connectorRevisionsService) prune(ctx context.Context, connectorID string, upTo int64) *errors.ServiceError {
+	if upTo < 1 {
+		return nil
+	}
+	dbConn := k.connectionFactory.New()
+
+	var revisions dbapi.ConnectorRevisionList
+	if err := dbConn.Where("connector_id = ?", connectorID).Find(&revisions).Error; err != nil {
+		return errors.GeneralError("failed to list revisions of connector %s: %v", connectorID, err)
+	}
+	var pruned, kept dbapi.ConnectorRevisionList
+	for _, r := range revisions {
+		if r.Revision <= upTo {
+			pruned = append(pruned, r)
+		} else {
+			kept = append(kept, r)
+		}
+	}
+	if len(pruned) == 0 {
+		return nil
+	}
+
+	if err := dbConn.Where("connector_id = ? AND revision <= ?", connectorID, upTo).
+		Delete(&dbapi.ConnectorRevision{}).Error; err != nil {
+		return services.HandleDeleteError("Connector revision", "connector_id", connectorID, err)
+	}
+
+	keptRefs, err := revisionSecretRefs(kept)
+	if err != nil {
+		return err
+	}
+	prunedRefs, err := revisionSecretRefs(pruned)
+	if err != nil {
+		return err
+	}
+	staleRefs := make([]string, 0, len(prunedRefs))
+	for _, ref := range prunedRefs {
+		if !arrays.Contains(keptRefs, ref) {
+			staleRefs = append(staleRefs, ref)
+		}
+	}
+	if len(staleRefs) > 0 {
+		_ = db.AddPostCommitAction(ctx, func() {
+			for _, ref := range staleRefs {
+				if err := k.vaultService.DeleteSecretString(ref); err != nil {
+					logger.Logger.Errorf("failed to delete vault secret key '%s': %v", ref, err)
+				}
+			}
+		})
+	}
+	return nil
+}
+
+func (k *connectorRevisionsService) List(ctx context.Context, connectorID string) (dbapi.ConnectorRevisionList, *errors.ServiceError) {
+	var revisions dbapi.ConnectorRevisionList
+	if err := k.connectionFactory.New().Where("connector_id = ?", connectorID).
+		Order("revision desc").Find(&revisions).Error; err != nil {
+		return nil, errors.GeneralError("failed to list revisions of connector %s: %v", connectorID, err)
+	}
+	return revisions, nil
+}
+
+func (k *connectorRevisionsService) Get(ctx context.Context, connectorID string, revision int64) (*dbapi.ConnectorRevision, *errors.ServiceError) {
+	var result dbapi.ConnectorRevision
+	if err := k.connectionFactory.New().Where("connector_id = ? AND revision = ?", connectorID, revision).
+		First(&result).Error; err != nil {
+		return nil, services.HandleGetError("Connector revision", "revision", revision, err)
+	}
+	return &result, nil
+}
+
+func (k *connectorRevisionsService) SecretRefs(ctx context.Context, connectorID string) ([]string, *errors.ServiceError) {
+	revisions, err := k.List(ctx, connectorID)
+	if err != nil {
+		return nil, err
+	}
+	return revisionSecretRefs(revisions)
+}
+
+// SameConnectorRevisionConfig returns true if both revisions hold the same connector configuration
+func SameConnectorRevisionConfig(a *dbapi.ConnectorRevision, b *dbapi.ConnectorRevision) bool {
+	if a.ConnectorTypeId != b.ConnectorTypeId ||
+		a.Channel != b.Channel ||
+		a.ConnectorShardMetadataID != b.ConnectorShardMetadataID ||
+		a.Kafka != b.Kafka ||
+		a.SchemaRegistry != b.SchemaRegistry ||
+		a.ServiceAccount.ClientId != b.ServiceAccount.ClientId ||
+		a.ServiceAccount.ClientSecretRef != b.ServiceAccount.ClientSecretRef {
+		return false
+	}
+
+	// compare the specs as objects, since jsonb doesn't preserve the formatting and the order of the keys
+	var specA, specB interface{}
+	if len(a.ConnectorSpec) != 0 {
+		if err := a.ConnectorSpec.Unmarshal(&specA); err != nil {
+			return false
+		}
+	}
+	if len(b.ConnectorSpec) != 0 {
+		if err := b.ConnectorSpec.Unmarshal(&specB); err != nil {
+			return false
+		}
+	}
+	return reflect.DeepEqual(specA, specB)
+}
+
+func revisionSecretRefs(revisions dbapi.ConnectorRevisionList) ([]string, *errors.ServiceError) {
+	var result []string
+	for _, r := range revisions {
+		if len(r.SecretRefs) == 0 {
+			continue
+		}
+		var refs []string
+		if err := r.SecretRefs.Unmarshal(&refs); err != nil {
+			return nil, errors.GeneralError("invalid secrets of revision %d of connector %s: %v", r.Revision, r.ConnectorID, err)
+		}
+		for _, ref := range refs {
+			if !arrays.Contains(result, ref) {
+				result = append(result, ref)
+			}
+		}
+	}
+	return result, nil
+}
+
+// GetConnectorSecretRefs returns the vault references of the service account secret and of the secrets in the connector spec
+func GetConnectorSecretRefs(resource *dbapi.Connector, ct *dbapi.ConnectorType) (result []string, err error) {
+
+	if resource.ServiceAccount.ClientSecretRef != "" {
+		result = append(result, resource.ServiceAccount.ClientSecretRef)
+	}
+
+	// find the existing secrets...
+	if len(resource.ConnectorSpec) != 0 {
+		_, err = secrets.ModifySecrets(ct.JsonSchema, resource.ConnectorSpec, func(node *ajson.Node) error {
+			if node.Type() != ajson.Object {
+				return nil
+			}
+			ref, err := node.GetKey("ref")
+			if err != nil {
+				return nil
+			}
+			key, err := ref.GetString()
+			if err != nil {
+				return nil
+			}
+			result = append(result, key)
+			return nil
+		})
+		if err != nil {
+			switch err := err.(type) {
+			case *errors.ServiceError:
+				return result, err
+			default:
+				return result, errors.GeneralError("could not get connector secrets: %v", err.Error())
+			}
+		}
+	}
+	return
+}
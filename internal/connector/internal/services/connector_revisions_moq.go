@@ -0,0 +1,245 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package services
+
+import (
+	"context"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"sync"
+)
+
+// Ensure, that ConnectorRevisionsServiceMock does implement ConnectorRevisionsService.
+// If this is not the case, regenerate this file with moq.
+var _ ConnectorRevisionsService = &ConnectorRevisionsServiceMock{}
+
+// ConnectorRevisionsServiceMock is a mock implementation of ConnectorRevisionsService.
+//
+//	func TestSomethingThatUsesConnectorRevisionsService(t *testing.T) {
+//
+//		// make and configure a mocked ConnectorRevisionsService
+//		mockedConnectorRevisionsService := &ConnectorRevisionsServiceMock{
+//			GetFunc: func(ctx context.Context, connectorID string, revision int64) (*dbapi.ConnectorRevision, *errors.ServiceError) {
+//				panic("mock out the Get method")
+//			},
+//			ListFunc: func(ctx context.Context, connectorID string) (dbapi.ConnectorRevisionList, *errors.ServiceError) {
+//				panic("mock out the List method")
+//			},
+//			RecordFunc: func(ctx context.Context, connector *dbapi.Connector, shardMetadataID int64) (*dbapi.ConnectorRevision, *errors.ServiceError) {
+//				panic("mock out the Record method")
+//			},
+//			SecretRefsFunc: func(ctx context.Context, connectorID string) ([]string, *errors.ServiceError) {
+//				panic("mock out the SecretRefs method")
+//			},
+//		}
+//
+//		// use mockedConnectorRevisionsService in code that requires ConnectorRevisionsService
+//		// and then make assertions.
+//
+//	}
+type ConnectorRevisionsServiceMock struct {
+	// GetFunc mocks the Get method.
+	GetFunc func(ctx context.Context, connectorID string, revision int64) (*dbapi.ConnectorRevision, *errors.ServiceError)
+
+	// ListFunc mocks the List method.
+	ListFunc func(ctx context.Context, connectorID string) (dbapi.ConnectorRevisionList, *errors.ServiceError)
+
+	// RecordFunc mocks the Record method.
+	RecordFunc func(ctx context.Context, connector *dbapi.Connector, shardMetadataID int64) (*dbapi.ConnectorRevision, *errors.ServiceError)
+
+	// SecretRefsFunc mocks the SecretRefs method.
+	SecretRefsFunc func(ctx context.Context, connectorID string) ([]string, *errors.ServiceError)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Get holds details about calls to the Get method.
+		Get []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ConnectorID is the connectorID argument value.
+			ConnectorID string
+			// Revision is the revision argument value.
+			Revision int64
+		}
+		// List holds details about calls to the List method.
+		List []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ConnectorID is the connectorID argument value.
+			ConnectorID string
+		}
+		// Record holds details about calls to the Record method.
+		Record []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Connector is the connector argument value.
+			Connector *dbapi.Connector
+			// ShardMetadataID is the shardMetadataID argument value.
+			ShardMetadataID int64
+		}
+		// SecretRefs holds details about calls to the SecretRefs method.
+		SecretRefs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ConnectorID is the connectorID argument value.
+			ConnectorID string
+		}
+	}
+	lockGet        sync.RWMutex
+	lockList       sync.RWMutex
+	lockRecord     sync.RWMutex
+	lockSecretRefs sync.RWMutex
+}
+
+// Get calls GetFunc.
+func (mock *ConnectorRevisionsServiceMock) Get(ctx context.Context, connectorID string, revision int64) (*dbapi.ConnectorRevision, *errors.ServiceError) {
+	if mock.GetFunc == nil {
+		panic("ConnectorRevisionsServiceMock.GetFunc: method is nil but ConnectorRevisionsService.Get was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		ConnectorID string
+		Revision    int64
+	}{
+		Ctx:         ctx,
+		ConnectorID: connectorID,
+		Revision:    revision,
+	}
+	mock.lockGet.Lock()
+	mock.calls.Get = append(mock.calls.Get, callInfo)
+	mock.lockGet.Unlock()
+	return mock.GetFunc(ctx, connectorID, revision)
+}
+
+// GetCalls gets all the calls that were made to Get.
+// Check the length with:
+//
+//	len(mockedConnectorRevisionsService.GetCalls())
+func (mock *ConnectorRevisionsServiceMock) GetCalls() []struct {
+	Ctx         context.Context
+	ConnectorID string
+	Revision    int64
+} {
+	var calls []struct {
+		Ctx         context.Context
+		ConnectorID string
+		Revision    int64
+	}
+	mock.lockGet.RLock()
+	calls = mock.calls.Get
+	mock.lockGet.RUnlock()
+	return calls
+}
+
+// List calls ListFunc.
+func (mock *ConnectorRevisionsServiceMock) List(ctx context.Context, connectorID string) (dbapi.ConnectorRevisionList, *errors.ServiceError) {
+	if mock.ListFunc == nil {
+		panic("ConnectorRevisionsServiceMock.ListFunc: method is nil but ConnectorRevisionsService.List was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		ConnectorID string
+	}{
+		Ctx:         ctx,
+		ConnectorID: connectorID,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc(ctx, connectorID)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedConnectorRevisionsService.ListCalls())
+func (mock *ConnectorRevisionsServiceMock) ListCalls() []struct {
+	Ctx         context.Context
+	ConnectorID string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		ConnectorID string
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// Record calls RecordFunc.
+func (mock *ConnectorRevisionsServiceMock) Record(ctx context.Context, connector *dbapi.Connector, shardMetadataID int64) (*dbapi.ConnectorRevision, *errors.ServiceError) {
+	if mock.RecordFunc == nil {
+		panic("ConnectorRevisionsServiceMock.RecordFunc: method is nil but ConnectorRevisionsService.Record was just called")
+	}
+	callInfo := struct {
+		Ctx             context.Context
+		Connector       *dbapi.Connector
+		ShardMetadataID int64
+	}{
+		Ctx:             ctx,
+		Connector:       connector,
+		ShardMetadataID: shardMetadataID,
+	}
+	mock.lockRecord.Lock()
+	mock.calls.Record = append(mock.calls.Record, callInfo)
+	mock.lockRecord.Unlock()
+	return mock.RecordFunc(ctx, connector, shardMetadataID)
+}
+
+// RecordCalls gets all the calls that were made to Record.
+// Check the length with:
+//
+//	len(mockedConnectorRevisionsService.RecordCalls())
+func (mock *ConnectorRevisionsServiceMock) RecordCalls() []struct {
+	Ctx             context.Context
+	Connector       *dbapi.Connector
+	ShardMetadataID int64
+} {
+	var calls []struct {
+		Ctx             context.Context
+		Connector       *dbapi.Connector
+		ShardMetadataID int64
+	}
+	mock.lockRecord.RLock()
+	calls = mock.calls.Record
+	mock.lockRecord.RUnlock()
+	return calls
+}
+
+// SecretRefs calls SecretRefsFunc.
+func (mock *ConnectorRevisionsServiceMock) SecretRefs(ctx context.Context, connectorID string) ([]string, *errors.ServiceError) {
+	if mock.SecretRefsFunc == nil {
+		panic("ConnectorRevisionsServiceMock.SecretRefsFunc: method is nil but ConnectorRevisionsService.SecretRefs was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		ConnectorID string
+	}{
+		Ctx:         ctx,
+		ConnectorID: connectorID,
+	}
+	mock.lockSecretRefs.Lock()
+	mock.calls.SecretRefs = append(mock.calls.SecretRefs, callInfo)
+	mock.lockSecretRefs.Unlock()
+	return mock.SecretRefsFunc(ctx, connectorID)
+}
+
+// SecretRefsCalls gets all the calls that were made to SecretRefs.
+// Check the length with:
+//
+//	len(mockedConnectorRevisionsService.SecretRefsCalls())
+func (mock *ConnectorRevisionsServiceMock) SecretRefsCalls() []struct {
+	Ctx         context.Context
+	ConnectorID string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		ConnectorID string
+	}
+	mock.lockSecretRefs.RLock()
+	calls = mock.calls.SecretRefs
+	mock.lockSecretRefs.RUnlock()
+	return calls
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/vault"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_SameConnectorRevisionConfig(t *testing.T) {
+	revision := func(spec string, secretRef string) *dbapi.ConnectorRevision {
+		return &dbapi.ConnectorRevision{
+			ConnectorTypeId: "connector-type",
+			Channel:         "stable",
+			ConnectorSpec:   api.JSON(spec),
+			ServiceAccount:  dbapi.ServiceAccount{ClientId: "client", ClientSecretRef: secretRef},
+		}
+	}
+
+	tests := []struct {
+		name string
+		a    *dbapi.ConnectorRevision
+		b    *dbapi.ConnectorRevision
+		want bool
+	}{
+		{
+			name: "should ignore the formatting and the order of the keys of the specs",
+			a:    revision(`{"a": 1, "b": {"ref": "secret"}}`, "sa"),
+			b:    revision(`{"b":{"ref":"secret"},"a":1}`, "sa"),
+			want: true,
+		},
+		{
+			name: "should compare the specs",
+			a:    revision(`{"a": 1}`, "sa"),
+			b:    revision(`{"a": 2}`, "sa"),
+		},
+		{
+			name: "should compare the service account secrets",
+			a:    revision(`{"a": 1}`, "sa"),
+			b:    revision(`{"a": 1}`, "other-sa"),
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(SameConnectorRevisionConfig(tt.a, tt.b)).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_ConnectorRevisionsService_Record(t *testing.T) {
+	connectorTypesService := &ConnectorTypesServiceMock{
+		GetFunc: func(id string) (*dbapi.ConnectorType, *errors.ServiceError) {
+			return &dbapi.ConnectorType{JsonSchema: api.JSON(`{}`)}, nil
+		},
+	}
+	latest := func(revision int64, secretRef string) map[string]interface{} {
+		return map[string]interface{}{
+			"id": "latest", "connector_id": "connector", "revision": revision, "connector_type_id": "connector-type",
+			"connector_spec": []byte(`{"a":1}`), "service_account_client_id": "client", "service_account_client_secret": secretRef,
+			"secret_refs": []byte(`["` + secretRef + `"]`),
+		}
+	}
+
+	tests := []struct {
+		name         string
+		latest       []map[string]interface{}
+		pruned       []map[string]interface{}
+		secrets      []string
+		wantInsert   bool
+		wantRevision int64
+		wantSecrets  []string
+	}{
+		{
+			name:         "should record the first revision",
+			wantInsert:   true,
+			wantRevision: 1,
+			secrets:      []string{"sa"},
+			wantSecrets:  []string{"sa"},
+		},
+		{
+			name:         "should return the latest revision holding the same configuration",
+			latest:       []map[string]interface{}{latest(3, "sa")},
+			wantRevision: 3,
+			secrets:      []string{"sa"},
+			wantSecrets:  []string{"sa"},
+		},
+		{
+			name:         "should record a changed configuration as a new revision",
+			latest:       []map[string]interface{}{latest(3, "old-sa")},
+			wantInsert:   true,
+			wantRevision: 4,
+			secrets:      []string{"sa", "old-sa"},
+			wantSecrets:  []string{"sa", "old-sa"},
+		},
+		{
+			name:   "should prune the oldest revisions with the secrets no other revision uses",
+			latest: []map[string]interface{}{latest(MaxConnectorRevisions, "sa-10")},
+			pruned: []map[string]interface{}{
+				{"connector_id": "connector", "revision": 1, "secret_refs": []byte(`["sa-1", "shared"]`)},
+				{"connector_id": "connector", "revision": 2, "secret_refs": []byte(`["sa-2", "shared"]`)},
+			},
+			wantInsert:   true,
+			wantRevision: MaxConnectorRevisions + 1,
+			secrets:      []string{"sa", "sa-1", "sa-2", "shared"},
+			wantSecrets:  []string{"sa", "sa-2", "shared"},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().WithQuery(`select txid_current()`).WithReply([]map[string]interface{}{{"txid_current": 1}})
+			mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "connector_revisions" WHERE (connector_id = $1) AND "connector_revisions"."deleted_at" IS NULL ORDER BY revision desc LIMIT 1`).WithReply(tt.latest)
+			mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "connector_revisions" WHERE (connector_id = $1)`).WithReply(tt.pruned)
+			insert := mocket.Catcher.NewMock().WithQuery(`INSERT INTO "connector_revisions"`).WithRowsNum(1)
+			mocket.Catcher.NewMock().WithQuery(`DELETE FROM "connector_revisions"`).WithRowsNum(int64(len(tt.pruned)))
+
+			vaultService, err := vault.NewTmpVaultService()
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			for _, s := range tt.secrets {
+				g.Expect(vaultService.SetSecretString(s, "value", "connector")).To(gomega.Succeed())
+			}
+
+			connectionFactory := db.NewMockConnectionFactory(nil)
+			ctx, err := connectionFactory.NewContext(context.Background())
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+
+			connector := &dbapi.Connector{
+				ConnectorTypeId: "connector-type",
+				ConnectorSpec:   api.JSON(`{"a":1}`),
+				ServiceAccount:  dbapi.ServiceAccount{ClientId: "client", ClientSecretRef: "sa"},
+			}
+			connector.ID = "connector"
+			service := NewConnectorRevisionsService(connectionFactory, connectorTypesService, vaultService)
+			revision, serr := service.Record(ctx, connector, 0)
+			g.Expect(serr).To(gomega.BeNil())
+			g.Expect(db.Resolve(ctx)).To(gomega.Succeed())
+
+			g.Expect(insert.Triggered).To(gomega.Equal(tt.wantInsert))
+			g.Expect(revision.Revision).To(gomega.Equal(tt.wantRevision))
+			var remaining []string
+			g.Expect(vaultService.ForEachSecret(func(name string, owningResource string) bool {
+				remaining = append(remaining, name)
+				return true
+			})).To(gomega.Succeed())
+			g.Expect(remaining).To(gomega.ConsistOf(tt.wantSecrets))
+		})
+	}
+}
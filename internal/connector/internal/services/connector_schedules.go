@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/phase"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"gorm.io/gorm/clause"
+)
+
+// MaxConnectorSchedules is the maximum number of stop windows of a connector that are not completed yet
+const MaxConnectorSchedules = 10
+
+//go:generate moq -out connector_schedules_moq.go . ConnectorSchedulesService
+type ConnectorSchedulesService interface {
+	Create(ctx context.Context, schedule *dbapi.ConnectorSchedule) *errors.ServiceError
+	List(ctx context.Context, connectorID string) (dbapi.ConnectorScheduleList, *errors.ServiceError)
+	Delete(ctx context.Context, connectorID string, id string) *errors.ServiceError
+	// ListDue returns the pending stop windows that have started and the active stop windows that have ended at the given time
+	ListDue(now time.Time) (dbapi.ConnectorScheduleList, *errors.ServiceError)
+	// Apply stops or starts the connector of a due stop window and moves the window to its next state
+	Apply(ctx context.Context, schedule *dbapi.ConnectorSchedule, now time.Time) *errors.ServiceError
+	Update(ctx context.Context, schedule *dbapi.ConnectorSchedule) *errors.ServiceError
+}
+
+var _ ConnectorSchedulesService = &connectorSchedulesService{}
+
+type connectorSchedulesService struct {
+	connectionFactory *db.ConnectionFactory
+	connectorsService ConnectorsService
+	namespaceService  ConnectorNamespaceService
+}
+
+func NewConnectorSchedulesService(connectionFactory *db.ConnectionFactory, connectorsService ConnectorsService,
+	namespaceService ConnectorNamespaceService) *connectorSchedulesService {
+	return &connectorSchedulesService{
+		connectionFactory: connectionFactory,
+		connectorsService: connectorsService,
+		namespaceService:  namespaceService,
+	}
+}
+
+// Create creates a stop window for a connector, it must not overlap any other window of the connector that is not completed
+func (k *connectorSchedulesService) Create(ctx context.Context, schedule *dbapi.ConnectorSchedule) *errors.ServiceError {
+	dbConn := k.connectionFactory.New()
+
+	var schedules dbapi.ConnectorScheduleList
+	if err := dbConn.Where("connector_id = ? AND state <> ?", schedule.ConnectorID, dbapi.ConnectorScheduleCompleted).
+		Find(&schedules).Error; err != nil {
+		return errors.GeneralError("failed to list schedules of connector %s: %v", schedule.ConnectorID, err)
+	}
+	if len(schedules) >= MaxConnectorSchedules {
+		return errors.BadRequest("connector %s already has %d schedules that are not completed", schedule.ConnectorID, MaxConnectorSchedules)
+	}
+	for _, s := range schedules {
+		if schedule.StopAt.Before(s.StartAt) && s.StopAt.Before(schedule.StartAt) {
+			return errors.BadRequest("schedule overlaps schedule %s of connector %s", s.ID, schedule.ConnectorID)
+		}
+	}
+
+	schedule.State = dbapi.ConnectorSchedulePending
+	if err := dbConn.Create(schedule).Error; err != nil {
+		return services.HandleCreateError("Connector schedule", err)
+	}
+	return nil
+}
+
+func (k *connectorSchedulesService) List(ctx context.Context, connectorID string) (dbapi.ConnectorScheduleList, *errors.ServiceError) {
+	var schedules dbapi.ConnectorScheduleList
+	if err := k.connectionFactory.New().Where("connector_id = ?", connectorID).
+		Order("stop_at").Find(&schedules).Error; err != nil {
+		return nil, errors.GeneralError("failed to list schedules of connector %s: %v", connectorID, err)
+	}
+	return schedules, nil
+}
+
+// Delete deletes a stop window of a connector. An active window can't be deleted, the connector has to be started instead
+func (k *connectorSchedulesService) Delete(ctx context.Context, connectorID string, id string) *errors.ServiceError {
+	dbConn := k.connectionFactory.New()
+
+	var schedule dbapi.ConnectorSchedule
+	if err := dbConn.Where("id = ? AND connector_id = ?", id, connectorID).First(&schedule).Error; err != nil {
+		return services.HandleGetError("Connector schedule", "id", id, err)
+	}
+	if schedule.State == dbapi.ConnectorScheduleActive {
+		return errors.BadRequest("schedule %s is active, start connector %s instead of deleting it", id, connectorID)
+	}
+	if err := dbConn.Delete(&schedule).Error; err != nil {
+		return services.HandleDeleteError("Connector schedule", "id", id, err)
+	}
+	return nil
+}
+
+func (k *connectorSchedulesService) ListDue(now time.Time) (dbapi.ConnectorScheduleList, *errors.ServiceError) {
+	var schedules dbapi.ConnectorScheduleList
+	if err := k.connectionFactory.New().
+		Where("(state = ? AND stop_at <= ?) OR (state = ? AND start_at <= ?)",
+			dbapi.ConnectorSchedulePending, now, dbapi.ConnectorScheduleActive, now).
+		Order("stop_at").Find(&schedules).Error; err != nil {
+		return nil, errors.GeneralError("failed to list due connector schedules: %v", err)
+	}
+	return schedules, nil
+}
+
+func (k *connectorSchedulesService) Apply(ctx context.Context, schedule *dbapi.ConnectorSchedule, now time.Time) *errors.ServiceError {
+	var connector dbapi.Connector
+	if err := k.connectionFactory.New().Preload(clause.Associations).
+		Where("id = ?", schedule.ConnectorID).First(&connector).Error; err != nil {
+		if services.IsRecordNotFoundError(err) {
+			// the connector has been deleted, there is nothing left to stop or start
+			schedule.State = dbapi.ConnectorScheduleCompleted
+			schedule.Error = ""
+			return k.Update(ctx, schedule)
+		}
+		return services.HandleGetError("Connector", "id", schedule.ConnectorID, err)
+	}
+
+	switch schedule.State {
+	case dbapi.ConnectorSchedulePending:
+		if !now.Before(schedule.StartAt) {
+			// the whole window elapsed before it could be applied
+			schedule.State = dbapi.ConnectorScheduleCompleted
+			break
+		}
+		// only a running connector is stopped, so that a connector stopped by its owner isn't started at the end of the window
+		if connector.DesiredState == dbapi.ConnectorReady {
+			stopped, err := k.performOperation(ctx, &connector, phase.StopConnector)
+			if err != nil {
+				return err
+			}
+			schedule.ConnectorStopped = stopped
+		}
+		schedule.State = dbapi.ConnectorScheduleActive
+	case dbapi.ConnectorScheduleActive:
+		// the connector isn't started if it's been changed by its owner during the window
+		if schedule.ConnectorStopped && connector.DesiredState == dbapi.ConnectorStopped {
+			if _, err := k.performOperation(ctx, &connector, phase.RestartConnector); err != nil {
+				return err
+			}
+		}
+		schedule.State = dbapi.ConnectorScheduleCompleted
+	}
+
+	schedule.Error = ""
+	return k.Update(ctx, schedule)
+}
+
+func (k *connectorSchedulesService) Update(ctx context.Context, schedule *dbapi.ConnectorSchedule) *errors.ServiceError {
+	if err := k.connectionFactory.New().Model(schedule).
+		Select("state", "connector_stopped", "error").Updates(schedule).Error; err != nil {
+		return services.HandleUpdateError("Connector schedule", err)
+	}
+	return nil
+}
+
+func (k *connectorSchedulesService) performOperation(ctx context.Context, connector *dbapi.Connector, operation phase.ConnectorOperation) (bool, *errors.ServiceError) {
+	if connector.NamespaceId == nil {
+		return false, errors.BadRequest("connector %s is not assigned to a namespace", connector.ID)
+	}
+	namespace, err := k.namespaceService.Get(ctx, *connector.NamespaceId)
+	if err != nil {
+		return false, err
+	}
+	return phase.PerformConnectorOperation(namespace, connector, operation, func(connector *dbapi.Connector) *errors.ServiceError {
+		if err := k.connectorsService.SaveStatus(ctx, connector.Status); err != nil {
+			return err
+		}
+		return k.connectorsService.Update(ctx, connector)
+	})
+}
@@ -0,0 +1,340 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package services
+
+import (
+	"context"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"sync"
+	"time"
+)
+
+// Ensure, that ConnectorSchedulesServiceMock does implement ConnectorSchedulesService.
+// If this is not the case, regenerate this file with moq.
+var _ ConnectorSchedulesService = &ConnectorSchedulesServiceMock{}
+
+// ConnectorSchedulesServiceMock is a mock implementation of ConnectorSchedulesService.
+//
+//	func TestSomethingThatUsesConnectorSchedulesService(t *testing.T) {
+//
+//		// make and configure a mocked ConnectorSchedulesService
+//		mockedConnectorSchedulesService := &ConnectorSchedulesServiceMock{
+//			ApplyFunc: func(ctx context.Context, schedule *dbapi.ConnectorSchedule, now time.Time) *errors.ServiceError {
+//				panic("mock out the Apply method")
+//			},
+//			CreateFunc: func(ctx context.Context, schedule *dbapi.ConnectorSchedule) *errors.ServiceError {
+//				panic("mock out the Create method")
+//			},
+//			DeleteFunc: func(ctx context.Context, connectorID string, id string) *errors.ServiceError {
+//				panic("mock out the Delete method")
+//			},
+//			ListFunc: func(ctx context.Context, connectorID string) (dbapi.ConnectorScheduleList, *errors.ServiceError) {
+//				panic("mock out the List method")
+//			},
+//			ListDueFunc: func(now time.Time) (dbapi.ConnectorScheduleList, *errors.ServiceError) {
+//				panic("mock out the ListDue method")
+//			},
+//			UpdateFunc: func(ctx context.Context, schedule *dbapi.ConnectorSchedule) *errors.ServiceError {
+//				panic("mock out the Update method")
+//			},
+//		}
+//
+//		// use mockedConnectorSchedulesService in code that requires ConnectorSchedulesService
+//		// and then make assertions.
+//
+//	}
+type ConnectorSchedulesServiceMock struct {
+	// ApplyFunc mocks the Apply method.
+	ApplyFunc func(ctx context.Context, schedule *dbapi.ConnectorSchedule, now time.Time) *errors.ServiceError
+
+	// CreateFunc mocks the Create method.
+	CreateFunc func(ctx context.Context, schedule *dbapi.ConnectorSchedule) *errors.ServiceError
+
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(ctx context.Context, connectorID string, id string) *errors.ServiceError
+
+	// ListFunc mocks the List method.
+	ListFunc func(ctx context.Context, connectorID string) (dbapi.ConnectorScheduleList, *errors.ServiceError)
+
+	// ListDueFunc mocks the ListDue method.
+	ListDueFunc func(now time.Time) (dbapi.ConnectorScheduleList, *errors.ServiceError)
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(ctx context.Context, schedule *dbapi.ConnectorSchedule) *errors.ServiceError
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Apply holds details about calls to the Apply method.
+		Apply []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Schedule is the schedule argument value.
+			Schedule *dbapi.ConnectorSchedule
+			// Now is the now argument value.
+			Now time.Time
+		}
+		// Create holds details about calls to the Create method.
+		Create []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Schedule is the schedule argument value.
+			Schedule *dbapi.ConnectorSchedule
+		}
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ConnectorID is the connectorID argument value.
+			ConnectorID string
+			// ID is the id argument value.
+			ID string
+		}
+		// List holds details about calls to the List method.
+		List []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ConnectorID is the connectorID argument value.
+			ConnectorID string
+		}
+		// ListDue holds details about calls to the ListDue method.
+		ListDue []struct {
+			// Now is the now argument value.
+			Now time.Time
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Schedule is the schedule argument value.
+			Schedule *dbapi.ConnectorSchedule
+		}
+	}
+	lockApply   sync.RWMutex
+	lockCreate  sync.RWMutex
+	lockDelete  sync.RWMutex
+	lockList    sync.RWMutex
+	lockListDue sync.RWMutex
+	lockUpdate  sync.RWMutex
+}
+
+// Apply calls ApplyFunc.
+func (mock *ConnectorSchedulesServiceMock) Apply(ctx context.Context, schedule *dbapi.ConnectorSchedule, now time.Time) *errors.ServiceError {
+	if mock.ApplyFunc == nil {
+		panic("ConnectorSchedulesServiceMock.ApplyFunc: method is nil but ConnectorSchedulesService.Apply was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Schedule *dbapi.ConnectorSchedule
+		Now      time.Time
+	}{
+		Ctx:      ctx,
+		Schedule: schedule,
+		Now:      now,
+	}
+	mock.lockApply.Lock()
+	mock.calls.Apply = append(mock.calls.Apply, callInfo)
+	mock.lockApply.Unlock()
+	return mock.ApplyFunc(ctx, schedule, now)
+}
+
+// ApplyCalls gets all the calls that were made to Apply.
+// Check the length with:
+//
+//	len(mockedConnectorSchedulesService.ApplyCalls())
+func (mock *ConnectorSchedulesServiceMock) ApplyCalls() []struct {
+	Ctx      context.Context
+	Schedule *dbapi.ConnectorSchedule
+	Now      time.Time
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Schedule *dbapi.ConnectorSchedule
+		Now      time.Time
+	}
+	mock.lockApply.RLock()
+	calls = mock.calls.Apply
+	mock.lockApply.RUnlock()
+	return calls
+}
+
+// Create calls CreateFunc.
+func (mock *ConnectorSchedulesServiceMock) Create(ctx context.Context, schedule *dbapi.ConnectorSchedule) *errors.ServiceError {
+	if mock.CreateFunc == nil {
+		panic("ConnectorSchedulesServiceMock.CreateFunc: method is nil but ConnectorSchedulesService.Create was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Schedule *dbapi.ConnectorSchedule
+	}{
+		Ctx:      ctx,
+		Schedule: schedule,
+	}
+	mock.lockCreate.Lock()
+	mock.calls.Create = append(mock.calls.Create, callInfo)
+	mock.lockCreate.Unlock()
+	return mock.CreateFunc(ctx, schedule)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedConnectorSchedulesService.CreateCalls())
+func (mock *ConnectorSchedulesServiceMock) CreateCalls() []struct {
+	Ctx      context.Context
+	Schedule *dbapi.ConnectorSchedule
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Schedule *dbapi.ConnectorSchedule
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
+
+// Delete calls DeleteFunc.
+func (mock *ConnectorSchedulesServiceMock) Delete(ctx context.Context, connectorID string, id string) *errors.ServiceError {
+	if mock.DeleteFunc == nil {
+		panic("ConnectorSchedulesServiceMock.DeleteFunc: method is nil but ConnectorSchedulesService.Delete was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		ConnectorID string
+		ID          string
+	}{
+		Ctx:         ctx,
+		ConnectorID: connectorID,
+		ID:          id,
+	}
+	mock.lockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	mock.lockDelete.Unlock()
+	return mock.DeleteFunc(ctx, connectorID, id)
+}
+
+// DeleteCalls gets all the calls that were made to Delete.
+// Check the length with:
+//
+//	len(mockedConnectorSchedulesService.DeleteCalls())
+func (mock *ConnectorSchedulesServiceMock) DeleteCalls() []struct {
+	Ctx         context.Context
+	ConnectorID string
+	ID          string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		ConnectorID string
+		ID          string
+	}
+	mock.lockDelete.RLock()
+	calls = mock.calls.Delete
+	mock.lockDelete.RUnlock()
+	return calls
+}
+
+// List calls ListFunc.
+func (mock *ConnectorSchedulesServiceMock) List(ctx context.Context, connectorID string) (dbapi.ConnectorScheduleList, *errors.ServiceError) {
+	if mock.ListFunc == nil {
+		panic("ConnectorSchedulesServiceMock.ListFunc: method is nil but ConnectorSchedulesService.List was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		ConnectorID string
+	}{
+		Ctx:         ctx,
+		ConnectorID: connectorID,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc(ctx, connectorID)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedConnectorSchedulesService.ListCalls())
+func (mock *ConnectorSchedulesServiceMock) ListCalls() []struct {
+	Ctx         context.Context
+	ConnectorID string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		ConnectorID string
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// ListDue calls ListDueFunc.
+func (mock *ConnectorSchedulesServiceMock) ListDue(now time.Time) (dbapi.ConnectorScheduleList, *errors.ServiceError) {
+	if mock.ListDueFunc == nil {
+		panic("ConnectorSchedulesServiceMock.ListDueFunc: method is nil but ConnectorSchedulesService.ListDue was just called")
+	}
+	callInfo := struct {
+		Now time.Time
+	}{
+		Now: now,
+	}
+	mock.lockListDue.Lock()
+	mock.calls.ListDue = append(mock.calls.ListDue, callInfo)
+	mock.lockListDue.Unlock()
+	return mock.ListDueFunc(now)
+}
+
+// ListDueCalls gets all the calls that were made to ListDue.
+// Check the length with:
+//
+//	len(mockedConnectorSchedulesService.ListDueCalls())
+func (mock *ConnectorSchedulesServiceMock) ListDueCalls() []struct {
+	Now time.Time
+} {
+	var calls []struct {
+		Now time.Time
+	}
+	mock.lockListDue.RLock()
+	calls = mock.calls.ListDue
+	mock.lockListDue.RUnlock()
+	return calls
+}
+
+// Update calls UpdateFunc.
+func (mock *ConnectorSchedulesServiceMock) Update(ctx context.Context, schedule *dbapi.ConnectorSchedule) *errors.ServiceError {
+	if mock.UpdateFunc == nil {
+		panic("ConnectorSchedulesServiceMock.UpdateFunc: method is nil but ConnectorSchedulesService.Update was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Schedule *dbapi.ConnectorSchedule
+	}{
+		Ctx:      ctx,
+		Schedule: schedule,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	return mock.UpdateFunc(ctx, schedule)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedConnectorSchedulesService.UpdateCalls())
+func (mock *ConnectorSchedulesServiceMock) UpdateCalls() []struct {
+	Ctx      context.Context
+	Schedule *dbapi.ConnectorSchedule
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Schedule *dbapi.ConnectorSchedule
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
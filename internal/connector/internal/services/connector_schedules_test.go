@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_ConnectorSchedulesService_Create(t *testing.T) {
+	stopAt := time.Date(2023, 4, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		existing []map[string]interface{}
+		stopAt   time.Time
+		startAt  time.Time
+		wantCode int
+	}{
+		{
+			name:    "should create a window without other windows",
+			stopAt:  stopAt,
+			startAt: stopAt.Add(time.Hour),
+		},
+		{
+			name: "should create a window after another window",
+			existing: []map[string]interface{}{
+				{"id": "other", "stop_at": stopAt.Add(-2 * time.Hour), "start_at": stopAt.Add(-time.Hour), "state": "pending"},
+			},
+			stopAt:  stopAt,
+			startAt: stopAt.Add(time.Hour),
+		},
+		{
+			name: "should reject a window overlapping another window",
+			existing: []map[string]interface{}{
+				{"id": "other", "stop_at": stopAt.Add(30 * time.Minute), "start_at": stopAt.Add(2 * time.Hour), "state": "pending"},
+			},
+			stopAt:   stopAt,
+			startAt:  stopAt.Add(time.Hour),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "should reject a window within another window",
+			existing: []map[string]interface{}{
+				{"id": "other", "stop_at": stopAt.Add(-time.Hour), "start_at": stopAt.Add(2 * time.Hour), "state": "active"},
+			},
+			stopAt:   stopAt,
+			startAt:  stopAt.Add(time.Hour),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "should reject a window once the connector has the maximum number of windows",
+			existing: func() []map[string]interface{} {
+				var windows []map[string]interface{}
+				for i := 0; i < MaxConnectorSchedules; i++ {
+					windows = append(windows, map[string]interface{}{
+						"id": "other", "stop_at": stopAt.Add(-time.Duration(i+2) * time.Hour), "start_at": stopAt.Add(-time.Duration(i+1) * time.Hour), "state": "pending",
+					})
+				}
+				return windows
+			}(),
+			stopAt:   stopAt,
+			startAt:  stopAt.Add(time.Hour),
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "connector_schedules" WHERE (connector_id = $1 AND state <> $2)`).WithReply(tt.existing)
+			insert := mocket.Catcher.NewMock().WithQuery(`INSERT INTO "connector_schedules"`).WithRowsNum(1)
+
+			service := NewConnectorSchedulesService(db.NewMockConnectionFactory(nil), nil, nil)
+			schedule := &dbapi.ConnectorSchedule{ConnectorID: "connector", StopAt: tt.stopAt, StartAt: tt.startAt}
+			err := service.Create(context.Background(), schedule)
+
+			g.Expect(insert.Triggered).To(gomega.Equal(tt.wantCode == 0))
+			if tt.wantCode != 0 {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.HttpCode).To(gomega.Equal(tt.wantCode))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(schedule.State).To(gomega.Equal(dbapi.ConnectorSchedulePending))
+		})
+	}
+}
+
+func Test_ConnectorSchedulesService_Apply(t *testing.T) {
+	now := time.Date(2023, 4, 1, 10, 0, 0, 0, time.UTC)
+	namespaceId := "namespace"
+
+	tests := []struct {
+		name                 string
+		state                dbapi.ConnectorScheduleState
+		connectorStopped     bool
+		startAt              time.Time
+		connector            map[string]interface{}
+		wantState            dbapi.ConnectorScheduleState
+		wantConnectorStopped bool
+		wantDesiredState     dbapi.ConnectorDesiredState
+	}{
+		{
+			name:                 "should stop a ready connector when the window starts",
+			state:                dbapi.ConnectorSchedulePending,
+			startAt:              now.Add(time.Hour),
+			connector:            map[string]interface{}{"id": "connector", "namespace_id": namespaceId, "desired_state": "ready"},
+			wantState:            dbapi.ConnectorScheduleActive,
+			wantConnectorStopped: true,
+			wantDesiredState:     dbapi.ConnectorStopped,
+		},
+		{
+			name:      "should not stop a connector already stopped by its owner",
+			state:     dbapi.ConnectorSchedulePending,
+			startAt:   now.Add(time.Hour),
+			connector: map[string]interface{}{"id": "connector", "namespace_id": namespaceId, "desired_state": "stopped"},
+			wantState: dbapi.ConnectorScheduleActive,
+		},
+		{
+			name:      "should complete a window that elapsed before it was applied",
+			state:     dbapi.ConnectorSchedulePending,
+			startAt:   now,
+			connector: map[string]interface{}{"id": "connector", "namespace_id": namespaceId, "desired_state": "ready"},
+			wantState: dbapi.ConnectorScheduleCompleted,
+		},
+		{
+			name:             "should start the connector stopped by the window when it ends",
+			state:            dbapi.ConnectorScheduleActive,
+			connectorStopped: true,
+			startAt:          now,
+			connector:        map[string]interface{}{"id": "connector", "namespace_id": namespaceId, "desired_state": "stopped"},
+			wantState:        dbapi.ConnectorScheduleCompleted,
+			wantDesiredState: dbapi.ConnectorReady,
+		},
+		{
+			name:      "should not start a connector the window didn't stop",
+			state:     dbapi.ConnectorScheduleActive,
+			startAt:   now,
+			connector: map[string]interface{}{"id": "connector", "namespace_id": namespaceId, "desired_state": "stopped"},
+			wantState: dbapi.ConnectorScheduleCompleted,
+		},
+		{
+			name:             "should not start a connector deleted during the window",
+			state:            dbapi.ConnectorScheduleActive,
+			connectorStopped: true,
+			startAt:          now,
+			connector:        map[string]interface{}{"id": "connector", "namespace_id": namespaceId, "desired_state": "deleted"},
+			wantState:        dbapi.ConnectorScheduleCompleted,
+		},
+		{
+			name:      "should complete the window of a deleted connector",
+			state:     dbapi.ConnectorScheduleActive,
+			startAt:   now,
+			wantState: dbapi.ConnectorScheduleCompleted,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			var reply []map[string]interface{}
+			if tt.connector != nil {
+				reply = []map[string]interface{}{tt.connector}
+			}
+			mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "connectors" WHERE id = $1`).WithReply(reply)
+			update := mocket.Catcher.NewMock().WithQuery(`UPDATE "connector_schedules" SET`).WithRowsNum(1)
+
+			var updated *dbapi.Connector
+			connectorsService := &ConnectorsServiceMock{
+				SaveStatusFunc: func(ctx context.Context, resource dbapi.ConnectorStatus) *errors.ServiceError {
+					return nil
+				},
+				UpdateFunc: func(ctx context.Context, resource *dbapi.Connector) *errors.ServiceError {
+					updated = resource
+					return nil
+				},
+			}
+			namespaceService := &ConnectorNamespaceServiceMock{
+				GetFunc: func(ctx context.Context, namespaceID string) (*dbapi.ConnectorNamespace, *errors.ServiceError) {
+					namespace := &dbapi.ConnectorNamespace{}
+					namespace.ID = namespaceID
+					namespace.Status.Phase = dbapi.ConnectorNamespacePhaseReady
+					return namespace, nil
+				},
+			}
+
+			service := NewConnectorSchedulesService(db.NewMockConnectionFactory(nil), connectorsService, namespaceService)
+			schedule := &dbapi.ConnectorSchedule{
+				ConnectorID:      "connector",
+				StopAt:           now.Add(-time.Hour),
+				StartAt:          tt.startAt,
+				State:            tt.state,
+				ConnectorStopped: tt.connectorStopped,
+				Error:            "previous error",
+			}
+			schedule.ID = "schedule"
+			g.Expect(service.Apply(context.Background(), schedule, now)).To(gomega.BeNil())
+
+			g.Expect(update.Triggered).To(gomega.BeTrue())
+			g.Expect(schedule.State).To(gomega.Equal(tt.wantState))
+			g.Expect(schedule.Error).To(gomega.BeEmpty())
+			if tt.state == dbapi.ConnectorSchedulePending {
+				g.Expect(schedule.ConnectorStopped).To(gomega.Equal(tt.wantConnectorStopped))
+			}
+			if tt.wantDesiredState == "" {
+				g.Expect(updated).To(gomega.BeNil())
+				return
+			}
+			g.Expect(updated).ToNot(gomega.BeNil())
+			g.Expect(updated.DesiredState).To(gomega.Equal(tt.wantDesiredState))
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/metrics"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/vault"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+)
+
+const (
+	// secretsGCBatchSize is the number of owning resources looked up in the database at once
+	secretsGCBatchSize = 500
+)
+
+const (
+	SecretOrphanReasonConnectorGone = "owning connector doesn't exist"
+	SecretOrphanReasonNotReferenced = "not referenced by the owning connector"
+)
+
+type SecretsGCOptions struct {
+	// DryRun only reports the orphaned secrets that would be deleted
+	DryRun bool
+	// GracePeriod is the time a secret must have been orphaned for before it's deleted
+	GracePeriod time.Duration
+	Now         time.Time
+}
+
+type OrphanedSecret struct {
+	Name           string
+	OwningResource string
+	Reason         string
+	DetectedAt     time.Time
+	// Expired is true once the grace period of the secret has expired
+	Expired bool
+	// Deleted is true if the secret has been deleted from the vault
+	Deleted bool
+}
+
+type SecretsGCResult struct {
+	Scanned  int
+	Orphaned []OrphanedSecret
+	Deleted  int
+	Failed   int
+}
+
+type ConnectorSecretsGCService interface {
+	// CollectOrphanedSecrets finds the connector secrets in the vault whose owning resource doesn't exist or doesn't
+	// reference them anymore, and deletes the ones that have been orphaned for longer than the grace period
+	CollectOrphanedSecrets(ctx context.Context, options SecretsGCOptions) (*SecretsGCResult, *errors.ServiceError)
+}
+
+var _ ConnectorSecretsGCService = &connectorSecretsGCService{}
+
+type connectorSecretsGCService struct {
+	connectionFactory     *db.ConnectionFactory
+	vaultService          vault.VaultService
+	connectorTypesService ConnectorTypesService
+}
+
+func NewConnectorSecretsGCService(connectionFactory *db.ConnectionFactory, vaultService vault.VaultService,
+	connectorTypesService ConnectorTypesService) *connectorSecretsGCService {
+	return &connectorSecretsGCService{
+		connectionFactory:     connectionFactory,
+		vaultService:          vaultService,
+		connectorTypesService: connectorTypesService,
+	}
+}
+
+type ownedSecret struct {
+	name  string
+	owner string
+}
+
+func (k *connectorSecretsGCService) CollectOrphanedSecrets(ctx context.Context, options SecretsGCOptions) (*SecretsGCResult, *errors.ServiceError) {
+	result := &SecretsGCResult{}
+
+	// secrets without a known owning resource aren't managed by the connector service
+	var secrets []ownedSecret
+	if err := k.vaultService.ForEachSecret(func(name string, owningResource string) bool {
+		result.Scanned++
+		if strings.HasPrefix(owningResource, vault.ConnectorOwningResourcePrefix) {
+			secrets = append(secrets, ownedSecret{name: name, owner: owningResource})
+		}
+		return true
+	}); err != nil {
+		return nil, errors.GeneralError("failed to list vault secrets: %v", err)
+	}
+
+	reasons, serr := k.findOrphans(secrets)
+	if serr != nil {
+		return nil, serr
+	}
+
+	dbConn := k.connectionFactory.New()
+	var tracked dbapi.ConnectorSecretOrphanList
+	if err := dbConn.Find(&tracked).Error; err != nil {
+		return nil, errors.GeneralError("failed to list orphaned connector secrets: %v", err)
+	}
+	detected := make(map[string]*dbapi.ConnectorSecretOrphan, len(tracked))
+	for _, orphan := range tracked {
+		if _, ok := reasons[orphan.ID]; ok {
+			detected[orphan.ID] = orphan
+			continue
+		}
+		// the secret has been deleted or is used again
+		if err := dbConn.Unscoped().Delete(orphan).Error; err != nil {
+			return nil, errors.GeneralError("failed to remove orphaned connector secret %s: %v", orphan.ID, err)
+		}
+	}
+
+	for _, secret := range secrets {
+		reason, ok := reasons[secret.name]
+		if !ok {
+			continue
+		}
+		orphan, ok := detected[secret.name]
+		if !ok {
+			orphan = &dbapi.ConnectorSecretOrphan{
+				Model: db.Model{
+					ID: secret.name,
+				},
+				OwningResource: secret.owner,
+				Reason:         reason,
+				DetectedAt:     options.Now,
+			}
+			if err := dbConn.Create(orphan).Error; err != nil {
+				return nil, errors.GeneralError("failed to record orphaned connector secret %s: %v", secret.name, err)
+			}
+		}
+
+		orphaned := OrphanedSecret{
+			Name:           secret.name,
+			OwningResource: secret.owner,
+			Reason:         reason,
+			DetectedAt:     orphan.DetectedAt,
+			Expired:        !options.Now.Before(orphan.DetectedAt.Add(options.GracePeriod)),
+		}
+		if orphaned.Expired && !options.DryRun {
+			if err := k.vaultService.DeleteSecretString(secret.name); err != nil {
+				metrics.IncreaseConnectorSecretsGCFailureCount()
+				result.Failed++
+				logger.Logger.Errorf("failed to delete orphaned vault secret key '%s': %v", secret.name, err)
+			} else {
+				metrics.IncreaseConnectorSecretsGCDeletedCount()
+				result.Deleted++
+				orphaned.Deleted = true
+				if err := dbConn.Unscoped().Delete(orphan).Error; err != nil {
+					return nil, errors.GeneralError("failed to remove orphaned connector secret %s: %v", secret.name, err)
+				}
+			}
+		}
+		result.Orphaned = append(result.Orphaned, orphaned)
+	}
+
+	metrics.SetConnectorSecretsGCOrphanedCount(len(result.Orphaned) - result.Deleted)
+	return result, nil
+}
+
+// findOrphans returns the reasons why secrets are orphaned indexed by secret name
+func (k *connectorSecretsGCService) findOrphans(secrets []ownedSecret) (map[string]string, *errors.ServiceError) {
+	var connectorIds []string
+	seen := make(map[string]bool)
+	for _, s := range secrets {
+		if seen[s.owner] {
+			continue
+		}
+		seen[s.owner] = true
+		connectorIds = append(connectorIds, strings.TrimPrefix(s.owner, vault.ConnectorOwningResourcePrefix))
+	}
+
+	// secrets in use indexed by connector id, connectors that don't exist aren't in the map
+	usedSecrets := make(map[string][]string, len(connectorIds))
+	for start := 0; start < len(connectorIds); start += secretsGCBatchSize {
+		end := start + secretsGCBatchSize
+		if end > len(connectorIds) {
+			end = len(connectorIds)
+		}
+		if err := k.connectorSecrets(connectorIds[start:end], usedSecrets); err != nil {
+			return nil, err
+		}
+	}
+
+	reasons := make(map[string]string)
+	for _, s := range secrets {
+		used, ok := usedSecrets[strings.TrimPrefix(s.owner, vault.ConnectorOwningResourcePrefix)]
+		if !ok {
+			reasons[s.name] = SecretOrphanReasonConnectorGone
+		} else if used != nil && !arrays.Contains(used, s.name) {
+			reasons[s.name] = SecretOrphanReasonNotReferenced
+		}
+	}
+	return reasons, nil
+}
+
+// connectorSecrets adds the secrets used by the existing connectors and by their revisions to usedSecrets.
+// The secrets of connectors whose type is unknown can't be determined, they are added with nil secrets so that none is removed.
+func (k *connectorSecretsGCService) connectorSecrets(connectorIds []string, usedSecrets map[string][]string) *errors.ServiceError {
+	dbConn := k.connectionFactory.New()
+
+	var connectors dbapi.ConnectorList
+	if err := dbConn.Where("id IN ?", connectorIds).Find(&connectors).Error; err != nil {
+		return errors.GeneralError("failed to get connectors: %v", err)
+	}
+	for _, c := range connectors {
+		ct, err := k.connectorTypesService.Get(c.ConnectorTypeId)
+		if err != nil {
+			logger.Logger.Warningf("skipping secrets of connector %s with unknown type %s: %v", c.ID, c.ConnectorTypeId, err)
+			usedSecrets[c.ID] = nil
+			continue
+		}
+		refs, rerr := GetConnectorSecretRefs(c, ct)
+		if rerr != nil {
+			logger.Logger.Warningf("skipping secrets of connector %s: %v", c.ID, rerr)
+			usedSecrets[c.ID] = nil
+			continue
+		}
+		// non nil, even for connectors without secrets
+		usedSecrets[c.ID] = append(make([]string, 0, len(refs)), refs...)
+	}
+
+	var revisions dbapi.ConnectorRevisionList
+	if err := dbConn.Where("connector_id IN ?", connectorIds).Find(&revisions).Error; err != nil {
+		return errors.GeneralError("failed to get connector revisions: %v", err)
+	}
+	for _, r := range revisions {
+		used, ok := usedSecrets[r.ConnectorID]
+		if !ok || used == nil {
+			continue
+		}
+		refs, err := revisionSecretRefs(dbapi.ConnectorRevisionList{r})
+		if err != nil {
+			return err
+		}
+		usedSecrets[r.ConnectorID] = append(used, refs...)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/vault"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+// connectorTypesServiceStub only implements Get, which is all the secrets gc needs
+type connectorTypesServiceStub struct {
+	ConnectorTypesService
+}
+
+func (s *connectorTypesServiceStub) Get(id string) (*dbapi.ConnectorType, *errors.ServiceError) {
+	return &dbapi.ConnectorType{Model: db.Model{ID: id}}, nil
+}
+
+func Test_connectorSecretsGCService_CollectOrphanedSecrets(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		options     SecretsGCOptions
+		secrets     map[string]string
+		connectors  []map[string]interface{}
+		orphans     []map[string]interface{}
+		wantOrphans map[string]string
+		wantDeleted []string
+	}{
+		{
+			name:    "secrets of missing connectors are deleted without a grace period",
+			options: SecretsGCOptions{Now: now},
+			secrets: map[string]string{
+				"s1": vault.ConnectorOwningResourcePrefix + "c1",
+				"s2": "",
+				"s3": "/v1/other/c1",
+			},
+			wantOrphans: map[string]string{"s1": SecretOrphanReasonConnectorGone},
+			wantDeleted: []string{"s1"},
+		},
+		{
+			name:    "secrets not referenced by their connector are orphaned",
+			options: SecretsGCOptions{Now: now},
+			secrets: map[string]string{
+				"s1": vault.ConnectorOwningResourcePrefix + "c1",
+				"s2": vault.ConnectorOwningResourcePrefix + "c1",
+			},
+			connectors: []map[string]interface{}{
+				{"id": "c1", "connector_type_id": "ct1", "service_account_client_secret": "s1"},
+			},
+			wantOrphans: map[string]string{"s2": SecretOrphanReasonNotReferenced},
+			wantDeleted: []string{"s2"},
+		},
+		{
+			name:    "secrets without a connector owner are ignored",
+			options: SecretsGCOptions{Now: now},
+			secrets: map[string]string{
+				"s1": "",
+				"s2": "/v1/other/o1",
+			},
+			wantOrphans: map[string]string{},
+		},
+		{
+			name:    "orphaned secrets are kept during the grace period",
+			options: SecretsGCOptions{Now: now, GracePeriod: time.Hour},
+			secrets: map[string]string{
+				"s1": vault.ConnectorOwningResourcePrefix + "c1",
+				"s2": vault.ConnectorOwningResourcePrefix + "c2",
+			},
+			orphans: []map[string]interface{}{
+				{"id": "s2", "owning_resource": vault.ConnectorOwningResourcePrefix + "c2", "detected_at": now.Add(-2 * time.Hour)},
+			},
+			wantOrphans: map[string]string{"s1": SecretOrphanReasonConnectorGone, "s2": SecretOrphanReasonConnectorGone},
+			wantDeleted: []string{"s2"},
+		},
+		{
+			name:    "orphaned secrets aren't deleted in dry run mode",
+			options: SecretsGCOptions{Now: now, DryRun: true},
+			secrets: map[string]string{
+				"s1": vault.ConnectorOwningResourcePrefix + "c1",
+			},
+			wantOrphans: map[string]string{"s1": SecretOrphanReasonConnectorGone},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			vaultService, _ := vault.NewTmpVaultService()
+			for name, owner := range tt.secrets {
+				g.Expect(vaultService.SetSecretString(name, "secret", owner)).To(gomega.Succeed())
+			}
+
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "connectors"`).WithReply(tt.connectors)
+			mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "connector_revisions"`).WithReply(nil)
+			mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "connector_secret_orphans"`).WithReply(tt.orphans)
+
+			k := NewConnectorSecretsGCService(db.NewMockConnectionFactory(nil), vaultService, &connectorTypesServiceStub{})
+			result, err := k.CollectOrphanedSecrets(context.Background(), tt.options)
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(result.Scanned).To(gomega.Equal(len(tt.secrets)))
+
+			orphans := make(map[string]string)
+			var deleted []string
+			for _, orphan := range result.Orphaned {
+				orphans[orphan.Name] = orphan.Reason
+				if orphan.Deleted {
+					deleted = append(deleted, orphan.Name)
+				}
+			}
+			g.Expect(orphans).To(gomega.Equal(tt.wantOrphans))
+			g.Expect(deleted).To(gomega.ConsistOf(tt.wantDeleted))
+			g.Expect(result.Deleted).To(gomega.Equal(len(tt.wantDeleted)))
+
+			for name := range tt.secrets {
+				_, err := vaultService.GetSecretString(name)
+				if arrays.Contains(tt.wantDeleted, name) {
+					g.Expect(err).To(gomega.Equal(vault.NotFound))
+				} else {
+					g.Expect(err).To(gomega.BeNil())
+				}
+			}
+		})
+	}
+}
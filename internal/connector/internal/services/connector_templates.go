@@ -0,0 +1,486 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/queryparser"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/secrets"
+	"github.com/golang/glog"
+	"github.com/spyzhov/ajson"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MaxConnectorTemplateParameters is the maximum number of parameters a template can declare
+const MaxConnectorTemplateParameters = 50
+
+// ConnectorTemplateParameterName is the pattern of template parameter names
+var ConnectorTemplateParameterName = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// connectorTemplatePlaceholder matches the ${name} parameter placeholders in template specs
+var connectorTemplatePlaceholder = regexp.MustCompile(`\$\{([a-z_][a-z0-9_]*)\}`)
+
+type ConnectorTemplatesService interface {
+	Create(ctx context.Context, template *dbapi.ConnectorTemplate) *errors.ServiceError
+	Get(ctx context.Context, id string) (*dbapi.ConnectorTemplate, *errors.ServiceError)
+	List(ctx context.Context, listArgs *services.ListArguments) (dbapi.ConnectorTemplateList, *api.PagingMeta, *errors.ServiceError)
+	// Update saves a template and increments its version, it fails if the template was updated concurrently
+	Update(ctx context.Context, template *dbapi.ConnectorTemplate) *errors.ServiceError
+	Delete(ctx context.Context, id string) *errors.ServiceError
+	// RecordRender records the template version and the parameter values a connector was created from,
+	// the values of parameters bound to secret fields are never recorded
+	RecordRender(ctx context.Context, connectorId string, template *dbapi.ConnectorTemplate, parameters map[string]interface{}) *errors.ServiceError
+	// ListRenders returns the records of the existing connectors created from a template,
+	// the parameter values are only returned for the connectors of the user
+	ListRenders(ctx context.Context, templateId string) (dbapi.ConnectorTemplateRenderList, *errors.ServiceError)
+}
+
+var _ ConnectorTemplatesService = &connectorTemplatesService{}
+
+type connectorTemplatesService struct {
+	connectionFactory     *db.ConnectionFactory
+	connectorTypesService ConnectorTypesService
+}
+
+func NewConnectorTemplatesService(connectionFactory *db.ConnectionFactory, connectorTypesService ConnectorTypesService) *connectorTemplatesService {
+	return &connectorTemplatesService{
+		connectionFactory:     connectionFactory,
+		connectorTypesService: connectorTypesService,
+	}
+}
+
+func GetValidConnectorTemplateColumns() []string {
+	return []string{"id", "created_at", "updated_at", "name", "owner", "connector_type_id", "channel", "version"}
+}
+
+// filterTemplatesToOrg filters templates to the organisation of the user, or to the user without an organisation
+func filterTemplatesToOrg(ctx context.Context, dbConn *gorm.DB) (*gorm.DB, *errors.ServiceError) {
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil {
+		return dbConn, errors.Unauthenticated("user not authenticated")
+	}
+	owner, _ := claims.GetUsername()
+	if owner == "" {
+		return dbConn, errors.Unauthenticated("user not authenticated")
+	}
+
+	decision, serr := auth.AuthorizeList(ctx, auth.ResourceConnectorTemplate)
+	if serr != nil {
+		return dbConn, serr
+	}
+	orgId, _ := claims.GetOrgId()
+	if decision.FilterByOrganisation && orgId != "" {
+		return dbConn.Where("organisation_id = ?", orgId), nil
+	}
+	return dbConn.Where("owner = ?", owner), nil
+}
+
+func (k *connectorTemplatesService) Create(ctx context.Context, template *dbapi.ConnectorTemplate) *errors.ServiceError {
+	template.Version = 1
+	if err := k.connectionFactory.New().Create(template).Error; err != nil {
+		return errors.GeneralError("failed to create connector template: %v", err)
+	}
+	return nil
+}
+
+func (k *connectorTemplatesService) Get(ctx context.Context, id string) (*dbapi.ConnectorTemplate, *errors.ServiceError) {
+	dbConn, serr := filterTemplatesToOrg(ctx, k.connectionFactory.New())
+	if serr != nil {
+		return nil, serr
+	}
+
+	var template dbapi.ConnectorTemplate
+	if err := dbConn.Where("id = ?", id).First(&template).Error; err != nil {
+		return nil, services.HandleGetError("Connector template", "id", id, err)
+	}
+	return &template, nil
+}
+
+func (k *connectorTemplatesService) List(ctx context.Context, listArgs *services.ListArguments) (dbapi.ConnectorTemplateList, *api.PagingMeta, *errors.ServiceError) {
+	var resourceList dbapi.ConnectorTemplateList
+	pagingMeta := &api.PagingMeta{
+		Page: listArgs.Page,
+		Size: listArgs.Size,
+	}
+	if err := listArgs.Validate(GetValidConnectorTemplateColumns()); err != nil {
+		return resourceList, pagingMeta, errors.NewWithCause(errors.ErrorMalformedRequest, err, "unable to list connector templates: %s", err.Error())
+	}
+
+	dbConn, serr := filterTemplatesToOrg(ctx, k.connectionFactory.New())
+	if serr != nil {
+		return resourceList, pagingMeta, serr
+	}
+
+	// Apply search query
+	if len(listArgs.Search) > 0 {
+		queryParser := coreServices.NewQueryParser(GetValidConnectorTemplateColumns()...)
+		searchDbQuery, err := queryParser.Parse(listArgs.Search)
+		if err != nil {
+			return resourceList, pagingMeta, errors.NewWithCause(errors.ErrorFailedToParseSearch, err, "unable to list connector templates: %s", err.Error())
+		}
+		dbConn = dbConn.Where(searchDbQuery.Query, searchDbQuery.Values...)
+	}
+
+	total := int64(pagingMeta.Total)
+	dbConn.Session(&gorm.Session{}).Model(&resourceList).Count(&total)
+	pagingMeta.Total = int(total)
+	if pagingMeta.Size > pagingMeta.Total {
+		pagingMeta.Size = pagingMeta.Total
+	}
+	dbConn = dbConn.Offset((pagingMeta.Page - 1) * pagingMeta.Size).Limit(pagingMeta.Size)
+
+	if len(listArgs.OrderBy) == 0 {
+		dbConn = dbConn.Order("name")
+	}
+	for _, orderByArg := range listArgs.OrderBy {
+		dbConn = dbConn.Order(orderByArg)
+	}
+
+	if err := dbConn.Find(&resourceList).Error; err != nil {
+		return resourceList, pagingMeta, errors.GeneralError("unable to list connector templates: %v", err)
+	}
+	return resourceList, pagingMeta, nil
+}
+
+func (k *connectorTemplatesService) Update(ctx context.Context, template *dbapi.ConnectorTemplate) *errors.ServiceError {
+	version := template.Version
+	template.Version = version + 1
+	result := k.connectionFactory.New().Model(template).Where("version = ?", version).
+		Select("name", "description", "channel", "connector_spec", "parameters", "version").
+		Updates(template)
+	if result.Error != nil {
+		return errors.GeneralError("failed to update connector template %s: %v", template.ID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.Conflict("connector template %s was updated concurrently, version %d is stale", template.ID, version)
+	}
+	return nil
+}
+
+func (k *connectorTemplatesService) Delete(ctx context.Context, id string) *errors.ServiceError {
+	template, serr := k.Get(ctx, id)
+	if serr != nil {
+		return serr
+	}
+
+	if err := k.connectionFactory.New().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("template_id = ?", template.ID).Delete(&dbapi.ConnectorTemplateRender{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(template).Error
+	}); err != nil {
+		return services.HandleDeleteError("Connector template", "id", id, err)
+	}
+	return nil
+}
+
+func (k *connectorTemplatesService) RecordRender(ctx context.Context, connectorId string, template *dbapi.ConnectorTemplate, parameters map[string]interface{}) *errors.ServiceError {
+	values, err := json.Marshal(k.dropSecretParameters(template, parameters))
+	if err != nil {
+		return errors.GeneralError("invalid parameters of connector template %s: %v", template.ID, err)
+	}
+	render := dbapi.ConnectorTemplateRender{
+		ConnectorID:     connectorId,
+		TemplateID:      template.ID,
+		TemplateVersion: template.Version,
+		Parameters:      values,
+	}
+	if err := k.connectionFactory.New().Clauses(clause.OnConflict{UpdateAll: true}).Create(&render).Error; err != nil {
+		return errors.GeneralError("failed to record connector template %s of connector %s: %v", template.ID, connectorId, err)
+	}
+	return nil
+}
+
+// dropSecretParameters returns the parameters that aren't bound to secret fields of the connector type,
+// no parameter is returned when the secret fields can't be determined
+func (k *connectorTemplatesService) dropSecretParameters(template *dbapi.ConnectorTemplate, parameters map[string]interface{}) map[string]interface{} {
+	ct, serr := k.connectorTypesService.Get(template.ConnectorTypeId)
+	if serr != nil {
+		glog.Errorf("failed to get connector type %s of connector template %s, not recording its parameters: %v", template.ConnectorTypeId, template.ID, serr)
+		return map[string]interface{}{}
+	}
+	secretParameters, serr := GetConnectorTemplateSecretParameters(ct.JsonSchema, template.ConnectorSpec)
+	if serr != nil {
+		glog.Errorf("failed to get the secret parameters of connector template %s, not recording its parameters: %v", template.ID, serr)
+		return map[string]interface{}{}
+	}
+
+	result := make(map[string]interface{}, len(parameters))
+	for name, value := range parameters {
+		if !secretParameters[name] {
+			result[name] = value
+		}
+	}
+	return result
+}
+
+func (k *connectorTemplatesService) ListRenders(ctx context.Context, templateId string) (dbapi.ConnectorTemplateRenderList, *errors.ServiceError) {
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, errors.Unauthenticated("user not authenticated")
+	}
+	owner, _ := claims.GetUsername()
+
+	var renders dbapi.ConnectorTemplateRenderList
+	if err := k.connectionFactory.New().
+		Select("connector_template_renders.*, connectors.owner").
+		Joins("JOIN connectors ON connectors.id = connector_template_renders.connector_id AND connectors.deleted_at IS NULL").
+		Where("connector_template_renders.template_id = ?", templateId).
+		Order("connector_template_renders.connector_id").
+		Find(&renders).Error; err != nil {
+		return nil, errors.GeneralError("failed to list connectors of connector template %s: %v", templateId, err)
+	}
+
+	// the parameters of connectors of other users of the organisation aren't shared
+	for _, render := range renders {
+		if owner == "" || render.Owner != owner {
+			render.Parameters = nil
+		}
+	}
+	return renders, nil
+}
+
+// GetConnectorTemplateSecretParameters returns the names of the parameters bound to secret fields of a connector type schema.
+// Secret fields of a template spec can only be set to a single ${parameter} placeholder.
+func GetConnectorTemplateSecretParameters(schema api.JSON, spec api.JSON) (map[string]bool, *errors.ServiceError) {
+	secretParameters := map[string]bool{}
+	if len(spec) == 0 {
+		return secretParameters, nil
+	}
+	if _, err := secrets.ModifySecrets(schema, spec, func(node *ajson.Node) error {
+		if node.Type() == ajson.Null {
+			return nil
+		}
+		if node.Type() == ajson.String {
+			if s, _ := node.GetString(); IsConnectorTemplatePlaceholder(s) {
+				secretParameters[connectorTemplatePlaceholder.FindStringSubmatch(s)[1]] = true
+				return nil
+			}
+		}
+		return errors.BadRequest("secret field %s of a connector template can only be set to a ${parameter} placeholder", node.Path())
+	}); err != nil {
+		if serr, ok := err.(*errors.ServiceError); ok {
+			return nil, serr
+		}
+		return nil, errors.BadRequest("invalid connector spec: %v", err)
+	}
+	return secretParameters, nil
+}
+
+// GetConnectorTemplateParameters returns the parameters declared by a template
+func GetConnectorTemplateParameters(template *dbapi.ConnectorTemplate) ([]dbapi.ConnectorTemplateParameter, *errors.ServiceError) {
+	var parameters []dbapi.ConnectorTemplateParameter
+	if len(template.Parameters) != 0 {
+		if err := template.Parameters.Unmarshal(&parameters); err != nil {
+			return nil, errors.GeneralError("invalid parameters of connector template %s: %v", template.ID, err)
+		}
+	}
+	return parameters, nil
+}
+
+// ValidateConnectorTemplateParameters checks parameter declarations, and that template spec placeholders reference declared parameters
+func ValidateConnectorTemplateParameters(parameters []dbapi.ConnectorTemplateParameter, spec map[string]interface{}) *errors.ServiceError {
+	if len(parameters) > MaxConnectorTemplateParameters {
+		return errors.BadRequest("a connector template can declare at most %d parameters", MaxConnectorTemplateParameters)
+	}
+	declared := make(map[string]bool, len(parameters))
+	for _, p := range parameters {
+		if !ConnectorTemplateParameterName.MatchString(p.Name) {
+			return errors.BadRequest("invalid parameter name %q, it must match %s", p.Name, ConnectorTemplateParameterName)
+		}
+		if declared[p.Name] {
+			return errors.BadRequest("parameter %q is declared more than once", p.Name)
+		}
+		declared[p.Name] = true
+		if _, err := convertTemplateParameter(p, p.Default); p.Default != nil && err != nil {
+			return errors.BadRequest("invalid default value of parameter %q: %s", p.Name, err)
+		}
+		if _, err := convertTemplateParameter(p, nil); err != nil && err != errMissingParameterValue {
+			return errors.BadRequest("invalid type of parameter %q: %s", p.Name, err)
+		}
+	}
+
+	var undeclared error
+	walkTemplateStrings(spec, func(s string) {
+		for _, m := range connectorTemplatePlaceholder.FindAllStringSubmatch(s, -1) {
+			if !declared[m[1]] && undeclared == nil {
+				undeclared = fmt.Errorf("placeholder ${%s} references an undeclared parameter", m[1])
+			}
+		}
+	})
+	if undeclared != nil {
+		return errors.BadRequest("%s", undeclared)
+	}
+	return nil
+}
+
+// IsConnectorTemplatePlaceholder returns true if the value is a single ${name} placeholder
+func IsConnectorTemplatePlaceholder(value string) bool {
+	loc := connectorTemplatePlaceholder.FindStringIndex(value)
+	return loc != nil && loc[0] == 0 && loc[1] == len(value)
+}
+
+// RenderConnectorTemplate replaces the placeholders in the template spec with the parameter values, and merges the overrides in the result.
+// It returns the rendered spec and the parameter values, including defaults.
+func RenderConnectorTemplate(template *dbapi.ConnectorTemplate, values map[string]interface{}, overrides map[string]interface{}) (map[string]interface{}, map[string]interface{}, *errors.ServiceError) {
+	parameters, serr := GetConnectorTemplateParameters(template)
+	if serr != nil {
+		return nil, nil, serr
+	}
+
+	resolved := make(map[string]interface{}, len(parameters))
+	for _, p := range parameters {
+		value, ok := values[p.Name]
+		if !ok || value == nil {
+			value = p.Default
+		}
+		converted, err := convertTemplateParameter(p, value)
+		if err == errMissingParameterValue {
+			if p.Required {
+				return nil, nil, errors.BadRequest("missing value of required template parameter %q", p.Name)
+			}
+			continue
+		}
+		if err != nil {
+			return nil, nil, errors.BadRequest("invalid value of template parameter %q: %s", p.Name, err)
+		}
+		resolved[p.Name] = converted
+	}
+	for name := range values {
+		if _, ok := resolved[name]; !ok && !declaresParameter(parameters, name) {
+			return nil, nil, errors.BadRequest("unknown template parameter %q", name)
+		}
+	}
+
+	spec := map[string]interface{}{}
+	if len(template.ConnectorSpec) != 0 {
+		if err := template.ConnectorSpec.Unmarshal(&spec); err != nil {
+			return nil, nil, errors.GeneralError("invalid connector spec of connector template %s: %v", template.ID, err)
+		}
+	}
+	rendered, _ := renderTemplateValue(spec, resolved).(map[string]interface{})
+	if rendered == nil {
+		rendered = map[string]interface{}{}
+	}
+	return mergeTemplateSpec(rendered, overrides), resolved, nil
+}
+
+func declaresParameter(parameters []dbapi.ConnectorTemplateParameter, name string) bool {
+	for _, p := range parameters {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+var errMissingParameterValue = fmt.Errorf("missing value")
+
+// convertTemplateParameter checks the value of a parameter against its type
+func convertTemplateParameter(p dbapi.ConnectorTemplateParameter, value interface{}) (interface{}, error) {
+	switch p.Type {
+	case dbapi.ConnectorTemplateParameterString, dbapi.ConnectorTemplateParameterInteger,
+		dbapi.ConnectorTemplateParameterNumber, dbapi.ConnectorTemplateParameterBoolean:
+	default:
+		return nil, fmt.Errorf("type must be one of %v", dbapi.ValidConnectorTemplateParameterTypes)
+	}
+	if value == nil {
+		return nil, errMissingParameterValue
+	}
+
+	switch p.Type {
+	case dbapi.ConnectorTemplateParameterString:
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+	case dbapi.ConnectorTemplateParameterInteger:
+		if n, ok := value.(float64); ok && n == math.Trunc(n) {
+			return n, nil
+		}
+	case dbapi.ConnectorTemplateParameterNumber:
+		if n, ok := value.(float64); ok {
+			return n, nil
+		}
+	case dbapi.ConnectorTemplateParameterBoolean:
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+	}
+	return nil, fmt.Errorf("expected a value of type %s, got %v", p.Type, value)
+}
+
+// renderTemplateValue replaces the placeholders in the strings of a value. A string that is a single placeholder
+// is replaced with the typed parameter value, other placeholders are replaced with the string form of the value.
+// Placeholders of parameters without a value are removed.
+func renderTemplateValue(value interface{}, parameters map[string]interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		if IsConnectorTemplatePlaceholder(v) {
+			return parameters[connectorTemplatePlaceholder.FindStringSubmatch(v)[1]]
+		}
+		return connectorTemplatePlaceholder.ReplaceAllStringFunc(v, func(placeholder string) string {
+			if p, ok := parameters[connectorTemplatePlaceholder.FindStringSubmatch(placeholder)[1]]; ok {
+				return fmt.Sprint(p)
+			}
+			return ""
+		})
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			rendered := renderTemplateValue(item, parameters)
+			if rendered != nil {
+				result[key] = rendered
+			}
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			if rendered := renderTemplateValue(item, parameters); rendered != nil {
+				result = append(result, rendered)
+			}
+		}
+		return result
+	default:
+		return value
+	}
+}
+
+// mergeTemplateSpec merges the overrides in the spec, objects are merged recursively and other values are replaced
+func mergeTemplateSpec(spec map[string]interface{}, overrides map[string]interface{}) map[string]interface{} {
+	for key, override := range overrides {
+		if overrideMap, ok := override.(map[string]interface{}); ok {
+			if specMap, ok := spec[key].(map[string]interface{}); ok {
+				spec[key] = mergeTemplateSpec(specMap, overrideMap)
+				continue
+			}
+		}
+		spec[key] = override
+	}
+	return spec
+}
+
+func walkTemplateStrings(value interface{}, f func(string)) {
+	switch v := value.(type) {
+	case string:
+		f(v)
+	case map[string]interface{}:
+		for _, item := range v {
+			walkTemplateStrings(item, f)
+		}
+	case []interface{}:
+		for _, item := range v {
+			walkTemplateStrings(item, f)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package services
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_RenderConnectorTemplate(t *testing.T) {
+	template := &dbapi.ConnectorTemplate{
+		ConnectorSpec: []byte(`{
+			"kafka_topic": "${topic}",
+			"aws_region": "eu-${region}-1",
+			"batch": {"size": "${batch_size}", "enabled": "${batching}"},
+			"aws_secret_key": "${secret_key}",
+			"aws_access_key": "${access_key}"
+		}`),
+		Parameters: []byte(`[
+			{"name": "topic", "type": "string", "required": true},
+			{"name": "region", "type": "string", "default": "west"},
+			{"name": "batch_size", "type": "integer", "default": 100},
+			{"name": "batching", "type": "boolean"},
+			{"name": "secret_key", "type": "string"},
+			{"name": "access_key", "type": "string"}
+		]`),
+	}
+
+	tests := []struct {
+		name       string
+		values     map[string]interface{}
+		overrides  map[string]interface{}
+		wantSpec   string
+		wantParams map[string]interface{}
+		wantErr    string
+	}{
+		{
+			name:       "defaults are applied and optional parameters without values are dropped",
+			values:     map[string]interface{}{"topic": "orders"},
+			wantSpec:   `{"kafka_topic": "orders", "aws_region": "eu-west-1", "batch": {"size": 100}}`,
+			wantParams: map[string]interface{}{"topic": "orders", "region": "west", "batch_size": float64(100)},
+		},
+		{
+			name:       "typed values replace placeholders and overrides are merged",
+			values:     map[string]interface{}{"topic": "orders", "region": "central", "batch_size": float64(10), "batching": true, "secret_key": "s", "access_key": "a"},
+			overrides:  map[string]interface{}{"batch": map[string]interface{}{"size": float64(20)}, "aws_access_key": map[string]interface{}{"ref": "secret"}},
+			wantSpec:   `{"kafka_topic": "orders", "aws_region": "eu-central-1", "batch": {"size": 20, "enabled": true}, "aws_secret_key": "s", "aws_access_key": {"ref": "secret"}}`,
+			wantParams: map[string]interface{}{"topic": "orders", "region": "central", "batch_size": float64(10), "batching": true, "secret_key": "s", "access_key": "a"},
+		},
+		{
+			name:    "missing required parameter",
+			values:  map[string]interface{}{},
+			wantErr: `missing value of required template parameter "topic"`,
+		},
+		{
+			name:    "unknown parameter",
+			values:  map[string]interface{}{"topic": "orders", "partitions": float64(3)},
+			wantErr: `unknown template parameter "partitions"`,
+		},
+		{
+			name:    "integer parameter with a decimal value",
+			values:  map[string]interface{}{"topic": "orders", "batch_size": 1.5},
+			wantErr: `invalid value of template parameter "batch_size"`,
+		},
+		{
+			name:    "string parameter with a boolean value",
+			values:  map[string]interface{}{"topic": true},
+			wantErr: `invalid value of template parameter "topic"`,
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			spec, params, err := RenderConnectorTemplate(template, tt.values, tt.overrides)
+			if tt.wantErr != "" {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Reason).To(gomega.ContainSubstring(tt.wantErr))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(params).To(gomega.Equal(tt.wantParams))
+			rendered, jerr := json.Marshal(spec)
+			g.Expect(jerr).To(gomega.BeNil())
+			g.Expect(rendered).To(gomega.MatchJSON(tt.wantSpec))
+		})
+	}
+}
+
+func Test_ValidateConnectorTemplateParameters(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters []dbapi.ConnectorTemplateParameter
+		spec       map[string]interface{}
+		wantErr    string
+	}{
+		{
+			name:       "valid parameters",
+			parameters: []dbapi.ConnectorTemplateParameter{{Name: "topic", Type: dbapi.ConnectorTemplateParameterString, Default: "orders"}},
+			spec:       map[string]interface{}{"kafka_topic": "${topic}", "tags": []interface{}{"app-${topic}"}},
+		},
+		{
+			name:       "invalid parameter name",
+			parameters: []dbapi.ConnectorTemplateParameter{{Name: "Topic", Type: dbapi.ConnectorTemplateParameterString}},
+			wantErr:    `invalid parameter name "Topic"`,
+		},
+		{
+			name: "duplicated parameter",
+			parameters: []dbapi.ConnectorTemplateParameter{
+				{Name: "topic", Type: dbapi.ConnectorTemplateParameterString},
+				{Name: "topic", Type: dbapi.ConnectorTemplateParameterString},
+			},
+			wantErr: `parameter "topic" is declared more than once`,
+		},
+		{
+			name:       "invalid parameter type",
+			parameters: []dbapi.ConnectorTemplateParameter{{Name: "topic", Type: "array"}},
+			wantErr:    `invalid type of parameter "topic"`,
+		},
+		{
+			name:       "default value of the wrong type",
+			parameters: []dbapi.ConnectorTemplateParameter{{Name: "size", Type: dbapi.ConnectorTemplateParameterInteger, Default: "ten"}},
+			wantErr:    `invalid default value of parameter "size"`,
+		},
+		{
+			name:       "undeclared placeholder",
+			parameters: []dbapi.ConnectorTemplateParameter{{Name: "topic", Type: dbapi.ConnectorTemplateParameterString}},
+			spec:       map[string]interface{}{"nested": map[string]interface{}{"group": "${group_id}"}},
+			wantErr:    "placeholder ${group_id} references an undeclared parameter",
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			err := ValidateConnectorTemplateParameters(tt.parameters, tt.spec)
+			if tt.wantErr != "" {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Reason).To(gomega.ContainSubstring(tt.wantErr))
+			} else {
+				g.Expect(err).To(gomega.BeNil())
+			}
+		})
+	}
+}
+
+func Test_ConnectorTemplatesService_RecordRender(t *testing.T) {
+	template := &dbapi.ConnectorTemplate{
+		ConnectorTypeId: "connector-type",
+		ConnectorSpec:   []byte(`{"kafka_topic": "${topic}", "aws_secret_key": "${secret_key}"}`),
+		Version:         2,
+	}
+	template.ID = "template"
+	parameters := map[string]interface{}{"topic": "orders", "secret_key": "s3cr3t"}
+
+	tests := []struct {
+		name           string
+		connectorType  *dbapi.ConnectorType
+		typeErr        *errors.ServiceError
+		wantParameters string
+	}{
+		{
+			name: "parameters bound to secret fields aren't recorded",
+			connectorType: &dbapi.ConnectorType{JsonSchema: api.JSON(`{"type": "object", "properties": {
+				"kafka_topic": {"type": "string"},
+				"aws_secret_key": {"oneOf": [{"type": "string", "format": "password"}, {"type": "object"}]}
+			}}`)},
+			wantParameters: `{"topic":"orders"}`,
+		},
+		{
+			name:           "no parameter is recorded without the connector type",
+			typeErr:        errors.NotFound("connector type not found"),
+			wantParameters: `{}`,
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			var recorded []interface{}
+			mocket.Catcher.NewMock().WithQuery(`INSERT INTO "connector_template_renders"`).WithRowsNum(1).
+				WithCallback(func(query string, args []driver.NamedValue) {
+					for _, arg := range args {
+						recorded = append(recorded, arg.Value)
+					}
+				})
+
+			connectorTypesService := &ConnectorTypesServiceMock{
+				GetFunc: func(id string) (*dbapi.ConnectorType, *errors.ServiceError) {
+					return tt.connectorType, tt.typeErr
+				},
+			}
+			k := NewConnectorTemplatesService(db.NewMockConnectionFactory(nil), connectorTypesService)
+			g.Expect(k.RecordRender(context.Background(), "connector", template, parameters)).To(gomega.BeNil())
+
+			var values []string
+			for _, value := range recorded {
+				if b, ok := value.([]byte); ok {
+					values = append(values, string(b))
+				}
+			}
+			g.Expect(values).To(gomega.ConsistOf(gomega.MatchJSON(tt.wantParameters)))
+			g.Expect(fmt.Sprint(recorded)).ToNot(gomega.ContainSubstring("s3cr3t"))
+		})
+	}
+}
+
+func Test_ConnectorTemplatesService_ListRenders(t *testing.T) {
+	g := gomega.NewWithT(t)
+	mocket.Catcher.Reset()
+	mocket.Catcher.NewMock().WithQuery(`SELECT connector_template_renders.*, connectors.owner FROM "connector_template_renders"`).
+		WithReply([]map[string]interface{}{
+			{"connector_id": "alice-connector", "template_id": "template", "template_version": 1, "parameters": []byte(`{"topic":"orders"}`), "owner": "alice"},
+			{"connector_id": "bob-connector", "template_id": "template", "template_version": 1, "parameters": []byte(`{"topic":"payments"}`), "owner": "bob"},
+		})
+
+	ctx := auth.SetTokenInContext(context.Background(), &jwt.Token{Claims: jwt.MapClaims{"username": "alice", "org_id": "org1"}})
+	k := NewConnectorTemplatesService(db.NewMockConnectionFactory(nil), &ConnectorTypesServiceMock{})
+	renders, err := k.ListRenders(ctx, "template")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(renders).To(gomega.HaveLen(2))
+	g.Expect(renders[0].ConnectorID).To(gomega.Equal("alice-connector"))
+	g.Expect([]byte(renders[0].Parameters)).To(gomega.MatchJSON(`{"topic":"orders"}`))
+	g.Expect(renders[1].ConnectorID).To(gomega.Equal("bob-connector"))
+	g.Expect(renders[1].Parameters).To(gomega.BeNil())
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/golang/glog"
 )
 
+//go:generate moq -out connector_types_moq.go . ConnectorTypesService
 type ConnectorTypesService interface {
 	Get(id string) (*dbapi.ConnectorType, *errors.ServiceError)
 	List(listArgs *services.ListArguments) (dbapi.ConnectorTypeList, *api.PagingMeta, *errors.ServiceError)
@@ -304,7 +305,8 @@ func (cts *connectorTypesService) ListLabels(listArgs *services.ListArguments) (
 
 func (cts *connectorTypesService) ForEachConnectorCatalogEntry(f func(id string, channel string, ccc *config.ConnectorChannelConfig) *errors.ServiceError) *errors.ServiceError {
 
-	for _, entry := range cts.connectorsConfig.CatalogEntries {
+	catalogEntries, catalogChecksums := cts.connectorsConfig.Catalog()
+	for _, entry := range catalogEntries {
 		// create/update connector type
 		connectorType, err := presenters.ConvertConnectorType(entry.ConnectorType)
 		if err != nil {
@@ -326,7 +328,7 @@ func (cts *connectorTypesService) ForEachConnectorCatalogEntry(f func(id string,
 		// update type checksum for latest catalog shard metadata
 		dbConn := cts.connectionFactory.New()
 		if err = dbConn.Model(connectorType).Where("id = ?", connectorType.ID).
-			UpdateColumn("checksum", cts.connectorsConfig.CatalogChecksums[connectorType.ID]).Error; err != nil {
+			UpdateColumn("checksum", catalogChecksums[connectorType.ID]).Error; err != nil {
 			return errors.GeneralError("failed to update connector type %s checksum: %v", entry.ConnectorType.Id, err.Error())
 		}
 	}
@@ -434,7 +436,7 @@ func (cts *connectorTypesService) GetLatestConnectorShardMetadata(typeId, channe
 
 func (cts *connectorTypesService) CatalogEntriesReconciled() (bool, *errors.ServiceError) {
 	var typeIds []string
-	catalogChecksums := cts.connectorsConfig.CatalogChecksums
+	_, catalogChecksums := cts.connectorsConfig.Catalog()
 	for id := range catalogChecksums {
 		typeIds = append(typeIds, id)
 	}
@@ -458,8 +460,9 @@ func (cts *connectorTypesService) CatalogEntriesReconciled() (bool, *errors.Serv
 }
 
 func (cts *connectorTypesService) DeleteOrDeprecateRemovedTypes() *errors.ServiceError {
-	notToBeDeletedIDs := make([]string, len(cts.connectorsConfig.CatalogEntries))
-	for _, entry := range cts.connectorsConfig.CatalogEntries {
+	catalogEntries, _ := cts.connectorsConfig.Catalog()
+	notToBeDeletedIDs := make([]string, len(catalogEntries))
+	for _, entry := range catalogEntries {
 		notToBeDeletedIDs = append(notToBeDeletedIDs, entry.ConnectorType.Id)
 	}
 	glog.V(5).Infof("Connector Type IDs in catalog not to be deleted: %v", notToBeDeletedIDs)
@@ -0,0 +1,523 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package services
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	coreService "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"sync"
+)
+
+// Ensure, that ConnectorTypesServiceMock does implement ConnectorTypesService.
+// If this is not the case, regenerate this file with moq.
+var _ ConnectorTypesService = &ConnectorTypesServiceMock{}
+
+// ConnectorTypesServiceMock is a mock implementation of ConnectorTypesService.
+//
+//	func TestSomethingThatUsesConnectorTypesService(t *testing.T) {
+//
+//		// make and configure a mocked ConnectorTypesService
+//		mockedConnectorTypesService := &ConnectorTypesServiceMock{
+//			CatalogEntriesReconciledFunc: func() (bool, *errors.ServiceError) {
+//				panic("mock out the CatalogEntriesReconciled method")
+//			},
+//			DeleteOrDeprecateRemovedTypesFunc: func() *errors.ServiceError {
+//				panic("mock out the DeleteOrDeprecateRemovedTypes method")
+//			},
+//			ForEachConnectorCatalogEntryFunc: func(f func(id string, channel string, ccc *config.ConnectorChannelConfig) *errors.ServiceError) *errors.ServiceError {
+//				panic("mock out the ForEachConnectorCatalogEntry method")
+//			},
+//			GetFunc: func(id string) (*dbapi.ConnectorType, *errors.ServiceError) {
+//				panic("mock out the Get method")
+//			},
+//			GetCatalogEntryFunc: func(tyd string) (*dbapi.ConnectorCatalogEntry, *errors.ServiceError) {
+//				panic("mock out the GetCatalogEntry method")
+//			},
+//			GetConnectorShardMetadataFunc: func(typeId string, channel string, revision int64) (*dbapi.ConnectorShardMetadata, *errors.ServiceError) {
+//				panic("mock out the GetConnectorShardMetadata method")
+//			},
+//			GetLatestConnectorShardMetadataFunc: func(typeId string, channel string) (*dbapi.ConnectorShardMetadata, *errors.ServiceError) {
+//				panic("mock out the GetLatestConnectorShardMetadata method")
+//			},
+//			ListFunc: func(listArgs *coreService.ListArguments) (dbapi.ConnectorTypeList, *api.PagingMeta, *errors.ServiceError) {
+//				panic("mock out the List method")
+//			},
+//			ListCatalogEntriesFunc: func(listArguments *coreService.ListArguments) ([]dbapi.ConnectorCatalogEntry, *api.PagingMeta, *errors.ServiceError) {
+//				panic("mock out the ListCatalogEntries method")
+//			},
+//			ListLabelsFunc: func(listArgs *coreService.ListArguments) (dbapi.ConnectorTypeLabelCountList, *errors.ServiceError) {
+//				panic("mock out the ListLabels method")
+//			},
+//			PutConnectorShardMetadataFunc: func(ctc *dbapi.ConnectorShardMetadata) (int64, *errors.ServiceError) {
+//				panic("mock out the PutConnectorShardMetadata method")
+//			},
+//		}
+//
+//		// use mockedConnectorTypesService in code that requires ConnectorTypesService
+//		// and then make assertions.
+//
+//	}
+type ConnectorTypesServiceMock struct {
+	// CatalogEntriesReconciledFunc mocks the CatalogEntriesReconciled method.
+	CatalogEntriesReconciledFunc func() (bool, *errors.ServiceError)
+
+	// DeleteOrDeprecateRemovedTypesFunc mocks the DeleteOrDeprecateRemovedTypes method.
+	DeleteOrDeprecateRemovedTypesFunc func() *errors.ServiceError
+
+	// ForEachConnectorCatalogEntryFunc mocks the ForEachConnectorCatalogEntry method.
+	ForEachConnectorCatalogEntryFunc func(f func(id string, channel string, ccc *config.ConnectorChannelConfig) *errors.ServiceError) *errors.ServiceError
+
+	// GetFunc mocks the Get method.
+	GetFunc func(id string) (*dbapi.ConnectorType, *errors.ServiceError)
+
+	// GetCatalogEntryFunc mocks the GetCatalogEntry method.
+	GetCatalogEntryFunc func(tyd string) (*dbapi.ConnectorCatalogEntry, *errors.ServiceError)
+
+	// GetConnectorShardMetadataFunc mocks the GetConnectorShardMetadata method.
+	GetConnectorShardMetadataFunc func(typeId string, channel string, revision int64) (*dbapi.ConnectorShardMetadata, *errors.ServiceError)
+
+	// GetLatestConnectorShardMetadataFunc mocks the GetLatestConnectorShardMetadata method.
+	GetLatestConnectorShardMetadataFunc func(typeId string, channel string) (*dbapi.ConnectorShardMetadata, *errors.ServiceError)
+
+	// ListFunc mocks the List method.
+	ListFunc func(listArgs *coreService.ListArguments) (dbapi.ConnectorTypeList, *api.PagingMeta, *errors.ServiceError)
+
+	// ListCatalogEntriesFunc mocks the ListCatalogEntries method.
+	ListCatalogEntriesFunc func(listArguments *coreService.ListArguments) ([]dbapi.ConnectorCatalogEntry, *api.PagingMeta, *errors.ServiceError)
+
+	// ListLabelsFunc mocks the ListLabels method.
+	ListLabelsFunc func(listArgs *coreService.ListArguments) (dbapi.ConnectorTypeLabelCountList, *errors.ServiceError)
+
+	// PutConnectorShardMetadataFunc mocks the PutConnectorShardMetadata method.
+	PutConnectorShardMetadataFunc func(ctc *dbapi.ConnectorShardMetadata) (int64, *errors.ServiceError)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CatalogEntriesReconciled holds details about calls to the CatalogEntriesReconciled method.
+		CatalogEntriesReconciled []struct {
+		}
+		// DeleteOrDeprecateRemovedTypes holds details about calls to the DeleteOrDeprecateRemovedTypes method.
+		DeleteOrDeprecateRemovedTypes []struct {
+		}
+		// ForEachConnectorCatalogEntry holds details about calls to the ForEachConnectorCatalogEntry method.
+		ForEachConnectorCatalogEntry []struct {
+			// F is the f argument value.
+			F func(id string, channel string, ccc *config.ConnectorChannelConfig) *errors.ServiceError
+		}
+		// Get holds details about calls to the Get method.
+		Get []struct {
+			// ID is the id argument value.
+			ID string
+		}
+		// GetCatalogEntry holds details about calls to the GetCatalogEntry method.
+		GetCatalogEntry []struct {
+			// Tyd is the tyd argument value.
+			Tyd string
+		}
+		// GetConnectorShardMetadata holds details about calls to the GetConnectorShardMetadata method.
+		GetConnectorShardMetadata []struct {
+			// TypeId is the typeId argument value.
+			TypeId string
+			// Channel is the channel argument value.
+			Channel string
+			// Revision is the revision argument value.
+			Revision int64
+		}
+		// GetLatestConnectorShardMetadata holds details about calls to the GetLatestConnectorShardMetadata method.
+		GetLatestConnectorShardMetadata []struct {
+			// TypeId is the typeId argument value.
+			TypeId string
+			// Channel is the channel argument value.
+			Channel string
+		}
+		// List holds details about calls to the List method.
+		List []struct {
+			// ListArgs is the listArgs argument value.
+			ListArgs *coreService.ListArguments
+		}
+		// ListCatalogEntries holds details about calls to the ListCatalogEntries method.
+		ListCatalogEntries []struct {
+			// ListArguments is the listArguments argument value.
+			ListArguments *coreService.ListArguments
+		}
+		// ListLabels holds details about calls to the ListLabels method.
+		ListLabels []struct {
+			// ListArgs is the listArgs argument value.
+			ListArgs *coreService.ListArguments
+		}
+		// PutConnectorShardMetadata holds details about calls to the PutConnectorShardMetadata method.
+		PutConnectorShardMetadata []struct {
+			// Ctc is the ctc argument value.
+			Ctc *dbapi.ConnectorShardMetadata
+		}
+	}
+	lockCatalogEntriesReconciled        sync.RWMutex
+	lockDeleteOrDeprecateRemovedTypes   sync.RWMutex
+	lockForEachConnectorCatalogEntry    sync.RWMutex
+	lockGet                             sync.RWMutex
+	lockGetCatalogEntry                 sync.RWMutex
+	lockGetConnectorShardMetadata       sync.RWMutex
+	lockGetLatestConnectorShardMetadata sync.RWMutex
+	lockList                            sync.RWMutex
+	lockListCatalogEntries              sync.RWMutex
+	lockListLabels                      sync.RWMutex
+	lockPutConnectorShardMetadata       sync.RWMutex
+}
+
+// CatalogEntriesReconciled calls CatalogEntriesReconciledFunc.
+func (mock *ConnectorTypesServiceMock) CatalogEntriesReconciled() (bool, *errors.ServiceError) {
+	if mock.CatalogEntriesReconciledFunc == nil {
+		panic("ConnectorTypesServiceMock.CatalogEntriesReconciledFunc: method is nil but ConnectorTypesService.CatalogEntriesReconciled was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockCatalogEntriesReconciled.Lock()
+	mock.calls.CatalogEntriesReconciled = append(mock.calls.CatalogEntriesReconciled, callInfo)
+	mock.lockCatalogEntriesReconciled.Unlock()
+	return mock.CatalogEntriesReconciledFunc()
+}
+
+// CatalogEntriesReconciledCalls gets all the calls that were made to CatalogEntriesReconciled.
+// Check the length with:
+//
+//	len(mockedConnectorTypesService.CatalogEntriesReconciledCalls())
+func (mock *ConnectorTypesServiceMock) CatalogEntriesReconciledCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockCatalogEntriesReconciled.RLock()
+	calls = mock.calls.CatalogEntriesReconciled
+	mock.lockCatalogEntriesReconciled.RUnlock()
+	return calls
+}
+
+// DeleteOrDeprecateRemovedTypes calls DeleteOrDeprecateRemovedTypesFunc.
+func (mock *ConnectorTypesServiceMock) DeleteOrDeprecateRemovedTypes() *errors.ServiceError {
+	if mock.DeleteOrDeprecateRemovedTypesFunc == nil {
+		panic("ConnectorTypesServiceMock.DeleteOrDeprecateRemovedTypesFunc: method is nil but ConnectorTypesService.DeleteOrDeprecateRemovedTypes was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockDeleteOrDeprecateRemovedTypes.Lock()
+	mock.calls.DeleteOrDeprecateRemovedTypes = append(mock.calls.DeleteOrDeprecateRemovedTypes, callInfo)
+	mock.lockDeleteOrDeprecateRemovedTypes.Unlock()
+	return mock.DeleteOrDeprecateRemovedTypesFunc()
+}
+
+// DeleteOrDeprecateRemovedTypesCalls gets all the calls that were made to DeleteOrDeprecateRemovedTypes.
+// Check the length with:
+//
+//	len(mockedConnectorTypesService.DeleteOrDeprecateRemovedTypesCalls())
+func (mock *ConnectorTypesServiceMock) DeleteOrDeprecateRemovedTypesCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockDeleteOrDeprecateRemovedTypes.RLock()
+	calls = mock.calls.DeleteOrDeprecateRemovedTypes
+	mock.lockDeleteOrDeprecateRemovedTypes.RUnlock()
+	return calls
+}
+
+// ForEachConnectorCatalogEntry calls ForEachConnectorCatalogEntryFunc.
+func (mock *ConnectorTypesServiceMock) ForEachConnectorCatalogEntry(f func(id string, channel string, ccc *config.ConnectorChannelConfig) *errors.ServiceError) *errors.ServiceError {
+	if mock.ForEachConnectorCatalogEntryFunc == nil {
+		panic("ConnectorTypesServiceMock.ForEachConnectorCatalogEntryFunc: method is nil but ConnectorTypesService.ForEachConnectorCatalogEntry was just called")
+	}
+	callInfo := struct {
+		F func(id string, channel string, ccc *config.ConnectorChannelConfig) *errors.ServiceError
+	}{
+		F: f,
+	}
+	mock.lockForEachConnectorCatalogEntry.Lock()
+	mock.calls.ForEachConnectorCatalogEntry = append(mock.calls.ForEachConnectorCatalogEntry, callInfo)
+	mock.lockForEachConnectorCatalogEntry.Unlock()
+	return mock.ForEachConnectorCatalogEntryFunc(f)
+}
+
+// ForEachConnectorCatalogEntryCalls gets all the calls that were made to ForEachConnectorCatalogEntry.
+// Check the length with:
+//
+//	len(mockedConnectorTypesService.ForEachConnectorCatalogEntryCalls())
+func (mock *ConnectorTypesServiceMock) ForEachConnectorCatalogEntryCalls() []struct {
+	F func(id string, channel string, ccc *config.ConnectorChannelConfig) *errors.ServiceError
+} {
+	var calls []struct {
+		F func(id string, channel string, ccc *config.ConnectorChannelConfig) *errors.ServiceError
+	}
+	mock.lockForEachConnectorCatalogEntry.RLock()
+	calls = mock.calls.ForEachConnectorCatalogEntry
+	mock.lockForEachConnectorCatalogEntry.RUnlock()
+	return calls
+}
+
+// Get calls GetFunc.
+func (mock *ConnectorTypesServiceMock) Get(id string) (*dbapi.ConnectorType, *errors.ServiceError) {
+	if mock.GetFunc == nil {
+		panic("ConnectorTypesServiceMock.GetFunc: method is nil but ConnectorTypesService.Get was just called")
+	}
+	callInfo := struct {
+		ID string
+	}{
+		ID: id,
+	}
+	mock.lockGet.Lock()
+	mock.calls.Get = append(mock.calls.Get, callInfo)
+	mock.lockGet.Unlock()
+	return mock.GetFunc(id)
+}
+
+// GetCalls gets all the calls that were made to Get.
+// Check the length with:
+//
+//	len(mockedConnectorTypesService.GetCalls())
+func (mock *ConnectorTypesServiceMock) GetCalls() []struct {
+	ID string
+} {
+	var calls []struct {
+		ID string
+	}
+	mock.lockGet.RLock()
+	calls = mock.calls.Get
+	mock.lockGet.RUnlock()
+	return calls
+}
+
+// GetCatalogEntry calls GetCatalogEntryFunc.
+func (mock *ConnectorTypesServiceMock) GetCatalogEntry(tyd string) (*dbapi.ConnectorCatalogEntry, *errors.ServiceError) {
+	if mock.GetCatalogEntryFunc == nil {
+		panic("ConnectorTypesServiceMock.GetCatalogEntryFunc: method is nil but ConnectorTypesService.GetCatalogEntry was just called")
+	}
+	callInfo := struct {
+		Tyd string
+	}{
+		Tyd: tyd,
+	}
+	mock.lockGetCatalogEntry.Lock()
+	mock.calls.GetCatalogEntry = append(mock.calls.GetCatalogEntry, callInfo)
+	mock.lockGetCatalogEntry.Unlock()
+	return mock.GetCatalogEntryFunc(tyd)
+}
+
+// GetCatalogEntryCalls gets all the calls that were made to GetCatalogEntry.
+// Check the length with:
+//
+//	len(mockedConnectorTypesService.GetCatalogEntryCalls())
+func (mock *ConnectorTypesServiceMock) GetCatalogEntryCalls() []struct {
+	Tyd string
+} {
+	var calls []struct {
+		Tyd string
+	}
+	mock.lockGetCatalogEntry.RLock()
+	calls = mock.calls.GetCatalogEntry
+	mock.lockGetCatalogEntry.RUnlock()
+	return calls
+}
+
+// GetConnectorShardMetadata calls GetConnectorShardMetadataFunc.
+func (mock *ConnectorTypesServiceMock) GetConnectorShardMetadata(typeId string, channel string, revision int64) (*dbapi.ConnectorShardMetadata, *errors.ServiceError) {
+	if mock.GetConnectorShardMetadataFunc == nil {
+		panic("ConnectorTypesServiceMock.GetConnectorShardMetadataFunc: method is nil but ConnectorTypesService.GetConnectorShardMetadata was just called")
+	}
+	callInfo := struct {
+		TypeId   string
+		Channel  string
+		Revision int64
+	}{
+		TypeId:   typeId,
+		Channel:  channel,
+		Revision: revision,
+	}
+	mock.lockGetConnectorShardMetadata.Lock()
+	mock.calls.GetConnectorShardMetadata = append(mock.calls.GetConnectorShardMetadata, callInfo)
+	mock.lockGetConnectorShardMetadata.Unlock()
+	return mock.GetConnectorShardMetadataFunc(typeId, channel, revision)
+}
+
+// GetConnectorShardMetadataCalls gets all the calls that were made to GetConnectorShardMetadata.
+// Check the length with:
+//
+//	len(mockedConnectorTypesService.GetConnectorShardMetadataCalls())
+func (mock *ConnectorTypesServiceMock) GetConnectorShardMetadataCalls() []struct {
+	TypeId   string
+	Channel  string
+	Revision int64
+} {
+	var calls []struct {
+		TypeId   string
+		Channel  string
+		Revision int64
+	}
+	mock.lockGetConnectorShardMetadata.RLock()
+	calls = mock.calls.GetConnectorShardMetadata
+	mock.lockGetConnectorShardMetadata.RUnlock()
+	return calls
+}
+
+// GetLatestConnectorShardMetadata calls GetLatestConnectorShardMetadataFunc.
+func (mock *ConnectorTypesServiceMock) GetLatestConnectorShardMetadata(typeId string, channel string) (*dbapi.ConnectorShardMetadata, *errors.ServiceError) {
+	if mock.GetLatestConnectorShardMetadataFunc == nil {
+		panic("ConnectorTypesServiceMock.GetLatestConnectorShardMetadataFunc: method is nil but ConnectorTypesService.GetLatestConnectorShardMetadata was just called")
+	}
+	callInfo := struct {
+		TypeId  string
+		Channel string
+	}{
+		TypeId:  typeId,
+		Channel: channel,
+	}
+	mock.lockGetLatestConnectorShardMetadata.Lock()
+	mock.calls.GetLatestConnectorShardMetadata = append(mock.calls.GetLatestConnectorShardMetadata, callInfo)
+	mock.lockGetLatestConnectorShardMetadata.Unlock()
+	return mock.GetLatestConnectorShardMetadataFunc(typeId, channel)
+}
+
+// GetLatestConnectorShardMetadataCalls gets all the calls that were made to GetLatestConnectorShardMetadata.
+// Check the length with:
+//
+//	len(mockedConnectorTypesService.GetLatestConnectorShardMetadataCalls())
+func (mock *ConnectorTypesServiceMock) GetLatestConnectorShardMetadataCalls() []struct {
+	TypeId  string
+	Channel string
+} {
+	var calls []struct {
+		TypeId  string
+		Channel string
+	}
+	mock.lockGetLatestConnectorShardMetadata.RLock()
+	calls = mock.calls.GetLatestConnectorShardMetadata
+	mock.lockGetLatestConnectorShardMetadata.RUnlock()
+	return calls
+}
+
+// List calls ListFunc.
+func (mock *ConnectorTypesServiceMock) List(listArgs *coreService.ListArguments) (dbapi.ConnectorTypeList, *api.PagingMeta, *errors.ServiceError) {
+	if mock.ListFunc == nil {
+		panic("ConnectorTypesServiceMock.ListFunc: method is nil but ConnectorTypesService.List was just called")
+	}
+	callInfo := struct {
+		ListArgs *coreService.ListArguments
+	}{
+		ListArgs: listArgs,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc(listArgs)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedConnectorTypesService.ListCalls())
+func (mock *ConnectorTypesServiceMock) ListCalls() []struct {
+	ListArgs *coreService.ListArguments
+} {
+	var calls []struct {
+		ListArgs *coreService.ListArguments
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// ListCatalogEntries calls ListCatalogEntriesFunc.
+func (mock *ConnectorTypesServiceMock) ListCatalogEntries(listArguments *coreService.ListArguments) ([]dbapi.ConnectorCatalogEntry, *api.PagingMeta, *errors.ServiceError) {
+	if mock.ListCatalogEntriesFunc == nil {
+		panic("ConnectorTypesServiceMock.ListCatalogEntriesFunc: method is nil but ConnectorTypesService.ListCatalogEntries was just called")
+	}
+	callInfo := struct {
+		ListArguments *coreService.ListArguments
+	}{
+		ListArguments: listArguments,
+	}
+	mock.lockListCatalogEntries.Lock()
+	mock.calls.ListCatalogEntries = append(mock.calls.ListCatalogEntries, callInfo)
+	mock.lockListCatalogEntries.Unlock()
+	return mock.ListCatalogEntriesFunc(listArguments)
+}
+
+// ListCatalogEntriesCalls gets all the calls that were made to ListCatalogEntries.
+// Check the length with:
+//
+//	len(mockedConnectorTypesService.ListCatalogEntriesCalls())
+func (mock *ConnectorTypesServiceMock) ListCatalogEntriesCalls() []struct {
+	ListArguments *coreService.ListArguments
+} {
+	var calls []struct {
+		ListArguments *coreService.ListArguments
+	}
+	mock.lockListCatalogEntries.RLock()
+	calls = mock.calls.ListCatalogEntries
+	mock.lockListCatalogEntries.RUnlock()
+	return calls
+}
+
+// ListLabels calls ListLabelsFunc.
+func (mock *ConnectorTypesServiceMock) ListLabels(listArgs *coreService.ListArguments) (dbapi.ConnectorTypeLabelCountList, *errors.ServiceError) {
+	if mock.ListLabelsFunc == nil {
+		panic("ConnectorTypesServiceMock.ListLabelsFunc: method is nil but ConnectorTypesService.ListLabels was just called")
+	}
+	callInfo := struct {
+		ListArgs *coreService.ListArguments
+	}{
+		ListArgs: listArgs,
+	}
+	mock.lockListLabels.Lock()
+	mock.calls.ListLabels = append(mock.calls.ListLabels, callInfo)
+	mock.lockListLabels.Unlock()
+	return mock.ListLabelsFunc(listArgs)
+}
+
+// ListLabelsCalls gets all the calls that were made to ListLabels.
+// Check the length with:
+//
+//	len(mockedConnectorTypesService.ListLabelsCalls())
+func (mock *ConnectorTypesServiceMock) ListLabelsCalls() []struct {
+	ListArgs *coreService.ListArguments
+} {
+	var calls []struct {
+		ListArgs *coreService.ListArguments
+	}
+	mock.lockListLabels.RLock()
+	calls = mock.calls.ListLabels
+	mock.lockListLabels.RUnlock()
+	return calls
+}
+
+// PutConnectorShardMetadata calls PutConnectorShardMetadataFunc.
+func (mock *ConnectorTypesServiceMock) PutConnectorShardMetadata(ctc *dbapi.ConnectorShardMetadata) (int64, *errors.ServiceError) {
+	if mock.PutConnectorShardMetadataFunc == nil {
+		panic("ConnectorTypesServiceMock.PutConnectorShardMetadataFunc: method is nil but ConnectorTypesService.PutConnectorShardMetadata was just called")
+	}
+	callInfo := struct {
+		Ctc *dbapi.ConnectorShardMetadata
+	}{
+		Ctc: ctc,
+	}
+	mock.lockPutConnectorShardMetadata.Lock()
+	mock.calls.PutConnectorShardMetadata = append(mock.calls.PutConnectorShardMetadata, callInfo)
+	mock.lockPutConnectorShardMetadata.Unlock()
+	return mock.PutConnectorShardMetadataFunc(ctc)
+}
+
+// PutConnectorShardMetadataCalls gets all the calls that were made to PutConnectorShardMetadata.
+// Check the length with:
+//
+//	len(mockedConnectorTypesService.PutConnectorShardMetadataCalls())
+func (mock *ConnectorTypesServiceMock) PutConnectorShardMetadataCalls() []struct {
+	Ctc *dbapi.ConnectorShardMetadata
+} {
+	var calls []struct {
+		Ctc *dbapi.ConnectorShardMetadata
+	}
+	mock.lockPutConnectorShardMetadata.RLock()
+	calls = mock.calls.PutConnectorShardMetadata
+	mock.lockPutConnectorShardMetadata.RUnlock()
+	return calls
+}
@@ -0,0 +1,396 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/queryparser"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
+	"gorm.io/gorm"
+)
+
+// WeekDays are the names of the days of the week accepted in a maintenance window, indexed by time.Weekday
+var WeekDays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+type ConnectorUpgradesService interface {
+	// GetPolicy returns the upgrade policy of a namespace or of a connector, only one of the ids must be set
+	GetPolicy(ctx context.Context, namespaceID string, connectorID string) (*dbapi.ConnectorUpgradePolicy, *errors.ServiceError)
+	// SavePolicy creates or replaces the upgrade policy of a namespace or of a connector
+	SavePolicy(ctx context.Context, policy *dbapi.ConnectorUpgradePolicy) *errors.ServiceError
+	DeletePolicy(ctx context.Context, namespaceID string, connectorID string) *errors.ServiceError
+	ListUpgrades(ctx context.Context, listArgs *services.ListArguments) (dbapi.ConnectorUpgradeList, *api.PagingMeta, *errors.ServiceError)
+	GetUpgrade(ctx context.Context, id string) (*dbapi.ConnectorUpgrade, *errors.ServiceError)
+
+	// ListInProgressUpgrades returns the upgrades waiting for the agent to report a healthy deployment
+	ListInProgressUpgrades() (dbapi.ConnectorUpgradeList, *errors.ServiceError)
+	// CheckUpgrade completes an upgrade if the deployment is healthy, and rolls it back if it failed or timed out
+	CheckUpgrade(ctx context.Context, upgrade *dbapi.ConnectorUpgrade, now time.Time) *errors.ServiceError
+	// ListUpgradableDeployments returns the deployments with an available channel or operator upgrade
+	ListUpgradableDeployments() (dbapi.ConnectorDeploymentList, *errors.ServiceError)
+	// ApplyUpgrade upgrades a deployment if allowed by its upgrade policy, it returns the applied upgrade if any
+	ApplyUpgrade(ctx context.Context, deployment *dbapi.ConnectorDeployment, now time.Time) (*dbapi.ConnectorUpgrade, *errors.ServiceError)
+}
+
+var _ ConnectorUpgradesService = &connectorUpgradesService{}
+
+type connectorUpgradesService struct {
+	connectionFactory     *db.ConnectionFactory
+	bus                   signalbus.SignalBus
+	connectorTypesService ConnectorTypesService
+	connectorsConfig      *config.ConnectorsConfig
+}
+
+func NewConnectorUpgradesService(connectionFactory *db.ConnectionFactory, bus signalbus.SignalBus,
+	connectorTypesService ConnectorTypesService, connectorsConfig *config.ConnectorsConfig) *connectorUpgradesService {
+	return &connectorUpgradesService{
+		connectionFactory:     connectionFactory,
+		bus:                   bus,
+		connectorTypesService: connectorTypesService,
+		connectorsConfig:      connectorsConfig,
+	}
+}
+
+func (k *connectorUpgradesService) GetPolicy(ctx context.Context, namespaceID string, connectorID string) (*dbapi.ConnectorUpgradePolicy, *errors.ServiceError) {
+	var policy dbapi.ConnectorUpgradePolicy
+	dbConn := k.connectionFactory.New()
+	if namespaceID != "" {
+		dbConn = dbConn.Where("namespace_id = ?", namespaceID)
+	} else {
+		dbConn = dbConn.Where("connector_id = ?", connectorID)
+	}
+	if err := dbConn.First(&policy).Error; err != nil {
+		return nil, services.HandleGetError("Connector upgrade policy", "owner id", namespaceID+connectorID, err)
+	}
+	return &policy, nil
+}
+
+func (k *connectorUpgradesService) SavePolicy(ctx context.Context, policy *dbapi.ConnectorUpgradePolicy) *errors.ServiceError {
+	var namespaceID, connectorID string
+	if policy.NamespaceID != nil {
+		namespaceID = *policy.NamespaceID
+	}
+	if policy.ConnectorID != nil {
+		connectorID = *policy.ConnectorID
+	}
+	existing, err := k.GetPolicy(ctx, namespaceID, connectorID)
+	if err != nil && !err.Is404() {
+		return err
+	}
+	if existing != nil {
+		policy.ID = existing.ID
+		policy.CreatedAt = existing.CreatedAt
+	} else {
+		policy.ID = api.NewID()
+	}
+	if err := k.connectionFactory.New().Save(policy).Error; err != nil {
+		return services.HandleCreateError("Connector upgrade policy", err)
+	}
+	return nil
+}
+
+func (k *connectorUpgradesService) DeletePolicy(ctx context.Context, namespaceID string, connectorID string) *errors.ServiceError {
+	policy, err := k.GetPolicy(ctx, namespaceID, connectorID)
+	if err != nil {
+		return err
+	}
+	if err := k.connectionFactory.New().Delete(policy).Error; err != nil {
+		return services.HandleDeleteError("Connector upgrade policy", "id", policy.ID, err)
+	}
+	return nil
+}
+
+func GetValidUpgradeColumns() []string {
+	return []string{"deployment_id", "connector_id", "namespace_id", "kind", "state"}
+}
+
+func (k *connectorUpgradesService) ListUpgrades(ctx context.Context, listArgs *services.ListArguments) (dbapi.ConnectorUpgradeList, *api.PagingMeta, *errors.ServiceError) {
+	var resourceList dbapi.ConnectorUpgradeList
+	dbConn := k.connectionFactory.New()
+	pagingMeta := &api.PagingMeta{
+		Page: listArgs.Page,
+		Size: listArgs.Size,
+	}
+
+	// Apply search query
+	if len(listArgs.Search) > 0 {
+		queryParser := coreServices.NewQueryParser(GetValidUpgradeColumns()...)
+		searchDbQuery, err := queryParser.Parse(listArgs.Search)
+		if err != nil {
+			return resourceList, pagingMeta, errors.NewWithCause(errors.ErrorFailedToParseSearch, err, "unable to list connector upgrades: %s", err.Error())
+		}
+		dbConn = dbConn.Where(searchDbQuery.Query, searchDbQuery.Values...)
+	}
+
+	total := int64(pagingMeta.Total)
+	dbConn.Session(&gorm.Session{}).Model(&resourceList).Count(&total)
+	pagingMeta.Total = int(total)
+	if pagingMeta.Size > pagingMeta.Total {
+		pagingMeta.Size = pagingMeta.Total
+	}
+	dbConn = dbConn.Offset((pagingMeta.Page - 1) * pagingMeta.Size).Limit(pagingMeta.Size)
+
+	// latest upgrades first
+	if err := dbConn.Order("created_at desc").Find(&resourceList).Error; err != nil {
+		return resourceList, pagingMeta, errors.GeneralError("unable to list connector upgrades: %v", err)
+	}
+	return resourceList, pagingMeta, nil
+}
+
+func (k *connectorUpgradesService) GetUpgrade(ctx context.Context, id string) (*dbapi.ConnectorUpgrade, *errors.ServiceError) {
+	var upgrade dbapi.ConnectorUpgrade
+	if err := k.connectionFactory.New().Where("id = ?", id).First(&upgrade).Error; err != nil {
+		return nil, services.HandleGetError("Connector upgrade", "id", id, err)
+	}
+	return &upgrade, nil
+}
+
+func (k *connectorUpgradesService) ListInProgressUpgrades() (dbapi.ConnectorUpgradeList, *errors.ServiceError) {
+	var upgrades dbapi.ConnectorUpgradeList
+	if err := k.connectionFactory.New().Where("state = ?", dbapi.ConnectorUpgradeInProgress).
+		Order("started_at").Find(&upgrades).Error; err != nil {
+		return nil, errors.GeneralError("failed to list connector upgrades in progress: %v", err)
+	}
+	return upgrades, nil
+}
+
+func (k *connectorUpgradesService) CheckUpgrade(ctx context.Context, upgrade *dbapi.ConnectorUpgrade, now time.Time) *errors.ServiceError {
+	dbConn := k.connectionFactory.New()
+
+	var deployment dbapi.ConnectorDeployment
+	if err := dbConn.Joins("Status").Where("connector_deployments.id = ?", upgrade.DeploymentID).
+		First(&deployment).Error; err != nil {
+		if services.IsRecordNotFoundError(err) {
+			// the connector has been deleted, there is nothing left to check
+			upgrade.State = dbapi.ConnectorUpgradeSucceeded
+			upgrade.Error = "deployment was deleted during the upgrade"
+			return k.updateUpgrade(upgrade)
+		}
+		return services.HandleGetError("Connector deployment", "id", upgrade.DeploymentID, err)
+	}
+
+	// only statuses reported for the upgraded deployment are relevant
+	if deployment.Status.Version >= upgrade.DeploymentVersion {
+		switch deployment.Status.Phase {
+		case dbapi.ConnectorStatusPhaseReady, dbapi.ConnectorStatusPhaseStopped:
+			upgrade.State = dbapi.ConnectorUpgradeSucceeded
+			return k.updateUpgrade(upgrade)
+		case dbapi.ConnectorStatusPhaseFailed:
+			return k.rollback(ctx, upgrade, &deployment, "deployment failed after the upgrade")
+		}
+	}
+
+	if now.Sub(upgrade.StartedAt) > k.connectorsConfig.ConnectorUpgradeHealthTimeout {
+		return k.rollback(ctx, upgrade, &deployment, fmt.Sprintf("deployment didn't become healthy within %s after the upgrade",
+			k.connectorsConfig.ConnectorUpgradeHealthTimeout))
+	}
+
+	// keep waiting
+	return nil
+}
+
+func (k *connectorUpgradesService) rollback(ctx context.Context, upgrade *dbapi.ConnectorUpgrade, deployment *dbapi.ConnectorDeployment, reason string) *errors.ServiceError {
+	var updates map[string]interface{}
+	switch upgrade.Kind {
+	case dbapi.ConnectorUpgradeChannel:
+		updates = map[string]interface{}{"connector_shard_metadata_id": upgrade.FromShardMetadataID}
+	case dbapi.ConnectorUpgradeOperator:
+		updates = map[string]interface{}{"operator_id": upgrade.FromOperatorID}
+	}
+	if err := k.updateDeployment(ctx, deployment, updates); err != nil {
+		return err
+	}
+
+	upgrade.State = dbapi.ConnectorUpgradeRolledBack
+	upgrade.Error = reason
+	return k.updateUpgrade(upgrade)
+}
+
+func (k *connectorUpgradesService) ListUpgradableDeployments() (dbapi.ConnectorDeploymentList, *errors.ServiceError) {
+	var deployments dbapi.ConnectorDeploymentList
+	if err := k.connectionFactory.New().
+		Joins("Status").Joins("ConnectorShardMetadata").Joins("Connector").
+		Where("\"Connector\".\"deleted_at\" IS NULL").
+		Where("\"ConnectorShardMetadata\".\"latest_revision\" IS NOT NULL OR \"Status\".\"upgrade_available\"").
+		Order("connector_deployments.version").
+		Find(&deployments).Error; err != nil {
+		return nil, errors.GeneralError("failed to list upgradable connector deployments: %v", err)
+	}
+	return deployments, nil
+}
+
+func (k *connectorUpgradesService) ApplyUpgrade(ctx context.Context, deployment *dbapi.ConnectorDeployment, now time.Time) (*dbapi.ConnectorUpgrade, *errors.ServiceError) {
+	dbConn := k.connectionFactory.New()
+
+	policy, err := k.effectivePolicy(ctx, deployment)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil || policy.Type == dbapi.ConnectorUpgradePolicyManual {
+		return nil, nil
+	}
+	if policy.Type == dbapi.ConnectorUpgradePolicyMaintenanceWindow && !InMaintenanceWindow(policy, now) {
+		return nil, nil
+	}
+
+	// only healthy deployments that the agent has caught up with are upgraded
+	if deployment.Status.Version < deployment.Version ||
+		(deployment.Status.Phase != dbapi.ConnectorStatusPhaseReady && deployment.Status.Phase != dbapi.ConnectorStatusPhaseStopped) {
+		return nil, nil
+	}
+
+	// roll out gradually in every namespace
+	var inProgress int64
+	if err := dbConn.Model(&dbapi.ConnectorUpgrade{}).
+		Where("(namespace_id = ? OR deployment_id = ?) AND state = ?", deployment.NamespaceID, deployment.ID, dbapi.ConnectorUpgradeInProgress).
+		Count(&inProgress).Error; err != nil {
+		return nil, errors.GeneralError("failed to count connector upgrades in progress in namespace %s: %v", deployment.NamespaceID, err)
+	}
+	maxConcurrent := policy.MaxConcurrentUpgrades
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+	if inProgress >= int64(maxConcurrent) {
+		return nil, nil
+	}
+
+	upgrade := &dbapi.ConnectorUpgrade{
+		Model: db.Model{
+			ID: api.NewID(),
+		},
+		DeploymentID: deployment.ID,
+		ConnectorID:  deployment.ConnectorID,
+		NamespaceID:  deployment.NamespaceID,
+		State:        dbapi.ConnectorUpgradeInProgress,
+		StartedAt:    now,
+	}
+	var updates map[string]interface{}
+	if latest := deployment.ConnectorShardMetadata.LatestRevision; latest != nil {
+		shardMetadata, err := k.connectorTypesService.GetConnectorShardMetadata(deployment.ConnectorShardMetadata.ConnectorTypeId,
+			deployment.ConnectorShardMetadata.Channel, *latest)
+		if err != nil {
+			return nil, err
+		}
+		upgrade.Kind = dbapi.ConnectorUpgradeChannel
+		upgrade.FromShardMetadataID = deployment.ConnectorShardMetadataID
+		upgrade.ToShardMetadataID = shardMetadata.ID
+		updates = map[string]interface{}{"connector_shard_metadata_id": shardMetadata.ID}
+	} else if deployment.Status.UpgradeAvailable {
+		var operators struct {
+			Assigned  dbapi.ConnectorOperator `json:"assigned"`
+			Available dbapi.ConnectorOperator `json:"available"`
+		}
+		if err := json.Unmarshal(deployment.Status.Operators, &operators); err != nil {
+			return nil, errors.GeneralError("invalid status operators of connector deployment %s: %v", deployment.ID, err)
+		}
+		if operators.Available.Id == "" {
+			return nil, nil
+		}
+		upgrade.Kind = dbapi.ConnectorUpgradeOperator
+		upgrade.FromOperatorID = deployment.OperatorID
+		upgrade.ToOperatorID = operators.Available.Id
+		updates = map[string]interface{}{"operator_id": operators.Available.Id}
+	} else {
+		return nil, nil
+	}
+
+	// an upgrade that was rolled back isn't applied again automatically
+	var rolledBack int64
+	if err := dbConn.Model(&dbapi.ConnectorUpgrade{}).
+		Where("deployment_id = ? AND state = ? AND kind = ? AND to_shard_metadata_id = ? AND to_operator_id = ?",
+			deployment.ID, dbapi.ConnectorUpgradeRolledBack, upgrade.Kind, upgrade.ToShardMetadataID, upgrade.ToOperatorID).
+		Count(&rolledBack).Error; err != nil {
+		return nil, errors.GeneralError("failed to count rolled back upgrades of connector deployment %s: %v", deployment.ID, err)
+	}
+	if rolledBack > 0 {
+		return nil, nil
+	}
+
+	if err := k.updateDeployment(ctx, deployment, updates); err != nil {
+		return nil, err
+	}
+	upgrade.DeploymentVersion = deployment.Version
+	if err := dbConn.Create(upgrade).Error; err != nil {
+		return nil, services.HandleCreateError("Connector upgrade", err)
+	}
+	return upgrade, nil
+}
+
+// effectivePolicy returns the policy of the connector of the deployment, or else the policy of its namespace
+func (k *connectorUpgradesService) effectivePolicy(ctx context.Context, deployment *dbapi.ConnectorDeployment) (*dbapi.ConnectorUpgradePolicy, *errors.ServiceError) {
+	policy, err := k.GetPolicy(ctx, "", deployment.ConnectorID)
+	if err == nil {
+		return policy, nil
+	} else if !err.Is404() {
+		return nil, err
+	}
+	policy, err = k.GetPolicy(ctx, deployment.NamespaceID, "")
+	if err == nil {
+		return policy, nil
+	} else if !err.Is404() {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// updateDeployment updates the deployment and reads back its new version, agents are notified of the change
+func (k *connectorUpgradesService) updateDeployment(ctx context.Context, deployment *dbapi.ConnectorDeployment, updates map[string]interface{}) *errors.ServiceError {
+	dbConn := k.connectionFactory.New()
+	if err := dbConn.Model(&dbapi.ConnectorDeployment{}).Where("id = ?", deployment.ID).
+		Updates(updates).Error; err != nil {
+		return services.HandleUpdateError("Connector deployment", err)
+	}
+	if err := dbConn.Select("version").Where("id = ?", deployment.ID).First(deployment).Error; err != nil {
+		return services.HandleGetError("Connector deployment", "id", deployment.ID, err)
+	}
+	_ = db.AddPostCommitAction(ctx, func() {
+		k.bus.Notify(fmt.Sprintf("/kafka_connector_clusters/%s/deployments", deployment.ClusterID))
+	})
+	return nil
+}
+
+func (k *connectorUpgradesService) updateUpgrade(upgrade *dbapi.ConnectorUpgrade) *errors.ServiceError {
+	if err := k.connectionFactory.New().Model(upgrade).
+		Select("state", "error").Updates(upgrade).Error; err != nil {
+		return services.HandleUpdateError("Connector upgrade", err)
+	}
+	return nil
+}
+
+// InMaintenanceWindow returns true if the given time is within the maintenance window of the policy
+func InMaintenanceWindow(policy *dbapi.ConnectorUpgradePolicy, now time.Time) bool {
+	if policy.WindowDurationHours <= 0 {
+		return false
+	}
+	now = now.UTC()
+	duration := time.Duration(policy.WindowDurationHours) * time.Hour
+	today := time.Date(now.Year(), now.Month(), now.Day(), policy.WindowStartHour, 0, 0, 0, time.UTC)
+
+	// windows that started on previous days may still be open
+	for start := today; now.Sub(start) < duration; start = start.AddDate(0, 0, -1) {
+		if !now.Before(start) && windowStartsOn(policy, start.Weekday()) {
+			return true
+		}
+	}
+	return false
+}
+
+func windowStartsOn(policy *dbapi.ConnectorUpgradePolicy, day time.Weekday) bool {
+	if policy.WindowDays == "" {
+		return true
+	}
+	for _, d := range strings.Split(policy.WindowDays, ",") {
+		if strings.TrimSpace(d) == WeekDays[day] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,356 @@
+package services
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_InMaintenanceWindow(t *testing.T) {
+	// 2023-04-01 is a Saturday
+	saturday := func(hour int) time.Time {
+		return time.Date(2023, 4, 1, hour, 30, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		scenario string
+		policy   dbapi.ConnectorUpgradePolicy
+		now      time.Time
+		want     bool
+	}{
+		{
+			scenario: "every day, within the window",
+			policy:   dbapi.ConnectorUpgradePolicy{WindowStartHour: 2, WindowDurationHours: 2},
+			now:      saturday(3),
+			want:     true,
+		},
+		{
+			scenario: "every day, after the window",
+			policy:   dbapi.ConnectorUpgradePolicy{WindowStartHour: 2, WindowDurationHours: 2},
+			now:      saturday(4),
+			want:     false,
+		},
+		{
+			scenario: "every day, before the window",
+			policy:   dbapi.ConnectorUpgradePolicy{WindowStartHour: 2, WindowDurationHours: 2},
+			now:      saturday(1),
+			want:     false,
+		},
+		{
+			scenario: "on the day of the window",
+			policy:   dbapi.ConnectorUpgradePolicy{WindowDays: "sat,sun", WindowStartHour: 2, WindowDurationHours: 2},
+			now:      saturday(2),
+			want:     true,
+		},
+		{
+			scenario: "on another day",
+			policy:   dbapi.ConnectorUpgradePolicy{WindowDays: "mon", WindowStartHour: 2, WindowDurationHours: 2},
+			now:      saturday(2),
+			want:     false,
+		},
+		{
+			scenario: "window started the day before and crosses midnight",
+			policy:   dbapi.ConnectorUpgradePolicy{WindowDays: "fri", WindowStartHour: 22, WindowDurationHours: 4},
+			now:      saturday(1),
+			want:     true,
+		},
+		{
+			scenario: "window crossing midnight started on a day that isn't in the window",
+			policy:   dbapi.ConnectorUpgradePolicy{WindowDays: "sat", WindowStartHour: 22, WindowDurationHours: 4},
+			now:      saturday(1),
+			want:     false,
+		},
+		{
+			scenario: "no window",
+			policy:   dbapi.ConnectorUpgradePolicy{},
+			now:      saturday(0),
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.scenario, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(InMaintenanceWindow(&tt.policy, tt.now)).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_ConnectorUpgradesService_CheckUpgrade(t *testing.T) {
+	startedAt := time.Date(2023, 4, 1, 10, 0, 0, 0, time.UTC)
+	timeout := 15 * time.Minute
+
+	tests := []struct {
+		name             string
+		kind             dbapi.ConnectorUpgradeKind
+		deployment       []map[string]interface{}
+		now              time.Time
+		wantState        dbapi.ConnectorUpgradeState
+		wantRollbackTo   interface{}
+		wantNoUpdate     bool
+		wantErrorMessage string
+	}{
+		{
+			name:       "should complete an upgrade once the upgraded deployment is ready",
+			kind:       dbapi.ConnectorUpgradeChannel,
+			deployment: []map[string]interface{}{{"id": "deployment", "version": 5, "Status__version": 5, "Status__phase": "ready"}},
+			now:        startedAt.Add(time.Minute),
+			wantState:  dbapi.ConnectorUpgradeSucceeded,
+		},
+		{
+			name:         "should keep waiting while the agent reports the deployment before the upgrade",
+			kind:         dbapi.ConnectorUpgradeChannel,
+			deployment:   []map[string]interface{}{{"id": "deployment", "version": 5, "Status__version": 4, "Status__phase": "ready"}},
+			now:          startedAt.Add(time.Minute),
+			wantState:    dbapi.ConnectorUpgradeInProgress,
+			wantNoUpdate: true,
+		},
+		{
+			name:           "should roll back the shard metadata of a failed channel upgrade",
+			kind:           dbapi.ConnectorUpgradeChannel,
+			deployment:     []map[string]interface{}{{"id": "deployment", "version": 5, "Status__version": 5, "Status__phase": "failed"}},
+			now:            startedAt.Add(time.Minute),
+			wantState:      dbapi.ConnectorUpgradeRolledBack,
+			wantRollbackTo: int64(1),
+		},
+		{
+			name:           "should roll back the operator of a failed operator upgrade",
+			kind:           dbapi.ConnectorUpgradeOperator,
+			deployment:     []map[string]interface{}{{"id": "deployment", "version": 5, "Status__version": 5, "Status__phase": "failed"}},
+			now:            startedAt.Add(time.Minute),
+			wantState:      dbapi.ConnectorUpgradeRolledBack,
+			wantRollbackTo: "old-operator",
+		},
+		{
+			name:           "should roll back a deployment that didn't become healthy in time",
+			kind:           dbapi.ConnectorUpgradeChannel,
+			deployment:     []map[string]interface{}{{"id": "deployment", "version": 5, "Status__version": 5, "Status__phase": "provisioning"}},
+			now:            startedAt.Add(timeout + time.Second),
+			wantState:      dbapi.ConnectorUpgradeRolledBack,
+			wantRollbackTo: int64(1),
+		},
+		{
+			name:         "should keep waiting for a deployment becoming healthy before the timeout",
+			kind:         dbapi.ConnectorUpgradeChannel,
+			deployment:   []map[string]interface{}{{"id": "deployment", "version": 5, "Status__version": 5, "Status__phase": "provisioning"}},
+			now:          startedAt.Add(timeout - time.Second),
+			wantState:    dbapi.ConnectorUpgradeInProgress,
+			wantNoUpdate: true,
+		},
+		{
+			name:             "should complete the upgrade of a deleted deployment",
+			kind:             dbapi.ConnectorUpgradeChannel,
+			now:              startedAt.Add(time.Minute),
+			wantState:        dbapi.ConnectorUpgradeSucceeded,
+			wantErrorMessage: "deployment was deleted during the upgrade",
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().WithQuery(`LEFT JOIN "connector_deployment_statuses" "Status"`).WithReply(tt.deployment)
+			var rollbackArgs []interface{}
+			updateDeployment := mocket.Catcher.NewMock().WithQuery(`UPDATE "connector_deployments" SET`).WithRowsNum(1).
+				WithCallback(func(query string, args []driver.NamedValue) {
+					for _, arg := range args {
+						rollbackArgs = append(rollbackArgs, arg.Value)
+					}
+				})
+			mocket.Catcher.NewMock().WithQuery(`SELECT "version" FROM "connector_deployments"`).WithReply([]map[string]interface{}{{"version": 6}})
+			updateUpgrade := mocket.Catcher.NewMock().WithQuery(`UPDATE "connector_upgrades" SET`).WithRowsNum(1)
+
+			service := NewConnectorUpgradesService(db.NewMockConnectionFactory(nil), signalbus.NewSignalBus(), nil,
+				&config.ConnectorsConfig{ConnectorUpgradeHealthTimeout: timeout})
+			upgrade := &dbapi.ConnectorUpgrade{
+				DeploymentID:        "deployment",
+				Kind:                tt.kind,
+				FromShardMetadataID: 1,
+				ToShardMetadataID:   2,
+				FromOperatorID:      "old-operator",
+				ToOperatorID:        "new-operator",
+				DeploymentVersion:   5,
+				State:               dbapi.ConnectorUpgradeInProgress,
+				StartedAt:           startedAt,
+			}
+			upgrade.ID = "upgrade"
+			g.Expect(service.CheckUpgrade(context.Background(), upgrade, tt.now)).To(gomega.BeNil())
+
+			g.Expect(upgrade.State).To(gomega.Equal(tt.wantState))
+			g.Expect(updateUpgrade.Triggered).To(gomega.Equal(!tt.wantNoUpdate))
+			g.Expect(updateDeployment.Triggered).To(gomega.Equal(tt.wantRollbackTo != nil))
+			if tt.wantErrorMessage != "" {
+				g.Expect(upgrade.Error).To(gomega.Equal(tt.wantErrorMessage))
+			}
+			if tt.wantRollbackTo == nil {
+				return
+			}
+			g.Expect(upgrade.Error).ToNot(gomega.BeEmpty())
+			g.Expect(rollbackArgs).To(gomega.ContainElement(tt.wantRollbackTo))
+		})
+	}
+}
+
+func Test_ConnectorUpgradesService_ApplyUpgrade(t *testing.T) {
+	// 2023-04-01 is a Saturday
+	now := time.Date(2023, 4, 1, 3, 0, 0, 0, time.UTC)
+	automatic := map[string]interface{}{"id": "policy", "type": "automatic", "max_concurrent_upgrades": 2}
+	latestRevision := int64(2)
+
+	tests := []struct {
+		name              string
+		connectorPolicy   []map[string]interface{}
+		namespacePolicy   []map[string]interface{}
+		inProgress        int
+		rolledBack        int
+		statusVersion     int64
+		statusPhase       dbapi.ConnectorStatusPhase
+		operatorUpgrade   bool
+		wantKind          dbapi.ConnectorUpgradeKind
+		wantShardMetadata int64
+		wantOperatorID    string
+	}{
+		{
+			name:              "should upgrade the channel of a deployment with the namespace policy",
+			namespacePolicy:   []map[string]interface{}{automatic},
+			wantKind:          dbapi.ConnectorUpgradeChannel,
+			wantShardMetadata: 2,
+		},
+		{
+			name:            "should upgrade the operator of a deployment",
+			namespacePolicy: []map[string]interface{}{automatic},
+			operatorUpgrade: true,
+			wantKind:        dbapi.ConnectorUpgradeOperator,
+			wantOperatorID:  "new-operator",
+		},
+		{
+			name: "should not upgrade a deployment without policy",
+		},
+		{
+			name:            "should prefer the manual policy of the connector to the policy of its namespace",
+			connectorPolicy: []map[string]interface{}{{"id": "connector-policy", "type": "manual"}},
+			namespacePolicy: []map[string]interface{}{automatic},
+		},
+		{
+			name: "should upgrade within the maintenance window",
+			namespacePolicy: []map[string]interface{}{{"id": "policy", "type": "maintenance_window",
+				"window_days": "sat", "window_start_hour": 2, "window_duration_hours": 2}},
+			wantKind:          dbapi.ConnectorUpgradeChannel,
+			wantShardMetadata: 2,
+		},
+		{
+			name: "should not upgrade outside of the maintenance window",
+			namespacePolicy: []map[string]interface{}{{"id": "policy", "type": "maintenance_window",
+				"window_days": "sun", "window_start_hour": 2, "window_duration_hours": 2}},
+		},
+		{
+			name:              "should upgrade while the namespace is under the rollout limit",
+			namespacePolicy:   []map[string]interface{}{automatic},
+			inProgress:        1,
+			wantKind:          dbapi.ConnectorUpgradeChannel,
+			wantShardMetadata: 2,
+		},
+		{
+			name:            "should not upgrade once the namespace reached the rollout limit",
+			namespacePolicy: []map[string]interface{}{automatic},
+			inProgress:      2,
+		},
+		{
+			name:            "should upgrade a single deployment at a time without rollout limit",
+			namespacePolicy: []map[string]interface{}{{"id": "policy", "type": "automatic"}},
+			inProgress:      1,
+		},
+		{
+			name:            "should not apply again an upgrade that was rolled back",
+			namespacePolicy: []map[string]interface{}{automatic},
+			rolledBack:      1,
+		},
+		{
+			name:            "should not upgrade a deployment the agent hasn't caught up with",
+			namespacePolicy: []map[string]interface{}{automatic},
+			statusVersion:   4,
+		},
+		{
+			name:            "should not upgrade a failed deployment",
+			namespacePolicy: []map[string]interface{}{automatic},
+			statusPhase:     dbapi.ConnectorStatusPhaseFailed,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().WithQuery(`"connector_upgrade_policies" WHERE (connector_id = $1)`).WithReply(tt.connectorPolicy)
+			mocket.Catcher.NewMock().WithQuery(`"connector_upgrade_policies" WHERE namespace_id = $1`).WithReply(tt.namespacePolicy)
+			mocket.Catcher.NewMock().WithQuery(`(namespace_id = $1 OR deployment_id = $2) AND state = $3`).
+				WithReply([]map[string]interface{}{{"count": tt.inProgress}})
+			mocket.Catcher.NewMock().WithQuery(`to_shard_metadata_id = $4 AND to_operator_id = $5`).
+				WithReply([]map[string]interface{}{{"count": tt.rolledBack}})
+			updateDeployment := mocket.Catcher.NewMock().WithQuery(`UPDATE "connector_deployments" SET`).WithRowsNum(1)
+			mocket.Catcher.NewMock().WithQuery(`SELECT "version" FROM "connector_deployments"`).WithReply([]map[string]interface{}{{"version": 6}})
+			insert := mocket.Catcher.NewMock().WithQuery(`INSERT INTO "connector_upgrades"`).WithRowsNum(1)
+
+			connectorTypesService := &ConnectorTypesServiceMock{
+				GetConnectorShardMetadataFunc: func(typeId string, channel string, revision int64) (*dbapi.ConnectorShardMetadata, *errors.ServiceError) {
+					return &dbapi.ConnectorShardMetadata{ID: revision, ConnectorTypeId: typeId, Channel: channel, Revision: revision}, nil
+				},
+			}
+			service := NewConnectorUpgradesService(db.NewMockConnectionFactory(nil), signalbus.NewSignalBus(), connectorTypesService,
+				&config.ConnectorsConfig{ConnectorUpgradeHealthTimeout: 15 * time.Minute})
+
+			deployment := &dbapi.ConnectorDeployment{
+				Version:                  5,
+				ConnectorID:              "connector",
+				NamespaceID:              "namespace",
+				OperatorID:               "old-operator",
+				ConnectorShardMetadataID: 1,
+				ConnectorShardMetadata:   dbapi.ConnectorShardMetadata{ID: 1, ConnectorTypeId: "connector-type", Channel: "stable", Revision: 1},
+			}
+			deployment.ID = "deployment"
+			deployment.Status.Version = 5
+			if tt.statusVersion != 0 {
+				deployment.Status.Version = tt.statusVersion
+			}
+			deployment.Status.Phase = dbapi.ConnectorStatusPhaseReady
+			if tt.statusPhase != "" {
+				deployment.Status.Phase = tt.statusPhase
+			}
+			if tt.operatorUpgrade {
+				deployment.Status.UpgradeAvailable = true
+				deployment.Status.Operators = api.JSON(`{"assigned": {"id": "old-operator"}, "available": {"id": "new-operator"}}`)
+			} else {
+				deployment.ConnectorShardMetadata.LatestRevision = &latestRevision
+			}
+
+			upgrade, err := service.ApplyUpgrade(context.Background(), deployment, now)
+			g.Expect(err).To(gomega.BeNil())
+
+			g.Expect(insert.Triggered).To(gomega.Equal(tt.wantKind != ""))
+			g.Expect(updateDeployment.Triggered).To(gomega.Equal(tt.wantKind != ""))
+			if tt.wantKind == "" {
+				g.Expect(upgrade).To(gomega.BeNil())
+				return
+			}
+			g.Expect(upgrade.Kind).To(gomega.Equal(tt.wantKind))
+			g.Expect(upgrade.State).To(gomega.Equal(dbapi.ConnectorUpgradeInProgress))
+			g.Expect(upgrade.DeploymentVersion).To(gomega.Equal(int64(6)))
+			g.Expect(upgrade.ToShardMetadataID).To(gomega.Equal(tt.wantShardMetadata))
+			g.Expect(upgrade.ToOperatorID).To(gomega.Equal(tt.wantOperatorID))
+		})
+	}
+}
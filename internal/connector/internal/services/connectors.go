@@ -28,6 +28,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 )
 
+//go:generate moq -out connectors_moq.go . ConnectorsService
 type ConnectorsService interface {
 	Create(ctx context.Context, resource *dbapi.Connector) *errors.ServiceError
 	Get(ctx context.Context, id string) (*dbapi.ConnectorWithConditions, *errors.ServiceError)
@@ -37,6 +38,7 @@ type ConnectorsService interface {
 	Delete(ctx context.Context, id string) *errors.ServiceError
 	ForEach(f func(*dbapi.Connector) *errors.ServiceError, query string, args ...interface{}) []error
 	ForceDelete(ctx context.Context, id string) *errors.ServiceError
+	Restart(ctx context.Context, id string) *errors.ServiceError
 
 	ResolveConnectorRefsWithBase64Secrets(resource *dbapi.Connector) (bool, *errors.ServiceError)
 }
@@ -122,29 +124,30 @@ func (k *connectorsService) Get(ctx context.Context, id string) (*dbapi.Connecto
 
 func filterConnectorsToOwnerOrOrg(ctx context.Context, dbConn *gorm.DB, factory *db.ConnectionFactory) (*gorm.DB, *errors.ServiceError) {
 
-	claims, err := auth.GetClaimsFromContext(ctx)
+	subjects, err := namespaceSubjectsFromContext(ctx)
 	if err != nil {
-		return dbConn, errors.Unauthenticated("user not authenticated")
+		return dbConn, err
 	}
-	owner, _ := claims.GetUsername()
-	if owner == "" {
-		return dbConn, errors.Unauthenticated("user not authenticated")
+	owner, orgId := subjects.UserId, subjects.OrgId
+	decision, err := auth.AuthorizeList(ctx, auth.ResourceConnector)
+	if err != nil {
+		return dbConn, err
 	}
 
-	orgId, _ := claims.GetOrgId()
-	filterByOrganisationId := auth.GetFilterByOrganisationFromContext(ctx)
-
 	// filter by organisationId if a user is part of an organisation and is not allowed as a service account
-	if filterByOrganisationId {
+	if decision.FilterByOrganisation {
 		// unassigned connectors with no namespace_id use owner and org
-		// assigned connectors use tenant user or organisation
+		// assigned connectors use tenant user or organisation, or namespace role bindings
 		dbConn = dbConn.Where("(connectors.namespace_id is null AND (owner = ? OR organisation_id = ?)) OR (connectors.namespace_id IS NOT NULL AND connectors.namespace_id IN (?))",
 			owner,
 			orgId,
-			factory.New().Table("connector_namespaces").Select("id").
-				Where("deleted_at is null AND (tenant_user_id = ? OR tenant_organisation_id = ?)", owner, orgId))
+			visibleNamespaces(factory, subjects))
 	} else {
-		dbConn = dbConn.Where("owner = ?", owner)
+		// connectors of the user, and connectors in namespaces shared with the user with role bindings
+		dbConn = dbConn.Where("owner = ? OR (connectors.namespace_id IS NOT NULL AND connectors.namespace_id IN (?))",
+			owner,
+			factory.New().Table("connector_namespaces").Select("id").
+				Where("deleted_at IS NULL AND id IN (?)", boundNamespaces(factory.New(), subjects, dbapi.ConnectorNamespaceRoleViewer)))
 	}
 	return dbConn, nil
 }
@@ -168,10 +171,38 @@ func (k *connectorsService) Delete(ctx context.Context, id string) *errors.Servi
 	if err := dbConn.Where("id = ?", id).Delete(&dbapi.ConnectorStatus{}).Error; err != nil {
 		return services.HandleGetError("ConnectorStatus", "id", id, err)
 	}
+	if err := dbConn.Where("connector_id = ?", id).Delete(&dbapi.ConnectorSchedule{}).Error; err != nil {
+		return services.HandleDeleteError("ConnectorSchedule", "connector_id", id, err)
+	}
+	if err := dbConn.Where("connector_id = ?", id).Delete(&dbapi.ConnectorUpgradePolicy{}).Error; err != nil {
+		return services.HandleDeleteError("ConnectorUpgradePolicy", "connector_id", id, err)
+	}
+	var revisions dbapi.ConnectorRevisionList
+	if err := dbConn.Where("connector_id = ?", id).Find(&revisions).Error; err != nil {
+		return errors.GeneralError("unable to get revisions of connector with id %s: %s", id, err)
+	}
+	revisionSecrets, serr := revisionSecretRefs(revisions)
+	if serr != nil {
+		return serr
+	}
+	if err := dbConn.Where("connector_id = ?", id).Delete(&dbapi.ConnectorRevision{}).Error; err != nil {
+		return services.HandleDeleteError("ConnectorRevision", "connector_id", id, err)
+	}
 
 	_ = db.AddPostCommitAction(ctx, func() {
 		// delete related distributed resources...
 
+		// secrets of previous revisions that are not used by the connector anymore
+		for _, r := range revisionSecrets {
+			if r == resource.ServiceAccount.ClientSecretRef || strings.Contains(string(resource.ConnectorSpec), r) {
+				continue
+			}
+			err := k.vaultService.DeleteSecretString(r)
+			if err != nil {
+				logger.Logger.Errorf("failed to delete vault secret key '%s': %v", r, err)
+			}
+		}
+
 		if resource.ServiceAccount.ClientSecretRef != "" {
 			err := k.vaultService.DeleteSecretString(resource.ServiceAccount.ClientSecretRef)
 			if err != nil {
@@ -426,6 +457,25 @@ func (k *connectorsService) ForceDelete(ctx context.Context, id string) *errors.
 	return nil
 }
 
+// Restart bumps the restart generation of a connector, the new generation is carried to the agent in the deployment spec
+func (k *connectorsService) Restart(ctx context.Context, id string) *errors.ServiceError {
+	update := k.connectionFactory.New().Model(&dbapi.Connector{}).Where("id = ?", id).
+		Update("restart_generation", gorm.Expr("restart_generation + 1"))
+	if err := update.Error; err != nil {
+		return services.HandleUpdateError("Connector", err)
+	}
+	if update.RowsAffected == 0 {
+		return services.HandleGetError("Connector", "id", id, gorm.ErrRecordNotFound)
+	}
+
+	_ = db.AddPostCommitAction(ctx, func() {
+		// Wake up the reconcile loop...
+		k.bus.Notify("reconcile:connector")
+	})
+
+	return nil
+}
+
 func (k *connectorsService) ResolveConnectorRefsWithBase64Secrets(connector *dbapi.Connector) (bool, *errors.ServiceError) {
 	err := getSecretsFromVaultAsBase64(connector, k.connectorTypesService, k.vaultService)
 	if err != nil {
@@ -0,0 +1,541 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package services
+
+import (
+	"context"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	coreService "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"sync"
+)
+
+// Ensure, that ConnectorsServiceMock does implement ConnectorsService.
+// If this is not the case, regenerate this file with moq.
+var _ ConnectorsService = &ConnectorsServiceMock{}
+
+// ConnectorsServiceMock is a mock implementation of ConnectorsService.
+//
+//	func TestSomethingThatUsesConnectorsService(t *testing.T) {
+//
+//		// make and configure a mocked ConnectorsService
+//		mockedConnectorsService := &ConnectorsServiceMock{
+//			CreateFunc: func(ctx context.Context, resource *dbapi.Connector) *errors.ServiceError {
+//				panic("mock out the Create method")
+//			},
+//			DeleteFunc: func(ctx context.Context, id string) *errors.ServiceError {
+//				panic("mock out the Delete method")
+//			},
+//			ForEachFunc: func(f func(*dbapi.Connector) *errors.ServiceError, query string, args ...interface{}) []error {
+//				panic("mock out the ForEach method")
+//			},
+//			ForceDeleteFunc: func(ctx context.Context, id string) *errors.ServiceError {
+//				panic("mock out the ForceDelete method")
+//			},
+//			GetFunc: func(ctx context.Context, id string) (*dbapi.ConnectorWithConditions, *errors.ServiceError) {
+//				panic("mock out the Get method")
+//			},
+//			ListFunc: func(ctx context.Context, listArgs *coreService.ListArguments, clusterId string) (dbapi.ConnectorWithConditionsList, *api.PagingMeta, *errors.ServiceError) {
+//				panic("mock out the List method")
+//			},
+//			ResolveConnectorRefsWithBase64SecretsFunc: func(resource *dbapi.Connector) (bool, *errors.ServiceError) {
+//				panic("mock out the ResolveConnectorRefsWithBase64Secrets method")
+//			},
+//			RestartFunc: func(ctx context.Context, id string) *errors.ServiceError {
+//				panic("mock out the Restart method")
+//			},
+//			SaveStatusFunc: func(ctx context.Context, resource dbapi.ConnectorStatus) *errors.ServiceError {
+//				panic("mock out the SaveStatus method")
+//			},
+//			UpdateFunc: func(ctx context.Context, resource *dbapi.Connector) *errors.ServiceError {
+//				panic("mock out the Update method")
+//			},
+//		}
+//
+//		// use mockedConnectorsService in code that requires ConnectorsService
+//		// and then make assertions.
+//
+//	}
+type ConnectorsServiceMock struct {
+	// CreateFunc mocks the Create method.
+	CreateFunc func(ctx context.Context, resource *dbapi.Connector) *errors.ServiceError
+
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(ctx context.Context, id string) *errors.ServiceError
+
+	// ForEachFunc mocks the ForEach method.
+	ForEachFunc func(f func(*dbapi.Connector) *errors.ServiceError, query string, args ...interface{}) []error
+
+	// ForceDeleteFunc mocks the ForceDelete method.
+	ForceDeleteFunc func(ctx context.Context, id string) *errors.ServiceError
+
+	// GetFunc mocks the Get method.
+	GetFunc func(ctx context.Context, id string) (*dbapi.ConnectorWithConditions, *errors.ServiceError)
+
+	// ListFunc mocks the List method.
+	ListFunc func(ctx context.Context, listArgs *coreService.ListArguments, clusterId string) (dbapi.ConnectorWithConditionsList, *api.PagingMeta, *errors.ServiceError)
+
+	// ResolveConnectorRefsWithBase64SecretsFunc mocks the ResolveConnectorRefsWithBase64Secrets method.
+	ResolveConnectorRefsWithBase64SecretsFunc func(resource *dbapi.Connector) (bool, *errors.ServiceError)
+
+	// RestartFunc mocks the Restart method.
+	RestartFunc func(ctx context.Context, id string) *errors.ServiceError
+
+	// SaveStatusFunc mocks the SaveStatus method.
+	SaveStatusFunc func(ctx context.Context, resource dbapi.ConnectorStatus) *errors.ServiceError
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(ctx context.Context, resource *dbapi.Connector) *errors.ServiceError
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Create holds details about calls to the Create method.
+		Create []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Resource is the resource argument value.
+			Resource *dbapi.Connector
+		}
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+		// ForEach holds details about calls to the ForEach method.
+		ForEach []struct {
+			// F is the f argument value.
+			F func(*dbapi.Connector) *errors.ServiceError
+			// Query is the query argument value.
+			Query string
+			// Args is the args argument value.
+			Args []interface{}
+		}
+		// ForceDelete holds details about calls to the ForceDelete method.
+		ForceDelete []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+		// Get holds details about calls to the Get method.
+		Get []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+		// List holds details about calls to the List method.
+		List []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ListArgs is the listArgs argument value.
+			ListArgs *coreService.ListArguments
+			// ClusterId is the clusterId argument value.
+			ClusterId string
+		}
+		// ResolveConnectorRefsWithBase64Secrets holds details about calls to the ResolveConnectorRefsWithBase64Secrets method.
+		ResolveConnectorRefsWithBase64Secrets []struct {
+			// Resource is the resource argument value.
+			Resource *dbapi.Connector
+		}
+		// Restart holds details about calls to the Restart method.
+		Restart []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+		// SaveStatus holds details about calls to the SaveStatus method.
+		SaveStatus []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Resource is the resource argument value.
+			Resource dbapi.ConnectorStatus
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Resource is the resource argument value.
+			Resource *dbapi.Connector
+		}
+	}
+	lockCreate                                sync.RWMutex
+	lockDelete                                sync.RWMutex
+	lockForEach                               sync.RWMutex
+	lockForceDelete                           sync.RWMutex
+	lockGet                                   sync.RWMutex
+	lockList                                  sync.RWMutex
+	lockResolveConnectorRefsWithBase64Secrets sync.RWMutex
+	lockRestart                               sync.RWMutex
+	lockSaveStatus                            sync.RWMutex
+	lockUpdate                                sync.RWMutex
+}
+
+// Create calls CreateFunc.
+func (mock *ConnectorsServiceMock) Create(ctx context.Context, resource *dbapi.Connector) *errors.ServiceError {
+	if mock.CreateFunc == nil {
+		panic("ConnectorsServiceMock.CreateFunc: method is nil but ConnectorsService.Create was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Resource *dbapi.Connector
+	}{
+		Ctx:      ctx,
+		Resource: resource,
+	}
+	mock.lockCreate.Lock()
+	mock.calls.Create = append(mock.calls.Create, callInfo)
+	mock.lockCreate.Unlock()
+	return mock.CreateFunc(ctx, resource)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedConnectorsService.CreateCalls())
+func (mock *ConnectorsServiceMock) CreateCalls() []struct {
+	Ctx      context.Context
+	Resource *dbapi.Connector
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Resource *dbapi.Connector
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
+
+// Delete calls DeleteFunc.
+func (mock *ConnectorsServiceMock) Delete(ctx context.Context, id string) *errors.ServiceError {
+	if mock.DeleteFunc == nil {
+		panic("ConnectorsServiceMock.DeleteFunc: method is nil but ConnectorsService.Delete was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	mock.lockDelete.Unlock()
+	return mock.DeleteFunc(ctx, id)
+}
+
+// DeleteCalls gets all the calls that were made to Delete.
+// Check the length with:
+//
+//	len(mockedConnectorsService.DeleteCalls())
+func (mock *ConnectorsServiceMock) DeleteCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockDelete.RLock()
+	calls = mock.calls.Delete
+	mock.lockDelete.RUnlock()
+	return calls
+}
+
+// ForEach calls ForEachFunc.
+func (mock *ConnectorsServiceMock) ForEach(f func(*dbapi.Connector) *errors.ServiceError, query string, args ...interface{}) []error {
+	if mock.ForEachFunc == nil {
+		panic("ConnectorsServiceMock.ForEachFunc: method is nil but ConnectorsService.ForEach was just called")
+	}
+	callInfo := struct {
+		F     func(*dbapi.Connector) *errors.ServiceError
+		Query string
+		Args  []interface{}
+	}{
+		F:     f,
+		Query: query,
+		Args:  args,
+	}
+	mock.lockForEach.Lock()
+	mock.calls.ForEach = append(mock.calls.ForEach, callInfo)
+	mock.lockForEach.Unlock()
+	return mock.ForEachFunc(f, query, args...)
+}
+
+// ForEachCalls gets all the calls that were made to ForEach.
+// Check the length with:
+//
+//	len(mockedConnectorsService.ForEachCalls())
+func (mock *ConnectorsServiceMock) ForEachCalls() []struct {
+	F     func(*dbapi.Connector) *errors.ServiceError
+	Query string
+	Args  []interface{}
+} {
+	var calls []struct {
+		F     func(*dbapi.Connector) *errors.ServiceError
+		Query string
+		Args  []interface{}
+	}
+	mock.lockForEach.RLock()
+	calls = mock.calls.ForEach
+	mock.lockForEach.RUnlock()
+	return calls
+}
+
+// ForceDelete calls ForceDeleteFunc.
+func (mock *ConnectorsServiceMock) ForceDelete(ctx context.Context, id string) *errors.ServiceError {
+	if mock.ForceDeleteFunc == nil {
+		panic("ConnectorsServiceMock.ForceDeleteFunc: method is nil but ConnectorsService.ForceDelete was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockForceDelete.Lock()
+	mock.calls.ForceDelete = append(mock.calls.ForceDelete, callInfo)
+	mock.lockForceDelete.Unlock()
+	return mock.ForceDeleteFunc(ctx, id)
+}
+
+// ForceDeleteCalls gets all the calls that were made to ForceDelete.
+// Check the length with:
+//
+//	len(mockedConnectorsService.ForceDeleteCalls())
+func (mock *ConnectorsServiceMock) ForceDeleteCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockForceDelete.RLock()
+	calls = mock.calls.ForceDelete
+	mock.lockForceDelete.RUnlock()
+	return calls
+}
+
+// Get calls GetFunc.
+func (mock *ConnectorsServiceMock) Get(ctx context.Context, id string) (*dbapi.ConnectorWithConditions, *errors.ServiceError) {
+	if mock.GetFunc == nil {
+		panic("ConnectorsServiceMock.GetFunc: method is nil but ConnectorsService.Get was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGet.Lock()
+	mock.calls.Get = append(mock.calls.Get, callInfo)
+	mock.lockGet.Unlock()
+	return mock.GetFunc(ctx, id)
+}
+
+// GetCalls gets all the calls that were made to Get.
+// Check the length with:
+//
+//	len(mockedConnectorsService.GetCalls())
+func (mock *ConnectorsServiceMock) GetCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockGet.RLock()
+	calls = mock.calls.Get
+	mock.lockGet.RUnlock()
+	return calls
+}
+
+// List calls ListFunc.
+func (mock *ConnectorsServiceMock) List(ctx context.Context, listArgs *coreService.ListArguments, clusterId string) (dbapi.ConnectorWithConditionsList, *api.PagingMeta, *errors.ServiceError) {
+	if mock.ListFunc == nil {
+		panic("ConnectorsServiceMock.ListFunc: method is nil but ConnectorsService.List was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ListArgs  *coreService.ListArguments
+		ClusterId string
+	}{
+		Ctx:       ctx,
+		ListArgs:  listArgs,
+		ClusterId: clusterId,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc(ctx, listArgs, clusterId)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedConnectorsService.ListCalls())
+func (mock *ConnectorsServiceMock) ListCalls() []struct {
+	Ctx       context.Context
+	ListArgs  *coreService.ListArguments
+	ClusterId string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ListArgs  *coreService.ListArguments
+		ClusterId string
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// ResolveConnectorRefsWithBase64Secrets calls ResolveConnectorRefsWithBase64SecretsFunc.
+func (mock *ConnectorsServiceMock) ResolveConnectorRefsWithBase64Secrets(resource *dbapi.Connector) (bool, *errors.ServiceError) {
+	if mock.ResolveConnectorRefsWithBase64SecretsFunc == nil {
+		panic("ConnectorsServiceMock.ResolveConnectorRefsWithBase64SecretsFunc: method is nil but ConnectorsService.ResolveConnectorRefsWithBase64Secrets was just called")
+	}
+	callInfo := struct {
+		Resource *dbapi.Connector
+	}{
+		Resource: resource,
+	}
+	mock.lockResolveConnectorRefsWithBase64Secrets.Lock()
+	mock.calls.ResolveConnectorRefsWithBase64Secrets = append(mock.calls.ResolveConnectorRefsWithBase64Secrets, callInfo)
+	mock.lockResolveConnectorRefsWithBase64Secrets.Unlock()
+	return mock.ResolveConnectorRefsWithBase64SecretsFunc(resource)
+}
+
+// ResolveConnectorRefsWithBase64SecretsCalls gets all the calls that were made to ResolveConnectorRefsWithBase64Secrets.
+// Check the length with:
+//
+//	len(mockedConnectorsService.ResolveConnectorRefsWithBase64SecretsCalls())
+func (mock *ConnectorsServiceMock) ResolveConnectorRefsWithBase64SecretsCalls() []struct {
+	Resource *dbapi.Connector
+} {
+	var calls []struct {
+		Resource *dbapi.Connector
+	}
+	mock.lockResolveConnectorRefsWithBase64Secrets.RLock()
+	calls = mock.calls.ResolveConnectorRefsWithBase64Secrets
+	mock.lockResolveConnectorRefsWithBase64Secrets.RUnlock()
+	return calls
+}
+
+// Restart calls RestartFunc.
+func (mock *ConnectorsServiceMock) Restart(ctx context.Context, id string) *errors.ServiceError {
+	if mock.RestartFunc == nil {
+		panic("ConnectorsServiceMock.RestartFunc: method is nil but ConnectorsService.Restart was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockRestart.Lock()
+	mock.calls.Restart = append(mock.calls.Restart, callInfo)
+	mock.lockRestart.Unlock()
+	return mock.RestartFunc(ctx, id)
+}
+
+// RestartCalls gets all the calls that were made to Restart.
+// Check the length with:
+//
+//	len(mockedConnectorsService.RestartCalls())
+func (mock *ConnectorsServiceMock) RestartCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockRestart.RLock()
+	calls = mock.calls.Restart
+	mock.lockRestart.RUnlock()
+	return calls
+}
+
+// SaveStatus calls SaveStatusFunc.
+func (mock *ConnectorsServiceMock) SaveStatus(ctx context.Context, resource dbapi.ConnectorStatus) *errors.ServiceError {
+	if mock.SaveStatusFunc == nil {
+		panic("ConnectorsServiceMock.SaveStatusFunc: method is nil but ConnectorsService.SaveStatus was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Resource dbapi.ConnectorStatus
+	}{
+		Ctx:      ctx,
+		Resource: resource,
+	}
+	mock.lockSaveStatus.Lock()
+	mock.calls.SaveStatus = append(mock.calls.SaveStatus, callInfo)
+	mock.lockSaveStatus.Unlock()
+	return mock.SaveStatusFunc(ctx, resource)
+}
+
+// SaveStatusCalls gets all the calls that were made to SaveStatus.
+// Check the length with:
+//
+//	len(mockedConnectorsService.SaveStatusCalls())
+func (mock *ConnectorsServiceMock) SaveStatusCalls() []struct {
+	Ctx      context.Context
+	Resource dbapi.ConnectorStatus
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Resource dbapi.ConnectorStatus
+	}
+	mock.lockSaveStatus.RLock()
+	calls = mock.calls.SaveStatus
+	mock.lockSaveStatus.RUnlock()
+	return calls
+}
+
+// Update calls UpdateFunc.
+func (mock *ConnectorsServiceMock) Update(ctx context.Context, resource *dbapi.Connector) *errors.ServiceError {
+	if mock.UpdateFunc == nil {
+		panic("ConnectorsServiceMock.UpdateFunc: method is nil but ConnectorsService.Update was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Resource *dbapi.Connector
+	}{
+		Ctx:      ctx,
+		Resource: resource,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	return mock.UpdateFunc(ctx, resource)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedConnectorsService.UpdateCalls())
+func (mock *ConnectorsServiceMock) UpdateCalls() []struct {
+	Ctx      context.Context
+	Resource *dbapi.Connector
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Resource *dbapi.Connector
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
@@ -18,6 +18,22 @@ type Config struct {
 	SecretPrefix        string `json:"secret_prefix"`
 	SecretPrefixEnable  bool   `json:"secret_prefix_enable"`
 	Region              string `json:"region"`
+
+	// HashiCorp Vault KV v2 configuration
+	HashicorpAddress             string `json:"hashicorp_address"`
+	HashicorpNamespace           string `json:"hashicorp_namespace"`
+	HashicorpMount               string `json:"hashicorp_mount"`
+	HashicorpAuthMethod          string `json:"hashicorp_auth_method"`
+	HashicorpAuthMount           string `json:"hashicorp_auth_mount"`
+	HashicorpToken               string `json:"hashicorp_token"`
+	HashicorpTokenFile           string `json:"hashicorp_token_file"`
+	HashicorpRoleId              string `json:"hashicorp_role_id"`
+	HashicorpRoleIdFile          string `json:"hashicorp_role_id_file"`
+	HashicorpSecretId            string `json:"hashicorp_secret_id"`
+	HashicorpSecretIdFile        string `json:"hashicorp_secret_id_file"`
+	HashicorpKubernetesRole      string `json:"hashicorp_kubernetes_role"`
+	HashicorpKubernetesTokenFile string `json:"hashicorp_kubernetes_token_file"`
+	HashicorpCACertFile          string `json:"hashicorp_ca_cert_file"`
 }
 
 func NewConfig() *Config {
@@ -28,22 +44,58 @@ func NewConfig() *Config {
 		Region:              DefaultRegion,
 		SecretPrefixEnable:  false,
 		SecretPrefix:        "managed-connectors",
+
+		HashicorpAddress:             "http://127.0.0.1:8200",
+		HashicorpMount:               "secret",
+		HashicorpAuthMethod:          HashicorpAuthToken,
+		HashicorpTokenFile:           "secrets/vault/hashicorp_token",
+		HashicorpRoleIdFile:          "secrets/vault/hashicorp_role_id",
+		HashicorpSecretIdFile:        "secrets/vault/hashicorp_secret_id",
+		HashicorpKubernetesTokenFile: "/var/run/secrets/kubernetes.io/serviceaccount/token",
 	}
 }
 
 func (c *Config) AddFlags(fs *pflag.FlagSet) {
-	fs.StringVar(&c.Kind, "vault-kind", c.Kind, "The kind of vault to use: aws|tmp")
+	fs.StringVar(&c.Kind, "vault-kind", c.Kind, "The kind of vault to use: aws|hashicorp|tmp")
 	fs.StringVar(&c.AccessKeyFile, "vault-access-key-file", c.AccessKeyFile, "File containing vault access key")
 	fs.StringVar(&c.SecretAccessKeyFile, "vault-secret-access-key-file", c.SecretAccessKeyFile, "File containing vault secret access key")
-	fs.BoolVar(&c.SecretPrefixEnable, "vault-secret-prefix-enable", c.SecretPrefixEnable, "Enable use of a prefix for all managed connectors secret names in AWS vault, default false")
-	fs.StringVar(&c.SecretPrefix, "vault-secret-prefix", c.SecretPrefix, "Prefix to use for all managed connectors secret names in AWS vault")
+	fs.BoolVar(&c.SecretPrefixEnable, "vault-secret-prefix-enable", c.SecretPrefixEnable, "Enable use of a prefix for all managed connectors secret names in AWS and HashiCorp vaults, default false")
+	fs.StringVar(&c.SecretPrefix, "vault-secret-prefix", c.SecretPrefix, "Prefix to use for all managed connectors secret names in AWS and HashiCorp vaults")
 	fs.StringVar(&c.Region, "vault-region", c.Region, "The region of the vault")
+	fs.StringVar(&c.HashicorpAddress, "vault-hashicorp-address", c.HashicorpAddress, "The address of the HashiCorp vault server")
+	fs.StringVar(&c.HashicorpNamespace, "vault-hashicorp-namespace", c.HashicorpNamespace, "The HashiCorp vault enterprise namespace, if any")
+	fs.StringVar(&c.HashicorpMount, "vault-hashicorp-mount", c.HashicorpMount, "The mount path of the KV v2 secrets engine in the HashiCorp vault")
+	fs.StringVar(&c.HashicorpAuthMethod, "vault-hashicorp-auth-method", c.HashicorpAuthMethod, "The HashiCorp vault auth method to use: token|approle|kubernetes")
+	fs.StringVar(&c.HashicorpAuthMount, "vault-hashicorp-auth-mount", c.HashicorpAuthMount, "The mount path of the HashiCorp vault auth method, defaults to the name of the auth method")
+	fs.StringVar(&c.HashicorpTokenFile, "vault-hashicorp-token-file", c.HashicorpTokenFile, "File containing the HashiCorp vault token, used with the token auth method")
+	fs.StringVar(&c.HashicorpRoleIdFile, "vault-hashicorp-role-id-file", c.HashicorpRoleIdFile, "File containing the HashiCorp vault AppRole role id")
+	fs.StringVar(&c.HashicorpSecretIdFile, "vault-hashicorp-secret-id-file", c.HashicorpSecretIdFile, "File containing the HashiCorp vault AppRole secret id")
+	fs.StringVar(&c.HashicorpKubernetesRole, "vault-hashicorp-kubernetes-role", c.HashicorpKubernetesRole, "The HashiCorp vault role to login as with the kubernetes auth method")
+	fs.StringVar(&c.HashicorpKubernetesTokenFile, "vault-hashicorp-kubernetes-token-file", c.HashicorpKubernetesTokenFile, "File containing the kubernetes service account token, used with the kubernetes auth method")
+	fs.StringVar(&c.HashicorpCACertFile, "vault-hashicorp-ca-cert-file", c.HashicorpCACertFile, "File containing the CA certificate of the HashiCorp vault server, the system CAs are used if empty")
 }
 
 func (c *Config) Validate(env *environments.Env) error {
 	if c.Kind == KindAws && c.SecretPrefixEnable && len(c.SecretPrefix) == 0 {
 		return fmt.Errorf("error validating AWS vault config, vault-secret-prefix must be set to a non-empty value if vault-secret-prefix-enable is true")
 	}
+	if c.Kind == KindHashicorp {
+		if c.SecretPrefixEnable && len(c.SecretPrefix) == 0 {
+			return fmt.Errorf("error validating HashiCorp vault config, vault-secret-prefix must be set to a non-empty value if vault-secret-prefix-enable is true")
+		}
+		if c.HashicorpAddress == "" || c.HashicorpMount == "" {
+			return fmt.Errorf("error validating HashiCorp vault config, vault-hashicorp-address and vault-hashicorp-mount must be set")
+		}
+		switch c.HashicorpAuthMethod {
+		case HashicorpAuthToken, HashicorpAuthAppRole:
+		case HashicorpAuthKubernetes:
+			if c.HashicorpKubernetesRole == "" {
+				return fmt.Errorf("error validating HashiCorp vault config, vault-hashicorp-kubernetes-role must be set for the kubernetes auth method")
+			}
+		default:
+			return fmt.Errorf("error validating HashiCorp vault config, invalid vault-hashicorp-auth-method %q", c.HashicorpAuthMethod)
+		}
+	}
 	return nil
 }
 
@@ -58,5 +110,17 @@ func (c *Config) ReadFiles() error {
 			return err
 		}
 	}
+	if c.Kind == KindHashicorp {
+		// the kubernetes service account token is read on every login, since it's rotated
+		switch c.HashicorpAuthMethod {
+		case HashicorpAuthToken:
+			return shared.ReadFileValueString(c.HashicorpTokenFile, &c.HashicorpToken)
+		case HashicorpAuthAppRole:
+			if err := shared.ReadFileValueString(c.HashicorpRoleIdFile, &c.HashicorpRoleId); err != nil {
+				return err
+			}
+			return shared.ReadFileValueString(c.HashicorpSecretIdFile, &c.HashicorpSecretId)
+		}
+	}
 	return nil
 }
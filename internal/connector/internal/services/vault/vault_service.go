@@ -6,10 +6,14 @@ import (
 )
 
 const (
-	KindTmp = "tmp"
-	KindAws = "aws"
+	KindTmp       = "tmp"
+	KindAws       = "aws"
+	KindHashicorp = "hashicorp"
 
 	DefaultRegion = "us-east-1"
+
+	// ConnectorOwningResourcePrefix is the prefix of the owning resource of the secrets of a connector
+	ConnectorOwningResourcePrefix = "/v1/connector/"
 )
 
 type VaultService interface {
@@ -25,6 +29,8 @@ func NewVaultService(vaultConfig *Config) (VaultService, error) {
 	switch vaultConfig.Kind {
 	case KindAws:
 		return NewAwsVaultService(vaultConfig)
+	case KindHashicorp:
+		return NewHashicorpVaultService(vaultConfig)
 	case KindTmp:
 		return NewTmpVaultService()
 	default:
@@ -0,0 +1,345 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/metrics"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+	vaultapi "github.com/hashicorp/vault/api"
+)
+
+const (
+	HashicorpAuthToken      = "token"
+	HashicorpAuthAppRole    = "approle"
+	HashicorpAuthKubernetes = "kubernetes"
+
+	// hashicorpValueKey is the key of the secret value in the KV v2 secret data
+	hashicorpValueKey = "value"
+	// hashicorpTokenRenewBefore is how long before the expiry of a login token a new login is done
+	hashicorpTokenRenewBefore = time.Minute
+)
+
+// HashicorpNotFoundError is returned when a secret doesn't exist in the HashiCorp vault
+type HashicorpNotFoundError struct {
+	Name string
+}
+
+func (e *HashicorpNotFoundError) Error() string {
+	return fmt.Sprintf("secret %s not found", e.Name)
+}
+
+var _ VaultService = &hashicorpVaultService{}
+
+// hashicorpVaultService stores secrets in a HashiCorp vault KV v2 secrets engine,
+// the owning resource of a secret is stored in its custom metadata
+type hashicorpVaultService struct {
+	client              *vaultapi.Client
+	mount               string
+	authMethod          string
+	authMount           string
+	roleId              string
+	secretId            string
+	kubernetesRole      string
+	kubernetesTokenFile string
+	secretPrefixEnable  bool
+	secretPrefix        string
+
+	mu          sync.Mutex
+	token       string
+	tokenExpiry time.Time
+}
+
+func NewHashicorpVaultService(vaultConfig *Config) (*hashicorpVaultService, error) {
+	clientConfig := vaultapi.DefaultConfig()
+	if clientConfig.Error != nil {
+		return nil, fmt.Errorf("invalid HashiCorp vault client configuration: %w", clientConfig.Error)
+	}
+	clientConfig.Address = strings.TrimSuffix(vaultConfig.HashicorpAddress, "/")
+	clientConfig.Timeout = 30 * time.Second
+	if vaultConfig.HashicorpCACertFile != "" {
+		if err := clientConfig.ConfigureTLS(&vaultapi.TLSConfig{
+			CACert: shared.BuildFullFilePath(vaultConfig.HashicorpCACertFile),
+		}); err != nil {
+			return nil, fmt.Errorf("invalid HashiCorp vault CA certificate in %s: %w", vaultConfig.HashicorpCACertFile, err)
+		}
+	}
+	client, err := vaultapi.NewClient(clientConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HashiCorp vault client: %w", err)
+	}
+	// tokens are only set from the configuration, never from the environment
+	client.ClearToken()
+	if vaultConfig.HashicorpNamespace != "" {
+		client.SetNamespace(vaultConfig.HashicorpNamespace)
+	}
+
+	authMount := vaultConfig.HashicorpAuthMount
+	if authMount == "" {
+		authMount = vaultConfig.HashicorpAuthMethod
+	}
+
+	k := &hashicorpVaultService{
+		client:              client,
+		mount:               strings.Trim(vaultConfig.HashicorpMount, "/"),
+		authMethod:          vaultConfig.HashicorpAuthMethod,
+		authMount:           strings.Trim(authMount, "/"),
+		roleId:              vaultConfig.HashicorpRoleId,
+		secretId:            vaultConfig.HashicorpSecretId,
+		kubernetesRole:      vaultConfig.HashicorpKubernetesRole,
+		kubernetesTokenFile: vaultConfig.HashicorpKubernetesTokenFile,
+		secretPrefixEnable:  vaultConfig.SecretPrefixEnable,
+		secretPrefix:        strings.Trim(vaultConfig.SecretPrefix, "/") + "/",
+	}
+
+	switch k.authMethod {
+	case HashicorpAuthToken:
+		if vaultConfig.HashicorpToken == "" {
+			return nil, fmt.Errorf("a HashiCorp vault token is required for the token auth method")
+		}
+		k.token = vaultConfig.HashicorpToken
+		k.client.SetToken(k.token)
+	case HashicorpAuthAppRole, HashicorpAuthKubernetes:
+		if err := k.login(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("invalid HashiCorp vault auth method: %s", k.authMethod)
+	}
+	return k, nil
+}
+
+func (k *hashicorpVaultService) Kind() string {
+	return KindHashicorp
+}
+
+func (k *hashicorpVaultService) GetSecretString(name string) (string, error) {
+	metrics.IncreaseVaultServiceTotalCount("get")
+
+	var secret *vaultapi.KVSecret
+	err := k.do(func(ctx context.Context) (err error) {
+		secret, err = k.client.KVv2(k.mount).Get(ctx, k.getVaultSecretName(name))
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, vaultapi.ErrSecretNotFound) {
+			metrics.IncreaseVaultServiceErrorsCount("get")
+			return "", &HashicorpNotFoundError{Name: name}
+		}
+		metrics.IncreaseVaultServiceFailureCount("get")
+		return "", err
+	}
+	value, ok := secret.Data[hashicorpValueKey].(string)
+	if !ok {
+		metrics.IncreaseVaultServiceErrorsCount("get")
+		return "", &HashicorpNotFoundError{Name: name}
+	}
+	metrics.IncreaseVaultServiceSuccessCount("get")
+	return value, nil
+}
+
+func (k *hashicorpVaultService) SetSecretString(name string, value string, owningResource string) error {
+	metrics.IncreaseVaultServiceTotalCount("set")
+
+	kv := k.client.KVv2(k.mount)
+	path := k.getVaultSecretName(name)
+	err := k.do(func(ctx context.Context) error {
+		// KV v2 can't write the data and the metadata in one request, the metadata is written first
+		// so that a secret never exists without its owning resource, which the garbage collector relies on
+		if owningResource != "" {
+			if err := kv.PutMetadata(ctx, path, vaultapi.KVMetadataPutInput{
+				CustomMetadata: map[string]interface{}{OwnerResourceTagKey: owningResource},
+			}); err != nil {
+				return err
+			}
+		}
+		// secrets are never updated, check-and-set 0 fails if the secret already has a version
+		_, err := kv.Put(ctx, path, map[string]interface{}{hashicorpValueKey: value}, vaultapi.WithCheckAndSet(0))
+		return err
+	})
+	if err != nil {
+		metrics.IncreaseVaultServiceFailureCount("set")
+		return err
+	}
+	metrics.IncreaseVaultServiceSuccessCount("set")
+	return nil
+}
+
+func (k *hashicorpVaultService) DeleteSecretString(name string) error {
+	metrics.IncreaseVaultServiceTotalCount("delete")
+
+	kv := k.client.KVv2(k.mount)
+	path := k.getVaultSecretName(name)
+	err := k.do(func(ctx context.Context) error {
+		// vault doesn't report missing secrets on delete, so check that the secret exists first
+		if _, err := kv.GetMetadata(ctx, path); err != nil {
+			return err
+		}
+		// deleting the metadata deletes all the versions of the secret
+		return kv.DeleteMetadata(ctx, path)
+	})
+	if err != nil {
+		if errors.Is(err, vaultapi.ErrSecretNotFound) {
+			metrics.IncreaseVaultServiceErrorsCount("delete")
+			return &HashicorpNotFoundError{Name: name}
+		}
+		metrics.IncreaseVaultServiceFailureCount("delete")
+		return err
+	}
+	metrics.IncreaseVaultServiceSuccessCount("delete")
+	return nil
+}
+
+func (k *hashicorpVaultService) ForEachSecret(f func(name string, owningResource string) bool) error {
+	_, err := k.forEachSecret("", f)
+	return err
+}
+
+// forEachSecret calls f for the secrets in the given folder and its sub folders,
+// it returns false once f returned false
+func (k *hashicorpVaultService) forEachSecret(folder string, f func(name string, owningResource string) bool) (bool, error) {
+	var list *vaultapi.Secret
+	err := k.do(func(ctx context.Context) (err error) {
+		list, err = k.client.Logical().ListWithContext(ctx, fmt.Sprintf("%s/metadata/%s", k.mount, k.getVaultSecretName(folder)))
+		return err
+	})
+	if err != nil {
+		metrics.IncreaseVaultServiceFailureCount("get")
+		return false, err
+	}
+	if list == nil || list.Data == nil {
+		// no secrets
+		return true, nil
+	}
+	keys, _ := list.Data["keys"].([]interface{})
+
+	kv := k.client.KVv2(k.mount)
+	for _, item := range keys {
+		key, ok := item.(string)
+		if !ok {
+			continue
+		}
+		name := folder + key
+		if strings.HasSuffix(key, "/") {
+			if more, err := k.forEachSecret(name, f); err != nil || !more {
+				return more, err
+			}
+			continue
+		}
+
+		metrics.IncreaseVaultServiceTotalCount("get")
+		var metadata *vaultapi.KVMetadata
+		err := k.do(func(ctx context.Context) (err error) {
+			metadata, err = kv.GetMetadata(ctx, k.getVaultSecretName(name))
+			return err
+		})
+		if err != nil {
+			if errors.Is(err, vaultapi.ErrSecretNotFound) {
+				// deleted since it was listed
+				metrics.IncreaseVaultServiceErrorsCount("get")
+				continue
+			}
+			metrics.IncreaseVaultServiceFailureCount("get")
+			return false, err
+		}
+		metrics.IncreaseVaultServiceSuccessCount("get")
+		owner, _ := metadata.CustomMetadata[OwnerResourceTagKey].(string)
+		if !f(name, owner) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (k *hashicorpVaultService) getVaultSecretName(name string) string {
+	if k.secretPrefixEnable {
+		return k.secretPrefix + name
+	}
+	return name
+}
+
+// do sends requests to the vault with a valid token,
+// it logs in again and retries once if the token has been revoked or has expired
+func (k *hashicorpVaultService) do(request func(ctx context.Context) error) error {
+	token, err := k.getToken()
+	if err != nil {
+		return err
+	}
+	err = request(context.Background())
+	var responseErr *vaultapi.ResponseError
+	if errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusForbidden && k.authMethod != HashicorpAuthToken {
+		k.mu.Lock()
+		if k.token == token {
+			k.tokenExpiry = time.Time{}
+		}
+		k.mu.Unlock()
+		if _, err = k.getToken(); err != nil {
+			return err
+		}
+		err = request(context.Background())
+	}
+	return err
+}
+
+func (k *hashicorpVaultService) getToken() (string, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.authMethod == HashicorpAuthToken || time.Now().Add(hashicorpTokenRenewBefore).Before(k.tokenExpiry) {
+		return k.token, nil
+	}
+	if err := k.loginLocked(); err != nil {
+		return "", err
+	}
+	return k.token, nil
+}
+
+func (k *hashicorpVaultService) login() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.loginLocked()
+}
+
+func (k *hashicorpVaultService) loginLocked() error {
+	var body map[string]interface{}
+	switch k.authMethod {
+	case HashicorpAuthAppRole:
+		body = map[string]interface{}{"role_id": k.roleId, "secret_id": k.secretId}
+	case HashicorpAuthKubernetes:
+		// read the service account token on every login, since it's rotated by kubernetes
+		var jwt string
+		if err := shared.ReadFileValueString(k.kubernetesTokenFile, &jwt); err != nil {
+			return fmt.Errorf("failed to read kubernetes service account token: %w", err)
+		}
+		body = map[string]interface{}{"role": k.kubernetesRole, "jwt": jwt}
+	default:
+		return fmt.Errorf("login isn't supported by the HashiCorp vault auth method %s", k.authMethod)
+	}
+
+	// login without the expired token
+	client, err := k.client.CloneWithHeaders()
+	if err != nil {
+		return fmt.Errorf("failed to login to HashiCorp vault with %s: %w", k.authMethod, err)
+	}
+	client.ClearToken()
+	secret, err := client.Logical().Write(fmt.Sprintf("auth/%s/login", k.authMount), body)
+	if err != nil {
+		return fmt.Errorf("failed to login to HashiCorp vault with %s: %w", k.authMethod, err)
+	}
+	if secret == nil || secret.Auth == nil {
+		return fmt.Errorf("HashiCorp vault login response to auth/%s/login has no auth", k.authMount)
+	}
+	k.token = secret.Auth.ClientToken
+	k.client.SetToken(k.token)
+	if secret.Auth.LeaseDuration > 0 {
+		k.tokenExpiry = time.Now().Add(time.Duration(secret.Auth.LeaseDuration) * time.Second)
+	} else {
+		// tokens without a lease don't expire
+		k.tokenExpiry = time.Now().AddDate(100, 0, 0)
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package vault_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/vault"
+	"github.com/onsi/gomega"
+)
+
+const (
+	fakeHashicorpRoleId   = "role-id"
+	fakeHashicorpSecretId = "secret-id"
+	fakeHashicorpToken    = "approle-token"
+)
+
+// fakeHashicorpVault is a minimal in-memory implementation of the HashiCorp vault KV v2 and AppRole login APIs
+type fakeHashicorpVault struct {
+	mu       sync.Mutex
+	data     map[string]map[string]interface{}
+	metadata map[string]map[string]string
+	tokens   map[string]bool
+	// failDataWrites makes the writes of secret data fail, as if the fleet manager crashed before writing them
+	failDataWrites bool
+}
+
+func newFakeHashicorpVault() *httptest.Server {
+	return httptest.NewServer(newFakeHashicorpVaultHandler())
+}
+
+func newFakeHashicorpVaultHandler() *fakeHashicorpVault {
+	return &fakeHashicorpVault{
+		data:     map[string]map[string]interface{}{},
+		metadata: map[string]map[string]string{},
+		tokens:   map[string]bool{},
+	}
+}
+
+func (v *fakeHashicorpVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if r.URL.Path == "/v1/auth/approle/login" {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["role_id"] != fakeHashicorpRoleId || body["secret_id"] != fakeHashicorpSecretId {
+			writeFakeHashicorpResponse(w, http.StatusBadRequest, map[string]interface{}{"errors": []string{"invalid role or secret id"}})
+			return
+		}
+		v.tokens[fakeHashicorpToken] = true
+		writeFakeHashicorpResponse(w, http.StatusOK, map[string]interface{}{
+			"auth": map[string]interface{}{"client_token": fakeHashicorpToken, "lease_duration": 3600},
+		})
+		return
+	}
+	if !v.tokens[r.Header.Get("X-Vault-Token")] {
+		writeFakeHashicorpResponse(w, http.StatusForbidden, map[string]interface{}{"errors": []string{"permission denied"}})
+		return
+	}
+
+	switch {
+	case strings.HasPrefix(r.URL.Path, "/v1/secret/data/"):
+		name := strings.TrimPrefix(r.URL.Path, "/v1/secret/data/")
+		switch r.Method {
+		case http.MethodGet:
+			data, ok := v.data[name]
+			if !ok {
+				writeFakeHashicorpResponse(w, http.StatusNotFound, map[string]interface{}{"errors": []string{}})
+				return
+			}
+			writeFakeHashicorpResponse(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{
+				"data":     data,
+				"metadata": map[string]interface{}{"version": 1, "custom_metadata": v.metadata[name]},
+			}})
+		case http.MethodPost, http.MethodPut:
+			var body struct {
+				Data map[string]interface{} `json:"data"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if v.failDataWrites {
+				writeFakeHashicorpResponse(w, http.StatusBadRequest, map[string]interface{}{"errors": []string{"write failed"}})
+				return
+			}
+			if _, ok := v.data[name]; ok {
+				writeFakeHashicorpResponse(w, http.StatusBadRequest, map[string]interface{}{"errors": []string{"check-and-set parameter did not match the current version"}})
+				return
+			}
+			v.data[name] = body.Data
+			if _, ok := v.metadata[name]; !ok {
+				v.metadata[name] = map[string]string{}
+			}
+			writeFakeHashicorpResponse(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"version": 1}})
+		}
+	case r.URL.Path == "/v1/secret/metadata" || strings.HasPrefix(r.URL.Path, "/v1/secret/metadata/"):
+		name := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/v1/secret/metadata"), "/")
+		switch {
+		case r.Method == "LIST" || (r.Method == http.MethodGet && r.URL.Query().Get("list") == "true"):
+			name = strings.TrimSuffix(name, "/") + "/"
+			if name == "/" {
+				name = ""
+			}
+			var keys []string
+			seen := map[string]bool{}
+			for k := range v.metadata {
+				if strings.HasPrefix(k, name) {
+					k = strings.TrimPrefix(k, name)
+					if i := strings.Index(k, "/"); i >= 0 {
+						k = k[:i+1]
+					}
+					if !seen[k] {
+						seen[k] = true
+						keys = append(keys, k)
+					}
+				}
+			}
+			if len(keys) == 0 {
+				writeFakeHashicorpResponse(w, http.StatusNotFound, map[string]interface{}{"errors": []string{}})
+				return
+			}
+			sort.Strings(keys)
+			writeFakeHashicorpResponse(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"keys": keys}})
+		case r.Method == http.MethodGet:
+			metadata, ok := v.metadata[name]
+			if !ok {
+				writeFakeHashicorpResponse(w, http.StatusNotFound, map[string]interface{}{"errors": []string{}})
+				return
+			}
+			writeFakeHashicorpResponse(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"custom_metadata": metadata}})
+		case r.Method == http.MethodPost || r.Method == http.MethodPut:
+			var body struct {
+				CustomMetadata map[string]string `json:"custom_metadata"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			v.metadata[name] = body.CustomMetadata
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete:
+			delete(v.data, name)
+			delete(v.metadata, name)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	default:
+		writeFakeHashicorpResponse(w, http.StatusNotFound, map[string]interface{}{"errors": []string{}})
+	}
+}
+
+func writeFakeHashicorpResponse(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func newFakeHashicorpVaultService(t *testing.T, fake *fakeHashicorpVault, prefix string) vault.VaultService {
+	g := gomega.NewWithT(t)
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	service, err := vault.NewVaultService(&vault.Config{
+		Kind:                vault.KindHashicorp,
+		HashicorpAddress:    server.URL,
+		HashicorpMount:      "secret",
+		HashicorpAuthMethod: vault.HashicorpAuthAppRole,
+		HashicorpRoleId:     fakeHashicorpRoleId,
+		HashicorpSecretId:   fakeHashicorpSecretId,
+		SecretPrefixEnable:  prefix != "",
+		SecretPrefix:        prefix,
+	})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	return service
+}
+
+func Test_HashicorpVaultService_ForEachSecret(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+	}{
+		{
+			name: "should list the secrets of all the folders",
+		},
+		{
+			name:   "should list the secrets of all the folders under the prefix",
+			prefix: "managed-connectors",
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			service := newFakeHashicorpVaultService(t, newFakeHashicorpVaultHandler(), tt.prefix)
+			secrets := map[string]string{
+				"top":                      "/v1/connector/top",
+				"folder/nested":            "/v1/connector/nested",
+				"folder/sub/deeply-nested": "/v1/connector/deeply-nested",
+			}
+			for name, owner := range secrets {
+				g.Expect(service.SetSecretString(name, "value", owner)).To(gomega.Succeed())
+			}
+
+			listed := map[string]string{}
+			g.Expect(service.ForEachSecret(func(name string, owningResource string) bool {
+				listed[name] = owningResource
+				return true
+			})).To(gomega.Succeed())
+			g.Expect(listed).To(gomega.Equal(secrets))
+
+			count := 0
+			g.Expect(service.ForEachSecret(func(name string, owningResource string) bool {
+				count++
+				return false
+			})).To(gomega.Succeed())
+			g.Expect(count).To(gomega.Equal(1))
+		})
+	}
+}
+
+func Test_HashicorpVaultService_SetSecretString(t *testing.T) {
+	g := gomega.NewWithT(t)
+	fake := newFakeHashicorpVaultHandler()
+	service := newFakeHashicorpVaultService(t, fake, "")
+
+	// a secret whose data couldn't be written is still listed with its owner, so that it can be collected
+	fake.failDataWrites = true
+	g.Expect(service.SetSecretString("secret", "value", "/v1/connector/id")).ToNot(gomega.Succeed())
+	listed := map[string]string{}
+	g.Expect(service.ForEachSecret(func(name string, owningResource string) bool {
+		listed[name] = owningResource
+		return true
+	})).To(gomega.Succeed())
+	g.Expect(listed).To(gomega.Equal(map[string]string{"secret": "/v1/connector/id"}))
+
+	fake.failDataWrites = false
+	g.Expect(service.SetSecretString("secret", "value", "/v1/connector/id")).To(gomega.Succeed())
+	g.Expect(service.SetSecretString("secret", "other", "/v1/connector/id")).ToNot(gomega.Succeed())
+	value, err := service.GetSecretString("secret")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(value).To(gomega.Equal("value"))
+}
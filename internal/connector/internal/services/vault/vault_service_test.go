@@ -6,7 +6,6 @@ import (
 	"testing"
 	"text/template"
 
-	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/handlers"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/metrics"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/vault"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
@@ -27,6 +26,12 @@ func TestNewVaultService(t *testing.T) {
 	}
 	g.Expect(vc.ReadFiles()).To(gomega.BeNil())
 
+	fakeHashicorpVault := newFakeHashicorpVault()
+	defer fakeHashicorpVault.Close()
+
+	// Enable testing against a HashiCorp vault, e.g. a dev-mode server, if its address and token are configured..
+	hashicorpAddress, hashicorpToken := os.Getenv("VAULT_ADDR"), os.Getenv("VAULT_TOKEN")
+
 	tests := []struct {
 		config       *vault.Config
 		wantErrOnNew bool
@@ -61,6 +66,42 @@ func TestNewVaultService(t *testing.T) {
 			skip: vc.Kind != vault.KindAws,
 			name: vault.KindAws + "-with-prefix",
 		},
+		{
+			config: &vault.Config{
+				Kind:                vault.KindHashicorp,
+				HashicorpAddress:    fakeHashicorpVault.URL,
+				HashicorpMount:      "secret",
+				HashicorpAuthMethod: vault.HashicorpAuthAppRole,
+				HashicorpRoleId:     fakeHashicorpRoleId,
+				HashicorpSecretId:   fakeHashicorpSecretId,
+				SecretPrefixEnable:  true,
+				SecretPrefix:        "managed-connectors",
+			},
+			name: vault.KindHashicorp + "-approle",
+		},
+		{
+			config: &vault.Config{
+				Kind:                vault.KindHashicorp,
+				HashicorpAddress:    fakeHashicorpVault.URL,
+				HashicorpMount:      "secret",
+				HashicorpAuthMethod: vault.HashicorpAuthAppRole,
+				HashicorpRoleId:     fakeHashicorpRoleId,
+				HashicorpSecretId:   "wrong",
+			},
+			wantErrOnNew: true,
+			name:         vault.KindHashicorp + "-approle-invalid-secret-id",
+		},
+		{
+			config: &vault.Config{
+				Kind:                vault.KindHashicorp,
+				HashicorpAddress:    hashicorpAddress,
+				HashicorpMount:      "secret",
+				HashicorpAuthMethod: vault.HashicorpAuthToken,
+				HashicorpToken:      hashicorpToken,
+			},
+			skip: hashicorpAddress == "" || hashicorpToken == "",
+			name: vault.KindHashicorp + "-token",
+		},
 		{
 			config:       &vault.Config{Kind: "wrong"},
 			wantErrOnNew: true,
@@ -73,12 +114,12 @@ func TestNewVaultService(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
 
+			if tt.skip {
+				t.SkipNow()
+			}
 			svc, err := vault.NewVaultService(tt.config)
 			g.Expect(err != nil).Should(gomega.Equal(tt.wantErrOnNew), "NewVaultService() error = %v, wantErr %v", err, tt.wantErrOnNew)
 			if err == nil {
-				if tt.skip {
-					t.SkipNow()
-				}
 				happyPath(svc, t)
 			}
 		})
@@ -96,7 +137,7 @@ func happyPath(service vault.VaultService, t *testing.T) {
 	g.Expect(err).Should(gomega.BeNil())
 
 	keyName := api.NewID()
-	err = service.SetSecretString(keyName, "hello", handlers.OwningResourcePrefix+"thistest")
+	err = service.SetSecretString(keyName, "hello", vault.ConnectorOwningResourcePrefix+"thistest")
 	g.Expect(err).Should(gomega.BeNil())
 
 	value, err := service.GetSecretString(keyName)
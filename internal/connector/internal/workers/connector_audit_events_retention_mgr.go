@@ -0,0 +1,12 @@
+package workers
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/audit"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+)
+
+// NewConnectorAuditEventsRetentionManager creates a worker that deletes the expired audit events of the connector_mgmt API
+func NewConnectorAuditEventsRetentionManager(auditEventsService audit.AuditEventsService, auditConfig *audit.AuditConfig,
+	reconciler workers.Reconciler) *audit.RetentionWorker {
+	return audit.NewRetentionWorker("connector_audit_events_retention", audit.ServiceConnectors, auditEventsService, auditConfig, reconciler)
+}
@@ -0,0 +1,75 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/golang/glog"
+)
+
+var _ environments.BootService = &ConnectorCatalogRefresher{}
+
+// ConnectorCatalogRefresher periodically reloads the in-memory connector catalog on every replica.
+// Unlike the leader elected ConnectorCatalogSyncManager, which reconciles connector types in the database,
+// it keeps the catalog used by the startup reconcile check and the connector type lookups of each replica current.
+type ConnectorCatalogRefresher struct {
+	connectorsConfig *config.ConnectorsConfig
+	stop             chan struct{}
+	done             chan struct{}
+}
+
+func NewConnectorCatalogRefresher(connectorsConfig *config.ConnectorsConfig) *ConnectorCatalogRefresher {
+	return &ConnectorCatalogRefresher{
+		connectorsConfig: connectorsConfig,
+	}
+}
+
+// Start reloads the connector catalog every catalog sync interval, a zero interval disables the reloads
+func (r *ConnectorCatalogRefresher) Start() {
+	interval := r.connectorsConfig.ConnectorCatalogSyncInterval
+	if interval <= 0 {
+		return
+	}
+	r.stop = make(chan struct{})
+	r.done = make(chan struct{})
+	go func() {
+		defer close(r.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-r.stop:
+				return
+			case <-ticker.C:
+				r.Refresh()
+			}
+		}
+	}()
+}
+
+func (r *ConnectorCatalogRefresher) Stop() {
+	if r.stop == nil {
+		return
+	}
+	close(r.stop)
+	<-r.done
+}
+
+// Refresh reloads the connector catalog and replaces the in-memory catalog when it changed
+func (r *ConnectorCatalogRefresher) Refresh() {
+	catalog, err := r.connectorsConfig.LoadCatalog(context.Background())
+	if err != nil {
+		// keep the current catalog, the sync manager reports the source errors
+		glog.Errorf("Failed to refresh connector catalog, keeping the current catalog: %v", err)
+		return
+	}
+
+	_, checksums := r.connectorsConfig.Catalog()
+	if equalChecksums(checksums, catalog.Checksums) {
+		return
+	}
+	glog.Infof("Connector catalog changed, refreshed %d connector types", len(catalog.Entries))
+	r.connectorsConfig.SetCatalog(catalog.Entries, catalog.Checksums)
+}
@@ -0,0 +1,110 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+)
+
+var _ workers.Worker = &ConnectorCatalogSyncManager{}
+
+// ConnectorCatalogSyncManager periodically reloads the connector catalog from its directories and remote sources,
+// and reconciles connector types when the catalog changes. The in-memory catalog of the other replicas is refreshed by
+// the ConnectorCatalogRefresher
+type ConnectorCatalogSyncManager struct {
+	workers.BaseWorker
+	connectorsConfig      *config.ConnectorsConfig
+	catalogSourcesService services.ConnectorCatalogSourcesService
+	connectorTypesService services.ConnectorTypesService
+	connectorTypeManager  *ConnectorTypeManager
+	lastRun               time.Time
+	// reconciled holds the checksums of the last catalog reconciled successfully, the in-memory catalog may already
+	// have been replaced by the refresher of this replica, so the first sync always reconciles
+	reconciled map[string]string
+}
+
+func NewConnectorCatalogSyncManager(connectorsConfig *config.ConnectorsConfig, catalogSourcesService services.ConnectorCatalogSourcesService,
+	connectorTypesService services.ConnectorTypesService, connectorTypeManager *ConnectorTypeManager,
+	reconciler workers.Reconciler) *ConnectorCatalogSyncManager {
+	return &ConnectorCatalogSyncManager{
+		BaseWorker: workers.BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: "connector_catalog_sync",
+			Reconciler: reconciler,
+		},
+		connectorsConfig:      connectorsConfig,
+		catalogSourcesService: catalogSourcesService,
+		connectorTypesService: connectorTypesService,
+		connectorTypeManager:  connectorTypeManager,
+	}
+}
+
+func (m *ConnectorCatalogSyncManager) Start() {
+	m.StartWorker(m)
+}
+
+func (m *ConnectorCatalogSyncManager) Stop() {
+	m.StopWorker(m)
+}
+
+func (m *ConnectorCatalogSyncManager) Reconcile() []error {
+	// the catalog loaded at startup is reconciled by the connector type manager first
+	if !m.connectorTypeManager.HasTerminated() {
+		return nil
+	}
+
+	now := time.Now()
+	if m.connectorsConfig.ConnectorCatalogSyncInterval <= 0 || now.Sub(m.lastRun) < m.connectorsConfig.ConnectorCatalogSyncInterval {
+		return nil
+	}
+	m.lastRun = now
+
+	glog.V(5).Infoln("Syncing connector catalog sources...")
+	var errs []error
+	catalog, err := m.connectorsConfig.LoadCatalog(context.Background())
+	if serr := m.catalogSourcesService.SaveStatus(context.Background(), catalog.Sources, now); serr != nil {
+		errs = append(errs, serr)
+	}
+	if err != nil {
+		// keep the current catalog, a missing source would otherwise delete or deprecate its connector types
+		glog.Errorf("Failed to sync connector catalog sources: %v", err)
+		return append(errs, err)
+	}
+
+	if m.reconciled != nil && equalChecksums(m.reconciled, catalog.Checksums) {
+		glog.V(5).Infoln("Connector catalog is up to date")
+		return errs
+	}
+
+	glog.Infof("Connector catalog changed, reconciling %d connector types", len(catalog.Entries))
+	m.connectorsConfig.SetCatalog(catalog.Entries, catalog.Checksums)
+
+	// same flow as the startup reconcile of the connector type manager
+	if err := m.connectorTypesService.DeleteOrDeprecateRemovedTypes(); err != nil {
+		return append(errs, err)
+	}
+	if err := m.connectorTypesService.ForEachConnectorCatalogEntry(m.connectorTypeManager.ReconcileConnectorCatalogEntry); err != nil {
+		return append(errs, err)
+	}
+	m.reconciled = catalog.Checksums
+
+	glog.V(5).Infoln("Connector catalog sync processed")
+	return errs
+}
+
+func equalChecksums(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for id, sum := range a {
+		if other, ok := b[id]; !ok || other != sum {
+			return false
+		}
+	}
+	return true
+}
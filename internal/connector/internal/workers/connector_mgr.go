@@ -26,6 +26,7 @@ type ConnectorManager struct {
 	connectorClusterService services.ConnectorClusterService
 	connectorTypesService   services.ConnectorTypesService
 	vaultService            vault.VaultService
+	revisionsService        services.ConnectorRevisionsService
 	lastVersion             int64
 	db                      *db.ConnectionFactory
 	ctx                     context.Context
@@ -37,6 +38,7 @@ func NewConnectorManager(
 	connectorService services.ConnectorsService,
 	connectorClusterService services.ConnectorClusterService,
 	vaultService vault.VaultService,
+	revisionsService services.ConnectorRevisionsService,
 	db *db.ConnectionFactory,
 	reconciler workers.Reconciler,
 ) *ConnectorManager {
@@ -50,6 +52,7 @@ func NewConnectorManager(
 		connectorClusterService: connectorClusterService,
 		connectorTypesService:   connectorTypesService,
 		vaultService:            vaultService,
+		revisionsService:        revisionsService,
 		db:                      db,
 	}
 
@@ -168,6 +171,11 @@ func (k *ConnectorManager) reconcileAssigning(ctx context.Context, connector *db
 		return errors.Wrapf(err, "failed to update connector status %s with namespace details", status.ID)
 	}
 
+	revision, err := k.revisionsService.Record(ctx, connector, shardMetadata.ID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to record revision of connector %s", connector.ID)
+	}
+
 	deployment := dbapi.ConnectorDeployment{
 		Model: db.Model{
 			ID: api.NewID(),
@@ -177,6 +185,7 @@ func (k *ConnectorManager) reconcileAssigning(ctx context.Context, connector *db
 		NamespaceID:              namespace.ID,
 		ConnectorVersion:         connector.Version,
 		ConnectorShardMetadataID: shardMetadata.ID,
+		ConfigRevision:           revision.Revision,
 		Status:                   dbapi.ConnectorDeploymentStatus{},
 	}
 
@@ -242,6 +251,12 @@ func (k *ConnectorManager) reconcileConnectorUpdate(ctx context.Context, connect
 		// we may need to update the deployment due to connector change.
 		if deployment.ConnectorVersion != connector.Version {
 			deployment.ConnectorVersion = connector.Version
+			// record a new revision if the connector configuration changed
+			revision, rerr := k.revisionsService.Record(ctx, connector, deployment.ConnectorShardMetadataID)
+			if rerr != nil {
+				return errors.Wrapf(rerr, "failed to record revision of connector %s", connector.ID)
+			}
+			deployment.ConfigRevision = revision.Revision
 			if serr = k.connectorClusterService.SaveDeployment(ctx, &deployment); serr != nil {
 				err = errors.Wrapf(serr, "failed to update connector version in deployment for connector %s", connector.ID)
 			}
@@ -0,0 +1,88 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+)
+
+var _ workers.Worker = &ConnectorScheduleManager{}
+
+// ConnectorScheduleManager stops and starts connectors at the beginning and the end of their stop windows
+type ConnectorScheduleManager struct {
+	workers.BaseWorker
+	schedulesService services.ConnectorSchedulesService
+	db               *db.ConnectionFactory
+	ctx              context.Context
+}
+
+func NewConnectorScheduleManager(schedulesService services.ConnectorSchedulesService, db *db.ConnectionFactory,
+	reconciler workers.Reconciler) *ConnectorScheduleManager {
+	return &ConnectorScheduleManager{
+		BaseWorker: workers.BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: "connector_schedule",
+			Reconciler: reconciler,
+		},
+		schedulesService: schedulesService,
+		db:               db,
+	}
+}
+
+func (m *ConnectorScheduleManager) Start() {
+	m.StartWorker(m)
+}
+
+func (m *ConnectorScheduleManager) Stop() {
+	m.StopWorker(m)
+}
+
+func (m *ConnectorScheduleManager) Reconcile() []error {
+	glog.V(5).Infoln("Reconciling connector schedules...")
+
+	if m.ctx == nil {
+		ctx, err := m.db.NewContext(context.Background())
+		if err != nil {
+			return []error{err}
+		}
+		m.ctx = ctx
+	}
+
+	now := time.Now()
+	schedules, serr := m.schedulesService.ListDue(now)
+	if serr != nil {
+		return []error{serr}
+	}
+
+	var errs []error
+	for _, schedule := range schedules {
+		if err := InDBTransaction(m.ctx, func(ctx context.Context) error {
+			if err := m.schedulesService.Apply(ctx, schedule, now); err != nil {
+				return err
+			}
+			return nil
+		}); err != nil {
+			glog.Errorf("Failed to apply schedule %s of connector %s: %v", schedule.ID, schedule.ConnectorID, err)
+			errs = append(errs, err)
+
+			// record the error in its own transaction, the schedule is retried on the next reconcile
+			schedule.Error = err.Error()
+			if err := InDBTransaction(m.ctx, func(ctx context.Context) error {
+				if err := m.schedulesService.Update(ctx, schedule); err != nil {
+					return err
+				}
+				return nil
+			}); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	glog.V(5).Infof("Reconciled %d connector schedules with %d errors", len(schedules), len(errs))
+	return errs
+}
@@ -0,0 +1,74 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+)
+
+var _ workers.Worker = &ConnectorSecretsGCManager{}
+
+// ConnectorSecretsGCManager periodically deletes the vault secrets of connectors
+// that don't exist anymore, or that aren't referenced by their connector anymore
+type ConnectorSecretsGCManager struct {
+	workers.BaseWorker
+	gcService        services.ConnectorSecretsGCService
+	connectorsConfig *config.ConnectorsConfig
+	lastRun          time.Time
+}
+
+func NewConnectorSecretsGCManager(gcService services.ConnectorSecretsGCService, connectorsConfig *config.ConnectorsConfig,
+	reconciler workers.Reconciler) *ConnectorSecretsGCManager {
+	return &ConnectorSecretsGCManager{
+		BaseWorker: workers.BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: "connector_secrets_gc",
+			Reconciler: reconciler,
+		},
+		gcService:        gcService,
+		connectorsConfig: connectorsConfig,
+	}
+}
+
+func (m *ConnectorSecretsGCManager) Start() {
+	m.StartWorker(m)
+}
+
+func (m *ConnectorSecretsGCManager) Stop() {
+	m.StopWorker(m)
+}
+
+func (m *ConnectorSecretsGCManager) Reconcile() []error {
+	// listing all the vault secrets is expensive, so it's done less often than the reconcile interval
+	now := time.Now()
+	if m.connectorsConfig.ConnectorSecretsGCInterval <= 0 || now.Sub(m.lastRun) < m.connectorsConfig.ConnectorSecretsGCInterval {
+		return nil
+	}
+	m.lastRun = now
+
+	glog.V(5).Infoln("Collecting orphaned connector secrets...")
+	result, err := m.gcService.CollectOrphanedSecrets(context.Background(), services.SecretsGCOptions{
+		DryRun:      m.connectorsConfig.ConnectorSecretsGCDryRun,
+		GracePeriod: m.connectorsConfig.ConnectorSecretsGCGracePeriod,
+		Now:         now,
+	})
+	if err != nil {
+		return []error{err}
+	}
+
+	if m.connectorsConfig.ConnectorSecretsGCDryRun {
+		for _, orphan := range result.Orphaned {
+			if orphan.Expired {
+				glog.Infof("Dry run, not deleting orphaned connector secret %s owned by %s: %s", orphan.Name, orphan.OwningResource, orphan.Reason)
+			}
+		}
+	}
+	glog.V(5).Infof("Scanned %d vault secrets, found %d orphaned connector secrets, deleted %d and failed to delete %d",
+		result.Scanned, len(result.Orphaned), result.Deleted, result.Failed)
+	return nil
+}
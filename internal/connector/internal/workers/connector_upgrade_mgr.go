@@ -0,0 +1,102 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+)
+
+var _ workers.Worker = &ConnectorUpgradeManager{}
+
+// ConnectorUpgradeManager applies channel and operator upgrades to connector deployments according to their upgrade policies,
+// and rolls back upgraded deployments that don't become healthy
+type ConnectorUpgradeManager struct {
+	workers.BaseWorker
+	upgradesService services.ConnectorUpgradesService
+	db              *db.ConnectionFactory
+	ctx             context.Context
+}
+
+func NewConnectorUpgradeManager(upgradesService services.ConnectorUpgradesService, db *db.ConnectionFactory,
+	reconciler workers.Reconciler) *ConnectorUpgradeManager {
+	return &ConnectorUpgradeManager{
+		BaseWorker: workers.BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: "connector_upgrade",
+			Reconciler: reconciler,
+		},
+		upgradesService: upgradesService,
+		db:              db,
+	}
+}
+
+func (m *ConnectorUpgradeManager) Start() {
+	m.StartWorker(m)
+}
+
+func (m *ConnectorUpgradeManager) Stop() {
+	m.StopWorker(m)
+}
+
+func (m *ConnectorUpgradeManager) Reconcile() []error {
+	glog.V(5).Infoln("Reconciling connector upgrades...")
+
+	if m.ctx == nil {
+		ctx, err := m.db.NewContext(context.Background())
+		if err != nil {
+			return []error{err}
+		}
+		m.ctx = ctx
+	}
+
+	now := time.Now()
+	var errs []error
+
+	// check upgrades in progress first, so that they count against the namespace rollout limits
+	upgrades, serr := m.upgradesService.ListInProgressUpgrades()
+	if serr != nil {
+		return []error{serr}
+	}
+	for _, upgrade := range upgrades {
+		if err := InDBTransaction(m.ctx, func(ctx context.Context) error {
+			if err := m.upgradesService.CheckUpgrade(ctx, upgrade, now); err != nil {
+				return err
+			}
+			return nil
+		}); err != nil {
+			glog.Errorf("Failed to check upgrade %s of connector %s: %v", upgrade.ID, upgrade.ConnectorID, err)
+			errs = append(errs, err)
+		}
+	}
+
+	deployments, serr := m.upgradesService.ListUpgradableDeployments()
+	if serr != nil {
+		return append(errs, serr)
+	}
+	applied := 0
+	for i := range deployments {
+		deployment := &deployments[i]
+		if err := InDBTransaction(m.ctx, func(ctx context.Context) error {
+			upgrade, err := m.upgradesService.ApplyUpgrade(ctx, deployment, now)
+			if err != nil {
+				return err
+			}
+			if upgrade != nil {
+				applied++
+				glog.Infof("Started %s upgrade %s of connector %s", upgrade.Kind, upgrade.ID, upgrade.ConnectorID)
+			}
+			return nil
+		}); err != nil {
+			glog.Errorf("Failed to upgrade deployment %s of connector %s: %v", deployment.ID, deployment.ConnectorID, err)
+			errs = append(errs, err)
+		}
+	}
+
+	glog.V(5).Infof("Checked %d connector upgrades and started %d upgrades with %d errors", len(upgrades), applied, len(errs))
+	return errs
+}
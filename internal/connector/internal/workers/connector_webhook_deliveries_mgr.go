@@ -0,0 +1,11 @@
+package workers
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+)
+
+// NewConnectorWebhookDeliveriesManager creates a worker that sends the pending webhook deliveries of the connector_mgmt API
+func NewConnectorWebhookDeliveriesManager(webhookService webhooks.WebhookService, reconciler workers.Reconciler) *webhooks.DeliveryWorker {
+	return webhooks.NewDeliveryWorker("connector_webhook_deliveries", webhooks.ServiceConnectors, webhookService, reconciler)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
 	environments2 "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/providers"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/encryption"
 	coreWorkers "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
 
 	"github.com/goava/di"
@@ -24,9 +25,11 @@ func ConfigProviders(kafkaEnabled bool) di.Option {
 	result := di.Options(
 		di.Provide(config.NewConnectorsConfig, di.As(new(environments2.ConfigModule))),
 		di.Provide(config.NewConnectorsQuotaConfig, di.As(new(environments2.ConfigModule))),
+		di.Provide(config.NewConnectorMetricsConfig, di.As(new(environments2.ConfigModule))),
 		di.Provide(environments2.Func(serviceProviders)),
 		di.Provide(migrations.New),
 		di.Provide(cmdvault.NewVaultCommand),
+		di.ProvideValue(encryption.EncryptedColumn{Table: "connector_clusters", Column: "client_secret"}),
 	)
 
 	// If we are not running in the kas-fleet-manager.. we need to inject more types into the DI container
@@ -53,17 +56,36 @@ func serviceProviders() di.Option {
 		di.Provide(services.NewConnectorTypesService, di.As(new(services.ConnectorTypesService))),
 		di.Provide(services.NewConnectorClusterService, di.As(new(services.ConnectorClusterService)), di.As(new(auth.AuthAgentService))),
 		di.Provide(services.NewConnectorNamespaceService, di.As(new(services.ConnectorNamespaceService))),
+		di.Provide(services.NewConnectorSchedulesService, di.As(new(services.ConnectorSchedulesService))),
+		di.Provide(services.NewConnectorRevisionsService, di.As(new(services.ConnectorRevisionsService))),
+		di.Provide(services.NewConnectorUpgradesService, di.As(new(services.ConnectorUpgradesService))),
+		di.Provide(services.NewConnectorSecretsGCService, di.As(new(services.ConnectorSecretsGCService))),
+		di.Provide(services.NewConnectorCatalogSourcesService, di.As(new(services.ConnectorCatalogSourcesService))),
+		di.Provide(services.NewConnectorLogsService, di.As(new(services.ConnectorLogsService))),
+		di.Provide(services.NewConnectorMetricsService, di.As(new(services.ConnectorMetricsService))),
+		di.Provide(services.NewConnectorTemplatesService, di.As(new(services.ConnectorTemplatesService))),
+		di.Provide(services.NewConnectorNamespaceRoleBindingsService, di.As(new(services.ConnectorNamespaceRoleBindingsService))),
 		di.Provide(authz.NewAuthZService, di.As(new(authz.AuthZService))),
 		di.Provide(handlers.NewConnectorNamespaceHandler),
 		di.Provide(handlers.NewConnectorAdminHandler),
 		di.Provide(handlers.NewConnectorTypesHandler),
 		di.Provide(handlers.NewConnectorsHandler),
+		di.Provide(handlers.NewConnectorLifecycleHandler),
 		di.Provide(handlers.NewConnectorClusterHandler),
+		di.Provide(handlers.NewConnectorObservabilityHandler),
+		di.Provide(handlers.NewConnectorTemplatesHandler),
 		di.Provide(routes.NewRouteLoader),
 		di.Provide(workers.NewConnectorTypeManager, di.As(new(coreWorkers.Worker))),
 		di.Provide(workers.NewClusterManager, di.As(new(coreWorkers.Worker))),
 		di.Provide(workers.NewConnectorManager, di.As(new(coreWorkers.Worker))),
 		di.Provide(workers.NewNamespaceManager, di.As(new(coreWorkers.Worker))),
+		di.Provide(workers.NewConnectorScheduleManager, di.As(new(coreWorkers.Worker))),
+		di.Provide(workers.NewConnectorUpgradeManager, di.As(new(coreWorkers.Worker))),
+		di.Provide(workers.NewConnectorSecretsGCManager, di.As(new(coreWorkers.Worker))),
+		di.Provide(workers.NewConnectorCatalogSyncManager, di.As(new(coreWorkers.Worker))),
+		di.Provide(workers.NewConnectorCatalogRefresher, di.As(new(environments2.BootService))),
+		di.Provide(workers.NewConnectorAuditEventsRetentionManager, di.As(new(coreWorkers.Worker))),
+		di.Provide(workers.NewConnectorWebhookDeliveriesManager, di.As(new(coreWorkers.Worker))),
 		di.Provide(workers.NewApiServerReadyCondition),
 	)
 }
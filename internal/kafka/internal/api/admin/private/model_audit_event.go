@@ -0,0 +1,43 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+import (
+	"time"
+)
+
+// AuditEvent A request recorded in the audit trail. The secrets and connector specs of the request body and diff are redacted
+type AuditEvent struct {
+	Id        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	Service   string    `json:"service"`
+	// The username of the user or service account that sent the request
+	Actor          string `json:"actor"`
+	OrganisationId string `json:"organisation_id,omitempty"`
+	// The subset of the token claims identifying the actor
+	Claims map[string]interface{} `json:"claims,omitempty"`
+	Method string                 `json:"method"`
+	// The route template of the request, e.g. /api/kafkas_mgmt/v1/kafkas/{id}
+	Route string `json:"route"`
+	Path  string `json:"path"`
+	// The id of the resource the request acted on
+	ResourceId string `json:"resource_id,omitempty"`
+	// The redacted request body
+	RequestBody map[string]interface{} `json:"request_body,omitempty"`
+	// The old and new values of the fields changed by the request, nested fields are separated by a '.'
+	Diff        map[string]AuditChange `json:"diff,omitempty"`
+	StatusCode  int32                  `json:"status_code"`
+	Outcome     string                 `json:"outcome"`
+	OperationId string                 `json:"operation_id,omitempty"`
+	RemoteAddr  string                 `json:"remote_addr,omitempty"`
+	// The decisions of the authorizer of the request
+	Authorization []AuthorizationDecision `json:"authorization,omitempty"`
+}
@@ -0,0 +1,22 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// AuthorizationDecision The decision of the authorizer on an action of the actor
+type AuthorizationDecision struct {
+	// The engine of the decision, default or opa
+	Engine       string `json:"engine"`
+	Action       string `json:"action"`
+	ResourceType string `json:"resource_type"`
+	ResourceId   string `json:"resource_id,omitempty"`
+	Allowed      bool   `json:"allowed"`
+	Reason       string `json:"reason,omitempty"`
+}
@@ -0,0 +1,53 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+import (
+	"time"
+)
+
+// DataPlaneCluster struct for DataPlaneCluster
+type DataPlaneCluster struct {
+	Id        string `json:"id"`
+	Kind      string `json:"kind"`
+	Href      string `json:"href"`
+	ClusterId string `json:"cluster_id"`
+	// Values: [cluster_accepted, cluster_provisioning, cluster_provisioned, waiting_for_kas_fleetshard_operator, ready, full, failed, deprovisioning, cleanup]
+	Status string `json:"status,omitempty"`
+	// Name of Cloud used to deploy. For example AWS
+	CloudProvider string `json:"cloud_provider,omitempty"`
+	// Values will be regions of specific cloud provider. For example: us-east-1 for AWS
+	Region  string `json:"region,omitempty"`
+	MultiAz bool   `json:"multi_az"`
+	// Values: [managed, enterprise]
+	ClusterType string `json:"cluster_type,omitempty"`
+	// Values: [ocm, aws_eks, standalone]
+	ProviderType string `json:"provider_type,omitempty"`
+	// The organization owning the cluster. Only set for enterprise clusters
+	OrganizationId string `json:"organization_id,omitempty"`
+	// Comma separated list of the kafka instance types supported by the cluster
+	SupportedInstanceType string `json:"supported_instance_type,omitempty"`
+	// The capacity information per kafka instance type reported by the data plane
+	DynamicCapacityInfo map[string]DataPlaneClusterDynamicCapacityInfo `json:"dynamic_capacity_info,omitempty"`
+	// The strimzi versions available in the cluster
+	AvailableStrimziVersions []DataPlaneClusterStrimziVersion `json:"available_strimzi_versions,omitempty"`
+	// Number of kafkas hosted in the cluster. Kafkas in deleting state are not included
+	KafkaCount int32 `json:"kafka_count"`
+	// The number of streaming units consumed per kafka instance type. Only returned when getting a single cluster
+	ConsumedStreamingUnits map[string]int32 `json:"consumed_streaming_units,omitempty"`
+	// Whether new kafkas can be placed in the cluster
+	PlacementEligible bool `json:"placement_eligible"`
+	// Whether the cluster has been cordoned. A cordoned cluster does not accept any new kafka
+	Cordoned    bool                         `json:"cordoned"`
+	DrainStatus *DataPlaneClusterDrainStatus `json:"drain_status,omitempty"`
+	CreatedAt   time.Time                    `json:"created_at,omitempty"`
+	UpdatedAt   time.Time                    `json:"updated_at,omitempty"`
+}
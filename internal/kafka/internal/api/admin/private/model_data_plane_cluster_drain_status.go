@@ -0,0 +1,33 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+import (
+	"time"
+)
+
+// DataPlaneClusterDrainStatus The progress of the drain of a data plane cluster
+type DataPlaneClusterDrainStatus struct {
+	// Whether kafkas are still being moved out of the cluster
+	Draining    bool       `json:"draining"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	// IDs of the kafkas currently being moved to another cluster
+	MigratingKafkaIds []string `json:"migrating_kafka_ids,omitempty"`
+	// Number of kafkas that have been successfully moved to another cluster
+	MigratedKafkasCount int32 `json:"migrated_kafkas_count"`
+	// Number of kafkas still placed in the cluster
+	RemainingKafkasCount int32 `json:"remaining_kafkas_count"`
+	// IDs of the kafkas that failed while being moved to another cluster
+	FailedKafkaIds []string `json:"failed_kafka_ids,omitempty"`
+	// The last error encountered while draining the cluster
+	LastError string `json:"last_error,omitempty"`
+}
@@ -0,0 +1,18 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// DataPlaneClusterDynamicCapacityInfo struct for DataPlaneClusterDynamicCapacityInfo
+type DataPlaneClusterDynamicCapacityInfo struct {
+	MaxNodes       int32 `json:"max_nodes"`
+	MaxUnits       int32 `json:"max_units"`
+	RemainingUnits int32 `json:"remaining_units"`
+}
@@ -0,0 +1,17 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// DataPlaneClusterUpdateRequest struct for DataPlaneClusterUpdateRequest
+type DataPlaneClusterUpdateRequest struct {
+	// The action to perform on the cluster. Values: [cordon, uncordon, mark_full, mark_ready, deprovision_empty]
+	Action string `json:"action"`
+}
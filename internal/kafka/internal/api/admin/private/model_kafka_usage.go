@@ -0,0 +1,27 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// KafkaUsage The usage of the kafkas of an organisation on a given day
+type KafkaUsage struct {
+	// The UTC day of the usage, in the YYYY-MM-DD format
+	Date                  string `json:"date"`
+	OrganisationId        string `json:"organisation_id"`
+	InstanceType          string `json:"instance_type"`
+	SizeId                string `json:"size_id"`
+	BillingModel          string `json:"billing_model"`
+	Marketplace           string `json:"marketplace,omitempty"`
+	BillingCloudAccountId string `json:"billing_cloud_account_id,omitempty"`
+	// The number of kafkas of the organisation with the same instance type, size and billing
+	KafkaCount int32 `json:"kafka_count"`
+	// The number of streaming units consumed by these kafkas
+	StreamingUnits int32 `json:"streaming_units"`
+}
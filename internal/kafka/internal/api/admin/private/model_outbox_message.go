@@ -0,0 +1,35 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+import (
+	"time"
+)
+
+// OutboxMessage An external side effect, recorded in the transaction changing the state requiring it and executed by the outbox worker
+type OutboxMessage struct {
+	Id   string `json:"id"`
+	Kind string `json:"kind"`
+	// The type of the side effect, e.g. kafka.delete_quota
+	Type string `json:"type"`
+	// Identifies the side effect, a message is only enqueued once for a given key
+	IdempotencyKey string `json:"idempotency_key"`
+	// The parameters of the side effect
+	Payload       map[string]interface{} `json:"payload,omitempty"`
+	Status        string                 `json:"status"`
+	Attempts      int32                  `json:"attempts"`
+	NextAttemptAt time.Time              `json:"next_attempt_at,omitempty"`
+	LastAttemptAt time.Time              `json:"last_attempt_at,omitempty"`
+	LastError     string                 `json:"last_error,omitempty"`
+	ProcessedAt   time.Time              `json:"processed_at,omitempty"`
+	CreatedAt     time.Time              `json:"created_at"`
+	UpdatedAt     time.Time              `json:"updated_at"`
+}
@@ -0,0 +1,18 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// PlacementSimulation struct for PlacementSimulation
+type PlacementSimulation struct {
+	Kind              string                         `json:"kind"`
+	Placements        []PlacementSimulationPlacement `json:"placements"`
+	ProjectedScaleUps []PlacementSimulationScaleUp   `json:"projected_scale_ups"`
+}
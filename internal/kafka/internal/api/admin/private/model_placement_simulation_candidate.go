@@ -0,0 +1,20 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// PlacementSimulationCandidate struct for PlacementSimulationCandidate
+type PlacementSimulationCandidate struct {
+	ClusterId    string           `json:"cluster_id"`
+	Eligible     bool             `json:"eligible"`
+	Score        int64            `json:"score"`
+	PluginScores map[string]int64 `json:"plugin_scores,omitempty"`
+	Reasons      []string         `json:"reasons,omitempty"`
+}
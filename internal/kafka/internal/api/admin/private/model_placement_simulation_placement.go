@@ -0,0 +1,31 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// PlacementSimulationPlacement struct for PlacementSimulationPlacement
+type PlacementSimulationPlacement struct {
+	// The index of the kafka once the kafkas of the request have been expanded according to their count
+	Index         int32  `json:"index"`
+	CloudProvider string `json:"cloud_provider"`
+	Region        string `json:"region"`
+	MultiAz       bool   `json:"multi_az"`
+	InstanceType  string `json:"instance_type"`
+	SizeId        string `json:"size_id"`
+	Placed        bool   `json:"placed"`
+	// The ID of the cluster the kafka would be placed in
+	ClusterId string `json:"cluster_id,omitempty"`
+	// Whether the cluster the kafka would be placed in would be created by the dynamic scaling
+	OnProjectedCluster bool   `json:"on_projected_cluster"`
+	Reason             string `json:"reason"`
+	// The sizes of the instance type that could be created in the region when the kafka was placed
+	AvailableSizes []string                       `json:"available_sizes"`
+	Candidates     []PlacementSimulationCandidate `json:"candidates,omitempty"`
+}
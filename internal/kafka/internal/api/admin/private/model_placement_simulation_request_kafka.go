@@ -0,0 +1,24 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// PlacementSimulationRequestKafka struct for PlacementSimulationRequestKafka
+type PlacementSimulationRequestKafka struct {
+	// The cloud provider of the kafka. Defaults to aws
+	CloudProvider string `json:"cloud_provider,omitempty"`
+	Region        string `json:"region"`
+	// Whether the kafka is multi availability zones. Defaults to true for the standard instance type and to false otherwise
+	MultiAz      *bool  `json:"multi_az,omitempty"`
+	InstanceType string `json:"instance_type"`
+	SizeId       string `json:"size_id"`
+	// The number of identical kafkas to place. Defaults to 1
+	Count int32 `json:"count,omitempty"`
+}
@@ -0,0 +1,23 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// PlacementSimulationScaleUp struct for PlacementSimulationScaleUp
+type PlacementSimulationScaleUp struct {
+	// The ID given to the cluster within the simulation
+	ClusterId     string `json:"cluster_id"`
+	CloudProvider string `json:"cloud_provider"`
+	Region        string `json:"region"`
+	MultiAz       bool   `json:"multi_az"`
+	InstanceType  string `json:"instance_type"`
+	// The capacity of the cluster, estimated from the biggest ready cluster of the same cloud provider, region and instance type. 0 if there is no such cluster
+	EstimatedMaxStreamingUnits int32 `json:"estimated_max_streaming_units"`
+}
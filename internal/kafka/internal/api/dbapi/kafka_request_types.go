@@ -13,26 +13,27 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/kafkas/types"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db/dbencryption"
 	"gorm.io/gorm"
 )
 
 type KafkaRequest struct {
 	api.Meta
-	Region                           string `json:"region"`
-	ClusterID                        string `json:"cluster_id" gorm:"index"`
-	CloudProvider                    string `json:"cloud_provider"`
-	MultiAZ                          bool   `json:"multi_az"`
-	Name                             string `json:"name" gorm:"index"`
-	Status                           string `json:"status" gorm:"index"`
-	CanaryServiceAccountClientID     string `json:"canary_service_account_client_id"`
-	CanaryServiceAccountClientSecret string `json:"canary_service_account_client_secret"`
-	SubscriptionId                   string `json:"subscription_id"`
-	Owner                            string `json:"owner" gorm:"index"` // TODO: ocm owner?
-	OwnerAccountId                   string `json:"owner_account_id"`
-	BootstrapServerHost              string `json:"bootstrap_server_host"`
-	AdminApiServerURL                string `json:"admin_api_server_url"`
-	OrganisationId                   string `json:"organisation_id" gorm:"index"`
-	FailedReason                     string `json:"failed_reason"`
+	Region                           string                       `json:"region"`
+	ClusterID                        string                       `json:"cluster_id" gorm:"index"`
+	CloudProvider                    string                       `json:"cloud_provider"`
+	MultiAZ                          bool                         `json:"multi_az"`
+	Name                             string                       `json:"name" gorm:"index"`
+	Status                           string                       `json:"status" gorm:"index"`
+	CanaryServiceAccountClientID     string                       `json:"canary_service_account_client_id"`
+	CanaryServiceAccountClientSecret dbencryption.EncryptedString `json:"canary_service_account_client_secret"`
+	SubscriptionId                   string                       `json:"subscription_id"`
+	Owner                            string                       `json:"owner" gorm:"index"` // TODO: ocm owner?
+	OwnerAccountId                   string                       `json:"owner_account_id"`
+	BootstrapServerHost              string                       `json:"bootstrap_server_host"`
+	AdminApiServerURL                string                       `json:"admin_api_server_url"`
+	OrganisationId                   string                       `json:"organisation_id" gorm:"index"`
+	FailedReason                     string                       `json:"failed_reason"`
 	// PlacementId field should be updated every time when a KafkaRequest is assigned to an OSD cluster (even if it's the same one again)
 	PlacementId string `json:"placement_id"`
 
@@ -75,6 +76,34 @@ type KafkaRequest struct {
 	KafkasRoutesBaseDomainTLSKeyRef string
 	// KafkasRoutesBaseDomainTLSCrtRef is the key referencing the TLS certificate crt (public part of the certificate) for the base kafka domain
 	KafkasRoutesBaseDomainTLSCrtRef string
+	// PlacementDecision records how the data plane cluster of the kafka instance has been selected the last time it was placed.
+	// Use the `SetPlacementDecision` and `GetPlacementDecision` helper methods to set and read it.
+	PlacementDecision api.JSON `json:"placement_decision"`
+}
+
+// KafkaPlacementDecision describes the selection of the data plane cluster of a kafka instance
+type KafkaPlacementDecision struct {
+	// Strategy is the name of the placement strategy that took the decision
+	Strategy string `json:"strategy"`
+	// ClusterID is the ID of the selected cluster. It is empty when no cluster could be selected
+	ClusterID string `json:"cluster_id"`
+	// Reason summarises why the cluster has been selected or why no cluster could be selected
+	Reason     string                    `json:"reason"`
+	DecidedAt  time.Time                 `json:"decided_at"`
+	Candidates []KafkaPlacementCandidate `json:"candidates"`
+}
+
+// KafkaPlacementCandidate describes how a data plane cluster has been evaluated during a placement
+type KafkaPlacementCandidate struct {
+	ClusterID string `json:"cluster_id"`
+	// Eligible is false if the cluster has been rejected by a filter plugin
+	Eligible bool `json:"eligible"`
+	// Score is the weighted sum of the scores given by the score plugins
+	Score int64 `json:"score"`
+	// PluginScores are the scores, between 0 and 100, given by each enabled score plugin
+	PluginScores map[string]int64 `json:"plugin_scores,omitempty"`
+	// Reasons explain why the cluster has been rejected or how it has been scored
+	Reasons []string `json:"reasons,omitempty"`
 }
 
 type KafkaPromotionStatus string
@@ -146,6 +175,31 @@ func (k *KafkaRequest) SetRoutes(routes []DataPlaneKafkaRoute) error {
 	}
 }
 
+// GetPlacementDecision returns the last placement decision of the kafka or nil if the kafka has never been placed
+func (k *KafkaRequest) GetPlacementDecision() (*KafkaPlacementDecision, error) {
+	if k.PlacementDecision == nil {
+		return nil, nil
+	}
+
+	var decision KafkaPlacementDecision
+	if err := json.Unmarshal(k.PlacementDecision, &decision); err != nil {
+		return nil, err
+	}
+
+	return &decision, nil
+}
+
+// SetPlacementDecision sets the placement decision of the kafka into a json object that can be persisted in the database
+func (k *KafkaRequest) SetPlacementDecision(decision KafkaPlacementDecision) error {
+	d, err := json.Marshal(decision)
+	if err != nil {
+		return err
+	}
+
+	k.PlacementDecision = d
+	return nil
+}
+
 // GetExpirationTime returns when the Kafka request will expire based on the
 // provided lifespanSeconds value. lifespanSeconds is assumed to be greater
 // than 0
@@ -0,0 +1,31 @@
+package dbapi
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+)
+
+// KafkaUsageDaily is the usage of the kafkas of an organisation on a given day
+// for a combination of instance type, size, billing model, marketplace and billing cloud account
+type KafkaUsageDaily struct {
+	api.Meta
+	// UsageDate is the UTC day of the usage
+	UsageDate             time.Time `json:"usage_date" gorm:"type:date"`
+	OrganisationId        string    `json:"organisation_id"`
+	InstanceType          string    `json:"instance_type"`
+	SizeId                string    `json:"size_id"`
+	BillingModel          string    `json:"billing_model"`
+	Marketplace           string    `json:"marketplace"`
+	BillingCloudAccountId string    `json:"billing_cloud_account_id"`
+	// KafkaCount is the number of kafkas of the organisation at the time of the snapshot
+	KafkaCount int `json:"kafka_count"`
+	// StreamingUnits is the number of streaming units consumed by the kafkas of the organisation at the time of the snapshot
+	StreamingUnits int `json:"streaming_units"`
+}
+
+type KafkaUsageDailyList []*KafkaUsageDaily
+
+func (KafkaUsageDaily) TableName() string {
+	return "kafka_usage_daily"
+}
@@ -0,0 +1,54 @@
+package dbapi
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db/dbencryption"
+)
+
+// Statuses of the service account lifecycles
+const (
+	ServiceAccountLifecycleActive  = "active"
+	ServiceAccountLifecycleRevoked = "revoked"
+)
+
+// ServiceAccountLifecycle tracks the expiry and the usage of a service account of the SSO, its ID is the id of the service account
+type ServiceAccountLifecycle struct {
+	api.Meta
+	ClientId       string `gorm:"index"`
+	OrganisationId string `gorm:"index"`
+	Owner          string
+	// ExpiresAt is the optional expiry of the service account, its credentials are revoked once expired
+	ExpiresAt *time.Time
+	// CredentialsRotatedAt is the time the current secret was generated, the max credential age of the organisation starts from it
+	CredentialsRotatedAt time.Time
+	LastUsedAt           *time.Time
+	// ExpiryWarnedAt is set once the organisation is warned of the upcoming expiry of the current credentials
+	ExpiryWarnedAt *time.Time
+	Status         string `gorm:"index"`
+	// RotatedClientSecret is the secret generated by the last rotation of the expired credentials, it's returned to the owner of
+	// the service account until the credentials are reset or revoked
+	RotatedClientSecret dbencryption.EncryptedString
+	// CredentialsExpireAt and ExpiryAction are set from the policy of the organisation when the lifecycle is loaded
+	CredentialsExpireAt *time.Time `gorm:"-"`
+	ExpiryAction        string     `gorm:"-"`
+}
+
+// Expiry returns when the service account or its credentials expire given the policy of its organisation,
+// and the action applied to the credentials then. It returns nil when nothing expires.
+func (l *ServiceAccountLifecycle) Expiry(policy config.ServiceAccountLifecyclePolicy) (*time.Time, string) {
+	var expiry *time.Time
+	action := ""
+	if policy.MaxCredentialAge > 0 {
+		credentialsExpiry := l.CredentialsRotatedAt.Add(policy.MaxCredentialAge)
+		expiry = &credentialsExpiry
+		action = policy.Action
+	}
+	if l.ExpiresAt != nil && (expiry == nil || !l.ExpiresAt.After(*expiry)) {
+		expiry = l.ExpiresAt
+		action = config.ServiceAccountExpiryActionRevoke
+	}
+	return expiry, action
+}
@@ -0,0 +1,67 @@
+package dbapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/onsi/gomega"
+)
+
+func TestServiceAccountLifecycle_Expiry(t *testing.T) {
+	rotatedAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	credentialsExpiry := rotatedAt.Add(90 * 24 * time.Hour)
+	earlyExpiry := rotatedAt.Add(30 * 24 * time.Hour)
+	lateExpiry := rotatedAt.Add(365 * 24 * time.Hour)
+	rotatePolicy := config.ServiceAccountLifecyclePolicy{MaxCredentialAge: 90 * 24 * time.Hour, Action: config.ServiceAccountExpiryActionRotate}
+
+	tests := []struct {
+		name       string
+		expiresAt  *time.Time
+		policy     config.ServiceAccountLifecyclePolicy
+		wantExpiry *time.Time
+		wantAction string
+	}{
+		{
+			name: "should return no expiry without expiry nor max credential age",
+		},
+		{
+			name:       "should return the expiry of the credentials given the max credential age",
+			policy:     rotatePolicy,
+			wantExpiry: &credentialsExpiry,
+			wantAction: config.ServiceAccountExpiryActionRotate,
+		},
+		{
+			name:       "should revoke the credentials of the service account at its expiry without max credential age",
+			expiresAt:  &lateExpiry,
+			wantExpiry: &lateExpiry,
+			wantAction: config.ServiceAccountExpiryActionRevoke,
+		},
+		{
+			name:       "should revoke the credentials of the service account if it expires before its credentials",
+			expiresAt:  &earlyExpiry,
+			policy:     rotatePolicy,
+			wantExpiry: &earlyExpiry,
+			wantAction: config.ServiceAccountExpiryActionRevoke,
+		},
+		{
+			name:       "should apply the policy if the credentials expire before the service account",
+			expiresAt:  &lateExpiry,
+			policy:     rotatePolicy,
+			wantExpiry: &credentialsExpiry,
+			wantAction: config.ServiceAccountExpiryActionRotate,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			t.Parallel()
+			lifecycle := &ServiceAccountLifecycle{CredentialsRotatedAt: rotatedAt, ExpiresAt: tt.expiresAt}
+			expiry, action := lifecycle.Expiry(tt.policy)
+			g.Expect(expiry).To(gomega.Equal(tt.wantExpiry))
+			g.Expect(action).To(gomega.Equal(tt.wantAction))
+		})
+	}
+}
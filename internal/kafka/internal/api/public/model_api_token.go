@@ -0,0 +1,34 @@
+/*
+ * Kafka Management API
+ *
+ * Kafka Management API is a REST API to manage Kafka instances
+ *
+ * API version: 1.15.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+import (
+	"time"
+)
+
+// ApiToken An opaque API token, sent as a bearer token. It's only allowed on the routes matching its scopes and is rejected once it's expired or revoked.
+type ApiToken struct {
+	Id     string   `json:"id"`
+	Kind   string   `json:"kind"`
+	Href   string   `json:"href"`
+	Name   string   `json:"name"`
+	Type   string   `json:"type"`
+	Scopes []string `json:"scopes"`
+	// The user who created the token
+	Owner string `json:"owner,omitempty"`
+	// The beginning of the token, to recognize it
+	TokenPrefix string    `json:"token_prefix,omitempty"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	LastUsedAt  time.Time `json:"last_used_at,omitempty"`
+	CreatedAt   time.Time `json:"created_at,omitempty"`
+	// The token, it's only returned when the token is created
+	Token string `json:"token,omitempty"`
+}
@@ -0,0 +1,26 @@
+/*
+ * Kafka Management API
+ *
+ * Kafka Management API is a REST API to manage Kafka instances
+ *
+ * API version: 1.15.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+import (
+	"time"
+)
+
+// ApiTokenRequest struct for ApiTokenRequest
+type ApiTokenRequest struct {
+	Name string `json:"name"`
+	// The type of the token, personal by default
+	Type string `json:"type,omitempty"`
+	// The scopes of the token, a write scope grants the read scope of the same resources
+	Scopes []string `json:"scopes"`
+	// The expiry of the token, it defaults to the default lifetime of the tokens and must not exceed their maximum lifetime
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+}
@@ -17,15 +17,26 @@ import (
 // ServiceAccount Service Account created in MAS-SSO for the Kafka Cluster for authentication
 type ServiceAccount struct {
 	// server generated unique id of the service account
-	Id           string `json:"id"`
-	Kind         string `json:"kind"`
-	Href         string `json:"href"`
-	Name         string `json:"name,omitempty"`
-	Description  string `json:"description,omitempty"`
-	ClientId     string `json:"client_id,omitempty"`
+	Id          string `json:"id"`
+	Kind        string `json:"kind"`
+	Href        string `json:"href"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	ClientId    string `json:"client_id,omitempty"`
+	// secret of the service account, returned at its creation, when its credentials are reset, and to its owner once its expired credentials are rotated
 	ClientSecret string `json:"client_secret,omitempty"`
 	// Deprecated
 	DeprecatedOwner string    `json:"owner,omitempty"`
 	CreatedBy       string    `json:"created_by,omitempty"`
 	CreatedAt       time.Time `json:"created_at,omitempty"`
+	// expiry of the service account, its credentials are revoked once expired
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// expiry of the current credentials, given the expiry of the service account and the maximum credential age of the organisation
+	CredentialsExpireAt *time.Time `json:"credentials_expire_at,omitempty"`
+	// time the current credentials were generated
+	CredentialsRotatedAt *time.Time `json:"credentials_rotated_at,omitempty"`
+	// last time the service account was used to call the fleet manager API, the use of its credentials against the Kafka instances isn't recorded
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	// status of the service account, the credentials of a revoked service account can not be reset
+	Status string `json:"status,omitempty"`
 }
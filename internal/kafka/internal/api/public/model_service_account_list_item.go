@@ -33,4 +33,14 @@ type ServiceAccountListItem struct {
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	// description of the service account
 	Description string `json:"description,omitempty"`
+	// expiry of the service account, its credentials are revoked once expired
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// expiry of the current credentials, given the expiry of the service account and the maximum credential age of the organisation
+	CredentialsExpireAt *time.Time `json:"credentials_expire_at,omitempty"`
+	// time the current credentials were generated
+	CredentialsRotatedAt *time.Time `json:"credentials_rotated_at,omitempty"`
+	// last time the service account was used to call the fleet manager API, the use of its credentials against the Kafka instances isn't recorded
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	// status of the service account, the credentials of a revoked service account can not be reset
+	Status string `json:"status,omitempty"`
 }
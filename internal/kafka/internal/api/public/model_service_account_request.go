@@ -10,10 +10,16 @@
 
 package public
 
+import (
+	"time"
+)
+
 // ServiceAccountRequest Schema for the request to create a service account
 type ServiceAccountRequest struct {
 	// The name of the service account
 	Name string `json:"name"`
 	// A description for the service account
 	Description string `json:"description,omitempty"`
+	// Optional expiry of the service account, it must be in the future. Its credentials are revoked once expired.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
 }
@@ -0,0 +1,30 @@
+/*
+ * Kafka Management API
+ *
+ * Kafka Management API is a REST API to manage Kafka instances
+ *
+ * API version: 1.15.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+import (
+	"time"
+)
+
+// WebhookSubscription A subscription of an organisation to the state change events of its resources
+type WebhookSubscription struct {
+	Id   string `json:"id"`
+	Kind string `json:"kind"`
+	Href string `json:"href"`
+	// The URL the events are posted to
+	Url        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	Owner      string    `json:"owner,omitempty"`
+	CreatedAt  time.Time `json:"created_at,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at,omitempty"`
+	// The secret of the HMAC-SHA256 signature of the deliveries, in the X-Webhook-Signature header. It's only returned when the subscription is created.
+	Secret string `json:"secret,omitempty"`
+}
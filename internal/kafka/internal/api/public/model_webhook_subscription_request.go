@@ -0,0 +1,20 @@
+/*
+ * Kafka Management API
+ *
+ * Kafka Management API is a REST API to manage Kafka instances
+ *
+ * API version: 1.15.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// WebhookSubscriptionRequest struct for WebhookSubscriptionRequest
+type WebhookSubscriptionRequest struct {
+	// An https URL the events are posted to
+	Url        string   `json:"url,omitempty"`
+	EventTypes []string `json:"event_types,omitempty"`
+	// The secret of the signature of the deliveries, generated when it isn't set on creation
+	Secret string `json:"secret,omitempty"`
+}
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"fmt"
+)
+
+const (
+	defaultMaxConcurrentKafkaMigrationsPerCluster = 1
+	defaultMaxConcurrentKafkaMigrations           = 5
+)
+
+// ClusterDrainConfig contains the configuration used to move the kafkas out of a cordoned data plane cluster
+type ClusterDrainConfig struct {
+	// MaxConcurrentKafkaMigrationsPerCluster is the maximum number of kafkas of a single draining cluster
+	// that can be moved to other clusters at the same time
+	MaxConcurrentKafkaMigrationsPerCluster int
+	// MaxConcurrentKafkaMigrations is the maximum number of kafkas that can be moved out of all the
+	// draining clusters at the same time
+	MaxConcurrentKafkaMigrations int
+}
+
+func NewClusterDrainConfig() ClusterDrainConfig {
+	return ClusterDrainConfig{
+		MaxConcurrentKafkaMigrationsPerCluster: defaultMaxConcurrentKafkaMigrationsPerCluster,
+		MaxConcurrentKafkaMigrations:           defaultMaxConcurrentKafkaMigrations,
+	}
+}
+
+func (c *ClusterDrainConfig) validate() error {
+	if c.MaxConcurrentKafkaMigrationsPerCluster <= 0 {
+		return fmt.Errorf("cluster drain max concurrent kafka migrations per cluster must be greater than 0, got %d", c.MaxConcurrentKafkaMigrationsPerCluster)
+	}
+
+	if c.MaxConcurrentKafkaMigrations < c.MaxConcurrentKafkaMigrationsPerCluster {
+		return fmt.Errorf("cluster drain max concurrent kafka migrations (%d) must be greater than or equal to the max concurrent kafka migrations per cluster (%d)", c.MaxConcurrentKafkaMigrations, c.MaxConcurrentKafkaMigrationsPerCluster)
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func Test_ClusterDrainConfig_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  ClusterDrainConfig
+		wantErr bool
+	}{
+		{
+			name:    "should not return an error for the default configuration",
+			config:  NewClusterDrainConfig(),
+			wantErr: false,
+		},
+		{
+			name: "should return an error when the max concurrent migrations per cluster is not positive",
+			config: ClusterDrainConfig{
+				MaxConcurrentKafkaMigrationsPerCluster: 0,
+				MaxConcurrentKafkaMigrations:           5,
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return an error when the global max concurrent migrations is lower than the per cluster one",
+			config: ClusterDrainConfig{
+				MaxConcurrentKafkaMigrationsPerCluster: 3,
+				MaxConcurrentKafkaMigrations:           2,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			g.Expect(tt.config.validate() != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
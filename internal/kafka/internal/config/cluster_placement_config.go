@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+)
+
+const (
+	// BinPackingScorePlugin favours the clusters that will be the most utilised once the kafka is placed
+	BinPackingScorePlugin = "bin_packing"
+	// SpreadScorePlugin favours the clusters hosting the fewest kafkas
+	SpreadScorePlugin = "spread"
+	// LeastLoadedScorePlugin favours the clusters that will be the least utilised once the kafka is placed
+	LeastLoadedScorePlugin = "least_loaded"
+	// StrimziVersionAffinityScorePlugin favours the clusters where the Strimzi version desired by the kafka is available and ready
+	StrimziVersionAffinityScorePlugin = "strimzi_version_affinity"
+)
+
+var supportedPlacementScorePlugins = []string{
+	BinPackingScorePlugin,
+	SpreadScorePlugin,
+	LeastLoadedScorePlugin,
+	StrimziVersionAffinityScorePlugin,
+}
+
+// ClusterPlacementConfig contains the configuration of the scoring used to select the data plane cluster of a kafka.
+// It is read from the `placement` section of the data plane cluster configuration file e.g:
+//
+//	placement:
+//	  score_weights:
+//	    bin_packing: 2
+//	    strimzi_version_affinity: 1
+type ClusterPlacementConfig struct {
+	// ScoreWeights maps the name of a score plugin to its weight.
+	// Score plugins that are not listed or that have a weight of 0 are disabled.
+	// When all the score plugins are disabled, the first eligible cluster is selected.
+	ScoreWeights map[string]int `yaml:"score_weights"`
+}
+
+func NewClusterPlacementConfig() ClusterPlacementConfig {
+	return ClusterPlacementConfig{
+		ScoreWeights: map[string]int{},
+	}
+}
+
+// GetScoreWeight returns the weight of the given score plugin. 0 is returned if the plugin is not configured
+func (c *ClusterPlacementConfig) GetScoreWeight(plugin string) int {
+	return c.ScoreWeights[plugin]
+}
+
+func (c *ClusterPlacementConfig) validate() error {
+	for plugin, weight := range c.ScoreWeights {
+		if !arrays.Contains(supportedPlacementScorePlugins, plugin) {
+			return fmt.Errorf("placement score plugin %q is not supported. Supported score plugins are: %s", plugin, strings.Join(supportedPlacementScorePlugins, ", "))
+		}
+
+		if weight < 0 {
+			return fmt.Errorf("weight of placement score plugin %q must be greater than or equal to 0, got %d", plugin, weight)
+		}
+	}
+
+	return nil
+}
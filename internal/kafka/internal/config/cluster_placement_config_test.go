@@ -0,0 +1,56 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func Test_ClusterPlacementConfig_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  ClusterPlacementConfig
+		wantErr bool
+	}{
+		{
+			name:    "should not return an error for the default configuration",
+			config:  NewClusterPlacementConfig(),
+			wantErr: false,
+		},
+		{
+			name: "should not return an error when the weights of supported score plugins are set",
+			config: ClusterPlacementConfig{
+				ScoreWeights: map[string]int{
+					BinPackingScorePlugin:             2,
+					SpreadScorePlugin:                 0,
+					LeastLoadedScorePlugin:            0,
+					StrimziVersionAffinityScorePlugin: 1,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "should return an error when a score plugin is not supported",
+			config: ClusterPlacementConfig{
+				ScoreWeights: map[string]int{"unknown": 1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return an error when a weight is negative",
+			config: ClusterPlacementConfig{
+				ScoreWeights: map[string]int{BinPackingScorePlugin: -1},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			g.Expect(tt.config.validate() != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
@@ -55,6 +55,8 @@ type DataplaneClusterConfig struct {
 	ObservabilityOperatorOLMConfig              OperatorInstallationConfig
 	DynamicScalingConfig                        DynamicScalingConfig
 	NodePrewarmingConfig                        NodePrewarmingConfig
+	ClusterDrainConfig                          ClusterDrainConfig
+	ClusterPlacementConfig                      ClusterPlacementConfig
 }
 
 type OperatorInstallationConfig struct {
@@ -136,8 +138,10 @@ func NewDataplaneClusterConfig() *DataplaneClusterConfig {
 			IndexImage:              defaultObservabilityOperatorIndexImage,
 			SubscriptionStartingCSV: defaultObservabilityOperatorStartingCSV,
 		},
-		DynamicScalingConfig: NewDynamicScalingConfig(),
-		NodePrewarmingConfig: NewNodePrewarmingConfig(),
+		DynamicScalingConfig:   NewDynamicScalingConfig(),
+		NodePrewarmingConfig:   NewNodePrewarmingConfig(),
+		ClusterDrainConfig:     NewClusterDrainConfig(),
+		ClusterPlacementConfig: NewClusterPlacementConfig(),
 	}
 }
 
@@ -250,6 +254,16 @@ func (conf *ClusterConfig) IsNumberOfStreamingUnitsWithinClusterLimit(clusterID
 	return true
 }
 
+// GetClusterKafkaInstanceLimit returns the streaming units limit of the given cluster.
+// -1 is returned if the cluster has no limit or is not in the manual list.
+func (conf *ClusterConfig) GetClusterKafkaInstanceLimit(clusterID string) int {
+	if clusterConfigMap, exist := conf.clusterConfigMap[clusterID]; exist {
+		return clusterConfigMap.KafkaInstanceLimit
+	}
+
+	return -1
+}
+
 func (conf *ClusterConfig) IsClusterSchedulable(clusterID string) bool {
 	if clusterConfigMap, exist := conf.clusterConfigMap[clusterID]; exist {
 		return clusterConfigMap.Schedulable
@@ -341,6 +355,8 @@ func (c *DataplaneClusterConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&c.ObservabilityOperatorOLMConfig.SubscriptionStartingCSV, "observability-operator-starting-csv", c.ObservabilityOperatorOLMConfig.SubscriptionStartingCSV, "Observability operator subscription starting CSV")
 	fs.StringVar(&c.DynamicScalingConfig.filePath, "dynamic-scaling-config-file", c.DynamicScalingConfig.filePath, "File path to a file containing the dynamic scaling configuration")
 	fs.StringVar(&c.NodePrewarmingConfig.filePath, "node-prewarming-config-file", c.NodePrewarmingConfig.filePath, "File path to a file containing the node prewarming configuration")
+	fs.IntVar(&c.ClusterDrainConfig.MaxConcurrentKafkaMigrationsPerCluster, "cluster-drain-max-concurrent-kafka-migrations-per-cluster", c.ClusterDrainConfig.MaxConcurrentKafkaMigrationsPerCluster, "Maximum number of kafkas of a draining data plane cluster that can be moved to other clusters at the same time")
+	fs.IntVar(&c.ClusterDrainConfig.MaxConcurrentKafkaMigrations, "cluster-drain-max-concurrent-kafka-migrations", c.ClusterDrainConfig.MaxConcurrentKafkaMigrations, "Maximum number of kafkas that can be moved out of all the draining data plane clusters at the same time")
 }
 
 func (c *DataplaneClusterConfig) Validate(env *environments.Env) error {
@@ -355,6 +371,10 @@ func (c *DataplaneClusterConfig) Validate(env *environments.Env) error {
 		}
 	}
 
+	if err := c.ClusterDrainConfig.validate(); err != nil {
+		return err
+	}
+
 	return c.NodePrewarmingConfig.validate(kafkaConfig)
 }
 
@@ -437,6 +457,42 @@ func (c *DataplaneClusterConfig) ReadFiles() error {
 		return err
 	}
 
+	err = c.readClusterPlacementConfig()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// readClusterPlacementConfig reads the optional `placement` section of the data plane cluster configuration file.
+// The default placement configuration is kept if the file does not exist.
+func (c *DataplaneClusterConfig) readClusterPlacementConfig() error {
+	fileContents, err := shared.ReadFile(c.DataPlaneClusterConfigFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	placement := struct {
+		ClusterPlacementConfig *ClusterPlacementConfig `yaml:"placement"`
+	}{}
+
+	if err := yaml.Unmarshal([]byte(fileContents), &placement); err != nil {
+		return err
+	}
+
+	if placement.ClusterPlacementConfig == nil {
+		return nil
+	}
+
+	if err := placement.ClusterPlacementConfig.validate(); err != nil {
+		return err
+	}
+
+	c.ClusterPlacementConfig = *placement.ClusterPlacementConfig
 	return nil
 }
 
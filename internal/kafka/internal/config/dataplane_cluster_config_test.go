@@ -1,6 +1,7 @@
 package config
 
 import (
+	"os"
 	"testing"
 
 	"gopkg.in/yaml.v2"
@@ -9,6 +10,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/cloudproviders"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/kafkas/types"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
 
 	"github.com/onsi/gomega"
 )
@@ -1030,3 +1032,62 @@ func Test_readKubeconfig(t *testing.T) {
 		})
 	}
 }
+
+func Test_readClusterPlacementConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		// fileContent is the content of the data plane cluster configuration file. The file does not exist if empty
+		fileContent string
+		want        ClusterPlacementConfig
+		wantErr     bool
+	}{
+		{
+			name:        "should keep the default placement configuration when the file does not exist",
+			fileContent: "",
+			want:        NewClusterPlacementConfig(),
+			wantErr:     false,
+		},
+		{
+			name:        "should keep the default placement configuration when the placement section is missing",
+			fileContent: "clusters: []",
+			want:        NewClusterPlacementConfig(),
+			wantErr:     false,
+		},
+		{
+			name:        "should read the score weights of the placement section",
+			fileContent: "clusters: []\nplacement:\n  score_weights:\n    bin_packing: 2\n    strimzi_version_affinity: 1\n",
+			want: ClusterPlacementConfig{
+				ScoreWeights: map[string]int{
+					BinPackingScorePlugin:             2,
+					StrimziVersionAffinityScorePlugin: 1,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:        "should return an error when the placement section is invalid",
+			fileContent: "placement:\n  score_weights:\n    unknown: 2\n",
+			want:        NewClusterPlacementConfig(),
+			wantErr:     true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			config := NewDataplaneClusterConfig()
+			config.DataPlaneClusterConfigFile = "unexistingfilename"
+			if tt.fileContent != "" {
+				file, err := shared.CreateTempFileFromStringData("test_dataplane_cluster_config", tt.fileContent)
+				g.Expect(err).ToNot(gomega.HaveOccurred())
+				defer os.Remove(file)
+				config.DataPlaneClusterConfigFile = file
+			}
+
+			err := config.readClusterPlacementConfig()
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(config.ClusterPlacementConfig).To(gomega.Equal(tt.want))
+		})
+	}
+}
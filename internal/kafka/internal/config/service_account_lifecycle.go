@@ -0,0 +1,104 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+	"github.com/spf13/pflag"
+)
+
+// Actions applied to the expired service account credentials
+const (
+	// ServiceAccountExpiryActionRotate regenerates the secret, the owner gets the new one with the service account
+	ServiceAccountExpiryActionRotate = "rotate"
+	// ServiceAccountExpiryActionRevoke regenerates the secret without returning it and refuses any further reset of the credentials.
+	// The service account itself isn't disabled in the SSO, it can't authenticate anymore as nobody knows its secret.
+	ServiceAccountExpiryActionRevoke = "revoke"
+)
+
+var _ environments.ConfigModule = (*ServiceAccountLifecycleConfig)(nil)
+
+// ServiceAccountLifecyclePolicy limits the age of the credentials of the service accounts of an organisation
+type ServiceAccountLifecyclePolicy struct {
+	OrganisationId string `yaml:"organisation_id"`
+	// MaxCredentialAge is the maximum duration since the last reset of the credentials, the policy is disabled when it's zero
+	MaxCredentialAge time.Duration `yaml:"max_credential_age"`
+	// WarningPeriod is how long before the expiry of the credentials the organisation is warned
+	WarningPeriod time.Duration `yaml:"warning_period"`
+	// Action applied to the expired credentials
+	Action string `yaml:"action"`
+}
+
+type ServiceAccountLifecyclePolicies struct {
+	// Default is the policy of the organisations without policy
+	Default       ServiceAccountLifecyclePolicy   `yaml:"default"`
+	Organisations []ServiceAccountLifecyclePolicy `yaml:"organisations"`
+}
+
+// ServiceAccountLifecycleConfig is the configuration of the expiry of the service accounts and of their credentials
+type ServiceAccountLifecycleConfig struct {
+	PoliciesFile string
+	Policies     ServiceAccountLifecyclePolicies
+	// LastUsedUpdateInterval is the minimum delay between two updates of the last used timestamp of a service account
+	LastUsedUpdateInterval time.Duration
+	// BackfillInterval is the delay between two backfills of the lifecycles of the service accounts that aren't tracked yet
+	BackfillInterval time.Duration
+}
+
+func NewServiceAccountLifecycleConfig() *ServiceAccountLifecycleConfig {
+	return &ServiceAccountLifecycleConfig{
+		PoliciesFile:           "config/service-account-lifecycle-policies.yaml",
+		LastUsedUpdateInterval: 5 * time.Minute,
+		BackfillInterval:       24 * time.Hour,
+	}
+}
+
+func (c *ServiceAccountLifecycleConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&c.PoliciesFile, "service-account-lifecycle-policies-file", c.PoliciesFile, "File containing the maximum credential age policies of the service accounts of the organisations")
+	fs.DurationVar(&c.LastUsedUpdateInterval, "service-account-last-used-update-interval", c.LastUsedUpdateInterval, "Minimum delay between two updates of the last used timestamp of a service account")
+	fs.DurationVar(&c.BackfillInterval, "service-account-lifecycle-backfill-interval", c.BackfillInterval, "Delay between two backfills of the lifecycles of the service accounts of the SSO that aren't tracked yet, a zero interval disables the backfill")
+}
+
+func (c *ServiceAccountLifecycleConfig) ReadFiles() error {
+	if err := shared.ReadYamlFile(c.PoliciesFile, &c.Policies); err != nil {
+		return err
+	}
+	return c.Policies.validate()
+}
+
+// PolicyFor returns the policy of the organisation, or the default policy when the organisation has none
+func (c *ServiceAccountLifecycleConfig) PolicyFor(organisationId string) ServiceAccountLifecyclePolicy {
+	for _, policy := range c.Policies.Organisations {
+		if policy.OrganisationId == organisationId {
+			return policy
+		}
+	}
+	return c.Policies.Default
+}
+
+func (p *ServiceAccountLifecyclePolicies) validate() error {
+	if err := p.Default.validate(); err != nil {
+		return fmt.Errorf("invalid default service account lifecycle policy: %w", err)
+	}
+	for _, policy := range p.Organisations {
+		if policy.OrganisationId == "" {
+			return fmt.Errorf("service account lifecycle policy without organisation_id")
+		}
+		if err := policy.validate(); err != nil {
+			return fmt.Errorf("invalid service account lifecycle policy of organisation %s: %w", policy.OrganisationId, err)
+		}
+	}
+	return nil
+}
+
+func (p *ServiceAccountLifecyclePolicy) validate() error {
+	if p.MaxCredentialAge < 0 || p.WarningPeriod < 0 {
+		return fmt.Errorf("max_credential_age and warning_period must not be negative")
+	}
+	if p.MaxCredentialAge > 0 && p.Action != ServiceAccountExpiryActionRotate && p.Action != ServiceAccountExpiryActionRevoke {
+		return fmt.Errorf("action must be %s or %s, got %q", ServiceAccountExpiryActionRotate, ServiceAccountExpiryActionRevoke, p.Action)
+	}
+	return nil
+}
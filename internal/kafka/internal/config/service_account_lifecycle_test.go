@@ -0,0 +1,108 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func Test_ServiceAccountLifecycleConfig_ReadFiles(t *testing.T) {
+	g := gomega.NewWithT(t)
+	c := NewServiceAccountLifecycleConfig()
+	c.PoliciesFile = "config/service-account-lifecycle-policies.yaml"
+
+	g.Expect(c.ReadFiles()).To(gomega.Succeed())
+	g.Expect(c.Policies.Default.MaxCredentialAge).To(gomega.BeZero())
+}
+
+func Test_ServiceAccountLifecyclePolicies_validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		policies ServiceAccountLifecyclePolicies
+		wantErr  bool
+	}{
+		{
+			name: "should accept a default policy without max credential age",
+			policies: ServiceAccountLifecyclePolicies{
+				Default: ServiceAccountLifecyclePolicy{},
+			},
+		},
+		{
+			name: "should accept valid organisation policies",
+			policies: ServiceAccountLifecyclePolicies{
+				Organisations: []ServiceAccountLifecyclePolicy{
+					{OrganisationId: "org-1", MaxCredentialAge: time.Hour, WarningPeriod: time.Minute, Action: ServiceAccountExpiryActionRotate},
+					{OrganisationId: "org-2", MaxCredentialAge: time.Hour, Action: ServiceAccountExpiryActionRevoke},
+				},
+			},
+		},
+		{
+			name: "should reject a policy with a max credential age and an unknown action",
+			policies: ServiceAccountLifecyclePolicies{
+				Default: ServiceAccountLifecyclePolicy{MaxCredentialAge: time.Hour, Action: "delete"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should reject a negative warning period",
+			policies: ServiceAccountLifecyclePolicies{
+				Default: ServiceAccountLifecyclePolicy{WarningPeriod: -time.Hour},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should reject an organisation policy without organisation id",
+			policies: ServiceAccountLifecyclePolicies{
+				Organisations: []ServiceAccountLifecyclePolicy{
+					{MaxCredentialAge: time.Hour, Action: ServiceAccountExpiryActionRotate},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(tt.policies.validate() != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
+
+func Test_ServiceAccountLifecycleConfig_PolicyFor(t *testing.T) {
+	orgPolicy := ServiceAccountLifecyclePolicy{OrganisationId: "org-1", MaxCredentialAge: time.Hour, Action: ServiceAccountExpiryActionRevoke}
+	defaultPolicy := ServiceAccountLifecyclePolicy{MaxCredentialAge: 2 * time.Hour, Action: ServiceAccountExpiryActionRotate}
+	c := &ServiceAccountLifecycleConfig{
+		Policies: ServiceAccountLifecyclePolicies{
+			Default:       defaultPolicy,
+			Organisations: []ServiceAccountLifecyclePolicy{orgPolicy},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		organisationId string
+		want           ServiceAccountLifecyclePolicy
+	}{
+		{
+			name:           "should return the policy of the organisation",
+			organisationId: "org-1",
+			want:           orgPolicy,
+		},
+		{
+			name:           "should return the default policy if the organisation has none",
+			organisationId: "org-2",
+			want:           defaultPolicy,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(c.PolicyFor(tt.organisationId)).To(gomega.Equal(tt.want))
+		})
+	}
+}
@@ -5,24 +5,26 @@ import "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
 // The development environment is intended for use while developing features, requiring manual verification
 func NewDevelopmentEnvLoader() environments.EnvLoader {
 	return environments.SimpleEnvLoader{
-		"v":                             "10",
-		"ocm-debug":                     "false",
-		"ams-base-url":                  "https://api.stage.openshift.com",
-		"ocm-base-url":                  "https://api.stage.openshift.com",
-		"enable-ocm-mock":               "false",
-		"enable-https":                  "false",
-		"enable-metrics-https":          "false",
-		"enable-terms-acceptance":       "false",
-		"api-server-bindaddress":        "localhost:8000",
-		"enable-sentry":                 "false",
-		"enable-deny-list":              "true",
-		"enable-access-list":            "false",
-		"enable-instance-limit-control": "false",
-		"mas-sso-base-url":              "http://127.0.0.1:8180",
-		"redhat-sso-base-url":           "https://sso.stage.redhat.com",
-		"mas-sso-realm":                 "rhoas",
-		"sso-provider-type":             "mas_sso",
-		"osd-idp-mas-sso-realm":         "rhoas-kafka-sre",
+		"v":                              "10",
+		"ocm-debug":                      "false",
+		"ams-base-url":                   "https://api.stage.openshift.com",
+		"ocm-base-url":                   "https://api.stage.openshift.com",
+		"enable-ocm-mock":                "false",
+		"enable-https":                   "false",
+		"enable-metrics-https":           "false",
+		"enable-terms-acceptance":        "false",
+		"api-server-bindaddress":         "localhost:8000",
+		"enable-sentry":                  "false",
+		"webhook-allow-insecure-urls":    "true",
+		"webhook-allow-private-networks": "true",
+		"enable-deny-list":               "true",
+		"enable-access-list":             "false",
+		"enable-instance-limit-control":  "false",
+		"mas-sso-base-url":               "http://127.0.0.1:8180",
+		"redhat-sso-base-url":            "https://sso.stage.redhat.com",
+		"mas-sso-realm":                  "rhoas",
+		"sso-provider-type":              "mas_sso",
+		"osd-idp-mas-sso-realm":          "rhoas-kafka-sre",
 		"enable-kafka-sre-identity-provider-configuration": "false",
 		"enable-kafka-external-certificate":                "false",
 		"allow-developer-instance":                         "true",
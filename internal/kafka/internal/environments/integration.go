@@ -30,6 +30,8 @@ func (b IntegrationEnvLoader) Defaults() map[string]string {
 		"enable-ocm-mock":                   "true",
 		"ocm-mock-mode":                     ocm.MockModeEmulateServer,
 		"enable-sentry":                     "false",
+		"webhook-allow-insecure-urls":       "true",
+		"webhook-allow-private-networks":    "true",
 		"enable-deny-list":                  "true",
 		"enable-access-list":                "false",
 		"enable-instance-limit-control":     "true",
@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+	"github.com/golang/glog"
+	"github.com/gorilla/mux"
+)
+
+const (
+	clusterActionCordon           = "cordon"
+	clusterActionUncordon         = "uncordon"
+	clusterActionMarkFull         = "mark_full"
+	clusterActionMarkReady        = "mark_ready"
+	clusterActionDeprovisionEmpty = "deprovision_empty"
+)
+
+var supportedClusterActions = []string{clusterActionCordon, clusterActionUncordon, clusterActionMarkFull, clusterActionMarkReady, clusterActionDeprovisionEmpty}
+
+func GetAcceptedClusterOrderByParams() []string {
+	return []string{"cloud_provider", "cluster_id", "cluster_type", "cordoned", "created_at", "multi_az", "organization_id", "region", "status", "updated_at"}
+}
+
+type adminClusterHandler struct {
+	clusterService      services.ClusterService
+	clusterDrainService services.ClusterDrainService
+}
+
+func NewAdminClusterHandler(clusterService services.ClusterService, clusterDrainService services.ClusterDrainService) *adminClusterHandler {
+	return &adminClusterHandler{
+		clusterService:      clusterService,
+		clusterDrainService: clusterDrainService,
+	}
+}
+
+func (h adminClusterHandler) List(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			listArgs := coreServices.NewListArguments(r.URL.Query())
+
+			if err := listArgs.Validate(GetAcceptedClusterOrderByParams()); err != nil {
+				return nil, errors.NewWithCause(errors.ErrorMalformedRequest, err, "unable to list clusters: %s", err.Error())
+			}
+
+			clusters, paging, err := h.clusterService.List(listArgs)
+			if err != nil {
+				return nil, err
+			}
+
+			clusterIDs := []string{}
+			for _, cluster := range clusters {
+				clusterIDs = append(clusterIDs, cluster.ClusterID)
+			}
+
+			kafkaCountPerCluster, err := h.clusterService.CountKafkasPerCluster(clusterIDs)
+			if err != nil {
+				return nil, err
+			}
+
+			clusterList := private.DataPlaneClusterList{
+				Kind:  "DataPlaneClusterList",
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []private.DataPlaneCluster{},
+			}
+
+			for _, cluster := range clusters {
+				clusterList.Items = append(clusterList.Items, presenters.PresentDataPlaneClusterAdminEndpoint(*cluster, kafkaCountPerCluster[cluster.ClusterID], nil))
+			}
+
+			return clusterList, nil
+		},
+	}
+
+	handlers.HandleList(w, r, cfg)
+}
+
+func (h adminClusterHandler) Get(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			clusterID := mux.Vars(r)["id"]
+			cluster, err := h.findCluster(clusterID)
+			if err != nil {
+				return nil, err
+			}
+
+			return h.presentClusterDetails(*cluster)
+		},
+	}
+	handlers.HandleGet(w, r, cfg)
+}
+
+func (h adminClusterHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var clusterUpdateReq private.DataPlaneClusterUpdateRequest
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &clusterUpdateReq,
+		Validate: []handlers.Validate{
+			func() *errors.ServiceError {
+				if !arrays.Contains(supportedClusterActions, clusterUpdateReq.Action) {
+					return errors.BadRequest("action %q is not supported. Supported actions are: %v", clusterUpdateReq.Action, supportedClusterActions)
+				}
+				return nil
+			},
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			clusterID := mux.Vars(r)["id"]
+
+			var cluster *api.Cluster
+			var err *errors.ServiceError
+			switch clusterUpdateReq.Action {
+			case clusterActionCordon:
+				cluster, err = h.clusterDrainService.Cordon(clusterID)
+			case clusterActionUncordon:
+				cluster, err = h.clusterDrainService.Uncordon(clusterID)
+			case clusterActionMarkFull:
+				cluster, err = h.updateClusterStatus(clusterID, api.ClusterReady, api.ClusterFull)
+			case clusterActionMarkReady:
+				cluster, err = h.updateClusterStatus(clusterID, api.ClusterFull, api.ClusterReady)
+			case clusterActionDeprovisionEmpty:
+				cluster, err = h.deprovisionEmptyCluster(clusterID)
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			return h.presentClusterDetails(*cluster)
+		},
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
+
+func (h adminClusterHandler) Drain(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			clusterID := mux.Vars(r)["id"]
+			cluster, err := h.clusterDrainService.Drain(clusterID)
+			if err != nil {
+				return nil, err
+			}
+
+			return h.presentClusterDetails(*cluster)
+		},
+	}
+	handlers.Handle(w, r, cfg, http.StatusAccepted)
+}
+
+func (h adminClusterHandler) findCluster(clusterID string) (*api.Cluster, *errors.ServiceError) {
+	cluster, err := h.clusterService.FindClusterByID(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cluster == nil {
+		return nil, errors.NotFound("cluster with id %q not found", clusterID)
+	}
+
+	return cluster, nil
+}
+
+// updateClusterStatus moves the cluster from the expected status to the desired one
+func (h adminClusterHandler) updateClusterStatus(clusterID string, expectedStatus, desiredStatus api.ClusterStatus) (*api.Cluster, *errors.ServiceError) {
+	cluster, err := h.findCluster(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cluster.Status == desiredStatus {
+		return cluster, nil
+	}
+
+	if cluster.Status != expectedStatus {
+		return nil, errors.BadRequest("cluster with id %q is in %q state. Only clusters in %q state can be moved to %q state", clusterID, cluster.Status, expectedStatus, desiredStatus)
+	}
+
+	if updateErr := h.clusterService.UpdateStatus(*cluster, desiredStatus); updateErr != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, updateErr, "failed to update status of cluster %q", clusterID)
+	}
+
+	glog.Infof("cluster %q status has been updated from %q to %q", clusterID, expectedStatus, desiredStatus)
+	cluster.Status = desiredStatus
+
+	return cluster, nil
+}
+
+// deprovisionEmptyCluster marks an empty cluster for deprovisioning regardless of the dynamic scaling evaluation.
+// Clusters still hosting kafkas are refused as their deprovisioning is reverted by the deprovisioning worker:
+// they have to be drained first.
+func (h adminClusterHandler) deprovisionEmptyCluster(clusterID string) (*api.Cluster, *errors.ServiceError) {
+	cluster, err := h.findCluster(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cluster.Status == api.ClusterDeprovisioning {
+		return cluster, nil
+	}
+
+	if cluster.ClusterType == api.EnterpriseDataPlaneClusterType.String() {
+		return nil, errors.BadRequest("cluster with id %q is an enterprise cluster. Enterprise clusters have to be deregistered by their owner", clusterID)
+	}
+
+	if cluster.Status != api.ClusterReady && cluster.Status != api.ClusterFull {
+		return nil, errors.BadRequest("cluster with id %q is in %q state and cannot be deprovisioned. Only clusters in %q or %q state can be deprovisioned", clusterID, cluster.Status, api.ClusterReady, api.ClusterFull)
+	}
+
+	nonEmptyCluster, err := h.clusterService.FindNonEmptyClusterByID(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if nonEmptyCluster != nil {
+		return nil, errors.BadRequest("cluster with id %q still hosts kafkas and cannot be deprovisioned. Drain the cluster first", clusterID)
+	}
+
+	if updateErr := h.clusterService.UpdateStatus(*cluster, api.ClusterDeprovisioning); updateErr != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, updateErr, "failed to deprovision cluster %q", clusterID)
+	}
+
+	glog.Infof("cluster %q has been marked for deprovisioning", clusterID)
+	cluster.Status = api.ClusterDeprovisioning
+
+	return cluster, nil
+}
+
+func (h adminClusterHandler) presentClusterDetails(cluster api.Cluster) (*private.DataPlaneCluster, *errors.ServiceError) {
+	kafkaCountPerCluster, err := h.clusterService.CountKafkasPerCluster([]string{cluster.ClusterID})
+	if err != nil {
+		return nil, err
+	}
+
+	consumedStreamingUnits, computeErr := h.clusterService.ComputeConsumedStreamingUnitCountPerInstanceType(cluster.ClusterID)
+	if computeErr != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, computeErr, "failed to compute consumed streaming units of cluster %q", cluster.ClusterID)
+	}
+
+	presentedCluster := presenters.PresentDataPlaneClusterAdminEndpoint(cluster, kafkaCountPerCluster[cluster.ClusterID], consumedStreamingUnits)
+	return &presentedCluster, nil
+}
@@ -0,0 +1,335 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/gorilla/mux"
+	"github.com/onsi/gomega"
+)
+
+func buildAdminClusterServiceMock(cluster *api.Cluster) *services.ClusterServiceMock {
+	return &services.ClusterServiceMock{
+		FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+			return cluster, nil
+		},
+		CountKafkasPerClusterFunc: func(clusterIDs []string) (map[string]int, *errors.ServiceError) {
+			return map[string]int{}, nil
+		},
+		ComputeConsumedStreamingUnitCountPerInstanceTypeFunc: func(clusterID string) (services.StreamingUnitCountPerInstanceType, error) {
+			return services.StreamingUnitCountPerInstanceType{}, nil
+		},
+		UpdateStatusFunc: func(cluster api.Cluster, status api.ClusterStatus) error {
+			return nil
+		},
+		FindNonEmptyClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+			return nil, nil
+		},
+	}
+}
+
+func Test_adminClusterHandler_List(t *testing.T) {
+	type fields struct {
+		clusterService services.ClusterService
+	}
+
+	tests := []struct {
+		name           string
+		fields         fields
+		url            string
+		wantStatusCode int
+	}{
+		{
+			name: "should successfully list the clusters",
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					ListFunc: func(listArgs *coreServices.ListArguments) (api.ClusterList, *api.PagingMeta, *errors.ServiceError) {
+						return api.ClusterList{{ClusterID: "cluster-id", Status: api.ClusterReady}}, &api.PagingMeta{Page: 1, Size: 1, Total: 1}, nil
+					},
+					CountKafkasPerClusterFunc: func(clusterIDs []string) (map[string]int, *errors.ServiceError) {
+						return map[string]int{"cluster-id": 2}, nil
+					},
+				},
+			},
+			url:            "/clusters",
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "should return bad request if the order by param is not supported",
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{},
+			},
+			url:            "/clusters?orderBy=client_secret",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should return an error if listing the clusters fails",
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					ListFunc: func(listArgs *coreServices.ListArguments) (api.ClusterList, *api.PagingMeta, *errors.ServiceError) {
+						return nil, nil, errors.GeneralError("test")
+					},
+				},
+			},
+			url:            "/clusters",
+			wantStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			h := NewAdminClusterHandler(tt.fields.clusterService, &services.ClusterDrainServiceMock{})
+			req, rw := GetHandlerParams("GET", tt.url, nil, t)
+			h.List(rw, req)
+			resp := rw.Result()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			resp.Body.Close()
+		})
+	}
+}
+
+func Test_adminClusterHandler_Get(t *testing.T) {
+	type fields struct {
+		clusterService services.ClusterService
+	}
+
+	tests := []struct {
+		name           string
+		fields         fields
+		wantStatusCode int
+	}{
+		{
+			name: "should successfully return the cluster",
+			fields: fields{
+				clusterService: buildAdminClusterServiceMock(&api.Cluster{ClusterID: "cluster-id", Status: api.ClusterReady}),
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "should return not found if the cluster does not exist",
+			fields: fields{
+				clusterService: buildAdminClusterServiceMock(nil),
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "should return an error if finding the cluster fails",
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return nil, errors.GeneralError("test")
+					},
+				},
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			h := NewAdminClusterHandler(tt.fields.clusterService, &services.ClusterDrainServiceMock{})
+			req, rw := GetHandlerParams("GET", "/{id}", nil, t)
+			req = mux.SetURLVars(req, map[string]string{"id": "cluster-id"})
+			h.Get(rw, req)
+			resp := rw.Result()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			resp.Body.Close()
+		})
+	}
+}
+
+func Test_adminClusterHandler_Update(t *testing.T) {
+	type fields struct {
+		clusterService      *services.ClusterServiceMock
+		clusterDrainService services.ClusterDrainService
+	}
+
+	tests := []struct {
+		name           string
+		fields         fields
+		action         string
+		wantStatusCode int
+		wantStatus     api.ClusterStatus
+	}{
+		{
+			name: "should return bad request if the action is not supported",
+			fields: fields{
+				clusterService:      buildAdminClusterServiceMock(&api.Cluster{ClusterID: "cluster-id", Status: api.ClusterReady}),
+				clusterDrainService: &services.ClusterDrainServiceMock{},
+			},
+			action:         "delete",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should cordon the cluster",
+			fields: fields{
+				clusterService: buildAdminClusterServiceMock(nil),
+				clusterDrainService: &services.ClusterDrainServiceMock{
+					CordonFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return &api.Cluster{ClusterID: clusterID, Cordoned: true}, nil
+					},
+				},
+			},
+			action:         "cordon",
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "should return the error returned when uncordoning the cluster",
+			fields: fields{
+				clusterService: buildAdminClusterServiceMock(nil),
+				clusterDrainService: &services.ClusterDrainServiceMock{
+					UncordonFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return nil, errors.NotFound("not found")
+					},
+				},
+			},
+			action:         "uncordon",
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "should mark a ready cluster as full",
+			fields: fields{
+				clusterService:      buildAdminClusterServiceMock(&api.Cluster{ClusterID: "cluster-id", Status: api.ClusterReady}),
+				clusterDrainService: &services.ClusterDrainServiceMock{},
+			},
+			action:         "mark_full",
+			wantStatusCode: http.StatusOK,
+			wantStatus:     api.ClusterFull,
+		},
+		{
+			name: "should not mark a provisioning cluster as full",
+			fields: fields{
+				clusterService:      buildAdminClusterServiceMock(&api.Cluster{ClusterID: "cluster-id", Status: api.ClusterProvisioning}),
+				clusterDrainService: &services.ClusterDrainServiceMock{},
+			},
+			action:         "mark_full",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should mark a full cluster as ready",
+			fields: fields{
+				clusterService:      buildAdminClusterServiceMock(&api.Cluster{ClusterID: "cluster-id", Status: api.ClusterFull}),
+				clusterDrainService: &services.ClusterDrainServiceMock{},
+			},
+			action:         "mark_ready",
+			wantStatusCode: http.StatusOK,
+			wantStatus:     api.ClusterReady,
+		},
+		{
+			name: "should deprovision an empty cluster",
+			fields: fields{
+				clusterService:      buildAdminClusterServiceMock(&api.Cluster{ClusterID: "cluster-id", Status: api.ClusterReady}),
+				clusterDrainService: &services.ClusterDrainServiceMock{},
+			},
+			action:         "deprovision_empty",
+			wantStatusCode: http.StatusOK,
+			wantStatus:     api.ClusterDeprovisioning,
+		},
+		{
+			name: "should not deprovision an enterprise cluster",
+			fields: fields{
+				clusterService:      buildAdminClusterServiceMock(&api.Cluster{ClusterID: "cluster-id", Status: api.ClusterReady, ClusterType: api.EnterpriseDataPlaneClusterType.String()}),
+				clusterDrainService: &services.ClusterDrainServiceMock{},
+			},
+			action:         "deprovision_empty",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should not deprovision a cluster still hosting kafkas",
+			fields: fields{
+				clusterService: func() *services.ClusterServiceMock {
+					clusterService := buildAdminClusterServiceMock(&api.Cluster{ClusterID: "cluster-id", Status: api.ClusterReady})
+					clusterService.FindNonEmptyClusterByIDFunc = func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return &api.Cluster{ClusterID: clusterID}, nil
+					}
+					return clusterService
+				}(),
+				clusterDrainService: &services.ClusterDrainServiceMock{},
+			},
+			action:         "deprovision_empty",
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			h := NewAdminClusterHandler(tt.fields.clusterService, tt.fields.clusterDrainService)
+			body, err := json.Marshal(private.DataPlaneClusterUpdateRequest{Action: tt.action})
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			req, rw := GetHandlerParams("PATCH", "/{id}", bytes.NewBuffer(body), t)
+			req = mux.SetURLVars(req, map[string]string{"id": "cluster-id"})
+			h.Update(rw, req)
+			resp := rw.Result()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			resp.Body.Close()
+
+			if tt.wantStatus != "" {
+				updateStatusCalls := tt.fields.clusterService.UpdateStatusCalls()
+				g.Expect(updateStatusCalls).To(gomega.HaveLen(1))
+				g.Expect(updateStatusCalls[0].Status).To(gomega.Equal(tt.wantStatus))
+			}
+		})
+	}
+}
+
+func Test_adminClusterHandler_Drain(t *testing.T) {
+	type fields struct {
+		clusterDrainService services.ClusterDrainService
+	}
+
+	tests := []struct {
+		name           string
+		fields         fields
+		wantStatusCode int
+	}{
+		{
+			name: "should accept the drain of the cluster",
+			fields: fields{
+				clusterDrainService: &services.ClusterDrainServiceMock{
+					DrainFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return &api.Cluster{ClusterID: clusterID, Cordoned: true}, nil
+					},
+				},
+			},
+			wantStatusCode: http.StatusAccepted,
+		},
+		{
+			name: "should return the error returned by the drain",
+			fields: fields{
+				clusterDrainService: &services.ClusterDrainServiceMock{
+					DrainFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return nil, errors.BadRequest("cannot be drained")
+					},
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			h := NewAdminClusterHandler(buildAdminClusterServiceMock(nil), tt.fields.clusterDrainService)
+			req, rw := GetHandlerParams("POST", "/{id}/drain", nil, t)
+			req = mux.SetURLVars(req, map[string]string{"id": "cluster-id"})
+			h.Drain(rw, req)
+			resp := rw.Result()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			resp.Body.Close()
+		})
+	}
+}
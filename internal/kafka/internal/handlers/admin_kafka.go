@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -17,6 +18,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/presenters"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services/kafkatlscertmgmt"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
 	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
@@ -56,6 +58,9 @@ func (h adminKafkaHandler) Get(w http.ResponseWriter, r *http.Request) {
 			if err != nil {
 				return nil, err
 			}
+			if err := validateKafkaAdminResource(ctx, kafkaRequest)(); err != nil {
+				return nil, err
+			}
 			return presenters.PresentKafkaRequestAdminEndpoint(kafkaRequest, h.accountService)
 		},
 	}
@@ -110,6 +115,16 @@ func (h adminKafkaHandler) Delete(w http.ResponseWriter, r *http.Request) {
 			id := mux.Vars(r)["id"]
 			ctx := r.Context()
 
+			if auth.IsAdminResourceRestricted(ctx) {
+				kafkaRequest, err := h.kafkaService.Get(ctx, id)
+				if err != nil {
+					return nil, err
+				}
+				if err := validateKafkaAdminResource(ctx, kafkaRequest)(); err != nil {
+					return nil, err
+				}
+			}
+
 			err := h.kafkaService.RegisterKafkaDeprovisionJob(ctx, id)
 			return nil, err
 		},
@@ -128,6 +143,7 @@ func (h *adminKafkaHandler) Update(w http.ResponseWriter, r *http.Request) {
 		MarshalInto: &kafkaUpdateReq,
 		Validate: []handlers.Validate{
 			validateGettingKafkaFromDatabase(id, kafkaRequest, err),
+			validateKafkaAdminResource(ctx, kafkaRequest),
 			ValidateKafkaUpdateFields(
 				&kafkaUpdateReq,
 			),
@@ -191,6 +207,7 @@ func (h *adminKafkaHandler) Update(w http.ResponseWriter, r *http.Request) {
 				return kafka.Status
 			}
 
+			before := kafkaAdminAuditedFields(kafkaRequest)
 			updateRequired := update(&kafkaRequest.DesiredKafkaVersion, kafkaUpdateReq.KafkaVersion)
 			updateRequired = update(&kafkaRequest.DesiredStrimziVersion, kafkaUpdateReq.StrimziVersion) || updateRequired
 			updateRequired = update(&kafkaRequest.DesiredKafkaIBPVersion, kafkaUpdateReq.KafkaIbpVersion) || updateRequired
@@ -204,6 +221,7 @@ func (h *adminKafkaHandler) Update(w http.ResponseWriter, r *http.Request) {
 				if err != nil {
 					return nil, err
 				}
+				auth.RecordAuditDiff(ctx, before, kafkaAdminAuditedFields(kafkaRequest))
 			}
 			return presenters.PresentKafkaRequestAdminEndpoint(kafkaRequest, h.accountService)
 		},
@@ -211,6 +229,17 @@ func (h *adminKafkaHandler) Update(w http.ResponseWriter, r *http.Request) {
 	handlers.Handle(w, r, cfg, http.StatusOK)
 }
 
+// kafkaAdminAuditedFields returns the fields of a kafka updatable through the admin API, as recorded in the audit trail
+func kafkaAdminAuditedFields(kafkaRequest *dbapi.KafkaRequest) map[string]interface{} {
+	return map[string]interface{}{
+		"kafka_version":           kafkaRequest.DesiredKafkaVersion,
+		"strimzi_version":         kafkaRequest.DesiredStrimziVersion,
+		"kafka_ibp_version":       kafkaRequest.DesiredKafkaIBPVersion,
+		"max_data_retention_size": kafkaRequest.MaxDataRetentionSize,
+		"status":                  kafkaRequest.Status,
+	}
+}
+
 func (h *adminKafkaHandler) RevokeCertificateOfAKafka(w http.ResponseWriter, r *http.Request) {
 	id := mux.Vars(r)["id"]
 	ctx := r.Context()
@@ -221,6 +250,7 @@ func (h *adminKafkaHandler) RevokeCertificateOfAKafka(w http.ResponseWriter, r *
 		MarshalInto: &kafkaCertificationRevocationRequest,
 		Validate: []handlers.Validate{
 			validateGettingKafkaFromDatabase(id, kafkaRequest, err),
+			validateKafkaAdminResource(ctx, kafkaRequest),
 		},
 		Action: func() (i interface{}, serviceError *errors.ServiceError) {
 			reason, err := kafkatlscertmgmt.ParseReason(int(kafkaCertificationRevocationRequest.RevocationReason))
@@ -306,3 +336,16 @@ func validateGettingKafkaFromDatabase(kafkaId string, kafkaFromDatabase *dbapi.K
 		return nil
 	}
 }
+
+// validateKafkaAdminResource checks that the admin policies of the user allow access to the kafka
+func validateKafkaAdminResource(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) handlers.Validate {
+	return func() *errors.ServiceError {
+		return auth.CheckAdminResource(ctx, map[string]string{
+			auth.AdminResourceRegion:         kafkaRequest.Region,
+			auth.AdminResourceCloudProvider:  kafkaRequest.CloudProvider,
+			auth.AdminResourceOrganisationId: kafkaRequest.OrganisationId,
+			auth.AdminResourceClusterId:      kafkaRequest.ClusterID,
+			auth.AdminResourceInstanceType:   kafkaRequest.InstanceType,
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/kafkas/types"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+)
+
+// maxPlacementSimulationKafkas is the maximum number of kafkas, once expanded according to their count, of a placement simulation
+const maxPlacementSimulationKafkas = 500
+
+type adminPlacementSimulationHandler struct {
+	placementSimulationService services.PlacementSimulationService
+	providerConfig             *config.ProviderConfig
+}
+
+func NewAdminPlacementSimulationHandler(placementSimulationService services.PlacementSimulationService, providerConfig *config.ProviderConfig) *adminPlacementSimulationHandler {
+	return &adminPlacementSimulationHandler{
+		placementSimulationService: placementSimulationService,
+		providerConfig:             providerConfig,
+	}
+}
+
+func (h adminPlacementSimulationHandler) Simulate(w http.ResponseWriter, r *http.Request) {
+	var simulationReq private.PlacementSimulationRequest
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &simulationReq,
+		Validate: []handlers.Validate{
+			validatePlacementSimulationRequest(&simulationReq),
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			result, err := h.placementSimulationService.Simulate(h.expandPlacementSimulationKafkas(simulationReq))
+			if err != nil {
+				return nil, err
+			}
+
+			return presenters.PresentPlacementSimulation(*result), nil
+		},
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
+
+// expandPlacementSimulationKafkas returns the kafkas of the request repeated according to their count, with the defaults applied
+func (h adminPlacementSimulationHandler) expandPlacementSimulationKafkas(simulationReq private.PlacementSimulationRequest) []services.PlacementSimulationKafka {
+	defaultProvider, _ := h.providerConfig.ProvidersConfig.SupportedProviders.GetDefault()
+
+	kafkas := []services.PlacementSimulationKafka{}
+	for _, requestedKafka := range simulationReq.Kafkas {
+		// in line with the kafka creation, the instance type determines the availability zones mode unless specified
+		multiAZ := requestedKafka.InstanceType == types.STANDARD.String()
+		if requestedKafka.MultiAz != nil {
+			multiAZ = *requestedKafka.MultiAz
+		}
+
+		kafka := services.PlacementSimulationKafka{
+			CloudProvider: arrays.FirstNonEmptyOrDefault(defaultProvider.Name, requestedKafka.CloudProvider),
+			Region:        requestedKafka.Region,
+			MultiAZ:       multiAZ,
+			InstanceType:  requestedKafka.InstanceType,
+			SizeId:        requestedKafka.SizeId,
+		}
+
+		count := int(requestedKafka.Count)
+		if count == 0 {
+			count = 1
+		}
+
+		for i := 0; i < count; i++ {
+			kafkas = append(kafkas, kafka)
+		}
+	}
+
+	return kafkas
+}
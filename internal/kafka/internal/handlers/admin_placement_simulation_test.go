@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/onsi/gomega"
+)
+
+func Test_adminPlacementSimulationHandler_Simulate(t *testing.T) {
+	providerConfig := &config.ProviderConfig{
+		ProvidersConfig: config.ProviderConfiguration{
+			SupportedProviders: config.ProviderList{
+				{Name: "aws", Default: true},
+			},
+		},
+	}
+
+	multiAZ := false
+
+	tests := []struct {
+		name           string
+		request        private.PlacementSimulationRequest
+		simulateErr    *errors.ServiceError
+		wantStatusCode int
+		wantKafkas     []services.PlacementSimulationKafka
+	}{
+		{
+			name:           "should return bad request if no kafka is provided",
+			request:        private.PlacementSimulationRequest{},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should return bad request if the size of a kafka is missing",
+			request: private.PlacementSimulationRequest{
+				Kafkas: []private.PlacementSimulationRequestKafka{{Region: "us-east-1", InstanceType: "standard"}},
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should return bad request if the count of a kafka is negative",
+			request: private.PlacementSimulationRequest{
+				Kafkas: []private.PlacementSimulationRequestKafka{{Region: "us-east-1", InstanceType: "standard", SizeId: "x1", Count: -1}},
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should return bad request if too many kafkas are requested",
+			request: private.PlacementSimulationRequest{
+				Kafkas: []private.PlacementSimulationRequestKafka{{Region: "us-east-1", InstanceType: "standard", SizeId: "x1", Count: maxPlacementSimulationKafkas + 1}},
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should expand the kafkas according to their count and apply the defaults",
+			request: private.PlacementSimulationRequest{
+				Kafkas: []private.PlacementSimulationRequestKafka{
+					{Region: "us-east-1", InstanceType: "standard", SizeId: "x1", Count: 2},
+					{CloudProvider: "gcp", Region: "us-east1", InstanceType: "developer", SizeId: "x1"},
+					{Region: "us-east-1", InstanceType: "standard", SizeId: "x2", MultiAz: &multiAZ},
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantKafkas: []services.PlacementSimulationKafka{
+				{CloudProvider: "aws", Region: "us-east-1", MultiAZ: true, InstanceType: "standard", SizeId: "x1"},
+				{CloudProvider: "aws", Region: "us-east-1", MultiAZ: true, InstanceType: "standard", SizeId: "x1"},
+				{CloudProvider: "gcp", Region: "us-east1", MultiAZ: false, InstanceType: "developer", SizeId: "x1"},
+				{CloudProvider: "aws", Region: "us-east-1", MultiAZ: false, InstanceType: "standard", SizeId: "x2"},
+			},
+		},
+		{
+			name: "should return the error returned by the simulation",
+			request: private.PlacementSimulationRequest{
+				Kafkas: []private.PlacementSimulationRequestKafka{{Region: "us-east-1", InstanceType: "standard", SizeId: "x9"}},
+			},
+			simulateErr:    errors.InstancePlanNotSupported("size x9 is not supported"),
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			placementSimulationService := &services.PlacementSimulationServiceMock{
+				SimulateFunc: func(kafkas []services.PlacementSimulationKafka) (*services.PlacementSimulationResult, *errors.ServiceError) {
+					if tt.simulateErr != nil {
+						return nil, tt.simulateErr
+					}
+
+					result := &services.PlacementSimulationResult{}
+					for _, kafka := range kafkas {
+						result.Placements = append(result.Placements, services.PlacementSimulationPlacement{Kafka: kafka, Placed: true, ClusterID: "cluster-id"})
+					}
+					return result, nil
+				},
+			}
+
+			h := NewAdminPlacementSimulationHandler(placementSimulationService, providerConfig)
+			body, err := json.Marshal(tt.request)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			req, rw := GetHandlerParams("POST", "/placement/simulations", bytes.NewBuffer(body), t)
+			h.Simulate(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+
+			if tt.wantKafkas == nil {
+				return
+			}
+
+			calls := placementSimulationService.SimulateCalls()
+			g.Expect(calls).To(gomega.HaveLen(1))
+			g.Expect(calls[0].Kafkas).To(gomega.Equal(tt.wantKafkas))
+
+			var simulation private.PlacementSimulation
+			g.Expect(json.NewDecoder(resp.Body).Decode(&simulation)).To(gomega.Succeed())
+			g.Expect(simulation.Placements).To(gomega.HaveLen(len(tt.wantKafkas)))
+			for i, placement := range simulation.Placements {
+				g.Expect(placement.Index).To(gomega.Equal(int32(i)))
+				g.Expect(placement.SizeId).To(gomega.Equal(tt.wantKafkas[i].SizeId))
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+)
+
+const (
+	// defaultKafkaUsagePeriodDays is the number of days before the to day that are returned when the from day is not given
+	defaultKafkaUsagePeriodDays = 30
+	// maxKafkaUsagePeriodDays is the maximum number of days that can be requested at once
+	maxKafkaUsagePeriodDays = 366
+
+	kafkaUsageFormatJSON = "json"
+	kafkaUsageFormatCSV  = "csv"
+)
+
+type adminUsageHandler struct {
+	kafkaUsageService services.KafkaUsageService
+}
+
+func NewAdminUsageHandler(kafkaUsageService services.KafkaUsageService) *adminUsageHandler {
+	return &adminUsageHandler{
+		kafkaUsageService: kafkaUsageService,
+	}
+}
+
+func (h adminUsageHandler) Get(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	orgID := query.Get("org_id")
+	format := kafkaUsageFormat(r)
+
+	var from, to time.Time
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			validateKafkaUsageFormat(format),
+			validateKafkaUsagePeriod(query.Get("from"), query.Get("to"), &from, &to),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			usages, err := h.kafkaUsageService.ListDailyUsage(orgID, from, to)
+			if err != nil {
+				return nil, err
+			}
+
+			if format == kafkaUsageFormatCSV {
+				return presenters.PresentKafkaUsageCSV(usages), nil
+			}
+
+			return presenters.PresentKafkaUsageList(usages), nil
+		},
+	}
+
+	if format == kafkaUsageFormatCSV {
+		handlers.HandleGetCSV(w, r, cfg)
+		return
+	}
+
+	handlers.HandleGet(w, r, cfg)
+}
+
+// kafkaUsageFormat returns the format requested through the format query parameter, falling back to the Accept header
+func kafkaUsageFormat(r *http.Request) string {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return strings.ToLower(format)
+	}
+
+	if strings.Contains(r.Header.Get("Accept"), "text/csv") {
+		return kafkaUsageFormatCSV
+	}
+
+	return kafkaUsageFormatJSON
+}
+
+func validateKafkaUsageFormat(format string) handlers.Validate {
+	return func() *errors.ServiceError {
+		if format != kafkaUsageFormatJSON && format != kafkaUsageFormatCSV {
+			return errors.BadRequest("format %q is not supported, it must be either %q or %q", format, kafkaUsageFormatJSON, kafkaUsageFormatCSV)
+		}
+
+		return nil
+	}
+}
+
+// validateKafkaUsagePeriod parses the from and to days of the requested usage into from and to.
+// to defaults to the current UTC day and from to defaultKafkaUsagePeriodDays days before to
+func validateKafkaUsagePeriod(fromParam, toParam string, from, to *time.Time) handlers.Validate {
+	return func() *errors.ServiceError {
+		*to = services.TruncateToUTCDay(time.Now())
+		if toParam != "" {
+			parsed, err := time.Parse(services.UsageDateLayout, toParam)
+			if err != nil {
+				return errors.BadRequest("to %q must be a day in the YYYY-MM-DD format", toParam)
+			}
+			*to = parsed
+		}
+
+		*from = to.AddDate(0, 0, -defaultKafkaUsagePeriodDays)
+		if fromParam != "" {
+			parsed, err := time.Parse(services.UsageDateLayout, fromParam)
+			if err != nil {
+				return errors.BadRequest("from %q must be a day in the YYYY-MM-DD format", fromParam)
+			}
+			*from = parsed
+		}
+
+		if from.After(*to) {
+			return errors.BadRequest("from %q must not be after to %q", from.Format(services.UsageDateLayout), to.Format(services.UsageDateLayout))
+		}
+
+		if to.Sub(*from) > maxKafkaUsagePeriodDays*24*time.Hour {
+			return errors.BadRequest("the usage of at most %d days can be requested at once", maxKafkaUsagePeriodDays)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/onsi/gomega"
+)
+
+func Test_adminUsageHandler_Get(t *testing.T) {
+	usageDate := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	usages := dbapi.KafkaUsageDailyList{
+		{
+			UsageDate:      usageDate,
+			OrganisationId: "org-id",
+			InstanceType:   "standard",
+			SizeId:         "x1",
+			BillingModel:   "standard",
+			KafkaCount:     2,
+			StreamingUnits: 2,
+		},
+	}
+
+	tests := []struct {
+		name            string
+		url             string
+		accept          string
+		listErr         *errors.ServiceError
+		wantStatusCode  int
+		wantContentType string
+		wantBody        string
+		wantOrgID       string
+		wantFrom        time.Time
+		wantTo          time.Time
+	}{
+		{
+			name:           "should return bad request if from is not a valid day",
+			url:            "/usage?from=01-03-2023",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should return bad request if to is not a valid day",
+			url:            "/usage?to=2023-03-01T00:00:00Z",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should return bad request if from is after to",
+			url:            "/usage?from=2023-03-02&to=2023-03-01",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should return bad request if too many days are requested",
+			url:            "/usage?from=2022-01-01&to=2023-03-01",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should return bad request if the format is not supported",
+			url:            "/usage?format=xml",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should return the error returned when listing the usage",
+			url:            "/usage?from=2023-03-01&to=2023-03-01",
+			listErr:        errors.GeneralError("failed to list the usage"),
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:            "should return the usage as json by default and default from to 30 days before to",
+			url:             "/usage?org_id=org-id&to=2023-03-31",
+			wantStatusCode:  http.StatusOK,
+			wantContentType: "application/json",
+			wantOrgID:       "org-id",
+			wantFrom:        usageDate,
+			wantTo:          time.Date(2023, 3, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:            "should return the usage as csv when requested by the format query parameter",
+			url:             "/usage?from=2023-03-01&to=2023-03-01&format=csv",
+			wantStatusCode:  http.StatusOK,
+			wantContentType: "text/csv",
+			wantBody:        "date,organisation_id,instance_type,size_id,billing_model,marketplace,billing_cloud_account_id,kafka_count,streaming_units\n2023-03-01,org-id,standard,x1,standard,,,2,2\n",
+			wantFrom:        usageDate,
+			wantTo:          usageDate,
+		},
+		{
+			name:            "should return the usage as csv when requested by the Accept header",
+			url:             "/usage?from=2023-03-01&to=2023-03-01",
+			accept:          "text/csv",
+			wantStatusCode:  http.StatusOK,
+			wantContentType: "text/csv",
+			wantFrom:        usageDate,
+			wantTo:          usageDate,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			kafkaUsageService := &services.KafkaUsageServiceMock{
+				ListDailyUsageFunc: func(orgID string, from, to time.Time) (dbapi.KafkaUsageDailyList, *errors.ServiceError) {
+					if tt.listErr != nil {
+						return nil, tt.listErr
+					}
+					return usages, nil
+				},
+			}
+
+			h := NewAdminUsageHandler(kafkaUsageService)
+			req, rw := GetHandlerParams("GET", tt.url, nil, t)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			h.Get(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+
+			if tt.wantContentType == "" {
+				return
+			}
+
+			g.Expect(resp.Header.Get("Content-Type")).To(gomega.Equal(tt.wantContentType))
+			calls := kafkaUsageService.ListDailyUsageCalls()
+			g.Expect(calls).To(gomega.HaveLen(1))
+			g.Expect(calls[0].OrgID).To(gomega.Equal(tt.wantOrgID))
+			g.Expect(calls[0].From).To(gomega.Equal(tt.wantFrom))
+			g.Expect(calls[0].To).To(gomega.Equal(tt.wantTo))
+
+			if tt.wantBody != "" {
+				g.Expect(rw.Body.String()).To(gomega.Equal(tt.wantBody))
+			}
+
+			if tt.wantContentType == "application/json" {
+				var list private.KafkaUsageList
+				g.Expect(json.NewDecoder(resp.Body).Decode(&list)).To(gomega.Succeed())
+				g.Expect(list.Kind).To(gomega.Equal("KafkaUsageList"))
+				g.Expect(list.Items).To(gomega.HaveLen(1))
+				g.Expect(list.Items[0].Date).To(gomega.Equal("2023-03-01"))
+				g.Expect(list.Items[0].StreamingUnits).To(gomega.Equal(int32(2)))
+			}
+		})
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/presenters"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db/dbencryption"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
@@ -82,8 +84,15 @@ func (h clusterHandler) RegisterEnterpriseCluster(w http.ResponseWriter, r *http
 				return nil, errors.GeneralError(getOrgIdErr.Error())
 			}
 
-			if !claims.IsOrgAdmin() {
-				return nil, errors.New(errors.ErrorUnauthorized, "non admin user not authorized to perform this action")
+			if err := auth.RequireAuthorization(ctx, auth.ActionCreate, auth.AuthorizationResource{
+				Type: auth.ResourceEnterpriseCluster,
+				Id:   clusterPayload.ClusterId,
+				Attributes: map[string]interface{}{
+					"cloud_provider": clusterSpec.CloudProvider,
+					"region":         clusterSpec.Region,
+				},
+			}, errors.ErrorUnauthorized, "non admin user not authorized to perform this action"); err != nil {
+				return nil, err
 			}
 
 			supportedKafkaInstanceType := api.StandardTypeSupport.String()
@@ -120,7 +129,7 @@ func (h clusterHandler) RegisterEnterpriseCluster(w http.ResponseWriter, r *http
 			}
 
 			clusterRequest.ClientID = fsoParams.GetParam(services.KasFleetshardOperatorParamServiceAccountId)
-			clusterRequest.ClientSecret = fsoParams.GetParam(services.KasFleetshardOperatorParamServiceAccountSecret)
+			clusterRequest.ClientSecret = dbencryption.EncryptedString(fsoParams.GetParam(services.KasFleetshardOperatorParamServiceAccountSecret))
 
 			svcErr = h.clusterService.RegisterClusterJob(clusterRequest)
 			if svcErr != nil {
@@ -243,8 +252,9 @@ func (h clusterHandler) GetEnterpriseClusterWithAddonParams(w http.ResponseWrite
 			claims, _ := getClaims(ctx)
 			orgID, _ := claims.GetOrgId()
 
-			if !claims.IsOrgAdmin() {
-				return nil, errors.New(errors.ErrorUnauthorized, "non admin user not authorized to perform this action")
+			if err := auth.RequireAuthorization(ctx, auth.ActionGet, auth.AuthorizationResource{Type: auth.ResourceEnterpriseCluster, Id: clusterID},
+				errors.ErrorUnauthorized, "non admin user not authorized to perform this action"); err != nil {
+				return nil, err
 			}
 
 			cluster, err := h.clusterService.FindClusterByID(clusterID)
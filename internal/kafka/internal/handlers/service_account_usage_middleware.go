@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+)
+
+// NewServiceAccountUsageMiddleware returns a middleware recording the last time the service accounts called the API,
+// the service account is identified by the clientId claim of the token
+func NewServiceAccountUsageMiddleware(lifecycleService services.ServiceAccountLifecycleService) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if claims, err := auth.GetClaimsFromContext(r.Context()); err == nil {
+				if clientId, err := claims.GetClientID(); err == nil && clientId != "" {
+					lifecycleService.RecordUsage(clientId, time.Now())
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -1,13 +1,18 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
 
@@ -16,12 +21,14 @@ import (
 )
 
 type serviceAccountsHandler struct {
-	service sso.KeycloakService
+	service          sso.KeycloakService
+	lifecycleService services.ServiceAccountLifecycleService
 }
 
-func NewServiceAccountHandler(service sso.KafkaKeycloakService) *serviceAccountsHandler {
+func NewServiceAccountHandler(service sso.KafkaKeycloakService, lifecycleService services.ServiceAccountLifecycleService) *serviceAccountsHandler {
 	return &serviceAccountsHandler{
-		service: service,
+		service:          service,
+		lifecycleService: lifecycleService,
 	}
 }
 
@@ -39,6 +46,11 @@ func (s serviceAccountsHandler) ListServiceAccounts(w http.ResponseWriter, r *ht
 				return nil, err
 			}
 
+			lifecycles, err := s.lifecycleService.Lifecycles(ctx, sa)
+			if err != nil {
+				return nil, err
+			}
+
 			serviceAccountList := public.ServiceAccountList{
 				Kind:  "ServiceAccountList",
 				Items: []public.ServiceAccountListItem{},
@@ -46,7 +58,7 @@ func (s serviceAccountsHandler) ListServiceAccounts(w http.ResponseWriter, r *ht
 
 			for i := range sa {
 				account := sa[i]
-				converted := presenters.PresentServiceAccountListItem(&account)
+				converted := presenters.PresentServiceAccountListItem(&account, lifecycles[account.ID])
 				serviceAccountList.Items = append(serviceAccountList.Items, converted)
 			}
 
@@ -77,6 +89,7 @@ func (s serviceAccountsHandler) CreateServiceAccount(w http.ResponseWriter, r *h
 			handlers.ValidateMaxLength(&serviceAccountRequest.Description, "description", &handlers.MaxServiceAccountDescLength),
 			handlers.ValidateServiceAccountName(&serviceAccountRequest.Name, "name"),
 			handlers.ValidateServiceAccountDesc(&serviceAccountRequest.Description, "description"),
+			validateServiceAccountExpiry(&serviceAccountRequest.ExpiresAt),
 		},
 		Action: func() (interface{}, *errors.ServiceError) {
 			ctx := r.Context()
@@ -85,7 +98,11 @@ func (s serviceAccountsHandler) CreateServiceAccount(w http.ResponseWriter, r *h
 			if err != nil {
 				return nil, err
 			}
-			return presenters.PresentServiceAccount(serviceAccount), nil
+			lifecycle, err := s.lifecycleService.Track(ctx, serviceAccount, serviceAccountRequest.ExpiresAt)
+			if err != nil {
+				return nil, err
+			}
+			return presenters.PresentServiceAccount(serviceAccount, lifecycle), nil
 		},
 	}
 	handlers.Handle(w, r, cfg, http.StatusAccepted)
@@ -100,8 +117,10 @@ func (s serviceAccountsHandler) DeleteServiceAccount(w http.ResponseWriter, r *h
 		},
 		Action: func() (interface{}, *errors.ServiceError) {
 			ctx := r.Context()
-			err := s.service.DeleteServiceAccount(ctx, id)
-			return nil, err
+			if err := s.service.DeleteServiceAccount(ctx, id); err != nil {
+				return nil, err
+			}
+			return nil, s.lifecycleService.Untrack(id)
 		},
 	}
 
@@ -117,11 +136,18 @@ func (s serviceAccountsHandler) ResetServiceAccountCredential(w http.ResponseWri
 		},
 		Action: func() (interface{}, *errors.ServiceError) {
 			ctx := r.Context()
+			if err := s.lifecycleService.CheckCredentialsReset(id); err != nil {
+				return nil, err
+			}
 			sa, err := s.service.ResetServiceAccountCredentials(ctx, id)
 			if err != nil {
 				return nil, err
 			}
-			return presenters.PresentServiceAccount(sa), nil
+			lifecycle, err := s.lifecycleService.CredentialsReset(ctx, sa)
+			if err != nil {
+				return nil, err
+			}
+			return presenters.PresentServiceAccount(sa, lifecycle), nil
 		},
 	}
 
@@ -151,7 +177,12 @@ func (s serviceAccountsHandler) GetServiceAccountByClientId(w http.ResponseWrite
 				return nil, err
 			}
 
-			converted := presenters.PresentServiceAccountListItem(sa)
+			lifecycles, err := s.lifecycleService.Lifecycles(ctx, []api.ServiceAccount{*sa})
+			if err != nil {
+				return nil, err
+			}
+
+			converted := presenters.PresentServiceAccountListItem(sa, lifecycles[sa.ID])
 			serviceAccountList.Items = append(serviceAccountList.Items, converted)
 			return serviceAccountList, nil
 		},
@@ -173,7 +204,15 @@ func (s serviceAccountsHandler) GetServiceAccountById(w http.ResponseWriter, r *
 			if err != nil {
 				return nil, err
 			}
-			return presenters.PresentServiceAccount(sa), nil
+			lifecycles, err := s.lifecycleService.Lifecycles(ctx, []api.ServiceAccount{*sa})
+			if err != nil {
+				return nil, err
+			}
+			lifecycle := lifecycles[sa.ID]
+			if lifecycle != nil && lifecycle.RotatedClientSecret != "" && isServiceAccountOwner(ctx, lifecycle) {
+				sa.ClientSecret = string(lifecycle.RotatedClientSecret)
+			}
+			return presenters.PresentServiceAccount(sa, lifecycle), nil
 		},
 	}
 
@@ -199,3 +238,22 @@ func (s serviceAccountsHandler) GetSsoProviders(w http.ResponseWriter, r *http.R
 
 	handlers.HandleGet(w, r, cfg)
 }
+
+// isServiceAccountOwner returns whether the user of the request is the owner of the service account of the lifecycle
+func isServiceAccountOwner(ctx context.Context, lifecycle *dbapi.ServiceAccountLifecycle) bool {
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil {
+		return false
+	}
+	username, err := claims.GetUsername()
+	return err == nil && username != "" && username == lifecycle.Owner
+}
+
+func validateServiceAccountExpiry(expiresAt **time.Time) handlers.Validate {
+	return func() *errors.ServiceError {
+		if *expiresAt != nil && !(*expiresAt).After(time.Now()) {
+			return errors.FieldValidationError("expires_at must be in the future")
+		}
+		return nil
+	}
+}
@@ -3,25 +3,54 @@ package handlers
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/url"
 	"testing"
+	"time"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/keycloak"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/gorilla/mux"
 	"github.com/onsi/gomega"
 )
 
 var (
-	createServiceAccountRequest = `{"name": "my-app-sa","description": "service account for my app"}`
+	createServiceAccountRequest        = `{"name": "my-app-sa","description": "service account for my app"}`
+	createExpiredServiceAccountRequest = `{"name": "my-app-sa","description": "service account for my app","expires_at": "2020-01-01T00:00:00Z"}`
 )
 
+func newServiceAccountLifecycleServiceMock() *services.ServiceAccountLifecycleServiceMock {
+	return &services.ServiceAccountLifecycleServiceMock{
+		TrackFunc: func(ctx context.Context, account *api.ServiceAccount, expiresAt *time.Time) (*dbapi.ServiceAccountLifecycle, *errors.ServiceError) {
+			return &dbapi.ServiceAccountLifecycle{ExpiresAt: expiresAt, Status: dbapi.ServiceAccountLifecycleActive}, nil
+		},
+		LifecyclesFunc: func(ctx context.Context, accounts []api.ServiceAccount) (map[string]*dbapi.ServiceAccountLifecycle, *errors.ServiceError) {
+			return map[string]*dbapi.ServiceAccountLifecycle{}, nil
+		},
+		CheckCredentialsResetFunc: func(id string) *errors.ServiceError {
+			return nil
+		},
+		CredentialsResetFunc: func(ctx context.Context, account *api.ServiceAccount) (*dbapi.ServiceAccountLifecycle, *errors.ServiceError) {
+			return &dbapi.ServiceAccountLifecycle{CredentialsRotatedAt: time.Now()}, nil
+		},
+		UntrackFunc: func(id string) *errors.ServiceError {
+			return nil
+		},
+	}
+}
+
 func TestNewServiceAccountHandler(t *testing.T) {
 	type args struct {
-		service sso.KafkaKeycloakService
+		service          sso.KafkaKeycloakService
+		lifecycleService services.ServiceAccountLifecycleService
 	}
 	tests := []struct {
 		name string
@@ -31,10 +60,12 @@ func TestNewServiceAccountHandler(t *testing.T) {
 		{
 			name: "should return a NewServiceAccountHandler",
 			args: args{
-				service: &sso.KeycloakServiceMock{},
+				service:          &sso.KeycloakServiceMock{},
+				lifecycleService: &services.ServiceAccountLifecycleServiceMock{},
 			},
 			want: &serviceAccountsHandler{
-				service: &sso.KeycloakServiceMock{},
+				service:          &sso.KeycloakServiceMock{},
+				lifecycleService: &services.ServiceAccountLifecycleServiceMock{},
 			},
 		},
 	}
@@ -44,7 +75,7 @@ func TestNewServiceAccountHandler(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			g := gomega.NewWithT(t)
-			g.Expect(NewServiceAccountHandler(tt.args.service)).To(gomega.Equal(tt.want))
+			g.Expect(NewServiceAccountHandler(tt.args.service, tt.args.lifecycleService)).To(gomega.Equal(tt.want))
 		})
 	}
 }
@@ -112,7 +143,7 @@ func Test_serviceAccountsHandler_ListServiceAccounts(t *testing.T) {
 			g := gomega.NewWithT(t)
 			req, rw := GetHandlerParams("GET", tt.args.url, nil, t)
 
-			h := NewServiceAccountHandler(tt.fields.service)
+			h := NewServiceAccountHandler(tt.fields.service, newServiceAccountLifecycleServiceMock())
 			h.ListServiceAccounts(rw, req)
 			resp := rw.Result()
 			resp.Body.Close()
@@ -123,7 +154,8 @@ func Test_serviceAccountsHandler_ListServiceAccounts(t *testing.T) {
 
 func Test_serviceAccountsHandler_CreateServiceAccount(t *testing.T) {
 	type fields struct {
-		service sso.KeycloakService
+		service          sso.KeycloakService
+		lifecycleService services.ServiceAccountLifecycleService
 	}
 	type args struct {
 		url  string
@@ -141,6 +173,7 @@ func Test_serviceAccountsHandler_CreateServiceAccount(t *testing.T) {
 				service: &sso.KeycloakServiceMock{CreateServiceAccountFunc: func(serviceAccountRequest *api.ServiceAccountRequest, ctx context.Context) (*api.ServiceAccount, *errors.ServiceError) {
 					return &api.ServiceAccount{}, nil
 				}},
+				lifecycleService: newServiceAccountLifecycleServiceMock(),
 			},
 			args: args{
 				url:  "/api/kafkas_mgmt/v1/service_accounts",
@@ -148,12 +181,43 @@ func Test_serviceAccountsHandler_CreateServiceAccount(t *testing.T) {
 			},
 			wantStatusCode: http.StatusAccepted,
 		},
+		{
+			name: "should return status code 400 if the expiry of the service account is in the past",
+			fields: fields{
+				service:          &sso.KeycloakServiceMock{},
+				lifecycleService: newServiceAccountLifecycleServiceMock(),
+			},
+			args: args{
+				url:  "/api/kafkas_mgmt/v1/service_accounts",
+				body: []byte(createExpiredServiceAccountRequest),
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should return status code 500 if it fails to track the lifecycle of the service account",
+			fields: fields{
+				service: &sso.KeycloakServiceMock{CreateServiceAccountFunc: func(serviceAccountRequest *api.ServiceAccountRequest, ctx context.Context) (*api.ServiceAccount, *errors.ServiceError) {
+					return &api.ServiceAccount{}, nil
+				}},
+				lifecycleService: &services.ServiceAccountLifecycleServiceMock{
+					TrackFunc: func(ctx context.Context, account *api.ServiceAccount, expiresAt *time.Time) (*dbapi.ServiceAccountLifecycle, *errors.ServiceError) {
+						return nil, errors.GeneralError("failed to track the service account")
+					},
+				},
+			},
+			args: args{
+				url:  "/api/kafkas_mgmt/v1/service_accounts",
+				body: []byte(createServiceAccountRequest),
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
 		{
 			name: "should return status code 500 if it fails to create the service account",
 			fields: fields{
 				service: &sso.KeycloakServiceMock{CreateServiceAccountFunc: func(serviceAccountRequest *api.ServiceAccountRequest, ctx context.Context) (*api.ServiceAccount, *errors.ServiceError) {
 					return nil, errors.GeneralError("error creating service account")
 				}},
+				lifecycleService: newServiceAccountLifecycleServiceMock(),
 			},
 			args: args{
 				url:  "/api/kafkas_mgmt/v1/service_accounts",
@@ -170,7 +234,7 @@ func Test_serviceAccountsHandler_CreateServiceAccount(t *testing.T) {
 			g := gomega.NewWithT(t)
 			req, rw := GetHandlerParams("POST", tt.args.url, bytes.NewBuffer(tt.args.body), t)
 
-			h := NewServiceAccountHandler(tt.fields.service)
+			h := NewServiceAccountHandler(tt.fields.service, tt.fields.lifecycleService)
 			h.CreateServiceAccount(rw, req)
 			resp := rw.Result()
 			resp.Body.Close()
@@ -230,7 +294,7 @@ func Test_serviceAccountsHandler_DeleteServiceAccount(t *testing.T) {
 			req, rw := GetHandlerParams("DELETE", tt.args.url, nil, t)
 			req = mux.SetURLVars(req, map[string]string{"id": "b5843c4b-a702-100d-fc77-70e9b20e554f"})
 
-			h := NewServiceAccountHandler(tt.fields.service)
+			h := NewServiceAccountHandler(tt.fields.service, newServiceAccountLifecycleServiceMock())
 			h.DeleteServiceAccount(rw, req)
 			resp := rw.Result()
 			resp.Body.Close()
@@ -241,7 +305,8 @@ func Test_serviceAccountsHandler_DeleteServiceAccount(t *testing.T) {
 
 func Test_serviceAccountsHandler_ResetServiceAccountCredential(t *testing.T) {
 	type fields struct {
-		service sso.KeycloakService
+		service          sso.KeycloakService
+		lifecycleService services.ServiceAccountLifecycleService
 	}
 	type args struct {
 		url string
@@ -260,6 +325,7 @@ func Test_serviceAccountsHandler_ResetServiceAccountCredential(t *testing.T) {
 						return &api.ServiceAccount{}, nil
 					},
 				},
+				lifecycleService: newServiceAccountLifecycleServiceMock(),
 			},
 			args: args{
 				url: "/api/kafkas_mgmt/v1/service_accounts/{id}/reset_credentials",
@@ -274,12 +340,28 @@ func Test_serviceAccountsHandler_ResetServiceAccountCredential(t *testing.T) {
 						return nil, errors.GeneralError("failed to reset the service account credentials")
 					},
 				},
+				lifecycleService: newServiceAccountLifecycleServiceMock(),
 			},
 			args: args{
 				url: "/api/kafkas_mgmt/v1/service_accounts/{id}/reset_credentials",
 			},
 			wantStatusCode: http.StatusInternalServerError,
 		},
+		{
+			name: "should return status code 403 if the credentials of the service account are revoked",
+			fields: fields{
+				service: &sso.KeycloakServiceMock{},
+				lifecycleService: &services.ServiceAccountLifecycleServiceMock{
+					CheckCredentialsResetFunc: func(id string) *errors.ServiceError {
+						return errors.Forbidden("the credentials of service account %s are revoked", id)
+					},
+				},
+			},
+			args: args{
+				url: "/api/kafkas_mgmt/v1/service_accounts/{id}/reset_credentials",
+			},
+			wantStatusCode: http.StatusForbidden,
+		},
 	}
 
 	for _, testcase := range tests {
@@ -290,7 +372,7 @@ func Test_serviceAccountsHandler_ResetServiceAccountCredential(t *testing.T) {
 			req, rw := GetHandlerParams("POST", tt.args.url, nil, t)
 			req = mux.SetURLVars(req, map[string]string{"id": "b5843c4b-a702-100d-fc77-70e9b20e554f"})
 
-			h := NewServiceAccountHandler(tt.fields.service)
+			h := NewServiceAccountHandler(tt.fields.service, tt.fields.lifecycleService)
 			h.ResetServiceAccountCredential(rw, req)
 			resp := rw.Result()
 			resp.Body.Close()
@@ -362,7 +444,7 @@ func Test_serviceAccountsHandler_GetServiceAccountByClientId(t *testing.T) {
 			req.Form = url.Values{}
 			req.Form.Add("client_id", "srvc-acct-7f4f2226-f0cc-7f40-8d74-9b38934d2be0")
 
-			h := NewServiceAccountHandler(tt.fields.service)
+			h := NewServiceAccountHandler(tt.fields.service, newServiceAccountLifecycleServiceMock())
 			h.GetServiceAccountByClientId(rw, req)
 			resp := rw.Result()
 			resp.Body.Close()
@@ -372,17 +454,34 @@ func Test_serviceAccountsHandler_GetServiceAccountByClientId(t *testing.T) {
 }
 
 func Test_serviceAccountsHandler_GetServiceAccountById(t *testing.T) {
+	rotatedLifecycleService := func() *services.ServiceAccountLifecycleServiceMock {
+		lifecycleService := newServiceAccountLifecycleServiceMock()
+		lifecycleService.LifecyclesFunc = func(ctx context.Context, accounts []api.ServiceAccount) (map[string]*dbapi.ServiceAccountLifecycle, *errors.ServiceError) {
+			return map[string]*dbapi.ServiceAccountLifecycle{
+				accounts[0].ID: {Owner: "owner-user", Status: dbapi.ServiceAccountLifecycleActive, RotatedClientSecret: "rotated-secret"},
+			}, nil
+		}
+		return lifecycleService
+	}
+	getServiceAccount := &sso.KeycloakServiceMock{
+		GetServiceAccountByIdFunc: func(ctx context.Context, id string) (*api.ServiceAccount, *errors.ServiceError) {
+			return &api.ServiceAccount{ID: id}, nil
+		},
+	}
 	type fields struct {
-		service sso.KeycloakService
+		service          sso.KeycloakService
+		lifecycleService services.ServiceAccountLifecycleService
 	}
 	type args struct {
-		url string
+		url      string
+		username string
 	}
 	tests := []struct {
-		name           string
-		fields         fields
-		args           args
-		wantStatusCode int
+		name             string
+		fields           fields
+		args             args
+		wantStatusCode   int
+		wantClientSecret string
 	}{
 		{
 			name: "should return status code 200 if it successfully gets the service account by id",
@@ -392,12 +491,38 @@ func Test_serviceAccountsHandler_GetServiceAccountById(t *testing.T) {
 						return &api.ServiceAccount{}, nil
 					},
 				},
+				lifecycleService: newServiceAccountLifecycleServiceMock(),
 			},
 			args: args{
 				url: "/api/kafkas_mgmt/v1/service_accounts/{id}",
 			},
 			wantStatusCode: http.StatusOK,
 		},
+		{
+			name: "should return the secret of the rotated credentials to the owner of the service account",
+			fields: fields{
+				service:          getServiceAccount,
+				lifecycleService: rotatedLifecycleService(),
+			},
+			args: args{
+				url:      "/api/kafkas_mgmt/v1/service_accounts/{id}",
+				username: "owner-user",
+			},
+			wantStatusCode:   http.StatusOK,
+			wantClientSecret: "rotated-secret",
+		},
+		{
+			name: "should not return the secret of the rotated credentials to another user",
+			fields: fields{
+				service:          getServiceAccount,
+				lifecycleService: rotatedLifecycleService(),
+			},
+			args: args{
+				url:      "/api/kafkas_mgmt/v1/service_accounts/{id}",
+				username: "other-user",
+			},
+			wantStatusCode: http.StatusOK,
+		},
 		{
 			name: "should return status code 500 if it fails to get the service account by id",
 			fields: fields{
@@ -406,6 +531,7 @@ func Test_serviceAccountsHandler_GetServiceAccountById(t *testing.T) {
 						return nil, errors.GeneralError("failed to get service acccont")
 					},
 				},
+				lifecycleService: newServiceAccountLifecycleServiceMock(),
 			},
 			args: args{
 				url: "/api/kafkas_mgmt/v1/service_accounts/{id}",
@@ -421,12 +547,20 @@ func Test_serviceAccountsHandler_GetServiceAccountById(t *testing.T) {
 			g := gomega.NewWithT(t)
 			req, rw := GetHandlerParams("GET", tt.args.url, nil, t)
 			req = mux.SetURLVars(req, map[string]string{"id": "b5843c4b-a702-100d-fc77-70e9b20e554f"})
+			if tt.args.username != "" {
+				req = req.WithContext(auth.SetTokenInContext(req.Context(), &jwt.Token{Claims: jwt.MapClaims{"username": tt.args.username}}))
+			}
 
-			h := NewServiceAccountHandler(tt.fields.service)
+			h := NewServiceAccountHandler(tt.fields.service, tt.fields.lifecycleService)
 			h.GetServiceAccountById(rw, req)
 			resp := rw.Result()
-			resp.Body.Close()
+			defer resp.Body.Close()
 			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantStatusCode == http.StatusOK {
+				var sa public.ServiceAccount
+				g.Expect(json.NewDecoder(resp.Body).Decode(&sa)).To(gomega.Succeed())
+				g.Expect(sa.ClientSecret).To(gomega.Equal(tt.wantClientSecret))
+			}
 		})
 	}
 }
@@ -470,7 +604,7 @@ func Test_serviceAccountsHandler_GetSsoProviders(t *testing.T) {
 			g := gomega.NewWithT(t)
 			req, rw := GetHandlerParams("GET", tt.args.url, nil, t)
 
-			h := NewServiceAccountHandler(tt.fields.service)
+			h := NewServiceAccountHandler(tt.fields.service, newServiceAccountLifecycleServiceMock())
 			h.GetSsoProviders(rw, req)
 			resp := rw.Result()
 			resp.Body.Close()
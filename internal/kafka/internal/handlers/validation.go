@@ -329,20 +329,11 @@ func stringSet(value *string) bool {
 
 func validateUserIsKafkaOwnerOrOrgAdmin(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) handlers.Validate {
 	return func() *errors.ServiceError {
-		claims, claimsErr := getClaims(ctx)
-		if claimsErr != nil {
+		if _, claimsErr := getClaims(ctx); claimsErr != nil {
 			return claimsErr
 		}
-
-		username, _ := claims.GetUsername()
-		orgID, _ := claims.GetOrgId()
-		isOrgAdmin := claims.IsOrgAdmin()
-
-		authorized := kafkaRequest.OrganisationId == orgID && (isOrgAdmin || kafkaRequest.Owner == username)
-		if !authorized {
-			return errors.New(errors.ErrorUnauthorized, "user not authorized to perform this action")
-		}
-		return nil
+		return auth.RequireAuthorization(ctx, auth.ActionUpdate, services.KafkaAuthorizationResource(kafkaRequest),
+			errors.ErrorUnauthorized, "user not authorized to perform this action")
 	}
 }
 
@@ -470,8 +461,9 @@ func validateEnterpriseClusterEligibleForDeregistration(ctx context.Context, clu
 			return errors.GeneralError(getOrgIdErr.Error())
 		}
 
-		if !claims.IsOrgAdmin() {
-			return errors.New(errors.ErrorUnauthorized, "non admin user not authorized to perform this action")
+		if err := auth.RequireAuthorization(ctx, auth.ActionDelete, auth.AuthorizationResource{Type: auth.ResourceEnterpriseCluster, Id: clusterID},
+			errors.ErrorUnauthorized, "non admin user not authorized to perform this action"); err != nil {
+			return err
 		}
 
 		cluster, err := clusterService.FindClusterByID(clusterID)
@@ -606,3 +598,34 @@ func validateKafkaPromoteRequestFields(kafkaPromoteRequest *public.KafkaPromoteR
 		return nil
 	}
 }
+
+func validatePlacementSimulationRequest(simulationReq *private.PlacementSimulationRequest) handlers.Validate {
+	return func() *errors.ServiceError {
+		if len(simulationReq.Kafkas) == 0 {
+			return errors.FieldValidationError("at least one kafka must be provided")
+		}
+
+		total := 0
+		for i, kafka := range simulationReq.Kafkas {
+			if kafka.Region == "" || kafka.InstanceType == "" || kafka.SizeId == "" {
+				return errors.FieldValidationError("region, instance_type and size_id of the kafka at index %d must be provided", i)
+			}
+
+			if kafka.Count < 0 {
+				return errors.FieldValidationError("count of the kafka at index %d must not be negative", i)
+			}
+
+			if kafka.Count == 0 {
+				total++
+			} else {
+				total += int(kafka.Count)
+			}
+		}
+
+		if total > maxPlacementSimulationKafkas {
+			return errors.FieldValidationError("a placement simulation is limited to %d kafkas, %d were requested", maxPlacementSimulationKafkas, total)
+		}
+
+		return nil
+	}
+}
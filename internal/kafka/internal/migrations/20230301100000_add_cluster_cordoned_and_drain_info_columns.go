@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addClusterCordonedAndDrainInfoColumns() *gormigrate.Migration {
+	type Cluster struct {
+		Cordoned  bool   `gorm:"index;default:false"`
+		DrainInfo string `json:"drain_info" gorm:"type:jsonb"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "20230301100000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&Cluster{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, column := range []string{"cordoned", "drain_info"} {
+				if !tx.Migrator().HasColumn(&Cluster{}, column) {
+					continue
+				}
+				if err := tx.Migrator().DropColumn(&Cluster{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addPlacementDecisionInKafkaRequestsTable() *gormigrate.Migration {
+	type KafkaRequest struct {
+		PlacementDecision string `json:"placement_decision" gorm:"type:jsonb"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "20230315100000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&KafkaRequest{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&KafkaRequest{}, "placement_decision") {
+				return tx.Migrator().DropColumn(&KafkaRequest{}, "placement_decision")
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,38 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+type KafkaUsageDaily20230320100000 struct {
+	db.Model
+	UsageDate             time.Time `gorm:"type:date;uniqueIndex:uix_kafka_usage_daily"`
+	OrganisationId        string    `gorm:"uniqueIndex:uix_kafka_usage_daily;index"`
+	InstanceType          string    `gorm:"uniqueIndex:uix_kafka_usage_daily"`
+	SizeId                string    `gorm:"uniqueIndex:uix_kafka_usage_daily"`
+	BillingModel          string    `gorm:"uniqueIndex:uix_kafka_usage_daily"`
+	Marketplace           string    `gorm:"uniqueIndex:uix_kafka_usage_daily"`
+	BillingCloudAccountId string    `gorm:"uniqueIndex:uix_kafka_usage_daily"`
+	KafkaCount            int
+	StreamingUnits        int
+}
+
+func (KafkaUsageDaily20230320100000) TableName() string {
+	return "kafka_usage_daily"
+}
+
+func addKafkaUsageDailyTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20230320100000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&KafkaUsageDaily20230320100000{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&KafkaUsageDaily20230320100000{})
+		},
+	}
+}
@@ -0,0 +1,60 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+type AuditEvent20230510100000 struct {
+	ID             string    `gorm:"primaryKey"`
+	CreatedAt      time.Time `gorm:"index"`
+	Service        string    `gorm:"index"`
+	Actor          string    `gorm:"index"`
+	OrganisationId string    `gorm:"index"`
+	Claims         []byte    `gorm:"type:jsonb"`
+	Method         string
+	Route          string
+	Path           string
+	ResourceId     string `gorm:"index"`
+	RequestBody    []byte `gorm:"type:jsonb"`
+	Diff           []byte `gorm:"type:jsonb"`
+	StatusCode     int
+	Outcome        string
+	OperationId    string `gorm:"index"`
+	RemoteAddr     string
+}
+
+func (AuditEvent20230510100000) TableName() string {
+	return "audit_events"
+}
+
+// the audit events are append only, they can only be deleted by the retention workers once expired
+const createAuditEventsAppendOnlyTrigger = `
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+	RAISE EXCEPTION 'audit events are append only';
+END;
+$$ LANGUAGE plpgsql;
+DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events;
+CREATE TRIGGER audit_events_append_only BEFORE UPDATE ON audit_events FOR EACH ROW EXECUTE PROCEDURE audit_events_append_only();
+`
+
+func addAuditEventsTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20230510100000",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&AuditEvent20230510100000{}); err != nil {
+				return err
+			}
+			return tx.Exec(createAuditEventsAppendOnlyTrigger).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropTable(&AuditEvent20230510100000{}); err != nil {
+				return err
+			}
+			return tx.Exec("DROP FUNCTION IF EXISTS audit_events_append_only()").Error
+		},
+	}
+}
@@ -0,0 +1,59 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+type WebhookSubscription20230517100000 struct {
+	db.Model
+	Service        string `gorm:"index"`
+	OrganisationId string `gorm:"index"`
+	Owner          string
+	Url            string
+	EventTypes     []byte `gorm:"type:jsonb"`
+	Secret         string
+}
+
+func (WebhookSubscription20230517100000) TableName() string {
+	return "webhook_subscriptions"
+}
+
+type WebhookDelivery20230517100000 struct {
+	ID             string `gorm:"primaryKey"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	Service        string `gorm:"index"`
+	SubscriptionId string `gorm:"index"`
+	EventId        string
+	EventType      string
+	ResourceId     string
+	Payload        []byte `gorm:"type:jsonb"`
+	Status         string `gorm:"index"`
+	Attempts       int
+	NextAttemptAt  *time.Time `gorm:"index"`
+	LastAttemptAt  *time.Time
+	LastStatusCode int
+	LastError      string
+	DeliveredAt    *time.Time
+	RedeliveryOf   string
+}
+
+func (WebhookDelivery20230517100000) TableName() string {
+	return "webhook_deliveries"
+}
+
+func addWebhookTables() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20230517100000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&WebhookSubscription20230517100000{}, &WebhookDelivery20230517100000{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&WebhookDelivery20230517100000{}, &WebhookSubscription20230517100000{})
+		},
+	}
+}
@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+type OutboxMessage20230524100000 struct {
+	ID             string `gorm:"primaryKey"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	Type           string `gorm:"index"`
+	IdempotencyKey string `gorm:"uniqueIndex"`
+	Payload        []byte `gorm:"type:jsonb"`
+	Status         string `gorm:"index"`
+	Attempts       int
+	NextAttemptAt  *time.Time `gorm:"index"`
+	LastAttemptAt  *time.Time
+	LastError      string
+	ProcessedAt    *time.Time
+}
+
+func (OutboxMessage20230524100000) TableName() string {
+	return "outbox_messages"
+}
+
+func addOutboxMessagesTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20230524100000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&OutboxMessage20230524100000{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&OutboxMessage20230524100000{})
+		},
+	}
+}
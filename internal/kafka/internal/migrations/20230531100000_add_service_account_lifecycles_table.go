@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+type ServiceAccountLifecycle20230531100000 struct {
+	ID                   string `gorm:"primaryKey"`
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+	DeletedAt            gorm.DeletedAt `gorm:"index"`
+	ClientId             string         `gorm:"index"`
+	OrganisationId       string         `gorm:"index"`
+	Owner                string
+	ExpiresAt            *time.Time
+	CredentialsRotatedAt time.Time
+	LastUsedAt           *time.Time
+	ExpiryWarnedAt       *time.Time
+	Status               string `gorm:"index"`
+}
+
+func (ServiceAccountLifecycle20230531100000) TableName() string {
+	return "service_account_lifecycles"
+}
+
+func addServiceAccountLifecyclesTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20230531100000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&ServiceAccountLifecycle20230531100000{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&ServiceAccountLifecycle20230531100000{})
+		},
+	}
+}
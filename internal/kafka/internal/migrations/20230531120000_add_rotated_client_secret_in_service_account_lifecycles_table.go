@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const serviceAccountLifecyclesRotatedClientSecretColumnName = "rotated_client_secret"
+
+func addRotatedClientSecretInServiceAccountLifecyclesTable() *gormigrate.Migration {
+	type ServiceAccountLifecycle struct {
+		RotatedClientSecret string
+	}
+
+	return &gormigrate.Migration{
+		ID: "20230531120000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&ServiceAccountLifecycle{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if !tx.Migrator().HasColumn(&ServiceAccountLifecycle{}, serviceAccountLifecyclesRotatedClientSecretColumnName) {
+				return nil
+			}
+
+			return tx.Migrator().DropColumn(&ServiceAccountLifecycle{}, serviceAccountLifecyclesRotatedClientSecretColumnName)
+		},
+	}
+}
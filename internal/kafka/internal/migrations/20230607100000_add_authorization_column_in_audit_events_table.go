@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const auditEventsAuthorizationColumnName = "authorization"
+
+func addAuthorizationColumnInAuditEventsTable() *gormigrate.Migration {
+	type AuditEvent struct {
+		Authorization []byte `gorm:"type:jsonb"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "20230607100000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&AuditEvent{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if !tx.Migrator().HasColumn(&AuditEvent{}, auditEventsAuthorizationColumnName) {
+				return nil
+			}
+
+			return tx.Migrator().DropColumn(&AuditEvent{}, auditEventsAuthorizationColumnName)
+		},
+	}
+}
@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+type ApiToken20230612100000 struct {
+	db.Model
+	Name           string
+	Kind           string
+	OrganisationId string `gorm:"index"`
+	Owner          string
+	AccountId      string
+	Scopes         []byte `gorm:"type:jsonb"`
+	TokenHash      string `gorm:"uniqueIndex"`
+	TokenPrefix    string
+	ExpiresAt      time.Time
+	LastUsedAt     *time.Time
+}
+
+func (ApiToken20230612100000) TableName() string {
+	return "api_tokens"
+}
+
+func addApiTokensTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20230612100000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&ApiToken20230612100000{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			// The api_tokens table is shared with the connector service, it's dropped by the last service rolling back its migration
+			applied, err := db.IsMigrationApplied(tx, "connector_migrations", "202306120000")
+			if err != nil || applied {
+				return err
+			}
+			return tx.Migrator().DropTable(&ApiToken20230612100000{})
+		},
+	}
+}
@@ -98,6 +98,22 @@ var migrations = []*gormigrate.Migration{
 	updateExpiresAtZeroValueFromKafkaRequests(),
 	renameKafkaStorageSizeColumn(),
 	addKafkaDomainCertificateManagementInfoInKafkaRequestsTable(),
+	addClusterCordonedAndDrainInfoColumns(),
+	addClusterDrainWorkerInLeaderLeases(),
+	addPlacementDecisionInKafkaRequestsTable(),
+	addKafkaUsageDailyTable(),
+	addKafkaUsageWorkerInLeaderLeases(),
+	addAuditEventsTable(),
+	addAuditEventsRetentionWorkerInLeaderLeases(),
+	addWebhookTables(),
+	addWebhookDeliveriesWorkerInLeaderLeases(),
+	addOutboxMessagesTable(),
+	addOutboxWorkerInLeaderLeases(),
+	addServiceAccountLifecyclesTable(),
+	addServiceAccountLifecycleWorkerInLeaderLeases(),
+	addRotatedClientSecretInServiceAccountLifecyclesTable(),
+	addAuthorizationColumnInAuditEventsTable(),
+	addApiTokensTable(),
 }
 
 func New(dbConfig *db.DatabaseConfig) (*db.Migration, func(), error) {
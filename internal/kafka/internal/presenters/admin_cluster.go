@@ -0,0 +1,86 @@
+package presenters
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/golang/glog"
+)
+
+// PresentDataPlaneClusterAdminEndpoint presents the given cluster for the admin endpoints.
+// The consumed streaming units are only presented when provided.
+func PresentDataPlaneClusterAdminEndpoint(cluster api.Cluster, kafkaCount int, consumedStreamingUnits services.StreamingUnitCountPerInstanceType) private.DataPlaneCluster {
+	reference := PresentReference(cluster.ClusterID, cluster)
+
+	presentedCluster := private.DataPlaneCluster{
+		Id:                    reference.Id,
+		Kind:                  reference.Kind,
+		Href:                  reference.Href,
+		ClusterId:             cluster.ClusterID,
+		Status:                cluster.Status.String(),
+		CloudProvider:         cluster.CloudProvider,
+		Region:                cluster.Region,
+		MultiAz:               cluster.MultiAZ,
+		ClusterType:           cluster.ClusterType,
+		ProviderType:          cluster.ProviderType.String(),
+		OrganizationId:        cluster.OrganizationID,
+		SupportedInstanceType: cluster.SupportedInstanceType,
+		KafkaCount:            int32(kafkaCount),
+		PlacementEligible:     cluster.Status == api.ClusterReady && !cluster.Cordoned,
+		Cordoned:              cluster.Cordoned,
+		CreatedAt:             cluster.CreatedAt,
+		UpdatedAt:             cluster.UpdatedAt,
+	}
+
+	if dynamicCapacityInfo := cluster.RetrieveDynamicCapacityInfo(); len(dynamicCapacityInfo) > 0 {
+		presentedCluster.DynamicCapacityInfo = map[string]private.DataPlaneClusterDynamicCapacityInfo{}
+		for instanceType, capacity := range dynamicCapacityInfo {
+			presentedCluster.DynamicCapacityInfo[instanceType] = private.DataPlaneClusterDynamicCapacityInfo{
+				MaxNodes:       capacity.MaxNodes,
+				MaxUnits:       capacity.MaxUnits,
+				RemainingUnits: capacity.RemainingUnits,
+			}
+		}
+	}
+
+	availableStrimziVersions, err := cluster.GetAvailableStrimziVersions()
+	if err != nil {
+		glog.Errorf("failed to get available strimzi versions of cluster %q: %v", cluster.ClusterID, err)
+	}
+	for _, strimziVersion := range availableStrimziVersions {
+		presentedStrimziVersion := private.DataPlaneClusterStrimziVersion{
+			Version: strimziVersion.Version,
+			Ready:   strimziVersion.Ready,
+		}
+		for _, kafkaVersion := range strimziVersion.KafkaVersions {
+			presentedStrimziVersion.KafkaVersions = append(presentedStrimziVersion.KafkaVersions, kafkaVersion.Version)
+		}
+		for _, kafkaIBPVersion := range strimziVersion.KafkaIBPVersions {
+			presentedStrimziVersion.KafkaIbpVersions = append(presentedStrimziVersion.KafkaIbpVersions, kafkaIBPVersion.Version)
+		}
+		presentedCluster.AvailableStrimziVersions = append(presentedCluster.AvailableStrimziVersions, presentedStrimziVersion)
+	}
+
+	if consumedStreamingUnits != nil {
+		presentedCluster.ConsumedStreamingUnits = map[string]int32{}
+		for instanceType, count := range consumedStreamingUnits {
+			presentedCluster.ConsumedStreamingUnits[instanceType.String()] = int32(count)
+		}
+	}
+
+	// only clusters that have been drained at least once have a drain status
+	if drainInfo := cluster.RetrieveDrainInfo(); drainInfo.StartedAt != nil {
+		presentedCluster.DrainStatus = &private.DataPlaneClusterDrainStatus{
+			Draining:             drainInfo.Draining,
+			StartedAt:            drainInfo.StartedAt,
+			CompletedAt:          drainInfo.CompletedAt,
+			MigratingKafkaIds:    drainInfo.MigratingKafkaIDs,
+			MigratedKafkasCount:  int32(drainInfo.MigratedKafkasCount),
+			RemainingKafkasCount: int32(drainInfo.RemainingKafkasCount),
+			FailedKafkaIds:       drainInfo.FailedKafkaIDs,
+			LastError:            drainInfo.LastError,
+		}
+	}
+
+	return presentedCluster
+}
@@ -0,0 +1,103 @@
+package presenters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/kafkas/types"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/onsi/gomega"
+)
+
+func Test_PresentDataPlaneClusterAdminEndpoint(t *testing.T) {
+	startedAt := time.Now()
+
+	cordonedCluster := api.Cluster{
+		ClusterID:   clusterId,
+		Status:      api.ClusterReady,
+		Cordoned:    true,
+		ClusterType: api.ManagedDataPlaneClusterType.String(),
+	}
+	_ = cordonedCluster.SetDrainInfo(api.ClusterDrainInfo{Draining: true, StartedAt: &startedAt, RemainingKafkasCount: 2})
+	_ = cordonedCluster.SetDynamicCapacityInfo(map[string]api.DynamicCapacityInfo{
+		types.STANDARD.String(): {MaxNodes: 3, MaxUnits: 5, RemainingUnits: 2},
+	})
+	_ = cordonedCluster.SetAvailableStrimziVersions([]api.StrimziVersion{
+		{Version: "strimzi-cluster-operator.v0.23.0-0", Ready: true, KafkaVersions: []api.KafkaVersion{{Version: "2.8.1"}}, KafkaIBPVersions: []api.KafkaIBPVersion{{Version: "2.8"}}},
+	})
+
+	type args struct {
+		cluster                api.Cluster
+		kafkaCount             int
+		consumedStreamingUnits services.StreamingUnitCountPerInstanceType
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want private.DataPlaneCluster
+	}{
+		{
+			name: "should present a ready cluster as eligible for placement",
+			args: args{
+				cluster:    api.Cluster{ClusterID: clusterId, Status: api.ClusterReady},
+				kafkaCount: 1,
+			},
+			want: private.DataPlaneCluster{
+				Id:                clusterId,
+				Kind:              KindCluster,
+				Href:              "/api/kafkas_mgmt/v1/clusters/" + clusterId,
+				ClusterId:         clusterId,
+				Status:            api.ClusterReady.String(),
+				KafkaCount:        1,
+				PlacementEligible: true,
+			},
+		},
+		{
+			name: "should present the drain status, capacity, strimzi versions and consumed streaming units of a cordoned cluster",
+			args: args{
+				cluster:                cordonedCluster,
+				kafkaCount:             2,
+				consumedStreamingUnits: services.StreamingUnitCountPerInstanceType{types.STANDARD: 3},
+			},
+			want: private.DataPlaneCluster{
+				Id:                clusterId,
+				Kind:              KindCluster,
+				Href:              "/api/kafkas_mgmt/v1/clusters/" + clusterId,
+				ClusterId:         clusterId,
+				Status:            api.ClusterReady.String(),
+				ClusterType:       api.ManagedDataPlaneClusterType.String(),
+				KafkaCount:        2,
+				PlacementEligible: false,
+				Cordoned:          true,
+				DynamicCapacityInfo: map[string]private.DataPlaneClusterDynamicCapacityInfo{
+					types.STANDARD.String(): {MaxNodes: 3, MaxUnits: 5, RemainingUnits: 2},
+				},
+				AvailableStrimziVersions: []private.DataPlaneClusterStrimziVersion{
+					{Version: "strimzi-cluster-operator.v0.23.0-0", Ready: true, KafkaVersions: []string{"2.8.1"}, KafkaIbpVersions: []string{"2.8"}},
+				},
+				ConsumedStreamingUnits: map[string]int32{types.STANDARD.String(): 3},
+				DrainStatus: &private.DataPlaneClusterDrainStatus{
+					Draining:             true,
+					StartedAt:            &startedAt,
+					RemainingKafkasCount: 2,
+				},
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			got := PresentDataPlaneClusterAdminEndpoint(tt.args.cluster, tt.args.kafkaCount, tt.args.consumedStreamingUnits)
+			if got.DrainStatus != nil {
+				g.Expect(got.DrainStatus.StartedAt.Equal(*tt.want.DrainStatus.StartedAt)).To(gomega.BeTrue())
+				got.DrainStatus.StartedAt = tt.want.DrainStatus.StartedAt
+			}
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
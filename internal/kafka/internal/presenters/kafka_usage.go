@@ -0,0 +1,70 @@
+package presenters
+
+import (
+	"strconv"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+)
+
+var kafkaUsageCSVHeader = []string{
+	"date",
+	"organisation_id",
+	"instance_type",
+	"size_id",
+	"billing_model",
+	"marketplace",
+	"billing_cloud_account_id",
+	"kafka_count",
+	"streaming_units",
+}
+
+func PresentKafkaUsage(usage *dbapi.KafkaUsageDaily) private.KafkaUsage {
+	return private.KafkaUsage{
+		Date:                  usage.UsageDate.Format(services.UsageDateLayout),
+		OrganisationId:        usage.OrganisationId,
+		InstanceType:          usage.InstanceType,
+		SizeId:                usage.SizeId,
+		BillingModel:          usage.BillingModel,
+		Marketplace:           usage.Marketplace,
+		BillingCloudAccountId: usage.BillingCloudAccountId,
+		KafkaCount:            int32(usage.KafkaCount),
+		StreamingUnits:        int32(usage.StreamingUnits),
+	}
+}
+
+func PresentKafkaUsageList(usages dbapi.KafkaUsageDailyList) private.KafkaUsageList {
+	list := private.KafkaUsageList{
+		Kind:  "KafkaUsageList",
+		Items: []private.KafkaUsage{},
+	}
+
+	for _, usage := range usages {
+		list.Items = append(list.Items, PresentKafkaUsage(usage))
+	}
+
+	return list
+}
+
+// PresentKafkaUsageCSV returns the csv records of the usages, header included
+func PresentKafkaUsageCSV(usages dbapi.KafkaUsageDailyList) [][]string {
+	records := [][]string{kafkaUsageCSVHeader}
+
+	for _, usage := range usages {
+		presented := PresentKafkaUsage(usage)
+		records = append(records, []string{
+			presented.Date,
+			presented.OrganisationId,
+			presented.InstanceType,
+			presented.SizeId,
+			presented.BillingModel,
+			presented.Marketplace,
+			presented.BillingCloudAccountId,
+			strconv.Itoa(int(presented.KafkaCount)),
+			strconv.Itoa(int(presented.StreamingUnits)),
+		})
+	}
+
+	return records
+}
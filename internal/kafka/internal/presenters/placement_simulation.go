@@ -0,0 +1,61 @@
+package presenters
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+)
+
+func PresentPlacementSimulation(result services.PlacementSimulationResult) private.PlacementSimulation {
+	simulation := private.PlacementSimulation{
+		Kind:              "PlacementSimulation",
+		Placements:        []private.PlacementSimulationPlacement{},
+		ProjectedScaleUps: []private.PlacementSimulationScaleUp{},
+	}
+
+	for i, placement := range result.Placements {
+		presentedPlacement := private.PlacementSimulationPlacement{
+			Index:              int32(i),
+			CloudProvider:      placement.Kafka.CloudProvider,
+			Region:             placement.Kafka.Region,
+			MultiAz:            placement.Kafka.MultiAZ,
+			InstanceType:       placement.Kafka.InstanceType,
+			SizeId:             placement.Kafka.SizeId,
+			Placed:             placement.Placed,
+			ClusterId:          placement.ClusterID,
+			OnProjectedCluster: placement.OnProjectedCluster,
+			Reason:             placement.Reason,
+			AvailableSizes:     placement.AvailableSizes,
+		}
+
+		if presentedPlacement.AvailableSizes == nil {
+			presentedPlacement.AvailableSizes = []string{}
+		}
+
+		if placement.Decision != nil {
+			for _, candidate := range placement.Decision.Candidates {
+				presentedPlacement.Candidates = append(presentedPlacement.Candidates, private.PlacementSimulationCandidate{
+					ClusterId:    candidate.ClusterID,
+					Eligible:     candidate.Eligible,
+					Score:        candidate.Score,
+					PluginScores: candidate.PluginScores,
+					Reasons:      candidate.Reasons,
+				})
+			}
+		}
+
+		simulation.Placements = append(simulation.Placements, presentedPlacement)
+	}
+
+	for _, scaleUp := range result.ProjectedScaleUps {
+		simulation.ProjectedScaleUps = append(simulation.ProjectedScaleUps, private.PlacementSimulationScaleUp{
+			ClusterId:                  scaleUp.ClusterID,
+			CloudProvider:              scaleUp.CloudProvider,
+			Region:                     scaleUp.Region,
+			MultiAz:                    scaleUp.MultiAZ,
+			InstanceType:               scaleUp.InstanceType,
+			EstimatedMaxStreamingUnits: int32(scaleUp.EstimatedMaxStreamingUnits),
+		})
+	}
+
+	return simulation
+}
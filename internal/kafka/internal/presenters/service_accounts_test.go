@@ -2,7 +2,9 @@ package presenters
 
 import (
 	"testing"
+	"time"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
 	mocks "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/test/mocks/service_accounts"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
@@ -39,9 +41,24 @@ func TestConvertServiceAccountRequest(t *testing.T) {
 	}
 }
 
+var (
+	serviceAccountRotatedAt = time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	serviceAccountExpiresAt = time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+	serviceAccountLastUsed  = time.Date(2023, 5, 30, 0, 0, 0, 0, time.UTC)
+	serviceAccountLifecycle = &dbapi.ServiceAccountLifecycle{
+		ExpiresAt:            &serviceAccountExpiresAt,
+		CredentialsRotatedAt: serviceAccountRotatedAt,
+		LastUsedAt:           &serviceAccountLastUsed,
+		Status:               dbapi.ServiceAccountLifecycleActive,
+		CredentialsExpireAt:  &serviceAccountExpiresAt,
+		ExpiryAction:         "revoke",
+	}
+)
+
 func TestPresentServiceAccount(t *testing.T) {
 	type args struct {
-		from *api.ServiceAccount
+		from      *api.ServiceAccount
+		lifecycle *dbapi.ServiceAccountLifecycle
 	}
 
 	tests := []struct {
@@ -56,6 +73,22 @@ func TestPresentServiceAccount(t *testing.T) {
 			},
 			want: mocks.BuildServiceAccount(nil),
 		},
+		{
+			name: "should return ServiceAccount with its lifecycle",
+			args: args{
+				from:      mocks.BuildApiServiceAccount(nil),
+				lifecycle: serviceAccountLifecycle,
+			},
+			want: func() *public.ServiceAccount {
+				sa := mocks.BuildServiceAccount(nil)
+				sa.ExpiresAt = &serviceAccountExpiresAt
+				sa.CredentialsExpireAt = &serviceAccountExpiresAt
+				sa.CredentialsRotatedAt = &serviceAccountRotatedAt
+				sa.LastUsedAt = &serviceAccountLastUsed
+				sa.Status = dbapi.ServiceAccountLifecycleActive
+				return sa
+			}(),
+		},
 	}
 
 	for _, testcase := range tests {
@@ -63,14 +96,15 @@ func TestPresentServiceAccount(t *testing.T) {
 
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			g.Expect(PresentServiceAccount(tt.args.from)).To(gomega.Equal(tt.want))
+			g.Expect(PresentServiceAccount(tt.args.from, tt.args.lifecycle)).To(gomega.Equal(tt.want))
 		})
 	}
 }
 
 func TestPresentServiceAccountListItem(t *testing.T) {
 	type args struct {
-		from *api.ServiceAccount
+		from      *api.ServiceAccount
+		lifecycle *dbapi.ServiceAccountLifecycle
 	}
 
 	tests := []struct {
@@ -85,6 +119,22 @@ func TestPresentServiceAccountListItem(t *testing.T) {
 			},
 			want: mocks.BuildServiceAccountListItem(nil),
 		},
+		{
+			name: "should present ServiceAccountListItem with its lifecycle",
+			args: args{
+				from:      mocks.BuildApiServiceAccount(nil),
+				lifecycle: serviceAccountLifecycle,
+			},
+			want: func() public.ServiceAccountListItem {
+				item := mocks.BuildServiceAccountListItem(nil)
+				item.ExpiresAt = &serviceAccountExpiresAt
+				item.CredentialsExpireAt = &serviceAccountExpiresAt
+				item.CredentialsRotatedAt = &serviceAccountRotatedAt
+				item.LastUsedAt = &serviceAccountLastUsed
+				item.Status = dbapi.ServiceAccountLifecycleActive
+				return item
+			}(),
+		},
 	}
 
 	for _, testcase := range tests {
@@ -92,7 +142,7 @@ func TestPresentServiceAccountListItem(t *testing.T) {
 
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			g.Expect(PresentServiceAccountListItem(tt.args.from)).To(gomega.Equal(tt.want))
+			g.Expect(PresentServiceAccountListItem(tt.args.from, tt.args.lifecycle)).To(gomega.Equal(tt.want))
 		})
 	}
 }
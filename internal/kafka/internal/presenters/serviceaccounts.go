@@ -1,6 +1,9 @@
 package presenters
 
 import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 )
@@ -12,9 +15,10 @@ func ConvertServiceAccountRequest(account public.ServiceAccountRequest) *api.Ser
 	}
 }
 
-func PresentServiceAccount(account *api.ServiceAccount) *public.ServiceAccount {
+// PresentServiceAccount presents the service account with its lifecycle, the lifecycle is optional
+func PresentServiceAccount(account *api.ServiceAccount, lifecycle *dbapi.ServiceAccountLifecycle) *public.ServiceAccount {
 	reference := PresentReference(account.ID, account)
-	return &public.ServiceAccount{
+	sa := &public.ServiceAccount{
 		ClientId:        account.ClientID,
 		ClientSecret:    account.ClientSecret,
 		Name:            account.Name,
@@ -26,11 +30,20 @@ func PresentServiceAccount(account *api.ServiceAccount) *public.ServiceAccount {
 		Kind:            reference.Kind,
 		Href:            reference.Href,
 	}
+	if lifecycle != nil {
+		sa.ExpiresAt = lifecycle.ExpiresAt
+		sa.CredentialsExpireAt = lifecycle.CredentialsExpireAt
+		sa.CredentialsRotatedAt = presentTime(lifecycle.CredentialsRotatedAt)
+		sa.LastUsedAt = lifecycle.LastUsedAt
+		sa.Status = lifecycle.Status
+	}
+	return sa
 }
 
-func PresentServiceAccountListItem(account *api.ServiceAccount) public.ServiceAccountListItem {
+// PresentServiceAccountListItem presents the service account with its lifecycle, the lifecycle is optional
+func PresentServiceAccountListItem(account *api.ServiceAccount, lifecycle *dbapi.ServiceAccountLifecycle) public.ServiceAccountListItem {
 	ref := PresentReference(account.ID, account)
-	return public.ServiceAccountListItem{
+	item := public.ServiceAccountListItem{
 		Id:              ref.Id,
 		Kind:            ref.Kind,
 		Href:            ref.Href,
@@ -41,6 +54,21 @@ func PresentServiceAccountListItem(account *api.ServiceAccount) public.ServiceAc
 		CreatedAt:       account.CreatedAt,
 		CreatedBy:       account.CreatedBy,
 	}
+	if lifecycle != nil {
+		item.ExpiresAt = lifecycle.ExpiresAt
+		item.CredentialsExpireAt = lifecycle.CredentialsExpireAt
+		item.CredentialsRotatedAt = presentTime(lifecycle.CredentialsRotatedAt)
+		item.LastUsedAt = lifecycle.LastUsedAt
+		item.Status = lifecycle.Status
+	}
+	return item
+}
+
+func presentTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
 }
 
 func PresentSsoProvider(provider *api.SsoProvider) public.SsoProvider {
@@ -7,8 +7,11 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/account"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/apitokens"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/audit"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/authorization"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/clusters"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
@@ -55,14 +58,25 @@ type options struct {
 	DB                                        *db.ConnectionFactory
 	ClusterPlacementStrategy                  services.ClusterPlacementStrategy
 	ClusterService                            services.ClusterService
+	ClusterDrainService                       services.ClusterDrainService
+	PlacementSimulationService                services.PlacementSimulationService
+	KafkaUsageService                         services.KafkaUsageService
+	ServiceAccountLifecycleService            services.ServiceAccountLifecycleService
 	ProviderFactory                           clusters.ProviderFactory
 	SupportedKafkaInstanceTypes               services.SupportedKafkaInstanceTypesService
 	AccessControlListMiddleware               *acl.AccessControlListMiddleware
 	AccessControlListConfig                   *acl.AccessControlListConfig
 	EnterpriseClustersAccessControlMiddleware *internalAcl.EnterpriseClustersAccessControlMiddleware
 	AdminRoleAuthZConfig                      *auth.AdminRoleAuthZConfig
+	TrustedIssuersConfig                      *auth.TrustedIssuersConfig
 	KasFleetshardOperatorAddon                services.KasFleetshardOperatorAddon
 	KafkaTLSCertificateManagementService      kafkatlscertmgmt.KafkaTLSCertificateManagementService
+	AuditEventsService                        audit.AuditEventsService
+	WebhookService                            webhooks.WebhookService
+	WebhookConfig                             *webhooks.WebhookConfig
+	ApiTokenService                           apitokens.ApiTokenService
+	ApiTokenConfig                            *apitokens.ApiTokenConfig
+	Outbox                                    *db.Outbox
 }
 
 func NewRouteLoader(s options) environments.RouteLoader {
@@ -90,13 +104,14 @@ func (s *options) buildApiBaseRouter(mainRouter *mux.Router, basePath string) er
 	kafkaPromoteHandler := handlers.NewKafkaPromoteHandler(s.Kafka, s.KafkaConfig, kafkaPromoteValidatorFactory)
 	cloudProvidersHandler := handlers.NewCloudProviderHandler(s.CloudProviders, s.ProviderConfig, s.Kafka, s.ClusterPlacementStrategy, s.KafkaConfig)
 	errorsHandler := coreHandlers.NewErrorsHandler()
-	serviceAccountsHandler := handlers.NewServiceAccountHandler(s.Keycloak)
+	serviceAccountsHandler := handlers.NewServiceAccountHandler(s.Keycloak, s.ServiceAccountLifecycleService)
 	metricsHandler := handlers.NewMetricsHandler(s.Observatorium)
 	supportedKafkaInstanceTypesHandler := handlers.NewSupportedKafkaInstanceTypesHandler(s.SupportedKafkaInstanceTypes)
 
 	authorizeMiddleware := s.AccessControlListMiddleware.Authorize
 	requireOrgID := auth.NewRequireOrgIDMiddleware().RequireOrgID(errors.ErrorUnauthenticated)
-	requireIssuer := auth.NewRequireIssuerMiddleware().RequireIssuer([]string{s.ServerConfig.TokenIssuerURL}, errors.ErrorUnauthenticated)
+	requireIssuer := auth.NewRequireIssuerMiddleware().RequireIssuerOrTrustedIssuer([]string{s.ServerConfig.TokenIssuerURL, auth.ApiTokenIssuer}, s.TrustedIssuersConfig.Issuers, errors.ErrorUnauthenticated)
+	auditTrail := auth.NewAuditTrailMiddleware(s.AuditEventsService, audit.ServiceKafkas)
 	requireTermsAcceptance := auth.NewRequireTermsAcceptanceMiddleware().RequireTermsAcceptance(s.ServerConfig.EnableTermsAcceptance, s.AMSClient, errors.ErrorTermsNotAccepted)
 
 	// base path. Could be /api/kafkas_mgmt
@@ -104,6 +119,12 @@ func (s *options) buildApiBaseRouter(mainRouter *mux.Router, basePath string) er
 
 	// /v1
 	apiV1Router := apiRouter.PathPrefix("/v1").Subrouter()
+	apiV1Router.Use(handlers.NewServiceAccountUsageMiddleware(s.ServiceAccountLifecycleService))
+	// the API tokens are only allowed on the routes with the scopes they're given
+	apiV1Router.Use(auth.RequireApiTokenScopes(
+		auth.ApiTokenScopeRule{PathPrefix: basePath + "/v1/kafkas", Read: auth.ApiTokenScopeKafkasRead, Write: auth.ApiTokenScopeKafkasWrite},
+		auth.ApiTokenScopeRule{PathPrefix: basePath + "/v1/cloud_providers", Read: auth.ApiTokenScopeKafkasRead},
+	))
 
 	//  /openapi
 	apiV1Router.HandleFunc("/openapi", coreHandlers.NewOpenAPIHandler(openAPIDefinitions).Get).Methods(http.MethodGet)
@@ -136,6 +157,7 @@ func (s *options) buildApiBaseRouter(mainRouter *mux.Router, basePath string) er
 	apiV1KafkasRouter.HandleFunc("", kafkaHandler.List).
 		Name(logger.NewLogEvent("list-kafka", "list all kafkas").ToString()).
 		Methods(http.MethodGet)
+	apiV1KafkasRouter.Use(auditTrail.AuditMutations())
 	apiV1KafkasRouter.Use(requireIssuer)
 	apiV1KafkasRouter.Use(requireOrgID)
 	apiV1KafkasRouter.Use(authorizeMiddleware)
@@ -199,10 +221,70 @@ func (s *options) buildApiBaseRouter(mainRouter *mux.Router, basePath string) er
 		Name(logger.NewLogEvent("get-service-accounts", "get a service account by id").ToString()).
 		Methods(http.MethodGet)
 
+	apiV1ServiceAccountsRouter.Use(auditTrail.AuditMutations())
 	apiV1ServiceAccountsRouter.Use(requireIssuer)
 	apiV1ServiceAccountsRouter.Use(requireOrgID)
 	apiV1ServiceAccountsRouter.Use(authorizeMiddleware)
 
+	//  /webhooks
+	v1Collections = append(v1Collections, api.CollectionMetadata{
+		ID:   "webhooks",
+		Kind: "WebhookSubscriptionList",
+	})
+	webhooksHandler := coreHandlers.NewWebhooksHandler(webhooks.ServiceKafkas, basePath+"/v1", s.WebhookService, s.WebhookConfig)
+	apiV1WebhooksRouter := apiV1Router.PathPrefix("/webhooks").Subrouter()
+	apiV1WebhooksRouter.HandleFunc("", webhooksHandler.List).
+		Name(logger.NewLogEvent("list-webhooks", "list the webhook subscriptions").ToString()).
+		Methods(http.MethodGet)
+	apiV1WebhooksRouter.HandleFunc("", webhooksHandler.Create).
+		Name(logger.NewLogEvent("create-webhook", "create a webhook subscription").ToString()).
+		Methods(http.MethodPost)
+	apiV1WebhooksRouter.HandleFunc("/{id}", webhooksHandler.Get).
+		Name(logger.NewLogEvent("get-webhook", "get a webhook subscription").ToString()).
+		Methods(http.MethodGet)
+	apiV1WebhooksRouter.HandleFunc("/{id}", webhooksHandler.Update).
+		Name(logger.NewLogEvent("update-webhook", "update a webhook subscription").ToString()).
+		Methods(http.MethodPatch)
+	apiV1WebhooksRouter.HandleFunc("/{id}", webhooksHandler.Delete).
+		Name(logger.NewLogEvent("delete-webhook", "delete a webhook subscription").ToString()).
+		Methods(http.MethodDelete)
+	apiV1WebhooksRouter.HandleFunc("/{id}/deliveries", webhooksHandler.ListDeliveries).
+		Name(logger.NewLogEvent("list-webhook-deliveries", "list the deliveries of a webhook subscription").ToString()).
+		Methods(http.MethodGet)
+	apiV1WebhooksRouter.HandleFunc("/{id}/deliveries/{delivery_id}/redeliver", webhooksHandler.Redeliver).
+		Name(logger.NewLogEvent("redeliver-webhook-delivery", "redeliver a webhook delivery").ToString()).
+		Methods(http.MethodPost)
+
+	apiV1WebhooksRouter.Use(auditTrail.AuditMutations())
+	apiV1WebhooksRouter.Use(requireIssuer)
+	apiV1WebhooksRouter.Use(requireOrgID)
+	apiV1WebhooksRouter.Use(authorizeMiddleware)
+
+	//  /api_tokens
+	v1Collections = append(v1Collections, api.CollectionMetadata{
+		ID:   "api_tokens",
+		Kind: "ApiTokenList",
+	})
+	apiTokensHandler := coreHandlers.NewApiTokensHandler(basePath+"/v1", s.ApiTokenService, s.ApiTokenConfig)
+	apiV1ApiTokensRouter := apiV1Router.PathPrefix("/api_tokens").Subrouter()
+	apiV1ApiTokensRouter.HandleFunc("", apiTokensHandler.List).
+		Name(logger.NewLogEvent("list-api-tokens", "list the API tokens").ToString()).
+		Methods(http.MethodGet)
+	apiV1ApiTokensRouter.HandleFunc("", apiTokensHandler.Create).
+		Name(logger.NewLogEvent("create-api-token", "create an API token").ToString()).
+		Methods(http.MethodPost)
+	apiV1ApiTokensRouter.HandleFunc("/{id}", apiTokensHandler.Get).
+		Name(logger.NewLogEvent("get-api-token", "get an API token").ToString()).
+		Methods(http.MethodGet)
+	apiV1ApiTokensRouter.HandleFunc("/{id}", apiTokensHandler.Delete).
+		Name(logger.NewLogEvent("revoke-api-token", "revoke an API token").ToString()).
+		Methods(http.MethodDelete)
+
+	apiV1ApiTokensRouter.Use(auditTrail.AuditMutations())
+	apiV1ApiTokensRouter.Use(requireIssuer)
+	apiV1ApiTokensRouter.Use(requireOrgID)
+	apiV1ApiTokensRouter.Use(authorizeMiddleware)
+
 	//  /cloud_providers
 	v1Collections = append(v1Collections, api.CollectionMetadata{
 		ID:   "cloud_providers",
@@ -232,6 +314,7 @@ func (s *options) buildApiBaseRouter(mainRouter *mux.Router, basePath string) er
 	})
 	clusterHandler := handlers.NewClusterHandler(s.KasFleetshardOperatorAddon, s.ClusterService, s.ProviderFactory, s.KafkaConfig)
 	clusterRouter := apiV1Router.PathPrefix("/clusters").Subrouter()
+	clusterRouter.Use(auditTrail.AuditMutations())
 	clusterRouter.Use(s.EnterpriseClustersAccessControlMiddleware.Authorize)
 	clusterRouter.HandleFunc("", clusterHandler.RegisterEnterpriseCluster).
 		Name(logger.NewLogEvent("register-enterprise-cluster", "register enterprise data plane cluster").ToString()).
@@ -280,22 +363,72 @@ func (s *options) buildApiBaseRouter(mainRouter *mux.Router, basePath string) er
 	adminKafkaHandler := handlers.NewAdminKafkaHandler(s.Kafka, s.AccountService, s.ProviderConfig, s.ClusterService, s.KafkaConfig, s.KafkaTLSCertificateManagementService)
 	adminRouter := apiV1Router.PathPrefix("/admin").Subrouter()
 	adminRouter.Use(auth.NewRequireIssuerMiddleware().RequireIssuer([]string{s.Keycloak.GetConfig().AdminAPISSORealm.ValidIssuerURI}, errors.ErrorNotFound))
-	adminRouter.Use(auth.NewRolesAuthzMiddleware(s.AdminRoleAuthZConfig).RequireRolesForMethods(errors.ErrorNotFound))
-	adminRouter.Use(auth.NewAuditLogMiddleware().AuditLog(errors.ErrorNotFound))
+	// requests are audited before the roles are checked, so that the denied requests are recorded too
+	adminRouter.Use(auditTrail.AuditLog(errors.ErrorNotFound))
+	rolesAuthzMiddleware := auth.NewRolesAuthzMiddleware(s.AdminRoleAuthZConfig)
+	adminRouter.Use(rolesAuthzMiddleware.RequireRolesForMethods(errors.ErrorNotFound))
 	adminRouter.HandleFunc("/kafkas", adminKafkaHandler.List).
 		Name(logger.NewLogEvent("admin-list-kafkas", "[admin] list all kafkas").ToString()).
 		Methods(http.MethodGet)
-	adminRouter.HandleFunc("/kafkas/{id}", adminKafkaHandler.Get).
-		Name(logger.NewLogEvent("admin-get-kafka", "[admin] get kafka by id").ToString()).
+	// the handlers of single kafkas check the resource selectors of the admin policies
+	rolesAuthzMiddleware.CheckAdminResources(
+		adminRouter.HandleFunc("/kafkas/{id}", adminKafkaHandler.Get).
+			Name(logger.NewLogEvent("admin-get-kafka", "[admin] get kafka by id").ToString()).
+			Methods(http.MethodGet),
+		adminRouter.HandleFunc("/kafkas/{id}", adminKafkaHandler.Delete).
+			Name(logger.NewLogEvent("admin-delete-kafka", "[admin] delete kafka by id").ToString()).
+			Methods(http.MethodDelete),
+		adminRouter.HandleFunc("/kafkas/{id}", adminKafkaHandler.Update).
+			Name(logger.NewLogEvent("admin-update-kafka", "[admin] update kafka by id").ToString()).
+			Methods(http.MethodPatch),
+		adminRouter.HandleFunc("/kafkas/{id}/revoke_tls_certificate", adminKafkaHandler.RevokeCertificateOfAKafka).
+			Name(logger.NewLogEvent("admin-kafka-tls-certificate-revocation", "[admin] revoke the TLS certificate of a kafka by id").ToString()).
+			Methods(http.MethodPost),
+	)
+
+	// /api/kafkas_mgmt/v1/admin/clusters
+	adminClusterHandler := handlers.NewAdminClusterHandler(s.ClusterService, s.ClusterDrainService)
+	adminRouter.HandleFunc("/clusters", adminClusterHandler.List).
+		Name(logger.NewLogEvent("admin-list-clusters", "[admin] list all data plane clusters").ToString()).
 		Methods(http.MethodGet)
-	adminRouter.HandleFunc("/kafkas/{id}", adminKafkaHandler.Delete).
-		Name(logger.NewLogEvent("admin-delete-kafka", "[admin] delete kafka by id").ToString()).
-		Methods(http.MethodDelete)
-	adminRouter.HandleFunc("/kafkas/{id}", adminKafkaHandler.Update).
-		Name(logger.NewLogEvent("admin-update-kafka", "[admin] update kafka by id").ToString()).
+	adminRouter.HandleFunc("/clusters/{id}", adminClusterHandler.Get).
+		Name(logger.NewLogEvent("admin-get-cluster", "[admin] get data plane cluster by id").ToString()).
+		Methods(http.MethodGet)
+	adminRouter.HandleFunc("/clusters/{id}", adminClusterHandler.Update).
+		Name(logger.NewLogEvent("admin-update-cluster", "[admin] cordon, uncordon, mark full, mark ready or deprovision empty data plane cluster by id").ToString()).
 		Methods(http.MethodPatch)
-	adminRouter.HandleFunc("/kafkas/{id}/revoke_tls_certificate", adminKafkaHandler.RevokeCertificateOfAKafka).
-		Name(logger.NewLogEvent("admin-kafka-tls-certificate-revocation", "[admin] revoke the TLS certificate of a kafka by id").ToString()).
+	adminRouter.HandleFunc("/clusters/{id}/drain", adminClusterHandler.Drain).
+		Name(logger.NewLogEvent("admin-drain-cluster", "[admin] cordon and drain data plane cluster by id").ToString()).
+		Methods(http.MethodPost)
+
+	// /api/kafkas_mgmt/v1/admin/placement/simulations
+	adminPlacementSimulationHandler := handlers.NewAdminPlacementSimulationHandler(s.PlacementSimulationService, s.ProviderConfig)
+	adminRouter.HandleFunc("/placement/simulations", adminPlacementSimulationHandler.Simulate).
+		Name(logger.NewLogEvent("admin-simulate-placement", "[admin] simulate the placement of hypothetical kafkas").ToString()).
+		Methods(http.MethodPost)
+
+	// /api/kafkas_mgmt/v1/admin/usage
+	adminUsageHandler := handlers.NewAdminUsageHandler(s.KafkaUsageService)
+	adminRouter.HandleFunc("/usage", adminUsageHandler.Get).
+		Name(logger.NewLogEvent("admin-get-usage", "[admin] get the daily kafka usage per organisation").ToString()).
+		Methods(http.MethodGet)
+
+	// /api/kafkas_mgmt/v1/admin/audit_events
+	adminAuditEventsHandler := coreHandlers.NewAuditEventsHandler(audit.ServiceKafkas, s.AuditEventsService)
+	adminRouter.HandleFunc("/audit_events", adminAuditEventsHandler.List).
+		Name(logger.NewLogEvent("admin-list-audit-events", "[admin] list the audit events").ToString()).
+		Methods(http.MethodGet)
+	adminRouter.HandleFunc("/audit_events/export", adminAuditEventsHandler.Export).
+		Name(logger.NewLogEvent("admin-export-audit-events", "[admin] export the audit events").ToString()).
+		Methods(http.MethodGet)
+
+	// /api/kafkas_mgmt/v1/admin/outbox_messages
+	adminOutboxHandler := coreHandlers.NewOutboxHandler(s.Outbox)
+	adminRouter.HandleFunc("/outbox_messages", adminOutboxHandler.List).
+		Name(logger.NewLogEvent("admin-list-outbox-messages", "[admin] list the outbox messages").ToString()).
+		Methods(http.MethodGet)
+	adminRouter.HandleFunc("/outbox_messages/{id}/retry", adminOutboxHandler.Retry).
+		Name(logger.NewLogEvent("admin-retry-outbox-message", "[admin] retry a dead lettered outbox message").ToString()).
 		Methods(http.MethodPost)
 
 	// /api/kafkas_mgmt/v1
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	apiErrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/golang/glog"
+)
+
+//go:generate moq -out cluster_drain_service_moq.go . ClusterDrainService
+type ClusterDrainService interface {
+	// Cordon marks the data plane cluster with the given clusterID as cordoned.
+	// A cordoned cluster is excluded from placement and does not receive reserved kafkas anymore. Its kafkas are left untouched.
+	// Cordoning an already cordoned cluster is a no-op.
+	Cordon(clusterID string) (*api.Cluster, *apiErrors.ServiceError)
+	// Uncordon makes the data plane cluster with the given clusterID eligible for placement again.
+	// If the cluster was being drained, the drain is stopped. Kafkas that have already been moved out of the cluster are not moved back.
+	// Uncordoning a cluster that is not cordoned is a no-op.
+	Uncordon(clusterID string) (*api.Cluster, *apiErrors.ServiceError)
+	// Drain cordons the data plane cluster with the given clusterID and marks it as draining.
+	// A cordoned cluster is excluded from placement and does not receive reserved kafkas anymore.
+	// The kafkas already placed in it will then be progressively moved to other clusters of the same region by the cluster drain worker.
+	// Draining an already draining cluster is a no-op.
+	Drain(clusterID string) (*api.Cluster, *apiErrors.ServiceError)
+}
+
+var _ ClusterDrainService = &clusterDrainService{}
+
+type clusterDrainService struct {
+	clusterService ClusterService
+}
+
+func NewClusterDrainService(clusterService ClusterService) ClusterDrainService {
+	return &clusterDrainService{
+		clusterService: clusterService,
+	}
+}
+
+func (s *clusterDrainService) Cordon(clusterID string) (*api.Cluster, *apiErrors.ServiceError) {
+	cluster, err := s.findCluster(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cluster.Cordoned {
+		return cluster, nil
+	}
+
+	cluster.Cordoned = true
+	if err := s.clusterService.Updates(*cluster, map[string]interface{}{"cordoned": cluster.Cordoned}); err != nil {
+		return nil, apiErrors.NewWithCause(err.Code, err, "failed to cordon cluster %q", clusterID)
+	}
+
+	glog.Infof("cluster %q has been cordoned", clusterID)
+
+	return cluster, nil
+}
+
+func (s *clusterDrainService) Uncordon(clusterID string) (*api.Cluster, *apiErrors.ServiceError) {
+	cluster, err := s.findCluster(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !cluster.Cordoned {
+		return cluster, nil
+	}
+
+	cluster.Cordoned = false
+	values := map[string]interface{}{"cordoned": cluster.Cordoned}
+
+	drainInfo := cluster.RetrieveDrainInfo()
+	if drainInfo.Draining {
+		glog.Infof("stopping the drain of cluster %q as it is being uncordoned", clusterID)
+		drainInfo.Draining = false
+		if err := cluster.SetDrainInfo(drainInfo); err != nil {
+			return nil, apiErrors.NewWithCause(apiErrors.ErrorGeneral, err, "failed to set drain info of cluster %q", clusterID)
+		}
+		values["drain_info"] = cluster.DrainInfo
+	}
+
+	if err := s.clusterService.Updates(*cluster, values); err != nil {
+		return nil, apiErrors.NewWithCause(err.Code, err, "failed to uncordon cluster %q", clusterID)
+	}
+
+	glog.Infof("cluster %q has been uncordoned", clusterID)
+
+	return cluster, nil
+}
+
+func (s *clusterDrainService) Drain(clusterID string) (*api.Cluster, *apiErrors.ServiceError) {
+	cluster, err := s.findDrainableCluster(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cluster.IsDraining() {
+		return cluster, nil
+	}
+
+	now := time.Now()
+	cluster.Cordoned = true
+	if err := cluster.SetDrainInfo(api.ClusterDrainInfo{
+		Draining:  true,
+		StartedAt: &now,
+	}); err != nil {
+		return nil, apiErrors.NewWithCause(apiErrors.ErrorGeneral, err, "failed to set drain info of cluster %q", clusterID)
+	}
+
+	if err := s.clusterService.Updates(*cluster, map[string]interface{}{
+		"cordoned":   cluster.Cordoned,
+		"drain_info": cluster.DrainInfo,
+	}); err != nil {
+		return nil, apiErrors.NewWithCause(err.Code, err, "failed to start draining cluster %q", clusterID)
+	}
+
+	glog.Infof("cluster %q has been cordoned and marked for draining", clusterID)
+
+	return cluster, nil
+}
+
+func (s *clusterDrainService) findCluster(clusterID string) (*api.Cluster, *apiErrors.ServiceError) {
+	cluster, err := s.clusterService.FindClusterByID(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cluster == nil {
+		return nil, apiErrors.NotFound("cluster with id %q not found", clusterID)
+	}
+
+	return cluster, nil
+}
+
+func (s *clusterDrainService) findDrainableCluster(clusterID string) (*api.Cluster, *apiErrors.ServiceError) {
+	cluster, err := s.findCluster(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cluster.ClusterType == api.EnterpriseDataPlaneClusterType.String() {
+		return nil, apiErrors.BadRequest("cluster with id %q is an enterprise cluster and cannot be drained", clusterID)
+	}
+
+	if cluster.Status != api.ClusterReady && cluster.Status != api.ClusterFull {
+		return nil, apiErrors.BadRequest("cluster with id %q is in %q state and cannot be drained. Only clusters in %q or %q state can be drained", clusterID, cluster.Status, api.ClusterReady, api.ClusterFull)
+	}
+
+	return cluster, nil
+}
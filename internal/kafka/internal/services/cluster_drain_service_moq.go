@@ -0,0 +1,164 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package services
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	apiErrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"sync"
+)
+
+// Ensure, that ClusterDrainServiceMock does implement ClusterDrainService.
+// If this is not the case, regenerate this file with moq.
+var _ ClusterDrainService = &ClusterDrainServiceMock{}
+
+// ClusterDrainServiceMock is a mock implementation of ClusterDrainService.
+//
+//	func TestSomethingThatUsesClusterDrainService(t *testing.T) {
+//
+//		// make and configure a mocked ClusterDrainService
+//		mockedClusterDrainService := &ClusterDrainServiceMock{
+//			CordonFunc: func(clusterID string) (*api.Cluster, *apiErrors.ServiceError) {
+//				panic("mock out the Cordon method")
+//			},
+//			DrainFunc: func(clusterID string) (*api.Cluster, *apiErrors.ServiceError) {
+//				panic("mock out the Drain method")
+//			},
+//			UncordonFunc: func(clusterID string) (*api.Cluster, *apiErrors.ServiceError) {
+//				panic("mock out the Uncordon method")
+//			},
+//		}
+//
+//		// use mockedClusterDrainService in code that requires ClusterDrainService
+//		// and then make assertions.
+//
+//	}
+type ClusterDrainServiceMock struct {
+	// CordonFunc mocks the Cordon method.
+	CordonFunc func(clusterID string) (*api.Cluster, *apiErrors.ServiceError)
+
+	// DrainFunc mocks the Drain method.
+	DrainFunc func(clusterID string) (*api.Cluster, *apiErrors.ServiceError)
+
+	// UncordonFunc mocks the Uncordon method.
+	UncordonFunc func(clusterID string) (*api.Cluster, *apiErrors.ServiceError)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Cordon holds details about calls to the Cordon method.
+		Cordon []struct {
+			// ClusterID is the clusterID argument value.
+			ClusterID string
+		}
+		// Drain holds details about calls to the Drain method.
+		Drain []struct {
+			// ClusterID is the clusterID argument value.
+			ClusterID string
+		}
+		// Uncordon holds details about calls to the Uncordon method.
+		Uncordon []struct {
+			// ClusterID is the clusterID argument value.
+			ClusterID string
+		}
+	}
+	lockCordon   sync.RWMutex
+	lockDrain    sync.RWMutex
+	lockUncordon sync.RWMutex
+}
+
+// Cordon calls CordonFunc.
+func (mock *ClusterDrainServiceMock) Cordon(clusterID string) (*api.Cluster, *apiErrors.ServiceError) {
+	if mock.CordonFunc == nil {
+		panic("ClusterDrainServiceMock.CordonFunc: method is nil but ClusterDrainService.Cordon was just called")
+	}
+	callInfo := struct {
+		ClusterID string
+	}{
+		ClusterID: clusterID,
+	}
+	mock.lockCordon.Lock()
+	mock.calls.Cordon = append(mock.calls.Cordon, callInfo)
+	mock.lockCordon.Unlock()
+	return mock.CordonFunc(clusterID)
+}
+
+// CordonCalls gets all the calls that were made to Cordon.
+// Check the length with:
+//
+//	len(mockedClusterDrainService.CordonCalls())
+func (mock *ClusterDrainServiceMock) CordonCalls() []struct {
+	ClusterID string
+} {
+	var calls []struct {
+		ClusterID string
+	}
+	mock.lockCordon.RLock()
+	calls = mock.calls.Cordon
+	mock.lockCordon.RUnlock()
+	return calls
+}
+
+// Drain calls DrainFunc.
+func (mock *ClusterDrainServiceMock) Drain(clusterID string) (*api.Cluster, *apiErrors.ServiceError) {
+	if mock.DrainFunc == nil {
+		panic("ClusterDrainServiceMock.DrainFunc: method is nil but ClusterDrainService.Drain was just called")
+	}
+	callInfo := struct {
+		ClusterID string
+	}{
+		ClusterID: clusterID,
+	}
+	mock.lockDrain.Lock()
+	mock.calls.Drain = append(mock.calls.Drain, callInfo)
+	mock.lockDrain.Unlock()
+	return mock.DrainFunc(clusterID)
+}
+
+// DrainCalls gets all the calls that were made to Drain.
+// Check the length with:
+//
+//	len(mockedClusterDrainService.DrainCalls())
+func (mock *ClusterDrainServiceMock) DrainCalls() []struct {
+	ClusterID string
+} {
+	var calls []struct {
+		ClusterID string
+	}
+	mock.lockDrain.RLock()
+	calls = mock.calls.Drain
+	mock.lockDrain.RUnlock()
+	return calls
+}
+
+// Uncordon calls UncordonFunc.
+func (mock *ClusterDrainServiceMock) Uncordon(clusterID string) (*api.Cluster, *apiErrors.ServiceError) {
+	if mock.UncordonFunc == nil {
+		panic("ClusterDrainServiceMock.UncordonFunc: method is nil but ClusterDrainService.Uncordon was just called")
+	}
+	callInfo := struct {
+		ClusterID string
+	}{
+		ClusterID: clusterID,
+	}
+	mock.lockUncordon.Lock()
+	mock.calls.Uncordon = append(mock.calls.Uncordon, callInfo)
+	mock.lockUncordon.Unlock()
+	return mock.UncordonFunc(clusterID)
+}
+
+// UncordonCalls gets all the calls that were made to Uncordon.
+// Check the length with:
+//
+//	len(mockedClusterDrainService.UncordonCalls())
+func (mock *ClusterDrainServiceMock) UncordonCalls() []struct {
+	ClusterID string
+} {
+	var calls []struct {
+		ClusterID string
+	}
+	mock.lockUncordon.RLock()
+	calls = mock.calls.Uncordon
+	mock.lockUncordon.RUnlock()
+	return calls
+}
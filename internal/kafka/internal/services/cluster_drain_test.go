@@ -0,0 +1,242 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	apiErrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/onsi/gomega"
+)
+
+func Test_clusterDrainService_Drain(t *testing.T) {
+	type fields struct {
+		clusterService ClusterService
+	}
+
+	tests := []struct {
+		name         string
+		fields       fields
+		wantErr      bool
+		wantDraining bool
+	}{
+		{
+			name: "should return an error when the cluster cannot be found",
+			fields: fields{
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *apiErrors.ServiceError) {
+						return nil, nil
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return an error when the cluster is an enterprise cluster",
+			fields: fields{
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *apiErrors.ServiceError) {
+						return &api.Cluster{ClusterID: clusterID, Status: api.ClusterReady, ClusterType: api.EnterpriseDataPlaneClusterType.String()}, nil
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return an error when the cluster is not ready",
+			fields: fields{
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *apiErrors.ServiceError) {
+						return &api.Cluster{ClusterID: clusterID, Status: api.ClusterDeprovisioning}, nil
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return an error when the cluster update fails",
+			fields: fields{
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *apiErrors.ServiceError) {
+						return &api.Cluster{Meta: api.Meta{ID: "id"}, ClusterID: clusterID, Status: api.ClusterReady}, nil
+					},
+					UpdatesFunc: func(cluster api.Cluster, values map[string]interface{}) *apiErrors.ServiceError {
+						return apiErrors.GeneralError("failed to update cluster")
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should cordon the cluster and mark it as draining",
+			fields: fields{
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *apiErrors.ServiceError) {
+						return &api.Cluster{Meta: api.Meta{ID: "id"}, ClusterID: clusterID, Status: api.ClusterFull}, nil
+					},
+					UpdatesFunc: func(cluster api.Cluster, values map[string]interface{}) *apiErrors.ServiceError {
+						return nil
+					},
+				},
+			},
+			wantErr:      false,
+			wantDraining: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			s := NewClusterDrainService(tt.fields.clusterService)
+			cluster, err := s.Drain("cluster-id")
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if !tt.wantErr {
+				g.Expect(cluster.IsDraining()).To(gomega.Equal(tt.wantDraining))
+				g.Expect(cluster.RetrieveDrainInfo().StartedAt).ToNot(gomega.BeNil())
+			}
+		})
+	}
+}
+
+func Test_clusterDrainService_Cordon(t *testing.T) {
+	type fields struct {
+		clusterService *ClusterServiceMock
+	}
+
+	tests := []struct {
+		name        string
+		fields      fields
+		wantErr     bool
+		wantUpdates int
+	}{
+		{
+			name: "should return an error when the cluster cannot be found",
+			fields: fields{
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *apiErrors.ServiceError) {
+						return nil, nil
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should not update an already cordoned cluster",
+			fields: fields{
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *apiErrors.ServiceError) {
+						return &api.Cluster{Meta: api.Meta{ID: "id"}, ClusterID: clusterID, Cordoned: true}, nil
+					},
+				},
+			},
+			wantErr:     false,
+			wantUpdates: 0,
+		},
+		{
+			name: "should cordon the cluster",
+			fields: fields{
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *apiErrors.ServiceError) {
+						return &api.Cluster{Meta: api.Meta{ID: "id"}, ClusterID: clusterID, Status: api.ClusterReady}, nil
+					},
+					UpdatesFunc: func(cluster api.Cluster, values map[string]interface{}) *apiErrors.ServiceError {
+						return nil
+					},
+				},
+			},
+			wantErr:     false,
+			wantUpdates: 1,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			s := NewClusterDrainService(tt.fields.clusterService)
+			cluster, err := s.Cordon("cluster-id")
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if !tt.wantErr {
+				g.Expect(cluster.Cordoned).To(gomega.BeTrue())
+				g.Expect(cluster.IsDraining()).To(gomega.BeFalse())
+			}
+			g.Expect(tt.fields.clusterService.UpdatesCalls()).To(gomega.HaveLen(tt.wantUpdates))
+		})
+	}
+}
+
+func Test_clusterDrainService_Uncordon(t *testing.T) {
+	drainingCluster := api.Cluster{Meta: api.Meta{ID: "id"}, ClusterID: "cluster-id", Cordoned: true}
+	_ = drainingCluster.SetDrainInfo(api.ClusterDrainInfo{Draining: true})
+
+	type fields struct {
+		clusterService *ClusterServiceMock
+	}
+
+	tests := []struct {
+		name        string
+		fields      fields
+		wantErr     bool
+		wantUpdates int
+	}{
+		{
+			name: "should not update a cluster that is not cordoned",
+			fields: fields{
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *apiErrors.ServiceError) {
+						return &api.Cluster{Meta: api.Meta{ID: "id"}, ClusterID: clusterID}, nil
+					},
+				},
+			},
+			wantErr:     false,
+			wantUpdates: 0,
+		},
+		{
+			name: "should uncordon the cluster and stop its drain",
+			fields: fields{
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *apiErrors.ServiceError) {
+						cluster := drainingCluster
+						return &cluster, nil
+					},
+					UpdatesFunc: func(cluster api.Cluster, values map[string]interface{}) *apiErrors.ServiceError {
+						return nil
+					},
+				},
+			},
+			wantErr:     false,
+			wantUpdates: 1,
+		},
+		{
+			name: "should return an error when the cluster update fails",
+			fields: fields{
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *apiErrors.ServiceError) {
+						cluster := drainingCluster
+						return &cluster, nil
+					},
+					UpdatesFunc: func(cluster api.Cluster, values map[string]interface{}) *apiErrors.ServiceError {
+						return apiErrors.GeneralError("failed to update cluster")
+					},
+				},
+			},
+			wantErr:     true,
+			wantUpdates: 1,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			s := NewClusterDrainService(tt.fields.clusterService)
+			cluster, err := s.Uncordon("cluster-id")
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if !tt.wantErr {
+				g.Expect(cluster.Cordoned).To(gomega.BeFalse())
+				g.Expect(cluster.RetrieveDrainInfo().Draining).To(gomega.BeFalse())
+			}
+			g.Expect(tt.fields.clusterService.UpdatesCalls()).To(gomega.HaveLen(tt.wantUpdates))
+		})
+	}
+}
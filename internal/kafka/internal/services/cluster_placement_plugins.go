@@ -0,0 +1,216 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+)
+
+const maxPlacementScore int64 = 100
+
+// newPlacementFilterPlugins returns the filter plugins that every candidate cluster has to pass
+func newPlacementFilterPlugins() []PlacementFilterPlugin {
+	return []PlacementFilterPlugin{
+		&regionPlacementFilter{},
+		&instanceTypePlacementFilter{},
+		&organisationPlacementFilter{},
+		&privateNetworkPlacementFilter{},
+		&cordonedPlacementFilter{},
+	}
+}
+
+// newPlacementScorePlugins returns all the score plugins that can be enabled through the placement configuration
+func newPlacementScorePlugins() []PlacementScorePlugin {
+	return []PlacementScorePlugin{
+		&binPackingPlacementScore{},
+		&spreadPlacementScore{},
+		&leastLoadedPlacementScore{},
+		&strimziVersionAffinityPlacementScore{},
+	}
+}
+
+// regionPlacementFilter rejects the clusters that are not in the cloud provider, region and availability zones mode requested by the kafka
+type regionPlacementFilter struct{}
+
+func (f *regionPlacementFilter) Name() string {
+	return "region"
+}
+
+func (f *regionPlacementFilter) Filter(ctx *PlacementContext, candidate PlacementCandidate) (bool, string) {
+	cluster, kafka := candidate.Cluster, ctx.Kafka
+	if cluster.CloudProvider != kafka.CloudProvider || cluster.Region != kafka.Region {
+		return false, fmt.Sprintf("cluster is in region %q of cloud provider %q whereas kafka requested region %q of cloud provider %q", cluster.Region, cluster.CloudProvider, kafka.Region, kafka.CloudProvider)
+	}
+
+	if cluster.MultiAZ != kafka.MultiAZ {
+		return false, fmt.Sprintf("cluster multi_az is %t whereas kafka requested multi_az %t", cluster.MultiAZ, kafka.MultiAZ)
+	}
+
+	return true, ""
+}
+
+// instanceTypePlacementFilter rejects the clusters that do not support the instance type of the kafka.
+// A cluster without supported instance types supports all of them, in line with the default applied when a cluster is created.
+type instanceTypePlacementFilter struct{}
+
+func (f *instanceTypePlacementFilter) Name() string {
+	return "instance_type"
+}
+
+func (f *instanceTypePlacementFilter) Filter(ctx *PlacementContext, candidate PlacementCandidate) (bool, string) {
+	supportedInstanceTypes := candidate.Cluster.GetSupportedInstanceTypes()
+	if len(supportedInstanceTypes) == 0 || arrays.Contains(supportedInstanceTypes, ctx.Kafka.InstanceType) {
+		return true, ""
+	}
+
+	return false, fmt.Sprintf("cluster supports instance types %q but not %q", candidate.Cluster.SupportedInstanceType, ctx.Kafka.InstanceType)
+}
+
+// organisationPlacementFilter rejects the clusters that belong to an organisation other than the one of the kafka
+type organisationPlacementFilter struct{}
+
+func (f *organisationPlacementFilter) Name() string {
+	return "organisation"
+}
+
+func (f *organisationPlacementFilter) Filter(ctx *PlacementContext, candidate PlacementCandidate) (bool, string) {
+	if candidate.Cluster.OrganizationID == "" || candidate.Cluster.OrganizationID == ctx.Kafka.OrganisationId {
+		return true, ""
+	}
+
+	return false, fmt.Sprintf("cluster belongs to organisation %q", candidate.Cluster.OrganizationID)
+}
+
+// privateNetworkPlacementFilter rejects the clusters whose kafkas are only accessible via a private network
+// as the kafkas placed by the placement strategies have to be publicly accessible
+type privateNetworkPlacementFilter struct{}
+
+func (f *privateNetworkPlacementFilter) Name() string {
+	return "private_network"
+}
+
+func (f *privateNetworkPlacementFilter) Filter(ctx *PlacementContext, candidate PlacementCandidate) (bool, string) {
+	if candidate.Cluster.AccessKafkasViaPrivateNetwork {
+		return false, "kafkas of the cluster are only accessible via a private network"
+	}
+
+	return true, ""
+}
+
+// cordonedPlacementFilter rejects the clusters that have been cordoned
+type cordonedPlacementFilter struct{}
+
+func (f *cordonedPlacementFilter) Name() string {
+	return "cordoned"
+}
+
+func (f *cordonedPlacementFilter) Filter(ctx *PlacementContext, candidate PlacementCandidate) (bool, string) {
+	if candidate.Cluster.Cordoned {
+		return false, "cluster is cordoned"
+	}
+
+	return true, ""
+}
+
+// binPackingPlacementScore favours the clusters that will be the most utilised once the kafka is placed,
+// so that kafkas get packed in as few clusters as possible and empty clusters can be scaled down
+type binPackingPlacementScore struct{}
+
+func (s *binPackingPlacementScore) Name() string {
+	return config.BinPackingScorePlugin
+}
+
+func (s *binPackingPlacementScore) Score(ctx *PlacementContext, candidate PlacementCandidate) (int64, string) {
+	utilisation, ok := futureUtilisation(ctx, candidate)
+	if !ok {
+		return 0, "capacity of the cluster is unknown"
+	}
+
+	return utilisation, fmt.Sprintf("cluster will be %d%% utilised", utilisation)
+}
+
+// leastLoadedPlacementScore favours the clusters that will be the least utilised once the kafka is placed
+type leastLoadedPlacementScore struct{}
+
+func (s *leastLoadedPlacementScore) Name() string {
+	return config.LeastLoadedScorePlugin
+}
+
+func (s *leastLoadedPlacementScore) Score(ctx *PlacementContext, candidate PlacementCandidate) (int64, string) {
+	utilisation, ok := futureUtilisation(ctx, candidate)
+	if !ok {
+		return 0, "capacity of the cluster is unknown"
+	}
+
+	return maxPlacementScore - utilisation, fmt.Sprintf("cluster will be %d%% utilised", utilisation)
+}
+
+// spreadPlacementScore favours the clusters hosting the fewest kafkas among the candidates
+type spreadPlacementScore struct{}
+
+func (s *spreadPlacementScore) Name() string {
+	return config.SpreadScorePlugin
+}
+
+func (s *spreadPlacementScore) Score(ctx *PlacementContext, candidate PlacementCandidate) (int64, string) {
+	maxKafkaCount := 0
+	for _, c := range ctx.Candidates {
+		if c.KafkaCount > maxKafkaCount {
+			maxKafkaCount = c.KafkaCount
+		}
+	}
+
+	reason := fmt.Sprintf("cluster hosts %d kafkas, the most loaded candidate hosts %d kafkas", candidate.KafkaCount, maxKafkaCount)
+	if maxKafkaCount == 0 {
+		return maxPlacementScore, reason
+	}
+
+	return maxPlacementScore * int64(maxKafkaCount-candidate.KafkaCount) / int64(maxKafkaCount), reason
+}
+
+// strimziVersionAffinityPlacementScore favours the clusters where the Strimzi version desired by the kafka is available and ready.
+// Kafkas that do not have a desired Strimzi version yet favour the clusters having at least one ready Strimzi version.
+type strimziVersionAffinityPlacementScore struct{}
+
+func (s *strimziVersionAffinityPlacementScore) Name() string {
+	return config.StrimziVersionAffinityScorePlugin
+}
+
+func (s *strimziVersionAffinityPlacementScore) Score(ctx *PlacementContext, candidate PlacementCandidate) (int64, string) {
+	readyVersions, err := candidate.Cluster.GetAvailableAndReadyStrimziVersions()
+	if err != nil {
+		return 0, fmt.Sprintf("failed to get ready strimzi versions of the cluster: %s", err.Error())
+	}
+
+	desiredVersion := ctx.Kafka.DesiredStrimziVersion
+	if desiredVersion == "" {
+		if len(readyVersions) == 0 {
+			return 0, "cluster has no ready strimzi version"
+		}
+		return maxPlacementScore, "cluster has a ready strimzi version"
+	}
+
+	for _, version := range readyVersions {
+		if version.Version == desiredVersion {
+			return maxPlacementScore, fmt.Sprintf("desired strimzi version %q is ready in the cluster", desiredVersion)
+		}
+	}
+
+	return 0, fmt.Sprintf("desired strimzi version %q is not ready in the cluster", desiredVersion)
+}
+
+// futureUtilisation returns the percentage of the streaming units capacity of the candidate that will be consumed once the kafka is placed.
+// It returns false if the capacity of the candidate is unknown.
+func futureUtilisation(ctx *PlacementContext, candidate PlacementCandidate) (int64, bool) {
+	if candidate.MaxStreamingUnits <= 0 {
+		return 0, false
+	}
+
+	utilisation := maxPlacementScore * int64(candidate.ConsumedStreamingUnits+ctx.KafkaStreamingUnits) / int64(candidate.MaxStreamingUnits)
+	if utilisation > maxPlacementScore {
+		utilisation = maxPlacementScore
+	}
+
+	return utilisation, true
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/kafkas/types"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/onsi/gomega"
+)
+
+func Test_placementFilterPlugins(t *testing.T) {
+	kafka := &dbapi.KafkaRequest{
+		CloudProvider:  "aws",
+		Region:         "us-east-1",
+		MultiAZ:        true,
+		InstanceType:   types.STANDARD.String(),
+		OrganisationId: "org-id",
+	}
+
+	eligibleCluster := func() *api.Cluster {
+		return &api.Cluster{
+			CloudProvider:         "aws",
+			Region:                "us-east-1",
+			MultiAZ:               true,
+			SupportedInstanceType: api.AllInstanceTypeSupport.String(),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		plugin   PlacementFilterPlugin
+		modifyFn func(cluster *api.Cluster)
+		want     bool
+	}{
+		{
+			name:   "region filter should accept a cluster in the region of the kafka",
+			plugin: &regionPlacementFilter{},
+			want:   true,
+		},
+		{
+			name:     "region filter should reject a cluster in another region",
+			plugin:   &regionPlacementFilter{},
+			modifyFn: func(cluster *api.Cluster) { cluster.Region = "eu-west-1" },
+			want:     false,
+		},
+		{
+			name:     "region filter should reject a cluster of another cloud provider",
+			plugin:   &regionPlacementFilter{},
+			modifyFn: func(cluster *api.Cluster) { cluster.CloudProvider = "gcp" },
+			want:     false,
+		},
+		{
+			name:     "region filter should reject a single availability zone cluster for a multi availability zones kafka",
+			plugin:   &regionPlacementFilter{},
+			modifyFn: func(cluster *api.Cluster) { cluster.MultiAZ = false },
+			want:     false,
+		},
+		{
+			name:   "instance type filter should accept a cluster supporting the instance type of the kafka",
+			plugin: &instanceTypePlacementFilter{},
+			want:   true,
+		},
+		{
+			name:     "instance type filter should accept a cluster without supported instance types",
+			plugin:   &instanceTypePlacementFilter{},
+			modifyFn: func(cluster *api.Cluster) { cluster.SupportedInstanceType = "" },
+			want:     true,
+		},
+		{
+			name:     "instance type filter should reject a cluster not supporting the instance type of the kafka",
+			plugin:   &instanceTypePlacementFilter{},
+			modifyFn: func(cluster *api.Cluster) { cluster.SupportedInstanceType = types.DEVELOPER.String() },
+			want:     false,
+		},
+		{
+			name:     "organisation filter should accept a cluster of the organisation of the kafka",
+			plugin:   &organisationPlacementFilter{},
+			modifyFn: func(cluster *api.Cluster) { cluster.OrganizationID = "org-id" },
+			want:     true,
+		},
+		{
+			name:     "organisation filter should reject a cluster of another organisation",
+			plugin:   &organisationPlacementFilter{},
+			modifyFn: func(cluster *api.Cluster) { cluster.OrganizationID = "another-org-id" },
+			want:     false,
+		},
+		{
+			name:     "private network filter should reject a cluster whose kafkas are accessed via a private network",
+			plugin:   &privateNetworkPlacementFilter{},
+			modifyFn: func(cluster *api.Cluster) { cluster.AccessKafkasViaPrivateNetwork = true },
+			want:     false,
+		},
+		{
+			name:     "cordoned filter should reject a cordoned cluster",
+			plugin:   &cordonedPlacementFilter{},
+			modifyFn: func(cluster *api.Cluster) { cluster.Cordoned = true },
+			want:     false,
+		},
+		{
+			name:   "cordoned filter should accept a cluster that is not cordoned",
+			plugin: &cordonedPlacementFilter{},
+			want:   true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			cluster := eligibleCluster()
+			if tt.modifyFn != nil {
+				tt.modifyFn(cluster)
+			}
+
+			got, reason := tt.plugin.Filter(&PlacementContext{Kafka: kafka}, PlacementCandidate{Cluster: cluster})
+			g.Expect(got).To(gomega.Equal(tt.want))
+			g.Expect(reason == "").To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_strimziVersionAffinityPlacementScore_Score(t *testing.T) {
+	readyVersions := api.JSON(`[{"version": "strimzi-cluster-operator.v0.23.0-0", "ready": true}, {"version": "strimzi-cluster-operator.v0.24.0-0", "ready": false}]`)
+
+	tests := []struct {
+		name                     string
+		desiredStrimziVersion    string
+		availableStrimziVersions api.JSON
+		want                     int64
+	}{
+		{
+			name:                     "should favour a cluster with a ready version when the kafka has no desired version",
+			availableStrimziVersions: readyVersions,
+			want:                     maxPlacementScore,
+		},
+		{
+			name: "should not favour a cluster without a ready version when the kafka has no desired version",
+			want: 0,
+		},
+		{
+			name:                     "should favour a cluster where the desired version is ready",
+			desiredStrimziVersion:    "strimzi-cluster-operator.v0.23.0-0",
+			availableStrimziVersions: readyVersions,
+			want:                     maxPlacementScore,
+		},
+		{
+			name:                     "should not favour a cluster where the desired version is not ready",
+			desiredStrimziVersion:    "strimzi-cluster-operator.v0.24.0-0",
+			availableStrimziVersions: readyVersions,
+			want:                     0,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			plugin := &strimziVersionAffinityPlacementScore{}
+			ctx := &PlacementContext{Kafka: &dbapi.KafkaRequest{DesiredStrimziVersion: tt.desiredStrimziVersion}}
+			got, _ := plugin.Score(ctx, PlacementCandidate{Cluster: &api.Cluster{AvailableStrimziVersions: tt.availableStrimziVersions}})
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
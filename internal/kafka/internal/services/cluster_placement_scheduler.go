@@ -0,0 +1,189 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+// PlacementCandidate is a data plane cluster that is considered for the placement of a kafka
+type PlacementCandidate struct {
+	Cluster *api.Cluster
+	// ConsumedStreamingUnits is the number of streaming units already consumed in the cluster
+	ConsumedStreamingUnits int
+	// MaxStreamingUnits is the streaming units capacity of the cluster. It is 0 when the capacity is unknown or unlimited
+	MaxStreamingUnits int
+	// KafkaCount is the number of kafkas hosted in the cluster. It is only computed when the spread score plugin is enabled
+	KafkaCount int
+}
+
+// PlacementContext contains the information shared by the plugins during the placement of a kafka
+type PlacementContext struct {
+	Kafka *dbapi.KafkaRequest
+	// KafkaStreamingUnits is the number of streaming units that the kafka will consume once placed
+	KafkaStreamingUnits int
+	// Candidates are all the clusters considered for the placement, including the ones rejected by the filter plugins
+	Candidates []PlacementCandidate
+}
+
+// PlacementFilterPlugin decides whether a data plane cluster can receive a kafka
+type PlacementFilterPlugin interface {
+	Name() string
+	// Filter returns true if the candidate can receive the kafka. Otherwise it returns false along with the reason of the rejection.
+	Filter(ctx *PlacementContext, candidate PlacementCandidate) (bool, string)
+}
+
+// PlacementScorePlugin ranks the data plane clusters that passed all the filter plugins
+type PlacementScorePlugin interface {
+	Name() string
+	// Score returns a score between 0 and 100 along with an explanation of the score. The higher the score, the better the candidate.
+	Score(ctx *PlacementContext, candidate PlacementCandidate) (int64, string)
+}
+
+type weightedPlacementScorePlugin struct {
+	PlacementScorePlugin
+	weight int64
+}
+
+// clusterPlacementScheduler selects the data plane cluster of a kafka among the candidates given by a placement strategy.
+// Candidates are first filtered by the filter plugins, then the eligible ones are ranked by the enabled score plugins.
+// The candidate with the highest weighted score is selected, ties being broken by the order of the candidates.
+// When no score plugin is enabled, the first eligible candidate is selected.
+// The decision is recorded in the kafka along with the reasons of the rejection or the score of each candidate.
+type clusterPlacementScheduler struct {
+	clusterService ClusterService
+	filterPlugins  []PlacementFilterPlugin
+	scorePlugins   []weightedPlacementScorePlugin
+}
+
+func newClusterPlacementScheduler(clusterService ClusterService, placementConfig config.ClusterPlacementConfig) *clusterPlacementScheduler {
+	scorePlugins := []weightedPlacementScorePlugin{}
+	for _, plugin := range newPlacementScorePlugins() {
+		weight := placementConfig.GetScoreWeight(plugin.Name())
+		if weight > 0 {
+			scorePlugins = append(scorePlugins, weightedPlacementScorePlugin{PlacementScorePlugin: plugin, weight: int64(weight)})
+		}
+	}
+
+	return &clusterPlacementScheduler{
+		clusterService: clusterService,
+		filterPlugins:  newPlacementFilterPlugins(),
+		scorePlugins:   scorePlugins,
+	}
+}
+
+// schedule returns the selected cluster among the given candidates or nil if none of them is eligible
+func (s *clusterPlacementScheduler) schedule(strategy string, kafka *dbapi.KafkaRequest, kafkaStreamingUnits int, candidates []PlacementCandidate) (*api.Cluster, error) {
+	if err := s.countKafkasPerCandidate(candidates); err != nil {
+		return nil, err
+	}
+
+	ctx := &PlacementContext{
+		Kafka:               kafka,
+		KafkaStreamingUnits: kafkaStreamingUnits,
+		Candidates:          candidates,
+	}
+
+	decision := dbapi.KafkaPlacementDecision{
+		Strategy:   strategy,
+		DecidedAt:  time.Now(),
+		Candidates: make([]dbapi.KafkaPlacementCandidate, 0, len(candidates)),
+	}
+
+	selected := -1
+	for i, candidate := range candidates {
+		evaluation := s.evaluate(ctx, candidate)
+		decision.Candidates = append(decision.Candidates, evaluation)
+		if evaluation.Eligible && (selected == -1 || evaluation.Score > decision.Candidates[selected].Score) {
+			selected = i
+		}
+	}
+
+	var cluster *api.Cluster
+	switch {
+	case selected == -1:
+		decision.Reason = fmt.Sprintf("none of the %d candidate clusters is eligible", len(candidates))
+	case len(s.scorePlugins) == 0:
+		cluster = candidates[selected].Cluster
+		decision.ClusterID = cluster.ClusterID
+		decision.Reason = "first eligible cluster selected as no score plugin is enabled"
+	default:
+		cluster = candidates[selected].Cluster
+		decision.ClusterID = cluster.ClusterID
+		decision.Reason = fmt.Sprintf("eligible cluster with the highest score (%d) selected", decision.Candidates[selected].Score)
+	}
+
+	if err := kafka.SetPlacementDecision(decision); err != nil {
+		return nil, errors.Wrapf(err, "failed to record placement decision of kafka %q", kafka.ID)
+	}
+
+	glog.V(10).Infof("placement decision for kafka %q: cluster = %q, reason = %q, candidates = %v", kafka.ID, decision.ClusterID, decision.Reason, decision.Candidates)
+
+	return cluster, nil
+}
+
+// evaluate runs the filter plugins against the candidate and scores it if it passed all of them
+func (s *clusterPlacementScheduler) evaluate(ctx *PlacementContext, candidate PlacementCandidate) dbapi.KafkaPlacementCandidate {
+	evaluation := dbapi.KafkaPlacementCandidate{
+		ClusterID: candidate.Cluster.ClusterID,
+		Eligible:  true,
+	}
+
+	for _, filter := range s.filterPlugins {
+		if ok, reason := filter.Filter(ctx, candidate); !ok {
+			evaluation.Eligible = false
+			evaluation.Reasons = append(evaluation.Reasons, fmt.Sprintf("%s: %s", filter.Name(), reason))
+		}
+	}
+
+	if !evaluation.Eligible || len(s.scorePlugins) == 0 {
+		return evaluation
+	}
+
+	evaluation.PluginScores = map[string]int64{}
+	for _, plugin := range s.scorePlugins {
+		score, reason := plugin.Score(ctx, candidate)
+		evaluation.PluginScores[plugin.Name()] = score
+		evaluation.Score += plugin.weight * score
+		evaluation.Reasons = append(evaluation.Reasons, fmt.Sprintf("%s: %s", plugin.Name(), reason))
+	}
+
+	return evaluation
+}
+
+// countKafkasPerCandidate sets the number of kafkas hosted in each candidate when a score plugin needs it
+func (s *clusterPlacementScheduler) countKafkasPerCandidate(candidates []PlacementCandidate) error {
+	if !s.isScorePluginEnabled(config.SpreadScorePlugin) || len(candidates) == 0 {
+		return nil
+	}
+
+	clusterIDs := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		clusterIDs = append(clusterIDs, candidate.Cluster.ClusterID)
+	}
+
+	kafkaCountPerCluster, err := s.clusterService.CountKafkasPerCluster(clusterIDs)
+	if err != nil {
+		return errors.Wrapf(err, "failed to count kafkas of clusters %v", clusterIDs)
+	}
+
+	for i := range candidates {
+		candidates[i].KafkaCount = kafkaCountPerCluster[candidates[i].Cluster.ClusterID]
+	}
+
+	return nil
+}
+
+func (s *clusterPlacementScheduler) isScorePluginEnabled(name string) bool {
+	for _, plugin := range s.scorePlugins {
+		if plugin.Name() == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,234 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/kafkas/types"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	apiErrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/onsi/gomega"
+)
+
+func Test_clusterPlacementScheduler_schedule(t *testing.T) {
+	type fields struct {
+		clusterService  ClusterService
+		placementConfig config.ClusterPlacementConfig
+	}
+
+	type args struct {
+		kafka               *dbapi.KafkaRequest
+		kafkaStreamingUnits int
+		candidates          []PlacementCandidate
+	}
+
+	kafka := func() *dbapi.KafkaRequest {
+		return &dbapi.KafkaRequest{
+			Meta:          api.Meta{ID: "kafka-id"},
+			CloudProvider: "aws",
+			Region:        "us-east-1",
+			MultiAZ:       true,
+			InstanceType:  types.STANDARD.String(),
+		}
+	}
+
+	cluster := func(clusterID string, modifyFn func(cluster *api.Cluster)) *api.Cluster {
+		c := &api.Cluster{
+			ClusterID:             clusterID,
+			CloudProvider:         "aws",
+			Region:                "us-east-1",
+			MultiAZ:               true,
+			SupportedInstanceType: api.AllInstanceTypeSupport.String(),
+		}
+		if modifyFn != nil {
+			modifyFn(c)
+		}
+		return c
+	}
+
+	tests := []struct {
+		name              string
+		fields            fields
+		args              args
+		wantClusterID     string
+		wantErr           bool
+		wantEligible      []bool
+		wantDecisionScore []int64
+	}{
+		{
+			name: "should select the first eligible candidate when no score plugin is enabled",
+			fields: fields{
+				placementConfig: config.NewClusterPlacementConfig(),
+			},
+			args: args{
+				kafka: kafka(),
+				candidates: []PlacementCandidate{
+					{Cluster: cluster("cordoned", func(c *api.Cluster) { c.Cordoned = true })},
+					{Cluster: cluster("first", nil), ConsumedStreamingUnits: 1, MaxStreamingUnits: 10},
+					{Cluster: cluster("second", nil), ConsumedStreamingUnits: 9, MaxStreamingUnits: 10},
+				},
+			},
+			wantClusterID:     "first",
+			wantEligible:      []bool{false, true, true},
+			wantDecisionScore: []int64{0, 0, 0},
+		},
+		{
+			name: "should not select any cluster when none of the candidates is eligible",
+			fields: fields{
+				placementConfig: config.NewClusterPlacementConfig(),
+			},
+			args: args{
+				kafka: kafka(),
+				candidates: []PlacementCandidate{
+					{Cluster: cluster("other-region", func(c *api.Cluster) { c.Region = "eu-west-1" })},
+					{Cluster: cluster("private", func(c *api.Cluster) { c.AccessKafkasViaPrivateNetwork = true })},
+				},
+			},
+			wantClusterID:     "",
+			wantEligible:      []bool{false, false},
+			wantDecisionScore: []int64{0, 0},
+		},
+		{
+			name: "should select the most utilised candidate when bin packing is enabled",
+			fields: fields{
+				placementConfig: config.ClusterPlacementConfig{
+					ScoreWeights: map[string]int{config.BinPackingScorePlugin: 1},
+				},
+			},
+			args: args{
+				kafka:               kafka(),
+				kafkaStreamingUnits: 1,
+				candidates: []PlacementCandidate{
+					{Cluster: cluster("least-utilised", nil), ConsumedStreamingUnits: 1, MaxStreamingUnits: 10},
+					{Cluster: cluster("most-utilised", nil), ConsumedStreamingUnits: 8, MaxStreamingUnits: 10},
+				},
+			},
+			wantClusterID:     "most-utilised",
+			wantEligible:      []bool{true, true},
+			wantDecisionScore: []int64{20, 90},
+		},
+		{
+			name: "should select the least utilised candidate when least loaded is enabled",
+			fields: fields{
+				placementConfig: config.ClusterPlacementConfig{
+					ScoreWeights: map[string]int{config.LeastLoadedScorePlugin: 2},
+				},
+			},
+			args: args{
+				kafka:               kafka(),
+				kafkaStreamingUnits: 1,
+				candidates: []PlacementCandidate{
+					{Cluster: cluster("most-utilised", nil), ConsumedStreamingUnits: 8, MaxStreamingUnits: 10},
+					{Cluster: cluster("least-utilised", nil), ConsumedStreamingUnits: 1, MaxStreamingUnits: 10},
+				},
+			},
+			wantClusterID:     "least-utilised",
+			wantEligible:      []bool{true, true},
+			wantDecisionScore: []int64{20, 160},
+		},
+		{
+			name: "should select the candidate hosting the fewest kafkas when spread is enabled",
+			fields: fields{
+				clusterService: &ClusterServiceMock{
+					CountKafkasPerClusterFunc: func(clusterIDs []string) (map[string]int, *apiErrors.ServiceError) {
+						return map[string]int{"busy": 4, "quiet": 1}, nil
+					},
+				},
+				placementConfig: config.ClusterPlacementConfig{
+					ScoreWeights: map[string]int{config.SpreadScorePlugin: 1},
+				},
+			},
+			args: args{
+				kafka: kafka(),
+				candidates: []PlacementCandidate{
+					{Cluster: cluster("busy", nil)},
+					{Cluster: cluster("quiet", nil)},
+				},
+			},
+			wantClusterID:     "quiet",
+			wantEligible:      []bool{true, true},
+			wantDecisionScore: []int64{0, 75},
+		},
+		{
+			name: "should return an error when counting the kafkas per cluster fails",
+			fields: fields{
+				clusterService: &ClusterServiceMock{
+					CountKafkasPerClusterFunc: func(clusterIDs []string) (map[string]int, *apiErrors.ServiceError) {
+						return nil, apiErrors.GeneralError("failed to count kafkas")
+					},
+				},
+				placementConfig: config.ClusterPlacementConfig{
+					ScoreWeights: map[string]int{config.SpreadScorePlugin: 1},
+				},
+			},
+			args: args{
+				kafka: kafka(),
+				candidates: []PlacementCandidate{
+					{Cluster: cluster("busy", nil)},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should combine the weighted scores of the enabled score plugins",
+			fields: fields{
+				placementConfig: config.ClusterPlacementConfig{
+					ScoreWeights: map[string]int{
+						config.BinPackingScorePlugin:             1,
+						config.StrimziVersionAffinityScorePlugin: 2,
+					},
+				},
+			},
+			args: args{
+				kafka:               kafka(),
+				kafkaStreamingUnits: 1,
+				candidates: []PlacementCandidate{
+					{Cluster: cluster("no-strimzi", nil), ConsumedStreamingUnits: 8, MaxStreamingUnits: 10},
+					{Cluster: cluster("ready-strimzi", func(c *api.Cluster) {
+						c.AvailableStrimziVersions = api.JSON(`[{"version": "strimzi-cluster-operator.v0.23.0-0", "ready": true}]`)
+					}), ConsumedStreamingUnits: 1, MaxStreamingUnits: 10},
+				},
+			},
+			wantClusterID:     "ready-strimzi",
+			wantEligible:      []bool{true, true},
+			wantDecisionScore: []int64{90, 220},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			s := newClusterPlacementScheduler(tt.fields.clusterService, tt.fields.placementConfig)
+
+			got, err := s.schedule("test-strategy", tt.args.kafka, tt.args.kafkaStreamingUnits, tt.args.candidates)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
+				return
+			}
+
+			decision, decisionErr := tt.args.kafka.GetPlacementDecision()
+			g.Expect(decisionErr).ToNot(gomega.HaveOccurred())
+			g.Expect(decision).ToNot(gomega.BeNil())
+			g.Expect(decision.Strategy).To(gomega.Equal("test-strategy"))
+			g.Expect(decision.ClusterID).To(gomega.Equal(tt.wantClusterID))
+			g.Expect(decision.Reason).ToNot(gomega.BeEmpty())
+			g.Expect(decision.Candidates).To(gomega.HaveLen(len(tt.args.candidates)))
+			for i, candidate := range decision.Candidates {
+				g.Expect(candidate.Eligible).To(gomega.Equal(tt.wantEligible[i]))
+				g.Expect(candidate.Score).To(gomega.Equal(tt.wantDecisionScore[i]))
+				if !candidate.Eligible {
+					g.Expect(candidate.Reasons).ToNot(gomega.BeEmpty())
+				}
+			}
+
+			if tt.wantClusterID == "" {
+				g.Expect(got).To(gomega.BeNil())
+			} else {
+				g.Expect(got).ToNot(gomega.BeNil())
+				g.Expect(got.ClusterID).To(gomega.Equal(tt.wantClusterID))
+			}
+		})
+	}
+}
@@ -16,16 +16,26 @@ type ClusterPlacementStrategy interface {
 	FindCluster(kafka *dbapi.KafkaRequest) (*api.Cluster, error)
 }
 
-// NewClusterPlacementStrategy return a concrete strategy impl. depends on the placement configuration
+const (
+	firstReadyClusterStrategyName           = "first_ready_cluster"
+	firstSchedulableWithinLimitStrategyName = "first_schedulable_within_limit"
+	firstReadyWithCapacityStrategyName      = "first_ready_with_capacity"
+)
+
+// NewClusterPlacementStrategy return a concrete strategy impl. depends on the placement configuration.
+// The strategy gives the candidate clusters having enough capacity for the kafka and the cluster is then selected
+// among them by a scheduler configured through the placement section of the data plane cluster configuration.
 func NewClusterPlacementStrategy(clusterService ClusterService, dataplaneClusterConfig *config.DataplaneClusterConfig, kafkaConfig *config.KafkaConfig) ClusterPlacementStrategy {
+	scheduler := newClusterPlacementScheduler(clusterService, dataplaneClusterConfig.ClusterPlacementConfig)
+
 	var clusterSelection ClusterPlacementStrategy
 	switch {
 	case dataplaneClusterConfig.IsDataPlaneManualScalingEnabled():
-		clusterSelection = &FirstSchedulableWithinLimit{dataplaneClusterConfig, clusterService, kafkaConfig}
+		clusterSelection = &FirstSchedulableWithinLimit{dataplaneClusterConfig, clusterService, kafkaConfig, scheduler}
 	case dataplaneClusterConfig.IsDataPlaneAutoScalingEnabled():
-		clusterSelection = &FirstReadyWithCapacity{clusterService, kafkaConfig}
+		clusterSelection = &FirstReadyWithCapacity{clusterService, kafkaConfig, scheduler}
 	default:
-		clusterSelection = &FirstReadyCluster{clusterService, kafkaConfig}
+		clusterSelection = &FirstReadyCluster{clusterService, kafkaConfig, scheduler}
 	}
 	return clusterSelection
 }
@@ -42,7 +52,8 @@ type findDataPlaneClusterByIdIfItHasCapacityAvailable struct {
 // Once the cluster is found, it has to match the following rules:
 // 1. The cluster has to be in ready state.
 // 2. It also also has to be in the same organization as the kafka request.
-// 3. It must have remaining capacity to receive the Kafka.
+// 3. It must not be cordoned.
+// 4. It must have remaining capacity to receive the Kafka.
 // Capacity capacity is evaluated based on the MaxUnits stored in DynamicCapacityInfo and the actual used capacity.
 func (f *findDataPlaneClusterByIdIfItHasCapacityAvailable) FindCluster(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
 	cluster, err := f.clusterService.FindClusterByID(kafka.ClusterID)
@@ -58,6 +69,10 @@ func (f *findDataPlaneClusterByIdIfItHasCapacityAvailable) FindCluster(kafka *db
 		return nil, apiErrors.BadRequest("cluster with id: %s is not ready to accept kafkas", kafka.ClusterID)
 	}
 
+	if cluster.Cordoned {
+		return nil, apiErrors.BadRequest("cluster with id: %s is cordoned and does not accept kafkas", kafka.ClusterID)
+	}
+
 	kafkaSizeConsumption, sizeErr := f.kafkaConfig.GetKafkaInstanceSize(kafka.InstanceType, kafka.SizeId)
 	if sizeErr != nil {
 		return nil, sizeErr
@@ -83,10 +98,12 @@ func (f *findDataPlaneClusterByIdIfItHasCapacityAvailable) FindCluster(kafka *db
 	return nil, nil
 }
 
-// FirstReadyCluster finds and returns the first cluster with Ready status
+// FirstReadyCluster finds and returns a cluster with Ready status.
+// The first one is returned unless score plugins are enabled.
 type FirstReadyCluster struct {
 	ClusterService ClusterService
 	kafkaConfig    *config.KafkaConfig
+	scheduler      *clusterPlacementScheduler
 }
 
 func (f *FirstReadyCluster) FindCluster(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
@@ -104,22 +121,31 @@ func (f *FirstReadyCluster) FindCluster(kafka *dbapi.KafkaRequest) (*api.Cluster
 		MultiAZ:               kafka.MultiAZ,
 		Status:                api.ClusterReady,
 		SupportedInstanceType: kafka.InstanceType,
+		ExcludeCordoned:       true,
 	}
 
-	cluster, err := f.ClusterService.FindCluster(criteria)
+	clusters, err := f.ClusterService.FindAllClusters(criteria)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to find a cluster with criteria '%v'", criteria)
+		return nil, errors.Wrapf(err, "failed to find all clusters with criteria '%v'", criteria)
 	}
 
-	return cluster, nil
+	// the capacity of the clusters is not tracked with this strategy
+	candidates := make([]PlacementCandidate, 0, len(clusters))
+	for _, cluster := range clusters {
+		candidates = append(candidates, PlacementCandidate{Cluster: cluster})
+	}
+
+	return f.scheduler.schedule(firstReadyClusterStrategyName, kafka, 0, candidates)
 }
 
-// FirstSchedulableWithinLimit finds and returns the first cluster which is schedulable and the number of
+// FirstSchedulableWithinLimit finds and returns a cluster which is schedulable and the number of
 // Kafka clusters associated with it is within the defined limit.
+// The first one in the order of the configuration is returned unless score plugins are enabled.
 type FirstSchedulableWithinLimit struct {
 	dataplaneClusterConfig *config.DataplaneClusterConfig
 	clusterService         ClusterService
 	kafkaConfig            *config.KafkaConfig
+	scheduler              *clusterPlacementScheduler
 }
 
 func (f *FirstSchedulableWithinLimit) FindCluster(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
@@ -137,6 +163,7 @@ func (f *FirstSchedulableWithinLimit) FindCluster(kafka *dbapi.KafkaRequest) (*a
 		MultiAZ:               kafka.MultiAZ,
 		Status:                api.ClusterReady,
 		SupportedInstanceType: kafka.InstanceType,
+		ExcludeCordoned:       true,
 	}
 
 	kafkaInstanceSize, e := f.kafkaConfig.GetKafkaInstanceSize(kafka.InstanceType, kafka.SizeId)
@@ -164,17 +191,26 @@ func (f *FirstSchedulableWithinLimit) FindCluster(kafka *dbapi.KafkaRequest) (*a
 
 	//#3 which schedulable cluster is also within the limit
 	//we want to make sure the order of the ids configuration is always respected: e.g the first cluster in the configuration that passes all the checks should be picked first
+	candidates := []PlacementCandidate{}
 	for _, clusterID := range clusterIDs {
 		currentStreamingUnitConsumption := consumedStreamingUnitPerClusterID[clusterID]
 		futureStreamingUnitConsumptionInTheCluster := currentStreamingUnitConsumption + kafkaInstanceSize.CapacityConsumed
 		numberOfKafkaIsWithinLimit := f.dataplaneClusterConfig.ClusterConfig.IsNumberOfStreamingUnitsWithinClusterLimit(clusterID, futureStreamingUnitConsumptionInTheCluster)
 		if numberOfKafkaIsWithinLimit {
-			return searchForClusterFromClustersList(clusters, clusterID), nil
+			// a negative limit means that the cluster has no limit
+			limit := f.dataplaneClusterConfig.ClusterConfig.GetClusterKafkaInstanceLimit(clusterID)
+			if limit < 0 {
+				limit = 0
+			}
+			candidates = append(candidates, PlacementCandidate{
+				Cluster:                searchForClusterFromClustersList(clusters, clusterID),
+				ConsumedStreamingUnits: currentStreamingUnitConsumption,
+				MaxStreamingUnits:      limit,
+			})
 		}
 	}
 
-	//no cluster available
-	return nil, nil
+	return f.scheduler.schedule(firstSchedulableWithinLimitStrategyName, kafka, kafkaInstanceSize.CapacityConsumed, candidates)
 }
 
 // findClusterIDsOfManagedClustersThatAreSchedulable returns the clusterIDs of managed clusters that are schedulable
@@ -215,10 +251,12 @@ func (f *FirstSchedulableWithinLimit) findConsumedStreamingUnitPerClusterID(clus
 	return consumedStreamingUnitPerClusterID, nil
 }
 
-// FirstReadyWithCapacity finds and returns the first cluster in a Ready status with remaining capacity
+// FirstReadyWithCapacity finds and returns a cluster in a Ready status with remaining capacity.
+// The first one is returned unless score plugins are enabled.
 type FirstReadyWithCapacity struct {
 	clusterService ClusterService
 	kafkaConfig    *config.KafkaConfig
+	scheduler      *clusterPlacementScheduler
 }
 
 func (f *FirstReadyWithCapacity) FindCluster(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
@@ -237,6 +275,7 @@ func (f *FirstReadyWithCapacity) FindCluster(kafka *dbapi.KafkaRequest) (*api.Cl
 		MultiAZ:               kafka.MultiAZ,
 		Status:                api.ClusterReady,
 		SupportedInstanceType: kafka.InstanceType,
+		ExcludeCordoned:       true,
 	}
 
 	clusters, findAllClusterErr := f.clusterService.FindAllClusters(criteria)
@@ -255,17 +294,21 @@ func (f *FirstReadyWithCapacity) FindCluster(kafka *dbapi.KafkaRequest) (*api.Cl
 		return nil, errors.Wrapf(getInstanceSizeErr, "failed to get kafka instance size for cluster with criteria '%v'", criteria)
 	}
 
+	candidates := []PlacementCandidate{}
 	for _, cluster := range clusters {
 		if cluster.ClusterType == api.ManagedDataPlaneClusterType.String() {
 			clusterNotFull := f.isManagedClusterNotFull(cluster, streamingUnitCountPerRegionList, kafka, instanceSize)
 			if clusterNotFull {
-				return cluster, nil
+				candidates = append(candidates, PlacementCandidate{
+					Cluster:                cluster,
+					ConsumedStreamingUnits: streamingUnitCountPerRegionList.GetStreamingUnitCountForClusterAndInstanceType(cluster.ClusterID, kafka.InstanceType),
+					MaxStreamingUnits:      int(cluster.RetrieveDynamicCapacityInfo()[kafka.InstanceType].MaxUnits),
+				})
 			}
 		}
 	}
 
-	// no cluster found
-	return nil, nil
+	return f.scheduler.schedule(firstReadyWithCapacityStrategyName, kafka, instanceSize.CapacityConsumed, candidates)
 }
 
 func (f *FirstReadyWithCapacity) isManagedClusterNotFull(cluster *api.Cluster, streamingUnitCountPerRegionList KafkaStreamingUnitCountPerClusterList,
@@ -36,15 +36,15 @@ func TestFirstReadyCluster_FindCluster(t *testing.T) {
 				Kafka:                  config.NewKafkaConfig(),
 				DataplaneClusterConfig: config.NewDataplaneClusterConfig(),
 				ClusterService: &ClusterServiceMock{
-					FindClusterFunc: func(criteria FindClusterCriteria) (*api.Cluster, error) {
-						return &api.Cluster{}, nil
+					FindAllClustersFunc: func(criteria FindClusterCriteria) ([]*api.Cluster, error) {
+						return []*api.Cluster{{ClusterID: "test01"}, {ClusterID: "test02"}}, nil
 					},
 				},
 			},
 			args: args{
 				kafka: &dbapi.KafkaRequest{},
 			},
-			want:    &api.Cluster{},
+			want:    &api.Cluster{ClusterID: "test01"},
 			wantErr: false,
 		},
 		{
@@ -53,8 +53,8 @@ func TestFirstReadyCluster_FindCluster(t *testing.T) {
 				Kafka:                  config.NewKafkaConfig(),
 				DataplaneClusterConfig: config.NewDataplaneClusterConfig(),
 				ClusterService: &ClusterServiceMock{
-					FindClusterFunc: func(criteria FindClusterCriteria) (*api.Cluster, error) {
-						return nil, nil
+					FindAllClustersFunc: func(criteria FindClusterCriteria) ([]*api.Cluster, error) {
+						return []*api.Cluster{}, nil
 					},
 				},
 			},
@@ -70,7 +70,7 @@ func TestFirstReadyCluster_FindCluster(t *testing.T) {
 				Kafka:                  config.NewKafkaConfig(),
 				DataplaneClusterConfig: config.NewDataplaneClusterConfig(),
 				ClusterService: &ClusterServiceMock{
-					FindClusterFunc: func(criteria FindClusterCriteria) (*api.Cluster, error) {
+					FindAllClustersFunc: func(criteria FindClusterCriteria) ([]*api.Cluster, error) {
 						return nil, errors.New("not found")
 					},
 				},
@@ -90,6 +90,7 @@ func TestFirstReadyCluster_FindCluster(t *testing.T) {
 			g := gomega.NewWithT(t)
 			f := &FirstReadyCluster{
 				ClusterService: tt.fields.ClusterService,
+				scheduler:      newClusterPlacementScheduler(tt.fields.ClusterService, config.NewClusterPlacementConfig()),
 			}
 			got, err := f.FindCluster(tt.args.kafka)
 			if (err != nil) != tt.wantErr {
@@ -268,6 +269,7 @@ func TestFirstScheduleWithinLimit_FindCluster(t *testing.T) {
 				dataplaneClusterConfig: tt.fields.DataplaneClusterConfig,
 				clusterService:         tt.fields.ClusterService,
 				kafkaConfig:            tt.fields.kafkaConfig,
+				scheduler:              newClusterPlacementScheduler(tt.fields.ClusterService, config.NewClusterPlacementConfig()),
 			}
 			got, err := f.FindCluster(tt.args.kafka)
 			if (err != nil) != tt.wantErr {
@@ -309,8 +311,9 @@ func TestFirstReadyWithCapacity_FindCluster(t *testing.T) {
 			},
 			want: nil,
 			wantErr: errors.Wrapf(errors.New("failed to find clusters"), fmt.Sprintf("failed to find all clusters with criteria '%v'", FindClusterCriteria{
-				MultiAZ: mockkafkas.BuildKafkaRequest().MultiAZ,
-				Status:  api.ClusterReady,
+				MultiAZ:         mockkafkas.BuildKafkaRequest().MultiAZ,
+				Status:          api.ClusterReady,
+				ExcludeCordoned: true,
 			})),
 		},
 		{
@@ -335,8 +338,9 @@ func TestFirstReadyWithCapacity_FindCluster(t *testing.T) {
 			},
 			want: nil,
 			wantErr: errors.Wrapf(errors.New("failed to retrieve streaming unit count per region and instance type"), fmt.Sprintf("failed to get count of streaming units by cluster and instance type for criteria '%v'", FindClusterCriteria{
-				MultiAZ: mockkafkas.BuildKafkaRequest().MultiAZ,
-				Status:  api.ClusterReady,
+				MultiAZ:         mockkafkas.BuildKafkaRequest().MultiAZ,
+				Status:          api.ClusterReady,
+				ExcludeCordoned: true,
 			})),
 		},
 		{
@@ -375,6 +379,7 @@ func TestFirstReadyWithCapacity_FindCluster(t *testing.T) {
 				MultiAZ:               mockkafkas.BuildKafkaRequest().MultiAZ,
 				Status:                api.ClusterReady,
 				SupportedInstanceType: "unsupported",
+				ExcludeCordoned:       true,
 			})),
 		},
 		{
@@ -558,6 +563,7 @@ func TestFirstReadyWithCapacity_FindCluster(t *testing.T) {
 			f := &FirstReadyWithCapacity{
 				clusterService: tt.fields.ClusterService,
 				kafkaConfig:    tt.fields.KafkaConfig,
+				scheduler:      newClusterPlacementScheduler(tt.fields.ClusterService, config.NewClusterPlacementConfig()),
 			}
 
 			got, err := f.FindCluster(tt.args.kafka)
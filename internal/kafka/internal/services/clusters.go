@@ -17,6 +17,8 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
 	kafkaTypes "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/kafkas/types"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/metrics"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/queryparser"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
@@ -41,6 +43,17 @@ type ClusterService interface {
 	// Update updates a Cluster. Only fields whose value is different than the
 	// zero-value of their corresponding type will be updated
 	Update(cluster api.Cluster) *apiErrors.ServiceError
+	// Updates updates the given fields of a cluster. This takes in a map so that even zero-fields can be updated.
+	// Use this only when you want to update the multiple columns that may contain zero-fields, otherwise use the `ClusterService.Update()` method.
+	Updates(cluster api.Cluster, values map[string]interface{}) *apiErrors.ServiceError
+	// List returns the clusters matching the given list arguments, regardless of their type or organization.
+	// This is meant to be used by admin endpoints only.
+	List(listArgs *coreServices.ListArguments) (api.ClusterList, *api.PagingMeta, *apiErrors.ServiceError)
+	// CountKafkasPerCluster returns the number of kafkas hosted in each of the given clusters.
+	// Kafkas that are in deleting state won't be included in the count as they no longer consume resources in the data plane cluster.
+	CountKafkasPerCluster(clusterIDs []string) (map[string]int, *apiErrors.ServiceError)
+	// ListCordonedClusters returns all the clusters that have been cordoned
+	ListCordonedClusters() ([]api.Cluster, *apiErrors.ServiceError)
 	FindCluster(criteria FindClusterCriteria) (*api.Cluster, error)
 	// FindClusterByID returns the cluster corresponding to the provided clusterID.
 	// If the cluster has not been found nil is returned. If there has been an issue
@@ -256,6 +269,111 @@ func (c clusterService) Update(cluster api.Cluster) *apiErrors.ServiceError {
 	return nil
 }
 
+func (c clusterService) Updates(cluster api.Cluster, values map[string]interface{}) *apiErrors.ServiceError {
+	if cluster.ID == "" {
+		return apiErrors.Validation("id is undefined")
+	}
+
+	dbConn := c.connectionFactory.New().Model(&api.Cluster{}).Where("id = ?", cluster.ID)
+
+	if err := dbConn.Updates(values).Error; err != nil {
+		return apiErrors.NewWithCause(apiErrors.ErrorGeneral, err, "failed to update cluster")
+	}
+
+	return nil
+}
+
+// ClusterSearchableColumns are the columns that can be used in the search query of the clusters list
+var ClusterSearchableColumns = []string{"cluster_id", "cloud_provider", "region", "status", "cluster_type", "multi_az", "cordoned", "organization_id"}
+
+func (c clusterService) List(listArgs *coreServices.ListArguments) (api.ClusterList, *api.PagingMeta, *apiErrors.ServiceError) {
+	var clusterList api.ClusterList
+	dbConn := c.connectionFactory.New()
+	pagingMeta := &api.PagingMeta{
+		Page: listArgs.Page,
+		Size: listArgs.Size,
+	}
+
+	// Apply search query
+	if len(listArgs.Search) > 0 {
+		searchDbQuery, err := queryparser.NewQueryParser(ClusterSearchableColumns...).Parse(listArgs.Search)
+		if err != nil {
+			return clusterList, pagingMeta, apiErrors.NewWithCause(apiErrors.ErrorFailedToParseSearch, err, "unable to list clusters: %s", err.Error())
+		}
+		dbConn = dbConn.Where(searchDbQuery.Query, searchDbQuery.Values...)
+	}
+
+	if len(listArgs.OrderBy) == 0 {
+		// default orderBy creation time
+		dbConn = dbConn.Order("created_at")
+	}
+
+	// Set the order by arguments if any
+	for _, orderByArg := range listArgs.OrderBy {
+		dbConn = dbConn.Order(orderByArg)
+	}
+
+	// set total, limit and paging (based on https://gitlab.cee.redhat.com/service/api-guidelines#user-content-paging)
+	total := int64(pagingMeta.Total)
+	dbConn.Model(&clusterList).Count(&total)
+	pagingMeta.Total = int(total)
+	if pagingMeta.Size > pagingMeta.Total {
+		pagingMeta.Size = pagingMeta.Total
+	}
+	dbConn = dbConn.Offset((pagingMeta.Page - 1) * pagingMeta.Size).Limit(pagingMeta.Size)
+
+	// execute query
+	if err := dbConn.Find(&clusterList).Error; err != nil {
+		return clusterList, pagingMeta, apiErrors.NewWithCause(apiErrors.ErrorGeneral, err, "unable to list clusters")
+	}
+
+	return clusterList, pagingMeta, nil
+}
+
+func (c clusterService) CountKafkasPerCluster(clusterIDs []string) (map[string]int, *apiErrors.ServiceError) {
+	var results []struct {
+		ClusterID string
+		Count     int
+	}
+
+	kafkaCountPerCluster := map[string]int{}
+	if len(clusterIDs) == 0 {
+		return kafkaCountPerCluster, nil
+	}
+
+	if err := c.connectionFactory.New().
+		Model(&dbapi.KafkaRequest{}).
+		Select("cluster_id, count(1) as count").
+		Where("cluster_id in (?)", clusterIDs).
+		Where("status not in (?)", kafkaStatusesThatNoLongerConsumeResourcesInTheDataPlane).
+		Group("cluster_id").
+		Scan(&results).Error; err != nil {
+		return nil, apiErrors.NewWithCause(apiErrors.ErrorGeneral, err, "failed to count kafkas per cluster")
+	}
+
+	for _, clusterID := range clusterIDs {
+		kafkaCountPerCluster[clusterID] = 0
+	}
+
+	for _, result := range results {
+		kafkaCountPerCluster[result.ClusterID] = result.Count
+	}
+
+	return kafkaCountPerCluster, nil
+}
+
+func (c clusterService) ListCordonedClusters() ([]api.Cluster, *apiErrors.ServiceError) {
+	dbConn := c.connectionFactory.New()
+
+	var clusters []api.Cluster
+
+	if err := dbConn.Model(&api.Cluster{}).Where("cordoned = ?", true).Order("created_at asc").Scan(&clusters).Error; err != nil {
+		return nil, apiErrors.NewWithCause(apiErrors.ErrorGeneral, err, "failed to list cordoned clusters")
+	}
+
+	return clusters, nil
+}
+
 func (c clusterService) UpdateStatus(cluster api.Cluster, status api.ClusterStatus) error {
 	if status.String() == "" {
 		return apiErrors.Validation("status is undefined")
@@ -323,6 +441,8 @@ type FindClusterCriteria struct {
 	Status                api.ClusterStatus
 	SupportedInstanceType string
 	ExternalID            string
+	// ExcludeCordoned excludes the clusters that have been cordoned from the result
+	ExcludeCordoned bool
 }
 
 func (c clusterService) FindCluster(criteria FindClusterCriteria) (*api.Cluster, error) {
@@ -343,6 +463,10 @@ func (c clusterService) FindCluster(criteria FindClusterCriteria) (*api.Cluster,
 		dbConn = dbConn.Where("supported_instance_type like ?", fmt.Sprintf("%%%s%%", criteria.SupportedInstanceType))
 	}
 
+	if criteria.ExcludeCordoned {
+		dbConn = dbConn.Where("cordoned = ?", false)
+	}
+
 	// we order them by "created_at" field instead of the default "id" field.
 	// They are mostly the same as the library we use (xid) does take the generation timestamp into consideration,
 	// However, it only down to the level of seconds. This means that if a few records are created at almost the same time,
@@ -536,6 +660,10 @@ func (c clusterService) FindAllClusters(criteria FindClusterCriteria) ([]*api.Cl
 	if criteria.SupportedInstanceType != "" {
 		dbConn.Where("supported_instance_type like ?", fmt.Sprintf("%%%s%%", criteria.SupportedInstanceType))
 	}
+
+	if criteria.ExcludeCordoned {
+		dbConn.Where("cordoned = ?", false)
+	}
 	// we order them by "created_at" field instead of the default "id" field.
 	// They are mostly the same as the library we use (xid) does take the generation timestamp into consideration,
 	// However, it only down to the level of seconds. This means that if a few records are created at almost the same time,
@@ -765,6 +893,7 @@ type KafkaStreamingUnitCountPerCluster struct {
 	MaxUnits      int32
 	Status        string
 	ClusterType   string
+	Cordoned      bool
 }
 
 func (k KafkaStreamingUnitCountPerCluster) isSame(kafkaPerRegionFromDB *KafkaPerClusterCount) bool {
@@ -817,6 +946,7 @@ type ClusterSelection struct {
 	DynamicCapacityInfo   api.JSON
 	Status                string
 	ClusterType           string
+	Cordoned              bool
 }
 
 func (c *clusterService) FindStreamingUnitCountByClusterAndInstanceType() (KafkaStreamingUnitCountPerClusterList, error) {
@@ -862,6 +992,7 @@ func (c *clusterService) FindStreamingUnitCountByClusterAndInstanceType() (Kafka
 				MaxUnits:      maxUnits,
 				Status:        clusterSelection.Status,
 				ClusterType:   clusterSelection.ClusterType,
+				Cordoned:      clusterSelection.Cordoned,
 			})
 		}
 	}
@@ -1606,6 +1606,85 @@ func Test_clusterService_CountByStatus(t *testing.T) {
 	}
 }
 
+func Test_clusterService_CountKafkasPerCluster(t *testing.T) {
+	type fields struct {
+		connectionFactory *db.ConnectionFactory
+	}
+	type args struct {
+		clusterIDs []string
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		args      args
+		wantErr   bool
+		want      map[string]int
+		setupFunc func()
+	}{
+		{
+			name:    "should return an empty map when no cluster ids are given",
+			fields:  fields{connectionFactory: db.NewMockConnectionFactory(nil)},
+			args:    args{clusterIDs: []string{}},
+			wantErr: false,
+			want:    map[string]int{},
+		},
+		{
+			name:   "should return the kafka count of each cluster",
+			fields: fields{connectionFactory: db.NewMockConnectionFactory(nil)},
+			args: args{
+				clusterIDs: []string{"cluster-1", "cluster-2"},
+			},
+			wantErr: false,
+			setupFunc: func() {
+				counters := []map[string]interface{}{
+					{
+						"cluster_id": "cluster-1",
+						"count":      3,
+					},
+				}
+				mocket.Catcher.Reset().
+					NewMock().
+					WithQuery(`SELECT cluster_id, count(1) as count FROM "kafka_requests" WHERE cluster_id in ($1,$2) AND status not in ($3) AND "kafka_requests"."deleted_at" IS NULL GROUP BY "cluster_id"`).
+					WithArgs("cluster-1", "cluster-2", "deleting").
+					WithReply(counters)
+			},
+			want: map[string]int{
+				"cluster-1": 3,
+				"cluster-2": 0,
+			},
+		},
+		{
+			name:   "should return an error when the query fails",
+			fields: fields{connectionFactory: db.NewMockConnectionFactory(nil)},
+			args: args{
+				clusterIDs: []string{"cluster-1"},
+			},
+			wantErr: true,
+			setupFunc: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT`).WithQueryException()
+			},
+			want: nil,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			if tt.setupFunc != nil {
+				tt.setupFunc()
+			}
+			c := clusterService{
+				connectionFactory: tt.fields.connectionFactory,
+			}
+			count, err := c.CountKafkasPerCluster(tt.args.clusterIDs)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(count).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
 func Test_clusterService_CheckClusterStatus(t *testing.T) {
 	type fields struct {
 		connectionFactory      *db.ConnectionFactory
@@ -9,6 +9,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/ocm"
 	apiErrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
 	"sync"
 )
 
@@ -40,6 +41,9 @@ var _ ClusterService = &ClusterServiceMock{}
 //			CountByStatusFunc: func(clusterStatuss []api.ClusterStatus) ([]ClusterStatusCount, *apiErrors.ServiceError) {
 //				panic("mock out the CountByStatus method")
 //			},
+//			CountKafkasPerClusterFunc: func(clusterIDs []string) (map[string]int, *apiErrors.ServiceError) {
+//				panic("mock out the CountKafkasPerCluster method")
+//			},
 //			CreateFunc: func(cluster *api.Cluster) (*api.Cluster, *apiErrors.ServiceError) {
 //				panic("mock out the Create method")
 //			},
@@ -91,9 +95,15 @@ var _ ClusterService = &ClusterServiceMock{}
 //			IsStrimziKafkaVersionAvailableInClusterFunc: func(cluster *api.Cluster, strimziVersion string, kafkaVersion string, ibpVersion string) (bool, error) {
 //				panic("mock out the IsStrimziKafkaVersionAvailableInCluster method")
 //			},
+//			ListFunc: func(listArgs *coreServices.ListArguments) (api.ClusterList, *api.PagingMeta, *apiErrors.ServiceError) {
+//				panic("mock out the List method")
+//			},
 //			ListByStatusFunc: func(state api.ClusterStatus) ([]api.Cluster, *apiErrors.ServiceError) {
 //				panic("mock out the ListByStatus method")
 //			},
+//			ListCordonedClustersFunc: func() ([]api.Cluster, *apiErrors.ServiceError) {
+//				panic("mock out the ListCordonedClusters method")
+//			},
 //			ListEnterpriseClustersOfAnOrganizationFunc: func(ctx context.Context) ([]*api.Cluster, *apiErrors.ServiceError) {
 //				panic("mock out the ListEnterpriseClustersOfAnOrganization method")
 //			},
@@ -118,6 +128,9 @@ var _ ClusterService = &ClusterServiceMock{}
 //			UpdateStatusFunc: func(cluster api.Cluster, status api.ClusterStatus) error {
 //				panic("mock out the UpdateStatus method")
 //			},
+//			UpdatesFunc: func(cluster api.Cluster, values map[string]interface{}) *apiErrors.ServiceError {
+//				panic("mock out the Updates method")
+//			},
 //		}
 //
 //		// use mockedClusterService in code that requires ClusterService
@@ -143,6 +156,9 @@ type ClusterServiceMock struct {
 	// CountByStatusFunc mocks the CountByStatus method.
 	CountByStatusFunc func(clusterStatuss []api.ClusterStatus) ([]ClusterStatusCount, *apiErrors.ServiceError)
 
+	// CountKafkasPerClusterFunc mocks the CountKafkasPerCluster method.
+	CountKafkasPerClusterFunc func(clusterIDs []string) (map[string]int, *apiErrors.ServiceError)
+
 	// CreateFunc mocks the Create method.
 	CreateFunc func(cluster *api.Cluster) (*api.Cluster, *apiErrors.ServiceError)
 
@@ -194,9 +210,15 @@ type ClusterServiceMock struct {
 	// IsStrimziKafkaVersionAvailableInClusterFunc mocks the IsStrimziKafkaVersionAvailableInCluster method.
 	IsStrimziKafkaVersionAvailableInClusterFunc func(cluster *api.Cluster, strimziVersion string, kafkaVersion string, ibpVersion string) (bool, error)
 
+	// ListFunc mocks the List method.
+	ListFunc func(listArgs *coreServices.ListArguments) (api.ClusterList, *api.PagingMeta, *apiErrors.ServiceError)
+
 	// ListByStatusFunc mocks the ListByStatus method.
 	ListByStatusFunc func(state api.ClusterStatus) ([]api.Cluster, *apiErrors.ServiceError)
 
+	// ListCordonedClustersFunc mocks the ListCordonedClusters method.
+	ListCordonedClustersFunc func() ([]api.Cluster, *apiErrors.ServiceError)
+
 	// ListEnterpriseClustersOfAnOrganizationFunc mocks the ListEnterpriseClustersOfAnOrganization method.
 	ListEnterpriseClustersOfAnOrganizationFunc func(ctx context.Context) ([]*api.Cluster, *apiErrors.ServiceError)
 
@@ -221,6 +243,9 @@ type ClusterServiceMock struct {
 	// UpdateStatusFunc mocks the UpdateStatus method.
 	UpdateStatusFunc func(cluster api.Cluster, status api.ClusterStatus) error
 
+	// UpdatesFunc mocks the Updates method.
+	UpdatesFunc func(cluster api.Cluster, values map[string]interface{}) *apiErrors.ServiceError
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// ApplyResources holds details about calls to the ApplyResources method.
@@ -259,6 +284,11 @@ type ClusterServiceMock struct {
 			// ClusterStatuss is the clusterStatuss argument value.
 			ClusterStatuss []api.ClusterStatus
 		}
+		// CountKafkasPerCluster holds details about calls to the CountKafkasPerCluster method.
+		CountKafkasPerCluster []struct {
+			// ClusterIDs is the clusterIDs argument value.
+			ClusterIDs []string
+		}
 		// Create holds details about calls to the Create method.
 		Create []struct {
 			// Cluster is the cluster argument value.
@@ -350,11 +380,19 @@ type ClusterServiceMock struct {
 			// IbpVersion is the ibpVersion argument value.
 			IbpVersion string
 		}
+		// List holds details about calls to the List method.
+		List []struct {
+			// ListArgs is the listArgs argument value.
+			ListArgs *coreServices.ListArguments
+		}
 		// ListByStatus holds details about calls to the ListByStatus method.
 		ListByStatus []struct {
 			// State is the state argument value.
 			State api.ClusterStatus
 		}
+		// ListCordonedClusters holds details about calls to the ListCordonedClusters method.
+		ListCordonedClusters []struct {
+		}
 		// ListEnterpriseClustersOfAnOrganization holds details about calls to the ListEnterpriseClustersOfAnOrganization method.
 		ListEnterpriseClustersOfAnOrganization []struct {
 			// Ctx is the ctx argument value.
@@ -403,6 +441,13 @@ type ClusterServiceMock struct {
 			// Status is the status argument value.
 			Status api.ClusterStatus
 		}
+		// Updates holds details about calls to the Updates method.
+		Updates []struct {
+			// Cluster is the cluster argument value.
+			Cluster api.Cluster
+			// Values is the values argument value.
+			Values map[string]interface{}
+		}
 	}
 	lockApplyResources                                   sync.RWMutex
 	lockCheckClusterStatus                               sync.RWMutex
@@ -410,6 +455,7 @@ type ClusterServiceMock struct {
 	lockComputeConsumedStreamingUnitCountPerInstanceType sync.RWMutex
 	lockConfigureAndSaveIdentityProvider                 sync.RWMutex
 	lockCountByStatus                                    sync.RWMutex
+	lockCountKafkasPerCluster                            sync.RWMutex
 	lockCreate                                           sync.RWMutex
 	lockDelete                                           sync.RWMutex
 	lockDeleteByClusterID                                sync.RWMutex
@@ -427,7 +473,9 @@ type ClusterServiceMock struct {
 	lockInstallClusterLogging                            sync.RWMutex
 	lockInstallStrimzi                                   sync.RWMutex
 	lockIsStrimziKafkaVersionAvailableInCluster          sync.RWMutex
+	lockList                                             sync.RWMutex
 	lockListByStatus                                     sync.RWMutex
+	lockListCordonedClusters                             sync.RWMutex
 	lockListEnterpriseClustersOfAnOrganization           sync.RWMutex
 	lockListGroupByProviderAndRegion                     sync.RWMutex
 	lockListNonEnterpriseClusterIDs                      sync.RWMutex
@@ -436,6 +484,7 @@ type ClusterServiceMock struct {
 	lockUpdate                                           sync.RWMutex
 	lockUpdateMultiClusterStatus                         sync.RWMutex
 	lockUpdateStatus                                     sync.RWMutex
+	lockUpdates                                          sync.RWMutex
 }
 
 // ApplyResources calls ApplyResourcesFunc.
@@ -642,6 +691,38 @@ func (mock *ClusterServiceMock) CountByStatusCalls() []struct {
 	return calls
 }
 
+// CountKafkasPerCluster calls CountKafkasPerClusterFunc.
+func (mock *ClusterServiceMock) CountKafkasPerCluster(clusterIDs []string) (map[string]int, *apiErrors.ServiceError) {
+	if mock.CountKafkasPerClusterFunc == nil {
+		panic("ClusterServiceMock.CountKafkasPerClusterFunc: method is nil but ClusterService.CountKafkasPerCluster was just called")
+	}
+	callInfo := struct {
+		ClusterIDs []string
+	}{
+		ClusterIDs: clusterIDs,
+	}
+	mock.lockCountKafkasPerCluster.Lock()
+	mock.calls.CountKafkasPerCluster = append(mock.calls.CountKafkasPerCluster, callInfo)
+	mock.lockCountKafkasPerCluster.Unlock()
+	return mock.CountKafkasPerClusterFunc(clusterIDs)
+}
+
+// CountKafkasPerClusterCalls gets all the calls that were made to CountKafkasPerCluster.
+// Check the length with:
+//
+//	len(mockedClusterService.CountKafkasPerClusterCalls())
+func (mock *ClusterServiceMock) CountKafkasPerClusterCalls() []struct {
+	ClusterIDs []string
+} {
+	var calls []struct {
+		ClusterIDs []string
+	}
+	mock.lockCountKafkasPerCluster.RLock()
+	calls = mock.calls.CountKafkasPerCluster
+	mock.lockCountKafkasPerCluster.RUnlock()
+	return calls
+}
+
 // Create calls CreateFunc.
 func (mock *ClusterServiceMock) Create(cluster *api.Cluster) (*api.Cluster, *apiErrors.ServiceError) {
 	if mock.CreateFunc == nil {
@@ -1197,6 +1278,38 @@ func (mock *ClusterServiceMock) IsStrimziKafkaVersionAvailableInClusterCalls() [
 	return calls
 }
 
+// List calls ListFunc.
+func (mock *ClusterServiceMock) List(listArgs *coreServices.ListArguments) (api.ClusterList, *api.PagingMeta, *apiErrors.ServiceError) {
+	if mock.ListFunc == nil {
+		panic("ClusterServiceMock.ListFunc: method is nil but ClusterService.List was just called")
+	}
+	callInfo := struct {
+		ListArgs *coreServices.ListArguments
+	}{
+		ListArgs: listArgs,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc(listArgs)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedClusterService.ListCalls())
+func (mock *ClusterServiceMock) ListCalls() []struct {
+	ListArgs *coreServices.ListArguments
+} {
+	var calls []struct {
+		ListArgs *coreServices.ListArguments
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
 // ListByStatus calls ListByStatusFunc.
 func (mock *ClusterServiceMock) ListByStatus(state api.ClusterStatus) ([]api.Cluster, *apiErrors.ServiceError) {
 	if mock.ListByStatusFunc == nil {
@@ -1229,6 +1342,33 @@ func (mock *ClusterServiceMock) ListByStatusCalls() []struct {
 	return calls
 }
 
+// ListCordonedClusters calls ListCordonedClustersFunc.
+func (mock *ClusterServiceMock) ListCordonedClusters() ([]api.Cluster, *apiErrors.ServiceError) {
+	if mock.ListCordonedClustersFunc == nil {
+		panic("ClusterServiceMock.ListCordonedClustersFunc: method is nil but ClusterService.ListCordonedClusters was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockListCordonedClusters.Lock()
+	mock.calls.ListCordonedClusters = append(mock.calls.ListCordonedClusters, callInfo)
+	mock.lockListCordonedClusters.Unlock()
+	return mock.ListCordonedClustersFunc()
+}
+
+// ListCordonedClustersCalls gets all the calls that were made to ListCordonedClusters.
+// Check the length with:
+//
+//	len(mockedClusterService.ListCordonedClustersCalls())
+func (mock *ClusterServiceMock) ListCordonedClustersCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockListCordonedClusters.RLock()
+	calls = mock.calls.ListCordonedClusters
+	mock.lockListCordonedClusters.RUnlock()
+	return calls
+}
+
 // ListEnterpriseClustersOfAnOrganization calls ListEnterpriseClustersOfAnOrganizationFunc.
 func (mock *ClusterServiceMock) ListEnterpriseClustersOfAnOrganization(ctx context.Context) ([]*api.Cluster, *apiErrors.ServiceError) {
 	if mock.ListEnterpriseClustersOfAnOrganizationFunc == nil {
@@ -1499,3 +1639,39 @@ func (mock *ClusterServiceMock) UpdateStatusCalls() []struct {
 	mock.lockUpdateStatus.RUnlock()
 	return calls
 }
+
+// Updates calls UpdatesFunc.
+func (mock *ClusterServiceMock) Updates(cluster api.Cluster, values map[string]interface{}) *apiErrors.ServiceError {
+	if mock.UpdatesFunc == nil {
+		panic("ClusterServiceMock.UpdatesFunc: method is nil but ClusterService.Updates was just called")
+	}
+	callInfo := struct {
+		Cluster api.Cluster
+		Values  map[string]interface{}
+	}{
+		Cluster: cluster,
+		Values:  values,
+	}
+	mock.lockUpdates.Lock()
+	mock.calls.Updates = append(mock.calls.Updates, callInfo)
+	mock.lockUpdates.Unlock()
+	return mock.UpdatesFunc(cluster, values)
+}
+
+// UpdatesCalls gets all the calls that were made to Updates.
+// Check the length with:
+//
+//	len(mockedClusterService.UpdatesCalls())
+func (mock *ClusterServiceMock) UpdatesCalls() []struct {
+	Cluster api.Cluster
+	Values  map[string]interface{}
+} {
+	var calls []struct {
+		Cluster api.Cluster
+		Values  map[string]interface{}
+	}
+	mock.lockUpdates.RLock()
+	calls = mock.calls.Updates
+	mock.lockUpdates.RUnlock()
+	return calls
+}
@@ -150,7 +150,10 @@ func (d *dataPlaneClusterService) setClusterStatus(cluster *api.Cluster, status
 		metrics.UpdateClusterStatusSinceCreatedMetric(*cluster, api.ClusterReady)
 	}
 
-	cluster.Status = api.ClusterReady
+	// a cluster marked as full by an admin keeps its status until it is explicitly marked as ready again
+	if cluster.Status != api.ClusterFull {
+		cluster.Status = api.ClusterReady
+	}
 
 	svcErr := d.ClusterService.Update(*cluster)
 
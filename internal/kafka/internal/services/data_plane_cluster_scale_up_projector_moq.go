@@ -0,0 +1,74 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package services
+
+import (
+	"sync"
+)
+
+// Ensure, that DataPlaneClusterScaleUpProjectorMock does implement DataPlaneClusterScaleUpProjector.
+// If this is not the case, regenerate this file with moq.
+var _ DataPlaneClusterScaleUpProjector = &DataPlaneClusterScaleUpProjectorMock{}
+
+// DataPlaneClusterScaleUpProjectorMock is a mock implementation of DataPlaneClusterScaleUpProjector.
+//
+//	func TestSomethingThatUsesDataPlaneClusterScaleUpProjector(t *testing.T) {
+//
+//		// make and configure a mocked DataPlaneClusterScaleUpProjector
+//		mockedDataPlaneClusterScaleUpProjector := &DataPlaneClusterScaleUpProjectorMock{
+//			ProjectScaleUpFunc: func(clusterService ClusterService) error {
+//				panic("mock out the ProjectScaleUp method")
+//			},
+//		}
+//
+//		// use mockedDataPlaneClusterScaleUpProjector in code that requires DataPlaneClusterScaleUpProjector
+//		// and then make assertions.
+//
+//	}
+type DataPlaneClusterScaleUpProjectorMock struct {
+	// ProjectScaleUpFunc mocks the ProjectScaleUp method.
+	ProjectScaleUpFunc func(clusterService ClusterService) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ProjectScaleUp holds details about calls to the ProjectScaleUp method.
+		ProjectScaleUp []struct {
+			// ClusterService is the clusterService argument value.
+			ClusterService ClusterService
+		}
+	}
+	lockProjectScaleUp sync.RWMutex
+}
+
+// ProjectScaleUp calls ProjectScaleUpFunc.
+func (mock *DataPlaneClusterScaleUpProjectorMock) ProjectScaleUp(clusterService ClusterService) error {
+	if mock.ProjectScaleUpFunc == nil {
+		panic("DataPlaneClusterScaleUpProjectorMock.ProjectScaleUpFunc: method is nil but DataPlaneClusterScaleUpProjector.ProjectScaleUp was just called")
+	}
+	callInfo := struct {
+		ClusterService ClusterService
+	}{
+		ClusterService: clusterService,
+	}
+	mock.lockProjectScaleUp.Lock()
+	mock.calls.ProjectScaleUp = append(mock.calls.ProjectScaleUp, callInfo)
+	mock.lockProjectScaleUp.Unlock()
+	return mock.ProjectScaleUpFunc(clusterService)
+}
+
+// ProjectScaleUpCalls gets all the calls that were made to ProjectScaleUp.
+// Check the length with:
+//
+//	len(mockedDataPlaneClusterScaleUpProjector.ProjectScaleUpCalls())
+func (mock *DataPlaneClusterScaleUpProjectorMock) ProjectScaleUpCalls() []struct {
+	ClusterService ClusterService
+} {
+	var calls []struct {
+		ClusterService ClusterService
+	}
+	mock.lockProjectScaleUp.RLock()
+	calls = mock.calls.ProjectScaleUp
+	mock.lockProjectScaleUp.RUnlock()
+	return calls
+}
@@ -534,6 +534,37 @@ func Test_DataPlaneCluster_setClusterStatus(t *testing.T) {
 			wantAvailableStrimziVersions: api.JSON([]byte(`[{"version":"1.0.0","ready":true,"kafkaVersions":[{"version":"3.0.1"}],"kafkaIBPVersions":[{"version":"3.0.1"}]}]`)),
 			wantErr:                      false,
 		},
+		{
+			name: "keep the full status of a cluster marked as full",
+			inputFactory: func() *input {
+				apiCluster := &api.Cluster{
+					ClusterID:           testClusterID,
+					MultiAZ:             true,
+					Status:              api.ClusterFull,
+					DynamicCapacityInfo: api.JSON([]byte(`{"key":{"max_nodes": 90}}`)),
+				}
+
+				clusterService := &ClusterServiceMock{
+					UpdateFunc: func(cluster api.Cluster) *errors.ServiceError {
+						return nil
+					},
+				}
+
+				testStatus := sampleValidBaseDataPlaneClusterStatusRequest()
+				c := sampleValidApplicationConfigForDataPlaneClusterTest(clusterService)
+				dataPlaneClusterService := NewDataPlaneClusterService(c)
+				return &input{
+					status:                  testStatus,
+					cluster:                 apiCluster,
+					dataPlaneClusterService: dataPlaneClusterService,
+					clusterService:          clusterService,
+				}
+			},
+			wantStatus:                   api.ClusterFull,
+			wantDynamicCapacityInfo:      api.JSON([]byte(`{"key":{"max_nodes":90,"max_units":10,"remaining_units":2}}`)),
+			wantAvailableStrimziVersions: api.JSON([]byte(`[{"version":"1.0.0","ready":true,"kafkaVersions":[{"version":"3.0.1"}],"kafkaIBPVersions":[{"version":"3.0.1"}]}]`)),
+			wantErr:                      false,
+		},
 		{
 			name: "return an error when updates in the database fails",
 			inputFactory: func() *input {
@@ -11,9 +11,11 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 	serviceError "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/metrics"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
 	"github.com/golang/glog"
 	"github.com/pkg/errors"
 )
@@ -55,16 +57,21 @@ type DataPlaneKafkaService interface {
 }
 
 type dataPlaneKafkaService struct {
-	kafkaService   KafkaService
-	clusterService ClusterService
-	kafkaConfig    *config.KafkaConfig
+	kafkaService      KafkaService
+	clusterService    ClusterService
+	kafkaConfig       *config.KafkaConfig
+	connectionFactory *db.ConnectionFactory
+	webhookService    webhooks.WebhookService
 }
 
-func NewDataPlaneKafkaService(kafkaSrv KafkaService, clusterSrv ClusterService, kafkaConfig *config.KafkaConfig) *dataPlaneKafkaService {
+func NewDataPlaneKafkaService(kafkaSrv KafkaService, clusterSrv ClusterService, kafkaConfig *config.KafkaConfig,
+	connectionFactory *db.ConnectionFactory, webhookService webhooks.WebhookService) *dataPlaneKafkaService {
 	return &dataPlaneKafkaService{
-		kafkaService:   kafkaSrv,
-		clusterService: clusterSrv,
-		kafkaConfig:    kafkaConfig,
+		kafkaService:      kafkaSrv,
+		clusterService:    clusterSrv,
+		kafkaConfig:       kafkaConfig,
+		connectionFactory: connectionFactory,
+		webhookService:    webhookService,
 	}
 }
 
@@ -233,10 +240,18 @@ func (d *dataPlaneKafkaService) setKafkaClusterReady(kafka *dbapi.KafkaRequest)
 		return err
 	}
 
+	becameReady := kafka.Status != constants.KafkaRequestStatusReady.String()
 	err = d.kafkaService.Updates(kafka, map[string]interface{}{"admin_api_server_url": kafka.AdminApiServerURL, "failed_reason": "", "status": constants.KafkaRequestStatusReady.String()})
 	if err != nil {
 		return serviceError.NewWithCause(err.Code, err, "failed to update kafka %q", kafka.ID)
 	}
+	if becameReady {
+		kafka.Status = constants.KafkaRequestStatusReady.String()
+		kafka.FailedReason = ""
+		if err := enqueueKafkaStatusWebhookEvent(d.webhookService, d.connectionFactory.New(), kafka); err != nil {
+			return serviceError.NewWithCause(err.Code, err, "failed to notify the webhooks that kafka %q is ready", kafka.ID)
+		}
+	}
 
 	if shouldSendMetric {
 		metrics.UpdateKafkaRequestsStatusSinceCreatedMetric(constants.KafkaRequestStatusReady, kafka.ID, kafka.ClusterID, time.Since(kafka.CreatedAt))
@@ -354,6 +369,9 @@ func (d *dataPlaneKafkaService) setKafkaClusterFailed(kafka *dbapi.KafkaRequest,
 	if err != nil {
 		return serviceError.NewWithCause(err.Code, err, "failed to update kafka cluster to %q status for kafka %q", constants.KafkaRequestStatusFailed, kafka.ID)
 	}
+	if err := enqueueKafkaStatusWebhookEvent(d.webhookService, d.connectionFactory.New(), kafka); err != nil {
+		return serviceError.NewWithCause(err.Code, err, "failed to notify the webhooks that kafka %q failed", kafka.ID)
+	}
 	if shouldSendMetric {
 		metrics.UpdateKafkaRequestsStatusSinceCreatedMetric(constants.KafkaRequestStatusFailed, kafka.ID, kafka.ClusterID, time.Since(kafka.CreatedAt))
 		metrics.IncreaseKafkaTotalOperationsCountMetric(constants.KafkaOperationCreate)
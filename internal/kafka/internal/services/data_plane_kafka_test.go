@@ -11,10 +11,13 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
 	"github.com/onsi/gomega"
+	"gorm.io/gorm"
 )
 
 func Test_dataPlaneKafkaService_UpdateDataPlaneKafkaService(t *testing.T) {
@@ -723,7 +726,11 @@ func Test_dataPlaneKafkaService_UpdateDataPlaneKafkaService(t *testing.T) {
 				"rejected":  0,
 				"suspended": 0,
 			}
-			s := NewDataPlaneKafkaService(tt.fields.kafkaService(counter), tt.fields.clusterService, &config.KafkaConfig{})
+			s := NewDataPlaneKafkaService(tt.fields.kafkaService(counter), tt.fields.clusterService, &config.KafkaConfig{}, db.NewMockConnectionFactory(nil), &webhooks.WebhookServiceMock{
+				EnqueueFunc: func(dbConn *gorm.DB, events ...webhooks.Event) *errors.ServiceError {
+					return nil
+				},
+			})
 			err := s.UpdateDataPlaneKafkaService(context.TODO(), tt.args.clusterId, tt.args.status)
 			g.Expect(err).To(gomega.Equal(tt.want))
 			g.Expect(counter).To(gomega.Equal(tt.expectCounters))
@@ -1002,7 +1009,11 @@ func TestDataPlaneKafkaService_UpdateVersions(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
 			v := versions{}
-			s := NewDataPlaneKafkaService(tt.kafkaService(&v), tt.clusterService, &config.KafkaConfig{})
+			s := NewDataPlaneKafkaService(tt.kafkaService(&v), tt.clusterService, &config.KafkaConfig{}, db.NewMockConnectionFactory(nil), &webhooks.WebhookServiceMock{
+				EnqueueFunc: func(dbConn *gorm.DB, events ...webhooks.Event) *errors.ServiceError {
+					return nil
+				},
+			})
 			err := s.UpdateDataPlaneKafkaService(context.TODO(), tt.clusterId, tt.status)
 			if err != nil && !tt.wantErr {
 				t.Errorf("unexpected error %v", err)
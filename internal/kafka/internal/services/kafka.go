@@ -8,6 +8,7 @@ import (
 	"sync"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
 	"gorm.io/gorm"
 
@@ -93,7 +94,7 @@ type KafkaService interface {
 	// kafkas for a given clusterID. The number of generated reserved managed
 	// kafkas in the cluster is the sum of the specified number of reserved
 	// instances among all instance types supported by the cluster.
-	// If the cluster is not in ready status the result is an empty list.
+	// If the cluster is not in ready status or it is cordoned the result is an empty list.
 	// Generated kafka names have the following naming schema:
 	// reserved-kafka-<instance_type>-<kafka_number> where kafka_number goes from
 	// 1..<num_reserved_instances>_for_the_given_instance_type>
@@ -101,6 +102,8 @@ type KafkaService interface {
 	GenerateReservedManagedKafkasByClusterID(clusterID string) ([]managedkafka.ManagedKafka, *errors.ServiceError)
 	RegisterKafkaJob(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError
 	ListByStatus(status ...constants.KafkaStatus) ([]*dbapi.KafkaRequest, *errors.ServiceError)
+	// ListByClusterID returns all the kafkas placed on the given data plane cluster that are not under deletion
+	ListByClusterID(clusterID string) ([]*dbapi.KafkaRequest, *errors.ServiceError)
 	// UpdateStatus change the status of the Kafka cluster
 	// The returned boolean is to be used to know if the update has been tried or not. An update is not tried if the
 	// original status is 'deprovision' (cluster in deprovision state can't be change state) or if the final status is the
@@ -112,6 +115,9 @@ type KafkaService interface {
 	// Use this only when you want to update the multiple columns that may contain zero-fields, otherwise use the `KafkaService.Update()` method.
 	// See https://gorm.io/docs/update.html#Updates-multiple-columns for more info
 	Updates(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *errors.ServiceError
+	// UpdatesIfStatusIn updates the given fields of a kafka only if its current status is one of the given statuses.
+	// The returned boolean is false when the kafka isn't in one of those statuses anymore, e.g. it's being deleted.
+	UpdatesIfStatusIn(kafkaRequest *dbapi.KafkaRequest, statuses []string, values map[string]interface{}) (bool, *errors.ServiceError)
 	ChangeKafkaCNAMErecords(kafkaRequest *dbapi.KafkaRequest, action KafkaRoutesAction) (*route53.ChangeResourceRecordSetsOutput, *errors.ServiceError)
 	GetCNAMERecordStatus(kafkaRequest *dbapi.KafkaRequest) (*CNameRecordStatus, error)
 	AssignInstanceType(owner string, organisationID string) (types.KafkaInstanceType, *errors.ServiceError)
@@ -155,6 +161,7 @@ type kafkaService struct {
 	providerConfig                       *config.ProviderConfig
 	clusterPlacementStrategy             ClusterPlacementStrategy
 	kafkaTLSCertificateManagementService kafkatlscertmgmt.KafkaTLSCertificateManagementService
+	webhookService                       webhooks.WebhookService
 }
 
 func NewKafkaService(
@@ -162,8 +169,9 @@ func NewKafkaService(
 	kafkaConfig *config.KafkaConfig, dataplaneClusterConfig *config.DataplaneClusterConfig, awsConfig *config.AWSConfig,
 	quotaServiceFactory QuotaServiceFactory, awsClientFactory aws.ClientFactory, authorizationService authorization.Authorization,
 	providerConfig *config.ProviderConfig, clusterPlacementStrategy ClusterPlacementStrategy,
-	kafkaTLSCertificateManagementService kafkatlscertmgmt.KafkaTLSCertificateManagementService) *kafkaService {
-	return &kafkaService{
+	kafkaTLSCertificateManagementService kafkatlscertmgmt.KafkaTLSCertificateManagementService, webhookService webhooks.WebhookService,
+	outbox *db.Outbox) *kafkaService {
+	k := &kafkaService{
 		connectionFactory:                    connectionFactory,
 		clusterService:                       clusterService,
 		keycloakService:                      keycloakService,
@@ -176,7 +184,10 @@ func NewKafkaService(
 		providerConfig:                       providerConfig,
 		clusterPlacementStrategy:             clusterPlacementStrategy,
 		kafkaTLSCertificateManagementService: kafkaTLSCertificateManagementService,
+		webhookService:                       webhookService,
 	}
+	k.registerOutboxHandlers(outbox)
+	return k
 }
 
 func (k *kafkaService) ValidateBillingAccount(externalId string, instanceType types.KafkaInstanceType, billingModelID string, billingCloudAccountId string, marketplace *string) *errors.ServiceError {
@@ -425,16 +436,6 @@ func (k *kafkaService) RegisterKafkaJob(kafkaRequest *dbapi.KafkaRequest) *error
 		}
 	}
 
-	subscriptionId, err := k.reserveQuota(kafkaRequest)
-
-	if err != nil {
-		return err
-	}
-
-	dbConn := k.connectionFactory.New()
-	kafkaRequest.SubscriptionId = subscriptionId
-	kafkaRequest.Status = constants.KafkaRequestStatusAccepted.String()
-
 	// when creating new kafka - default storage size is assigned
 	instanceType, instanceTypeErr := k.kafkaConfig.SupportedInstanceTypes.Configuration.GetKafkaInstanceTypeByID(kafkaRequest.InstanceType)
 	if instanceTypeErr != nil {
@@ -446,6 +447,16 @@ func (k *kafkaService) RegisterKafkaJob(kafkaRequest *dbapi.KafkaRequest) *error
 		return errors.InstancePlanNotSupported(sizeErr.Error())
 	}
 
+	subscriptionId, err := k.reserveQuota(kafkaRequest)
+
+	if err != nil {
+		return err
+	}
+
+	dbConn := k.connectionFactory.New()
+	kafkaRequest.SubscriptionId = subscriptionId
+	kafkaRequest.Status = constants.KafkaRequestStatusAccepted.String()
+
 	kafkaRequest.MaxDataRetentionSize = size.MaxDataRetentionSize.String()
 
 	// We intentionally manually set CreatedAt and UpdatedAt instead of letting
@@ -466,6 +477,8 @@ func (k *kafkaService) RegisterKafkaJob(kafkaRequest *dbapi.KafkaRequest) *error
 	// we want to use the correct quota to perform the deletion.
 	kafkaRequest.QuotaType = k.kafkaConfig.Quota.Type
 	if err := dbConn.Create(kafkaRequest).Error; err != nil {
+		// the reserved quota would be orphaned, its deletion is added to the outbox
+		k.enqueueReservedQuotaDeletion(kafkaRequest)
 		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to create kafka request") //hide the db error to http caller
 	}
 
@@ -507,41 +520,34 @@ func (k *kafkaService) PrepareKafkaRequest(kafkaRequest *dbapi.KafkaRequest) *er
 		return errors.NewWithCause(errors.ErrorGeneral, err, "error assigning bootstrap server host to kafka %s", kafkaRequest.ID)
 	}
 
-	if k.keycloakService.GetConfig().EnableAuthenticationOnKafka {
-		clientId := strings.ToLower(fmt.Sprintf("%s-%s", CanaryServiceAccountPrefix, kafkaRequest.ID))
-		serviceAccountRequest := sso.CompleteServiceAccountRequest{
-			Owner:          kafkaRequest.Owner,
-			OwnerAccountId: kafkaRequest.OwnerAccountId,
-			ClientId:       clientId,
-			OrgId:          kafkaRequest.OrganisationId,
-			Name:           fmt.Sprintf("canary-service-account-for-kafka %s", kafkaRequest.ID),
-			Description:    fmt.Sprintf("canary service account for kafka %s", kafkaRequest.ID),
-		}
-
-		canaryServiceAccount, err := k.keycloakService.CreateServiceAccountInternal(serviceAccountRequest)
-
-		if err != nil {
-			return errors.FailedToCreateSSOClient("failed to  create canary service account %s:%v", kafkaRequest.ID, err)
-		}
-
-		kafkaRequest.CanaryServiceAccountClientID = canaryServiceAccount.ClientID
-		kafkaRequest.CanaryServiceAccountClientSecret = canaryServiceAccount.ClientSecret
-	}
-
 	// Update the Kafka Request record in the database
 	// Only updates the fields below
 	updatedKafkaRequest := &dbapi.KafkaRequest{
 		Meta: api.Meta{
 			ID: kafkaRequest.ID,
 		},
-		BootstrapServerHost:              kafkaRequest.BootstrapServerHost,
-		CanaryServiceAccountClientID:     kafkaRequest.CanaryServiceAccountClientID,
-		CanaryServiceAccountClientSecret: kafkaRequest.CanaryServiceAccountClientSecret,
-		PlacementId:                      api.NewID(),
-		Status:                           constants.KafkaRequestStatusProvisioning.String(),
-		Namespace:                        kafkaRequest.Namespace,
-	}
-	if err := k.Update(updatedKafkaRequest); err != nil {
+		BootstrapServerHost: kafkaRequest.BootstrapServerHost,
+		PlacementId:         api.NewID(),
+		Status:              constants.KafkaRequestStatusProvisioning.String(),
+		Namespace:           kafkaRequest.Namespace,
+	}
+	dbConn := k.connectionFactory.New()
+	if err := dbConn.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(updatedKafkaRequest).
+			Where("status not IN (?)", kafkaDeletionStatuses). // ignore updates of kafka under deletion
+			Updates(updatedKafkaRequest)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		// the canary service account is created by the outbox once the kafka is provisioning, the kafka isn't sent to
+		// the data plane until its canary service account is set
+		if k.keycloakService.GetConfig().EnableAuthenticationOnKafka && kafkaRequest.CanaryServiceAccountClientID == "" {
+			return db.EnqueueOutboxMessage(tx, outboxCreateKafkaCanaryServiceAccount, outboxIdempotencyKey(outboxCreateKafkaCanaryServiceAccount, kafkaRequest.ID), createKafkaCanaryServiceAccountMessage{
+				KafkaId: kafkaRequest.ID,
+			})
+		}
+		return nil
+	}); err != nil {
 		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to update kafka request")
 	}
 	return nil
@@ -562,6 +568,25 @@ func (k *kafkaService) ListByStatus(status ...constants.KafkaStatus) ([]*dbapi.K
 	return kafkas, nil
 }
 
+func (k *kafkaService) ListByClusterID(clusterID string) ([]*dbapi.KafkaRequest, *errors.ServiceError) {
+	if clusterID == "" {
+		return nil, errors.Validation("clusterID is undefined")
+	}
+	dbConn := k.connectionFactory.New()
+
+	var kafkas []*dbapi.KafkaRequest
+
+	if err := dbConn.Model(&dbapi.KafkaRequest{}).
+		Where("cluster_id = ?", clusterID).
+		Where("status NOT IN (?)", kafkaDeletionStatuses).
+		Order("created_at asc").
+		Scan(&kafkas).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to list kafkas of cluster %q", clusterID)
+	}
+
+	return kafkas, nil
+}
+
 func (k *kafkaService) ListKafkasToBePromoted() ([]*dbapi.KafkaRequest, *errors.ServiceError) {
 	dbConn := k.connectionFactory.New()
 
@@ -602,26 +627,33 @@ func (k *kafkaService) Get(ctx context.Context, id string) (*dbapi.KafkaRequest,
 		if user == "" {
 			return nil, errors.Unauthenticated("user not authenticated")
 		}
-
-		orgId, _ := claims.GetOrgId()
-		filterByOrganisationId := auth.GetFilterByOrganisationFromContext(ctx)
-
-		// filter by organisationId if a user is part of an organisation and is not allowed as a service account
-		if filterByOrganisationId {
-			dbConn = dbConn.Where("organisation_id = ?", orgId)
-		} else {
-			dbConn = dbConn.Where("owner = ?", user)
-		}
 	}
 
+	resourceTypeStr := "KafkaResource"
+	if user != "" {
+		resourceTypeStr = fmt.Sprintf("%s for user %s", resourceTypeStr, user)
+	}
 	var kafkaRequest dbapi.KafkaRequest
 	if err := dbConn.First(&kafkaRequest).Error; err != nil {
-		resourceTypeStr := "KafkaResource"
-		if user != "" {
-			resourceTypeStr = fmt.Sprintf("%s for user %s", resourceTypeStr, user)
-		}
 		return nil, services.HandleGetError(resourceTypeStr, "id", id, err)
 	}
+
+	if user != "" {
+		decision, err := auth.Authorize(ctx, auth.ActionGet, KafkaAuthorizationResource(&kafkaRequest))
+		if err != nil {
+			return nil, err
+		}
+		if !decision.Allowed {
+			// the kafkas the user can't see are reported as not found
+			if decision.ErrorCode == errors.ErrorNotFound {
+				return nil, services.HandleGetError(resourceTypeStr, "id", id, gorm.ErrRecordNotFound)
+			}
+			if decision.Reason != "" {
+				return nil, errors.New(decision.ErrorCode, "%s", decision.Reason)
+			}
+			return nil, errors.New(decision.ErrorCode, "user %s not authorized to get kafka %s", user, id)
+		}
+	}
 	return &kafkaRequest, nil
 }
 
@@ -650,22 +682,30 @@ func (k *kafkaService) RegisterKafkaDeprovisionJob(ctx context.Context, id strin
 		return errors.NewWithCause(errors.ErrorUnauthenticated, err, "user not authenticated")
 	}
 
-	dbConn := k.connectionFactory.New()
+	dbConn := k.connectionFactory.New().Where("id = ?", id)
 
-	if auth.GetIsAdminFromContext(ctx) {
-		dbConn = dbConn.Where("id = ?", id)
-	} else if claims.IsOrgAdmin() {
+	isAdmin := auth.GetIsAdminFromContext(ctx)
+	if !isAdmin {
 		orgId, _ := claims.GetOrgId()
-		dbConn = dbConn.Where("id = ?", id).Where("organisation_id = ?", orgId)
-	} else {
 		user, _ := claims.GetUsername()
-		dbConn = dbConn.Where("id = ?", id).Where("owner = ? ", user)
+		dbConn = dbConn.Where("organisation_id = ? OR owner = ?", orgId, user)
 	}
 
 	var kafkaRequest dbapi.KafkaRequest
 	if err := dbConn.First(&kafkaRequest).Error; err != nil {
 		return services.HandleGetError("KafkaResource", "id", id, err)
 	}
+
+	// the kafkas the user isn't allowed to delete are reported as not found
+	if !isAdmin {
+		decision, authErr := auth.Authorize(ctx, auth.ActionDelete, KafkaAuthorizationResource(&kafkaRequest))
+		if authErr != nil {
+			return authErr
+		}
+		if !decision.Allowed {
+			return services.HandleGetError("KafkaResource", "id", id, gorm.ErrRecordNotFound)
+		}
+	}
 	metrics.IncreaseKafkaTotalOperationsCountMetric(constants.KafkaOperationDeprovision)
 
 	deprovisionStatus := constants.KafkaRequestStatusDeprovision
@@ -764,47 +804,22 @@ func (k *kafkaService) DeprovisionExpiredKafkas() *errors.ServiceError {
 func (k *kafkaService) Delete(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
 	dbConn := k.connectionFactory.New()
 
-	// if the we don't have the clusterID we can only delete the row from the database
-	if kafkaRequest.ClusterID != "" {
-		// delete the kafka client in mas sso
-		if k.keycloakService.GetConfig().EnableAuthenticationOnKafka {
-			if kafkaRequest.CanaryServiceAccountClientID != "" {
-				keycloakErr := k.keycloakService.DeleteServiceAccountInternal(kafkaRequest.CanaryServiceAccountClientID)
-
-				if keycloakErr != nil {
-					// Log the info for not found and proceed - not an error if service account is not found
-					if keycloakErr.Code == errors.ErrorServiceAccountNotFound {
-						glog.V(10).Infof("Service account with ID '%s' not found. Skipping deletion", kafkaRequest.CanaryServiceAccountClientID)
-					} else {
-						return errors.NewWithCause(errors.ErrorGeneral, keycloakErr, "error deleting canary service account")
-					}
-				}
-			}
-		}
-
-		routes, err := kafkaRequest.GetRoutes()
+	// only revoke the certificates if they have been generated and the certificate is not shared among all kafkas
+	if kafkaRequest.ClusterID != "" && kafkaRequest.HasCertificateInfo() && !kafkaRequest.IsUsingSharedTLSCertificate(k.kafkaConfig) {
+		err := k.kafkaTLSCertificateManagementService.RevokeCertificate(context.Background(), kafkaRequest.KafkasRoutesBaseDomainName, kafkatlscertmgmt.CessationOfOperation)
 		if err != nil {
-			return errors.NewWithCause(errors.ErrorGeneral, err, "failed to get routes")
-		}
-		// Only delete the routes when they are set
-		if routes != nil && k.kafkaConfig.EnableKafkaCNAMERegistration {
-			_, err := k.ChangeKafkaCNAMErecords(kafkaRequest, KafkaRoutesActionDelete)
-			if err != nil {
-				return err
-			}
-		}
-
-		// only revoke the certificates if they have been generated and the certificate is not shared among all kafkas
-		if kafkaRequest.HasCertificateInfo() && !kafkaRequest.IsUsingSharedTLSCertificate(k.kafkaConfig) {
-			err = k.kafkaTLSCertificateManagementService.RevokeCertificate(context.Background(), kafkaRequest.KafkasRoutesBaseDomainName, kafkatlscertmgmt.CessationOfOperation)
-			if err != nil {
-				return errors.NewWithCause(errors.ErrorGeneral, err, "error revoking certificate for the base domain %q of kafka with id %q", kafkaRequest.KafkasRoutesBaseDomainName, kafkaRequest.ID)
-			}
+			return errors.NewWithCause(errors.ErrorGeneral, err, "error revoking certificate for the base domain %q of kafka with id %q", kafkaRequest.KafkasRoutesBaseDomainName, kafkaRequest.ID)
 		}
 	}
 
-	// soft delete the kafka request
-	if err := dbConn.Delete(kafkaRequest).Error; err != nil {
+	// soft delete the kafka request, the quota, the canary service account and the CNAME records of the kafka are deleted
+	// by the outbox worker once the kafka request is deleted
+	if err := dbConn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(kafkaRequest).Error; err != nil {
+			return err
+		}
+		return k.enqueueKafkaDeletionOutboxMessages(tx, kafkaRequest)
+	}); err != nil {
 		return errors.NewWithCause(errors.ErrorGeneral, err, "unable to delete kafka request with id %s", kafkaRequest.ID)
 	}
 
@@ -845,11 +860,14 @@ func (k *kafkaService) List(ctx context.Context, listArgs *services.ListArgument
 			return nil, nil, errors.Unauthenticated("user not authenticated")
 		}
 
+		decision, serr := auth.AuthorizeList(ctx, auth.ResourceKafka)
+		if serr != nil {
+			return nil, nil, serr
+		}
 		orgId, _ := claims.GetOrgId()
-		filterByOrganisationId := auth.GetFilterByOrganisationFromContext(ctx)
 
 		// filter by organisationId if a user is part of an organisation and is not allowed as a service account
-		if filterByOrganisationId {
+		if decision.FilterByOrganisation {
 			// filter kafka requests by organisation_id since the user is allowed to see all kafka requests of my id
 			dbConn = dbConn.Where("organisation_id = ?", orgId)
 		} else {
@@ -899,6 +917,10 @@ func (k *kafkaService) GetManagedKafkaByClusterID(clusterID string) ([]managedka
 		Where("cluster_id = ?", clusterID).
 		Where("status IN (?)", kafkaManagedCRStatuses).
 		Where("bootstrap_server_host != ''")
+	if k.keycloakService.GetConfig().EnableAuthenticationOnKafka {
+		// the provisioning kafkas are sent once the outbox created their canary service account
+		dbConn = dbConn.Where("NOT (status = ? AND canary_service_account_client_id = '')", constants.KafkaRequestStatusProvisioning.String())
+	}
 
 	var kafkaRequestList dbapi.KafkaList
 	if err := dbConn.Find(&kafkaRequestList).Error; err != nil {
@@ -951,6 +973,10 @@ func (k *kafkaService) GenerateReservedManagedKafkasByClusterID(clusterID string
 		logger.Logger.V(10).Infof("ClusterID '%s' is not ready. Its status is '%s'. Returning an empty list of reserved managed kafkas", clusterID, cluster.Status)
 		return reservedKafkas, nil
 	}
+	if cluster.Cordoned {
+		logger.Logger.V(10).Infof("ClusterID '%s' is cordoned. Returning an empty list of reserved managed kafkas", clusterID)
+		return reservedKafkas, nil
+	}
 
 	latestStrimziVersion, err := cluster.GetLatestAvailableAndReadyStrimziVersion()
 	if err != nil {
@@ -1007,6 +1033,17 @@ func (k *kafkaService) Updates(kafkaRequest *dbapi.KafkaRequest, fields map[stri
 	return nil
 }
 
+func (k *kafkaService) UpdatesIfStatusIn(kafkaRequest *dbapi.KafkaRequest, statuses []string, fields map[string]interface{}) (bool, *errors.ServiceError) {
+	result := k.connectionFactory.New().
+		Model(kafkaRequest).
+		Where("status IN (?)", statuses).
+		Updates(fields)
+	if result.Error != nil {
+		return false, errors.NewWithCause(errors.ErrorGeneral, result.Error, "failed to update kafka")
+	}
+	return result.RowsAffected > 0, nil
+}
+
 func (k *kafkaService) VerifyAndUpdateKafkaAdmin(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
 	if !auth.GetIsAdminFromContext(ctx) {
 		return errors.New(errors.ErrorUnauthenticated, "user not authenticated")
@@ -1034,22 +1071,32 @@ func (k *kafkaService) VerifyAndUpdateKafkaAdmin(ctx context.Context, kafkaReque
 func (k *kafkaService) UpdateStatus(id string, status constants.KafkaStatus) (bool, *errors.ServiceError) {
 	dbConn := k.connectionFactory.New()
 
-	if kafka, err := k.GetByID(id); err != nil {
+	kafka, err := k.GetByID(id)
+	if err != nil {
 		return true, errors.NewWithCause(errors.ErrorGeneral, err, "failed to update status")
-	} else {
-		// only allow to change the status to "deleting" if the cluster is already in "deprovision" status
-		if kafka.Status == constants.KafkaRequestStatusDeprovision.String() && status != constants.KafkaRequestStatusDeleting {
-			return false, errors.GeneralError("failed to update status: cluster is deprovisioning")
-		}
+	}
+	// only allow to change the status to "deleting" if the cluster is already in "deprovision" status
+	if kafka.Status == constants.KafkaRequestStatusDeprovision.String() && status != constants.KafkaRequestStatusDeleting {
+		return false, errors.GeneralError("failed to update status: cluster is deprovisioning")
+	}
 
-		if kafka.Status == status.String() {
-			// no update needed
-			return false, errors.GeneralError("failed to update status: the cluster %s is already in %s state", id, status.String())
-		}
+	if kafka.Status == status.String() {
+		// no update needed
+		return false, errors.GeneralError("failed to update status: the cluster %s is already in %s state", id, status.String())
 	}
 
-	if err := dbConn.Model(&dbapi.KafkaRequest{Meta: api.Meta{ID: id}}).Update("status", status).Error; err != nil {
-		return true, errors.NewWithCause(errors.ErrorGeneral, err, "failed to update kafka status")
+	// the webhook event is added to the outbox in the transaction updating the status, so that it's only delivered once the status is updated
+	if err := dbConn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&dbapi.KafkaRequest{Meta: api.Meta{ID: id}}).Update("status", status).Error; err != nil {
+			return errors.NewWithCause(errors.ErrorGeneral, err, "failed to update kafka status")
+		}
+		kafka.Status = status.String()
+		if err := enqueueKafkaStatusWebhookEvent(k.webhookService, tx, kafka); err != nil {
+			return err
+		}
+		return nil
+	}); err != nil {
+		return true, errors.ToServiceError(err)
 	}
 
 	return true, nil
@@ -1253,7 +1300,7 @@ func buildManagedKafkaCR(kafkaRequest *dbapi.KafkaRequest, kafkaConfig *config.K
 		serviceAccounts = append(serviceAccounts, managedkafka.ServiceAccount{
 			Name:      "canary",
 			Principal: kafkaRequest.CanaryServiceAccountClientID,
-			Password:  kafkaRequest.CanaryServiceAccountClientSecret,
+			Password:  string(kafkaRequest.CanaryServiceAccountClientSecret),
 		})
 		managedKafkaCR.Spec.ServiceAccounts = serviceAccounts
 	}
@@ -0,0 +1,27 @@
+package services
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+)
+
+// KafkaAuthorizationResource returns the kafka as a resource of the authorizer, the authorization policies can refer to its attributes
+func KafkaAuthorizationResource(kafkaRequest *dbapi.KafkaRequest) auth.AuthorizationResource {
+	return auth.AuthorizationResource{
+		Type:           auth.ResourceKafka,
+		Id:             kafkaRequest.ID,
+		OrganisationId: kafkaRequest.OrganisationId,
+		Owner:          kafkaRequest.Owner,
+		Attributes: map[string]interface{}{
+			"name":           kafkaRequest.Name,
+			"instance_type":  kafkaRequest.InstanceType,
+			"size_id":        kafkaRequest.SizeId,
+			"cloud_provider": kafkaRequest.CloudProvider,
+			"region":         kafkaRequest.Region,
+			"billing_model":  kafkaRequest.DesiredKafkaBillingModel,
+			"marketplace":    kafkaRequest.Marketplace,
+			"cluster_id":     kafkaRequest.ClusterID,
+			"status":         kafkaRequest.Status,
+		},
+	}
+}
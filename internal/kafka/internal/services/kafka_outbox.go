@@ -0,0 +1,222 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db/dbencryption"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+	"github.com/golang/glog"
+	"gorm.io/gorm"
+)
+
+// Types of the outbox messages of the side effects of the kafkas
+const (
+	outboxCreateKafkaCanaryServiceAccount = "kafka.create_canary_service_account"
+	outboxDeleteKafkaQuota                = "kafka.delete_quota"
+	outboxDeleteKafkaCanaryServiceAccount = "kafka.delete_canary_service_account"
+	outboxDeleteKafkaCNAMERecords         = "kafka.delete_cname_records"
+)
+
+type createKafkaCanaryServiceAccountMessage struct {
+	KafkaId string `json:"kafka_id"`
+}
+
+type deleteKafkaQuotaMessage struct {
+	KafkaId        string `json:"kafka_id"`
+	QuotaType      string `json:"quota_type"`
+	SubscriptionId string `json:"subscription_id"`
+}
+
+type deleteKafkaCanaryServiceAccountMessage struct {
+	KafkaId  string `json:"kafka_id"`
+	ClientId string `json:"client_id"`
+}
+
+type deleteKafkaCNAMERecordsMessage struct {
+	KafkaId string `json:"kafka_id"`
+}
+
+// registerOutboxHandlers registers the handlers executing the outbox messages enqueued by the kafka service
+func (k *kafkaService) registerOutboxHandlers(outbox *db.Outbox) {
+	outbox.RegisterHandler(outboxCreateKafkaCanaryServiceAccount, k.handleCreateKafkaCanaryServiceAccount)
+	outbox.RegisterHandler(outboxDeleteKafkaQuota, k.handleDeleteKafkaQuota)
+	outbox.RegisterHandler(outboxDeleteKafkaCanaryServiceAccount, k.handleDeleteKafkaCanaryServiceAccount)
+	outbox.RegisterHandler(outboxDeleteKafkaCNAMERecords, k.handleDeleteKafkaCNAMERecords)
+}
+
+// enqueueKafkaDeletionOutboxMessages adds the clean up of the external resources of a kafka to the outbox with the given connection,
+// which must be the transaction soft deleting the kafka
+func (k *kafkaService) enqueueKafkaDeletionOutboxMessages(tx *gorm.DB, kafkaRequest *dbapi.KafkaRequest) error {
+	if kafkaRequest.SubscriptionId != "" {
+		if err := db.EnqueueOutboxMessage(tx, outboxDeleteKafkaQuota, outboxIdempotencyKey(outboxDeleteKafkaQuota, kafkaRequest.ID), deleteKafkaQuotaMessage{
+			KafkaId:        kafkaRequest.ID,
+			QuotaType:      kafkaRequest.QuotaType,
+			SubscriptionId: kafkaRequest.SubscriptionId,
+		}); err != nil {
+			return err
+		}
+	}
+
+	// if the we don't have the clusterID the kafka wasn't provisioned and has no service account nor CNAME records
+	if kafkaRequest.ClusterID == "" {
+		return nil
+	}
+
+	if k.keycloakService.GetConfig().EnableAuthenticationOnKafka && kafkaRequest.CanaryServiceAccountClientID != "" {
+		if err := db.EnqueueOutboxMessage(tx, outboxDeleteKafkaCanaryServiceAccount, outboxIdempotencyKey(outboxDeleteKafkaCanaryServiceAccount, kafkaRequest.ID), deleteKafkaCanaryServiceAccountMessage{
+			KafkaId:  kafkaRequest.ID,
+			ClientId: kafkaRequest.CanaryServiceAccountClientID,
+		}); err != nil {
+			return err
+		}
+	}
+
+	routes, err := kafkaRequest.GetRoutes()
+	if err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to get routes")
+	}
+	// Only delete the routes when they are set
+	if routes != nil && k.kafkaConfig.EnableKafkaCNAMERegistration {
+		if err := db.EnqueueOutboxMessage(tx, outboxDeleteKafkaCNAMERecords, outboxIdempotencyKey(outboxDeleteKafkaCNAMERecords, kafkaRequest.ID), deleteKafkaCNAMERecordsMessage{
+			KafkaId: kafkaRequest.ID,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// enqueueReservedQuotaDeletion adds the deletion of the quota reserved for a kafka that couldn't be created to the outbox
+func (k *kafkaService) enqueueReservedQuotaDeletion(kafkaRequest *dbapi.KafkaRequest) {
+	if kafkaRequest.SubscriptionId == "" {
+		return
+	}
+	if err := db.EnqueueOutboxMessage(k.connectionFactory.New(), outboxDeleteKafkaQuota, outboxIdempotencyKey(outboxDeleteKafkaQuota, kafkaRequest.ID), deleteKafkaQuotaMessage{
+		KafkaId:        kafkaRequest.ID,
+		QuotaType:      kafkaRequest.QuotaType,
+		SubscriptionId: kafkaRequest.SubscriptionId,
+	}); err != nil {
+		glog.Errorf("failed to enqueue the deletion of the subscription id %s reserved for kafka %s: %v", kafkaRequest.SubscriptionId, kafkaRequest.ID, err)
+	}
+}
+
+func (k *kafkaService) handleCreateKafkaCanaryServiceAccount(ctx context.Context, payload []byte) error {
+	var message createKafkaCanaryServiceAccountMessage
+	if err := json.Unmarshal(payload, &message); err != nil {
+		return err
+	}
+	dbConn := k.connectionFactory.New()
+	var kafkaRequest dbapi.KafkaRequest
+	if err := dbConn.Where("id = ?", message.KafkaId).First(&kafkaRequest).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			glog.V(10).Infof("Kafka with ID '%s' not found. Skipping the creation of its canary service account", message.KafkaId)
+			return nil
+		}
+		return errors.NewWithCause(errors.ErrorGeneral, err, "unable to get kafka %s", message.KafkaId)
+	}
+	// the message is attempted again when the result of a previous attempt wasn't recorded
+	if kafkaRequest.CanaryServiceAccountClientID != "" || arrays.Contains(kafkaDeletionStatuses, kafkaRequest.Status) {
+		return nil
+	}
+
+	// the client id of the canary service account is derived from the kafka id, the service account of a previous attempt is returned
+	// instead of creating another one
+	canaryServiceAccount, serviceErr := k.keycloakService.CreateServiceAccountInternal(newCanaryServiceAccountRequest(&kafkaRequest))
+	if serviceErr != nil {
+		return errors.FailedToCreateSSOClient("failed to create canary service account %s:%v", kafkaRequest.ID, serviceErr)
+	}
+
+	result := dbConn.Model(&kafkaRequest).
+		Where("status not IN (?)", kafkaDeletionStatuses).
+		Where("canary_service_account_client_id = ''").
+		Updates(&dbapi.KafkaRequest{
+			CanaryServiceAccountClientID:     canaryServiceAccount.ClientID,
+			CanaryServiceAccountClientSecret: dbencryption.EncryptedString(canaryServiceAccount.ClientSecret),
+		})
+	if result.Error != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, result.Error, "failed to update the canary service account of kafka %s", kafkaRequest.ID)
+	}
+	if result.RowsAffected == 0 {
+		// the kafka was deleted while the service account was created, without enqueuing its deletion
+		return db.EnqueueOutboxMessage(dbConn, outboxDeleteKafkaCanaryServiceAccount, outboxIdempotencyKey(outboxDeleteKafkaCanaryServiceAccount, kafkaRequest.ID), deleteKafkaCanaryServiceAccountMessage{
+			KafkaId:  kafkaRequest.ID,
+			ClientId: canaryServiceAccount.ClientID,
+		})
+	}
+	return nil
+}
+
+func (k *kafkaService) handleDeleteKafkaQuota(ctx context.Context, payload []byte) error {
+	var message deleteKafkaQuotaMessage
+	if err := json.Unmarshal(payload, &message); err != nil {
+		return err
+	}
+	quotaService, factoryErr := k.quotaServiceFactory.GetQuotaService(api.QuotaType(message.QuotaType))
+	if factoryErr != nil {
+		return factoryErr
+	}
+	if err := quotaService.DeleteQuota(message.SubscriptionId); err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to delete subscription id %s for kafka %s", message.SubscriptionId, message.KafkaId)
+	}
+	return nil
+}
+
+func (k *kafkaService) handleDeleteKafkaCanaryServiceAccount(ctx context.Context, payload []byte) error {
+	var message deleteKafkaCanaryServiceAccountMessage
+	if err := json.Unmarshal(payload, &message); err != nil {
+		return err
+	}
+	if err := k.keycloakService.DeleteServiceAccountInternal(message.ClientId); err != nil {
+		// Log the info for not found and proceed - not an error if service account is not found
+		if err.Code == errors.ErrorServiceAccountNotFound {
+			glog.V(10).Infof("Service account with ID '%s' not found. Skipping deletion", message.ClientId)
+			return nil
+		}
+		return errors.NewWithCause(errors.ErrorGeneral, err, "error deleting canary service account of kafka %s", message.KafkaId)
+	}
+	return nil
+}
+
+func (k *kafkaService) handleDeleteKafkaCNAMERecords(ctx context.Context, payload []byte) error {
+	var message deleteKafkaCNAMERecordsMessage
+	if err := json.Unmarshal(payload, &message); err != nil {
+		return err
+	}
+	// the kafka is soft deleted by the transaction enqueuing the message
+	var kafkaRequest dbapi.KafkaRequest
+	if err := k.connectionFactory.New().Unscoped().Where("id = ?", message.KafkaId).First(&kafkaRequest).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			glog.V(10).Infof("Kafka with ID '%s' not found. Skipping the deletion of its CNAME records", message.KafkaId)
+			return nil
+		}
+		return errors.NewWithCause(errors.ErrorGeneral, err, "unable to get kafka %s", message.KafkaId)
+	}
+	// the records that were already deleted are ignored by the aws client
+	if _, err := k.ChangeKafkaCNAMErecords(&kafkaRequest, KafkaRoutesActionDelete); err != nil {
+		return err
+	}
+	return nil
+}
+
+func outboxIdempotencyKey(messageType string, kafkaId string) string {
+	return fmt.Sprintf("%s/%s", messageType, kafkaId)
+}
+
+func newCanaryServiceAccountRequest(kafkaRequest *dbapi.KafkaRequest) sso.CompleteServiceAccountRequest {
+	return sso.CompleteServiceAccountRequest{
+		Owner:          kafkaRequest.Owner,
+		OwnerAccountId: kafkaRequest.OwnerAccountId,
+		ClientId:       strings.ToLower(fmt.Sprintf("%s-%s", CanaryServiceAccountPrefix, kafkaRequest.ID)),
+		OrgId:          kafkaRequest.OrganisationId,
+		Name:           fmt.Sprintf("canary-service-account-for-kafka %s", kafkaRequest.ID),
+		Description:    fmt.Sprintf("canary service account for kafka %s", kafkaRequest.ID),
+	}
+}
@@ -0,0 +1,218 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/converters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/keycloak"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_kafkaService_registerOutboxHandlers(t *testing.T) {
+	g := gomega.NewWithT(t)
+	outbox := db.NewOutbox(nil, db.NewOutboxConfig())
+	k := &kafkaService{}
+	k.registerOutboxHandlers(outbox)
+	g.Expect(outbox.Types()).To(gomega.ConsistOf(outboxCreateKafkaCanaryServiceAccount, outboxDeleteKafkaQuota, outboxDeleteKafkaCanaryServiceAccount, outboxDeleteKafkaCNAMERecords))
+}
+
+func Test_kafkaService_handleCreateKafkaCanaryServiceAccount(t *testing.T) {
+	tests := []struct {
+		name             string
+		kafkaRequest     *dbapi.KafkaRequest
+		createAccountErr *errors.ServiceError
+		updatedRows      int64
+		wantErr          bool
+		wantCreated      bool
+		wantUpdated      bool
+		wantDeletion     bool
+	}{
+		{
+			name:         "should create the canary service account and set it on the kafka",
+			kafkaRequest: buildKafkaRequest(nil),
+			updatedRows:  1,
+			wantCreated:  true,
+			wantUpdated:  true,
+		},
+		{
+			name: "should not create the canary service account when the kafka was deleted",
+		},
+		{
+			name: "should not create the canary service account when the kafka already has one",
+			kafkaRequest: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.CanaryServiceAccountClientID = "canary-id"
+			}),
+		},
+		{
+			name: "should not create the canary service account when the kafka is being deleted",
+			kafkaRequest: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.Status = constants.KafkaRequestStatusDeprovision.String()
+			}),
+		},
+		{
+			name:             "should return an error when the canary service account can't be created",
+			kafkaRequest:     buildKafkaRequest(nil),
+			createAccountErr: errors.FailedToCreateSSOClient("failed to create the sso client"),
+			wantErr:          true,
+			wantCreated:      true,
+		},
+		{
+			name:         "should enqueue the deletion of the canary service account when the kafka was deleted while it was created",
+			kafkaRequest: buildKafkaRequest(nil),
+			wantCreated:  true,
+			wantUpdated:  true,
+			wantDeletion: true,
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			var kafkas []map[string]interface{}
+			if tt.kafkaRequest != nil {
+				kafkas = converters.ConvertKafkaRequest(tt.kafkaRequest)
+				kafkas[0]["canary_service_account_client_id"] = tt.kafkaRequest.CanaryServiceAccountClientID
+			}
+			mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).WithReply(kafkas)
+			update := mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_requests"`).WithRowsNum(tt.updatedRows)
+			deletion := mocket.Catcher.NewMock().WithQuery(`INSERT INTO "outbox_messages"`).WithRowsNum(1)
+			mocket.Catcher.NewMock().WithQueryException().WithExecException()
+
+			var createdClientId string
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				keycloakService: &sso.KeycloakServiceMock{
+					CreateServiceAccountInternalFunc: func(request sso.CompleteServiceAccountRequest) (*api.ServiceAccount, *errors.ServiceError) {
+						createdClientId = request.ClientId
+						if tt.createAccountErr != nil {
+							return nil, tt.createAccountErr
+						}
+						return &api.ServiceAccount{ClientID: request.ClientId, ClientSecret: "secret"}, nil
+					},
+				},
+			}
+			payload, err := json.Marshal(createKafkaCanaryServiceAccountMessage{KafkaId: testID})
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+
+			err = k.handleCreateKafkaCanaryServiceAccount(context.Background(), payload)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(createdClientId != "").To(gomega.Equal(tt.wantCreated))
+			if tt.wantCreated {
+				g.Expect(createdClientId).To(gomega.Equal("canary-" + testID))
+			}
+			g.Expect(update.Triggered).To(gomega.Equal(tt.wantUpdated))
+			g.Expect(deletion.Triggered).To(gomega.Equal(tt.wantDeletion))
+		})
+	}
+}
+
+func Test_kafkaService_handleDeleteKafkaQuota(t *testing.T) {
+	tests := []struct {
+		name           string
+		deleteQuotaErr *errors.ServiceError
+		wantErr        bool
+	}{
+		{
+			name: "should delete the quota of the subscription",
+		},
+		{
+			name:           "should return an error when the quota can't be deleted",
+			deleteQuotaErr: errors.GeneralError("failed to delete the quota"),
+			wantErr:        true,
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			var deletedSubscriptionId string
+			var quotaType api.QuotaType
+			k := &kafkaService{
+				quotaServiceFactory: &QuotaServiceFactoryMock{
+					GetQuotaServiceFunc: func(t api.QuotaType) (QuotaService, *errors.ServiceError) {
+						quotaType = t
+						return &QuotaServiceMock{
+							DeleteQuotaFunc: func(subscriptionId string) *errors.ServiceError {
+								deletedSubscriptionId = subscriptionId
+								return tt.deleteQuotaErr
+							},
+						}, nil
+					},
+				},
+			}
+			payload, err := json.Marshal(deleteKafkaQuotaMessage{KafkaId: testID, QuotaType: api.AMSQuotaType.String(), SubscriptionId: "subscription-id"})
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+
+			err = k.handleDeleteKafkaQuota(context.Background(), payload)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(quotaType).To(gomega.Equal(api.AMSQuotaType))
+			g.Expect(deletedSubscriptionId).To(gomega.Equal("subscription-id"))
+		})
+	}
+}
+
+func Test_kafkaService_handleDeleteKafkaCanaryServiceAccount(t *testing.T) {
+	tests := []struct {
+		name             string
+		deleteAccountErr *errors.ServiceError
+		wantErr          bool
+	}{
+		{
+			name: "should delete the canary service account",
+		},
+		{
+			name:             "should not fail when the canary service account was already deleted",
+			deleteAccountErr: &errors.ServiceError{Code: errors.ErrorServiceAccountNotFound},
+		},
+		{
+			name:             "should return an error when the canary service account can't be deleted",
+			deleteAccountErr: &errors.ServiceError{Code: errors.ErrorFailedToDeleteServiceAccount},
+			wantErr:          true,
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			var deletedClientId string
+			k := &kafkaService{
+				keycloakService: &sso.KeycloakServiceMock{
+					GetConfigFunc: func() *keycloak.KeycloakConfig {
+						return &keycloak.KeycloakConfig{EnableAuthenticationOnKafka: true}
+					},
+					DeleteServiceAccountInternalFunc: func(clientId string) *errors.ServiceError {
+						deletedClientId = clientId
+						return tt.deleteAccountErr
+					},
+				},
+			}
+			payload, err := json.Marshal(deleteKafkaCanaryServiceAccountMessage{KafkaId: testID, ClientId: "canary-id"})
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+
+			err = k.handleDeleteKafkaCanaryServiceAccount(context.Background(), payload)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(deletedClientId).To(gomega.Equal("canary-id"))
+		})
+	}
+}
+
+func Test_kafkaService_handleDeleteKafkaCNAMERecords_KafkaNotFound(t *testing.T) {
+	g := gomega.NewWithT(t)
+	mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "kafka_requests"`).WithReply(nil)
+	k := &kafkaService{
+		connectionFactory: db.NewMockConnectionFactory(nil),
+	}
+	payload, err := json.Marshal(deleteKafkaCNAMERecordsMessage{KafkaId: testID})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	g.Expect(k.handleDeleteKafkaCNAMERecords(context.Background(), payload)).To(gomega.Succeed())
+}
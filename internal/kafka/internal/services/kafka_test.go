@@ -30,6 +30,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/authorization"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
 	"github.com/onsi/gomega"
 	goerrors "github.com/pkg/errors"
 	mocket "github.com/selvatico/go-mocket"
@@ -329,12 +330,33 @@ func Test_kafkaService_Get(t *testing.T) {
 			setupFn: func() {
 				mocket.Catcher.Reset().
 					NewMock().
-					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1 AND owner = $2`).
-					WithArgs(testID, testUser).
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+					WithArgs(testID).
 					WithReply(converters.ConvertKafkaRequest(buildKafkaRequest(nil)))
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 		},
+		{
+			name: "error when the kafka is owned by another user",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			args: args{
+				ctx: authenticatedCtx,
+				id:  testID,
+			},
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().
+					NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+					WithArgs(testID).
+					WithReply(converters.ConvertKafkaRequest(buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+						kafkaRequest.Owner = "other-user"
+					})))
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
 	}
 	// we loop through each test case defined in the list above and start a new test invocation, using the testing
 	// t.Run function
@@ -469,6 +491,7 @@ func Test_kafkaService_PrepareKafkaRequest(t *testing.T) {
 		setupFn                 func()
 		wantErr                 bool
 		wantBootstrapServerHost string
+		wantCanaryEnqueued      bool
 	}{
 		{
 			name: "successful kafka request preparation",
@@ -588,48 +611,7 @@ func Test_kafkaService_PrepareKafkaRequest(t *testing.T) {
 			wantBootstrapServerHost: fmt.Sprintf("%s-%s.clusterDNS", TruncateString(longKafkaName, truncatedNameLen), testID),
 		},
 		{
-			name: "failed SSO client creation",
-			fields: fields{
-				connectionFactory: db.NewMockConnectionFactory(nil),
-				clusterService: &ClusterServiceMock{
-					GetClusterDNSFunc: func(string) (string, *errors.ServiceError) {
-						return "clusterDNS", nil
-					},
-				},
-				keycloakService: &sso.KeycloakServiceMock{
-					GetConfigFunc: func() *keycloak.KeycloakConfig {
-						return &keycloak.KeycloakConfig{
-							KafkaRealm: &keycloak.KeycloakRealmConfig{
-								ClientID: "test",
-							},
-							EnableAuthenticationOnKafka: true,
-						}
-					},
-					CreateServiceAccountInternalFunc: func(request sso.CompleteServiceAccountRequest) (*api.ServiceAccount, *errors.ServiceError) {
-						return nil, errors.FailedToCreateSSOClient("failed to create the sso client")
-					},
-				},
-				kafkaTLSCertificateManagementService: &kafkatlscertmgmt.KafkaTLSCertificateManagementServiceMock{
-					IsAutomaticCertificateManagementEnabledFunc: func() bool {
-						return false
-					},
-					ManageCertificateFunc: func(ctx context.Context, domain string) (kafkatlscertmgmt.CertificateManagementOutput, error) {
-						return kafkatlscertmgmt.CertificateManagementOutput{}, nil
-					},
-				},
-				kafkaConfig: &config.KafkaConfig{},
-			},
-			args: args{
-				kafkaRequest: buildKafkaRequest(nil),
-			},
-			setupFn: func() {
-				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests"`)
-				mocket.Catcher.NewMock().WithExecException().WithQueryException()
-			},
-			wantErr: true,
-		},
-		{
-			name: "failed to create canary service account",
+			name: "should enqueue the creation of the canary service account",
 			fields: fields{
 				connectionFactory: db.NewMockConnectionFactory(nil),
 				clusterService: &ClusterServiceMock{
@@ -638,9 +620,6 @@ func Test_kafkaService_PrepareKafkaRequest(t *testing.T) {
 					},
 				},
 				keycloakService: &sso.KeycloakServiceMock{
-					CreateServiceAccountInternalFunc: func(request sso.CompleteServiceAccountRequest) (*api.ServiceAccount, *errors.ServiceError) {
-						return nil, errors.FailedToCreateSSOClient("failed to create the sso client")
-					},
 					GetConfigFunc: func() *keycloak.KeycloakConfig {
 						return &keycloak.KeycloakConfig{
 							KafkaRealm: &keycloak.KeycloakRealmConfig{
@@ -664,10 +643,12 @@ func Test_kafkaService_PrepareKafkaRequest(t *testing.T) {
 				kafkaRequest: buildKafkaRequest(nil),
 			},
 			setupFn: func() {
-				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests"`)
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests"`).WithRowsNum(1)
+				mocket.Catcher.NewMock().WithQuery(`INSERT INTO "outbox_messages"`).WithRowsNum(1)
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
-			wantErr: true,
+			wantErr:            false,
+			wantCanaryEnqueued: true,
 		},
 	}
 	for _, testcase := range tests {
@@ -836,6 +817,7 @@ func Test_kafkaService_Delete(t *testing.T) {
 			},
 			setupFn: func() {
 				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET "deleted_at"`)
+				mocket.Catcher.NewMock().WithQuery(`INSERT INTO "outbox_messages"`)
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 		},
@@ -862,11 +844,12 @@ func Test_kafkaService_Delete(t *testing.T) {
 			},
 			setupFn: func() {
 				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET "deleted_at"`)
+				mocket.Catcher.NewMock().WithQuery(`INSERT INTO "outbox_messages"`)
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 		},
 		{
-			name: "fail to delete kafka request: error when canary service account",
+			name: "fail to delete kafka request: error when adding the deletion of the canary service account to the outbox",
 			fields: fields{
 				connectionFactory: db.NewMockConnectionFactory(nil),
 				keycloakService: &sso.KeycloakServiceMock{
@@ -875,9 +858,6 @@ func Test_kafkaService_Delete(t *testing.T) {
 							EnableAuthenticationOnKafka: true,
 						}
 					},
-					DeleteServiceAccountInternalFunc: func(clientId string) *errors.ServiceError {
-						return &errors.ServiceError{}
-					},
 				},
 				kafkaConfig: &config.KafkaConfig{},
 			},
@@ -887,6 +867,10 @@ func Test_kafkaService_Delete(t *testing.T) {
 					kafkaRequest.CanaryServiceAccountClientID = "canary-id"
 				}),
 			},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET "deleted_at"`)
+				mocket.Catcher.NewMock().WithQuery(`INSERT INTO "outbox_messages"`).WithExecException()
+			},
 			wantErr: true,
 		},
 		{
@@ -939,6 +923,7 @@ func Test_kafkaService_Delete(t *testing.T) {
 			},
 			setupFn: func() {
 				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET "deleted_at"`)
+				mocket.Catcher.NewMock().WithQuery(`INSERT INTO "outbox_messages"`)
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 		},
@@ -976,6 +961,7 @@ func Test_kafkaService_Delete(t *testing.T) {
 			},
 			setupFn: func() {
 				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET "deleted_at"`)
+				mocket.Catcher.NewMock().WithQuery(`INSERT INTO "outbox_messages"`)
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 		},
@@ -1013,6 +999,7 @@ func Test_kafkaService_Delete(t *testing.T) {
 			},
 			setupFn: func() {
 				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET "deleted_at"`)
+				mocket.Catcher.NewMock().WithQuery(`INSERT INTO "outbox_messages"`)
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 		},
@@ -1050,6 +1037,7 @@ func Test_kafkaService_Delete(t *testing.T) {
 			},
 			setupFn: func() {
 				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET "deleted_at"`)
+				mocket.Catcher.NewMock().WithQuery(`INSERT INTO "outbox_messages"`)
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 			wantErr: true,
@@ -1143,6 +1131,7 @@ func Test_kafkaService_RegisterKafkaJob(t *testing.T) {
 
 	defaultDataplaneClusterConfig := []config.ManualCluster{buildManualCluster(1, api.AllInstanceTypeSupport.String(), testKafkaRequestRegion)}
 	nowTime := time.Now()
+	var quotaDeletionEnqueued *mocket.FakeResponse
 
 	tests := []struct {
 		name    string
@@ -1208,6 +1197,49 @@ func Test_kafkaService_RegisterKafkaJob(t *testing.T) {
 				g.Expect(kafkaRequest.UpdatedAt).To(gomega.Equal(nowTime))
 			},
 		},
+		{
+			name: "should enqueue the deletion of the reserved quota when the kafka can't be created",
+			fields: fields{
+				connectionFactory:      db.NewMockConnectionFactory(nil),
+				kafkaConfig:            defaultKafkaConf,
+				dataplaneClusterConfig: buildDataplaneClusterConfig(defaultDataplaneClusterConfig),
+				clusterPlmtStrategy: &ClusterPlacementStrategyMock{
+					FindClusterFunc: func(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
+						return mockCluster, nil
+					},
+				},
+				quotaService: &QuotaServiceMock{
+					ReserveQuotaFunc: func(kafka *dbapi.KafkaRequest) (string, *errors.ServiceError) {
+						return "fake-subscription-id", nil
+					},
+				},
+				providerConfig: buildProviderConfiguration(testKafkaRequestRegion, MaxClusterCapacity, MaxClusterCapacity, false),
+			},
+			args: args{
+				kafkaRequest: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+					kafkaRequest.ID = ""
+					kafkaRequest.ClusterID = ""
+					kafkaRequest.InstanceType = types.STANDARD.String()
+				}),
+			},
+			setupFn: func(connectionFactory *db.ConnectionFactory) {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE region = $1 AND cloud_provider = $2 AND instance_type = $3 AND "kafka_requests"."deleted_at" IS NULL`).
+					WithReply(nil)
+				mocket.Catcher.NewMock().WithQuery(`INSERT INTO "kafka_requests"`).WithQueryException().WithExecException()
+				quotaDeletionEnqueued = mocket.Catcher.NewMock().WithQuery(`INSERT INTO "outbox_messages"`).WithRowsNum(1)
+				mocket.Catcher.NewMock().WithQueryException().WithExecException()
+			},
+			error: errorCheck{
+				wantErr:  true,
+				code:     errors.ErrorGeneral,
+				httpCode: http.StatusInternalServerError,
+			},
+			verifyKafkaUpdatedContentsFunc: func(g *gomega.WithT, kafkaRequest *dbapi.KafkaRequest) {
+				g.Expect(kafkaRequest.SubscriptionId).To(gomega.Equal("fake-subscription-id"))
+				g.Expect(quotaDeletionEnqueued.Triggered).To(gomega.BeTrue())
+			},
+		},
 		{
 			name: "registering kafka job succeeds with developer",
 			fields: fields{
@@ -2710,6 +2742,58 @@ func Test_kafkaService_Updates(t *testing.T) {
 	}
 }
 
+func Test_kafkaService_UpdatesIfStatusIn(t *testing.T) {
+	tests := []struct {
+		name        string
+		wantErr     bool
+		wantUpdated bool
+		setupFn     func()
+	}{
+		{
+			name:    "fail when database returns an error",
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery("UPDATE").WithExecException()
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name:        "return false when the kafka is not in one of the given statuses",
+			wantUpdated: false,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests"`).WithRowsNum(0)
+				mocket.Catcher.NewMock().WithQueryException().WithExecException()
+			},
+		},
+		{
+			name:        "return true when the kafka is updated",
+			wantUpdated: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET "status"=$1,"updated_at"=$2 WHERE status IN ($3,$4)`).WithRowsNum(1)
+				mocket.Catcher.NewMock().WithQueryException().WithExecException()
+			},
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			k := kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				kafkaConfig:       config.NewKafkaConfig(),
+				awsConfig:         config.NewAWSConfig(),
+			}
+			updated, err := k.UpdatesIfStatusIn(buildKafkaRequest(nil), []string{constants.KafkaRequestStatusReady.String(), constants.KafkaRequestStatusProvisioning.String()}, map[string]interface{}{
+				"status": constants.KafkaRequestStatusProvisioning.String(),
+			})
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(updated).To(gomega.Equal(tt.wantUpdated))
+		})
+	}
+}
+
 func Test_kafkaService_DeprovisionKafkaForUsers(t *testing.T) {
 	type fields struct {
 		connectionFactory *db.ConnectionFactory
@@ -3619,7 +3703,8 @@ func Test_kafkaService_GetManagedKafkaByClusterID(t *testing.T) {
 			want:    []managedkafka.ManagedKafka{*managedkafkaCRWithoutCerts},
 			setupFn: func() {
 				mocket.Catcher.Reset()
-				query := fmt.Sprintf(`SELECT * FROM "%s"`, kafkaRequestTableName)
+				// the provisioning kafkas without a canary service account aren't returned
+				query := fmt.Sprintf(`SELECT * FROM "%s" WHERE cluster_id = $1 AND status IN ($2,$3,$4,$5,$6,$7,$8) AND bootstrap_server_host != '' AND (NOT (status = $9 AND canary_service_account_client_id = ''))`, kafkaRequestTableName)
 				response := converters.ConvertKafkaRequestList(kafkaRequestList)
 				mocket.Catcher.NewMock().WithQuery(query).WithReply(response)
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
@@ -4418,6 +4503,7 @@ func Test_NewKafkaService(t *testing.T) {
 		providerConfig                       *config.ProviderConfig
 		clusterPlacementStrategy             ClusterPlacementStrategy
 		kafkaTLSCertificateManagementService kafkatlscertmgmt.KafkaTLSCertificateManagementService
+		webhookService                       webhooks.WebhookService
 	}
 	tests := []struct {
 		name string
@@ -4438,6 +4524,7 @@ func Test_NewKafkaService(t *testing.T) {
 				providerConfig:                       &config.ProviderConfig{},
 				clusterPlacementStrategy:             &ClusterPlacementStrategyMock{},
 				kafkaTLSCertificateManagementService: &kafkatlscertmgmt.KafkaTLSCertificateManagementServiceMock{},
+				webhookService:                       &webhooks.WebhookServiceMock{},
 			},
 			want: &kafkaService{
 				connectionFactory:                    &db.ConnectionFactory{},
@@ -4451,6 +4538,7 @@ func Test_NewKafkaService(t *testing.T) {
 				providerConfig:                       &config.ProviderConfig{},
 				clusterPlacementStrategy:             &ClusterPlacementStrategyMock{},
 				kafkaTLSCertificateManagementService: &kafkatlscertmgmt.KafkaTLSCertificateManagementServiceMock{},
+				webhookService:                       &webhooks.WebhookServiceMock{},
 			},
 		},
 	}
@@ -4470,7 +4558,9 @@ func Test_NewKafkaService(t *testing.T) {
 			tt.args.authorizationService,
 			tt.args.providerConfig,
 			tt.args.clusterPlacementStrategy,
-			tt.args.kafkaTLSCertificateManagementService)).To(gomega.Equal(tt.want))
+			tt.args.kafkaTLSCertificateManagementService,
+			tt.args.webhookService,
+			db.NewOutbox(nil, db.NewOutboxConfig()))).To(gomega.Equal(tt.want))
 	}
 }
 
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/golang/glog"
+	"gorm.io/gorm"
+)
+
+// UsageDateLayout is the layout of the days of the kafka usage
+const UsageDateLayout = "2006-01-02"
+
+// nonBillableKafkaStatuses are the statuses of the kafkas that are not accounted in the usage
+var nonBillableKafkaStatuses = []string{
+	constants.KafkaRequestStatusFailed.String(),
+	constants.KafkaRequestStatusDeprovision.String(),
+	constants.KafkaRequestStatusDeleting.String(),
+}
+
+//go:generate moq -out kafka_usage_service_moq.go . KafkaUsageService
+type KafkaUsageService interface {
+	// RecordDailyUsage snapshots the current usage of the kafkas of every organisation as the usage of the given UTC day.
+	// The kafkas that failed or are being deleted are not accounted. A previously recorded usage for the same day is replaced.
+	RecordDailyUsage(day time.Time) *errors.ServiceError
+	// IsDailyUsageRecorded returns whether the usage of the given UTC day has already been recorded
+	IsDailyUsageRecorded(day time.Time) (bool, *errors.ServiceError)
+	// ListDailyUsage returns the usage recorded between the from and to UTC days, both included, ordered by day and organisation.
+	// The usage of all the organisations is returned when orgID is empty.
+	ListDailyUsage(orgID string, from, to time.Time) (dbapi.KafkaUsageDailyList, *errors.ServiceError)
+}
+
+var _ KafkaUsageService = &kafkaUsageService{}
+
+type kafkaUsageService struct {
+	connectionFactory *db.ConnectionFactory
+	kafkaConfig       *config.KafkaConfig
+}
+
+func NewKafkaUsageService(connectionFactory *db.ConnectionFactory, kafkaConfig *config.KafkaConfig) KafkaUsageService {
+	return &kafkaUsageService{
+		connectionFactory: connectionFactory,
+		kafkaConfig:       kafkaConfig,
+	}
+}
+
+func (s *kafkaUsageService) RecordDailyUsage(day time.Time) *errors.ServiceError {
+	usageDate := TruncateToUTCDay(day)
+
+	var kafkas dbapi.KafkaList
+	dbConn := s.connectionFactory.New()
+	if err := dbConn.Where("status NOT IN (?)", nonBillableKafkaStatuses).Find(&kafkas).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to list kafkas to record the usage of %s", usageDate.Format(UsageDateLayout))
+	}
+
+	usages, svcErr := s.aggregateUsage(usageDate, kafkas)
+	if svcErr != nil {
+		return svcErr
+	}
+
+	if err := dbConn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("usage_date = ?", usageDate).Delete(&dbapi.KafkaUsageDaily{}).Error; err != nil {
+			return err
+		}
+
+		if len(usages) == 0 {
+			return nil
+		}
+
+		return tx.Create(&usages).Error
+	}); err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to record the usage of %s", usageDate.Format(UsageDateLayout))
+	}
+
+	glog.Infof("usage of %s recorded for %d kafkas", usageDate.Format(UsageDateLayout), len(kafkas))
+
+	return nil
+}
+
+func (s *kafkaUsageService) IsDailyUsageRecorded(day time.Time) (bool, *errors.ServiceError) {
+	usageDate := TruncateToUTCDay(day)
+
+	var count int64
+	if err := s.connectionFactory.New().Model(&dbapi.KafkaUsageDaily{}).Where("usage_date = ?", usageDate).Count(&count).Error; err != nil {
+		return false, errors.NewWithCause(errors.ErrorGeneral, err, "failed to check whether the usage of %s is recorded", usageDate.Format(UsageDateLayout))
+	}
+
+	// a day without any billable kafka has no usage rows, so it is reported as not recorded.
+	// Recording it again is harmless as it keeps being empty.
+	return count > 0, nil
+}
+
+func (s *kafkaUsageService) ListDailyUsage(orgID string, from, to time.Time) (dbapi.KafkaUsageDailyList, *errors.ServiceError) {
+	dbConn := s.connectionFactory.New().
+		Where("usage_date >= ?", TruncateToUTCDay(from)).
+		Where("usage_date <= ?", TruncateToUTCDay(to))
+
+	if orgID != "" {
+		dbConn = dbConn.Where("organisation_id = ?", orgID)
+	}
+
+	var usages dbapi.KafkaUsageDailyList
+	if err := dbConn.Order("usage_date, organisation_id, instance_type, size_id, billing_model, marketplace, billing_cloud_account_id").Find(&usages).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to list the usage of organisation %q", orgID)
+	}
+
+	return usages, nil
+}
+
+// aggregateUsage sums up the kafkas and the streaming units they consume per organisation,
+// instance type, size, billing model, marketplace and billing cloud account
+func (s *kafkaUsageService) aggregateUsage(usageDate time.Time, kafkas dbapi.KafkaList) ([]*dbapi.KafkaUsageDaily, *errors.ServiceError) {
+	usagesByKey := map[string]*dbapi.KafkaUsageDaily{}
+	for _, kafka := range kafkas {
+		size, err := s.kafkaConfig.GetKafkaInstanceSize(kafka.InstanceType, kafka.SizeId)
+		if err != nil {
+			return nil, errors.NewWithCause(errors.ErrorInstancePlanNotSupported, err, "failed to get size %q of instance type %q of kafka %q", kafka.SizeId, kafka.InstanceType, kafka.ID)
+		}
+
+		key := fmt.Sprintf("%s/%s/%s/%s/%s/%s", kafka.OrganisationId, kafka.InstanceType, kafka.SizeId, kafka.ActualKafkaBillingModel, kafka.Marketplace, kafka.BillingCloudAccountId)
+		usage, ok := usagesByKey[key]
+		if !ok {
+			usage = &dbapi.KafkaUsageDaily{
+				Meta:                  api.Meta{ID: api.NewID()},
+				UsageDate:             usageDate,
+				OrganisationId:        kafka.OrganisationId,
+				InstanceType:          kafka.InstanceType,
+				SizeId:                kafka.SizeId,
+				BillingModel:          kafka.ActualKafkaBillingModel,
+				Marketplace:           kafka.Marketplace,
+				BillingCloudAccountId: kafka.BillingCloudAccountId,
+			}
+			usagesByKey[key] = usage
+		}
+
+		usage.KafkaCount++
+		usage.StreamingUnits += size.CapacityConsumed
+	}
+
+	keys := make([]string, 0, len(usagesByKey))
+	for key := range usagesByKey {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	usages := make([]*dbapi.KafkaUsageDaily, 0, len(keys))
+	for _, key := range keys {
+		usages = append(usages, usagesByKey[key])
+	}
+
+	return usages, nil
+}
+
+// TruncateToUTCDay returns the beginning of the UTC day of the given time
+func TruncateToUTCDay(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
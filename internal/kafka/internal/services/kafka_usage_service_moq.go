@@ -0,0 +1,177 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package services
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	apiErrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"sync"
+	"time"
+)
+
+// Ensure, that KafkaUsageServiceMock does implement KafkaUsageService.
+// If this is not the case, regenerate this file with moq.
+var _ KafkaUsageService = &KafkaUsageServiceMock{}
+
+// KafkaUsageServiceMock is a mock implementation of KafkaUsageService.
+//
+//	func TestSomethingThatUsesKafkaUsageService(t *testing.T) {
+//
+//		// make and configure a mocked KafkaUsageService
+//		mockedKafkaUsageService := &KafkaUsageServiceMock{
+//			IsDailyUsageRecordedFunc: func(day time.Time) (bool, *apiErrors.ServiceError) {
+//				panic("mock out the IsDailyUsageRecorded method")
+//			},
+//			ListDailyUsageFunc: func(orgID string, from time.Time, to time.Time) (dbapi.KafkaUsageDailyList, *apiErrors.ServiceError) {
+//				panic("mock out the ListDailyUsage method")
+//			},
+//			RecordDailyUsageFunc: func(day time.Time) *apiErrors.ServiceError {
+//				panic("mock out the RecordDailyUsage method")
+//			},
+//		}
+//
+//		// use mockedKafkaUsageService in code that requires KafkaUsageService
+//		// and then make assertions.
+//
+//	}
+type KafkaUsageServiceMock struct {
+	// IsDailyUsageRecordedFunc mocks the IsDailyUsageRecorded method.
+	IsDailyUsageRecordedFunc func(day time.Time) (bool, *apiErrors.ServiceError)
+
+	// ListDailyUsageFunc mocks the ListDailyUsage method.
+	ListDailyUsageFunc func(orgID string, from time.Time, to time.Time) (dbapi.KafkaUsageDailyList, *apiErrors.ServiceError)
+
+	// RecordDailyUsageFunc mocks the RecordDailyUsage method.
+	RecordDailyUsageFunc func(day time.Time) *apiErrors.ServiceError
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// IsDailyUsageRecorded holds details about calls to the IsDailyUsageRecorded method.
+		IsDailyUsageRecorded []struct {
+			// Day is the day argument value.
+			Day time.Time
+		}
+		// ListDailyUsage holds details about calls to the ListDailyUsage method.
+		ListDailyUsage []struct {
+			// OrgID is the orgID argument value.
+			OrgID string
+			// From is the from argument value.
+			From time.Time
+			// To is the to argument value.
+			To time.Time
+		}
+		// RecordDailyUsage holds details about calls to the RecordDailyUsage method.
+		RecordDailyUsage []struct {
+			// Day is the day argument value.
+			Day time.Time
+		}
+	}
+	lockIsDailyUsageRecorded sync.RWMutex
+	lockListDailyUsage       sync.RWMutex
+	lockRecordDailyUsage     sync.RWMutex
+}
+
+// IsDailyUsageRecorded calls IsDailyUsageRecordedFunc.
+func (mock *KafkaUsageServiceMock) IsDailyUsageRecorded(day time.Time) (bool, *apiErrors.ServiceError) {
+	if mock.IsDailyUsageRecordedFunc == nil {
+		panic("KafkaUsageServiceMock.IsDailyUsageRecordedFunc: method is nil but KafkaUsageService.IsDailyUsageRecorded was just called")
+	}
+	callInfo := struct {
+		Day time.Time
+	}{
+		Day: day,
+	}
+	mock.lockIsDailyUsageRecorded.Lock()
+	mock.calls.IsDailyUsageRecorded = append(mock.calls.IsDailyUsageRecorded, callInfo)
+	mock.lockIsDailyUsageRecorded.Unlock()
+	return mock.IsDailyUsageRecordedFunc(day)
+}
+
+// IsDailyUsageRecordedCalls gets all the calls that were made to IsDailyUsageRecorded.
+// Check the length with:
+//
+//	len(mockedKafkaUsageService.IsDailyUsageRecordedCalls())
+func (mock *KafkaUsageServiceMock) IsDailyUsageRecordedCalls() []struct {
+	Day time.Time
+} {
+	var calls []struct {
+		Day time.Time
+	}
+	mock.lockIsDailyUsageRecorded.RLock()
+	calls = mock.calls.IsDailyUsageRecorded
+	mock.lockIsDailyUsageRecorded.RUnlock()
+	return calls
+}
+
+// ListDailyUsage calls ListDailyUsageFunc.
+func (mock *KafkaUsageServiceMock) ListDailyUsage(orgID string, from time.Time, to time.Time) (dbapi.KafkaUsageDailyList, *apiErrors.ServiceError) {
+	if mock.ListDailyUsageFunc == nil {
+		panic("KafkaUsageServiceMock.ListDailyUsageFunc: method is nil but KafkaUsageService.ListDailyUsage was just called")
+	}
+	callInfo := struct {
+		OrgID string
+		From  time.Time
+		To    time.Time
+	}{
+		OrgID: orgID,
+		From:  from,
+		To:    to,
+	}
+	mock.lockListDailyUsage.Lock()
+	mock.calls.ListDailyUsage = append(mock.calls.ListDailyUsage, callInfo)
+	mock.lockListDailyUsage.Unlock()
+	return mock.ListDailyUsageFunc(orgID, from, to)
+}
+
+// ListDailyUsageCalls gets all the calls that were made to ListDailyUsage.
+// Check the length with:
+//
+//	len(mockedKafkaUsageService.ListDailyUsageCalls())
+func (mock *KafkaUsageServiceMock) ListDailyUsageCalls() []struct {
+	OrgID string
+	From  time.Time
+	To    time.Time
+} {
+	var calls []struct {
+		OrgID string
+		From  time.Time
+		To    time.Time
+	}
+	mock.lockListDailyUsage.RLock()
+	calls = mock.calls.ListDailyUsage
+	mock.lockListDailyUsage.RUnlock()
+	return calls
+}
+
+// RecordDailyUsage calls RecordDailyUsageFunc.
+func (mock *KafkaUsageServiceMock) RecordDailyUsage(day time.Time) *apiErrors.ServiceError {
+	if mock.RecordDailyUsageFunc == nil {
+		panic("KafkaUsageServiceMock.RecordDailyUsageFunc: method is nil but KafkaUsageService.RecordDailyUsage was just called")
+	}
+	callInfo := struct {
+		Day time.Time
+	}{
+		Day: day,
+	}
+	mock.lockRecordDailyUsage.Lock()
+	mock.calls.RecordDailyUsage = append(mock.calls.RecordDailyUsage, callInfo)
+	mock.lockRecordDailyUsage.Unlock()
+	return mock.RecordDailyUsageFunc(day)
+}
+
+// RecordDailyUsageCalls gets all the calls that were made to RecordDailyUsage.
+// Check the length with:
+//
+//	len(mockedKafkaUsageService.RecordDailyUsageCalls())
+func (mock *KafkaUsageServiceMock) RecordDailyUsageCalls() []struct {
+	Day time.Time
+} {
+	var calls []struct {
+		Day time.Time
+	}
+	mock.lockRecordDailyUsage.RLock()
+	calls = mock.calls.RecordDailyUsage
+	mock.lockRecordDailyUsage.RUnlock()
+	return calls
+}
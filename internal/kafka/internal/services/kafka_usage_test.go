@@ -0,0 +1,218 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_kafkaUsageService_aggregateUsage(t *testing.T) {
+	usageDate := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	buildKafka := func(orgID, instanceType, sizeID, billingModel string) *dbapi.KafkaRequest {
+		return buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.OrganisationId = orgID
+			kafkaRequest.InstanceType = instanceType
+			kafkaRequest.SizeId = sizeID
+			kafkaRequest.ActualKafkaBillingModel = billingModel
+		})
+	}
+
+	tests := []struct {
+		name    string
+		kafkas  dbapi.KafkaList
+		want    []*dbapi.KafkaUsageDaily
+		wantErr *errors.ServiceError
+	}{
+		{
+			name:   "should return no usage if there are no kafkas",
+			kafkas: dbapi.KafkaList{},
+			want:   []*dbapi.KafkaUsageDaily{},
+		},
+		{
+			name: "should return an error if the size of a kafka is not supported",
+			kafkas: dbapi.KafkaList{
+				buildKafka("org-1", "standard", "x9", "standard"),
+			},
+			wantErr: errors.InstancePlanNotSupported("size not supported"),
+		},
+		{
+			name: "should sum up the kafkas and their streaming units per organisation, instance type, size and billing model",
+			kafkas: dbapi.KafkaList{
+				buildKafka("org-2", "standard", "x1", "standard"),
+				buildKafka("org-1", "developer", "x1", "standard"),
+				buildKafka("org-1", "standard", "x1", "standard"),
+				buildKafka("org-1", "standard", "x1", "standard"),
+				buildKafka("org-1", "standard", "x1", "enterprise"),
+			},
+			want: []*dbapi.KafkaUsageDaily{
+				{UsageDate: usageDate, OrganisationId: "org-1", InstanceType: "developer", SizeId: "x1", BillingModel: "standard", KafkaCount: 1, StreamingUnits: 2},
+				{UsageDate: usageDate, OrganisationId: "org-1", InstanceType: "standard", SizeId: "x1", BillingModel: "enterprise", KafkaCount: 1, StreamingUnits: 1},
+				{UsageDate: usageDate, OrganisationId: "org-1", InstanceType: "standard", SizeId: "x1", BillingModel: "standard", KafkaCount: 2, StreamingUnits: 2},
+				{UsageDate: usageDate, OrganisationId: "org-2", InstanceType: "standard", SizeId: "x1", BillingModel: "standard", KafkaCount: 1, StreamingUnits: 1},
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			s := &kafkaUsageService{
+				kafkaConfig: &defaultKafkaConf,
+			}
+
+			got, err := s.aggregateUsage(usageDate, tt.kafkas)
+			if tt.wantErr != nil {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErr.Code))
+				return
+			}
+
+			g.Expect(err).To(gomega.BeNil())
+			for _, usage := range got {
+				g.Expect(usage.ID).ToNot(gomega.BeEmpty())
+				usage.ID = ""
+			}
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_kafkaUsageService_RecordDailyUsage(t *testing.T) {
+	tests := []struct {
+		name    string
+		setupFn func()
+		wantErr bool
+	}{
+		{
+			name: "should return an error if the kafkas cannot be listed",
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "kafka_requests"`).WithQueryException()
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return an error if the usage cannot be recorded",
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "kafka_requests"`).WithReply(nil)
+				mocket.Catcher.NewMock().WithQuery(`DELETE FROM "kafka_usage_daily"`).WithExecException()
+			},
+			wantErr: true,
+		},
+		{
+			name: "should record the usage",
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "kafka_usage_daily"`).WithReply(nil)
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			s := NewKafkaUsageService(db.NewMockConnectionFactory(nil), &defaultKafkaConf)
+
+			err := s.RecordDailyUsage(time.Now())
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
+
+func Test_kafkaUsageService_IsDailyUsageRecorded(t *testing.T) {
+	tests := []struct {
+		name    string
+		setupFn func()
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "should return an error if the usage cannot be counted",
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT count(1) FROM "kafka_usage_daily"`).WithQueryException()
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return true if the usage of the day has been recorded",
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT count(1) FROM "kafka_usage_daily"`).WithReply([]map[string]interface{}{{"count": 2}})
+			},
+			want: true,
+		},
+		{
+			name: "should return false if the usage of the day has not been recorded",
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT count(1) FROM "kafka_usage_daily"`).WithReply([]map[string]interface{}{{"count": 0}})
+			},
+			want: false,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			s := NewKafkaUsageService(db.NewMockConnectionFactory(nil), &defaultKafkaConf)
+
+			got, err := s.IsDailyUsageRecorded(time.Now())
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_kafkaUsageService_ListDailyUsage(t *testing.T) {
+	from := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		orgID   string
+		setupFn func()
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "should return an error if the usage cannot be listed",
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "kafka_usage_daily"`).WithQueryException()
+			},
+			wantErr: true,
+		},
+		{
+			name:  "should list the usage of the organisation",
+			orgID: "org-1",
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT * FROM "kafka_usage_daily" WHERE usage_date >= $1 AND usage_date <= $2 AND (organisation_id = $3)`).
+					WithReply([]map[string]interface{}{
+						{"organisation_id": "org-1", "usage_date": from, "kafka_count": 1, "streaming_units": 1},
+						{"organisation_id": "org-1", "usage_date": to, "kafka_count": 2, "streaming_units": 2},
+					})
+			},
+			want: 2,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			s := NewKafkaUsageService(db.NewMockConnectionFactory(nil), &defaultKafkaConf)
+
+			got, err := s.ListDailyUsage(tt.orgID, from, to)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(got).To(gomega.HaveLen(tt.want))
+		})
+	}
+}
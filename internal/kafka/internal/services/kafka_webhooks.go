@@ -0,0 +1,52 @@
+package services
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
+	"gorm.io/gorm"
+)
+
+// kafkaStatusWebhookEvents are the webhook events of the kafka statuses the organisations can be notified of
+var kafkaStatusWebhookEvents = map[string]string{
+	constants.KafkaRequestStatusReady.String():     webhooks.EventKafkaReady,
+	constants.KafkaRequestStatusFailed.String():    webhooks.EventKafkaFailed,
+	constants.KafkaRequestStatusSuspended.String(): webhooks.EventKafkaSuspended,
+}
+
+// kafkaWebhookData is the kafka sent in the webhook deliveries
+type kafkaWebhookData struct {
+	Id            string `json:"id"`
+	Name          string `json:"name"`
+	Status        string `json:"status"`
+	Owner         string `json:"owner"`
+	CloudProvider string `json:"cloud_provider"`
+	Region        string `json:"region"`
+	InstanceType  string `json:"instance_type"`
+	FailedReason  string `json:"failed_reason,omitempty"`
+}
+
+// enqueueKafkaStatusWebhookEvent adds the webhook event of the new status of a kafka to the outbox with the given connection,
+// nothing is enqueued if the organisations can't be notified of the status
+func enqueueKafkaStatusWebhookEvent(webhookService webhooks.WebhookService, dbConn *gorm.DB, kafka *dbapi.KafkaRequest) *errors.ServiceError {
+	eventType, ok := kafkaStatusWebhookEvents[kafka.Status]
+	if !ok {
+		return nil
+	}
+	return webhookService.Enqueue(dbConn, webhooks.Event{
+		Type:           eventType,
+		OrganisationId: kafka.OrganisationId,
+		ResourceId:     kafka.ID,
+		Data: kafkaWebhookData{
+			Id:            kafka.ID,
+			Name:          kafka.Name,
+			Status:        kafka.Status,
+			Owner:         kafka.Owner,
+			CloudProvider: kafka.CloudProvider,
+			Region:        kafka.Region,
+			InstanceType:  kafka.InstanceType,
+			FailedReason:  kafka.FailedReason,
+		},
+	})
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
+	"github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+func Test_enqueueKafkaStatusWebhookEvent(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        constants.KafkaStatus
+		wantEventType string
+	}{
+		{
+			name:          "should enqueue the ready event",
+			status:        constants.KafkaRequestStatusReady,
+			wantEventType: webhooks.EventKafkaReady,
+		},
+		{
+			name:          "should enqueue the failed event",
+			status:        constants.KafkaRequestStatusFailed,
+			wantEventType: webhooks.EventKafkaFailed,
+		},
+		{
+			name:          "should enqueue the suspended event",
+			status:        constants.KafkaRequestStatusSuspended,
+			wantEventType: webhooks.EventKafkaSuspended,
+		},
+		{
+			name:   "should not enqueue any event for the other statuses",
+			status: constants.KafkaRequestStatusProvisioning,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			webhookService := &webhooks.WebhookServiceMock{
+				EnqueueFunc: func(dbConn *gorm.DB, events ...webhooks.Event) *errors.ServiceError {
+					g.Expect(events).To(gomega.HaveLen(1))
+					g.Expect(events[0].Type).To(gomega.Equal(tt.wantEventType))
+					g.Expect(events[0].OrganisationId).To(gomega.Equal("test-org"))
+					g.Expect(events[0].ResourceId).To(gomega.Equal(testID))
+					return nil
+				},
+			}
+			kafka := &dbapi.KafkaRequest{Meta: api.Meta{ID: testID}, OrganisationId: "test-org", Status: tt.status.String()}
+
+			g.Expect(enqueueKafkaStatusWebhookEvent(webhookService, nil, kafka)).To(gomega.BeNil())
+			g.Expect(len(webhookService.EnqueueCalls()) == 1).To(gomega.Equal(tt.wantEventType != ""))
+		})
+	}
+}
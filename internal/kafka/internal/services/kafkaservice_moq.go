@@ -12,7 +12,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	managedkafka "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api/managedkafkas.managedkafka.bf2.org/v1"
 	apiErrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
-	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
 	"sync"
 )
 
@@ -71,12 +71,15 @@ var _ KafkaService = &KafkaServiceMock{}
 //			IsQuotaEntitlementActiveFunc: func(kafkaRequest *dbapi.KafkaRequest) (bool, error) {
 //				panic("mock out the IsQuotaEntitlementActive method")
 //			},
-//			ListFunc: func(ctx context.Context, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *apiErrors.ServiceError) {
+//			ListFunc: func(ctx context.Context, listArgs *coreServices.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *apiErrors.ServiceError) {
 //				panic("mock out the List method")
 //			},
 //			ListAllFunc: func() (dbapi.KafkaList, *apiErrors.ServiceError) {
 //				panic("mock out the ListAll method")
 //			},
+//			ListByClusterIDFunc: func(clusterID string) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+//				panic("mock out the ListByClusterID method")
+//			},
 //			ListByStatusFunc: func(status ...constants.KafkaStatus) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
 //				panic("mock out the ListByStatus method")
 //			},
@@ -110,6 +113,9 @@ var _ KafkaService = &KafkaServiceMock{}
 //			UpdatesFunc: func(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *apiErrors.ServiceError {
 //				panic("mock out the Updates method")
 //			},
+//			UpdatesIfStatusInFunc: func(kafkaRequest *dbapi.KafkaRequest, statuses []string, values map[string]interface{}) (bool, *apiErrors.ServiceError) {
+//				panic("mock out the UpdatesIfStatusIn method")
+//			},
 //			ValidateBillingAccountFunc: func(externalId string, instanceType kafkaTypes.KafkaInstanceType, kafkaBillingModelID string, billingCloudAccountId string, marketplace *string) *apiErrors.ServiceError {
 //				panic("mock out the ValidateBillingAccount method")
 //			},
@@ -169,11 +175,14 @@ type KafkaServiceMock struct {
 	IsQuotaEntitlementActiveFunc func(kafkaRequest *dbapi.KafkaRequest) (bool, error)
 
 	// ListFunc mocks the List method.
-	ListFunc func(ctx context.Context, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *apiErrors.ServiceError)
+	ListFunc func(ctx context.Context, listArgs *coreServices.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *apiErrors.ServiceError)
 
 	// ListAllFunc mocks the ListAll method.
 	ListAllFunc func() (dbapi.KafkaList, *apiErrors.ServiceError)
 
+	// ListByClusterIDFunc mocks the ListByClusterID method.
+	ListByClusterIDFunc func(clusterID string) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError)
+
 	// ListByStatusFunc mocks the ListByStatus method.
 	ListByStatusFunc func(status ...constants.KafkaStatus) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError)
 
@@ -207,6 +216,9 @@ type KafkaServiceMock struct {
 	// UpdatesFunc mocks the Updates method.
 	UpdatesFunc func(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *apiErrors.ServiceError
 
+	// UpdatesIfStatusInFunc mocks the UpdatesIfStatusIn method.
+	UpdatesIfStatusInFunc func(kafkaRequest *dbapi.KafkaRequest, statuses []string, values map[string]interface{}) (bool, *apiErrors.ServiceError)
+
 	// ValidateBillingAccountFunc mocks the ValidateBillingAccount method.
 	ValidateBillingAccountFunc func(externalId string, instanceType kafkaTypes.KafkaInstanceType, kafkaBillingModelID string, billingCloudAccountId string, marketplace *string) *apiErrors.ServiceError
 
@@ -299,11 +311,16 @@ type KafkaServiceMock struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// ListArgs is the listArgs argument value.
-			ListArgs *services.ListArguments
+			ListArgs *coreServices.ListArguments
 		}
 		// ListAll holds details about calls to the ListAll method.
 		ListAll []struct {
 		}
+		// ListByClusterID holds details about calls to the ListByClusterID method.
+		ListByClusterID []struct {
+			// ClusterID is the clusterID argument value.
+			ClusterID string
+		}
 		// ListByStatus holds details about calls to the ListByStatus method.
 		ListByStatus []struct {
 			// Status is the status argument value.
@@ -359,6 +376,15 @@ type KafkaServiceMock struct {
 			// Values is the values argument value.
 			Values map[string]interface{}
 		}
+		// UpdatesIfStatusIn holds details about calls to the UpdatesIfStatusIn method.
+		UpdatesIfStatusIn []struct {
+			// KafkaRequest is the kafkaRequest argument value.
+			KafkaRequest *dbapi.KafkaRequest
+			// Statuses is the statuses argument value.
+			Statuses []string
+			// Values is the values argument value.
+			Values map[string]interface{}
+		}
 		// ValidateBillingAccount holds details about calls to the ValidateBillingAccount method.
 		ValidateBillingAccount []struct {
 			// ExternalId is the externalId argument value.
@@ -397,6 +423,7 @@ type KafkaServiceMock struct {
 	lockIsQuotaEntitlementActive                 sync.RWMutex
 	lockList                                     sync.RWMutex
 	lockListAll                                  sync.RWMutex
+	lockListByClusterID                          sync.RWMutex
 	lockListByStatus                             sync.RWMutex
 	lockListComponentVersions                    sync.RWMutex
 	lockListKafkasToBePromoted                   sync.RWMutex
@@ -408,6 +435,7 @@ type KafkaServiceMock struct {
 	lockUpdate                                   sync.RWMutex
 	lockUpdateStatus                             sync.RWMutex
 	lockUpdates                                  sync.RWMutex
+	lockUpdatesIfStatusIn                        sync.RWMutex
 	lockValidateBillingAccount                   sync.RWMutex
 	lockVerifyAndUpdateKafkaAdmin                sync.RWMutex
 }
@@ -900,13 +928,13 @@ func (mock *KafkaServiceMock) IsQuotaEntitlementActiveCalls() []struct {
 }
 
 // List calls ListFunc.
-func (mock *KafkaServiceMock) List(ctx context.Context, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *apiErrors.ServiceError) {
+func (mock *KafkaServiceMock) List(ctx context.Context, listArgs *coreServices.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *apiErrors.ServiceError) {
 	if mock.ListFunc == nil {
 		panic("KafkaServiceMock.ListFunc: method is nil but KafkaService.List was just called")
 	}
 	callInfo := struct {
 		Ctx      context.Context
-		ListArgs *services.ListArguments
+		ListArgs *coreServices.ListArguments
 	}{
 		Ctx:      ctx,
 		ListArgs: listArgs,
@@ -923,11 +951,11 @@ func (mock *KafkaServiceMock) List(ctx context.Context, listArgs *services.ListA
 //	len(mockedKafkaService.ListCalls())
 func (mock *KafkaServiceMock) ListCalls() []struct {
 	Ctx      context.Context
-	ListArgs *services.ListArguments
+	ListArgs *coreServices.ListArguments
 } {
 	var calls []struct {
 		Ctx      context.Context
-		ListArgs *services.ListArguments
+		ListArgs *coreServices.ListArguments
 	}
 	mock.lockList.RLock()
 	calls = mock.calls.List
@@ -962,6 +990,38 @@ func (mock *KafkaServiceMock) ListAllCalls() []struct {
 	return calls
 }
 
+// ListByClusterID calls ListByClusterIDFunc.
+func (mock *KafkaServiceMock) ListByClusterID(clusterID string) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+	if mock.ListByClusterIDFunc == nil {
+		panic("KafkaServiceMock.ListByClusterIDFunc: method is nil but KafkaService.ListByClusterID was just called")
+	}
+	callInfo := struct {
+		ClusterID string
+	}{
+		ClusterID: clusterID,
+	}
+	mock.lockListByClusterID.Lock()
+	mock.calls.ListByClusterID = append(mock.calls.ListByClusterID, callInfo)
+	mock.lockListByClusterID.Unlock()
+	return mock.ListByClusterIDFunc(clusterID)
+}
+
+// ListByClusterIDCalls gets all the calls that were made to ListByClusterID.
+// Check the length with:
+//
+//	len(mockedKafkaService.ListByClusterIDCalls())
+func (mock *KafkaServiceMock) ListByClusterIDCalls() []struct {
+	ClusterID string
+} {
+	var calls []struct {
+		ClusterID string
+	}
+	mock.lockListByClusterID.RLock()
+	calls = mock.calls.ListByClusterID
+	mock.lockListByClusterID.RUnlock()
+	return calls
+}
+
 // ListByStatus calls ListByStatusFunc.
 func (mock *KafkaServiceMock) ListByStatus(status ...constants.KafkaStatus) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
 	if mock.ListByStatusFunc == nil {
@@ -1311,6 +1371,46 @@ func (mock *KafkaServiceMock) UpdatesCalls() []struct {
 	return calls
 }
 
+// UpdatesIfStatusIn calls UpdatesIfStatusInFunc.
+func (mock *KafkaServiceMock) UpdatesIfStatusIn(kafkaRequest *dbapi.KafkaRequest, statuses []string, values map[string]interface{}) (bool, *apiErrors.ServiceError) {
+	if mock.UpdatesIfStatusInFunc == nil {
+		panic("KafkaServiceMock.UpdatesIfStatusInFunc: method is nil but KafkaService.UpdatesIfStatusIn was just called")
+	}
+	callInfo := struct {
+		KafkaRequest *dbapi.KafkaRequest
+		Statuses     []string
+		Values       map[string]interface{}
+	}{
+		KafkaRequest: kafkaRequest,
+		Statuses:     statuses,
+		Values:       values,
+	}
+	mock.lockUpdatesIfStatusIn.Lock()
+	mock.calls.UpdatesIfStatusIn = append(mock.calls.UpdatesIfStatusIn, callInfo)
+	mock.lockUpdatesIfStatusIn.Unlock()
+	return mock.UpdatesIfStatusInFunc(kafkaRequest, statuses, values)
+}
+
+// UpdatesIfStatusInCalls gets all the calls that were made to UpdatesIfStatusIn.
+// Check the length with:
+//
+//	len(mockedKafkaService.UpdatesIfStatusInCalls())
+func (mock *KafkaServiceMock) UpdatesIfStatusInCalls() []struct {
+	KafkaRequest *dbapi.KafkaRequest
+	Statuses     []string
+	Values       map[string]interface{}
+} {
+	var calls []struct {
+		KafkaRequest *dbapi.KafkaRequest
+		Statuses     []string
+		Values       map[string]interface{}
+	}
+	mock.lockUpdatesIfStatusIn.RLock()
+	calls = mock.calls.UpdatesIfStatusIn
+	mock.lockUpdatesIfStatusIn.RUnlock()
+	return calls
+}
+
 // ValidateBillingAccount calls ValidateBillingAccountFunc.
 func (mock *KafkaServiceMock) ValidateBillingAccount(externalId string, instanceType kafkaTypes.KafkaInstanceType, kafkaBillingModelID string, billingCloudAccountId string, marketplace *string) *apiErrors.ServiceError {
 	if mock.ValidateBillingAccountFunc == nil {
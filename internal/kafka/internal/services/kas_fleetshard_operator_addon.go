@@ -154,7 +154,7 @@ func (o *kasFleetshardOperatorAddon) buildAddonParams(cluster *api.Cluster, serv
 
 	if cluster.ClientID != "" && cluster.ClientSecret != "" {
 		clientId = cluster.ClientID
-		clientSecret = cluster.ClientSecret
+		clientSecret = string(cluster.ClientSecret)
 	} else {
 		clientId = serviceAccount.ClientID
 		clientSecret = serviceAccount.ClientSecret
@@ -0,0 +1,306 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/golang/glog"
+)
+
+// DataPlaneClusterScaleUpProjector evaluates the dynamic scale up of the data plane clusters
+// against the capacity served by the given ClusterService, registering the clusters that would be created in it
+//
+//go:generate moq -out data_plane_cluster_scale_up_projector_moq.go . DataPlaneClusterScaleUpProjector
+type DataPlaneClusterScaleUpProjector interface {
+	ProjectScaleUp(clusterService ClusterService) error
+}
+
+// PlacementSimulationKafka is a hypothetical kafka to be placed during a placement simulation
+type PlacementSimulationKafka struct {
+	CloudProvider string
+	Region        string
+	MultiAZ       bool
+	InstanceType  string
+	SizeId        string
+}
+
+// PlacementSimulationPlacement is the outcome of the placement of a hypothetical kafka
+type PlacementSimulationPlacement struct {
+	Kafka  PlacementSimulationKafka
+	Placed bool
+	// ClusterID is the ID of the cluster the kafka would be placed in. It is empty if the kafka could not be placed.
+	ClusterID string
+	// OnProjectedCluster indicates whether the kafka would be placed in a cluster that the dynamic scaling would create
+	OnProjectedCluster bool
+	Reason             string
+	// AvailableSizes are the sizes of the instance type that could be created in the region when the kafka was placed
+	AvailableSizes []string
+	// Decision is the placement decision of the placement strategy. It is nil if the placement strategy was not run.
+	Decision *dbapi.KafkaPlacementDecision
+}
+
+// PlacementSimulationScaleUp is a data plane cluster that the dynamic scaling would create
+type PlacementSimulationScaleUp struct {
+	ClusterID     string
+	CloudProvider string
+	Region        string
+	MultiAZ       bool
+	InstanceType  string
+	// EstimatedMaxStreamingUnits is the capacity of the cluster, estimated from the biggest ready cluster of the same
+	// cloud provider, region and instance type. It is 0 if there is no such cluster.
+	EstimatedMaxStreamingUnits int
+}
+
+type PlacementSimulationResult struct {
+	Placements        []PlacementSimulationPlacement
+	ProjectedScaleUps []PlacementSimulationScaleUp
+}
+
+//go:generate moq -out placement_simulation_service_moq.go . PlacementSimulationService
+type PlacementSimulationService interface {
+	// Simulate places the given hypothetical kafkas, in order, using the configured placement strategy, the sizes
+	// availability and the dynamic scaling logic against a snapshot of the current capacity of the data plane clusters.
+	// Each placed kafka consumes capacity from the snapshot, so that the following kafkas see it.
+	// Nothing is persisted and no data plane cluster is created.
+	Simulate(kafkas []PlacementSimulationKafka) (*PlacementSimulationResult, *errors.ServiceError)
+}
+
+var _ PlacementSimulationService = &placementSimulationService{}
+
+type placementSimulationService struct {
+	connectionFactory      *db.ConnectionFactory
+	clusterService         ClusterService
+	kafkaConfig            *config.KafkaConfig
+	dataplaneClusterConfig *config.DataplaneClusterConfig
+	providerConfig         *config.ProviderConfig
+	scaleUpProjector       DataPlaneClusterScaleUpProjector
+}
+
+func NewPlacementSimulationService(connectionFactory *db.ConnectionFactory, clusterService ClusterService,
+	kafkaConfig *config.KafkaConfig, dataplaneClusterConfig *config.DataplaneClusterConfig,
+	providerConfig *config.ProviderConfig, scaleUpProjector DataPlaneClusterScaleUpProjector) PlacementSimulationService {
+	return &placementSimulationService{
+		connectionFactory:      connectionFactory,
+		clusterService:         clusterService,
+		kafkaConfig:            kafkaConfig,
+		dataplaneClusterConfig: dataplaneClusterConfig,
+		providerConfig:         providerConfig,
+		scaleUpProjector:       scaleUpProjector,
+	}
+}
+
+// placementSimulation holds the state of a single simulation
+type placementSimulation struct {
+	*placementSimulationService
+
+	snapshot *placementSnapshotClusterService
+	// kafkaService is a kafka service reading the capacity of the data plane clusters from the snapshot.
+	// Only its read operations must be used.
+	kafkaService *kafkaService
+	strategy     ClusterPlacementStrategy
+	// simulatedStreamingUnits are the streaming units consumed by the simulated kafkas per cloud provider, region and instance type
+	simulatedStreamingUnits map[string]int
+}
+
+func (s *placementSimulationService) Simulate(kafkas []PlacementSimulationKafka) (*PlacementSimulationResult, *errors.ServiceError) {
+	for i, kafka := range kafkas {
+		if err := s.validate(kafka); err != nil {
+			return nil, errors.New(err.Code, "invalid kafka at index %d: %s", i, err.Reason)
+		}
+	}
+
+	snapshot, err := newPlacementSnapshotClusterService(s.clusterService)
+	if err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to take a snapshot of the data plane clusters capacity")
+	}
+
+	strategy := NewClusterPlacementStrategy(snapshot, s.dataplaneClusterConfig, s.kafkaConfig)
+	simulation := &placementSimulation{
+		placementSimulationService: s,
+		snapshot:                   snapshot,
+		strategy:                   strategy,
+		kafkaService: &kafkaService{
+			connectionFactory:        s.connectionFactory,
+			clusterService:           snapshot,
+			kafkaConfig:              s.kafkaConfig,
+			dataplaneClusterConfig:   s.dataplaneClusterConfig,
+			providerConfig:           s.providerConfig,
+			clusterPlacementStrategy: strategy,
+		},
+		simulatedStreamingUnits: map[string]int{},
+	}
+
+	result := &PlacementSimulationResult{
+		Placements:        make([]PlacementSimulationPlacement, 0, len(kafkas)),
+		ProjectedScaleUps: []PlacementSimulationScaleUp{},
+	}
+
+	for i, kafka := range kafkas {
+		placement, svcErr := simulation.place(i, kafka)
+		if svcErr != nil {
+			return nil, svcErr
+		}
+		result.Placements = append(result.Placements, *placement)
+	}
+
+	// the capacity consumed by the simulated kafkas may require a scale up to restore the capacity slack
+	if err := simulation.projectScaleUp(); err != nil {
+		return nil, err
+	}
+
+	for _, cluster := range snapshot.projectedClusters {
+		result.ProjectedScaleUps = append(result.ProjectedScaleUps, PlacementSimulationScaleUp{
+			ClusterID:                  cluster.ClusterID,
+			CloudProvider:              cluster.CloudProvider,
+			Region:                     cluster.Region,
+			MultiAZ:                    cluster.MultiAZ,
+			InstanceType:               cluster.SupportedInstanceType,
+			EstimatedMaxStreamingUnits: int(cluster.RetrieveDynamicCapacityInfo()[cluster.SupportedInstanceType].MaxUnits),
+		})
+	}
+
+	return result, nil
+}
+
+func (s *placementSimulationService) validate(kafka PlacementSimulationKafka) *errors.ServiceError {
+	if _, err := s.providerConfig.GetInstanceLimit(kafka.Region, kafka.CloudProvider, kafka.InstanceType); err != nil {
+		return err
+	}
+
+	if _, err := s.kafkaConfig.GetKafkaInstanceSize(kafka.InstanceType, kafka.SizeId); err != nil {
+		return errors.InstancePlanNotSupported(err.Error())
+	}
+
+	return nil
+}
+
+func (s *placementSimulation) place(index int, simulatedKafka PlacementSimulationKafka) (*PlacementSimulationPlacement, *errors.ServiceError) {
+	placement := &PlacementSimulationPlacement{Kafka: simulatedKafka}
+
+	kafka := &dbapi.KafkaRequest{
+		Meta:          api.Meta{ID: fmt.Sprintf("simulated-kafka-%d", index)},
+		CloudProvider: simulatedKafka.CloudProvider,
+		Region:        simulatedKafka.Region,
+		MultiAZ:       simulatedKafka.MultiAZ,
+		InstanceType:  simulatedKafka.InstanceType,
+		SizeId:        simulatedKafka.SizeId,
+	}
+
+	availableSizes, err := s.kafkaService.GetAvailableSizesInRegion(&FindClusterCriteria{
+		Provider:              kafka.CloudProvider,
+		Region:                kafka.Region,
+		MultiAZ:               kafka.MultiAZ,
+		SupportedInstanceType: kafka.InstanceType,
+	})
+	if err != nil {
+		return nil, errors.NewWithCause(err.Code, err, "failed to get available sizes in region %q of cloud provider %q for simulated kafka %d", kafka.Region, kafka.CloudProvider, index)
+	}
+	placement.AvailableSizes = availableSizes
+
+	hasCapacity, err := s.hasAvailableCapacityInRegion(kafka)
+	if err != nil {
+		return nil, err
+	}
+	if !hasCapacity {
+		placement.Reason = "region limit of the instance type has been reached"
+		return placement, nil
+	}
+
+	cluster, findErr := s.strategy.FindCluster(kafka)
+	if findErr != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, findErr, "failed to find a data plane cluster for simulated kafka %d", index)
+	}
+
+	if cluster == nil && s.dataplaneClusterConfig.IsDataPlaneAutoScalingEnabled() {
+		glog.V(10).Infof("no data plane cluster found for simulated kafka %d, projecting dynamic scale up", index)
+		if err := s.projectScaleUp(); err != nil {
+			return nil, err
+		}
+
+		cluster, findErr = s.strategy.FindCluster(kafka)
+		if findErr != nil {
+			return nil, errors.NewWithCause(errors.ErrorGeneral, findErr, "failed to find a data plane cluster for simulated kafka %d", index)
+		}
+	}
+
+	decision, decisionErr := kafka.GetPlacementDecision()
+	if decisionErr != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, decisionErr, "failed to get placement decision of simulated kafka %d", index)
+	}
+	placement.Decision = decision
+
+	if cluster == nil {
+		placement.Reason = "no data plane cluster can receive the kafka"
+		if decision != nil {
+			placement.Reason = decision.Reason
+		}
+		return placement, nil
+	}
+
+	streamingUnits, sizeErr := s.streamingUnits(kafka)
+	if sizeErr != nil {
+		return nil, sizeErr
+	}
+
+	s.snapshot.place(kafka, cluster.ClusterID, streamingUnits)
+	s.simulatedStreamingUnits[s.locatorKey(kafka)] += streamingUnits
+
+	placement.Placed = true
+	placement.ClusterID = cluster.ClusterID
+	placement.OnProjectedCluster = s.snapshot.isProjectedCluster(cluster.ClusterID)
+	placement.Reason = "placed by the placement strategy"
+	if decision != nil {
+		placement.Reason = decision.Reason
+	}
+
+	return placement, nil
+}
+
+// hasAvailableCapacityInRegion checks the region limit of the instance type of the kafka,
+// taking into account the streaming units consumed by the kafkas simulated so far
+func (s *placementSimulation) hasAvailableCapacityInRegion(kafka *dbapi.KafkaRequest) (bool, *errors.ServiceError) {
+	limit, err := s.providerConfig.GetInstanceLimit(kafka.Region, kafka.CloudProvider, kafka.InstanceType)
+	if err != nil {
+		return false, err
+	}
+
+	if limit == nil {
+		return s.kafkaService.HasAvailableCapacityInRegion(kafka)
+	}
+
+	remainingLimit := *limit - s.simulatedStreamingUnits[s.locatorKey(kafka)]
+	if remainingLimit <= 0 {
+		return false, nil
+	}
+
+	return s.kafkaService.capacityAvailableForRegionAndInstanceType(&remainingLimit, kafka)
+}
+
+func (s *placementSimulation) projectScaleUp() *errors.ServiceError {
+	if !s.dataplaneClusterConfig.IsDataPlaneAutoScalingEnabled() {
+		return nil
+	}
+
+	if err := s.scaleUpProjector.ProjectScaleUp(s.snapshot); err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to project the dynamic scale up of the data plane clusters")
+	}
+
+	return nil
+}
+
+func (s *placementSimulation) streamingUnits(kafka *dbapi.KafkaRequest) (int, *errors.ServiceError) {
+	size, err := s.kafkaConfig.GetKafkaInstanceSize(kafka.InstanceType, kafka.SizeId)
+	if err != nil {
+		return 0, errors.NewWithCause(errors.ErrorInstancePlanNotSupported, err, "failed to get size %q of instance type %q", kafka.SizeId, kafka.InstanceType)
+	}
+
+	return size.CapacityConsumed, nil
+}
+
+func (s *placementSimulation) locatorKey(kafka *dbapi.KafkaRequest) string {
+	return fmt.Sprintf("%s/%s/%s", kafka.CloudProvider, kafka.Region, kafka.InstanceType)
+}
@@ -0,0 +1,75 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package services
+
+import (
+	apiErrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"sync"
+)
+
+// Ensure, that PlacementSimulationServiceMock does implement PlacementSimulationService.
+// If this is not the case, regenerate this file with moq.
+var _ PlacementSimulationService = &PlacementSimulationServiceMock{}
+
+// PlacementSimulationServiceMock is a mock implementation of PlacementSimulationService.
+//
+//	func TestSomethingThatUsesPlacementSimulationService(t *testing.T) {
+//
+//		// make and configure a mocked PlacementSimulationService
+//		mockedPlacementSimulationService := &PlacementSimulationServiceMock{
+//			SimulateFunc: func(kafkas []PlacementSimulationKafka) (*PlacementSimulationResult, *apiErrors.ServiceError) {
+//				panic("mock out the Simulate method")
+//			},
+//		}
+//
+//		// use mockedPlacementSimulationService in code that requires PlacementSimulationService
+//		// and then make assertions.
+//
+//	}
+type PlacementSimulationServiceMock struct {
+	// SimulateFunc mocks the Simulate method.
+	SimulateFunc func(kafkas []PlacementSimulationKafka) (*PlacementSimulationResult, *apiErrors.ServiceError)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Simulate holds details about calls to the Simulate method.
+		Simulate []struct {
+			// Kafkas is the kafkas argument value.
+			Kafkas []PlacementSimulationKafka
+		}
+	}
+	lockSimulate sync.RWMutex
+}
+
+// Simulate calls SimulateFunc.
+func (mock *PlacementSimulationServiceMock) Simulate(kafkas []PlacementSimulationKafka) (*PlacementSimulationResult, *apiErrors.ServiceError) {
+	if mock.SimulateFunc == nil {
+		panic("PlacementSimulationServiceMock.SimulateFunc: method is nil but PlacementSimulationService.Simulate was just called")
+	}
+	callInfo := struct {
+		Kafkas []PlacementSimulationKafka
+	}{
+		Kafkas: kafkas,
+	}
+	mock.lockSimulate.Lock()
+	mock.calls.Simulate = append(mock.calls.Simulate, callInfo)
+	mock.lockSimulate.Unlock()
+	return mock.SimulateFunc(kafkas)
+}
+
+// SimulateCalls gets all the calls that were made to Simulate.
+// Check the length with:
+//
+//	len(mockedPlacementSimulationService.SimulateCalls())
+func (mock *PlacementSimulationServiceMock) SimulateCalls() []struct {
+	Kafkas []PlacementSimulationKafka
+} {
+	var calls []struct {
+		Kafkas []PlacementSimulationKafka
+	}
+	mock.lockSimulate.RLock()
+	calls = mock.calls.Simulate
+	mock.lockSimulate.RUnlock()
+	return calls
+}
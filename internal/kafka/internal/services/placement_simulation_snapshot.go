@@ -0,0 +1,205 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	apiErrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+	"github.com/pkg/errors"
+)
+
+// projectedClusterIDPrefix is the prefix of the ID given to the clusters that the dynamic scaling would create during a simulation
+const projectedClusterIDPrefix = "projected-cluster-"
+
+// placementSnapshotClusterService is a ClusterService serving the capacity of the data plane clusters from an in-memory
+// snapshot taken at the beginning of a placement simulation. The simulated kafkas placed in the snapshot and the clusters
+// registered by the dynamic scale up evaluation are only kept in memory.
+// Only the methods used by the placement strategies and the dynamic scale up evaluation are served from the snapshot,
+// the other methods are delegated to the underlying ClusterService and must not be used during a simulation.
+type placementSnapshotClusterService struct {
+	ClusterService
+
+	clusters                 []*api.Cluster
+	streamingUnitCounts      KafkaStreamingUnitCountPerClusterList
+	streamingUnitsPerCluster map[string]int
+	kafkaCountPerCluster     map[string]int
+	projectedClusters        []*api.Cluster
+}
+
+var _ ClusterService = &placementSnapshotClusterService{}
+
+// newPlacementSnapshotClusterService takes a snapshot of the data plane clusters and of their consumed capacity
+func newPlacementSnapshotClusterService(clusterService ClusterService) (*placementSnapshotClusterService, error) {
+	clusters, err := clusterService.FindAllClusters(FindClusterCriteria{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list data plane clusters")
+	}
+
+	streamingUnitCounts, err := clusterService.FindStreamingUnitCountByClusterAndInstanceType()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get count of streaming units by cluster and instance type")
+	}
+
+	instanceCounts, err := clusterService.FindKafkaInstanceCount(nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get count of streaming units by cluster")
+	}
+
+	streamingUnitsPerCluster := map[string]int{}
+	for _, instanceCount := range instanceCounts {
+		streamingUnitsPerCluster[instanceCount.ClusterID] = instanceCount.Count
+	}
+
+	clusterIDs := make([]string, 0, len(clusters))
+	for _, cluster := range clusters {
+		clusterIDs = append(clusterIDs, cluster.ClusterID)
+	}
+
+	kafkaCountPerCluster := map[string]int{}
+	if len(clusterIDs) > 0 {
+		counts, svcErr := clusterService.CountKafkasPerCluster(clusterIDs)
+		if svcErr != nil {
+			return nil, errors.Wrap(svcErr, "failed to count kafkas per cluster")
+		}
+		kafkaCountPerCluster = counts
+	}
+
+	return &placementSnapshotClusterService{
+		ClusterService:           clusterService,
+		clusters:                 clusters,
+		streamingUnitCounts:      streamingUnitCounts,
+		streamingUnitsPerCluster: streamingUnitsPerCluster,
+		kafkaCountPerCluster:     kafkaCountPerCluster,
+	}, nil
+}
+
+// FindAllClusters returns the clusters of the snapshot matching the criteria, in the same way the database query does:
+// zero values of the criteria are not used as filters.
+func (s *placementSnapshotClusterService) FindAllClusters(criteria FindClusterCriteria) ([]*api.Cluster, error) {
+	clusters := []*api.Cluster{}
+	for _, cluster := range s.clusters {
+		if s.matches(cluster, criteria) {
+			clusters = append(clusters, cluster)
+		}
+	}
+	return clusters, nil
+}
+
+func (s *placementSnapshotClusterService) FindCluster(criteria FindClusterCriteria) (*api.Cluster, error) {
+	clusters, _ := s.FindAllClusters(criteria)
+	if len(clusters) == 0 {
+		return nil, nil
+	}
+	return clusters[0], nil
+}
+
+func (s *placementSnapshotClusterService) FindClusterByID(clusterID string) (*api.Cluster, *apiErrors.ServiceError) {
+	_, cluster := arrays.FindFirst(s.clusters, func(c *api.Cluster) bool { return c.ClusterID == clusterID })
+	return cluster, nil
+}
+
+func (s *placementSnapshotClusterService) FindStreamingUnitCountByClusterAndInstanceType() (KafkaStreamingUnitCountPerClusterList, error) {
+	streamingUnitCounts := make(KafkaStreamingUnitCountPerClusterList, len(s.streamingUnitCounts))
+	copy(streamingUnitCounts, s.streamingUnitCounts)
+	return streamingUnitCounts, nil
+}
+
+func (s *placementSnapshotClusterService) FindKafkaInstanceCount(clusterIDs []string) ([]ResKafkaInstanceCount, error) {
+	res := []ResKafkaInstanceCount{}
+	for _, clusterID := range clusterIDs {
+		res = append(res, ResKafkaInstanceCount{ClusterID: clusterID, Count: s.streamingUnitsPerCluster[clusterID]})
+	}
+	return res, nil
+}
+
+func (s *placementSnapshotClusterService) CountKafkasPerCluster(clusterIDs []string) (map[string]int, *apiErrors.ServiceError) {
+	counts := map[string]int{}
+	for _, clusterID := range clusterIDs {
+		counts[clusterID] = s.kafkaCountPerCluster[clusterID]
+	}
+	return counts, nil
+}
+
+// RegisterClusterJob adds the cluster that the dynamic scaling would create to the snapshot.
+// The cluster is assumed to become ready with the same capacity as the biggest ready cluster of the same provider,
+// region and instance type. If there is no such cluster, its capacity cannot be estimated and the cluster is kept
+// in accepted state so that it cannot receive kafkas.
+func (s *placementSnapshotClusterService) RegisterClusterJob(clusterRequest *api.Cluster) *apiErrors.ServiceError {
+	cluster := *clusterRequest
+	cluster.ClusterID = fmt.Sprintf("%s%d", projectedClusterIDPrefix, len(s.projectedClusters)+1)
+	cluster.CreatedAt = time.Now()
+
+	estimatedMaxUnits, reference := s.estimateCapacityOfNewCluster(cluster.CloudProvider, cluster.Region, cluster.SupportedInstanceType)
+	if reference != nil {
+		cluster.Status = api.ClusterReady
+		cluster.AvailableStrimziVersions = reference.AvailableStrimziVersions
+		if err := cluster.SetDynamicCapacityInfo(map[string]api.DynamicCapacityInfo{
+			cluster.SupportedInstanceType: {MaxUnits: estimatedMaxUnits, RemainingUnits: estimatedMaxUnits},
+		}); err != nil {
+			return apiErrors.NewWithCause(apiErrors.ErrorGeneral, err, "failed to set the capacity of the projected cluster")
+		}
+	}
+
+	s.clusters = append(s.clusters, &cluster)
+	s.projectedClusters = append(s.projectedClusters, &cluster)
+	s.streamingUnitCounts = append(s.streamingUnitCounts, KafkaStreamingUnitCountPerCluster{
+		Region:        cluster.Region,
+		InstanceType:  cluster.SupportedInstanceType,
+		ClusterId:     cluster.ClusterID,
+		CloudProvider: cluster.CloudProvider,
+		MaxUnits:      estimatedMaxUnits,
+		Status:        cluster.Status.String(),
+		ClusterType:   cluster.ClusterType,
+	})
+
+	return nil
+}
+
+// place accounts for the capacity consumed by the kafka in the given cluster of the snapshot
+func (s *placementSnapshotClusterService) place(kafka *dbapi.KafkaRequest, clusterID string, streamingUnits int) {
+	s.streamingUnitsPerCluster[clusterID] += streamingUnits
+	s.kafkaCountPerCluster[clusterID]++
+	for i, count := range s.streamingUnitCounts {
+		if count.ClusterId == clusterID && count.InstanceType == kafka.InstanceType {
+			s.streamingUnitCounts[i].Count += int32(streamingUnits)
+			break
+		}
+	}
+}
+
+func (s *placementSnapshotClusterService) isProjectedCluster(clusterID string) bool {
+	_, cluster := arrays.FindFirst(s.projectedClusters, func(c *api.Cluster) bool { return c.ClusterID == clusterID })
+	return cluster != nil
+}
+
+func (s *placementSnapshotClusterService) estimateCapacityOfNewCluster(provider, region, instanceType string) (int32, *api.Cluster) {
+	var maxUnits int32
+	var reference *api.Cluster
+	for _, cluster := range s.clusters {
+		if cluster.CloudProvider != provider || cluster.Region != region || cluster.Status != api.ClusterReady ||
+			cluster.ClusterType != api.ManagedDataPlaneClusterType.String() {
+			continue
+		}
+
+		capacity, ok := cluster.RetrieveDynamicCapacityInfo()[instanceType]
+		if ok && capacity.MaxUnits > maxUnits {
+			maxUnits = capacity.MaxUnits
+			reference = cluster
+		}
+	}
+
+	return maxUnits, reference
+}
+
+func (s *placementSnapshotClusterService) matches(cluster *api.Cluster, criteria FindClusterCriteria) bool {
+	return (criteria.Provider == "" || cluster.CloudProvider == criteria.Provider) &&
+		(criteria.Region == "" || cluster.Region == criteria.Region) &&
+		(!criteria.MultiAZ || cluster.MultiAZ) &&
+		(criteria.Status == "" || cluster.Status == criteria.Status) &&
+		(criteria.ExternalID == "" || cluster.ExternalID == criteria.ExternalID) &&
+		(criteria.SupportedInstanceType == "" || arrays.Contains(cluster.GetSupportedInstanceTypes(), criteria.SupportedInstanceType)) &&
+		(!criteria.ExcludeCordoned || !cluster.Cordoned)
+}
@@ -0,0 +1,212 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_placementSimulationService_Simulate(t *testing.T) {
+	type fields struct {
+		clusterService   ClusterService
+		providerConfig   *config.ProviderConfig
+		scaleUpProjector *DataPlaneClusterScaleUpProjectorMock
+	}
+
+	readyCluster := func(clusterID string, maxUnits int32) *api.Cluster {
+		cluster := &api.Cluster{
+			ClusterID:             clusterID,
+			CloudProvider:         testKafkaRequestProvider,
+			Region:                testKafkaRequestRegion,
+			MultiAZ:               true,
+			Status:                api.ClusterReady,
+			ClusterType:           api.ManagedDataPlaneClusterType.String(),
+			SupportedInstanceType: api.StandardTypeSupport.String(),
+		}
+		_ = cluster.SetDynamicCapacityInfo(map[string]api.DynamicCapacityInfo{
+			api.StandardTypeSupport.String(): {MaxUnits: maxUnits},
+		})
+		return cluster
+	}
+
+	buildClusterService := func(cluster *api.Cluster, consumedStreamingUnits int32) *ClusterServiceMock {
+		return &ClusterServiceMock{
+			FindAllClustersFunc: func(criteria FindClusterCriteria) ([]*api.Cluster, error) {
+				return []*api.Cluster{cluster}, nil
+			},
+			FindStreamingUnitCountByClusterAndInstanceTypeFunc: func() (KafkaStreamingUnitCountPerClusterList, error) {
+				return KafkaStreamingUnitCountPerClusterList{
+					{
+						Region:        cluster.Region,
+						InstanceType:  api.StandardTypeSupport.String(),
+						ClusterId:     cluster.ClusterID,
+						Count:         consumedStreamingUnits,
+						CloudProvider: cluster.CloudProvider,
+						MaxUnits:      cluster.RetrieveDynamicCapacityInfo()[api.StandardTypeSupport.String()].MaxUnits,
+						Status:        cluster.Status.String(),
+						ClusterType:   cluster.ClusterType,
+					},
+				}, nil
+			},
+			FindKafkaInstanceCountFunc: func(clusterIDs []string) ([]ResKafkaInstanceCount, error) {
+				return []ResKafkaInstanceCount{{ClusterID: cluster.ClusterID, Count: int(consumedStreamingUnits)}}, nil
+			},
+			CountKafkasPerClusterFunc: func(clusterIDs []string) (map[string]int, *errors.ServiceError) {
+				return map[string]int{cluster.ClusterID: int(consumedStreamingUnits)}, nil
+			},
+		}
+	}
+
+	// scaleUpProjectorRegisteringOneCluster registers a single standard cluster the first time the scale up is projected
+	scaleUpProjectorRegisteringOneCluster := func() *DataPlaneClusterScaleUpProjectorMock {
+		projected := false
+		return &DataPlaneClusterScaleUpProjectorMock{
+			ProjectScaleUpFunc: func(clusterService ClusterService) error {
+				if projected {
+					return nil
+				}
+				projected = true
+				if err := clusterService.RegisterClusterJob(&api.Cluster{
+					CloudProvider:         testKafkaRequestProvider,
+					Region:                testKafkaRequestRegion,
+					MultiAZ:               true,
+					SupportedInstanceType: api.StandardTypeSupport.String(),
+					Status:                api.ClusterAccepted,
+					ClusterType:           api.ManagedDataPlaneClusterType.String(),
+				}); err != nil {
+					return err
+				}
+				return nil
+			},
+		}
+	}
+
+	noopScaleUpProjector := func() *DataPlaneClusterScaleUpProjectorMock {
+		return &DataPlaneClusterScaleUpProjectorMock{
+			ProjectScaleUpFunc: func(clusterService ClusterService) error {
+				return nil
+			},
+		}
+	}
+
+	standardKafkas := func(count int, sizeID string) []PlacementSimulationKafka {
+		kafkas := []PlacementSimulationKafka{}
+		for i := 0; i < count; i++ {
+			kafkas = append(kafkas, PlacementSimulationKafka{
+				CloudProvider: testKafkaRequestProvider,
+				Region:        testKafkaRequestRegion,
+				MultiAZ:       true,
+				InstanceType:  api.StandardTypeSupport.String(),
+				SizeId:        sizeID,
+			})
+		}
+		return kafkas
+	}
+
+	tests := []struct {
+		name                   string
+		fields                 fields
+		kafkas                 []PlacementSimulationKafka
+		wantErr                *errors.ServiceError
+		wantClusterIDs         []string
+		wantReasons            []string
+		wantProjectedScaleUps  []PlacementSimulationScaleUp
+		wantScaleUpProjections int
+	}{
+		{
+			name: "should return an error if the size of a kafka is not supported",
+			fields: fields{
+				clusterService:   buildClusterService(readyCluster("cluster-1", 10), 0),
+				providerConfig:   buildProviderConfiguration(testKafkaRequestRegion, 0, 0, true),
+				scaleUpProjector: scaleUpProjectorRegisteringOneCluster(),
+			},
+			kafkas:  standardKafkas(1, "x9"),
+			wantErr: errors.InstancePlanNotSupported("size not supported"),
+		},
+		{
+			name: "should return an error if the snapshot of the clusters cannot be taken",
+			fields: fields{
+				clusterService: &ClusterServiceMock{
+					FindAllClustersFunc: func(criteria FindClusterCriteria) ([]*api.Cluster, error) {
+						return nil, fmt.Errorf("failed to list clusters")
+					},
+				},
+				providerConfig:   buildProviderConfiguration(testKafkaRequestRegion, 0, 0, true),
+				scaleUpProjector: scaleUpProjectorRegisteringOneCluster(),
+			},
+			kafkas:  standardKafkas(1, "x1"),
+			wantErr: errors.GeneralError("failed to take snapshot"),
+		},
+		{
+			name: "should place the kafkas in the clusters projected by the dynamic scaling once existing clusters are full",
+			fields: fields{
+				clusterService:   buildClusterService(readyCluster("cluster-1", 2), 1),
+				providerConfig:   buildProviderConfiguration(testKafkaRequestRegion, 0, 0, true),
+				scaleUpProjector: scaleUpProjectorRegisteringOneCluster(),
+			},
+			kafkas:         standardKafkas(3, "x1"),
+			wantClusterIDs: []string{"cluster-1", "projected-cluster-1", "projected-cluster-1"},
+			wantProjectedScaleUps: []PlacementSimulationScaleUp{
+				{
+					ClusterID:                  "projected-cluster-1",
+					CloudProvider:              testKafkaRequestProvider,
+					Region:                     testKafkaRequestRegion,
+					MultiAZ:                    true,
+					InstanceType:               api.StandardTypeSupport.String(),
+					EstimatedMaxStreamingUnits: 2,
+				},
+			},
+			wantScaleUpProjections: 2,
+		},
+		{
+			name: "should not place the kafkas exceeding the region limit taking into account the previously simulated kafkas",
+			fields: fields{
+				clusterService:   buildClusterService(readyCluster("cluster-1", 10), 0),
+				providerConfig:   buildProviderConfiguration(testKafkaRequestRegion, 2, 0, false),
+				scaleUpProjector: noopScaleUpProjector(),
+			},
+			kafkas:                 standardKafkas(3, "x1"),
+			wantClusterIDs:         []string{"cluster-1", "cluster-1", ""},
+			wantReasons:            []string{"", "", "region limit of the instance type has been reached"},
+			wantProjectedScaleUps:  []PlacementSimulationScaleUp{},
+			wantScaleUpProjections: 1,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			s := NewPlacementSimulationService(db.NewMockConnectionFactory(nil), tt.fields.clusterService, &defaultKafkaConf,
+				buildDataplaneClusterConfigWithAutoscalingOn(), tt.fields.providerConfig, tt.fields.scaleUpProjector)
+
+			got, err := s.Simulate(tt.kafkas)
+			if tt.wantErr != nil {
+				g.Expect(err).To(gomega.HaveOccurred())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErr.Code))
+				return
+			}
+
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(got.Placements).To(gomega.HaveLen(len(tt.kafkas)))
+			for i, placement := range got.Placements {
+				g.Expect(placement.ClusterID).To(gomega.Equal(tt.wantClusterIDs[i]))
+				g.Expect(placement.Placed).To(gomega.Equal(tt.wantClusterIDs[i] != ""))
+				g.Expect(placement.OnProjectedCluster).To(gomega.Equal(tt.wantClusterIDs[i] == "projected-cluster-1"))
+				g.Expect(placement.AvailableSizes).To(gomega.Equal([]string{"x1"}))
+				if tt.wantReasons != nil && tt.wantReasons[i] != "" {
+					g.Expect(placement.Reason).To(gomega.Equal(tt.wantReasons[i]))
+				}
+			}
+			g.Expect(got.ProjectedScaleUps).To(gomega.Equal(tt.wantProjectedScaleUps))
+			g.Expect(tt.fields.scaleUpProjector.ProjectScaleUpCalls()).To(gomega.HaveLen(tt.wantScaleUpProjections))
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
 	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	"net/http"
 )
 
 type AMSQuotaService interface {
@@ -22,8 +23,9 @@ type AMSQuotaService interface {
 }
 
 type amsQuotaService struct {
-	amsClient   ocm.AMSClient
-	kafkaConfig *config.KafkaConfig
+	amsClient           ocm.AMSClient
+	amsCacheInvalidator ocm.AMSCacheInvalidator
+	kafkaConfig         *config.KafkaConfig
 }
 
 var _ services.QuotaService = &amsQuotaService{}
@@ -210,10 +212,10 @@ func (q amsQuotaService) hasConfiguredQuotaCost(organizationID string, kafkaBill
 	return false, nil
 }
 
-func (q amsQuotaService) getBillingModel(kafka *dbapi.KafkaRequest) (config.KafkaBillingModel, string, error) {
+func (q amsQuotaService) getBillingModel(kafka *dbapi.KafkaRequest) (config.KafkaBillingModel, string, string, error) {
 	orgID, err := q.amsClient.GetOrganisationIdFromExternalId(kafka.OrganisationId)
 	if err != nil {
-		return config.KafkaBillingModel{}, "", errors.NewWithCause(errors.ErrorGeneral, err, fmt.Sprintf("error checking quota: failed to get organization with external id %v", orgID))
+		return config.KafkaBillingModel{}, "", "", errors.NewWithCause(errors.ErrorGeneral, err, fmt.Sprintf("error checking quota: failed to get organization with external id %v", orgID))
 	}
 
 	resolver := utils.NewBillingModelResolver(q.amsClient, q.kafkaConfig)
@@ -221,10 +223,10 @@ func (q amsQuotaService) getBillingModel(kafka *dbapi.KafkaRequest) (config.Kafk
 	resolvedBillingModel, err := resolver.Resolve(orgID, kafka)
 
 	if err != nil {
-		return config.KafkaBillingModel{}, "", errors.NewWithCause(errors.ErrorInsufficientQuota, err, "unable to detect billing model")
+		return config.KafkaBillingModel{}, "", "", errors.NewWithCause(errors.ErrorInsufficientQuota, err, "unable to detect billing model")
 	}
 
-	return resolvedBillingModel.KafkaBillingModel, resolvedBillingModel.AMSBillingModel, nil
+	return resolvedBillingModel.KafkaBillingModel, resolvedBillingModel.AMSBillingModel, orgID, nil
 }
 
 func (q amsQuotaService) ReserveQuota(kafka *dbapi.KafkaRequest) (string, *errors.ServiceError) {
@@ -235,7 +237,7 @@ func (q amsQuotaService) ReserveQuota(kafka *dbapi.KafkaRequest) (string, *error
 		return "", errors.NewWithCause(errors.ErrorGeneral, e, "error reserving quota")
 	}
 
-	kafkaBillingModel, bm, err := q.getBillingModel(kafka)
+	kafkaBillingModel, bm, orgID, err := q.getBillingModel(kafka)
 	if err != nil {
 		svcErr := errors.ToServiceError(err)
 		return "", errors.NewWithCause(svcErr.Code, svcErr, "error getting billing model")
@@ -295,6 +297,9 @@ func (q amsQuotaService) ReserveQuota(kafka *dbapi.KafkaRequest) (string, *error
 		Build()
 
 	resp, err := q.amsClient.ClusterAuthorization(cb)
+	// the reservation changes the consumed quota of the organisation and its subscriptions. When it's denied, the cached
+	// quota costs that let it through are stale
+	q.invalidateCachedQuota(orgID)
 	if err != nil {
 		return "", errors.NewWithCause(errors.ErrorGeneral, err, "error reserving quota")
 	}
@@ -359,13 +364,40 @@ func (q amsQuotaService) DeleteQuota(subscriptionID string) *errors.ServiceError
 		return nil
 	}
 
-	_, err := q.amsClient.DeleteSubscription(subscriptionID)
+	subscription, found, err := q.GetSubscriptionByID(subscriptionID)
+	if err != nil {
+		return errors.GeneralError("failed to delete the quota: %v", err)
+	}
+
+	// the subscription may already have been deleted, i.e. when the deletion of the quota is retried by the outbox worker
+	// or when it was deleted by another replica, whose deletion didn't invalidate the subscriptions cached by this one
+	if !found {
+		q.amsCacheInvalidator.InvalidateSubscriptions()
+		return nil
+	}
+
+	return q.deleteSubscription(subscription)
+}
+
+func (q amsQuotaService) deleteSubscription(subscription *amsv1.Subscription) *errors.ServiceError {
+	status, err := q.amsClient.DeleteSubscription(subscription.ID())
+	q.invalidateCachedQuota(subscription.OrganizationID())
+	if status == http.StatusNotFound {
+		return nil
+	}
 	if err != nil {
 		return errors.GeneralError("failed to delete the quota: %v", err)
 	}
 	return nil
 }
 
+// invalidateCachedQuota invalidates the cached quota costs of the organisation and the cached subscriptions, once the
+// quota reserved in AMS changed
+func (q amsQuotaService) invalidateCachedQuota(organizationID string) {
+	q.amsCacheInvalidator.InvalidateQuotaCosts(organizationID)
+	q.amsCacheInvalidator.InvalidateSubscriptions()
+}
+
 // DeleteQuotaForBillingModel deletes the quota identified by `subscriptionID` only if it is related to received config.KafkaBillingModel
 func (q amsQuotaService) DeleteQuotaForBillingModel(subscriptionID string, kafkaBillingModel config.KafkaBillingModel) *errors.ServiceError {
 	if subscriptionID == "" {
@@ -382,7 +414,7 @@ func (q amsQuotaService) DeleteQuotaForBillingModel(subscriptionID string, kafka
 	}
 
 	if kafkaBillingModel.HasSupportForAMSBillingModel(string(subscription.ClusterBillingModel())) {
-		return q.DeleteQuota(subscriptionID)
+		return q.deleteSubscription(subscription)
 	}
 
 	// the specified subscription id is not related to the specified billing model
@@ -2,6 +2,7 @@ package quota
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
@@ -629,10 +630,10 @@ func Test_AMSGetBillingModel(t *testing.T) {
 		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			factory := NewDefaultQuotaServiceFactory(tt.fields.ocmClient, nil, nil, tt.fields.kafkaConfig)
+			factory := NewDefaultQuotaServiceFactory(tt.fields.ocmClient, newAMSCacheInvalidatorMock(), nil, nil, tt.fields.kafkaConfig)
 			quotaService, _ := factory.GetQuotaService(api.AMSQuotaType)
 
-			kafkaBillingModel, billingModel, err := quotaService.(*amsQuotaService).getBillingModel(&tt.args.request)
+			kafkaBillingModel, billingModel, _, err := quotaService.(*amsQuotaService).getBillingModel(&tt.args.request)
 			g.Expect(err != nil).To(gomega.Equal(tt.wantErr), "Unexpected error value %v", err)
 			if tt.wantErrMsg != "" {
 				g.Expect(err.Error()).To(gomega.ContainSubstring(tt.wantErrMsg))
@@ -778,7 +779,7 @@ func Test_AMSValidateBillingAccount(t *testing.T) {
 		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			factory := NewDefaultQuotaServiceFactory(tt.fields.ocmClient, nil, nil, tt.fields.kafkaConfig)
+			factory := NewDefaultQuotaServiceFactory(tt.fields.ocmClient, newAMSCacheInvalidatorMock(), nil, nil, tt.fields.kafkaConfig)
 			quotaService, _ := factory.GetQuotaService(api.AMSQuotaType)
 			// TODO: add a test value for billing model
 			err := quotaService.ValidateBillingAccount(tt.args.orgId, types.STANDARD, "", tt.args.billingAccountId, tt.args.marketplace)
@@ -963,7 +964,7 @@ func Test_AMSCheckQuota(t *testing.T) {
 		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			factory := NewDefaultQuotaServiceFactory(tt.fields.ocmClient, nil, nil, tt.fields.kafkaConfig)
+			factory := NewDefaultQuotaServiceFactory(tt.fields.ocmClient, newAMSCacheInvalidatorMock(), nil, nil, tt.fields.kafkaConfig)
 			quotaService, _ := factory.GetQuotaService(api.AMSQuotaType)
 			kafka := &dbapi.KafkaRequest{
 				Meta: api.Meta{
@@ -1166,7 +1167,7 @@ func Test_AMSReserveQuotaIfNotAlreadyReserved(t *testing.T) {
 
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			factory := NewDefaultQuotaServiceFactory(tt.fields.ocmClient, nil, nil, &tt.fields.kafkaConfig)
+			factory := NewDefaultQuotaServiceFactory(tt.fields.ocmClient, newAMSCacheInvalidatorMock(), nil, nil, &tt.fields.kafkaConfig)
 			quotaService, _ := factory.GetQuotaService(api.AMSQuotaType)
 
 			_, err := quotaService.ReserveQuotaIfNotAlreadyReserved(kafka)
@@ -1762,7 +1763,8 @@ func Test_AMSReserveQuota(t *testing.T) {
 		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			factory := NewDefaultQuotaServiceFactory(tt.fields.ocmClient, nil, nil, tt.fields.kafkaConfig)
+			invalidator := newAMSCacheInvalidatorMock()
+			factory := NewDefaultQuotaServiceFactory(tt.fields.ocmClient, invalidator, nil, nil, tt.fields.kafkaConfig)
 			quotaService, _ := factory.GetQuotaService(api.AMSQuotaType)
 			kafka := &dbapi.KafkaRequest{
 				Meta: api.Meta{
@@ -1792,18 +1794,37 @@ func Test_AMSReserveQuota(t *testing.T) {
 				clusterAuthorizationResource := clusterAuthorizationResources[0]
 				g.Expect(clusterAuthorizationResource.BillingModel()).To(gomega.BeEquivalentTo(tt.wantAMSBillingModel))
 			}
+			if len(tt.fields.ocmClient.(*ocm.ClientMock).ClusterAuthorizationCalls()) > 0 {
+				g.Expect(invalidator.InvalidateQuotaCostsCalls()).To(gomega.HaveLen(1))
+				g.Expect(invalidator.InvalidateSubscriptionsCalls()).To(gomega.HaveLen(1))
+			}
 		})
 	}
 }
 
+func newAMSCacheInvalidatorMock() *ocm.AMSCacheInvalidatorMock {
+	return &ocm.AMSCacheInvalidatorMock{
+		InvalidateQuotaCostsFunc:    func(organizationID string) {},
+		InvalidateSubscriptionsFunc: func() {},
+	}
+}
+
 func Test_Delete_Quota(t *testing.T) {
 	var amsDefaultKafkaConf = config.KafkaConfig{
 		Quota:                  config.NewKafkaQuotaConfig(),
 		SupportedInstanceTypes: test.NewAMSTestKafkaSupportedInstanceTypesConfig(),
 	}
 
+	subscription, err := v1.NewSubscription().ID("1223").OrganizationID("org-id").Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	getSubscriptionByID := func(id string) (*v1.Subscription, bool, error) {
+		return subscription, true, nil
+	}
+
 	type fields struct {
-		ocmClient ocm.Client
+		ocmClient *ocm.ClientMock
 	}
 	type args struct {
 		subscriptionId string
@@ -1817,7 +1838,10 @@ func Test_Delete_Quota(t *testing.T) {
 		// function has been executed
 		// wantErr is similar to want, but instead of testing the actual returned error, we're just testing than any
 		// error has been returned
-		wantErr bool
+		wantErr                         bool
+		wantDeleteCalls                 int
+		wantInvalidatedQuotaCosts       []string
+		wantInvalidateSubscriptionCalls int
 	}{
 		{
 			name: "delete a quota by id",
@@ -1826,12 +1850,65 @@ func Test_Delete_Quota(t *testing.T) {
 			},
 			fields: fields{
 				ocmClient: &ocm.ClientMock{
+					GetSubscriptionByIDFunc: getSubscriptionByID,
 					DeleteSubscriptionFunc: func(id string) (int, error) {
 						return 1, nil
 					},
 				},
 			},
-			wantErr: false,
+			wantErr:                         false,
+			wantDeleteCalls:                 1,
+			wantInvalidatedQuotaCosts:       []string{"org-id"},
+			wantInvalidateSubscriptionCalls: 1,
+		},
+		{
+			name: "should not fail if the subscription was already deleted",
+			args: args{
+				subscriptionId: "1223",
+			},
+			fields: fields{
+				ocmClient: &ocm.ClientMock{
+					GetSubscriptionByIDFunc: func(id string) (*v1.Subscription, bool, error) {
+						return nil, false, nil
+					},
+				},
+			},
+			wantErr:                         false,
+			wantDeleteCalls:                 0,
+			wantInvalidateSubscriptionCalls: 1,
+		},
+		{
+			name: "should not fail if the subscription was deleted concurrently",
+			args: args{
+				subscriptionId: "1223",
+			},
+			fields: fields{
+				ocmClient: &ocm.ClientMock{
+					GetSubscriptionByIDFunc: getSubscriptionByID,
+					DeleteSubscriptionFunc: func(id string) (int, error) {
+						return http.StatusNotFound, errors.NotFound("subscription not found")
+					},
+				},
+			},
+			wantErr:                         false,
+			wantDeleteCalls:                 1,
+			wantInvalidatedQuotaCosts:       []string{"org-id"},
+			wantInvalidateSubscriptionCalls: 1,
+		},
+		{
+			name: "failed to get the subscription",
+			args: args{
+				subscriptionId: "1223",
+			},
+			fields: fields{
+				ocmClient: &ocm.ClientMock{
+					GetSubscriptionByIDFunc: func(id string) (*v1.Subscription, bool, error) {
+						return nil, false, errors.GeneralError("failed to get subscription")
+					},
+				},
+			},
+			wantErr:         true,
+			wantDeleteCalls: 0,
 		},
 		{
 			name: "failed to delete a quota by id",
@@ -1840,24 +1917,35 @@ func Test_Delete_Quota(t *testing.T) {
 			},
 			fields: fields{
 				ocmClient: &ocm.ClientMock{
+					GetSubscriptionByIDFunc: getSubscriptionByID,
 					DeleteSubscriptionFunc: func(id string) (int, error) {
 						return 0, errors.GeneralError("failed to delete subscription")
 					},
 				},
 			},
-			wantErr: true,
+			wantErr:                         true,
+			wantDeleteCalls:                 1,
+			wantInvalidatedQuotaCosts:       []string{"org-id"},
+			wantInvalidateSubscriptionCalls: 1,
 		},
 	}
 
 	for _, testcase := range tests {
 		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
-			factory := NewDefaultQuotaServiceFactory(tt.fields.ocmClient, nil, nil, &amsDefaultKafkaConf)
+			g := gomega.NewWithT(t)
+			invalidator := newAMSCacheInvalidatorMock()
+			factory := NewDefaultQuotaServiceFactory(tt.fields.ocmClient, invalidator, nil, nil, &amsDefaultKafkaConf)
 			quotaService, _ := factory.GetQuotaService(api.AMSQuotaType)
 			err := quotaService.DeleteQuota(tt.args.subscriptionId)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("DeleteQuota() error = %v, wantErr %v", err, tt.wantErr)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(tt.fields.ocmClient.DeleteSubscriptionCalls()).To(gomega.HaveLen(tt.wantDeleteCalls))
+			var invalidatedQuotaCosts []string
+			for _, call := range invalidator.InvalidateQuotaCostsCalls() {
+				invalidatedQuotaCosts = append(invalidatedQuotaCosts, call.OrganizationID)
 			}
+			g.Expect(invalidatedQuotaCosts).To(gomega.Equal(tt.wantInvalidatedQuotaCosts))
+			g.Expect(invalidator.InvalidateSubscriptionsCalls()).To(gomega.HaveLen(tt.wantInvalidateSubscriptionCalls))
 		})
 	}
 }
@@ -2036,7 +2124,7 @@ func Test_amsQuotaService_CheckIfQuotaIsDefinedForInstanceType(t *testing.T) {
 		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			quotaServiceFactory := NewDefaultQuotaServiceFactory(tt.ocmClient, nil, nil, &amsDefaultKafkaConf)
+			quotaServiceFactory := NewDefaultQuotaServiceFactory(tt.ocmClient, newAMSCacheInvalidatorMock(), nil, nil, &amsDefaultKafkaConf)
 			quotaService, _ := quotaServiceFactory.GetQuotaService(api.AMSQuotaType)
 
 			// FIXME: fix when implementing support for KAFKA BILLING MODELS
@@ -2319,7 +2407,7 @@ func Test_amsQuotaService_IsQuotaEntitlementActive(t *testing.T) {
 		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			quotaServiceFactory := NewDefaultQuotaServiceFactory(tt.fields.amsClient, nil, nil, &tt.fields.kafkaConfig)
+			quotaServiceFactory := NewDefaultQuotaServiceFactory(tt.fields.amsClient, newAMSCacheInvalidatorMock(), nil, nil, &tt.fields.kafkaConfig)
 			quotaService, _ := quotaServiceFactory.GetQuotaService(api.AMSQuotaType)
 
 			got, err := quotaService.IsQuotaEntitlementActive(tt.args.kafka)
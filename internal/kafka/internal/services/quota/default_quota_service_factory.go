@@ -17,12 +17,13 @@ type DefaultQuotaServiceFactory struct {
 
 func NewDefaultQuotaServiceFactory(
 	amsClient ocm.AMSClient,
+	amsCacheInvalidator ocm.AMSCacheInvalidator,
 	connectionFactory *db.ConnectionFactory,
 	quotaManagementListConfig *quota_management.QuotaManagementListConfig,
 	kafkaConfig *config.KafkaConfig,
 ) services.QuotaServiceFactory {
 	quotaServiceContainer := map[api.QuotaType]services.QuotaService{
-		api.AMSQuotaType:                 &amsQuotaService{amsClient: amsClient, kafkaConfig: kafkaConfig},
+		api.AMSQuotaType:                 &amsQuotaService{amsClient: amsClient, amsCacheInvalidator: amsCacheInvalidator, kafkaConfig: kafkaConfig},
 		api.QuotaManagementListQuotaType: &QuotaManagementListService{connectionFactory: connectionFactory, quotaManagementList: quotaManagementListConfig, kafkaConfig: kafkaConfig},
 	}
 	return &DefaultQuotaServiceFactory{quotaServiceContainer: quotaServiceContainer}
@@ -0,0 +1,399 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db/dbencryption"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+	"github.com/golang/glog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// serviceAccountLifecycleBatchSize is the number of lifecycles loaded at once by the reconciliation
+	serviceAccountLifecycleBatchSize = 100
+	// maxRecordedServiceAccountUsages bounds the in memory throttling of the last used updates
+	maxRecordedServiceAccountUsages = 10000
+)
+
+//go:generate moq -out service_account_lifecycle_service_moq.go . ServiceAccountLifecycleService
+type ServiceAccountLifecycleService interface {
+	// Track starts tracking a service account created by the request, with an optional expiry
+	Track(ctx context.Context, account *api.ServiceAccount, expiresAt *time.Time) (*dbapi.ServiceAccountLifecycle, *errors.ServiceError)
+	// Lifecycles returns the lifecycles of the service accounts by id. The service accounts created before their lifecycle was tracked
+	// are tracked from their creation, in the organisation of the request, the same way as by Backfill.
+	Lifecycles(ctx context.Context, accounts []api.ServiceAccount) (map[string]*dbapi.ServiceAccountLifecycle, *errors.ServiceError)
+	// CheckCredentialsReset returns an error if the credentials of the service account can't be reset because they're revoked
+	CheckCredentialsReset(id string) *errors.ServiceError
+	// CredentialsReset restarts the credential age of a service account whose credentials were reset by the request, the secret of
+	// the last rotation isn't returned anymore
+	CredentialsReset(ctx context.Context, account *api.ServiceAccount) (*dbapi.ServiceAccountLifecycle, *errors.ServiceError)
+	// Untrack stops tracking a deleted service account
+	Untrack(id string) *errors.ServiceError
+	// RecordUsage sets the last used timestamp of a service account, at most once per configured interval
+	RecordUsage(clientId string, usedAt time.Time)
+	// Backfill tracks the service accounts of all the organisations that aren't tracked yet, it returns the number of service
+	// accounts tracked. It does nothing when the SSO provider can't list the service accounts of all the organisations.
+	Backfill(ctx context.Context) (int, *errors.ServiceError)
+	// Reconcile warns the organisations of the credentials about to expire, then applies the action of the policy of the
+	// organisation to the expired credentials: they're either rotated, the new secret being kept for the owner, or revoked.
+	// It returns the number of service accounts warned or expired.
+	Reconcile(ctx context.Context) (int, []error)
+}
+
+var _ ServiceAccountLifecycleService = &serviceAccountLifecycleService{}
+
+type serviceAccountLifecycleService struct {
+	connectionFactory *db.ConnectionFactory
+	keycloakService   sso.KeycloakService
+	webhookService    webhooks.WebhookService
+	config            *config.ServiceAccountLifecycleConfig
+
+	mu       sync.Mutex
+	lastUsed map[string]time.Time
+}
+
+func NewServiceAccountLifecycleService(connectionFactory *db.ConnectionFactory, keycloakService sso.KafkaKeycloakService,
+	webhookService webhooks.WebhookService, config *config.ServiceAccountLifecycleConfig) ServiceAccountLifecycleService {
+	return &serviceAccountLifecycleService{
+		connectionFactory: connectionFactory,
+		keycloakService:   keycloakService,
+		webhookService:    webhookService,
+		config:            config,
+		lastUsed:          map[string]time.Time{},
+	}
+}
+
+// serviceAccountWebhookData is the service account sent in the webhook deliveries
+type serviceAccountWebhookData struct {
+	Id                  string     `json:"id"`
+	ClientId            string     `json:"client_id"`
+	Owner               string     `json:"owner"`
+	Status              string     `json:"status"`
+	ExpiresAt           *time.Time `json:"expires_at,omitempty"`
+	CredentialsExpireAt *time.Time `json:"credentials_expire_at,omitempty"`
+	Action              string     `json:"action,omitempty"`
+}
+
+func (s *serviceAccountLifecycleService) Track(ctx context.Context, account *api.ServiceAccount, expiresAt *time.Time) (*dbapi.ServiceAccountLifecycle, *errors.ServiceError) {
+	orgId, err := organisationFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lifecycle := &dbapi.ServiceAccountLifecycle{
+		Meta:                 api.Meta{ID: account.ID},
+		ClientId:             account.ClientID,
+		OrganisationId:       orgId,
+		Owner:                account.CreatedBy,
+		ExpiresAt:            expiresAt,
+		CredentialsRotatedAt: time.Now(),
+		Status:               dbapi.ServiceAccountLifecycleActive,
+	}
+	if err := s.connectionFactory.New().Create(lifecycle).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to track the lifecycle of service account %s", account.ID)
+	}
+	s.setExpiry(lifecycle)
+	return lifecycle, nil
+}
+
+func (s *serviceAccountLifecycleService) Lifecycles(ctx context.Context, accounts []api.ServiceAccount) (map[string]*dbapi.ServiceAccountLifecycle, *errors.ServiceError) {
+	lifecycles := map[string]*dbapi.ServiceAccountLifecycle{}
+	if len(accounts) == 0 {
+		return lifecycles, nil
+	}
+	ids := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		ids = append(ids, account.ID)
+	}
+	var found []*dbapi.ServiceAccountLifecycle
+	if err := s.connectionFactory.New().Where("id IN ?", ids).Find(&found).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to get the lifecycles of the service accounts")
+	}
+	for _, lifecycle := range found {
+		s.setExpiry(lifecycle)
+		lifecycles[lifecycle.ID] = lifecycle
+	}
+
+	var untracked []*dbapi.ServiceAccountLifecycle
+	for _, account := range accounts {
+		if _, ok := lifecycles[account.ID]; ok {
+			continue
+		}
+		orgId, err := organisationFromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		untracked = append(untracked, s.newUntrackedLifecycle(account, orgId, time.Now()))
+	}
+	if len(untracked) > 0 {
+		// the service accounts tracked meanwhile by the backfill are skipped
+		if err := s.connectionFactory.New().Clauses(clause.OnConflict{DoNothing: true}).Create(untracked).Error; err != nil {
+			return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to track the lifecycles of the service accounts")
+		}
+		for _, lifecycle := range untracked {
+			s.setExpiry(lifecycle)
+			lifecycles[lifecycle.ID] = lifecycle
+		}
+	}
+	return lifecycles, nil
+}
+
+func (s *serviceAccountLifecycleService) Backfill(ctx context.Context) (int, *errors.ServiceError) {
+	tracked := 0
+	now := time.Now()
+	for first := 0; ; first += serviceAccountLifecycleBatchSize {
+		accounts, err := s.keycloakService.ListServiceAccountsInternal(first, serviceAccountLifecycleBatchSize)
+		if err != nil {
+			if err.Code == errors.ErrorNotImplemented {
+				glog.V(5).Infof("the service accounts aren't backfilled: %v", err)
+				return tracked, nil
+			}
+			return tracked, errors.NewWithCause(errors.ErrorGeneral, err, "failed to list the service accounts to track")
+		}
+		if len(accounts) == 0 {
+			return tracked, nil
+		}
+
+		ids := make([]string, 0, len(accounts))
+		for _, account := range accounts {
+			ids = append(ids, account.ID)
+		}
+		var trackedIds []string
+		if err := s.connectionFactory.New().Model(&dbapi.ServiceAccountLifecycle{}).Where("id IN ?", ids).Pluck("id", &trackedIds).Error; err != nil {
+			return tracked, errors.NewWithCause(errors.ErrorGeneral, err, "failed to get the lifecycles of the service accounts")
+		}
+		var untracked []*dbapi.ServiceAccountLifecycle
+		for _, account := range accounts {
+			if account.OrgId == "" || arrays.Contains(trackedIds, account.ID) {
+				continue
+			}
+			untracked = append(untracked, s.newUntrackedLifecycle(account, account.OrgId, now))
+		}
+		if len(untracked) > 0 {
+			// the service accounts tracked meanwhile by a request are skipped
+			result := s.connectionFactory.New().Clauses(clause.OnConflict{DoNothing: true}).Create(untracked)
+			if result.Error != nil {
+				return tracked, errors.NewWithCause(errors.ErrorGeneral, result.Error, "failed to track the lifecycles of the service accounts")
+			}
+			tracked += int(result.RowsAffected)
+		}
+		if len(accounts) < serviceAccountLifecycleBatchSize {
+			return tracked, nil
+		}
+	}
+}
+
+// newUntrackedLifecycle returns the lifecycle of a service account created before its lifecycle was tracked, its credentials age
+// starts from its creation. The expiry of the credentials that are already expired, or expire within the warning period, is
+// postponed to the end of the warning period so that the organisation is warned before they expire.
+func (s *serviceAccountLifecycleService) newUntrackedLifecycle(account api.ServiceAccount, orgId string, now time.Time) *dbapi.ServiceAccountLifecycle {
+	rotatedAt := account.CreatedAt
+	if rotatedAt.IsZero() {
+		rotatedAt = now
+	}
+	policy := s.config.PolicyFor(orgId)
+	if policy.MaxCredentialAge > 0 {
+		if warnedRotatedAt := now.Add(policy.WarningPeriod - policy.MaxCredentialAge); rotatedAt.Before(warnedRotatedAt) {
+			rotatedAt = warnedRotatedAt
+		}
+	}
+	return &dbapi.ServiceAccountLifecycle{
+		Meta:                 api.Meta{ID: account.ID},
+		ClientId:             account.ClientID,
+		OrganisationId:       orgId,
+		Owner:                account.CreatedBy,
+		CredentialsRotatedAt: rotatedAt,
+		Status:               dbapi.ServiceAccountLifecycleActive,
+	}
+}
+
+func (s *serviceAccountLifecycleService) CheckCredentialsReset(id string) *errors.ServiceError {
+	var lifecycle dbapi.ServiceAccountLifecycle
+	if err := s.connectionFactory.New().Where("id = ?", id).First(&lifecycle).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to get the lifecycle of service account %s", id)
+	}
+	if lifecycle.Status == dbapi.ServiceAccountLifecycleRevoked {
+		return errors.New(errors.ErrorForbidden, "the credentials of service account %s are revoked as it or its credentials expired, it must be recreated", id)
+	}
+	return nil
+}
+
+func (s *serviceAccountLifecycleService) CredentialsReset(ctx context.Context, account *api.ServiceAccount) (*dbapi.ServiceAccountLifecycle, *errors.ServiceError) {
+	lifecycles, err := s.Lifecycles(ctx, []api.ServiceAccount{*account})
+	if err != nil {
+		return nil, err
+	}
+	lifecycle := lifecycles[account.ID]
+	lifecycle.CredentialsRotatedAt = time.Now()
+	lifecycle.ExpiryWarnedAt = nil
+	lifecycle.RotatedClientSecret = ""
+	if err := s.connectionFactory.New().Model(lifecycle).Select("credentials_rotated_at", "expiry_warned_at", "rotated_client_secret").Updates(lifecycle).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to update the lifecycle of service account %s", account.ID)
+	}
+	s.setExpiry(lifecycle)
+	return lifecycle, nil
+}
+
+func (s *serviceAccountLifecycleService) Untrack(id string) *errors.ServiceError {
+	if err := s.connectionFactory.New().Where("id = ?", id).Delete(&dbapi.ServiceAccountLifecycle{}).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to delete the lifecycle of service account %s", id)
+	}
+	return nil
+}
+
+func (s *serviceAccountLifecycleService) RecordUsage(clientId string, usedAt time.Time) {
+	interval := s.config.LastUsedUpdateInterval
+	s.mu.Lock()
+	if last, ok := s.lastUsed[clientId]; ok && usedAt.Sub(last) < interval {
+		s.mu.Unlock()
+		return
+	}
+	if len(s.lastUsed) >= maxRecordedServiceAccountUsages {
+		s.lastUsed = map[string]time.Time{}
+	}
+	s.lastUsed[clientId] = usedAt
+	s.mu.Unlock()
+
+	// the condition on the stored timestamp throttles the updates of the other replicas too
+	if err := s.connectionFactory.New().Model(&dbapi.ServiceAccountLifecycle{}).
+		Where("client_id = ? AND (last_used_at IS NULL OR last_used_at < ?)", clientId, usedAt.Add(-interval)).
+		UpdateColumn("last_used_at", usedAt).Error; err != nil {
+		glog.Warningf("failed to record the usage of service account %s: %v", clientId, err)
+	}
+}
+
+func (s *serviceAccountLifecycleService) Reconcile(ctx context.Context) (int, []error) {
+	var errs []error
+	processed := 0
+	now := time.Now()
+	lastID := ""
+	for {
+		var lifecycles []*dbapi.ServiceAccountLifecycle
+		if err := s.connectionFactory.New().
+			Where("status = ? AND id > ?", dbapi.ServiceAccountLifecycleActive, lastID).
+			Order("id").
+			Limit(serviceAccountLifecycleBatchSize).
+			Find(&lifecycles).Error; err != nil {
+			return processed, append(errs, errors.NewWithCause(errors.ErrorGeneral, err, "failed to list the service account lifecycles"))
+		}
+		if len(lifecycles) == 0 {
+			return processed, errs
+		}
+		lastID = lifecycles[len(lifecycles)-1].ID
+
+		for _, lifecycle := range lifecycles {
+			done, err := s.reconcileLifecycle(lifecycle, now)
+			if err != nil {
+				errs = append(errs, err)
+			}
+			if done {
+				processed++
+			}
+		}
+	}
+}
+
+// reconcileLifecycle warns of the upcoming expiry of a service account or expires it, it returns whether anything was done
+func (s *serviceAccountLifecycleService) reconcileLifecycle(lifecycle *dbapi.ServiceAccountLifecycle, now time.Time) (bool, *errors.ServiceError) {
+	policy := s.config.PolicyFor(lifecycle.OrganisationId)
+	expiry, action := lifecycle.Expiry(policy)
+	if expiry == nil {
+		return false, nil
+	}
+	lifecycle.CredentialsExpireAt = expiry
+	lifecycle.ExpiryAction = action
+
+	if now.Before(*expiry) {
+		if lifecycle.ExpiryWarnedAt != nil || policy.WarningPeriod <= 0 || now.Before(expiry.Add(-policy.WarningPeriod)) {
+			return false, nil
+		}
+		lifecycle.ExpiryWarnedAt = &now
+		glog.Infof("credentials of service account %s of organisation %s expire at %s", lifecycle.ClientId, lifecycle.OrganisationId, expiry.Format(time.RFC3339))
+		return true, s.updateLifecycle(lifecycle, webhooks.EventServiceAccountCredentialsExpiring, "expiry_warned_at")
+	}
+
+	// the secret is regenerated so that the expired credentials can't be used anymore
+	account, err := s.keycloakService.ResetServiceAccountCredentialsInternal(lifecycle.ID)
+	if err != nil {
+		if err.Code == errors.ErrorServiceAccountNotFound {
+			glog.Infof("service account %s not found, its lifecycle isn't tracked anymore", lifecycle.ClientId)
+			return true, s.Untrack(lifecycle.ID)
+		}
+		return false, errors.NewWithCause(errors.ErrorGeneral, err, "failed to regenerate the expired credentials of service account %s", lifecycle.ClientId)
+	}
+
+	if action == config.ServiceAccountExpiryActionRevoke {
+		// the new secret is never returned, the service account can't authenticate anymore
+		lifecycle.Status = dbapi.ServiceAccountLifecycleRevoked
+		lifecycle.RotatedClientSecret = ""
+		glog.Infof("service account %s of organisation %s expired and its credentials are revoked", lifecycle.ClientId, lifecycle.OrganisationId)
+		return true, s.updateLifecycle(lifecycle, webhooks.EventServiceAccountCredentialsRevoked, "status", "rotated_client_secret")
+	}
+	// the new secret is kept, encrypted, for the owner of the service account who gets it with the service account
+	lifecycle.CredentialsRotatedAt = now
+	lifecycle.ExpiryWarnedAt = nil
+	lifecycle.RotatedClientSecret = dbencryption.EncryptedString(account.ClientSecret)
+	glog.Infof("expired credentials of service account %s of organisation %s are rotated", lifecycle.ClientId, lifecycle.OrganisationId)
+	s.setExpiry(lifecycle)
+	return true, s.updateLifecycle(lifecycle, webhooks.EventServiceAccountCredentialsRotated, "credentials_rotated_at", "expiry_warned_at", "rotated_client_secret")
+}
+
+// updateLifecycle updates the columns of the lifecycle and notifies its organisation of the event in the same transaction
+func (s *serviceAccountLifecycleService) updateLifecycle(lifecycle *dbapi.ServiceAccountLifecycle, eventType string, columns ...string) *errors.ServiceError {
+	err := s.connectionFactory.New().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(lifecycle).Select(columns).Updates(lifecycle).Error; err != nil {
+			return err
+		}
+		if err := s.webhookService.Enqueue(tx, webhooks.Event{
+			Type:           eventType,
+			OrganisationId: lifecycle.OrganisationId,
+			ResourceId:     lifecycle.ID,
+			Data: serviceAccountWebhookData{
+				Id:                  lifecycle.ID,
+				ClientId:            lifecycle.ClientId,
+				Owner:               lifecycle.Owner,
+				Status:              lifecycle.Status,
+				ExpiresAt:           lifecycle.ExpiresAt,
+				CredentialsExpireAt: lifecycle.CredentialsExpireAt,
+				Action:              lifecycle.ExpiryAction,
+			},
+		}); err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to update the lifecycle of service account %s", lifecycle.ClientId)
+	}
+	return nil
+}
+
+func (s *serviceAccountLifecycleService) setExpiry(lifecycle *dbapi.ServiceAccountLifecycle) {
+	lifecycle.CredentialsExpireAt, lifecycle.ExpiryAction = lifecycle.Expiry(s.config.PolicyFor(lifecycle.OrganisationId))
+}
+
+func organisationFromContext(ctx context.Context) (string, *errors.ServiceError) {
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil {
+		return "", errors.NewWithCause(errors.ErrorUnauthenticated, err, "user not authenticated")
+	}
+	orgId, err := claims.GetOrgId()
+	if err != nil {
+		return "", errors.NewWithCause(errors.ErrorUnauthenticated, err, "organisation id not found in the token")
+	}
+	return orgId, nil
+}
@@ -0,0 +1,417 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package services
+
+import (
+	"context"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	apiErrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"sync"
+	"time"
+)
+
+// Ensure, that ServiceAccountLifecycleServiceMock does implement ServiceAccountLifecycleService.
+// If this is not the case, regenerate this file with moq.
+var _ ServiceAccountLifecycleService = &ServiceAccountLifecycleServiceMock{}
+
+// ServiceAccountLifecycleServiceMock is a mock implementation of ServiceAccountLifecycleService.
+//
+//	func TestSomethingThatUsesServiceAccountLifecycleService(t *testing.T) {
+//
+//		// make and configure a mocked ServiceAccountLifecycleService
+//		mockedServiceAccountLifecycleService := &ServiceAccountLifecycleServiceMock{
+//			BackfillFunc: func(ctx context.Context) (int, *apiErrors.ServiceError) {
+//				panic("mock out the Backfill method")
+//			},
+//			CheckCredentialsResetFunc: func(id string) *apiErrors.ServiceError {
+//				panic("mock out the CheckCredentialsReset method")
+//			},
+//			CredentialsResetFunc: func(ctx context.Context, account *api.ServiceAccount) (*dbapi.ServiceAccountLifecycle, *apiErrors.ServiceError) {
+//				panic("mock out the CredentialsReset method")
+//			},
+//			LifecyclesFunc: func(ctx context.Context, accounts []api.ServiceAccount) (map[string]*dbapi.ServiceAccountLifecycle, *apiErrors.ServiceError) {
+//				panic("mock out the Lifecycles method")
+//			},
+//			ReconcileFunc: func(ctx context.Context) (int, []error) {
+//				panic("mock out the Reconcile method")
+//			},
+//			RecordUsageFunc: func(clientId string, usedAt time.Time)  {
+//				panic("mock out the RecordUsage method")
+//			},
+//			TrackFunc: func(ctx context.Context, account *api.ServiceAccount, expiresAt *time.Time) (*dbapi.ServiceAccountLifecycle, *apiErrors.ServiceError) {
+//				panic("mock out the Track method")
+//			},
+//			UntrackFunc: func(id string) *apiErrors.ServiceError {
+//				panic("mock out the Untrack method")
+//			},
+//		}
+//
+//		// use mockedServiceAccountLifecycleService in code that requires ServiceAccountLifecycleService
+//		// and then make assertions.
+//
+//	}
+type ServiceAccountLifecycleServiceMock struct {
+	// BackfillFunc mocks the Backfill method.
+	BackfillFunc func(ctx context.Context) (int, *apiErrors.ServiceError)
+
+	// CheckCredentialsResetFunc mocks the CheckCredentialsReset method.
+	CheckCredentialsResetFunc func(id string) *apiErrors.ServiceError
+
+	// CredentialsResetFunc mocks the CredentialsReset method.
+	CredentialsResetFunc func(ctx context.Context, account *api.ServiceAccount) (*dbapi.ServiceAccountLifecycle, *apiErrors.ServiceError)
+
+	// LifecyclesFunc mocks the Lifecycles method.
+	LifecyclesFunc func(ctx context.Context, accounts []api.ServiceAccount) (map[string]*dbapi.ServiceAccountLifecycle, *apiErrors.ServiceError)
+
+	// ReconcileFunc mocks the Reconcile method.
+	ReconcileFunc func(ctx context.Context) (int, []error)
+
+	// RecordUsageFunc mocks the RecordUsage method.
+	RecordUsageFunc func(clientId string, usedAt time.Time)
+
+	// TrackFunc mocks the Track method.
+	TrackFunc func(ctx context.Context, account *api.ServiceAccount, expiresAt *time.Time) (*dbapi.ServiceAccountLifecycle, *apiErrors.ServiceError)
+
+	// UntrackFunc mocks the Untrack method.
+	UntrackFunc func(id string) *apiErrors.ServiceError
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Backfill holds details about calls to the Backfill method.
+		Backfill []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// CheckCredentialsReset holds details about calls to the CheckCredentialsReset method.
+		CheckCredentialsReset []struct {
+			// ID is the id argument value.
+			ID string
+		}
+		// CredentialsReset holds details about calls to the CredentialsReset method.
+		CredentialsReset []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Account is the account argument value.
+			Account *api.ServiceAccount
+		}
+		// Lifecycles holds details about calls to the Lifecycles method.
+		Lifecycles []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Accounts is the accounts argument value.
+			Accounts []api.ServiceAccount
+		}
+		// Reconcile holds details about calls to the Reconcile method.
+		Reconcile []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// RecordUsage holds details about calls to the RecordUsage method.
+		RecordUsage []struct {
+			// ClientId is the clientId argument value.
+			ClientId string
+			// UsedAt is the usedAt argument value.
+			UsedAt time.Time
+		}
+		// Track holds details about calls to the Track method.
+		Track []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Account is the account argument value.
+			Account *api.ServiceAccount
+			// ExpiresAt is the expiresAt argument value.
+			ExpiresAt *time.Time
+		}
+		// Untrack holds details about calls to the Untrack method.
+		Untrack []struct {
+			// ID is the id argument value.
+			ID string
+		}
+	}
+	lockBackfill              sync.RWMutex
+	lockCheckCredentialsReset sync.RWMutex
+	lockCredentialsReset      sync.RWMutex
+	lockLifecycles            sync.RWMutex
+	lockReconcile             sync.RWMutex
+	lockRecordUsage           sync.RWMutex
+	lockTrack                 sync.RWMutex
+	lockUntrack               sync.RWMutex
+}
+
+// Backfill calls BackfillFunc.
+func (mock *ServiceAccountLifecycleServiceMock) Backfill(ctx context.Context) (int, *apiErrors.ServiceError) {
+	if mock.BackfillFunc == nil {
+		panic("ServiceAccountLifecycleServiceMock.BackfillFunc: method is nil but ServiceAccountLifecycleService.Backfill was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockBackfill.Lock()
+	mock.calls.Backfill = append(mock.calls.Backfill, callInfo)
+	mock.lockBackfill.Unlock()
+	return mock.BackfillFunc(ctx)
+}
+
+// BackfillCalls gets all the calls that were made to Backfill.
+// Check the length with:
+//
+//	len(mockedServiceAccountLifecycleService.BackfillCalls())
+func (mock *ServiceAccountLifecycleServiceMock) BackfillCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockBackfill.RLock()
+	calls = mock.calls.Backfill
+	mock.lockBackfill.RUnlock()
+	return calls
+}
+
+// CheckCredentialsReset calls CheckCredentialsResetFunc.
+func (mock *ServiceAccountLifecycleServiceMock) CheckCredentialsReset(id string) *apiErrors.ServiceError {
+	if mock.CheckCredentialsResetFunc == nil {
+		panic("ServiceAccountLifecycleServiceMock.CheckCredentialsResetFunc: method is nil but ServiceAccountLifecycleService.CheckCredentialsReset was just called")
+	}
+	callInfo := struct {
+		ID string
+	}{
+		ID: id,
+	}
+	mock.lockCheckCredentialsReset.Lock()
+	mock.calls.CheckCredentialsReset = append(mock.calls.CheckCredentialsReset, callInfo)
+	mock.lockCheckCredentialsReset.Unlock()
+	return mock.CheckCredentialsResetFunc(id)
+}
+
+// CheckCredentialsResetCalls gets all the calls that were made to CheckCredentialsReset.
+// Check the length with:
+//
+//	len(mockedServiceAccountLifecycleService.CheckCredentialsResetCalls())
+func (mock *ServiceAccountLifecycleServiceMock) CheckCredentialsResetCalls() []struct {
+	ID string
+} {
+	var calls []struct {
+		ID string
+	}
+	mock.lockCheckCredentialsReset.RLock()
+	calls = mock.calls.CheckCredentialsReset
+	mock.lockCheckCredentialsReset.RUnlock()
+	return calls
+}
+
+// CredentialsReset calls CredentialsResetFunc.
+func (mock *ServiceAccountLifecycleServiceMock) CredentialsReset(ctx context.Context, account *api.ServiceAccount) (*dbapi.ServiceAccountLifecycle, *apiErrors.ServiceError) {
+	if mock.CredentialsResetFunc == nil {
+		panic("ServiceAccountLifecycleServiceMock.CredentialsResetFunc: method is nil but ServiceAccountLifecycleService.CredentialsReset was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Account *api.ServiceAccount
+	}{
+		Ctx:     ctx,
+		Account: account,
+	}
+	mock.lockCredentialsReset.Lock()
+	mock.calls.CredentialsReset = append(mock.calls.CredentialsReset, callInfo)
+	mock.lockCredentialsReset.Unlock()
+	return mock.CredentialsResetFunc(ctx, account)
+}
+
+// CredentialsResetCalls gets all the calls that were made to CredentialsReset.
+// Check the length with:
+//
+//	len(mockedServiceAccountLifecycleService.CredentialsResetCalls())
+func (mock *ServiceAccountLifecycleServiceMock) CredentialsResetCalls() []struct {
+	Ctx     context.Context
+	Account *api.ServiceAccount
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Account *api.ServiceAccount
+	}
+	mock.lockCredentialsReset.RLock()
+	calls = mock.calls.CredentialsReset
+	mock.lockCredentialsReset.RUnlock()
+	return calls
+}
+
+// Lifecycles calls LifecyclesFunc.
+func (mock *ServiceAccountLifecycleServiceMock) Lifecycles(ctx context.Context, accounts []api.ServiceAccount) (map[string]*dbapi.ServiceAccountLifecycle, *apiErrors.ServiceError) {
+	if mock.LifecyclesFunc == nil {
+		panic("ServiceAccountLifecycleServiceMock.LifecyclesFunc: method is nil but ServiceAccountLifecycleService.Lifecycles was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Accounts []api.ServiceAccount
+	}{
+		Ctx:      ctx,
+		Accounts: accounts,
+	}
+	mock.lockLifecycles.Lock()
+	mock.calls.Lifecycles = append(mock.calls.Lifecycles, callInfo)
+	mock.lockLifecycles.Unlock()
+	return mock.LifecyclesFunc(ctx, accounts)
+}
+
+// LifecyclesCalls gets all the calls that were made to Lifecycles.
+// Check the length with:
+//
+//	len(mockedServiceAccountLifecycleService.LifecyclesCalls())
+func (mock *ServiceAccountLifecycleServiceMock) LifecyclesCalls() []struct {
+	Ctx      context.Context
+	Accounts []api.ServiceAccount
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Accounts []api.ServiceAccount
+	}
+	mock.lockLifecycles.RLock()
+	calls = mock.calls.Lifecycles
+	mock.lockLifecycles.RUnlock()
+	return calls
+}
+
+// Reconcile calls ReconcileFunc.
+func (mock *ServiceAccountLifecycleServiceMock) Reconcile(ctx context.Context) (int, []error) {
+	if mock.ReconcileFunc == nil {
+		panic("ServiceAccountLifecycleServiceMock.ReconcileFunc: method is nil but ServiceAccountLifecycleService.Reconcile was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockReconcile.Lock()
+	mock.calls.Reconcile = append(mock.calls.Reconcile, callInfo)
+	mock.lockReconcile.Unlock()
+	return mock.ReconcileFunc(ctx)
+}
+
+// ReconcileCalls gets all the calls that were made to Reconcile.
+// Check the length with:
+//
+//	len(mockedServiceAccountLifecycleService.ReconcileCalls())
+func (mock *ServiceAccountLifecycleServiceMock) ReconcileCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockReconcile.RLock()
+	calls = mock.calls.Reconcile
+	mock.lockReconcile.RUnlock()
+	return calls
+}
+
+// RecordUsage calls RecordUsageFunc.
+func (mock *ServiceAccountLifecycleServiceMock) RecordUsage(clientId string, usedAt time.Time) {
+	if mock.RecordUsageFunc == nil {
+		panic("ServiceAccountLifecycleServiceMock.RecordUsageFunc: method is nil but ServiceAccountLifecycleService.RecordUsage was just called")
+	}
+	callInfo := struct {
+		ClientId string
+		UsedAt   time.Time
+	}{
+		ClientId: clientId,
+		UsedAt:   usedAt,
+	}
+	mock.lockRecordUsage.Lock()
+	mock.calls.RecordUsage = append(mock.calls.RecordUsage, callInfo)
+	mock.lockRecordUsage.Unlock()
+	mock.RecordUsageFunc(clientId, usedAt)
+}
+
+// RecordUsageCalls gets all the calls that were made to RecordUsage.
+// Check the length with:
+//
+//	len(mockedServiceAccountLifecycleService.RecordUsageCalls())
+func (mock *ServiceAccountLifecycleServiceMock) RecordUsageCalls() []struct {
+	ClientId string
+	UsedAt   time.Time
+} {
+	var calls []struct {
+		ClientId string
+		UsedAt   time.Time
+	}
+	mock.lockRecordUsage.RLock()
+	calls = mock.calls.RecordUsage
+	mock.lockRecordUsage.RUnlock()
+	return calls
+}
+
+// Track calls TrackFunc.
+func (mock *ServiceAccountLifecycleServiceMock) Track(ctx context.Context, account *api.ServiceAccount, expiresAt *time.Time) (*dbapi.ServiceAccountLifecycle, *apiErrors.ServiceError) {
+	if mock.TrackFunc == nil {
+		panic("ServiceAccountLifecycleServiceMock.TrackFunc: method is nil but ServiceAccountLifecycleService.Track was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Account   *api.ServiceAccount
+		ExpiresAt *time.Time
+	}{
+		Ctx:       ctx,
+		Account:   account,
+		ExpiresAt: expiresAt,
+	}
+	mock.lockTrack.Lock()
+	mock.calls.Track = append(mock.calls.Track, callInfo)
+	mock.lockTrack.Unlock()
+	return mock.TrackFunc(ctx, account, expiresAt)
+}
+
+// TrackCalls gets all the calls that were made to Track.
+// Check the length with:
+//
+//	len(mockedServiceAccountLifecycleService.TrackCalls())
+func (mock *ServiceAccountLifecycleServiceMock) TrackCalls() []struct {
+	Ctx       context.Context
+	Account   *api.ServiceAccount
+	ExpiresAt *time.Time
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Account   *api.ServiceAccount
+		ExpiresAt *time.Time
+	}
+	mock.lockTrack.RLock()
+	calls = mock.calls.Track
+	mock.lockTrack.RUnlock()
+	return calls
+}
+
+// Untrack calls UntrackFunc.
+func (mock *ServiceAccountLifecycleServiceMock) Untrack(id string) *apiErrors.ServiceError {
+	if mock.UntrackFunc == nil {
+		panic("ServiceAccountLifecycleServiceMock.UntrackFunc: method is nil but ServiceAccountLifecycleService.Untrack was just called")
+	}
+	callInfo := struct {
+		ID string
+	}{
+		ID: id,
+	}
+	mock.lockUntrack.Lock()
+	mock.calls.Untrack = append(mock.calls.Untrack, callInfo)
+	mock.lockUntrack.Unlock()
+	return mock.UntrackFunc(id)
+}
+
+// UntrackCalls gets all the calls that were made to Untrack.
+// Check the length with:
+//
+//	len(mockedServiceAccountLifecycleService.UntrackCalls())
+func (mock *ServiceAccountLifecycleServiceMock) UntrackCalls() []struct {
+	ID string
+} {
+	var calls []struct {
+		ID string
+	}
+	mock.lockUntrack.RLock()
+	calls = mock.calls.Untrack
+	mock.lockUntrack.RUnlock()
+	return calls
+}
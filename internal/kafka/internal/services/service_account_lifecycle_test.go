@@ -0,0 +1,388 @@
+package services
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+	"gorm.io/gorm"
+)
+
+func Test_serviceAccountLifecycleService_reconcileLifecycle(t *testing.T) {
+	now := time.Now()
+	daysAgo := func(days int) time.Time {
+		return now.Add(-time.Duration(days) * 24 * time.Hour)
+	}
+	inDays := func(days int) *time.Time {
+		t := now.Add(time.Duration(days) * 24 * time.Hour)
+		return &t
+	}
+	lifecycleConfig := &config.ServiceAccountLifecycleConfig{
+		Policies: config.ServiceAccountLifecyclePolicies{
+			Organisations: []config.ServiceAccountLifecyclePolicy{
+				{
+					OrganisationId:   "rotating-org",
+					MaxCredentialAge: 90 * 24 * time.Hour,
+					WarningPeriod:    7 * 24 * time.Hour,
+					Action:           config.ServiceAccountExpiryActionRotate,
+				},
+				{
+					OrganisationId:   "revoking-org",
+					MaxCredentialAge: 90 * 24 * time.Hour,
+					WarningPeriod:    7 * 24 * time.Hour,
+					Action:           config.ServiceAccountExpiryActionRevoke,
+				},
+			},
+		},
+	}
+	resetCredentials := func(id string) (*api.ServiceAccount, *errors.ServiceError) {
+		return &api.ServiceAccount{ID: id, ClientSecret: "new-secret"}, nil
+	}
+
+	tests := []struct {
+		name              string
+		lifecycle         *dbapi.ServiceAccountLifecycle
+		resetFunc         func(id string) (*api.ServiceAccount, *errors.ServiceError)
+		want              bool
+		wantErr           bool
+		wantEventType     string
+		wantReset         bool
+		wantStatus        string
+		wantCredsRotated  bool
+		wantExpiryWarning bool
+		wantRotatedSecret string
+	}{
+		{
+			name:       "should do nothing if nothing expires",
+			lifecycle:  &dbapi.ServiceAccountLifecycle{OrganisationId: "other-org", CredentialsRotatedAt: daysAgo(1000)},
+			wantStatus: dbapi.ServiceAccountLifecycleActive,
+		},
+		{
+			name:       "should do nothing if the credentials don't expire soon",
+			lifecycle:  &dbapi.ServiceAccountLifecycle{OrganisationId: "rotating-org", CredentialsRotatedAt: daysAgo(10)},
+			wantStatus: dbapi.ServiceAccountLifecycleActive,
+		},
+		{
+			name:              "should warn of the upcoming expiry of the credentials",
+			lifecycle:         &dbapi.ServiceAccountLifecycle{OrganisationId: "rotating-org", CredentialsRotatedAt: daysAgo(85)},
+			want:              true,
+			wantEventType:     webhooks.EventServiceAccountCredentialsExpiring,
+			wantStatus:        dbapi.ServiceAccountLifecycleActive,
+			wantExpiryWarning: true,
+		},
+		{
+			name: "should not warn twice of the upcoming expiry of the credentials",
+			lifecycle: &dbapi.ServiceAccountLifecycle{
+				OrganisationId: "rotating-org", CredentialsRotatedAt: daysAgo(85), ExpiryWarnedAt: func() *time.Time { t := daysAgo(1); return &t }(),
+			},
+			wantStatus:        dbapi.ServiceAccountLifecycleActive,
+			wantExpiryWarning: true,
+		},
+		{
+			name:              "should rotate the expired credentials and keep the new secret for the owner",
+			lifecycle:         &dbapi.ServiceAccountLifecycle{OrganisationId: "rotating-org", CredentialsRotatedAt: daysAgo(91)},
+			resetFunc:         resetCredentials,
+			want:              true,
+			wantEventType:     webhooks.EventServiceAccountCredentialsRotated,
+			wantReset:         true,
+			wantStatus:        dbapi.ServiceAccountLifecycleActive,
+			wantCredsRotated:  true,
+			wantRotatedSecret: "new-secret",
+		},
+		{
+			name: "should revoke the expired credentials of the organisations revoking them",
+			lifecycle: &dbapi.ServiceAccountLifecycle{
+				OrganisationId: "revoking-org", CredentialsRotatedAt: daysAgo(91), RotatedClientSecret: "previous-secret",
+			},
+			resetFunc:     resetCredentials,
+			want:          true,
+			wantEventType: webhooks.EventServiceAccountCredentialsRevoked,
+			wantReset:     true,
+			wantStatus:    dbapi.ServiceAccountLifecycleRevoked,
+		},
+		{
+			name: "should revoke the credentials of the expired service account",
+			lifecycle: &dbapi.ServiceAccountLifecycle{
+				OrganisationId: "rotating-org", CredentialsRotatedAt: daysAgo(10), ExpiresAt: inDays(-1),
+			},
+			resetFunc:     resetCredentials,
+			want:          true,
+			wantEventType: webhooks.EventServiceAccountCredentialsRevoked,
+			wantReset:     true,
+			wantStatus:    dbapi.ServiceAccountLifecycleRevoked,
+		},
+		{
+			name:      "should stop tracking the expired service account if it doesn't exist anymore",
+			lifecycle: &dbapi.ServiceAccountLifecycle{OrganisationId: "rotating-org", CredentialsRotatedAt: daysAgo(91)},
+			resetFunc: func(id string) (*api.ServiceAccount, *errors.ServiceError) {
+				return nil, errors.New(errors.ErrorServiceAccountNotFound, "service account not found")
+			},
+			want:       true,
+			wantReset:  true,
+			wantStatus: dbapi.ServiceAccountLifecycleActive,
+		},
+		{
+			name:      "should return an error if the credentials can't be regenerated",
+			lifecycle: &dbapi.ServiceAccountLifecycle{OrganisationId: "rotating-org", CredentialsRotatedAt: daysAgo(91)},
+			resetFunc: func(id string) (*api.ServiceAccount, *errors.ServiceError) {
+				return nil, errors.GeneralError("sso unavailable")
+			},
+			wantErr:    true,
+			wantReset:  true,
+			wantStatus: dbapi.ServiceAccountLifecycleActive,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			keycloakService := &sso.KeycloakServiceMock{
+				ResetServiceAccountCredentialsInternalFunc: tt.resetFunc,
+			}
+			webhookService := &webhooks.WebhookServiceMock{
+				EnqueueFunc: func(dbConn *gorm.DB, events ...webhooks.Event) *errors.ServiceError {
+					g.Expect(events).To(gomega.HaveLen(1))
+					g.Expect(events[0].Type).To(gomega.Equal(tt.wantEventType))
+					g.Expect(events[0].OrganisationId).To(gomega.Equal(tt.lifecycle.OrganisationId))
+					g.Expect(events[0].ResourceId).To(gomega.Equal(testID))
+					return nil
+				},
+			}
+			s := NewServiceAccountLifecycleService(db.NewMockConnectionFactory(nil), keycloakService, webhookService, lifecycleConfig).(*serviceAccountLifecycleService)
+			tt.lifecycle.ID = testID
+			tt.lifecycle.ClientId = "client-id"
+			tt.lifecycle.Status = dbapi.ServiceAccountLifecycleActive
+			rotatedAt := tt.lifecycle.CredentialsRotatedAt
+
+			got, err := s.reconcileLifecycle(tt.lifecycle, now)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(got).To(gomega.Equal(tt.want))
+			g.Expect(len(keycloakService.ResetServiceAccountCredentialsInternalCalls()) == 1).To(gomega.Equal(tt.wantReset))
+			g.Expect(len(webhookService.EnqueueCalls()) == 1).To(gomega.Equal(tt.wantEventType != ""))
+			g.Expect(tt.lifecycle.Status).To(gomega.Equal(tt.wantStatus))
+			g.Expect(tt.lifecycle.CredentialsRotatedAt.Equal(now)).To(gomega.Equal(tt.wantCredsRotated))
+			if !tt.wantCredsRotated {
+				g.Expect(tt.lifecycle.CredentialsRotatedAt).To(gomega.Equal(rotatedAt))
+			}
+			g.Expect(tt.lifecycle.ExpiryWarnedAt != nil).To(gomega.Equal(tt.wantExpiryWarning))
+			g.Expect(string(tt.lifecycle.RotatedClientSecret)).To(gomega.Equal(tt.wantRotatedSecret))
+		})
+	}
+}
+
+func Test_serviceAccountLifecycleService_CheckCredentialsReset(t *testing.T) {
+	tests := []struct {
+		name     string
+		setupFn  func()
+		wantCode errors.ServiceErrorCode
+	}{
+		{
+			name: "should allow the reset of the credentials of an active service account",
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "service_account_lifecycles"`).
+					WithReply([]map[string]interface{}{{"id": testID, "status": dbapi.ServiceAccountLifecycleActive}})
+			},
+		},
+		{
+			name: "should allow the reset of the credentials of an untracked service account",
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "service_account_lifecycles"`).WithReply(nil)
+			},
+		},
+		{
+			name: "should forbid the reset of the revoked credentials of a service account",
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "service_account_lifecycles"`).
+					WithReply([]map[string]interface{}{{"id": testID, "status": dbapi.ServiceAccountLifecycleRevoked}})
+			},
+			wantCode: errors.ErrorForbidden,
+		},
+		{
+			name: "should return an error if the lifecycle can't be read",
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "service_account_lifecycles"`).WithQueryException()
+			},
+			wantCode: errors.ErrorGeneral,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			s := NewServiceAccountLifecycleService(db.NewMockConnectionFactory(nil), &sso.KeycloakServiceMock{}, &webhooks.WebhookServiceMock{}, &config.ServiceAccountLifecycleConfig{})
+
+			err := s.CheckCredentialsReset(testID)
+			if tt.wantCode == 0 {
+				g.Expect(err).To(gomega.BeNil())
+				return
+			}
+			g.Expect(err).ToNot(gomega.BeNil())
+			g.Expect(err.Code).To(gomega.Equal(tt.wantCode))
+		})
+	}
+}
+
+func Test_serviceAccountLifecycleService_RecordUsage(t *testing.T) {
+	g := gomega.NewWithT(t)
+	mocket.Catcher.Reset()
+	s := NewServiceAccountLifecycleService(db.NewMockConnectionFactory(nil), &sso.KeycloakServiceMock{}, &webhooks.WebhookServiceMock{},
+		&config.ServiceAccountLifecycleConfig{LastUsedUpdateInterval: time.Minute}).(*serviceAccountLifecycleService)
+	now := time.Now()
+
+	s.RecordUsage("client-id", now)
+	s.RecordUsage("client-id", now.Add(30*time.Second))
+	g.Expect(s.lastUsed["client-id"]).To(gomega.Equal(now))
+
+	s.RecordUsage("client-id", now.Add(2*time.Minute))
+	g.Expect(s.lastUsed["client-id"]).To(gomega.Equal(now.Add(2 * time.Minute)))
+}
+
+func Test_serviceAccountLifecycleService_newUntrackedLifecycle(t *testing.T) {
+	now := time.Now()
+	lifecycleConfig := &config.ServiceAccountLifecycleConfig{
+		Policies: config.ServiceAccountLifecyclePolicies{
+			Organisations: []config.ServiceAccountLifecyclePolicy{
+				{
+					OrganisationId:   "rotating-org",
+					MaxCredentialAge: 90 * 24 * time.Hour,
+					WarningPeriod:    7 * 24 * time.Hour,
+					Action:           config.ServiceAccountExpiryActionRotate,
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		orgId         string
+		createdAt     time.Time
+		wantRotatedAt time.Time
+	}{
+		{
+			name:          "should track the credentials age from the creation of the service account",
+			orgId:         "rotating-org",
+			createdAt:     now.Add(-10 * 24 * time.Hour),
+			wantRotatedAt: now.Add(-10 * 24 * time.Hour),
+		},
+		{
+			name:          "should track the credentials age from now when the creation of the service account isn't known",
+			orgId:         "rotating-org",
+			wantRotatedAt: now,
+		},
+		{
+			name:          "should postpone the expiry of expired credentials to the end of the warning period",
+			orgId:         "rotating-org",
+			createdAt:     now.Add(-1000 * 24 * time.Hour),
+			wantRotatedAt: now.Add(-83 * 24 * time.Hour),
+		},
+		{
+			name:          "should postpone the expiry of credentials expiring within the warning period to its end",
+			orgId:         "rotating-org",
+			createdAt:     now.Add(-88 * 24 * time.Hour),
+			wantRotatedAt: now.Add(-83 * 24 * time.Hour),
+		},
+		{
+			name:          "should not change the credentials age when the organisation has no policy",
+			orgId:         "other-org",
+			createdAt:     now.Add(-1000 * 24 * time.Hour),
+			wantRotatedAt: now.Add(-1000 * 24 * time.Hour),
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			s := &serviceAccountLifecycleService{config: lifecycleConfig}
+			lifecycle := s.newUntrackedLifecycle(api.ServiceAccount{ID: testID, ClientID: "client-id", CreatedBy: "owner", CreatedAt: tt.createdAt}, tt.orgId, now)
+			g.Expect(lifecycle.ID).To(gomega.Equal(testID))
+			g.Expect(lifecycle.OrganisationId).To(gomega.Equal(tt.orgId))
+			g.Expect(lifecycle.Status).To(gomega.Equal(dbapi.ServiceAccountLifecycleActive))
+			g.Expect(lifecycle.CredentialsRotatedAt).To(gomega.BeTemporally("==", tt.wantRotatedAt))
+		})
+	}
+}
+
+func Test_serviceAccountLifecycleService_Backfill(t *testing.T) {
+	accounts := []api.ServiceAccount{
+		{ID: "tracked", ClientID: "srvc-acct-tracked", OrgId: "org"},
+		{ID: "untracked", ClientID: "srvc-acct-untracked", OrgId: "org"},
+		{ID: "no-org", ClientID: "srvc-acct-no-org"},
+	}
+
+	tests := []struct {
+		name        string
+		listErr     *errors.ServiceError
+		wantTracked int
+		wantInsert  bool
+		wantErr     bool
+	}{
+		{
+			name:        "should track the service accounts that aren't tracked yet",
+			wantTracked: 1,
+			wantInsert:  true,
+		},
+		{
+			name:    "should do nothing when the sso provider can't list the service accounts",
+			listErr: errors.NotImplemented("not supported"),
+		},
+		{
+			name:    "should return an error when the service accounts can't be listed",
+			listErr: errors.GeneralError("failed to list the service accounts"),
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset().NewMock().WithQuery(`SELECT "id" FROM "service_account_lifecycles" WHERE id IN ($1,$2,$3)`).
+				WithReply([]map[string]interface{}{{"id": "tracked"}})
+			var inserted []driver.NamedValue
+			insert := mocket.Catcher.NewMock().WithQuery(`INSERT INTO "service_account_lifecycles"`).WithRowsNum(1).
+				WithCallback(func(query string, args []driver.NamedValue) {
+					inserted = args
+				})
+			mocket.Catcher.NewMock().WithQueryException().WithExecException()
+
+			var listed []int
+			s := NewServiceAccountLifecycleService(db.NewMockConnectionFactory(nil), &sso.KeycloakServiceMock{
+				ListServiceAccountsInternalFunc: func(first int, max int) ([]api.ServiceAccount, *errors.ServiceError) {
+					listed = append(listed, first)
+					if tt.listErr != nil {
+						return nil, tt.listErr
+					}
+					if first > 0 {
+						return nil, nil
+					}
+					return accounts, nil
+				},
+			}, &webhooks.WebhookServiceMock{}, &config.ServiceAccountLifecycleConfig{})
+
+			tracked, err := s.Backfill(context.Background())
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(tracked).To(gomega.Equal(tt.wantTracked))
+			g.Expect(listed).To(gomega.Equal([]int{0}))
+			g.Expect(insert.Triggered).To(gomega.Equal(tt.wantInsert))
+			if tt.wantInsert {
+				g.Expect(inserted).To(gomega.ContainElement(gomega.HaveField("Value", "untracked")))
+				g.Expect(inserted).ToNot(gomega.ContainElement(gomega.HaveField("Value", "no-org")))
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db/dbencryption"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
 	"github.com/google/uuid"
 
@@ -36,7 +37,7 @@ func buildKafkaDBApiRequest() dbapi.KafkaRequest {
 		Name:                             "test-cluster",
 		Status:                           "Creating",
 		CanaryServiceAccountClientID:     uuid.NewString(),
-		CanaryServiceAccountClientSecret: uuid.NewString(),
+		CanaryServiceAccountClientSecret: dbencryption.EncryptedString(uuid.NewString()),
 		SubscriptionId:                   "test",
 		Owner:                            "unit=test-user",
 		OwnerAccountId:                   uuid.NewString(),
@@ -0,0 +1,245 @@
+package cluster_mgrs
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	fleeterrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const (
+	clusterDrainWorkerType = "cluster_drain"
+)
+
+// migratableKafkaStatuses are the statuses in which a kafka can be moved out of a draining cluster.
+// Kafkas in other statuses are waited upon until they reach one of those statuses or get deleted.
+var migratableKafkaStatuses = []string{
+	constants.KafkaRequestStatusReady.String(),
+	constants.KafkaRequestStatusProvisioning.String(),
+}
+
+// ClusterDrainManager represents a worker that periodically moves the kafkas out of the data plane clusters that are being drained
+type ClusterDrainManager struct {
+	workers.BaseWorker
+	clusterService           services.ClusterService
+	kafkaService             services.KafkaService
+	clusterPlacementStrategy services.ClusterPlacementStrategy
+	dataplaneClusterConfig   *config.DataplaneClusterConfig
+}
+
+// NewClusterDrainManager creates a new worker that drains the cordoned data plane clusters
+func NewClusterDrainManager(reconciler workers.Reconciler,
+	clusterService services.ClusterService,
+	kafkaService services.KafkaService,
+	clusterPlacementStrategy services.ClusterPlacementStrategy,
+	dataplaneClusterConfig *config.DataplaneClusterConfig) *ClusterDrainManager {
+	return &ClusterDrainManager{
+		BaseWorker: workers.BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: clusterDrainWorkerType,
+			Reconciler: reconciler,
+		},
+		clusterService:           clusterService,
+		kafkaService:             kafkaService,
+		clusterPlacementStrategy: clusterPlacementStrategy,
+		dataplaneClusterConfig:   dataplaneClusterConfig,
+	}
+}
+
+// Start initializes the worker to drain the cordoned data plane clusters
+func (m *ClusterDrainManager) Start() {
+	m.StartWorker(m)
+}
+
+// Stop causes the process for draining the cordoned data plane clusters to stop.
+func (m *ClusterDrainManager) Stop() {
+	m.StopWorker(m)
+}
+
+func (m *ClusterDrainManager) Reconcile() []error {
+	glog.Infoln("reconciling draining clusters")
+
+	var errList fleeterrors.ErrorList
+
+	cordonedClusters, err := m.clusterService.ListCordonedClusters()
+	if err != nil {
+		errList.AddErrors(errors.Wrap(err, "failed to list cordoned clusters"))
+		return errList.ToErrorSlice()
+	}
+
+	drainingClusters := []api.Cluster{}
+	globalMigrationsInProgress := 0
+	for _, cluster := range cordonedClusters {
+		if cluster.IsDraining() {
+			drainingClusters = append(drainingClusters, cluster)
+			globalMigrationsInProgress += len(cluster.RetrieveDrainInfo().MigratingKafkaIDs)
+		}
+	}
+
+	glog.Infof("draining clusters count = %d", len(drainingClusters))
+
+	// the budget of new kafka migrations that can be started during this reconciliation across all the draining clusters
+	migrationsBudget := m.dataplaneClusterConfig.ClusterDrainConfig.MaxConcurrentKafkaMigrations - globalMigrationsInProgress
+	for _, cluster := range drainingClusters {
+		startedMigrations, err := m.reconcileDrainingCluster(cluster, migrationsBudget)
+		migrationsBudget -= startedMigrations
+		if err != nil {
+			errList.AddErrors(errors.Wrapf(err, "failed to reconcile draining cluster %q", cluster.ClusterID))
+		}
+	}
+
+	return errList.ToErrorSlice()
+}
+
+// reconcileDrainingCluster updates the progress of the kafkas being moved out of the given cluster,
+// starts moving new kafkas within the given budget and marks the drain as completed once the cluster is empty.
+// It returns the number of kafka migrations started.
+func (m *ClusterDrainManager) reconcileDrainingCluster(cluster api.Cluster, migrationsBudget int) (int, error) {
+	drainInfo := cluster.RetrieveDrainInfo()
+	drainInfo.LastError = ""
+
+	if err := m.updateMigratingKafkas(cluster, &drainInfo); err != nil {
+		return 0, err
+	}
+
+	kafkas, err := m.kafkaService.ListByClusterID(cluster.ClusterID)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to list kafkas of cluster %q", cluster.ClusterID)
+	}
+
+	startedMigrations := 0
+	perClusterLimit := m.dataplaneClusterConfig.ClusterDrainConfig.MaxConcurrentKafkaMigrationsPerCluster
+	for _, kafka := range kafkas {
+		if len(drainInfo.MigratingKafkaIDs) >= perClusterLimit || startedMigrations >= migrationsBudget {
+			break
+		}
+
+		if !m.isMigratable(kafka) || arrays.Contains(drainInfo.FailedKafkaIDs, kafka.ID) {
+			continue
+		}
+
+		targetCluster, findErr := m.clusterPlacementStrategy.FindCluster(kafka)
+		if findErr != nil {
+			glog.Errorf("failed to find a cluster to move kafka %q out of draining cluster %q to: %v", kafka.ID, cluster.ClusterID, findErr)
+			drainInfo.LastError = fmt.Sprintf("failed to find a cluster to move kafka %q to: %s", kafka.ID, findErr.Error())
+			continue
+		}
+
+		if targetCluster == nil {
+			drainInfo.LastError = fmt.Sprintf("no cluster in region %q can accept kafka %q of instance type %q at this moment", kafka.Region, kafka.ID, kafka.InstanceType)
+			continue
+		}
+
+		moved, moveErr := m.moveKafkaOutOfCluster(kafka)
+		if moveErr != nil {
+			drainInfo.LastError = moveErr.Error()
+			continue
+		}
+		if !moved {
+			// the kafka left the migratable statuses since it was listed, e.g. it's being deleted
+			glog.Infof("kafka %q is no longer in a migratable status, not moving it out of draining cluster %q", kafka.ID, cluster.ClusterID)
+			continue
+		}
+
+		glog.Infof("kafka %q is being moved out of draining cluster %q", kafka.ID, cluster.ClusterID)
+		drainInfo.MigratingKafkaIDs = append(drainInfo.MigratingKafkaIDs, kafka.ID)
+		startedMigrations++
+	}
+
+	drainInfo.RemainingKafkasCount = len(kafkas) - startedMigrations
+
+	if drainInfo.RemainingKafkasCount > 0 && len(drainInfo.MigratingKafkaIDs) == 0 && migrationsBudget > 0 && drainInfo.LastError == "" {
+		drainInfo.LastError = fmt.Sprintf("%d kafkas cannot be moved in their current status and are waited upon", drainInfo.RemainingKafkasCount)
+	}
+
+	if drainInfo.RemainingKafkasCount == 0 && len(drainInfo.MigratingKafkaIDs) == 0 {
+		glog.Infof("cluster %q has been drained", cluster.ClusterID)
+		now := time.Now()
+		drainInfo.Draining = false
+		drainInfo.CompletedAt = &now
+	}
+
+	if err := cluster.SetDrainInfo(drainInfo); err != nil {
+		return startedMigrations, errors.Wrapf(err, "failed to set drain info of cluster %q", cluster.ClusterID)
+	}
+
+	if err := m.clusterService.Updates(cluster, map[string]interface{}{"drain_info": cluster.DrainInfo}); err != nil {
+		return startedMigrations, errors.Wrapf(err, "failed to update drain info of cluster %q", cluster.ClusterID)
+	}
+
+	return startedMigrations, nil
+}
+
+// updateMigratingKafkas checks the kafkas that are being moved out of the cluster and updates the drain info with the ones
+// that have either been moved successfully or failed to be moved.
+func (m *ClusterDrainManager) updateMigratingKafkas(cluster api.Cluster, drainInfo *api.ClusterDrainInfo) error {
+	stillMigrating := []string{}
+	for _, kafkaID := range drainInfo.MigratingKafkaIDs {
+		kafka, err := m.kafkaService.GetByID(kafkaID)
+		if err != nil {
+			if err.Is404() {
+				// the kafka has been deleted in the meantime, nothing to move anymore
+				continue
+			}
+			return errors.Wrapf(err, "failed to get kafka %q being moved out of cluster %q", kafkaID, cluster.ClusterID)
+		}
+
+		switch {
+		case kafka.Status == constants.KafkaRequestStatusDeprovision.String() || kafka.Status == constants.KafkaRequestStatusDeleting.String():
+			continue
+		case kafka.Status == constants.KafkaRequestStatusFailed.String():
+			glog.Warningf("kafka %q failed while being moved out of cluster %q", kafka.ID, cluster.ClusterID)
+			drainInfo.FailedKafkaIDs = append(drainInfo.FailedKafkaIDs, kafka.ID)
+		case kafka.ClusterID == cluster.ClusterID:
+			// the kafka is back in the cluster, it will be considered again as a remaining kafka
+			continue
+		case kafka.Status == constants.KafkaRequestStatusReady.String() && kafka.ClusterID != "":
+			glog.Infof("kafka %q has been moved out of cluster %q to cluster %q", kafka.ID, cluster.ClusterID, kafka.ClusterID)
+			drainInfo.MigratedKafkasCount++
+		default:
+			stillMigrating = append(stillMigrating, kafka.ID)
+		}
+	}
+
+	drainInfo.MigratingKafkaIDs = stillMigrating
+	return nil
+}
+
+func (m *ClusterDrainManager) isMigratable(kafka *dbapi.KafkaRequest) bool {
+	return arrays.Contains(migratableKafkaStatuses, kafka.Status) && !kafka.DesiredBillingModelIsEnterprise()
+}
+
+// moveKafkaOutOfCluster unassigns the kafka from its data plane cluster and puts it back into provisioning state
+// so that it gets reassigned to another cluster by the provisioning kafkas worker.
+// A new placement id is generated to let the data plane know that this is a new placement of the kafka.
+// The kafka is only moved while it's in a migratable status, false is returned otherwise.
+func (m *ClusterDrainManager) moveKafkaOutOfCluster(kafka *dbapi.KafkaRequest) (bool, error) {
+	moved, err := m.kafkaService.UpdatesIfStatusIn(kafka, migratableKafkaStatuses, map[string]interface{}{
+		"cluster_id":                "",
+		"status":                    constants.KafkaRequestStatusProvisioning.String(),
+		"placement_id":              api.NewID(),
+		"bootstrap_server_host":     "",
+		"admin_api_server_url":      "",
+		"routes":                    nil,
+		"routes_created":            false,
+		"routes_creation_id":        "",
+		"desired_strimzi_version":   "",
+		"desired_kafka_version":     "",
+		"desired_kafka_ibp_version": "",
+	})
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to unassign kafka %q from its cluster", kafka.ID)
+	}
+
+	return moved, nil
+}
@@ -0,0 +1,346 @@
+package cluster_mgrs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	apiErrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/onsi/gomega"
+)
+
+func buildDrainingCluster(clusterID string, drainInfo api.ClusterDrainInfo) api.Cluster {
+	now := time.Now()
+	drainInfo.Draining = true
+	drainInfo.StartedAt = &now
+	cluster := api.Cluster{
+		Meta:      api.Meta{ID: clusterID},
+		ClusterID: clusterID,
+		Status:    api.ClusterReady,
+		Cordoned:  true,
+	}
+	_ = cluster.SetDrainInfo(drainInfo)
+	return cluster
+}
+
+func TestClusterDrainManager_Reconcile(t *testing.T) {
+	type fields struct {
+		clusterService           *services.ClusterServiceMock
+		kafkaService             *services.KafkaServiceMock
+		clusterPlacementStrategy *services.ClusterPlacementStrategyMock
+		drainConfig              config.ClusterDrainConfig
+	}
+
+	targetCluster := &api.Cluster{ClusterID: "target-cluster"}
+	readyKafka := func(id string) *dbapi.KafkaRequest {
+		return &dbapi.KafkaRequest{
+			Meta:      api.Meta{ID: id},
+			ClusterID: "draining-cluster",
+			Status:    constants.KafkaRequestStatusReady.String(),
+		}
+	}
+
+	tests := []struct {
+		name                  string
+		fields                fields
+		wantErr               bool
+		wantMovedKafkaIDs     []string
+		wantDrainInfoMatchers func(g gomega.Gomega, drainInfo api.ClusterDrainInfo)
+	}{
+		{
+			name: "should return an error when listing cordoned clusters fails",
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					ListCordonedClustersFunc: func() ([]api.Cluster, *apiErrors.ServiceError) {
+						return nil, apiErrors.GeneralError("failed to list cordoned clusters")
+					},
+				},
+				kafkaService:             &services.KafkaServiceMock{},
+				clusterPlacementStrategy: &services.ClusterPlacementStrategyMock{},
+				drainConfig:              config.NewClusterDrainConfig(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "should ignore cordoned clusters that are not being drained",
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					ListCordonedClustersFunc: func() ([]api.Cluster, *apiErrors.ServiceError) {
+						return []api.Cluster{{ClusterID: "cordoned-cluster", Cordoned: true}}, nil
+					},
+				},
+				kafkaService:             &services.KafkaServiceMock{},
+				clusterPlacementStrategy: &services.ClusterPlacementStrategyMock{},
+				drainConfig:              config.NewClusterDrainConfig(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "should move kafkas out of the cluster within the per cluster limit",
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					ListCordonedClustersFunc: func() ([]api.Cluster, *apiErrors.ServiceError) {
+						return []api.Cluster{buildDrainingCluster("draining-cluster", api.ClusterDrainInfo{})}, nil
+					},
+					UpdatesFunc: func(cluster api.Cluster, values map[string]interface{}) *apiErrors.ServiceError {
+						return nil
+					},
+				},
+				kafkaService: &services.KafkaServiceMock{
+					ListByClusterIDFunc: func(clusterID string) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+						return []*dbapi.KafkaRequest{readyKafka("kafka-1"), readyKafka("kafka-2")}, nil
+					},
+					UpdatesIfStatusInFunc: func(kafkaRequest *dbapi.KafkaRequest, statuses []string, values map[string]interface{}) (bool, *apiErrors.ServiceError) {
+						return true, nil
+					},
+				},
+				clusterPlacementStrategy: &services.ClusterPlacementStrategyMock{
+					FindClusterFunc: func(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
+						return targetCluster, nil
+					},
+				},
+				drainConfig: config.ClusterDrainConfig{
+					MaxConcurrentKafkaMigrationsPerCluster: 1,
+					MaxConcurrentKafkaMigrations:           5,
+				},
+			},
+			wantErr:           false,
+			wantMovedKafkaIDs: []string{"kafka-1"},
+			wantDrainInfoMatchers: func(g gomega.Gomega, drainInfo api.ClusterDrainInfo) {
+				g.Expect(drainInfo.Draining).To(gomega.BeTrue())
+				g.Expect(drainInfo.MigratingKafkaIDs).To(gomega.Equal([]string{"kafka-1"}))
+				g.Expect(drainInfo.RemainingKafkasCount).To(gomega.Equal(1))
+			},
+		},
+		{
+			name: "should skip the kafkas that left the migratable statuses since they were listed",
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					ListCordonedClustersFunc: func() ([]api.Cluster, *apiErrors.ServiceError) {
+						return []api.Cluster{buildDrainingCluster("draining-cluster", api.ClusterDrainInfo{})}, nil
+					},
+					UpdatesFunc: func(cluster api.Cluster, values map[string]interface{}) *apiErrors.ServiceError {
+						return nil
+					},
+				},
+				kafkaService: &services.KafkaServiceMock{
+					ListByClusterIDFunc: func(clusterID string) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+						return []*dbapi.KafkaRequest{readyKafka("deleting"), readyKafka("kafka-2")}, nil
+					},
+					UpdatesIfStatusInFunc: func(kafkaRequest *dbapi.KafkaRequest, statuses []string, values map[string]interface{}) (bool, *apiErrors.ServiceError) {
+						return kafkaRequest.ID != "deleting", nil
+					},
+				},
+				clusterPlacementStrategy: &services.ClusterPlacementStrategyMock{
+					FindClusterFunc: func(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
+						return targetCluster, nil
+					},
+				},
+				drainConfig: config.ClusterDrainConfig{
+					MaxConcurrentKafkaMigrationsPerCluster: 1,
+					MaxConcurrentKafkaMigrations:           5,
+				},
+			},
+			wantErr:           false,
+			wantMovedKafkaIDs: []string{"kafka-2"},
+			wantDrainInfoMatchers: func(g gomega.Gomega, drainInfo api.ClusterDrainInfo) {
+				g.Expect(drainInfo.MigratingKafkaIDs).To(gomega.Equal([]string{"kafka-2"}))
+			},
+		},
+		{
+			name: "should not move kafkas when the global limit has been reached",
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					ListCordonedClustersFunc: func() ([]api.Cluster, *apiErrors.ServiceError) {
+						return []api.Cluster{buildDrainingCluster("draining-cluster", api.ClusterDrainInfo{MigratingKafkaIDs: []string{"kafka-0"}})}, nil
+					},
+					UpdatesFunc: func(cluster api.Cluster, values map[string]interface{}) *apiErrors.ServiceError {
+						return nil
+					},
+				},
+				kafkaService: &services.KafkaServiceMock{
+					GetByIDFunc: func(id string) (*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+						return &dbapi.KafkaRequest{Meta: api.Meta{ID: id}, Status: constants.KafkaRequestStatusProvisioning.String()}, nil
+					},
+					ListByClusterIDFunc: func(clusterID string) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+						return []*dbapi.KafkaRequest{readyKafka("kafka-1")}, nil
+					},
+				},
+				clusterPlacementStrategy: &services.ClusterPlacementStrategyMock{},
+				drainConfig: config.ClusterDrainConfig{
+					MaxConcurrentKafkaMigrationsPerCluster: 1,
+					MaxConcurrentKafkaMigrations:           1,
+				},
+			},
+			wantErr: false,
+			wantDrainInfoMatchers: func(g gomega.Gomega, drainInfo api.ClusterDrainInfo) {
+				g.Expect(drainInfo.MigratingKafkaIDs).To(gomega.Equal([]string{"kafka-0"}))
+				g.Expect(drainInfo.RemainingKafkasCount).To(gomega.Equal(1))
+			},
+		},
+		{
+			name: "should record the moved and failed kafkas and skip the ones that cannot be moved",
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					ListCordonedClustersFunc: func() ([]api.Cluster, *apiErrors.ServiceError) {
+						return []api.Cluster{buildDrainingCluster("draining-cluster", api.ClusterDrainInfo{MigratingKafkaIDs: []string{"moved", "failed", "deleted"}})}, nil
+					},
+					UpdatesFunc: func(cluster api.Cluster, values map[string]interface{}) *apiErrors.ServiceError {
+						return nil
+					},
+				},
+				kafkaService: &services.KafkaServiceMock{
+					GetByIDFunc: func(id string) (*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+						switch id {
+						case "moved":
+							return &dbapi.KafkaRequest{Meta: api.Meta{ID: id}, ClusterID: "target-cluster", Status: constants.KafkaRequestStatusReady.String()}, nil
+						case "failed":
+							return &dbapi.KafkaRequest{Meta: api.Meta{ID: id}, Status: constants.KafkaRequestStatusFailed.String()}, nil
+						default:
+							return nil, apiErrors.NotFound("not found")
+						}
+					},
+					ListByClusterIDFunc: func(clusterID string) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+						suspended := readyKafka("suspended")
+						suspended.Status = constants.KafkaRequestStatusSuspended.String()
+						enterprise := readyKafka("enterprise")
+						enterprise.DesiredKafkaBillingModel = constants.BillingModelEnterprise.String()
+						return []*dbapi.KafkaRequest{suspended, enterprise}, nil
+					},
+				},
+				clusterPlacementStrategy: &services.ClusterPlacementStrategyMock{},
+				drainConfig:              config.NewClusterDrainConfig(),
+			},
+			wantErr: false,
+			wantDrainInfoMatchers: func(g gomega.Gomega, drainInfo api.ClusterDrainInfo) {
+				g.Expect(drainInfo.Draining).To(gomega.BeTrue())
+				g.Expect(drainInfo.MigratingKafkaIDs).To(gomega.BeEmpty())
+				g.Expect(drainInfo.MigratedKafkasCount).To(gomega.Equal(1))
+				g.Expect(drainInfo.FailedKafkaIDs).To(gomega.Equal([]string{"failed"}))
+				g.Expect(drainInfo.RemainingKafkasCount).To(gomega.Equal(2))
+				g.Expect(drainInfo.LastError).ToNot(gomega.BeEmpty())
+			},
+		},
+		{
+			name: "should report an error in the drain info when no cluster can accept the kafka",
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					ListCordonedClustersFunc: func() ([]api.Cluster, *apiErrors.ServiceError) {
+						return []api.Cluster{buildDrainingCluster("draining-cluster", api.ClusterDrainInfo{})}, nil
+					},
+					UpdatesFunc: func(cluster api.Cluster, values map[string]interface{}) *apiErrors.ServiceError {
+						return nil
+					},
+				},
+				kafkaService: &services.KafkaServiceMock{
+					ListByClusterIDFunc: func(clusterID string) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+						return []*dbapi.KafkaRequest{readyKafka("kafka-1")}, nil
+					},
+				},
+				clusterPlacementStrategy: &services.ClusterPlacementStrategyMock{
+					FindClusterFunc: func(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
+						return nil, nil
+					},
+				},
+				drainConfig: config.NewClusterDrainConfig(),
+			},
+			wantErr: false,
+			wantDrainInfoMatchers: func(g gomega.Gomega, drainInfo api.ClusterDrainInfo) {
+				g.Expect(drainInfo.Draining).To(gomega.BeTrue())
+				g.Expect(drainInfo.MigratingKafkaIDs).To(gomega.BeEmpty())
+				g.Expect(drainInfo.LastError).To(gomega.ContainSubstring("kafka-1"))
+			},
+		},
+		{
+			name: "should complete the drain once the cluster is empty",
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					ListCordonedClustersFunc: func() ([]api.Cluster, *apiErrors.ServiceError) {
+						return []api.Cluster{buildDrainingCluster("draining-cluster", api.ClusterDrainInfo{MigratingKafkaIDs: []string{"moved"}})}, nil
+					},
+					UpdatesFunc: func(cluster api.Cluster, values map[string]interface{}) *apiErrors.ServiceError {
+						return nil
+					},
+				},
+				kafkaService: &services.KafkaServiceMock{
+					GetByIDFunc: func(id string) (*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+						return &dbapi.KafkaRequest{Meta: api.Meta{ID: id}, ClusterID: "target-cluster", Status: constants.KafkaRequestStatusReady.String()}, nil
+					},
+					ListByClusterIDFunc: func(clusterID string) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+						return []*dbapi.KafkaRequest{}, nil
+					},
+				},
+				clusterPlacementStrategy: &services.ClusterPlacementStrategyMock{},
+				drainConfig:              config.NewClusterDrainConfig(),
+			},
+			wantErr: false,
+			wantDrainInfoMatchers: func(g gomega.Gomega, drainInfo api.ClusterDrainInfo) {
+				g.Expect(drainInfo.Draining).To(gomega.BeFalse())
+				g.Expect(drainInfo.CompletedAt).ToNot(gomega.BeNil())
+				g.Expect(drainInfo.MigratedKafkasCount).To(gomega.Equal(1))
+				g.Expect(drainInfo.RemainingKafkasCount).To(gomega.Equal(0))
+			},
+		},
+		{
+			name: "should return an error when listing the kafkas of the cluster fails",
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					ListCordonedClustersFunc: func() ([]api.Cluster, *apiErrors.ServiceError) {
+						return []api.Cluster{buildDrainingCluster("draining-cluster", api.ClusterDrainInfo{})}, nil
+					},
+				},
+				kafkaService: &services.KafkaServiceMock{
+					ListByClusterIDFunc: func(clusterID string) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+						return nil, apiErrors.GeneralError("failed to list kafkas")
+					},
+				},
+				clusterPlacementStrategy: &services.ClusterPlacementStrategyMock{},
+				drainConfig:              config.NewClusterDrainConfig(),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			m := &ClusterDrainManager{
+				clusterService:           tt.fields.clusterService,
+				kafkaService:             tt.fields.kafkaService,
+				clusterPlacementStrategy: tt.fields.clusterPlacementStrategy,
+				dataplaneClusterConfig: &config.DataplaneClusterConfig{
+					ClusterDrainConfig: tt.fields.drainConfig,
+				},
+			}
+
+			errs := m.Reconcile()
+			g.Expect(len(errs) > 0).To(gomega.Equal(tt.wantErr))
+
+			movedKafkaIDs := []string{}
+			for _, call := range tt.fields.kafkaService.UpdatesIfStatusInCalls() {
+				g.Expect(call.Statuses).To(gomega.Equal(migratableKafkaStatuses))
+				g.Expect(call.Values["cluster_id"]).To(gomega.Equal(""))
+				g.Expect(call.Values["status"]).To(gomega.Equal(constants.KafkaRequestStatusProvisioning.String()))
+				if call.KafkaRequest.ID != "deleting" {
+					movedKafkaIDs = append(movedKafkaIDs, call.KafkaRequest.ID)
+				}
+			}
+			if tt.wantMovedKafkaIDs != nil {
+				g.Expect(movedKafkaIDs).To(gomega.Equal(tt.wantMovedKafkaIDs))
+			}
+
+			if tt.wantDrainInfoMatchers != nil {
+				updates := tt.fields.clusterService.UpdatesCalls()
+				g.Expect(updates).To(gomega.HaveLen(1))
+				cluster := updates[0].Cluster
+				cluster.DrainInfo = updates[0].Values["drain_info"].(api.JSON)
+				tt.wantDrainInfoMatchers(g, cluster.RetrieveDrainInfo())
+			}
+		})
+	}
+}
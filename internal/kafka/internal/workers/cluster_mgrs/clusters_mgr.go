@@ -3,6 +3,7 @@ package cluster_mgrs
 import (
 	"fmt"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db/dbencryption"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
 
 	kafkaConstants "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
@@ -571,7 +572,7 @@ func (c *ClusterManager) reconcileKasFleetshardOperator(cluster api.Cluster) err
 	} else {
 		if cluster.ClientID == "" || cluster.ClientSecret == "" {
 			cluster.ClientID = params.GetParam(services.KasFleetshardOperatorParamServiceAccountId)
-			cluster.ClientSecret = params.GetParam(services.KasFleetshardOperatorParamServiceAccountSecret)
+			cluster.ClientSecret = dbencryption.EncryptedString(params.GetParam(services.KasFleetshardOperatorParamServiceAccountSecret))
 			if err := c.ClusterService.Update(cluster); err != nil {
 				return errors.WithMessagef(err, "failed to reconcile clientID of %s cluster %s: %s", cluster.Status, cluster.ClusterID, err.Error())
 			}
@@ -635,7 +636,7 @@ func (c *ClusterManager) reconcileAddonOperator(provisionedCluster api.Cluster)
 
 	if provisionedCluster.ClientID == "" || provisionedCluster.ClientSecret == "" {
 		provisionedCluster.ClientID = params.GetParam(services.KasFleetshardOperatorParamServiceAccountId)
-		provisionedCluster.ClientSecret = params.GetParam(services.KasFleetshardOperatorParamServiceAccountSecret)
+		provisionedCluster.ClientSecret = dbencryption.EncryptedString(params.GetParam(services.KasFleetshardOperatorParamServiceAccountSecret))
 		if err := c.ClusterService.Update(provisionedCluster); err != nil {
 			return false, errors.WithMessagef(err, "failed to reconcile clientID of %s cluster %s: %s", provisionedCluster.Status, provisionedCluster.ClusterID, err.Error())
 		}
@@ -891,7 +892,7 @@ func (c *ClusterManager) buildObservabilityRemoteWriteServiceAccountCredential(c
 		},
 		StringData: map[string]string{
 			"client_id":     cluster.ClientID,
-			"client_secret": cluster.ClientSecret,
+			"client_secret": string(cluster.ClientSecret),
 			"issuer_url":    c.SsoService.GetRealmConfig().ValidIssuerURI,
 		},
 	}
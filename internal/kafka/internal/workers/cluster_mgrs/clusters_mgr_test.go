@@ -2433,7 +2433,7 @@ func buildResourceSet(observabilityConfig observatorium.ObservabilityConfigurati
 			},
 			StringData: map[string]string{
 				"client_id":     cluster.ClientID,
-				"client_secret": cluster.ClientSecret,
+				"client_secret": string(cluster.ClientSecret),
 				"issuer_url":    "dummy",
 			},
 		})
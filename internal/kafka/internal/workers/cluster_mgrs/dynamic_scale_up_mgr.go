@@ -27,6 +27,7 @@ type DynamicScaleUpManager struct {
 }
 
 var _ workers.Worker = &DynamicScaleUpManager{}
+var _ services.DataPlaneClusterScaleUpProjector = &DynamicScaleUpManager{}
 
 func NewDynamicScaleUpManager(
 	reconciler workers.Reconciler,
@@ -68,7 +69,7 @@ func (m *DynamicScaleUpManager) Reconcile() []error {
 
 	glog.Infoln("running dynamic scale up reconcile event")
 
-	err := m.processDynamicScaleUpReconcileEvent()
+	err := m.processDynamicScaleUpReconcileEvent(m.ClusterService, !m.DataplaneClusterConfig.DynamicScalingConfig.IsDataplaneScaleUpTriggerEnabled())
 	if err != nil {
 		errList.AddErrors(err)
 	}
@@ -77,11 +78,18 @@ func (m *DynamicScaleUpManager) Reconcile() []error {
 	return errList.ToErrorSlice()
 }
 
+// ProjectScaleUp evaluates the dynamic scale up against the capacity served by the given cluster service
+// and registers the data plane clusters that would be created in it, regardless of whether the scale up trigger is enabled.
+// It is used to simulate the dynamic scaling against a snapshot of the capacity of the data plane clusters.
+func (m *DynamicScaleUpManager) ProjectScaleUp(clusterService services.ClusterService) error {
+	return m.processDynamicScaleUpReconcileEvent(clusterService, false)
+}
+
 // Note. "enterprise" clusters are not taken into consideration when
 // scaling up/ down with dynamic scaling turned on
-func (m *DynamicScaleUpManager) processDynamicScaleUpReconcileEvent() error {
+func (m *DynamicScaleUpManager) processDynamicScaleUpReconcileEvent(clusterService services.ClusterService, dryRun bool) error {
 	var errList fleeterrors.ErrorList
-	kafkaStreamingUnitCountPerClusterList, err := m.ClusterService.FindStreamingUnitCountByClusterAndInstanceType()
+	kafkaStreamingUnitCountPerClusterList, err := clusterService.FindStreamingUnitCountByClusterAndInstanceType()
 	if err != nil {
 		errList.AddErrors(err)
 		return errList
@@ -102,8 +110,8 @@ func (m *DynamicScaleUpManager) processDynamicScaleUpReconcileEvent() error {
 					instanceTypeConfig:                    &supportedInstanceTypeConfig,
 					kafkaStreamingUnitCountPerClusterList: kafkaStreamingUnitCountPerClusterList,
 					supportedKafkaInstanceTypesConfig:     &m.KafkaConfig.SupportedInstanceTypes.Configuration,
-					clusterService:                        m.ClusterService,
-					dryRun:                                !m.DataplaneClusterConfig.DynamicScalingConfig.IsDataplaneScaleUpTriggerEnabled(),
+					clusterService:                        clusterService,
+					dryRun:                                dryRun,
 				}
 				glog.Infof("evaluating dynamic scale up for locator '%+v'", currLocator)
 				shouldScaleUp, err := dynamicScaleUpProcessor.ShouldScaleUp()
@@ -377,6 +385,8 @@ type instanceTypeConsumptionSummaryCalculator struct {
 // For the calculation of the max streaming units capacity:
 //   - Clusters in deprovisioning and cleanup state are excluded, as
 //     clusters into those states don't accept kafka instances anymore.
+//   - Cordoned clusters are excluded, as they don't accept kafka instances
+//     anymore.
 //   - Clusters that are still not ready to accept kafka instance but that
 //     should eventually accept them (like accepted state for example)
 //     are included
@@ -427,6 +437,11 @@ func (i *instanceTypeConsumptionSummaryCalculator) Calculate() (instanceTypeCons
 			continue
 		}
 
+		// ignore cordoned clusters as they can't accept kafka anymore
+		if kafkaStreamingUnitCountPerCluster.Cordoned {
+			continue
+		}
+
 		if kafkaStreamingUnitCountPerCluster.FreeStreamingUnits() >= int32(biggestKafkaInstanceSizeCapacityConsumption) {
 			atLeastOneClusterHasCapacityForBiggestInstanceType = true
 		}
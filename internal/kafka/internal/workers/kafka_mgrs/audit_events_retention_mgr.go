@@ -0,0 +1,15 @@
+package kafka_mgrs
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/audit"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+)
+
+const (
+	auditEventsRetentionWorkerType = "audit_events_retention"
+)
+
+// NewAuditEventsRetentionManager creates a new worker that deletes the expired audit events of the kafkas_mgmt API
+func NewAuditEventsRetentionManager(reconciler workers.Reconciler, auditEventsService audit.AuditEventsService, auditConfig *audit.AuditConfig) *audit.RetentionWorker {
+	return audit.NewRetentionWorker(auditEventsRetentionWorkerType, audit.ServiceKafkas, auditEventsService, auditConfig, reconciler)
+}
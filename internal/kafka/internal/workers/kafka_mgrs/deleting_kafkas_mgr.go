@@ -9,30 +9,26 @@ import (
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 
-	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
-
 	"github.com/golang/glog"
 )
 
 // DeletingKafkaManager represents a kafka manager that periodically reconciles deleting and deprovision kafka requests.
 type DeletingKafkaManager struct {
 	workers.BaseWorker
-	kafkaService        services.KafkaService
-	keycloakConfig      *keycloak.KeycloakConfig
-	quotaServiceFactory services.QuotaServiceFactory
+	kafkaService   services.KafkaService
+	keycloakConfig *keycloak.KeycloakConfig
 }
 
 // NewDeletingKafkaManager creates a new kafka manager to reconcile deleting and deprovision kafkas.
-func NewDeletingKafkaManager(kafkaService services.KafkaService, keycloakConfig *keycloak.KeycloakConfig, quotaServiceFactory services.QuotaServiceFactory, reconciler workers.Reconciler) *DeletingKafkaManager {
+func NewDeletingKafkaManager(kafkaService services.KafkaService, keycloakConfig *keycloak.KeycloakConfig, reconciler workers.Reconciler) *DeletingKafkaManager {
 	return &DeletingKafkaManager{
 		BaseWorker: workers.BaseWorker{
 			Id:         uuid.New().String(),
 			WorkerType: "deleting_kafka",
 			Reconciler: reconciler,
 		},
-		kafkaService:        kafkaService,
-		keycloakConfig:      keycloakConfig,
-		quotaServiceFactory: quotaServiceFactory,
+		kafkaService:   kafkaService,
+		keycloakConfig: keycloakConfig,
 	}
 }
 
@@ -54,6 +50,7 @@ func (k *DeletingKafkaManager) Reconcile() []error {
 	// Kafkas in a "deleting" state have been removed, along with all their resources (i.e. ManagedKafka, Kafka CRs),
 	// from the data plane cluster by the KAS Fleetshard operator. This reconcile phase ensures that any other
 	// dependencies (i.e. SSO clients, CNAME records) are cleaned up for these Kafkas and their records soft deleted from the database.
+	// The clean up of the dependencies is added to the outbox in the transaction soft deleting the Kafkas.
 
 	deletingKafkas, serviceErr := k.kafkaService.ListByStatus(constants.KafkaRequestStatusDeleting)
 	originalTotalKafkaInDeleting := len(deletingKafkas)
@@ -93,15 +90,7 @@ func (k *DeletingKafkaManager) Reconcile() []error {
 }
 
 func (k *DeletingKafkaManager) reconcileDeletingKafkas(kafka *dbapi.KafkaRequest) error {
-	quotaService, factoryErr := k.quotaServiceFactory.GetQuotaService(api.QuotaType(kafka.QuotaType))
-	if factoryErr != nil {
-		return factoryErr
-	}
-	err := quotaService.DeleteQuota(kafka.SubscriptionId)
-	if err != nil {
-		return errors.Wrapf(err, "failed to delete subscription id %s for kafka %s", kafka.SubscriptionId, kafka.ID)
-	}
-
+	// the quota, SSO clients and CNAME records are deleted by the outbox worker once the kafka is deleted
 	if err := k.kafkaService.Delete(kafka); err != nil {
 		return errors.Wrapf(err, "failed to delete kafka %s", kafka.ID)
 	}
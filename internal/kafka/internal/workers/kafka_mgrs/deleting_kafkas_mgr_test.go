@@ -1,16 +1,13 @@
 package kafka_mgrs
 
 import (
-	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
 	"testing"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
-	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/kafkas/types"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
 	mockKafkas "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/test/mocks/kafkas"
 
-	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/keycloak"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	w "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
@@ -21,7 +18,6 @@ import (
 func TestDeletingKafkaManager_Reconcile(t *testing.T) {
 	type fields struct {
 		kafkaService   services.KafkaService
-		quotaService   services.QuotaService
 		keycloakConfig *keycloak.KeycloakConfig
 	}
 	tests := []struct {
@@ -63,13 +59,8 @@ func TestDeletingKafkaManager_Reconcile(t *testing.T) {
 							),
 						}, nil
 					},
-				},
-				quotaService: &services.QuotaServiceMock{
-					DeleteQuotaFunc: func(id string) *errors.ServiceError {
-						return errors.GeneralError("failed to delete quota")
-					},
-					CheckIfQuotaIsDefinedForInstanceTypeFunc: func(username string, externalId string, instanceType types.KafkaInstanceType, billingModel config.KafkaBillingModel) (bool, *errors.ServiceError) {
-						return true, nil
+					DeleteFunc: func(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+						return errors.GeneralError("failed to delete kafka request")
 					},
 				},
 				keycloakConfig: &keycloak.KeycloakConfig{
@@ -97,14 +88,6 @@ func TestDeletingKafkaManager_Reconcile(t *testing.T) {
 						return nil
 					},
 				},
-				quotaService: &services.QuotaServiceMock{
-					DeleteQuotaFunc: func(id string) *errors.ServiceError {
-						return nil
-					},
-					CheckIfQuotaIsDefinedForInstanceTypeFunc: func(username string, externalId string, instanceType types.KafkaInstanceType, billingModel config.KafkaBillingModel) (bool, *errors.ServiceError) {
-						return true, nil
-					},
-				},
 				keycloakConfig: enabledAuthKeycloakConfig,
 			},
 			wantErr: false,
@@ -118,11 +101,6 @@ func TestDeletingKafkaManager_Reconcile(t *testing.T) {
 			g := gomega.NewWithT(t)
 			k := NewDeletingKafkaManager(tt.fields.kafkaService,
 				tt.fields.keycloakConfig,
-				&services.QuotaServiceFactoryMock{
-					GetQuotaServiceFunc: func(quotaType api.QuotaType) (services.QuotaService, *errors.ServiceError) {
-						return tt.fields.quotaService, nil
-					},
-				},
 				w.Reconciler{})
 			g.Expect(len(k.Reconcile()) > 0).To(gomega.Equal(tt.wantErr))
 		})
@@ -132,7 +110,6 @@ func TestDeletingKafkaManager_Reconcile(t *testing.T) {
 func TestDeletingKafkaManager_reconcileDeletingKafkas(t *testing.T) {
 	type fields struct {
 		kafkaService services.KafkaService
-		quotaService services.QuotaService
 	}
 	type args struct {
 		kafka *dbapi.KafkaRequest
@@ -154,11 +131,6 @@ func TestDeletingKafkaManager_reconcileDeletingKafkas(t *testing.T) {
 						return nil
 					},
 				},
-				quotaService: &services.QuotaServiceMock{
-					DeleteQuotaFunc: func(id string) *errors.ServiceError {
-						return nil
-					},
-				},
 			},
 		},
 		{
@@ -172,30 +144,6 @@ func TestDeletingKafkaManager_reconcileDeletingKafkas(t *testing.T) {
 						return errors.GeneralError("failed to delete kafka request")
 					},
 				},
-				quotaService: &services.QuotaServiceMock{
-					DeleteQuotaFunc: func(id string) *errors.ServiceError {
-						return nil
-					},
-				},
-			},
-			wantErr: true,
-		},
-		{
-			name: "should fail if deleting quota fails",
-			args: args{
-				kafka: &dbapi.KafkaRequest{},
-			},
-			fields: fields{
-				kafkaService: &services.KafkaServiceMock{
-					DeleteFunc: func(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
-						return nil
-					},
-				},
-				quotaService: &services.QuotaServiceMock{
-					DeleteQuotaFunc: func(id string) *errors.ServiceError {
-						return errors.GeneralError("failed to delete quota")
-					},
-				},
 			},
 			wantErr: true,
 		},
@@ -208,11 +156,6 @@ func TestDeletingKafkaManager_reconcileDeletingKafkas(t *testing.T) {
 			g := gomega.NewWithT(t)
 			k := &DeletingKafkaManager{
 				kafkaService: tt.fields.kafkaService,
-				quotaServiceFactory: &services.QuotaServiceFactoryMock{
-					GetQuotaServiceFunc: func(quotaType api.QuotaType) (services.QuotaService, *errors.ServiceError) {
-						return tt.fields.quotaService, nil
-					},
-				},
 			}
 			g.Expect(k.reconcileDeletingKafkas(tt.args.kafka) != nil).To(gomega.Equal(tt.wantErr))
 		})
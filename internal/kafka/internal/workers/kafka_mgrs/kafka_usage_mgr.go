@@ -0,0 +1,66 @@
+package kafka_mgrs
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const (
+	kafkaUsageWorkerType = "kafka_usage"
+)
+
+// KafkaUsageManager represents a worker that records the daily usage of the kafkas of every organisation
+type KafkaUsageManager struct {
+	workers.BaseWorker
+	kafkaUsageService services.KafkaUsageService
+}
+
+// NewKafkaUsageManager creates a new worker that records the daily usage of the kafkas
+func NewKafkaUsageManager(reconciler workers.Reconciler, kafkaUsageService services.KafkaUsageService) *KafkaUsageManager {
+	return &KafkaUsageManager{
+		BaseWorker: workers.BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: kafkaUsageWorkerType,
+			Reconciler: reconciler,
+		},
+		kafkaUsageService: kafkaUsageService,
+	}
+}
+
+// Start initializes the worker to record the daily usage of the kafkas
+func (m *KafkaUsageManager) Start() {
+	m.StartWorker(m)
+}
+
+// Stop causes the process for recording the daily usage of the kafkas to stop.
+func (m *KafkaUsageManager) Stop() {
+	m.StopWorker(m)
+}
+
+// Reconcile records the usage of the current UTC day the first time it runs during that day.
+// The recorded usage is a snapshot of the kafkas at that time.
+func (m *KafkaUsageManager) Reconcile() []error {
+	day := services.TruncateToUTCDay(time.Now())
+
+	recorded, err := m.kafkaUsageService.IsDailyUsageRecorded(day)
+	if err != nil {
+		return []error{errors.Wrapf(err, "failed to check whether the kafka usage of %s is recorded", day.Format(services.UsageDateLayout))}
+	}
+
+	if recorded {
+		glog.V(10).Infof("kafka usage of %s is already recorded", day.Format(services.UsageDateLayout))
+		return nil
+	}
+
+	glog.Infof("recording kafka usage of %s", day.Format(services.UsageDateLayout))
+	if err := m.kafkaUsageService.RecordDailyUsage(day); err != nil {
+		return []error{errors.Wrapf(err, "failed to record the kafka usage of %s", day.Format(services.UsageDateLayout))}
+	}
+
+	return nil
+}
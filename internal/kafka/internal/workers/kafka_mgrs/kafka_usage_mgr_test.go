@@ -0,0 +1,83 @@
+package kafka_mgrs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/onsi/gomega"
+)
+
+func TestKafkaUsageManager_Reconcile(t *testing.T) {
+	tests := []struct {
+		name              string
+		kafkaUsageService *services.KafkaUsageServiceMock
+		wantErr           bool
+		wantRecorded      bool
+	}{
+		{
+			name: "should record the usage of the day if it is not recorded yet",
+			kafkaUsageService: &services.KafkaUsageServiceMock{
+				IsDailyUsageRecordedFunc: func(day time.Time) (bool, *errors.ServiceError) {
+					return false, nil
+				},
+				RecordDailyUsageFunc: func(day time.Time) *errors.ServiceError {
+					return nil
+				},
+			},
+			wantRecorded: true,
+		},
+		{
+			name: "should not record the usage of the day again",
+			kafkaUsageService: &services.KafkaUsageServiceMock{
+				IsDailyUsageRecordedFunc: func(day time.Time) (bool, *errors.ServiceError) {
+					return true, nil
+				},
+			},
+			wantRecorded: false,
+		},
+		{
+			name: "should return an error if checking whether the usage is recorded fails",
+			kafkaUsageService: &services.KafkaUsageServiceMock{
+				IsDailyUsageRecordedFunc: func(day time.Time) (bool, *errors.ServiceError) {
+					return false, errors.GeneralError("failed to check")
+				},
+			},
+			wantErr:      true,
+			wantRecorded: false,
+		},
+		{
+			name: "should return an error if recording the usage fails",
+			kafkaUsageService: &services.KafkaUsageServiceMock{
+				IsDailyUsageRecordedFunc: func(day time.Time) (bool, *errors.ServiceError) {
+					return false, nil
+				},
+				RecordDailyUsageFunc: func(day time.Time) *errors.ServiceError {
+					return errors.GeneralError("failed to record")
+				},
+			},
+			wantErr:      true,
+			wantRecorded: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			m := NewKafkaUsageManager(workers.Reconciler{}, tt.kafkaUsageService)
+
+			errs := m.Reconcile()
+			g.Expect(len(errs) > 0).To(gomega.Equal(tt.wantErr))
+
+			recordCalls := tt.kafkaUsageService.RecordDailyUsageCalls()
+			g.Expect(len(recordCalls) > 0).To(gomega.Equal(tt.wantRecorded))
+			for _, call := range recordCalls {
+				g.Expect(call.Day).To(gomega.Equal(services.TruncateToUTCDay(call.Day)))
+				g.Expect(call.Day).To(gomega.Equal(tt.kafkaUsageService.IsDailyUsageRecordedCalls()[0].Day))
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package kafka_mgrs
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+)
+
+const (
+	outboxWorkerType = "outbox"
+)
+
+// NewOutboxManager creates a new worker that executes the outbox messages of the side effects of the kafkas
+func NewOutboxManager(reconciler workers.Reconciler, outbox *db.Outbox) *workers.OutboxWorker {
+	return workers.NewOutboxWorker(outboxWorkerType, outbox, reconciler)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db/dbencryption"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
@@ -97,7 +98,7 @@ func (k *ReadyKafkaManager) reconcileCanaryServiceAccount(kafkaRequest *dbapi.Ka
 			return errors.Wrapf(err, "failed to create canary service account: %s", err.Error())
 		}
 		kafkaRequest.CanaryServiceAccountClientID = serviceAccount.ClientID
-		kafkaRequest.CanaryServiceAccountClientSecret = serviceAccount.ClientSecret
+		kafkaRequest.CanaryServiceAccountClientSecret = dbencryption.EncryptedString(serviceAccount.ClientSecret)
 		if err = k.kafkaService.Update(kafkaRequest); err != nil {
 			return errors.Wrapf(err, "failed to update kafka %s with canary service account details", kafkaRequest.ID)
 		}
@@ -0,0 +1,73 @@
+package kafka_mgrs
+
+import (
+	"context"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+)
+
+const (
+	serviceAccountLifecycleWorkerType = "service_account_lifecycle"
+)
+
+// ServiceAccountLifecycleManager represents a worker that warns of the expiry of the service accounts and of their credentials,
+// and rotates or revokes the expired ones
+type ServiceAccountLifecycleManager struct {
+	workers.BaseWorker
+	lifecycleService services.ServiceAccountLifecycleService
+	lifecycleConfig  *config.ServiceAccountLifecycleConfig
+	lastBackfill     time.Time
+}
+
+// NewServiceAccountLifecycleManager creates a new worker that enforces the expiry of the service accounts
+func NewServiceAccountLifecycleManager(reconciler workers.Reconciler, lifecycleService services.ServiceAccountLifecycleService,
+	lifecycleConfig *config.ServiceAccountLifecycleConfig) *ServiceAccountLifecycleManager {
+	return &ServiceAccountLifecycleManager{
+		BaseWorker: workers.BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: serviceAccountLifecycleWorkerType,
+			Reconciler: reconciler,
+		},
+		lifecycleService: lifecycleService,
+		lifecycleConfig:  lifecycleConfig,
+	}
+}
+
+// Start initializes the worker to enforce the expiry of the service accounts
+func (m *ServiceAccountLifecycleManager) Start() {
+	m.StartWorker(m)
+}
+
+// Stop causes the process for enforcing the expiry of the service accounts to stop.
+func (m *ServiceAccountLifecycleManager) Stop() {
+	m.StopWorker(m)
+}
+
+// Reconcile tracks the service accounts of the SSO that aren't tracked yet every backfill interval, then warns of the upcoming
+// expiries and applies the policy of the organisations to the expired service accounts
+func (m *ServiceAccountLifecycleManager) Reconcile() []error {
+	var errs []error
+	if interval := m.lifecycleConfig.BackfillInterval; interval > 0 && time.Since(m.lastBackfill) >= interval {
+		tracked, err := m.lifecycleService.Backfill(context.Background())
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			m.lastBackfill = time.Now()
+		}
+		if tracked > 0 {
+			glog.Infof("%d service accounts created before their lifecycle was tracked are now tracked", tracked)
+		}
+	}
+
+	processed, reconcileErrs := m.lifecycleService.Reconcile(context.Background())
+	errs = append(errs, reconcileErrs...)
+	if processed > 0 {
+		glog.Infof("%d service accounts warned of their expiry or expired", processed)
+	}
+	return errs
+}
@@ -0,0 +1,15 @@
+package kafka_mgrs
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+)
+
+const (
+	webhookDeliveriesWorkerType = "webhook_deliveries"
+)
+
+// NewWebhookDeliveriesManager creates a new worker that sends the pending webhook deliveries of the kafkas_mgmt API
+func NewWebhookDeliveriesManager(reconciler workers.Reconciler, webhookService webhooks.WebhookService) *webhooks.DeliveryWorker {
+	return webhooks.NewDeliveryWorker(webhookDeliveriesWorkerType, webhooks.ServiceKafkas, webhookService, reconciler)
+}
@@ -21,6 +21,7 @@ import (
 	environments2 "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/providers"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/quota_management"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/encryption"
 	"github.com/goava/di"
 )
 
@@ -51,6 +52,12 @@ func ConfigProviders() di.Option {
 		di.Provide(config.NewKasFleetshardConfig, di.As(new(environments2.ConfigModule))),
 		di.Provide(quota_management.NewQuotaManagementListConfig, di.As(new(environments2.ConfigModule))),
 		di.Provide(config.NewCertificateManagementConfig, di.As(new(environments2.ConfigModule)), di.As(new(environments2.ServiceValidator))),
+		di.Provide(config.NewServiceAccountLifecycleConfig, di.As(new(environments2.ConfigModule))),
+
+		// Sensitive columns encrypted at rest
+		di.ProvideValue(encryption.EncryptedColumn{Table: "kafka_requests", Column: "canary_service_account_client_secret"}),
+		di.ProvideValue(encryption.EncryptedColumn{Table: "clusters", Column: "client_secret"}),
+		di.ProvideValue(encryption.EncryptedColumn{Table: "service_account_lifecycles", Column: "rotated_client_secret"}),
 
 		// Additional CLI subcommands
 		di.Provide(environments2.Func(ServiceProviders)),
@@ -69,6 +76,10 @@ func ServiceProviders() di.Option {
 		di.Provide(services.NewObservatoriumService),
 		di.Provide(services.NewKasFleetshardOperatorAddon),
 		di.Provide(services.NewClusterPlacementStrategy),
+		di.Provide(services.NewClusterDrainService),
+		di.Provide(services.NewPlacementSimulationService),
+		di.Provide(services.NewKafkaUsageService),
+		di.Provide(services.NewServiceAccountLifecycleService),
 		di.Provide(services.NewDataPlaneClusterService, di.As(new(services.DataPlaneClusterService))),
 		di.Provide(services.NewDataPlaneKafkaService, di.As(new(services.DataPlaneKafkaService))),
 		di.Provide(handlers.NewAuthenticationBuilder),
@@ -76,10 +87,11 @@ func ServiceProviders() di.Option {
 		di.Provide(routes.NewRouteLoader),
 		di.Provide(quota.NewDefaultQuotaServiceFactory),
 		di.Provide(cluster_mgrs.NewClusterManager, di.As(new(workers.Worker))),
-		di.Provide(cluster_mgrs.NewDynamicScaleUpManager, di.As(new(workers.Worker))),
+		di.Provide(cluster_mgrs.NewDynamicScaleUpManager, di.As(new(workers.Worker)), di.As(new(services.DataPlaneClusterScaleUpProjector))),
 		di.Provide(cluster_mgrs.NewCleanupClustersManager, di.As(new(workers.Worker))),
 		di.Provide(cluster_mgrs.NewDeprovisioningClustersManager, di.As(new(workers.Worker))),
 		di.Provide(cluster_mgrs.NewDynamicScaleDownManager, di.As(new(workers.Worker))),
+		di.Provide(cluster_mgrs.NewClusterDrainManager, di.As(new(workers.Worker))),
 		di.Provide(kafka_mgrs.NewKafkaManager, di.As(new(workers.Worker))),
 		di.Provide(kafka_mgrs.NewAcceptedKafkaManager, di.As(new(workers.Worker))),
 		di.Provide(kafka_mgrs.NewPreparingKafkaManager, di.As(new(workers.Worker))),
@@ -87,6 +99,11 @@ func ServiceProviders() di.Option {
 		di.Provide(kafka_mgrs.NewProvisioningKafkaManager, di.As(new(workers.Worker))),
 		di.Provide(kafka_mgrs.NewReadyKafkaManager, di.As(new(workers.Worker))),
 		di.Provide(kafka_mgrs.NewKafkaCNAMEManager, di.As(new(workers.Worker))),
+		di.Provide(kafka_mgrs.NewKafkaUsageManager, di.As(new(workers.Worker))),
+		di.Provide(kafka_mgrs.NewAuditEventsRetentionManager, di.As(new(workers.Worker))),
+		di.Provide(kafka_mgrs.NewWebhookDeliveriesManager, di.As(new(workers.Worker))),
+		di.Provide(kafka_mgrs.NewOutboxManager, di.As(new(workers.Worker))),
+		di.Provide(kafka_mgrs.NewServiceAccountLifecycleManager, di.As(new(workers.Worker))),
 		di.Provide(promotion.NewPromotionKafkaManager, di.As(new(workers.Worker))),
 		di.Provide(acl.NewEnterpriseClustersAccessControlMiddleware),
 		di.Provide(kafkatlscertmgmt.NewKafkaTLSCertificateManagementService),
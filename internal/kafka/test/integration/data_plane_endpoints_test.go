@@ -638,7 +638,7 @@ func TestDataPlaneEndpoints_GetAndUpdateManagedKafkasWithTlsCerts(t *testing.T)
 	defer testServer.TearDown()
 	bootstrapServerHost := "some-bootstrap⁻host"
 	canaryServiceAccountClientId := "canary-servie-account-client-id"
-	canaryServiceAccountClientSecret := "canary-service-account-client-secret"
+	const canaryServiceAccountClientSecret = "canary-service-account-client-secret"
 
 	testKafka := &dbapi.KafkaRequest{
 		ClusterID:                        testServer.ClusterID,
@@ -699,7 +699,7 @@ func TestDataPlaneEndpoints_GetAndUpdateManagedKafkasWithServiceAccounts(t *test
 	defer testServer.TearDown()
 	bootstrapServerHost := "some-bootstrap⁻host"
 	canaryServiceAccountClientId := "canary-servie-account-client-id"
-	canaryServiceAccountClientSecret := "canary-service-account-client-secret"
+	const canaryServiceAccountClientSecret = "canary-service-account-client-secret"
 
 	testKafka := &dbapi.KafkaRequest{
 		ClusterID:                        testServer.ClusterID,
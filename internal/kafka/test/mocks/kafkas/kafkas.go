@@ -10,6 +10,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/kafkas/types"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db/dbencryption"
 
 	mocksupportedinstancetypes "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/test/mocks/supported_instance_types"
 
@@ -122,7 +123,7 @@ func With(attribute KafkaRequestAttribute, value string) KafkaRequestBuildOption
 		case CANARY_SERVICE_ACCOUNT_CLIENT_ID:
 			request.CanaryServiceAccountClientID = value
 		case CANARY_SERVICE_ACCOUNT_CLIENT_SECRET:
-			request.CanaryServiceAccountClientSecret = value
+			request.CanaryServiceAccountClientSecret = dbencryption.EncryptedString(value)
 		}
 	}
 }
@@ -1,6 +1,8 @@
 package acl
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
@@ -9,50 +11,82 @@ import (
 )
 
 type AccessControlListMiddleware struct {
-	accessControlListConfig *AccessControlListConfig
+	authorizer auth.Authorizer
 }
 
-func NewAccessControlListMiddleware(accessControlListConfig *AccessControlListConfig) *AccessControlListMiddleware {
+func NewAccessControlListMiddleware(accessControlListConfig *AccessControlListConfig, authorizationConfig *auth.AuthorizationConfig) *AccessControlListMiddleware {
 	middleware := AccessControlListMiddleware{
-		accessControlListConfig: accessControlListConfig,
+		authorizer: NewAuthorizer(accessControlListConfig, authorizationConfig),
 	}
 	return &middleware
 }
 
-// Middleware handler to authorize users based on the provided ACL configuration
+// Middleware handler to authorize users based on the provided ACL configuration, and the authorizer of the public APIs.
+// The authorizer is set in the context of the request to authorize the actions of the handlers.
 func (middleware *AccessControlListMiddleware) Authorize(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		context := r.Context()
-		claims, err := auth.GetClaimsFromContext(context)
+		context := auth.SetAuthorizerContext(r.Context(), middleware.authorizer)
+		decision, err := auth.Authorize(context, auth.ActionAccess, auth.AuthorizationResource{Type: auth.ResourceAPI})
 		if err != nil {
 			shared.HandleError(r, w, errors.NewWithCause(errors.ErrorForbidden, err, ""))
 			return
 		}
-
-		username, _ := claims.GetUsername()
-
-		if middleware.accessControlListConfig.EnableDenyList {
-			userIsDenied := middleware.accessControlListConfig.DenyList.IsUserDenied(username)
-			if userIsDenied {
-				shared.HandleError(r, w, errors.New(errors.ErrorForbidden, "user '%s' is not authorized to access the service.", username))
-				return
-			}
-		}
-
-		orgId, _ := claims.GetOrgId()
-
-		if middleware.accessControlListConfig.EnableAccessList {
-			orgIsAccepted := middleware.accessControlListConfig.AccessList.IsOrganisationAccepted(orgId)
-			if !orgIsAccepted {
-				shared.HandleError(r, w, errors.New(errors.ErrorServiceIsUnderMaintenance, "organisation '%s' is not authorized to access the service during the current service maintenance.", orgId))
-				return
-			}
+		if !decision.Allowed {
+			shared.HandleError(r, w, errors.New(decision.ErrorCode, "%s", decision.Reason))
+			return
 		}
 
 		// If the users claim has an orgId, resources should be filtered by their organisation. Otherwise, filter them by owner.
-		context = auth.SetFilterByOrganisationContext(context, orgId != "")
+		context = auth.SetFilterByOrganisationContext(context, decision.FilterByOrganisation)
 		*r = *r.WithContext(context)
 
 		next.ServeHTTP(w, r)
 	})
 }
+
+// accessControlListAuthorizer denies the access to the APIs to the denied users, and to the organisations not accepted during a
+// maintenance. The other decisions are the ones of the next authorizer.
+type accessControlListAuthorizer struct {
+	accessControlListConfig *AccessControlListConfig
+	next                    auth.Authorizer
+}
+
+var _ auth.Authorizer = &accessControlListAuthorizer{}
+
+// NewAuthorizer returns the authorizer of the public APIs: the built-in rules evaluated by the configured engine, restricted by
+// the access control lists. The lists are evaluated after the engine, so that the policies can't allow the denied users.
+func NewAuthorizer(accessControlListConfig *AccessControlListConfig, authorizationConfig *auth.AuthorizationConfig) auth.Authorizer {
+	authorizer := auth.NewDefaultAuthorizer()
+	if authorizationConfig != nil {
+		authorizer = authorizationConfig.NewAuthorizer(authorizer)
+	}
+	return &accessControlListAuthorizer{
+		accessControlListConfig: accessControlListConfig,
+		next:                    authorizer,
+	}
+}
+
+func (a *accessControlListAuthorizer) Authorize(ctx context.Context, request auth.AuthorizationRequest) (*auth.AuthorizationDecision, error) {
+	decision, err := a.next.Authorize(ctx, request)
+	if err != nil || request.Action != auth.ActionAccess {
+		return decision, err
+	}
+
+	username, _ := request.Claims.GetUsername()
+	if a.accessControlListConfig.EnableDenyList && a.accessControlListConfig.DenyList.IsUserDenied(username) {
+		decision.Allowed = false
+		decision.FilterByOrganisation = false
+		decision.ErrorCode = errors.ErrorForbidden
+		decision.Reason = fmt.Sprintf("user '%s' is not authorized to access the service.", username)
+		return decision, nil
+	}
+
+	orgId, _ := request.Claims.GetOrgId()
+	if a.accessControlListConfig.EnableAccessList && !a.accessControlListConfig.AccessList.IsOrganisationAccepted(orgId) {
+		decision.Allowed = false
+		decision.FilterByOrganisation = false
+		decision.ErrorCode = errors.ErrorServiceIsUnderMaintenance
+		decision.Reason = fmt.Sprintf("organisation '%s' is not authorized to access the service during the current service maintenance.", orgId)
+	}
+	return decision, nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/server"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
 	"github.com/golang/glog"
 	"github.com/onsi/gomega"
 )
@@ -41,15 +42,26 @@ func TestAccessControlListMiddleware_Authorize(t *testing.T) {
 	g := gomega.NewWithT(t)
 	authHelper, err := auth.NewAuthHelper(jwtKeyFile, jwtCAFile, serverConfig.TokenIssuerURL)
 	g.Expect(err).NotTo(gomega.HaveOccurred())
+	allowAllPolicyFile, err := shared.CreateTempFileFromStringData("authorization-policy", "package test.authz\n\ndecision := {\"allowed\": true}\n")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	defer os.Remove(allowAllPolicyFile)
+	allowAllAuthorizationConfig := &auth.AuthorizationConfig{
+		Engine:        auth.AuthorizationEngineOPA,
+		OPAPolicyFile: allowAllPolicyFile,
+		OPAQuery:      "data.test.authz.decision",
+	}
+	g.Expect(allowAllAuthorizationConfig.ReadFiles()).To(gomega.Succeed())
+
 	type fields struct {
 		accessControlListConfig *acl.AccessControlListConfig
 	}
 	tests := []struct {
-		name            string
-		fields          fields
-		wantErr         bool
-		wantHttpStatus1 http.Handler
-		wantHttpStatus  int
+		name                string
+		fields              fields
+		authorizationConfig *auth.AuthorizationConfig
+		wantErr             bool
+		wantHttpStatus1     http.Handler
+		wantHttpStatus      int
 	}{
 		{
 			name: "returns 403 Forbidden response when user is not allowed to access service",
@@ -62,6 +74,18 @@ func TestAccessControlListMiddleware_Authorize(t *testing.T) {
 			wantErr:        true,
 			wantHttpStatus: http.StatusForbidden,
 		},
+		{
+			name: "returns 403 Forbidden response when user is denied even if the authorization policies allow the access",
+			fields: fields{
+				&acl.AccessControlListConfig{
+					EnableDenyList: true,
+					DenyList:       acl.DeniedUsers{"username"},
+				},
+			},
+			authorizationConfig: allowAllAuthorizationConfig,
+			wantErr:             true,
+			wantHttpStatus:      http.StatusForbidden,
+		},
 		{
 			name: "returns 200 status if denyList is disabled",
 			fields: fields{
@@ -134,7 +158,11 @@ func TestAccessControlListMiddleware_Authorize(t *testing.T) {
 
 		rr := httptest.NewRecorder()
 
-		middleware := acl.NewAccessControlListMiddleware(tt.fields.accessControlListConfig)
+		authorizationConfig := tt.authorizationConfig
+		if authorizationConfig == nil {
+			authorizationConfig = auth.NewAuthorizationConfig()
+		}
+		middleware := acl.NewAccessControlListMiddleware(tt.fields.accessControlListConfig, authorizationConfig)
 		handler := middleware.Authorize(http.HandlerFunc(NextHandler))
 
 		// create a jwt and set it in the context
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Kinds of the API tokens
+const (
+	// ApiTokenPersonal tokens act on behalf of the user who created them
+	ApiTokenPersonal = "personal"
+	// ApiTokenOrganisation tokens act on behalf of the organisation, with the permissions of an organisation admin
+	ApiTokenOrganisation = "organisation"
+)
+
+// ApiToken is an opaque, scoped and expiring token minted by the fleet manager, i.e. for the automation of an organisation
+type ApiToken struct {
+	Meta
+	Name           string
+	Kind           string
+	OrganisationId string `gorm:"index"`
+	// Owner is the user who created the token, the token acts on their behalf
+	Owner     string
+	AccountId string
+	// Scopes is the JSON array of the scopes of the token
+	Scopes JSON `gorm:"type:jsonb"`
+	// TokenHash is the SHA-256 hash of the token, the token itself is only returned when it's created
+	TokenHash string `gorm:"uniqueIndex"`
+	// TokenPrefix is the beginning of the token, so that the owners can recognize their tokens
+	TokenPrefix string
+	ExpiresAt   time.Time
+	LastUsedAt  *time.Time
+}
+
+type ApiTokenList []*ApiToken
+
+func (t *ApiToken) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == "" {
+		t.ID = NewID()
+	}
+	return nil
+}
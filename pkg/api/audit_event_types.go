@@ -0,0 +1,52 @@
+package api
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Outcomes of the audited requests
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeFailure = "failure"
+	AuditOutcomeDenied  = "denied"
+)
+
+// AuditEvent is a request recorded in the append only audit trail.
+// Request bodies and diffs are redacted before being recorded, see auth.RedactAuditJSON.
+type AuditEvent struct {
+	ID        string    `json:"id" gorm:"primaryKey"`
+	CreatedAt time.Time `json:"created_at" gorm:"index"`
+	// Service is the API the event was recorded by, i.e. kafkas_mgmt or connector_mgmt
+	Service string `json:"service" gorm:"index"`
+
+	Actor          string `json:"actor" gorm:"index"`
+	OrganisationId string `json:"organisation_id" gorm:"index"`
+	// Claims is the subset of the token claims identifying the actor
+	Claims JSON `json:"claims" gorm:"type:jsonb"`
+
+	Method      string `json:"method"`
+	Route       string `json:"route"`
+	Path        string `json:"path"`
+	ResourceId  string `json:"resource_id" gorm:"index"`
+	RequestBody JSON   `json:"request_body,omitempty" gorm:"type:jsonb"`
+	// Diff holds the old and new values of the fields changed by the request
+	Diff JSON `json:"diff,omitempty" gorm:"type:jsonb"`
+	// Authorization holds the decisions of the authorizer of the request
+	Authorization JSON `json:"authorization,omitempty" gorm:"type:jsonb"`
+
+	StatusCode  int    `json:"status_code"`
+	Outcome     string `json:"outcome"`
+	OperationId string `json:"operation_id" gorm:"index"`
+	RemoteAddr  string `json:"remote_addr"`
+}
+
+type AuditEventList []*AuditEvent
+
+func (e *AuditEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == "" {
+		e.ID = NewID()
+	}
+	return nil
+}
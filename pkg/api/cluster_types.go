@@ -6,9 +6,11 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db/dbencryption"
 	kasfleetmanagererrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/golang/glog"
 	"github.com/pkg/errors"
@@ -142,17 +144,17 @@ var ClusterDeletionStatuses = []string{ClusterCleanup.String(), ClusterDeprovisi
 
 type Cluster struct {
 	Meta
-	CloudProvider      string        `json:"cloud_provider"`
-	ClusterID          string        `json:"cluster_id" gorm:"uniqueIndex"`
-	ExternalID         string        `json:"external_id"`
-	MultiAZ            bool          `json:"multi_az"`
-	Region             string        `json:"region"`
-	Status             ClusterStatus `json:"status" gorm:"index"`
-	StatusDetails      string        `json:"status_details" gorm:"-"`
-	IdentityProviderID string        `json:"identity_provider_id"`
-	ClusterDNS         string        `json:"cluster_dns"`
-	ClientID           string        `json:"client_id"`
-	ClientSecret       string        `json:"client_secret"`
+	CloudProvider      string                       `json:"cloud_provider"`
+	ClusterID          string                       `json:"cluster_id" gorm:"uniqueIndex"`
+	ExternalID         string                       `json:"external_id"`
+	MultiAZ            bool                         `json:"multi_az"`
+	Region             string                       `json:"region"`
+	Status             ClusterStatus                `json:"status" gorm:"index"`
+	StatusDetails      string                       `json:"status_details" gorm:"-"`
+	IdentityProviderID string                       `json:"identity_provider_id"`
+	ClusterDNS         string                       `json:"cluster_dns"`
+	ClientID           string                       `json:"client_id"`
+	ClientSecret       dbencryption.EncryptedString `json:"client_secret"`
 	// the provider type for the cluster, e.g. OCM, AWS, GCP, Standalone etc
 	ProviderType ClusterProviderType `json:"provider_type"`
 	// store the provider-specific information that can be used to managed the openshift/k8s cluster
@@ -180,6 +182,13 @@ type Cluster struct {
 
 	// AccessKafkasViaPrivateNetwork indicates whether Kafkas deployed on this OSD cluster have to be accessed via private network
 	AccessKafkasViaPrivateNetwork bool `json:"access_kafkas_via_private_network"`
+
+	// Cordoned indicates whether the cluster has been cordoned. A cordoned cluster is excluded from Kafka placement,
+	// it does not receive reserved Kafkas and its capacity is not taken into account by dynamic scaling.
+	Cordoned bool `json:"cordoned" gorm:"index"`
+	// DrainInfo holds the progress of the drain of the cluster. See the ClusterDrainInfo data type for the format of the JSON stored.
+	// Use the `SetDrainInfo` and `RetrieveDrainInfo` helper methods to set and read it.
+	DrainInfo JSON `json:"drain_info"`
 }
 
 type ClusterList []*Cluster
@@ -232,6 +241,26 @@ type DynamicCapacityInfo struct {
 	RemainingUnits int32 `json:"remaining_units"`
 }
 
+// ClusterDrainInfo holds the progress of the evacuation of the Kafkas of a cordoned cluster
+type ClusterDrainInfo struct {
+	// Draining is true while Kafkas are being moved out of the cluster
+	Draining bool `json:"draining"`
+	// StartedAt is the time when the drain of the cluster was requested
+	StartedAt *time.Time `json:"started_at,omitempty"`
+	// CompletedAt is the time when the last Kafka was moved out of the cluster
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	// MigratingKafkaIDs contains the IDs of the Kafkas that are currently being moved to another cluster
+	MigratingKafkaIDs []string `json:"migrating_kafka_ids,omitempty"`
+	// MigratedKafkasCount is the number of Kafkas that have been successfully moved to another cluster
+	MigratedKafkasCount int `json:"migrated_kafkas_count"`
+	// RemainingKafkasCount is the number of Kafkas that are still placed on the cluster
+	RemainingKafkasCount int `json:"remaining_kafkas_count"`
+	// FailedKafkaIDs contains the IDs of the Kafkas that failed to be moved to another cluster
+	FailedKafkaIDs []string `json:"failed_kafka_ids,omitempty"`
+	// LastError is the last error encountered while draining the cluster
+	LastError string `json:"last_error,omitempty"`
+}
+
 type KafkaVersion struct {
 	Version string `json:"version"`
 }
@@ -518,6 +547,36 @@ func (cluster *Cluster) RetrieveDynamicCapacityInfo() map[string]DynamicCapacity
 	return dynamicCapacityInfo
 }
 
+// SetDrainInfo sets the drain progress of the cluster into a json object that can be persisted in the database
+func (cluster *Cluster) SetDrainInfo(drainInfo ClusterDrainInfo) error {
+	marshalledDrainInfo, err := json.Marshal(drainInfo)
+	if err != nil {
+		return err
+	}
+
+	cluster.DrainInfo = marshalledDrainInfo
+	return nil
+}
+
+// RetrieveDrainInfo returns the drain progress of the cluster.
+// A zero value is returned if the cluster has never been drained
+func (cluster *Cluster) RetrieveDrainInfo() ClusterDrainInfo {
+	drainInfo := ClusterDrainInfo{}
+	if cluster.DrainInfo != nil {
+		// only log error returned by Unmarshal as the json stored in the cluster object should always be a valid ClusterDrainInfo json object.
+		if err := json.Unmarshal(cluster.DrainInfo, &drainInfo); err != nil {
+			glog.Errorf("Failed to retrieve drain info: %s", err.Error())
+		}
+	}
+
+	return drainInfo
+}
+
+// IsDraining returns true if the Kafkas of the cluster are being moved to other clusters
+func (cluster *Cluster) IsDraining() bool {
+	return cluster.Cordoned && cluster.RetrieveDrainInfo().Draining
+}
+
 // GetSupportedInstanceTypes returns a list of the supported instance types for
 // the cluster. If there are no supported instance types the result is
 // an empty list
@@ -3,6 +3,7 @@ package api
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/onsi/gomega"
 )
@@ -1131,6 +1132,88 @@ func Test_Cluster_RetrieveDynamicCapacityInfo(t *testing.T) {
 	}
 }
 
+func Test_Cluster_SetAndRetrieveDrainInfo(t *testing.T) {
+	startedAt := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		drainInfo ClusterDrainInfo
+	}{
+		{
+			name:      "stores and retrieves an empty drain info",
+			drainInfo: ClusterDrainInfo{},
+		},
+		{
+			name: "stores and retrieves the drain progress",
+			drainInfo: ClusterDrainInfo{
+				Draining:             true,
+				StartedAt:            &startedAt,
+				MigratingKafkaIDs:    []string{"kafka-1"},
+				MigratedKafkasCount:  2,
+				RemainingKafkasCount: 3,
+				FailedKafkaIDs:       []string{"kafka-2"},
+				LastError:            "some error",
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			cluster := &Cluster{}
+			g.Expect(cluster.SetDrainInfo(tt.drainInfo)).ToNot(gomega.HaveOccurred())
+			g.Expect(cluster.RetrieveDrainInfo()).To(gomega.Equal(tt.drainInfo))
+		})
+	}
+}
+
+func Test_Cluster_IsDraining(t *testing.T) {
+	tests := []struct {
+		name    string
+		cluster *Cluster
+		want    bool
+	}{
+		{
+			name:    "returns false when the cluster has never been drained",
+			cluster: &Cluster{},
+			want:    false,
+		},
+		{
+			name: "returns false when the cluster is not cordoned",
+			cluster: &Cluster{
+				DrainInfo: JSON([]byte(`{"draining":true}`)),
+			},
+			want: false,
+		},
+		{
+			name: "returns false when the drain of the cluster is completed",
+			cluster: &Cluster{
+				Cordoned:  true,
+				DrainInfo: JSON([]byte(`{"draining":false}`)),
+			},
+			want: false,
+		},
+		{
+			name: "returns true when the cluster is cordoned and being drained",
+			cluster: &Cluster{
+				Cordoned:  true,
+				DrainInfo: JSON([]byte(`{"draining":true}`)),
+			},
+			want: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			g.Expect(tt.cluster.IsDraining()).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
 func Test_Cluster_GetSupportedInstanceTypes(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -10,4 +10,6 @@ type ServiceAccount struct {
 	CreatedBy    string    `json:"owner,omitempty"`
 	Description  string    `json:"description,omitempty"`
 	CreatedAt    time.Time `json:"created_at,omitempty"`
+	// OrgId is the organisation of the service account, only set by the internal listing of the service accounts
+	OrgId string `json:"-"`
 }
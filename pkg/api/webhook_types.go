@@ -0,0 +1,72 @@
+package api
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db/dbencryption"
+	"gorm.io/gorm"
+)
+
+// Statuses of the webhook deliveries
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// WebhookSubscription is an organisation subscription to the state changes of the resources of a service
+type WebhookSubscription struct {
+	Meta
+	// Service is the API the subscription was created with, i.e. kafkas_mgmt or connector_mgmt
+	Service        string `gorm:"index"`
+	OrganisationId string `gorm:"index"`
+	Owner          string
+	Url            string
+	// EventTypes is the JSON array of the event types delivered to the subscription
+	EventTypes JSON `gorm:"type:jsonb"`
+	// Secret signs the deliveries, it's never returned once the subscription is created
+	Secret dbencryption.EncryptedString
+}
+
+type WebhookSubscriptionList []*WebhookSubscription
+
+func (s *WebhookSubscription) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == "" {
+		s.ID = NewID()
+	}
+	return nil
+}
+
+// WebhookDelivery is an event waiting to be, or already, delivered to a subscription.
+// The webhook_deliveries table is the outbox of the webhook events, the deliveries are kept as the delivery history.
+type WebhookDelivery struct {
+	ID             string    `json:"id" gorm:"primaryKey"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	Service        string    `json:"-" gorm:"index"`
+	SubscriptionId string    `json:"subscription_id" gorm:"index"`
+	// EventId identifies the event, it's the same for all the deliveries of an event
+	EventId    string `json:"event_id"`
+	EventType  string `json:"event_type"`
+	ResourceId string `json:"resource_id"`
+	Payload    JSON   `json:"payload" gorm:"type:jsonb"`
+
+	Status         string     `json:"status" gorm:"index"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty" gorm:"index"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at,omitempty"`
+	LastStatusCode int        `json:"last_status_code,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	// RedeliveryOf is the id of the delivery this delivery was requested to redeliver
+	RedeliveryOf string `json:"redelivery_of,omitempty"`
+}
+
+type WebhookDeliveryList []*WebhookDelivery
+
+func (d *WebhookDelivery) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == "" {
+		d.ID = NewID()
+	}
+	return nil
+}
@@ -0,0 +1,257 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	shared "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+	pkgErr "github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Resource attributes the admin handlers match against the resource selectors of the admin policies
+const (
+	AdminResourceRegion         = "region"
+	AdminResourceCloudProvider  = "cloud_provider"
+	AdminResourceOrganisationId = "organisation_id"
+	AdminResourceClusterId      = "cluster_id"
+	AdminResourceInstanceType   = "instance_type"
+	AdminResourceNamespaceId    = "namespace_id"
+)
+
+const contextAdminGrant contextKey = "admin-grant"
+
+// AdminPolicyRule grants access to the admin API routes matching one of the route patterns for the given methods.
+// Route patterns are matched against the path template of the route, i.e. /api/kafkas_mgmt/v1/admin/kafkas/{id},
+// a '*' matches a single path segment and a trailing '/**' matches any sub path.
+// When fields are set, only these fields of the request body can be sent, nested fields are separated by a '.'.
+// When resources are set, only the resources whose attributes match one of the listed values for every attribute can be accessed,
+// and the rule only matches the routes whose handlers check the resource selectors, see RolesAuthorizationMiddleware.CheckAdminResources.
+type AdminPolicyRule struct {
+	Routes    []string            `yaml:"routes"`
+	Methods   []string            `yaml:"methods"`
+	Fields    []string            `yaml:"fields,omitempty"`
+	Resources map[string][]string `yaml:"resources,omitempty"`
+}
+
+// AdminPolicy restricts a role to the requests matching its rules.
+// Roles without a policy are granted the methods of the admin API role mapping.
+type AdminPolicy struct {
+	Role  string            `yaml:"role"`
+	Rules []AdminPolicyRule `yaml:"rules"`
+}
+
+// AdminPolicyRequest is an admin API request evaluated against the admin policies
+type AdminPolicyRequest struct {
+	Roles  []string
+	Method string
+	Route  string
+	// Fields are the fields of the request body, nil if the body isn't a JSON object
+	Fields []string
+	// ChecksResources is true if the handler of the route checks the resource selectors with CheckAdminResource
+	ChecksResources bool
+}
+
+// AdminGrant is the result of the evaluation of the admin policies for a request.
+// An unrestricted grant comes from a role without policy, otherwise the grant holds the rules matching the request.
+type AdminGrant struct {
+	unrestricted bool
+	rules        []AdminPolicyRule
+}
+
+// Unrestricted returns true if the request isn't restricted by any resource selector
+func (g *AdminGrant) Unrestricted() bool {
+	if g.unrestricted {
+		return true
+	}
+	for _, rule := range g.rules {
+		if len(rule.Resources) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsResource checks that one of the granted rules selects a resource with the given attributes
+func (g *AdminGrant) AllowsResource(resource map[string]string) bool {
+	if g.Unrestricted() {
+		return true
+	}
+	return arrays.AnyMatch(g.rules, func(rule AdminPolicyRule) bool {
+		for attribute, values := range rule.Resources {
+			if !arrays.AnyMatch(values, func(v string) bool { return v == "*" || v == resource[attribute] }) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// EvaluateAdminPolicies returns the grant of a request, or nil if none of the roles allow the request
+func EvaluateAdminPolicies(roleMapping map[string][]string, policies map[string][]AdminPolicyRule, request AdminPolicyRequest) *AdminGrant {
+	grant := &AdminGrant{}
+	for _, role := range request.Roles {
+		rules, restricted := lookupPolicy(policies, role)
+		if !restricted {
+			if arrays.AnyMatch(roleMapping[request.Method], arrays.StringEqualsIgnoreCasePredicate(role)) {
+				return &AdminGrant{unrestricted: true}
+			}
+			continue
+		}
+		for _, rule := range rules {
+			if rule.matches(request) {
+				grant.rules = append(grant.rules, rule)
+			}
+		}
+	}
+	if len(grant.rules) == 0 {
+		return nil
+	}
+	return grant
+}
+
+func lookupPolicy(policies map[string][]AdminPolicyRule, role string) ([]AdminPolicyRule, bool) {
+	for name, rules := range policies {
+		if strings.EqualFold(name, role) {
+			return rules, true
+		}
+	}
+	return nil, false
+}
+
+func (r AdminPolicyRule) matches(request AdminPolicyRequest) bool {
+	// resource selectors can't be enforced by the handlers that don't check them, the rule doesn't grant access to their routes
+	if len(r.Resources) != 0 && !request.ChecksResources {
+		return false
+	}
+	if !arrays.AnyMatch(r.Methods, arrays.StringEqualsIgnoreCasePredicate(request.Method)) {
+		return false
+	}
+	if !arrays.AnyMatch(r.Routes, func(pattern string) bool { return matchRoute(pattern, request.Route) }) {
+		return false
+	}
+	if len(r.Fields) == 0 {
+		return true
+	}
+	if request.Fields == nil {
+		return false
+	}
+	return arrays.AllMatch(request.Fields, func(field string) bool {
+		return arrays.AnyMatch(r.Fields, func(allowed string) bool {
+			return field == allowed || strings.HasPrefix(field, allowed+".")
+		})
+	})
+}
+
+func matchRoute(pattern string, route string) bool {
+	if strings.HasSuffix(pattern, "/**") {
+		prefix := strings.Split(strings.TrimSuffix(pattern, "/**"), "/")
+		segments := strings.Split(route, "/")
+		if len(segments) < len(prefix) {
+			return false
+		}
+		pattern, route = strings.Join(prefix, "/"), strings.Join(segments[:len(prefix)], "/")
+	}
+	matched, err := path.Match(pattern, route)
+	return err == nil && matched
+}
+
+// bodyFields returns the fields of a JSON object, nested object fields are joined with a '.'
+func bodyFields(body []byte) []string {
+	if len(strings.TrimSpace(string(body))) == 0 {
+		return []string{}
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal(body, &object); err != nil {
+		return nil
+	}
+	fields := []string{}
+	var collect func(prefix string, object map[string]interface{})
+	collect = func(prefix string, object map[string]interface{}) {
+		for name, value := range object {
+			if nested, ok := value.(map[string]interface{}); ok && len(nested) != 0 {
+				collect(prefix+name+".", nested)
+			} else {
+				fields = append(fields, prefix+name)
+			}
+		}
+	}
+	collect("", object)
+	return fields
+}
+
+func SetAdminGrantContext(ctx context.Context, grant *AdminGrant) context.Context {
+	return context.WithValue(ctx, contextAdminGrant, grant)
+}
+
+func GetAdminGrantFromContext(ctx context.Context) *AdminGrant {
+	grant, _ := ctx.Value(contextAdminGrant).(*AdminGrant)
+	return grant
+}
+
+// IsAdminResourceRestricted returns true if the request in the context is restricted by resource selectors,
+// handlers can use it to skip loading resources that are only needed by CheckAdminResource
+func IsAdminResourceRestricted(ctx context.Context) bool {
+	grant := GetAdminGrantFromContext(ctx)
+	return grant != nil && !grant.Unrestricted()
+}
+
+// CheckAdminResource checks the resource selectors of the admin policies that granted the request in the context.
+// Requests without a grant in the context, i.e. requests not served by the admin API, are not restricted.
+func CheckAdminResource(ctx context.Context, resource map[string]string) *errors.ServiceError {
+	grant := GetAdminGrantFromContext(ctx)
+	if grant == nil || grant.AllowsResource(resource) {
+		return nil
+	}
+	return errors.Forbidden("the admin policies of the user do not allow access to this resource")
+}
+
+func readAdminPoliciesFile(file string, val *[]AdminPolicy) error {
+	fileContents, err := shared.ReadFile(file)
+	if err != nil {
+		return pkgErr.Wrap(err, "reading admin authz policies")
+	}
+
+	if err := yaml.UnmarshalStrict([]byte(fileContents), val); err != nil {
+		return pkgErr.Wrap(err, "unmarshalling admin authz policies")
+	}
+
+	return nil
+}
+
+func validateAdminPolicies(policies []AdminPolicy) error {
+	roles := map[string]struct{}{}
+	for _, policy := range policies {
+		if policy.Role == "" {
+			return fmt.Errorf("admin policy without role")
+		}
+		role := strings.ToLower(policy.Role)
+		if _, ok := roles[role]; ok {
+			return fmt.Errorf("more than one admin policy for role %q", policy.Role)
+		}
+		roles[role] = struct{}{}
+
+		for _, rule := range policy.Rules {
+			if len(rule.Routes) == 0 || len(rule.Methods) == 0 {
+				return fmt.Errorf("admin policy rules of role %q require at least a route and a method", policy.Role)
+			}
+			for _, method := range rule.Methods {
+				if !arrays.Contains(allowedHTTPMethods, method) {
+					return fmt.Errorf("invalid http method used %q in admin policy of role %q, expected to be one of [%s]",
+						method, policy.Role, strings.Join(allowedHTTPMethods, ","))
+				}
+			}
+			for _, route := range rule.Routes {
+				if _, err := path.Match(route, ""); err != nil || !strings.HasPrefix(route, "/") {
+					return fmt.Errorf("invalid route pattern %q in admin policy of role %q", route, policy.Role)
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,360 @@
+package auth
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/gorilla/mux"
+	"github.com/onsi/gomega"
+)
+
+const (
+	kafkaAdminRoute      = "/api/kafkas_mgmt/v1/admin/kafkas/{id}"
+	deploymentAdminRoute = "/api/connector_mgmt/v1/admin/kafka_connector_clusters/{connector_cluster_id}/deployments/{deployment_id}"
+)
+
+// adminPolicyTestCase is a request checked against the admin authz configuration and policies of the config directory
+type adminPolicyTestCase struct {
+	name     string
+	roles    []string
+	method   string
+	route    string
+	body     string
+	resource map[string]string
+	// checksResources is true for the routes whose handlers check the resource selectors
+	checksResources bool
+	allowed         bool
+}
+
+func (tt adminPolicyTestCase) evaluate(config *AdminRoleAuthZConfig) bool {
+	grant := EvaluateAdminPolicies(config.GetRoleMapping(), config.GetPolicies(), AdminPolicyRequest{
+		Roles:           tt.roles,
+		Method:          tt.method,
+		Route:           tt.route,
+		Fields:          bodyFields([]byte(tt.body)),
+		ChecksResources: tt.checksResources,
+	})
+	return grant != nil && (tt.resource == nil || grant.AllowsResource(tt.resource))
+}
+
+func Test_AdminPolicies_Configuration(t *testing.T) {
+	g := gomega.NewWithT(t)
+	config := NewAdminAuthZConfig()
+	g.Expect(config.ReadFiles()).To(gomega.Succeed())
+	g.Expect(config.Validate(nil)).To(gomega.Succeed())
+
+	tests := []adminPolicyTestCase{
+		{
+			name:    "method based roles are unchanged",
+			roles:   []string{"kas-fleet-manager-admin-write"},
+			method:  http.MethodPatch,
+			route:   kafkaAdminRoute,
+			body:    `{"kafka_version": "3.3.1", "suspended": true}`,
+			allowed: true,
+		},
+		{
+			name:    "support can read kafkas",
+			roles:   []string{"kas-fleet-manager-admin-support-read"},
+			method:  http.MethodGet,
+			route:   "/api/kafkas_mgmt/v1/admin/clusters/{id}",
+			allowed: true,
+		},
+		{
+			name:    "support can't update kafkas",
+			roles:   []string{"kas-fleet-manager-admin-support-read"},
+			method:  http.MethodPatch,
+			route:   kafkaAdminRoute,
+			body:    `{"suspended": true}`,
+			allowed: false,
+		},
+		{
+			name:    "kafka support can't read connectors",
+			roles:   []string{"kas-fleet-manager-admin-support-read"},
+			method:  http.MethodGet,
+			route:   "/api/connector_mgmt/v1/admin/kafka_connector_clusters",
+			allowed: false,
+		},
+		{
+			name:    "upgrade operators can update the strimzi version",
+			roles:   []string{"kas-fleet-manager-admin-upgrade"},
+			method:  http.MethodPatch,
+			route:   kafkaAdminRoute,
+			body:    `{"strimzi_version": "strimzi-cluster-operator.v0.32.0-0"}`,
+			allowed: true,
+		},
+		{
+			name:    "upgrade operators can't suspend kafkas",
+			roles:   []string{"kas-fleet-manager-admin-upgrade"},
+			method:  http.MethodPatch,
+			route:   kafkaAdminRoute,
+			body:    `{"strimzi_version": "strimzi-cluster-operator.v0.32.0-0", "suspended": true}`,
+			allowed: false,
+		},
+		{
+			name:    "upgrade operators can't delete kafkas",
+			roles:   []string{"kas-fleet-manager-admin-upgrade"},
+			method:  http.MethodDelete,
+			route:   kafkaAdminRoute,
+			allowed: false,
+		},
+		{
+			name:            "regional writers can update kafkas of their region",
+			roles:           []string{"kas-fleet-manager-admin-write-us-east-1"},
+			method:          http.MethodPatch,
+			route:           kafkaAdminRoute,
+			body:            `{"suspended": true}`,
+			resource:        map[string]string{AdminResourceRegion: "us-east-1"},
+			checksResources: true,
+			allowed:         true,
+		},
+		{
+			name:            "regional writers can't update kafkas of other regions",
+			roles:           []string{"kas-fleet-manager-admin-write-us-east-1"},
+			method:          http.MethodPatch,
+			route:           kafkaAdminRoute,
+			body:            `{"suspended": true}`,
+			resource:        map[string]string{AdminResourceRegion: "eu-west-1"},
+			checksResources: true,
+			allowed:         false,
+		},
+		{
+			name:            "resource selectors don't apply when another role is unrestricted",
+			roles:           []string{"kas-fleet-manager-admin-write-us-east-1", "kas-fleet-manager-admin-support-read"},
+			method:          http.MethodGet,
+			route:           kafkaAdminRoute,
+			resource:        map[string]string{AdminResourceRegion: "eu-west-1"},
+			checksResources: true,
+			allowed:         true,
+		},
+		{
+			name:    "regional writers can't access the routes not checking the resource selectors",
+			roles:   []string{"kas-fleet-manager-admin-write-us-east-1"},
+			method:  http.MethodGet,
+			route:   kafkaAdminRoute,
+			allowed: false,
+		},
+		{
+			name:    "connector upgrade operators can change the deployment operator",
+			roles:   []string{"cos-fleet-manager-admin-upgrade"},
+			method:  http.MethodPatch,
+			route:   deploymentAdminRoute,
+			body:    `{"spec": {"operator_id": "camel-k-1.1.0"}}`,
+			allowed: true,
+		},
+		{
+			name:    "connector upgrade operators can change the deployment shard metadata",
+			roles:   []string{"cos-fleet-manager-admin-upgrade"},
+			method:  http.MethodPatch,
+			route:   deploymentAdminRoute,
+			body:    `{"spec": {"shard_metadata": {"connector_revision": 5}}}`,
+			allowed: true,
+		},
+		{
+			name:    "connector upgrade operators can't change other deployment fields",
+			roles:   []string{"cos-fleet-manager-admin-upgrade"},
+			method:  http.MethodPatch,
+			route:   deploymentAdminRoute,
+			body:    `{"spec": {"operator_id": "camel-k-1.1.0", "allow_upgrade": true}}`,
+			allowed: false,
+		},
+		{
+			name:    "connector upgrade operators can list deployments",
+			roles:   []string{"cos-fleet-manager-admin-upgrade"},
+			method:  http.MethodGet,
+			route:   "/api/connector_mgmt/v1/admin/kafka_connector_clusters/{connector_cluster_id}/deployments",
+			allowed: true,
+		},
+		{
+			name:    "unknown roles are denied",
+			roles:   []string{"unknown"},
+			method:  http.MethodGet,
+			route:   kafkaAdminRoute,
+			allowed: false,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(tt.evaluate(config)).To(gomega.Equal(tt.allowed))
+		})
+	}
+}
+
+func Test_matchRoute(t *testing.T) {
+	tests := []struct {
+		pattern string
+		route   string
+		want    bool
+	}{
+		{pattern: "/admin/kafkas/{id}", route: "/admin/kafkas/{id}", want: true},
+		{pattern: "/admin/kafkas/*", route: "/admin/kafkas/{id}", want: true},
+		{pattern: "/admin/kafkas/*", route: "/admin/kafkas/{id}/revoke_tls_certificate", want: false},
+		{pattern: "/admin/**", route: "/admin", want: true},
+		{pattern: "/admin/**", route: "/admin/kafkas/{id}/revoke_tls_certificate", want: true},
+		{pattern: "/admin/*/{id}/**", route: "/admin/kafkas/{id}/revoke_tls_certificate", want: true},
+		{pattern: "/admin/**", route: "/api", want: false},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.pattern+" "+tt.route, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(matchRoute(tt.pattern, tt.route)).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_bodyFields(t *testing.T) {
+	g := gomega.NewWithT(t)
+	g.Expect(bodyFields([]byte(""))).To(gomega.BeEmpty())
+	g.Expect(bodyFields([]byte("[1, 2]"))).To(gomega.BeNil())
+	g.Expect(bodyFields([]byte(`{"a": 1, "b": {"c": {"d": true}, "e": {}}, "f": [{"g": 1}]}`))).
+		To(gomega.ConsistOf("a", "b.c.d", "b.e", "f"))
+}
+
+func Test_validateAdminPolicies(t *testing.T) {
+	tests := []struct {
+		name     string
+		policies []AdminPolicy
+		wantErr  bool
+	}{
+		{
+			name:     "valid policy",
+			policies: []AdminPolicy{{Role: "support", Rules: []AdminPolicyRule{{Routes: []string{"/admin/**"}, Methods: []string{http.MethodGet}}}}},
+		},
+		{
+			name:     "policy without role",
+			policies: []AdminPolicy{{Rules: []AdminPolicyRule{{Routes: []string{"/admin/**"}, Methods: []string{http.MethodGet}}}}},
+			wantErr:  true,
+		},
+		{
+			name:     "duplicated role",
+			policies: []AdminPolicy{{Role: "support"}, {Role: "Support"}},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid method",
+			policies: []AdminPolicy{{Role: "support", Rules: []AdminPolicyRule{{Routes: []string{"/admin/**"}, Methods: []string{"HEAD"}}}}},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid route pattern",
+			policies: []AdminPolicy{{Role: "support", Rules: []AdminPolicyRule{{Routes: []string{"/admin/[kafkas"}, Methods: []string{http.MethodGet}}}}},
+			wantErr:  true,
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			err := validateAdminPolicies(tt.policies)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
+
+func TestRolesAuthMiddleware_RequireRolesForMethods_Policies(t *testing.T) {
+	config := &AdminRoleAuthZConfig{
+		RolesConfig: RoleConfig{{HTTPMethod: http.MethodPatch, RoleNames: []string{"write"}}},
+		Policies: []AdminPolicy{{
+			Role: "upgrade",
+			Rules: []AdminPolicyRule{{
+				Routes:    []string{"/admin/kafkas/{id}"},
+				Methods:   []string{http.MethodPatch},
+				Fields:    []string{"strimzi_version"},
+				Resources: map[string][]string{AdminResourceRegion: {"us-east-1"}},
+			}},
+		}},
+	}
+
+	tests := []struct {
+		name string
+		role string
+		body string
+		// uncheckedRoute is true if the route isn't registered as checking the resource selectors
+		uncheckedRoute bool
+		want           int
+	}{
+		{
+			name: "roles without policy are granted the methods of the role mapping",
+			role: "write",
+			body: `{"suspended": true}`,
+			want: http.StatusOK,
+		},
+		{
+			name:           "roles without policy are granted the routes not checking the resource selectors",
+			role:           "write",
+			body:           `{"suspended": true}`,
+			uncheckedRoute: true,
+			want:           http.StatusOK,
+		},
+		{
+			name: "roles with a policy can send the allowed fields",
+			role: "upgrade",
+			body: `{"strimzi_version": "v0.32.0"}`,
+			want: http.StatusOK,
+		},
+		{
+			name: "roles with a policy can't send other fields",
+			role: "upgrade",
+			body: `{"strimzi_version": "v0.32.0", "suspended": true}`,
+			want: http.StatusNotFound,
+		},
+		{
+			name:           "rules with resource selectors don't grant the routes not checking them",
+			role:           "upgrade",
+			body:           `{"strimzi_version": "v0.32.0"}`,
+			uncheckedRoute: true,
+			want:           http.StatusNotFound,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			token := &jwt.Token{
+				Claims: jwt.MapClaims{
+					"realm_access": map[string]interface{}{
+						"roles": []interface{}{tt.role},
+					},
+				},
+			}
+			router := mux.NewRouter()
+			middleware := NewRolesAuthzMiddleware(config)
+			route := router.HandleFunc("/admin/kafkas/{id}", func(writer http.ResponseWriter, request *http.Request) {
+				// the body is still readable by the handler
+				body, err := io.ReadAll(request.Body)
+				g.Expect(err).To(gomega.BeNil())
+				g.Expect(string(body)).To(gomega.Equal(tt.body))
+				g.Expect(GetIsAdminFromContext(request.Context())).To(gomega.BeTrue())
+
+				restricted := IsAdminResourceRestricted(request.Context())
+				g.Expect(restricted).To(gomega.Equal(tt.role == "upgrade"))
+				g.Expect(CheckAdminResource(request.Context(), map[string]string{AdminResourceRegion: "us-east-1"})).To(gomega.BeNil())
+				if restricted {
+					g.Expect(CheckAdminResource(request.Context(), map[string]string{AdminResourceRegion: "eu-west-1"})).ToNot(gomega.BeNil())
+				}
+				shared.WriteJSONResponse(writer, http.StatusOK, "")
+			}).Methods(http.MethodPatch)
+			if !tt.uncheckedRoute {
+				middleware.CheckAdminResources(route)
+			}
+			router.Use(middleware.RequireRolesForMethods(errors.ErrorNotFound))
+
+			req := httptest.NewRequest(http.MethodPatch, "http://example.com/admin/kafkas/123", strings.NewReader(tt.body))
+			recorder := httptest.NewRecorder()
+			setContextToken(router, token).ServeHTTP(recorder, req)
+			resp := recorder.Result()
+			resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	// ApiTokenPrefix is the prefix of the API tokens, it tells them apart from the JWT tokens
+	ApiTokenPrefix = "kfm_"
+	// ApiTokenIssuer is the iss claim of the claims resolved from the API tokens
+	ApiTokenIssuer = "urn:kas-fleet-manager:api-tokens"
+
+	apiTokenIdClaim     = "api_token_id"
+	apiTokenScopesClaim = "api_token_scopes"
+)
+
+// Scopes of the API tokens, a write scope grants the read scope of the same resources
+const (
+	ApiTokenScopeKafkasRead      = "kafkas:read"
+	ApiTokenScopeKafkasWrite     = "kafkas:write"
+	ApiTokenScopeConnectorsRead  = "connectors:read"
+	ApiTokenScopeConnectorsWrite = "connectors:write"
+)
+
+var apiTokenScopeImplications = map[string][]string{
+	ApiTokenScopeKafkasRead:     {ApiTokenScopeKafkasWrite},
+	ApiTokenScopeConnectorsRead: {ApiTokenScopeConnectorsWrite},
+}
+
+// ApiTokenScopes returns the scopes the API tokens can be given
+func ApiTokenScopes() []string {
+	return []string{ApiTokenScopeKafkasRead, ApiTokenScopeKafkasWrite, ApiTokenScopeConnectorsRead, ApiTokenScopeConnectorsWrite}
+}
+
+// ApiTokenResolver resolves the API tokens to the tokens stored by the fleet manager
+type ApiTokenResolver interface {
+	// ResolveApiToken returns the token, it fails when the token is unknown, revoked or expired
+	ResolveApiToken(ctx context.Context, token string) (*api.ApiToken, *errors.ServiceError)
+}
+
+// ApiTokenClaims returns the claims of the identity of an API token. The personal tokens act as their owner, without the
+// organisation admin permissions, and the organisation tokens act as an organisation admin.
+func ApiTokenClaims(token *api.ApiToken) jwt.MapClaims {
+	var scopes []string
+	_ = json.Unmarshal(token.Scopes, &scopes)
+	return jwt.MapClaims{
+		"iss":               ApiTokenIssuer,
+		"exp":               token.ExpiresAt.Unix(),
+		apiTokenIdClaim:     token.ID,
+		apiTokenScopesClaim: scopes,
+		tenantUsernameClaim: token.Owner,
+		tenantIdClaim:       token.OrganisationId,
+		tenantUserIdClaim:   token.AccountId,
+		tenantOrgAdminClaim: token.Kind == api.ApiTokenOrganisation,
+	}
+}
+
+// NewApiTokenAuthenticationHandler authenticates the requests with a bearer API token: the claims of the identity of the
+// token are put in the context before calling next. The requests without API token are passed to the fallback
+// authentication handler.
+func NewApiTokenAuthenticationHandler(resolver ApiTokenResolver, next http.Handler, fallback http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		bearer := strings.TrimSpace(request.Header.Get("Authorization"))
+		if len(bearer) < len("Bearer ") || !strings.EqualFold(bearer[:len("Bearer ")], "Bearer ") {
+			fallback.ServeHTTP(writer, request)
+			return
+		}
+		tokenString := strings.TrimSpace(bearer[len("Bearer "):])
+		if !strings.HasPrefix(tokenString, ApiTokenPrefix) {
+			fallback.ServeHTTP(writer, request)
+			return
+		}
+
+		apiToken, err := resolver.ResolveApiToken(request.Context(), tokenString)
+		if err != nil {
+			shared.HandleError(request, writer, errors.Unauthenticated("invalid API token: %s", err.Reason))
+			return
+		}
+		token := &jwt.Token{Claims: ApiTokenClaims(apiToken), Valid: true}
+		next.ServeHTTP(writer, request.WithContext(SetTokenInContext(request.Context(), token)))
+	})
+}
+
+// ApiTokenScopeRule gives the scopes required from the API tokens by the requests of PathPrefix and its sub paths:
+// the Read scope for the GET requests and the Write scope for the other requests. An empty scope denies the requests.
+type ApiTokenScopeRule struct {
+	PathPrefix string
+	Read       string
+	Write      string
+}
+
+// RequireApiTokenScopes checks that the API tokens have the scope required by the rule of the longest path prefix of the
+// request. The requests of the paths without rule are denied to the API tokens. The requests authenticated with a JWT
+// token are left unchecked.
+func RequireApiTokenScopes(rules ...ApiTokenScopeRule) func(handler http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			claims, err := GetClaimsFromContext(request.Context())
+			if err != nil || claims.GetApiTokenId() == "" {
+				next.ServeHTTP(writer, request)
+				return
+			}
+
+			var rule *ApiTokenScopeRule
+			for i := range rules {
+				if matchesPathPrefix(request.URL.Path, rules[i].PathPrefix) && (rule == nil || len(rules[i].PathPrefix) > len(rule.PathPrefix)) {
+					rule = &rules[i]
+				}
+			}
+			scope := ""
+			if rule != nil {
+				scope = rule.Write
+				if request.Method == http.MethodGet || request.Method == http.MethodHead {
+					scope = rule.Read
+				}
+			}
+			if scope == "" {
+				shared.HandleError(request, writer, errors.Forbidden("API tokens are not allowed to %s %s", request.Method, request.URL.Path))
+				return
+			}
+			if !hasApiTokenScope(claims.GetApiTokenScopes(), scope) {
+				shared.HandleError(request, writer, errors.Forbidden("the API token is missing the %s scope", scope))
+				return
+			}
+			next.ServeHTTP(writer, request)
+		})
+	}
+}
+
+// matchesPathPrefix tells whether the path is the prefix or one of its sub paths
+func matchesPathPrefix(path string, prefix string) bool {
+	return path == prefix || strings.HasPrefix(path, strings.TrimSuffix(prefix, "/")+"/")
+}
+
+func hasApiTokenScope(scopes []string, scope string) bool {
+	return arrays.Contains(scopes, scope) ||
+		arrays.AnyMatch(apiTokenScopeImplications[scope], func(implied string) bool { return arrays.Contains(scopes, implied) })
+}
@@ -0,0 +1,82 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package auth
+
+import (
+	"context"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"sync"
+)
+
+// Ensure, that AuditEventRecorderMock does implement AuditEventRecorder.
+// If this is not the case, regenerate this file with moq.
+var _ AuditEventRecorder = &AuditEventRecorderMock{}
+
+// AuditEventRecorderMock is a mock implementation of AuditEventRecorder.
+//
+//	func TestSomethingThatUsesAuditEventRecorder(t *testing.T) {
+//
+//		// make and configure a mocked AuditEventRecorder
+//		mockedAuditEventRecorder := &AuditEventRecorderMock{
+//			RecordAuditEventFunc: func(ctx context.Context, event *api.AuditEvent) error {
+//				panic("mock out the RecordAuditEvent method")
+//			},
+//		}
+//
+//		// use mockedAuditEventRecorder in code that requires AuditEventRecorder
+//		// and then make assertions.
+//
+//	}
+type AuditEventRecorderMock struct {
+	// RecordAuditEventFunc mocks the RecordAuditEvent method.
+	RecordAuditEventFunc func(ctx context.Context, event *api.AuditEvent) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// RecordAuditEvent holds details about calls to the RecordAuditEvent method.
+		RecordAuditEvent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Event is the event argument value.
+			Event *api.AuditEvent
+		}
+	}
+	lockRecordAuditEvent sync.RWMutex
+}
+
+// RecordAuditEvent calls RecordAuditEventFunc.
+func (mock *AuditEventRecorderMock) RecordAuditEvent(ctx context.Context, event *api.AuditEvent) error {
+	if mock.RecordAuditEventFunc == nil {
+		panic("AuditEventRecorderMock.RecordAuditEventFunc: method is nil but AuditEventRecorder.RecordAuditEvent was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Event *api.AuditEvent
+	}{
+		Ctx:   ctx,
+		Event: event,
+	}
+	mock.lockRecordAuditEvent.Lock()
+	mock.calls.RecordAuditEvent = append(mock.calls.RecordAuditEvent, callInfo)
+	mock.lockRecordAuditEvent.Unlock()
+	return mock.RecordAuditEventFunc(ctx, event)
+}
+
+// RecordAuditEventCalls gets all the calls that were made to RecordAuditEvent.
+// Check the length with:
+//
+//	len(mockedAuditEventRecorder.RecordAuditEventCalls())
+func (mock *AuditEventRecorderMock) RecordAuditEventCalls() []struct {
+	Ctx   context.Context
+	Event *api.AuditEvent
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Event *api.AuditEvent
+	}
+	mock.lockRecordAuditEvent.RLock()
+	calls = mock.calls.RecordAuditEvent
+	mock.lockRecordAuditEvent.RUnlock()
+	return calls
+}
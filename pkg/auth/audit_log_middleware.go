@@ -1,37 +1,68 @@
 package auth
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/server/logging"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
-	"io"
-	"net/http"
+	"github.com/gorilla/mux"
 )
 
 type AuditLogMiddleware interface {
+	// AuditLog logs and records every request, requests without claims are rejected with the given error code
 	AuditLog(code errors.ServiceErrorCode) func(handler http.Handler) http.Handler
+	// AuditMutations only records the requests that aren't GET requests, and the GET requests denied by the authorizer
+	AuditMutations() func(handler http.Handler) http.Handler
+}
+
+//go:generate moq -out audit_event_recorder_moq.go . AuditEventRecorder
+
+// AuditEventRecorder persists the audit events recorded by the audit log middleware
+type AuditEventRecorder interface {
+	RecordAuditEvent(ctx context.Context, event *api.AuditEvent) error
 }
 
 type auditInfo struct {
-	Type               string        `json:"type"`
-	Username           string        `json:"username"`
-	Method             string        `json:"request_method,omitempty"`
-	RequestURI         string        `json:"request_url,omitempty"`
-	Body               io.ReadCloser `json:"request_body,omitempty"`
-	RemoteAddr         string        `json:"request_remote_ip,omitempty"`
-	ResponseStatusCode int           `json:"response_status_code,omitempty"`
+	Type               string          `json:"type"`
+	Username           string          `json:"username"`
+	Method             string          `json:"request_method,omitempty"`
+	RequestURI         string          `json:"request_url,omitempty"`
+	Body               json.RawMessage `json:"request_body,omitempty"`
+	RemoteAddr         string          `json:"request_remote_ip,omitempty"`
+	ResponseStatusCode int             `json:"response_status_code,omitempty"`
+	// Authorization holds the decisions of the authorizer of the request
+	Authorization []*AuthorizationDecision `json:"authorization,omitempty"`
 }
 
 type auditLogMiddleware struct {
+	recorder AuditEventRecorder
+	service  string
 }
 
 var _ AuditLogMiddleware = &auditLogMiddleware{}
 
+// NewAuditLogMiddleware creates a middleware that only writes the audited requests to the request log
 func NewAuditLogMiddleware() AuditLogMiddleware {
 	return &auditLogMiddleware{}
 }
 
+// NewAuditTrailMiddleware creates a middleware that also records the audited requests of a service with the given recorder
+func NewAuditTrailMiddleware(recorder AuditEventRecorder, service string) AuditLogMiddleware {
+	return &auditLogMiddleware{
+		recorder: recorder,
+		service:  service,
+	}
+}
+
 func (a *auditLogMiddleware) AuditLog(code errors.ServiceErrorCode) func(handler http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
@@ -42,13 +73,18 @@ func (a *auditLogMiddleware) AuditLog(code errors.ServiceErrorCode) func(handler
 				shared.HandleError(request, writer, serviceErr)
 				return
 			}
+			body, err := readAuditedBody(request)
+			if err != nil {
+				shared.HandleError(request, writer, serviceErr)
+				return
+			}
 			username, _ := claims.GetUsername()
 			info := auditInfo{
 				Type:       "audit",
 				Username:   username,
 				Method:     request.Method,
 				RequestURI: request.RequestURI,
-				Body:       request.Body,
+				Body:       body,
 				RemoteAddr: request.RemoteAddr,
 			}
 			logWriter := logging.NewLoggingWriter(writer, request, logging.NewJSONLogFormatter())
@@ -57,19 +93,190 @@ func (a *auditLogMiddleware) AuditLog(code errors.ServiceErrorCode) func(handler
 				shared.HandleError(request, writer, serviceErr)
 				return
 			}
+			state := &auditState{}
+			request = request.WithContext(context.WithValue(ctx, contextAuditState, state))
 			next.ServeHTTP(logWriter, request)
 			statusCode := logWriter.GetResponseStatusCode()
 			info = auditInfo{
 				Type:               "audit",
 				ResponseStatusCode: statusCode,
+				Authorization:      state.decisions,
 			}
 			// the logger will also add the operationId prefix to each of the log message, which can then be used to associated the two log messages together
 			err = logWriter.LogObject(info, nil)
 			if err != nil {
 				// response is already returned, just log the error if there is any
 				logWriter.Log(fmt.Sprintf("failed to log object %v", info), err)
+			}
+			a.record(request, claims, body, state, logWriter)
+		})
+	}
+}
+
+func (a *auditLogMiddleware) AuditMutations() func(handler http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			ctx := request.Context()
+			if request.Method == http.MethodGet || request.Method == http.MethodHead || request.Method == http.MethodOptions {
+				// the read requests are only recorded when the authorizer denies them, their response body isn't kept
+				statusWriter := &auditStatusWriter{ResponseWriter: writer}
+				state := &auditState{}
+				request = request.WithContext(context.WithValue(ctx, contextAuditState, state))
+				next.ServeHTTP(statusWriter, request)
+				if state.hasDeniedDecision() {
+					claims, _ := GetClaimsFromContext(ctx)
+					a.record(request, claims, nil, state, statusWriter)
+				}
+				return
+			}
+			claims, _ := GetClaimsFromContext(ctx)
+			body, err := readAuditedBody(request)
+			if err != nil {
+				shared.HandleError(request, writer, errors.New(errors.ErrorBadRequest, "unable to read request body"))
 				return
 			}
+			logWriter := logging.NewLoggingWriter(writer, request, logging.NewJSONLogFormatter())
+			state := &auditState{}
+			request = request.WithContext(context.WithValue(ctx, contextAuditState, state))
+			next.ServeHTTP(logWriter, request)
+			a.record(request, claims, body, state, logWriter)
 		})
 	}
 }
+
+// readAuditedBody reads the body of the request, which is then restored for the next handlers, and returns its redacted content
+func readAuditedBody(request *http.Request) (json.RawMessage, error) {
+	if request.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	request.Body = io.NopCloser(bytes.NewReader(body))
+	return RedactAuditJSON(body), nil
+}
+
+func (a *auditLogMiddleware) record(request *http.Request, claims KFMClaims, body json.RawMessage, state *auditState,
+	writer interface {
+		GetResponseStatusCode() int
+		GetResponseBody() []byte
+	}) {
+	if a.recorder == nil {
+		return
+	}
+	ctx := request.Context()
+
+	statusCode := writer.GetResponseStatusCode()
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	outcome := api.AuditOutcomeSuccess
+	switch {
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		outcome = api.AuditOutcomeDenied
+	case statusCode >= http.StatusBadRequest:
+		outcome = api.AuditOutcomeFailure
+	}
+
+	event := &api.AuditEvent{
+		Service:     a.service,
+		Method:      request.Method,
+		Path:        request.URL.Path,
+		Route:       request.URL.Path,
+		ResourceId:  state.resourceId,
+		StatusCode:  statusCode,
+		Outcome:     outcome,
+		RemoteAddr:  request.RemoteAddr,
+		RequestBody: api.JSON(body),
+	}
+	if opId, ok := ctx.Value(logger.OpIDKey).(string); ok {
+		event.OperationId = opId
+	}
+	if claims != nil {
+		event.Actor, _ = claims.GetUsername()
+		event.OrganisationId, _ = claims.GetOrgId()
+		event.Claims = auditClaims(claims)
+	}
+	if route := mux.CurrentRoute(request); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			event.Route = template
+			// the resource of the request is identified by the last variable of the route
+			if event.ResourceId == "" {
+				event.ResourceId = lastRouteVariable(request, template)
+			}
+		}
+	}
+	// resources created by the request are identified by the id of the response
+	if event.ResourceId == "" && outcome == api.AuditOutcomeSuccess && request.Method == http.MethodPost {
+		var created struct {
+			Id string `json:"id"`
+		}
+		if err := json.Unmarshal(writer.GetResponseBody(), &created); err == nil {
+			event.ResourceId = created.Id
+		}
+	}
+	if len(state.decisions) != 0 {
+		if decisions, err := json.Marshal(state.decisions); err == nil {
+			event.Authorization = decisions
+		}
+	}
+	if len(state.diff) != 0 {
+		if diff, err := json.Marshal(state.diff); err == nil {
+			event.Diff = diff
+		}
+	}
+
+	if err := a.recorder.RecordAuditEvent(ctx, event); err != nil {
+		// the response is already returned, the audit trail is best effort
+		logger.NewUHCLogger(ctx).Errorf("failed to record audit event of %s %s: %v", event.Method, event.Path, err)
+	}
+}
+
+// auditStatusWriter keeps the status code of the response
+type auditStatusWriter struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (w *auditStatusWriter) WriteHeader(statusCode int) {
+	w.statusCode = statusCode
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *auditStatusWriter) GetResponseStatusCode() int {
+	return w.statusCode
+}
+
+func (w *auditStatusWriter) GetResponseBody() []byte {
+	return nil
+}
+
+var routeVariablePattern = regexp.MustCompile(`\{([^}:]+)(:[^}]*)?\}`)
+
+func lastRouteVariable(request *http.Request, template string) string {
+	matches := routeVariablePattern.FindAllStringSubmatch(template, -1)
+	if len(matches) == 0 {
+		return ""
+	}
+	return mux.Vars(request)[matches[len(matches)-1][1]]
+}
+
+// auditClaims returns the subset of the claims recorded in the audit events
+func auditClaims(claims KFMClaims) api.JSON {
+	subset := map[string]interface{}{}
+	for _, claim := range []string{tenantUsernameClaim, alternateTenantUsernameClaim, tenantUserIdClaim, tenantIdClaim, alternateTenantIdClaim,
+		tenantOrgAdminClaim, clientIDclaim, "iss", "sub"} {
+		if value, ok := claims[claim]; ok {
+			subset[claim] = value
+		}
+	}
+	if roles := getRealmRolesClaim(claims); len(roles) != 0 {
+		subset["realm_roles"] = roles
+	}
+	result, err := json.Marshal(subset)
+	if err != nil {
+		return nil
+	}
+	return result
+}
@@ -1,13 +1,18 @@
 package auth
 
 import (
+	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/gorilla/mux"
 	"github.com/onsi/gomega"
 )
 
@@ -58,3 +63,207 @@ func TestAuditLogMiddleware_AuditLog(t *testing.T) {
 		})
 	}
 }
+
+func TestAuditTrailMiddleware(t *testing.T) {
+	token := &jwt.Token{Claims: jwt.MapClaims{
+		"username": "test user",
+		"org_id":   "12345",
+		"email":    "test@example.com",
+	}}
+
+	tests := []struct {
+		name          string
+		mutationsOnly bool
+		method        string
+		path          string
+		body          string
+		next          http.Handler
+		wantRecorded  bool
+		wantEvent     api.AuditEvent
+		wantBody      string
+		wantDecisions string
+	}{
+		{
+			name:   "should record an admin request with the resource id of the route",
+			method: http.MethodPatch,
+			path:   "/kafkas/kafka-id",
+			body:   `{"strimzi_version":"v1","credentials":{"password":"p"}}`,
+			next: http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				RecordAuditDiff(request.Context(), map[string]string{"strimzi_version": "v0"}, map[string]string{"strimzi_version": "v1"})
+				shared.WriteJSONResponse(writer, http.StatusOK, "")
+			}),
+			wantRecorded: true,
+			wantEvent: api.AuditEvent{
+				Service:        "test",
+				Actor:          "test user",
+				OrganisationId: "12345",
+				Method:         http.MethodPatch,
+				Route:          "/kafkas/{id}",
+				Path:           "/kafkas/kafka-id",
+				ResourceId:     "kafka-id",
+				StatusCode:     http.StatusOK,
+				Outcome:        api.AuditOutcomeSuccess,
+			},
+			wantBody: `{"strimzi_version":"v1","credentials":"***"}`,
+		},
+		{
+			name:   "should record the denied requests",
+			method: http.MethodDelete,
+			path:   "/kafkas/kafka-id",
+			next: http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				shared.HandleError(request, writer, errors.Forbidden("forbidden"))
+			}),
+			wantRecorded: true,
+			wantEvent: api.AuditEvent{
+				Service:        "test",
+				Actor:          "test user",
+				OrganisationId: "12345",
+				Method:         http.MethodDelete,
+				Route:          "/kafkas/{id}",
+				Path:           "/kafkas/kafka-id",
+				ResourceId:     "kafka-id",
+				StatusCode:     http.StatusForbidden,
+				Outcome:        api.AuditOutcomeDenied,
+			},
+		},
+		{
+			name:          "should record the id of the created resource",
+			mutationsOnly: true,
+			method:        http.MethodPost,
+			path:          "/kafkas",
+			body:          `{"name":"test"}`,
+			next: http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				shared.WriteJSONResponse(writer, http.StatusAccepted, map[string]string{"id": "new-id"})
+			}),
+			wantRecorded: true,
+			wantEvent: api.AuditEvent{
+				Service:        "test",
+				Actor:          "test user",
+				OrganisationId: "12345",
+				Method:         http.MethodPost,
+				Route:          "/kafkas",
+				Path:           "/kafkas",
+				ResourceId:     "new-id",
+				StatusCode:     http.StatusAccepted,
+				Outcome:        api.AuditOutcomeSuccess,
+			},
+			wantBody: `{"name":"test"}`,
+		},
+		{
+			name:          "should record the failed mutations",
+			mutationsOnly: true,
+			method:        http.MethodPost,
+			path:          "/kafkas",
+			body:          `{"name":""}`,
+			next: http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				shared.HandleError(request, writer, errors.BadRequest("invalid name"))
+			}),
+			wantRecorded: true,
+			wantEvent: api.AuditEvent{
+				Service:        "test",
+				Actor:          "test user",
+				OrganisationId: "12345",
+				Method:         http.MethodPost,
+				Route:          "/kafkas",
+				Path:           "/kafkas",
+				StatusCode:     http.StatusBadRequest,
+				Outcome:        api.AuditOutcomeFailure,
+			},
+			wantBody: `{"name":""}`,
+		},
+		{
+			name:          "should not record the get requests when only recording mutations",
+			mutationsOnly: true,
+			method:        http.MethodGet,
+			path:          "/kafkas/kafka-id",
+			next: http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				shared.WriteJSONResponse(writer, http.StatusOK, "")
+			}),
+			wantRecorded: false,
+		},
+		{
+			name:          "should record the get requests denied by the authorizer when only recording mutations",
+			mutationsOnly: true,
+			method:        http.MethodGet,
+			path:          "/kafkas/kafka-id",
+			next: http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				resource := AuthorizationResource{Type: ResourceWebhookSubscription, Id: "kafka-id"}
+				err := RequireAuthorization(request.Context(), ActionGet, resource, errors.ErrorForbidden, "forbidden")
+				shared.HandleError(request, writer, err)
+			}),
+			wantRecorded: true,
+			wantEvent: api.AuditEvent{
+				Service:        "test",
+				Actor:          "test user",
+				OrganisationId: "12345",
+				Method:         http.MethodGet,
+				Route:          "/kafkas/{id}",
+				Path:           "/kafkas/kafka-id",
+				ResourceId:     "kafka-id",
+				StatusCode:     http.StatusForbidden,
+				Outcome:        api.AuditOutcomeDenied,
+			},
+			wantDecisions: `[{"engine":"default","action":"get","resource_type":"webhook_subscription","resource_id":"kafka-id","allowed":false}]`,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			var recorded []*api.AuditEvent
+			eventRecorder := &AuditEventRecorderMock{
+				RecordAuditEventFunc: func(ctx context.Context, event *api.AuditEvent) error {
+					recorded = append(recorded, event)
+					return nil
+				},
+			}
+			auditTrail := NewAuditTrailMiddleware(eventRecorder, "test")
+			middleware := auditTrail.AuditLog(errors.ErrorNotFound)
+			if tt.mutationsOnly {
+				middleware = auditTrail.AuditMutations()
+			}
+
+			var receivedBody string
+			next := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				body, err := io.ReadAll(request.Body)
+				g.Expect(err).ToNot(gomega.HaveOccurred())
+				receivedBody = string(body)
+				tt.next.ServeHTTP(writer, request)
+			})
+			router := mux.NewRouter()
+			router.Handle("/kafkas", next)
+			router.Handle("/kafkas/{id}", next)
+			router.Use(func(handler http.Handler) http.Handler { return setContextToken(handler, token) })
+			router.Use(middleware)
+
+			req := httptest.NewRequest(tt.method, "http://example.com"+tt.path, strings.NewReader(tt.body))
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			// the body must still be readable by the handlers
+			g.Expect(receivedBody).To(gomega.Equal(tt.body))
+			if !tt.wantRecorded {
+				g.Expect(recorded).To(gomega.BeEmpty())
+				return
+			}
+			g.Expect(recorded).To(gomega.HaveLen(1))
+			event := recorded[0]
+			g.Expect([]byte(event.Claims)).To(gomega.MatchJSON(`{"username":"test user","org_id":"12345"}`))
+			if tt.wantBody == "" {
+				g.Expect(event.RequestBody).To(gomega.BeEmpty())
+			} else {
+				g.Expect([]byte(event.RequestBody)).To(gomega.MatchJSON(tt.wantBody))
+			}
+			if tt.method == http.MethodPatch {
+				g.Expect([]byte(event.Diff)).To(gomega.MatchJSON(`{"strimzi_version":{"old":"v0","new":"v1"}}`))
+			}
+			if tt.wantDecisions == "" {
+				g.Expect(event.Authorization).To(gomega.BeEmpty())
+			} else {
+				g.Expect([]byte(event.Authorization)).To(gomega.MatchJSON(tt.wantDecisions))
+			}
+			event.Claims, event.RequestBody, event.Diff, event.Authorization, event.RemoteAddr = nil, nil, nil, nil, ""
+			g.Expect(*event).To(gomega.Equal(tt.wantEvent))
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+)
+
+const contextAuditState contextKey = "audit-state"
+
+// AuditRedactedValue replaces the values of the redacted fields in the audit events
+const AuditRedactedValue = "***"
+
+// redactedAuditFields are the fields whose values are never recorded in the audit events,
+// besides any field containing secret, password or private_key, or ending with token
+var redactedAuditFields = []string{"connector", "connector_spec", "template_parameters", "credentials", "authorization"}
+
+// AuditChange is the old and new value of a field changed by an audited request
+type AuditChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// auditState holds what the handlers know about the audited request
+type auditState struct {
+	resourceId string
+	diff       map[string]AuditChange
+	decisions  []*AuthorizationDecision
+}
+
+func getAuditState(ctx context.Context) *auditState {
+	state, _ := ctx.Value(contextAuditState).(*auditState)
+	return state
+}
+
+// SetAuditResourceId sets the id of the resource of the audited request,
+// when it isn't the last variable of the route or the id of the created resource
+func SetAuditResourceId(ctx context.Context, resourceId string) {
+	if state := getAuditState(ctx); state != nil {
+		state.resourceId = resourceId
+	}
+}
+
+// RecordAuthorizationDecision records a decision of the authorizer of the audited request
+func RecordAuthorizationDecision(ctx context.Context, decision *AuthorizationDecision) {
+	if state := getAuditState(ctx); state != nil && decision != nil {
+		state.decisions = append(state.decisions, decision)
+	}
+}
+
+// hasDeniedDecision returns whether the authorizer denied an action of the audited request
+func (s *auditState) hasDeniedDecision() bool {
+	return arrays.AnyMatch(s.decisions, func(decision *AuthorizationDecision) bool {
+		return !decision.Allowed
+	})
+}
+
+// RecordAuditDiff records the fields changed by the audited request, before and after are the JSON representations of the resource.
+// Nested fields are separated by a '.', and the values of the redacted fields are replaced by AuditRedactedValue.
+func RecordAuditDiff(ctx context.Context, before interface{}, after interface{}) {
+	state := getAuditState(ctx)
+	if state == nil {
+		return
+	}
+	oldFields, newFields := flattenAuditObject(before), flattenAuditObject(after)
+	if state.diff == nil {
+		state.diff = map[string]AuditChange{}
+	}
+	for field, value := range newFields {
+		if old, ok := oldFields[field]; !ok || !reflect.DeepEqual(old, value) {
+			state.diff[field] = redactAuditChange(field, AuditChange{Old: old, New: value})
+		}
+	}
+	for field, old := range oldFields {
+		if _, ok := newFields[field]; !ok {
+			state.diff[field] = redactAuditChange(field, AuditChange{Old: old})
+		}
+	}
+}
+
+func redactAuditChange(field string, change AuditChange) AuditChange {
+	if arrays.AnyMatch(strings.Split(field, "."), isRedactedAuditField) {
+		return AuditChange{Old: AuditRedactedValue, New: AuditRedactedValue}
+	}
+	return change
+}
+
+func flattenAuditObject(o interface{}) map[string]interface{} {
+	fields := map[string]interface{}{}
+	var object map[string]interface{}
+	data, err := json.Marshal(o)
+	if err != nil || json.Unmarshal(data, &object) != nil {
+		return fields
+	}
+	var flatten func(prefix string, object map[string]interface{})
+	flatten = func(prefix string, object map[string]interface{}) {
+		for name, value := range object {
+			if nested, ok := value.(map[string]interface{}); ok && len(nested) != 0 && !isRedactedAuditField(name) {
+				flatten(prefix+name+".", nested)
+			} else {
+				fields[prefix+name] = value
+			}
+		}
+	}
+	flatten("", object)
+	return fields
+}
+
+// RedactAuditJSON returns a JSON document with the values of the redacted fields replaced by AuditRedactedValue,
+// or nil if the document is empty or isn't valid JSON. The values of JSON patch operations whose path goes through
+// a redacted field are redacted too.
+func RedactAuditJSON(data []byte) json.RawMessage {
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return nil
+	}
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil
+	}
+	redacted, err := json.Marshal(redactAuditValue(document))
+	if err != nil {
+		return nil
+	}
+	return redacted
+}
+
+func redactAuditValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		// the values of JSON patch operations (application/json-patch+json) are redacted by their path
+		if path, ok := v["path"].(string); ok && v["op"] != nil {
+			if _, ok := v["value"]; ok && arrays.AnyMatch(strings.Split(path, "/"), isRedactedAuditField) {
+				v["value"] = AuditRedactedValue
+			}
+		}
+		for name, field := range v {
+			if isRedactedAuditField(name) {
+				v[name] = AuditRedactedValue
+			} else {
+				v[name] = redactAuditValue(field)
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redactAuditValue(v[i])
+		}
+	}
+	return value
+}
+
+func isRedactedAuditField(name string) bool {
+	name = strings.ToLower(name)
+	return arrays.Contains(redactedAuditFields, name) ||
+		strings.Contains(name, "secret") ||
+		strings.Contains(name, "password") ||
+		strings.Contains(name, "private_key") ||
+		strings.HasSuffix(name, "token")
+}
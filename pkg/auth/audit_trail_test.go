@@ -0,0 +1,147 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func Test_RedactAuditJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "should return nil for an empty body",
+			data: "  ",
+			want: "",
+		},
+		{
+			name: "should return nil for a body that isn't JSON",
+			data: "name=test",
+			want: "",
+		},
+		{
+			name: "should keep the fields that aren't redacted",
+			data: `{"name":"test","kafka":{"id":"kafka-id","url":"kafka:443"}}`,
+			want: `{"name":"test","kafka":{"id":"kafka-id","url":"kafka:443"}}`,
+		},
+		{
+			name: "should redact the connector spec and the secrets",
+			data: `{"name":"test","connector":{"aws_access_key":"key"},"service_account":{"client_id":"id","client_secret":"secret"}}`,
+			want: `{"name":"test","connector":"***","service_account":{"client_id":"id","client_secret":"***"}}`,
+		},
+		{
+			name: "should redact the passwords, private keys and tokens in arrays",
+			data: `[{"password":"p"},{"tls":{"private_key":"k"}},{"refresh_token":"t"}]`,
+			want: `[{"password":"***"},{"tls":{"private_key":"***"}},{"refresh_token":"***"}]`,
+		},
+		{
+			name: "should redact the template parameters",
+			data: `{"template_id":"template","template_parameters":{"topic":"orders","aws_secret_key":"s"}}`,
+			want: `{"template_id":"template","template_parameters":"***"}`,
+		},
+		{
+			name: "should redact the values of the JSON patch operations of redacted fields",
+			data: `[
+				{"op":"replace","path":"/connector/aws_access_key","value":"key"},
+				{"op":"add","path":"/connector","value":{"aws_access_key":"key"}},
+				{"op":"replace","path":"/template_parameters/topic","value":"orders"},
+				{"op":"replace","path":"/name","value":"test"},
+				{"op":"remove","path":"/connector/aws_region"}
+			]`,
+			want: `[
+				{"op":"replace","path":"/connector/aws_access_key","value":"***"},
+				{"op":"add","path":"/connector","value":"***"},
+				{"op":"replace","path":"/template_parameters/topic","value":"***"},
+				{"op":"replace","path":"/name","value":"test"},
+				{"op":"remove","path":"/connector/aws_region"}
+			]`,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			got := RedactAuditJSON([]byte(tt.data))
+			if tt.want == "" {
+				g.Expect(got).To(gomega.BeNil())
+			} else {
+				g.Expect(got).To(gomega.MatchJSON(tt.want))
+			}
+		})
+	}
+}
+
+func Test_RecordAuditDiff(t *testing.T) {
+	type resource struct {
+		Name      string                 `json:"name"`
+		Status    string                 `json:"status,omitempty"`
+		Kafka     map[string]string      `json:"kafka,omitempty"`
+		Connector map[string]interface{} `json:"connector,omitempty"`
+	}
+
+	tests := []struct {
+		name   string
+		before interface{}
+		after  interface{}
+		want   map[string]AuditChange
+	}{
+		{
+			name:   "should not record unchanged fields",
+			before: resource{Name: "test", Kafka: map[string]string{"id": "kafka-id"}},
+			after:  resource{Name: "test", Kafka: map[string]string{"id": "kafka-id"}},
+			want:   map[string]AuditChange{},
+		},
+		{
+			name:   "should record the changed, added and removed nested fields",
+			before: resource{Name: "test", Status: "ready", Kafka: map[string]string{"id": "kafka-id"}},
+			after:  resource{Name: "renamed", Kafka: map[string]string{"id": "kafka-id", "url": "kafka:443"}},
+			want: map[string]AuditChange{
+				"name":      {Old: "test", New: "renamed"},
+				"status":    {Old: "ready"},
+				"kafka.url": {New: "kafka:443"},
+			},
+		},
+		{
+			name:   "should redact the changes of the connector spec",
+			before: resource{Name: "test", Connector: map[string]interface{}{"topic": "a"}},
+			after:  resource{Name: "test", Connector: map[string]interface{}{"topic": "b"}},
+			want: map[string]AuditChange{
+				"connector": {Old: AuditRedactedValue, New: AuditRedactedValue},
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			state := &auditState{}
+			ctx := context.WithValue(context.Background(), contextAuditState, state)
+			RecordAuditDiff(ctx, tt.before, tt.after)
+
+			got, err := json.Marshal(state.diff)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			want, err := json.Marshal(tt.want)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			if len(tt.want) == 0 {
+				g.Expect(state.diff).To(gomega.BeEmpty())
+			} else {
+				g.Expect(got).To(gomega.MatchJSON(want))
+			}
+		})
+	}
+}
+
+func Test_RecordAuditDiff_NotAudited(t *testing.T) {
+	g := gomega.NewWithT(t)
+	g.Expect(func() {
+		RecordAuditDiff(context.Background(), map[string]string{"name": "a"}, map[string]string{"name": "b"})
+		SetAuditResourceId(context.Background(), "id")
+	}).ToNot(gomega.Panic())
+}
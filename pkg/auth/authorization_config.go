@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+	"github.com/open-policy-agent/opa/rego"
+	pkgErr "github.com/pkg/errors"
+	"github.com/spf13/pflag"
+)
+
+var _ environments.ConfigModule = (*AuthorizationConfig)(nil)
+
+// AuthorizationConfig is the configuration of the authorizer of the public APIs
+type AuthorizationConfig struct {
+	// Engine is the engine of the decisions, the built-in rules or the Rego policies of the OPA engine
+	Engine string
+	// OPAPolicyFile is the Rego module of the policies evaluated by the OPA engine
+	OPAPolicyFile string
+	// OPAQuery is the query of the decision of the policies, it must evaluate to a boolean or to an object with allowed and reason
+	OPAQuery string
+
+	opaQuery rego.PreparedEvalQuery
+}
+
+func NewAuthorizationConfig() *AuthorizationConfig {
+	return &AuthorizationConfig{
+		Engine:        AuthorizationEngineDefault,
+		OPAPolicyFile: "config/authorization-policy.rego",
+		OPAQuery:      "data.kas_fleet_manager.authz.decision",
+	}
+}
+
+func (c *AuthorizationConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&c.Engine, "authorization-engine", c.Engine, fmt.Sprintf("Engine of the authorization decisions of the public APIs: %s or %s", AuthorizationEngineDefault, AuthorizationEngineOPA))
+	fs.StringVar(&c.OPAPolicyFile, "authorization-opa-policy-file", c.OPAPolicyFile, "Rego module of the authorization policies evaluated by the OPA engine")
+	fs.StringVar(&c.OPAQuery, "authorization-opa-query", c.OPAQuery, "Query of the authorization decision of the Rego policies")
+}
+
+// ReadFiles compiles the policies of the OPA engine when it's enabled
+func (c *AuthorizationConfig) ReadFiles() error {
+	switch c.Engine {
+	case AuthorizationEngineDefault:
+		return nil
+	case AuthorizationEngineOPA:
+	default:
+		return fmt.Errorf("unknown authorization engine %q, expected %s or %s", c.Engine, AuthorizationEngineDefault, AuthorizationEngineOPA)
+	}
+
+	policy, err := shared.ReadFile(c.OPAPolicyFile)
+	if err != nil {
+		return pkgErr.Wrap(err, "reading authorization policy")
+	}
+	c.opaQuery, err = prepareOPAQuery(context.Background(), c.OPAPolicyFile, policy, c.OPAQuery)
+	if err != nil {
+		return pkgErr.Wrap(err, "compiling authorization policy")
+	}
+	return nil
+}
+
+// NewAuthorizer returns the authorizer of the configured engine. The OPA engine is given the decisions of the built-in rules
+// of the next authorizer.
+func (c *AuthorizationConfig) NewAuthorizer(next Authorizer) Authorizer {
+	if c.Engine != AuthorizationEngineOPA {
+		return next
+	}
+	return NewOPAAuthorizer(c.opaQuery, next)
+}
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func Test_AuthorizationConfig_ReadFiles(t *testing.T) {
+	dir := t.TempDir()
+	invalidPolicyFile := filepath.Join(dir, "invalid.rego")
+	if err := os.WriteFile(invalidPolicyFile, []byte("package test.authz\n\ndecision = {"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		config  func(c *AuthorizationConfig)
+		wantErr bool
+	}{
+		{
+			name:   "should not read the policy with the default engine",
+			config: func(c *AuthorizationConfig) { c.OPAPolicyFile = filepath.Join(dir, "missing.rego") },
+		},
+		{
+			name:   "should compile the default policy with the opa engine",
+			config: func(c *AuthorizationConfig) { c.Engine = AuthorizationEngineOPA },
+		},
+		{
+			name:    "should return an error for an unknown engine",
+			config:  func(c *AuthorizationConfig) { c.Engine = "unknown" },
+			wantErr: true,
+		},
+		{
+			name: "should return an error for a missing policy",
+			config: func(c *AuthorizationConfig) {
+				c.Engine = AuthorizationEngineOPA
+				c.OPAPolicyFile = filepath.Join(dir, "missing.rego")
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return an error for an invalid policy",
+			config: func(c *AuthorizationConfig) {
+				c.Engine = AuthorizationEngineOPA
+				c.OPAPolicyFile = invalidPolicyFile
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			c := NewAuthorizationConfig()
+			tt.config(c)
+			err := c.ReadFiles()
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
+
+func Test_AuthorizationConfig_NewAuthorizer(t *testing.T) {
+	g := gomega.NewWithT(t)
+	request := AuthorizationRequest{
+		Claims:   KFMClaims{"username": "user", "org_id": "org"},
+		Action:   ActionCreate,
+		Resource: AuthorizationResource{Type: ResourceKafka, Attributes: map[string]interface{}{"instance_type": "standard"}},
+	}
+
+	c := NewAuthorizationConfig()
+	g.Expect(c.ReadFiles()).To(gomega.Succeed())
+	decision, err := c.NewAuthorizer(NewDefaultAuthorizer()).Authorize(context.Background(), request)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(decision.Engine).To(gomega.Equal(AuthorizationEngineDefault))
+	g.Expect(decision.Allowed).To(gomega.BeTrue())
+
+	c.Engine = AuthorizationEngineOPA
+	g.Expect(c.ReadFiles()).To(gomega.Succeed())
+	decision, err = c.NewAuthorizer(NewDefaultAuthorizer()).Authorize(context.Background(), request)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(decision.Engine).To(gomega.Equal(AuthorizationEngineOPA))
+	g.Expect(decision.Allowed).To(gomega.BeTrue())
+}
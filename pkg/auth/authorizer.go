@@ -0,0 +1,208 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+)
+
+const contextAuthorizer contextKey = "authorizer"
+
+// Actions authorized on the resources of the public APIs
+const (
+	// ActionAccess is authorized for every request of the public APIs, before the request is routed to its handler
+	ActionAccess = "access"
+	ActionCreate = "create"
+	ActionGet    = "get"
+	ActionList   = "list"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// Types of the resources of the public APIs
+const (
+	ResourceAPI                 = "api"
+	ResourceKafka               = "kafka"
+	ResourceEnterpriseCluster   = "enterprise_cluster"
+	ResourceWebhookSubscription = "webhook_subscription"
+	// ResourceApiToken is a personal API token, ResourceOrganisationApiToken an organisation API token
+	ResourceApiToken             = "api_token"
+	ResourceOrganisationApiToken = "organisation_api_token"
+	ResourceConnector            = "connector"
+	ResourceConnectorCluster     = "connector_cluster"
+	ResourceConnectorTemplate    = "connector_template"
+	// ResourceOrganisationConnectorNamespace is a connector namespace whose tenant is an organisation
+	ResourceOrganisationConnectorNamespace = "organisation_connector_namespace"
+)
+
+// Authorization engines
+const (
+	AuthorizationEngineDefault = "default"
+	AuthorizationEngineOPA     = "opa"
+)
+
+// orgAdminResources are the resources only managed by the organisation admins
+var orgAdminResources = []string{ResourceEnterpriseCluster, ResourceWebhookSubscription, ResourceOrganisationApiToken, ResourceOrganisationConnectorNamespace}
+
+// orgAdminManagedResources are the resources the users of an organisation get, but only the organisation admins create, update and delete
+var orgAdminManagedResources = []string{ResourceConnectorCluster}
+
+// AuthorizationResource is the resource an action is authorized on. The organisation and the owner are empty when
+// the resource doesn't exist yet, or when the action is on the collection of the resources.
+type AuthorizationResource struct {
+	Type           string `json:"type"`
+	Id             string `json:"id,omitempty"`
+	OrganisationId string `json:"organisation_id,omitempty"`
+	Owner          string `json:"owner,omitempty"`
+	// Attributes are the attributes of the resource the policies can refer to, e.g. the instance type of a kafka
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// AuthorizationRequest is the action of the user of the claims on a resource
+type AuthorizationRequest struct {
+	Claims   KFMClaims
+	Action   string
+	Resource AuthorizationResource
+}
+
+// AuthorizationDecision is the decision of an Authorizer, it's recorded in the audit events of the request
+type AuthorizationDecision struct {
+	Engine       string `json:"engine"`
+	Action       string `json:"action"`
+	ResourceType string `json:"resource_type"`
+	ResourceId   string `json:"resource_id,omitempty"`
+	Allowed      bool   `json:"allowed"`
+	Reason       string `json:"reason,omitempty"`
+	// FilterByOrganisation is whether the resources listed by the user are the ones of their organisation, or the ones they own
+	FilterByOrganisation bool `json:"-"`
+	// ErrorCode is the code of the error returned when the access to the API is denied
+	ErrorCode errors.ServiceErrorCode `json:"-"`
+}
+
+//go:generate moq -out authorizer_moq.go . Authorizer
+
+// Authorizer decides whether the users can perform the actions on the resources of the public APIs
+type Authorizer interface {
+	Authorize(ctx context.Context, request AuthorizationRequest) (*AuthorizationDecision, error)
+}
+
+type defaultAuthorizer struct{}
+
+var _ Authorizer = &defaultAuthorizer{}
+
+// NewDefaultAuthorizer returns the authorizer of the built-in rules:
+//   - the users of an organisation see the resources of their organisation, the users without organisation only see the resources they own
+//   - the resources are updated and deleted by their owner or by the admins of their organisation
+//   - the enterprise clusters and the webhook subscriptions are only managed by the organisation admins
+//   - the lists are allowed, their resources are filtered by organisation or by owner as told by the decision
+//   - the resources the user can't get are reported as not found
+func NewDefaultAuthorizer() Authorizer {
+	return &defaultAuthorizer{}
+}
+
+func (a *defaultAuthorizer) Authorize(_ context.Context, request AuthorizationRequest) (*AuthorizationDecision, error) {
+	username, _ := request.Claims.GetUsername()
+	orgId, _ := request.Claims.GetOrgId()
+	isOrgAdmin := request.Claims.IsOrgAdmin()
+	resource := request.Resource
+
+	decision := newAuthorizationDecision(AuthorizationEngineDefault, request)
+	decision.FilterByOrganisation = orgId != ""
+
+	if arrays.Contains(orgAdminResources, resource.Type) {
+		decision.Allowed = isOrgAdmin && orgId != "" && (resource.OrganisationId == "" || resource.OrganisationId == orgId)
+		return decision, nil
+	}
+
+	if arrays.Contains(orgAdminManagedResources, resource.Type) && request.Action != ActionGet && request.Action != ActionList {
+		decision.Allowed = isOrgAdmin && orgId != "" && (resource.OrganisationId == "" || resource.OrganisationId == orgId)
+		return decision, nil
+	}
+
+	switch request.Action {
+	case ActionGet:
+		decision.Allowed = (orgId != "" && resource.OrganisationId == orgId) || resource.Owner == username
+		if !decision.Allowed {
+			decision.ErrorCode = errors.ErrorNotFound
+		}
+	case ActionUpdate, ActionDelete:
+		decision.Allowed = resource.OrganisationId == orgId && (isOrgAdmin || resource.Owner == username)
+	}
+	return decision, nil
+}
+
+func newAuthorizationDecision(engine string, request AuthorizationRequest) *AuthorizationDecision {
+	return &AuthorizationDecision{
+		Engine:       engine,
+		Action:       request.Action,
+		ResourceType: request.Resource.Type,
+		ResourceId:   request.Resource.Id,
+		Allowed:      true,
+		ErrorCode:    errors.ErrorForbidden,
+	}
+}
+
+// SetAuthorizerContext sets the authorizer of the actions of the request
+func SetAuthorizerContext(ctx context.Context, authorizer Authorizer) context.Context {
+	return context.WithValue(ctx, contextAuthorizer, authorizer)
+}
+
+// GetAuthorizerFromContext returns the authorizer of the request, or the default authorizer if none was set
+func GetAuthorizerFromContext(ctx context.Context) Authorizer {
+	if authorizer, ok := ctx.Value(contextAuthorizer).(Authorizer); ok && authorizer != nil {
+		return authorizer
+	}
+	return NewDefaultAuthorizer()
+}
+
+// Authorize authorizes the action of the user of the request on the resource with the authorizer of the request,
+// and records the decision in the audit event of the request
+func Authorize(ctx context.Context, action string, resource AuthorizationResource) (*AuthorizationDecision, *errors.ServiceError) {
+	claims, err := GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, errors.NewWithCause(errors.ErrorUnauthenticated, err, "user not authenticated")
+	}
+	decision, err := GetAuthorizerFromContext(ctx).Authorize(ctx, AuthorizationRequest{
+		Claims:   claims,
+		Action:   action,
+		Resource: resource,
+	})
+	if err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to authorize the %s of the %s", action, resource.Type)
+	}
+	RecordAuthorizationDecision(ctx, decision)
+	return decision, nil
+}
+
+// AuthorizeList authorizes the user of the request to list the resources of the type. The FilterByOrganisation of the decision
+// tells whether the listed resources are the ones of the organisation of the user, or the ones they own.
+func AuthorizeList(ctx context.Context, resourceType string) (*AuthorizationDecision, *errors.ServiceError) {
+	decision, err := Authorize(ctx, ActionList, AuthorizationResource{Type: resourceType})
+	if err != nil {
+		return nil, err
+	}
+	if decision.Allowed {
+		return decision, nil
+	}
+	if decision.Reason != "" {
+		return nil, errors.New(decision.ErrorCode, "%s", decision.Reason)
+	}
+	return nil, errors.New(decision.ErrorCode, "user not authorized to list the %s resources", resourceType)
+}
+
+// RequireAuthorization returns an error of the given code if the action of the user of the request on the resource isn't allowed.
+// The error reason is the reason of the decision, or the given reason when the decision has none.
+func RequireAuthorization(ctx context.Context, action string, resource AuthorizationResource, code errors.ServiceErrorCode, reason string, values ...interface{}) *errors.ServiceError {
+	decision, err := Authorize(ctx, action, resource)
+	if err != nil {
+		return err
+	}
+	if decision.Allowed {
+		return nil
+	}
+	if decision.Reason != "" {
+		return errors.New(code, "%s", decision.Reason)
+	}
+	return errors.New(code, reason, values...)
+}
@@ -0,0 +1,81 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package auth
+
+import (
+	"context"
+	"sync"
+)
+
+// Ensure, that AuthorizerMock does implement Authorizer.
+// If this is not the case, regenerate this file with moq.
+var _ Authorizer = &AuthorizerMock{}
+
+// AuthorizerMock is a mock implementation of Authorizer.
+//
+//	func TestSomethingThatUsesAuthorizer(t *testing.T) {
+//
+//		// make and configure a mocked Authorizer
+//		mockedAuthorizer := &AuthorizerMock{
+//			AuthorizeFunc: func(ctx context.Context, request AuthorizationRequest) (*AuthorizationDecision, error) {
+//				panic("mock out the Authorize method")
+//			},
+//		}
+//
+//		// use mockedAuthorizer in code that requires Authorizer
+//		// and then make assertions.
+//
+//	}
+type AuthorizerMock struct {
+	// AuthorizeFunc mocks the Authorize method.
+	AuthorizeFunc func(ctx context.Context, request AuthorizationRequest) (*AuthorizationDecision, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Authorize holds details about calls to the Authorize method.
+		Authorize []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Request is the request argument value.
+			Request AuthorizationRequest
+		}
+	}
+	lockAuthorize sync.RWMutex
+}
+
+// Authorize calls AuthorizeFunc.
+func (mock *AuthorizerMock) Authorize(ctx context.Context, request AuthorizationRequest) (*AuthorizationDecision, error) {
+	if mock.AuthorizeFunc == nil {
+		panic("AuthorizerMock.AuthorizeFunc: method is nil but Authorizer.Authorize was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Request AuthorizationRequest
+	}{
+		Ctx:     ctx,
+		Request: request,
+	}
+	mock.lockAuthorize.Lock()
+	mock.calls.Authorize = append(mock.calls.Authorize, callInfo)
+	mock.lockAuthorize.Unlock()
+	return mock.AuthorizeFunc(ctx, request)
+}
+
+// AuthorizeCalls gets all the calls that were made to Authorize.
+// Check the length with:
+//
+//	len(mockedAuthorizer.AuthorizeCalls())
+func (mock *AuthorizerMock) AuthorizeCalls() []struct {
+	Ctx     context.Context
+	Request AuthorizationRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Request AuthorizationRequest
+	}
+	mock.lockAuthorize.RLock()
+	calls = mock.calls.Authorize
+	mock.lockAuthorize.RUnlock()
+	return calls
+}
@@ -0,0 +1,247 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/onsi/gomega"
+)
+
+func Test_defaultAuthorizer_Authorize(t *testing.T) {
+	user := KFMClaims{"username": "user", "org_id": "org"}
+	orgAdmin := KFMClaims{"username": "admin", "org_id": "org", "is_org_admin": true}
+	userWithoutOrg := KFMClaims{"username": "user"}
+
+	tests := []struct {
+		name                     string
+		request                  AuthorizationRequest
+		wantAllowed              bool
+		wantFilterByOrganisation bool
+		wantErrorCode            errors.ServiceErrorCode
+	}{
+		{
+			name:                     "should allow the access to the API and filter by organisation the users of an organisation",
+			request:                  AuthorizationRequest{Claims: user, Action: ActionAccess, Resource: AuthorizationResource{Type: ResourceAPI}},
+			wantAllowed:              true,
+			wantFilterByOrganisation: true,
+		},
+		{
+			name:                     "should allow the access to the API and filter by owner the users without organisation",
+			request:                  AuthorizationRequest{Claims: userWithoutOrg, Action: ActionAccess, Resource: AuthorizationResource{Type: ResourceAPI}},
+			wantAllowed:              true,
+			wantFilterByOrganisation: false,
+		},
+		{
+			name:                     "should allow the creation of a kafka",
+			request:                  AuthorizationRequest{Claims: user, Action: ActionCreate, Resource: AuthorizationResource{Type: ResourceKafka}},
+			wantAllowed:              true,
+			wantFilterByOrganisation: true,
+		},
+		{
+			name:                     "should allow to get a kafka of the organisation of the user",
+			request:                  AuthorizationRequest{Claims: user, Action: ActionGet, Resource: AuthorizationResource{Type: ResourceKafka, OrganisationId: "org", Owner: "other"}},
+			wantAllowed:              true,
+			wantFilterByOrganisation: true,
+		},
+		{
+			name:                     "should deny to get a kafka of another organisation",
+			request:                  AuthorizationRequest{Claims: user, Action: ActionGet, Resource: AuthorizationResource{Type: ResourceKafka, OrganisationId: "other", Owner: "other"}},
+			wantAllowed:              false,
+			wantFilterByOrganisation: true,
+			wantErrorCode:            errors.ErrorNotFound,
+		},
+		{
+			name:                     "should allow a user without organisation to get the kafkas they own",
+			request:                  AuthorizationRequest{Claims: userWithoutOrg, Action: ActionGet, Resource: AuthorizationResource{Type: ResourceKafka, Owner: "user"}},
+			wantAllowed:              true,
+			wantFilterByOrganisation: false,
+		},
+		{
+			name:          "should deny a user without organisation to get the kafkas they don't own",
+			request:       AuthorizationRequest{Claims: userWithoutOrg, Action: ActionGet, Resource: AuthorizationResource{Type: ResourceKafka, Owner: "other"}},
+			wantAllowed:   false,
+			wantErrorCode: errors.ErrorNotFound,
+		},
+		{
+			name:                     "should allow to list the kafkas and filter them by organisation",
+			request:                  AuthorizationRequest{Claims: user, Action: ActionList, Resource: AuthorizationResource{Type: ResourceKafka}},
+			wantAllowed:              true,
+			wantFilterByOrganisation: true,
+		},
+		{
+			name:                     "should allow a user without organisation to list the connectors and filter them by owner",
+			request:                  AuthorizationRequest{Claims: userWithoutOrg, Action: ActionList, Resource: AuthorizationResource{Type: ResourceConnector}},
+			wantAllowed:              true,
+			wantFilterByOrganisation: false,
+		},
+		{
+			name:                     "should allow the owner to delete a kafka",
+			request:                  AuthorizationRequest{Claims: user, Action: ActionDelete, Resource: AuthorizationResource{Type: ResourceKafka, OrganisationId: "org", Owner: "user"}},
+			wantAllowed:              true,
+			wantFilterByOrganisation: true,
+		},
+		{
+			name:                     "should deny a user to update a kafka of their organisation they don't own",
+			request:                  AuthorizationRequest{Claims: user, Action: ActionUpdate, Resource: AuthorizationResource{Type: ResourceKafka, OrganisationId: "org", Owner: "other"}},
+			wantAllowed:              false,
+			wantFilterByOrganisation: true,
+		},
+		{
+			name:                     "should allow an organisation admin to update a kafka of their organisation",
+			request:                  AuthorizationRequest{Claims: orgAdmin, Action: ActionUpdate, Resource: AuthorizationResource{Type: ResourceKafka, OrganisationId: "org", Owner: "other"}},
+			wantAllowed:              true,
+			wantFilterByOrganisation: true,
+		},
+		{
+			name:                     "should deny an organisation admin to delete a kafka of another organisation",
+			request:                  AuthorizationRequest{Claims: orgAdmin, Action: ActionDelete, Resource: AuthorizationResource{Type: ResourceKafka, OrganisationId: "other", Owner: "other"}},
+			wantAllowed:              false,
+			wantFilterByOrganisation: true,
+		},
+		{
+			name:                     "should allow an organisation admin to manage the enterprise clusters",
+			request:                  AuthorizationRequest{Claims: orgAdmin, Action: ActionCreate, Resource: AuthorizationResource{Type: ResourceEnterpriseCluster}},
+			wantAllowed:              true,
+			wantFilterByOrganisation: true,
+		},
+		{
+			name:                     "should deny a user to manage the webhook subscriptions",
+			request:                  AuthorizationRequest{Claims: user, Action: ActionList, Resource: AuthorizationResource{Type: ResourceWebhookSubscription}},
+			wantAllowed:              false,
+			wantFilterByOrganisation: true,
+		},
+		{
+			name:                     "should allow a user to get a connector cluster of their organisation",
+			request:                  AuthorizationRequest{Claims: user, Action: ActionGet, Resource: AuthorizationResource{Type: ResourceConnectorCluster, OrganisationId: "org"}},
+			wantAllowed:              true,
+			wantFilterByOrganisation: true,
+		},
+		{
+			name:                     "should deny a user to update a connector cluster of their organisation",
+			request:                  AuthorizationRequest{Claims: user, Action: ActionUpdate, Resource: AuthorizationResource{Type: ResourceConnectorCluster, OrganisationId: "org"}},
+			wantAllowed:              false,
+			wantFilterByOrganisation: true,
+			wantErrorCode:            errors.ErrorForbidden,
+		},
+		{
+			name:                     "should allow an organisation admin to create a connector cluster",
+			request:                  AuthorizationRequest{Claims: orgAdmin, Action: ActionCreate, Resource: AuthorizationResource{Type: ResourceConnectorCluster}},
+			wantAllowed:              true,
+			wantFilterByOrganisation: true,
+		},
+		{
+			name:                     "should deny a user to create a connector namespace of their organisation",
+			request:                  AuthorizationRequest{Claims: user, Action: ActionCreate, Resource: AuthorizationResource{Type: ResourceOrganisationConnectorNamespace, OrganisationId: "org"}},
+			wantAllowed:              false,
+			wantFilterByOrganisation: true,
+		},
+		{
+			name:                     "should deny an organisation admin to manage the enterprise clusters of another organisation",
+			request:                  AuthorizationRequest{Claims: orgAdmin, Action: ActionGet, Resource: AuthorizationResource{Type: ResourceEnterpriseCluster, OrganisationId: "other"}},
+			wantAllowed:              false,
+			wantFilterByOrganisation: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			decision, err := NewDefaultAuthorizer().Authorize(context.Background(), tt.request)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(decision.Engine).To(gomega.Equal(AuthorizationEngineDefault))
+			g.Expect(decision.Action).To(gomega.Equal(tt.request.Action))
+			g.Expect(decision.ResourceType).To(gomega.Equal(tt.request.Resource.Type))
+			g.Expect(decision.Allowed).To(gomega.Equal(tt.wantAllowed))
+			g.Expect(decision.FilterByOrganisation).To(gomega.Equal(tt.wantFilterByOrganisation))
+			if tt.wantErrorCode != 0 {
+				g.Expect(decision.ErrorCode).To(gomega.Equal(tt.wantErrorCode))
+			}
+		})
+	}
+}
+
+func Test_RequireAuthorization(t *testing.T) {
+	token := &jwt.Token{Claims: jwt.MapClaims{"username": "user", "org_id": "org"}}
+	resource := AuthorizationResource{Type: ResourceKafka, Id: "kafka-id"}
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		authorizer Authorizer
+		wantErr    *errors.ServiceError
+		wantAudit  bool
+	}{
+		{
+			name:    "should deny the action when the request has no claims",
+			ctx:     context.Background(),
+			wantErr: errors.New(errors.ErrorForbidden, "user not authorized to update kafka-id"),
+		},
+		{
+			name: "should return nil when the authorizer of the request allows the action",
+			ctx:  SetTokenInContext(context.Background(), token),
+			authorizer: &AuthorizerMock{
+				AuthorizeFunc: func(ctx context.Context, request AuthorizationRequest) (*AuthorizationDecision, error) {
+					return &AuthorizationDecision{Action: request.Action, ResourceType: request.Resource.Type, Allowed: true}, nil
+				},
+			},
+			wantAudit: true,
+		},
+		{
+			name: "should return the reason of the decision when the authorizer of the request denies the action",
+			ctx:  SetTokenInContext(context.Background(), token),
+			authorizer: &AuthorizerMock{
+				AuthorizeFunc: func(ctx context.Context, request AuthorizationRequest) (*AuthorizationDecision, error) {
+					return &AuthorizationDecision{Action: request.Action, ResourceType: request.Resource.Type, Reason: "denied by policy"}, nil
+				},
+			},
+			wantErr:   errors.New(errors.ErrorForbidden, "denied by policy"),
+			wantAudit: true,
+		},
+		{
+			name:    "should return the given reason when the default authorizer denies the action",
+			ctx:     SetTokenInContext(context.Background(), &jwt.Token{Claims: jwt.MapClaims{"username": "other", "org_id": "other"}}),
+			wantErr: errors.New(errors.ErrorForbidden, "user not authorized to update kafka-id"),
+		},
+		{
+			name: "should return a general error when the authorizer fails",
+			ctx:  SetTokenInContext(context.Background(), token),
+			authorizer: &AuthorizerMock{
+				AuthorizeFunc: func(ctx context.Context, request AuthorizationRequest) (*AuthorizationDecision, error) {
+					return nil, fmt.Errorf("failed")
+				},
+			},
+			wantErr: errors.New(errors.ErrorGeneral, "failed to authorize the update of the kafka"),
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			state := &auditState{}
+			ctx := context.WithValue(tt.ctx, contextAuditState, state)
+			if tt.authorizer != nil {
+				ctx = SetAuthorizerContext(ctx, tt.authorizer)
+			}
+			kafkaResource := resource
+			kafkaResource.OrganisationId, kafkaResource.Owner = "org", "user"
+
+			err := RequireAuthorization(ctx, ActionUpdate, kafkaResource, errors.ErrorForbidden, "user not authorized to update %s", kafkaResource.Id)
+			if tt.wantErr == nil {
+				g.Expect(err).To(gomega.BeNil())
+			} else {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErr.Code))
+				g.Expect(err.Reason).To(gomega.Equal(tt.wantErr.Reason))
+			}
+			if tt.wantAudit {
+				g.Expect(state.decisions).To(gomega.HaveLen(1))
+				g.Expect(state.decisions[0].Action).To(gomega.Equal(ActionUpdate))
+			}
+		})
+	}
+}
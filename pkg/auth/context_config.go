@@ -9,6 +9,7 @@ var (
 	tenantUsernameClaim string = "username"
 	tenantIdClaim       string = "org_id"
 	tenantOrgAdminClaim string = "is_org_admin" // same key used in mas-sso tokens
+	tenantGroupsClaim   string = "groups"
 
 	// sso.redhat.com token claim keys
 	alternateTenantUsernameClaim string = "preferred_username" // same key used in mas-sso tokens
@@ -31,6 +32,7 @@ func (c *ContextConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&tenantUsernameClaim, "tenant-username-claim", tenantUsernameClaim, "Token claims key to retrieve the corresponding user principal.")
 	fs.StringVar(&tenantIdClaim, "tenant-id-claim", tenantIdClaim, "Token claims key to retrieve the corresponding organisation ID.")
 	fs.StringVar(&tenantOrgAdminClaim, "tenant-org-admin-claim", tenantOrgAdminClaim, "Token claims key to retrieve the corresponding organisation admin role.")
+	fs.StringVar(&tenantGroupsClaim, "tenant-groups-claim", tenantGroupsClaim, "Token claims key to retrieve the groups of the user.")
 	fs.StringVar(&alternateTenantUsernameClaim, "alternate-tenant-username-claim", alternateTenantUsernameClaim, "Token claims key to retrieve the corresponding user principal using an alternative claim.")
 	fs.StringVar(&tenantUserIdClaim, "tenant-user-id-claim", tenantUserIdClaim, "Token claims key to retrieve the corresponding  Account ID.")
 	fs.StringVar(&alternateTenantIdClaim, "alternate-tenant-id-claim", alternateTenantIdClaim, "Token claims key to retrieve the corresponding organisation ID using an alternative claim.")
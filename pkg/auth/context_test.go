@@ -37,6 +37,42 @@ func TestContext_GetAccountIdFromClaims(t *testing.T) {
 	}
 }
 
+func TestContext_GetGroupsFromClaims(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims KFMClaims
+		want   []string
+	}{
+		{
+			name:   "Should return nil when tenantGroupsClaim is empty",
+			claims: KFMClaims{},
+			want:   nil,
+		},
+		{
+			name: "Should return a single group",
+			claims: KFMClaims{
+				tenantGroupsClaim: "platform",
+			},
+			want: []string{"platform"},
+		},
+		{
+			name: "Should return the string groups of a list",
+			claims: KFMClaims{
+				tenantGroupsClaim: []interface{}{"platform", 1, "apps"},
+			},
+			want: []string{"platform", "apps"},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(tt.claims.GetGroups()).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
 func TestContext_GetIsOrgAdminFromClaims(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -0,0 +1,259 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/metrics"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/golang/glog"
+	"github.com/mendsley/gojwk"
+)
+
+// Results of the refreshes of the JSON web key sets and of the validations of the tokens, reported in their metrics
+const (
+	JWKSRefreshSuccess = "success"
+	JWKSRefreshFailure = "failure"
+
+	TokenValid           = "valid"
+	TokenUnknownKey      = "unknown_key"
+	TokenInvalidAudience = "invalid_audience"
+	// TokenOrganisationNotAllowed is a token claiming an organisation that isn't allowed for its issuer
+	TokenOrganisationNotAllowed = "organisation_not_allowed"
+	TokenInvalid                = "invalid"
+)
+
+// ErrUnknownSigningKey is returned for the tokens signed by a key missing from the JSON web key set of their issuer
+var ErrUnknownSigningKey = fmt.Errorf("unknown signing key")
+
+var _ environments.BootService = &JWKSManager{}
+
+// JWKSManager keeps the JSON web key sets of the trusted issuers. The key sets are refreshed periodically and when a token
+// is signed by an unknown key, the keys removed from a key set are still accepted for a grace period so that the tokens
+// signed before a key rollover stay valid until they expire.
+type JWKSManager struct {
+	config *TrustedIssuersConfig
+	client *http.Client
+	now    func() time.Time
+
+	mutex   sync.Mutex
+	keySets map[string]*jwkSet
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+type jwkSet struct {
+	// mutex serializes the refreshes of the key set
+	mutex       sync.Mutex
+	jwksURL     string
+	keys        map[string]*jwk
+	refreshedAt time.Time
+	keysMutex   sync.RWMutex
+}
+
+type jwk struct {
+	key crypto.PublicKey
+	// expiresAt is the end of the grace period of a key removed from the key set, zero while it's in the key set
+	expiresAt time.Time
+}
+
+func NewJWKSManager(config *TrustedIssuersConfig) *JWKSManager {
+	return &JWKSManager{
+		config:  config,
+		client:  &http.Client{Timeout: 30 * time.Second},
+		now:     time.Now,
+		keySets: map[string]*jwkSet{},
+	}
+}
+
+// Start reloads the trusted issuers file and refreshes the key sets every reload interval
+func (m *JWKSManager) Start() {
+	m.stop = make(chan struct{})
+	m.done = make(chan struct{})
+	go func() {
+		defer close(m.done)
+		ticker := time.NewTicker(m.config.ReloadInterval)
+		defer ticker.Stop()
+		for {
+			m.Reconcile()
+			select {
+			case <-m.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (m *JWKSManager) Stop() {
+	if m.stop == nil {
+		return
+	}
+	close(m.stop)
+	<-m.done
+}
+
+// Reconcile reloads the trusted issuers file, drops the key sets of the issuers no longer trusted and refreshes the key sets
+// due for a refresh
+func (m *JWKSManager) Reconcile() {
+	if changed, err := m.config.Reload(); err != nil {
+		glog.Errorf("failed to reload the trusted issuers, keeping the current trusted issuers: %v", err)
+	} else if changed {
+		glog.Infof("trusted issuers reloaded from %s", m.config.TrustedIssuersFile)
+	}
+
+	issuers := m.config.Issuers.List()
+	trusted := make(map[string]bool, len(issuers))
+	for _, issuer := range issuers {
+		trusted[issuer.Issuer] = true
+	}
+	m.mutex.Lock()
+	for issuer := range m.keySets {
+		if !trusted[issuer] {
+			delete(m.keySets, issuer)
+			metrics.DeleteJWKSKeysMetric(issuer)
+		}
+	}
+	m.mutex.Unlock()
+
+	for _, issuer := range issuers {
+		refreshInterval := issuer.RefreshInterval
+		if refreshInterval == 0 {
+			refreshInterval = m.config.JwksRefreshInterval
+		}
+		if err := m.refresh(issuer, refreshInterval); err != nil {
+			glog.Errorf("failed to refresh the JSON web key set of issuer %q: %v", issuer.Issuer, err)
+		}
+	}
+}
+
+// Keyfunc returns the key of the token from the key set of the trusted issuer. The key set is refreshed when the key is
+// unknown, at most once every minimum refresh interval.
+func (m *JWKSManager) Keyfunc(issuer TrustedIssuer) jwt.Keyfunc {
+	return func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		if key, ok := m.keySet(issuer).get(kid, m.now()); ok {
+			return key, nil
+		}
+		if err := m.refresh(issuer, m.config.JwksMinRefreshInterval); err != nil {
+			return nil, err
+		}
+		if key, ok := m.keySet(issuer).get(kid, m.now()); ok {
+			return key, nil
+		}
+		return nil, ErrUnknownSigningKey
+	}
+}
+
+func (m *JWKSManager) keySet(issuer TrustedIssuer) *jwkSet {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	keySet, ok := m.keySets[issuer.Issuer]
+	if !ok || keySet.jwksURL != issuer.JwksURL {
+		keySet = &jwkSet{jwksURL: issuer.JwksURL, keys: map[string]*jwk{}}
+		m.keySets[issuer.Issuer] = keySet
+	}
+	return keySet
+}
+
+// refresh fetches the key set of the issuer when it wasn't refreshed for the given interval
+func (m *JWKSManager) refresh(issuer TrustedIssuer, interval time.Duration) error {
+	keySet := m.keySet(issuer)
+	keySet.mutex.Lock()
+	defer keySet.mutex.Unlock()
+
+	now := m.now()
+	if !keySet.refreshedAt.IsZero() && now.Sub(keySet.refreshedAt) < interval {
+		return nil
+	}
+	// failed refreshes are rate limited too, so that an unavailable issuer isn't called for every token
+	keySet.refreshedAt = now
+
+	keys, err := m.fetch(issuer.JwksURL)
+	if err != nil {
+		metrics.IncreaseJWKSRefreshCount(issuer.Issuer, JWKSRefreshFailure)
+		return err
+	}
+	metrics.IncreaseJWKSRefreshCount(issuer.Issuer, JWKSRefreshSuccess)
+	metrics.UpdateJWKSKeysMetric(issuer.Issuer, keySet.rollover(keys, now, m.config.JwksKeyGracePeriod))
+	return nil
+}
+
+func (m *JWKSManager) fetch(jwksURL string) (map[string]crypto.PublicKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), m.client.Timeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, jwksURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := m.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d from %s", response.StatusCode, jwksURL)
+	}
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	set, err := gojwk.Unmarshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON web key set from %s: %v", jwksURL, err)
+	}
+	keys := map[string]crypto.PublicKey{}
+	for _, key := range set.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		publicKey, err := key.DecodePublicKey()
+		if err != nil {
+			glog.Warningf("ignoring the key %q of the JSON web key set %s: %v", key.Kid, jwksURL, err)
+			continue
+		}
+		keys[key.Kid] = publicKey
+	}
+	return keys, nil
+}
+
+// rollover replaces the keys of the key set by the fetched keys. The removed keys are kept until the grace period elapsed.
+// It returns the number of keys of the key set.
+func (s *jwkSet) rollover(keys map[string]crypto.PublicKey, now time.Time, gracePeriod time.Duration) int {
+	s.keysMutex.Lock()
+	defer s.keysMutex.Unlock()
+
+	for kid, key := range s.keys {
+		if _, ok := keys[kid]; ok {
+			continue
+		}
+		if key.expiresAt.IsZero() {
+			key.expiresAt = now.Add(gracePeriod)
+		}
+		if !now.Before(key.expiresAt) {
+			delete(s.keys, kid)
+		}
+	}
+	for kid, key := range keys {
+		s.keys[kid] = &jwk{key: key}
+	}
+	return len(s.keys)
+}
+
+func (s *jwkSet) get(kid string, now time.Time) (crypto.PublicKey, bool) {
+	s.keysMutex.RLock()
+	defer s.keysMutex.RUnlock()
+	key, ok := s.keys[kid]
+	if !ok || (!key.expiresAt.IsZero() && !now.Before(key.expiresAt)) {
+		return nil, false
+	}
+	return key.key, true
+}
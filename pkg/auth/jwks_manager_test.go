@@ -0,0 +1,162 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/mendsley/gojwk"
+	"github.com/onsi/gomega"
+)
+
+// jwksServer serves the JSON web key set of the public keys of its signing keys
+type jwksServer struct {
+	*httptest.Server
+	mutex    sync.Mutex
+	keys     map[string]*rsa.PrivateKey
+	requests int32
+}
+
+func newJWKSServer(t *testing.T) *jwksServer {
+	s := &jwksServer{keys: map[string]*rsa.PrivateKey{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&s.requests, 1)
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		set := &gojwk.Key{Keys: []*gojwk.Key{}}
+		for kid, key := range s.keys {
+			publicKey, err := gojwk.PublicKey(&key.PublicKey)
+			if err != nil {
+				t.Errorf("unable to generate public jwk: %v", err)
+				return
+			}
+			publicKey.Kid = kid
+			publicKey.Use = "sig"
+			set.Keys = append(set.Keys, publicKey)
+		}
+		_ = json.NewEncoder(w).Encode(set)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// rotate replaces the signing keys of the server by a new key
+func (s *jwksServer) rotate(t *testing.T, kid string) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.keys = map[string]*rsa.PrivateKey{kid: key}
+	return key
+}
+
+func signToken(t *testing.T, key *rsa.PrivateKey, kid string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("unable to sign token: %v", err)
+	}
+	return signed
+}
+
+func Test_JWKSManager_KeyRollover(t *testing.T) {
+	g := gomega.NewWithT(t)
+	server := newJWKSServer(t)
+	server.rotate(t, "key-1")
+
+	config := NewTrustedIssuersConfig()
+	config.TrustedIssuersFile = ""
+	config.JwksKeyGracePeriod = 10 * time.Minute
+	issuer := TrustedIssuer{Issuer: "https://partner.example.com", JwksURL: server.URL, AllowHTTPJwksURL: true, Claims: ClaimMappings{Username: "email"}, OrganisationId: "partner-org"}
+	g.Expect(config.Issuers.Set([]TrustedIssuer{issuer})).To(gomega.Succeed())
+
+	now := time.Now()
+	manager := NewJWKSManager(config)
+	manager.now = func() time.Time { return now }
+	keyfunc := manager.Keyfunc(issuer)
+	lookup := func(kid string) error {
+		_, err := keyfunc(&jwt.Token{Header: map[string]interface{}{"kid": kid}})
+		return err
+	}
+
+	manager.Reconcile()
+	g.Expect(lookup("key-1")).To(gomega.Succeed())
+	g.Expect(atomic.LoadInt32(&server.requests)).To(gomega.Equal(int32(1)))
+
+	// an unknown key triggers a refresh once the minimum refresh interval elapsed
+	server.rotate(t, "key-2")
+	g.Expect(lookup("key-2")).To(gomega.MatchError(ErrUnknownSigningKey))
+	g.Expect(atomic.LoadInt32(&server.requests)).To(gomega.Equal(int32(1)))
+	now = now.Add(config.JwksMinRefreshInterval)
+	g.Expect(lookup("key-2")).To(gomega.Succeed())
+	g.Expect(atomic.LoadInt32(&server.requests)).To(gomega.Equal(int32(2)))
+
+	// the rotated key is accepted until the end of its grace period
+	g.Expect(lookup("key-1")).To(gomega.Succeed())
+	now = now.Add(config.JwksKeyGracePeriod)
+	g.Expect(lookup("key-1")).To(gomega.MatchError(ErrUnknownSigningKey))
+
+	// the key sets of the issuers no longer trusted are dropped
+	g.Expect(config.Issuers.Set(nil)).To(gomega.Succeed())
+	manager.Reconcile()
+	g.Expect(manager.keySets).To(gomega.BeEmpty())
+}
+
+func Test_JWKSManager_KeyRolloverWithoutGracePeriod(t *testing.T) {
+	g := gomega.NewWithT(t)
+	server := newJWKSServer(t)
+	server.rotate(t, "key-1")
+
+	config := NewTrustedIssuersConfig()
+	config.TrustedIssuersFile = ""
+	issuer := TrustedIssuer{Issuer: "https://partner.example.com", JwksURL: server.URL, AllowHTTPJwksURL: true, Claims: ClaimMappings{Username: "email"}, OrganisationId: "partner-org"}
+	g.Expect(config.Issuers.Set([]TrustedIssuer{issuer})).To(gomega.Succeed())
+
+	manager := NewJWKSManager(config)
+	keyfunc := manager.Keyfunc(issuer)
+	lookup := func(kid string) error {
+		_, err := keyfunc(&jwt.Token{Header: map[string]interface{}{"kid": kid}})
+		return err
+	}
+
+	manager.Reconcile()
+	g.Expect(lookup("key-1")).To(gomega.Succeed())
+
+	// the rotated key is rejected as soon as it's removed from the key set
+	server.rotate(t, "key-2")
+	g.Expect(manager.refresh(issuer, 0)).To(gomega.Succeed())
+	g.Expect(lookup("key-2")).To(gomega.Succeed())
+	g.Expect(lookup("key-1")).To(gomega.MatchError(ErrUnknownSigningKey))
+}
+
+func Test_JWKSManager_FailedRefreshKeepsKeys(t *testing.T) {
+	g := gomega.NewWithT(t)
+	server := newJWKSServer(t)
+	server.rotate(t, "key-1")
+
+	config := NewTrustedIssuersConfig()
+	config.TrustedIssuersFile = ""
+	issuer := TrustedIssuer{Issuer: "https://partner.example.com", JwksURL: server.URL, AllowHTTPJwksURL: true, Claims: ClaimMappings{Username: "email"}, OrganisationId: "partner-org"}
+	g.Expect(config.Issuers.Set([]TrustedIssuer{issuer})).To(gomega.Succeed())
+
+	now := time.Now()
+	manager := NewJWKSManager(config)
+	manager.now = func() time.Time { return now }
+	manager.Reconcile()
+
+	server.Close()
+	now = now.Add(config.JwksRefreshInterval)
+	manager.Reconcile()
+	_, err := manager.Keyfunc(issuer)(&jwt.Token{Header: map[string]interface{}{"kid": "key-1"}})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+}
@@ -57,3 +57,47 @@ func (c *KFMClaims) IsOrgAdmin() bool {
 	}
 	return false
 }
+
+// GetGroups returns the groups of the user, either a list of strings or a single string
+func (c *KFMClaims) GetGroups() []string {
+	switch groups := (*c)[tenantGroupsClaim].(type) {
+	case string:
+		return []string{groups}
+	case []string:
+		return groups
+	case []interface{}:
+		result := make([]string, 0, len(groups))
+		for _, g := range groups {
+			if s, ok := g.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	default:
+		return nil
+	}
+}
+
+// GetApiTokenId returns the id of the API token the claims were resolved from, empty for the JWT tokens
+func (c *KFMClaims) GetApiTokenId() string {
+	id, _ := (*c)[apiTokenIdClaim].(string)
+	return id
+}
+
+// GetApiTokenScopes returns the scopes of the API token the claims were resolved from
+func (c *KFMClaims) GetApiTokenScopes() []string {
+	switch scopes := (*c)[apiTokenScopesClaim].(type) {
+	case []string:
+		return scopes
+	case []interface{}:
+		result := make([]string, 0, len(scopes))
+		for _, s := range scopes {
+			if scope, ok := s.(string); ok {
+				result = append(result, scope)
+			}
+		}
+		return result
+	default:
+		return nil
+	}
+}
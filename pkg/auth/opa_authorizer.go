@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/open-policy-agent/opa/rego"
+)
+
+// opaAuthorizer decides with the Rego policies evaluated by an embedded Open Policy Agent engine.
+// The decision of the next authorizer is given to the policies, so that they can extend or restrict the built-in rules.
+type opaAuthorizer struct {
+	query rego.PreparedEvalQuery
+	next  Authorizer
+}
+
+var _ Authorizer = &opaAuthorizer{}
+
+// opaInput is the input document of the authorization policies
+type opaInput struct {
+	Action   string                 `json:"action"`
+	Resource AuthorizationResource  `json:"resource"`
+	User     opaUser                `json:"user"`
+	Claims   map[string]interface{} `json:"claims"`
+	Default  opaDecision            `json:"default_decision"`
+}
+
+type opaUser struct {
+	Username       string `json:"username"`
+	OrganisationId string `json:"organisation_id"`
+	IsOrgAdmin     bool   `json:"is_org_admin"`
+	ClientId       string `json:"client_id,omitempty"`
+}
+
+type opaDecision struct {
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// prepareOPAQuery compiles the policy module and prepares the query of the decisions
+func prepareOPAQuery(ctx context.Context, policyFile string, policy string, query string) (rego.PreparedEvalQuery, error) {
+	return rego.New(
+		rego.Query(query),
+		rego.Module(policyFile, policy),
+	).PrepareForEval(ctx)
+}
+
+// NewOPAAuthorizer returns an authorizer evaluating the query of the prepared policies, the decision of the next authorizer
+// is the default decision of the policies
+func NewOPAAuthorizer(query rego.PreparedEvalQuery, next Authorizer) Authorizer {
+	return &opaAuthorizer{
+		query: query,
+		next:  next,
+	}
+}
+
+func (a *opaAuthorizer) Authorize(ctx context.Context, request AuthorizationRequest) (*AuthorizationDecision, error) {
+	defaultDecision, err := a.next.Authorize(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	username, _ := request.Claims.GetUsername()
+	orgId, _ := request.Claims.GetOrgId()
+	clientId, _ := request.Claims.GetClientID()
+	input := opaInput{
+		Action:   request.Action,
+		Resource: request.Resource,
+		User: opaUser{
+			Username:       username,
+			OrganisationId: orgId,
+			IsOrgAdmin:     request.Claims.IsOrgAdmin(),
+			ClientId:       clientId,
+		},
+		Claims:  request.Claims,
+		Default: opaDecision{Allowed: defaultDecision.Allowed, Reason: defaultDecision.Reason},
+	}
+	if input.Claims == nil {
+		input.Claims = map[string]interface{}{}
+	}
+
+	results, err := a.query.Eval(ctx, rego.EvalInput(input))
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate the authorization policies: %w", err)
+	}
+
+	decision := *defaultDecision
+	decision.Engine = AuthorizationEngineOPA
+	decision.Allowed = false
+	decision.Reason = ""
+	if len(results) == 0 || len(results[0].Expressions) == 0 {
+		// an undefined decision denies the action
+		decision.Reason = "no authorization policy decision"
+	} else {
+		switch value := results[0].Expressions[0].Value.(type) {
+		case bool:
+			decision.Allowed = value
+		case map[string]interface{}:
+			decision.Allowed, _ = value["allowed"].(bool)
+			decision.Reason, _ = value["reason"].(string)
+		default:
+			return nil, fmt.Errorf("unexpected authorization policy decision %v, expected a boolean or an object with allowed and reason", value)
+		}
+	}
+	if !decision.Allowed && defaultDecision.Allowed {
+		decision.ErrorCode = errors.ErrorForbidden
+	}
+	return &decision, nil
+}
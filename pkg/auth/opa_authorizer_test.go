@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/onsi/gomega"
+)
+
+const testAuthorizationPolicy = `
+package test.authz
+
+standard_kafka_org_admin_organisations := {"admin-only-org"}
+
+decision = input.default_decision {
+	not denied_standard_kafka_creation
+}
+
+decision = {"allowed": false, "reason": "only the organisation admins can create standard kafka instances"} {
+	denied_standard_kafka_creation
+}
+
+denied_standard_kafka_creation {
+	input.action == "create"
+	input.resource.type == "kafka"
+	input.resource.attributes.instance_type == "standard"
+	standard_kafka_org_admin_organisations[input.user.organisation_id]
+	not input.user.is_org_admin
+}
+
+allowed = input.user.is_org_admin
+
+undefined {
+	false
+}
+
+invalid = "allowed"
+`
+
+func Test_opaAuthorizer_Authorize(t *testing.T) {
+	user := KFMClaims{"username": "user", "org_id": "admin-only-org"}
+	orgAdmin := KFMClaims{"username": "admin", "org_id": "admin-only-org", "is_org_admin": true}
+	standardKafka := AuthorizationResource{Type: ResourceKafka, Attributes: map[string]interface{}{"instance_type": "standard"}}
+
+	tests := []struct {
+		name          string
+		query         string
+		request       AuthorizationRequest
+		wantAllowed   bool
+		wantReason    string
+		wantErrorCode errors.ServiceErrorCode
+		wantErr       bool
+	}{
+		{
+			name:        "should return the decision of the built-in rules when no policy denies the action",
+			query:       "data.test.authz.decision",
+			request:     AuthorizationRequest{Claims: orgAdmin, Action: ActionCreate, Resource: standardKafka},
+			wantAllowed: true,
+		},
+		{
+			name:          "should deny the action denied by a policy with the reason of the policy",
+			query:         "data.test.authz.decision",
+			request:       AuthorizationRequest{Claims: user, Action: ActionCreate, Resource: standardKafka},
+			wantAllowed:   false,
+			wantReason:    "only the organisation admins can create standard kafka instances",
+			wantErrorCode: errors.ErrorForbidden,
+		},
+		{
+			name:        "should keep the denial of the built-in rules",
+			query:       "data.test.authz.decision",
+			request:     AuthorizationRequest{Claims: user, Action: ActionList, Resource: AuthorizationResource{Type: ResourceWebhookSubscription}},
+			wantAllowed: false,
+		},
+		{
+			name:        "should accept a boolean decision",
+			query:       "data.test.authz.allowed",
+			request:     AuthorizationRequest{Claims: orgAdmin, Action: ActionList, Resource: AuthorizationResource{Type: ResourceKafka}},
+			wantAllowed: true,
+		},
+		{
+			name:          "should deny the action when the decision is undefined",
+			query:         "data.test.authz.undefined",
+			request:       AuthorizationRequest{Claims: orgAdmin, Action: ActionList, Resource: AuthorizationResource{Type: ResourceKafka}},
+			wantAllowed:   false,
+			wantReason:    "no authorization policy decision",
+			wantErrorCode: errors.ErrorForbidden,
+		},
+		{
+			name:    "should return an error when the decision isn't a boolean or an object",
+			query:   "data.test.authz.invalid",
+			request: AuthorizationRequest{Claims: orgAdmin, Action: ActionList, Resource: AuthorizationResource{Type: ResourceKafka}},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			query, err := prepareOPAQuery(context.Background(), "test.rego", testAuthorizationPolicy, tt.query)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+
+			decision, err := NewOPAAuthorizer(query, NewDefaultAuthorizer()).Authorize(context.Background(), tt.request)
+			if tt.wantErr {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(decision.Engine).To(gomega.Equal(AuthorizationEngineOPA))
+			g.Expect(decision.Allowed).To(gomega.Equal(tt.wantAllowed))
+			g.Expect(decision.Reason).To(gomega.Equal(tt.wantReason))
+			if tt.wantErrorCode != 0 {
+				g.Expect(decision.ErrorCode).To(gomega.Equal(tt.wantErrorCode))
+			}
+		})
+	}
+}
@@ -12,6 +12,9 @@ type RequireIssuerMiddleware interface {
 	// RequireIssuer checks if the iss field in the JWT claim matches one of the given issuers.
 	// If it does not, then the specified code is returned.
 	RequireIssuer(issuers []string, code errors.ServiceErrorCode) func(handler http.Handler) http.Handler
+	// RequireIssuerOrTrustedIssuer checks if the iss field in the JWT claim matches one of the given issuers or one of the
+	// trusted issuers at the time of the request. If it does not, then the specified code is returned.
+	RequireIssuerOrTrustedIssuer(issuers []string, trustedIssuers *TrustedIssuers, code errors.ServiceErrorCode) func(handler http.Handler) http.Handler
 }
 
 type requireIssuerMiddleware struct {
@@ -24,6 +27,16 @@ func NewRequireIssuerMiddleware() RequireIssuerMiddleware {
 }
 
 func (m *requireIssuerMiddleware) RequireIssuer(issuers []string, code errors.ServiceErrorCode) func(handler http.Handler) http.Handler {
+	return m.requireIssuer(func() []string { return issuers }, code)
+}
+
+func (m *requireIssuerMiddleware) RequireIssuerOrTrustedIssuer(issuers []string, trustedIssuers *TrustedIssuers, code errors.ServiceErrorCode) func(handler http.Handler) http.Handler {
+	return m.requireIssuer(func() []string {
+		return append(append([]string{}, issuers...), arrays.Map(trustedIssuers.List(), func(issuer TrustedIssuer) string { return issuer.Issuer })...)
+	}, code)
+}
+
+func (m *requireIssuerMiddleware) requireIssuer(issuers func() []string, code errors.ServiceErrorCode) func(handler http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
 			ctx := request.Context()
@@ -34,7 +47,7 @@ func (m *requireIssuerMiddleware) RequireIssuer(issuers []string, code errors.Se
 				return
 			}
 
-			issuerAccepted := arrays.AnyMatch(issuers, func(issuer string) bool { return claims.VerifyIssuer(issuer, true) })
+			issuerAccepted := arrays.AnyMatch(issuers(), func(issuer string) bool { return claims.VerifyIssuer(issuer, true) })
 
 			if !issuerAccepted {
 				shared.HandleError(request, writer, serviceErr)
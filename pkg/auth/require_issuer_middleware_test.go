@@ -105,3 +105,28 @@ func TestRequireIssuerMiddleware(t *testing.T) {
 		})
 	}
 }
+
+func TestRequireIssuerOrTrustedIssuerMiddleware(t *testing.T) {
+	g := gomega.NewWithT(t)
+	trustedIssuers, err := NewTrustedIssuers()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	next := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		shared.WriteJSONResponse(writer, http.StatusOK, "")
+	})
+	requireIssuer := NewRequireIssuerMiddleware().RequireIssuerOrTrustedIssuer([]string{"desiredIssuer"}, trustedIssuers, errors.ErrorUnauthenticated)
+	serve := func(issuer string) int {
+		toTest := setContextToken(requireIssuer(next), &jwt.Token{Claims: jwt.MapClaims{"iss": issuer}})
+		recorder := httptest.NewRecorder()
+		toTest.ServeHTTP(recorder, httptest.NewRequest("GET", "http://example.com", nil))
+		return recorder.Result().StatusCode
+	}
+
+	g.Expect(serve("desiredIssuer")).To(gomega.Equal(http.StatusOK))
+	g.Expect(serve("partnerIssuer")).To(gomega.Equal(http.StatusUnauthorized))
+
+	// the trusted issuers are checked at the time of the request
+	err = trustedIssuers.Set([]TrustedIssuer{{Issuer: "partnerIssuer", JwksURL: "https://partner.example.com/certs", Claims: ClaimMappings{Username: "email"}, OrganisationId: "partner-org"}})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(serve("partnerIssuer")).To(gomega.Equal(http.StatusOK))
+	g.Expect(serve("anotherIssuer")).To(gomega.Equal(http.StatusUnauthorized))
+}
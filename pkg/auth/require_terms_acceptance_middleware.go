@@ -2,12 +2,9 @@ package auth
 
 import (
 	"net/http"
-	"time"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/ocm"
 
-	"github.com/patrickmn/go-cache"
-
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
 )
@@ -15,20 +12,16 @@ import (
 type RequireTermsAcceptanceMiddleware interface {
 	// RequireTermsAcceptance will check that the user has accepted the required terms.
 	// The current implementation is backed by OCM and can be disabled with the "enabled" flag set to false.
+	// The terms acceptance of the users is cached by the AMS client.
 	RequireTermsAcceptance(enabled bool, amsClient ocm.AMSClient, code errors.ServiceErrorCode) func(handler http.Handler) http.Handler
 }
 
-type requireTermsAcceptanceMiddleware struct {
-	cache *cache.Cache
-}
+type requireTermsAcceptanceMiddleware struct{}
 
 var _ RequireTermsAcceptanceMiddleware = &requireTermsAcceptanceMiddleware{}
 
 func NewRequireTermsAcceptanceMiddleware() RequireTermsAcceptanceMiddleware {
-	return &requireTermsAcceptanceMiddleware{
-		// entries will expire in 5 minutes and will be evicted every 10 minutes
-		cache: cache.New(5*time.Minute, 10*time.Minute),
-	}
+	return &requireTermsAcceptanceMiddleware{}
 }
 
 func (m *requireTermsAcceptanceMiddleware) RequireTermsAcceptance(enabled bool, amsClient ocm.AMSClient, code errors.ServiceErrorCode) func(handler http.Handler) http.Handler {
@@ -42,18 +35,13 @@ func (m *requireTermsAcceptanceMiddleware) RequireTermsAcceptance(enabled bool,
 					return
 				}
 				username, _ := claims.GetUsername()
-				termsRequired, cached := m.cache.Get(username)
-				if !cached {
-					termsRequired, _, err = amsClient.GetRequiresTermsAcceptance(username)
-					if err != nil {
-						shared.HandleError(request, writer, errors.NewWithCause(code, err, ""))
-						return
-					}
-
-					m.cache.Set(username, termsRequired, cache.DefaultExpiration)
+				termsRequired, _, err := amsClient.GetRequiresTermsAcceptance(username)
+				if err != nil {
+					shared.HandleError(request, writer, errors.NewWithCause(code, err, ""))
+					return
 				}
 
-				if termsRequired.(bool) {
+				if termsRequired {
 					shared.HandleError(request, writer, errors.New(code, "required terms have not been accepted"))
 					return
 				}
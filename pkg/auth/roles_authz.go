@@ -26,14 +26,17 @@ type RoleConfig []RolesConfiguration
 
 // AdminRoleAuthZConfig is the configuration of the role authZ middleware.
 type AdminRoleAuthZConfig struct {
-	RolesConfigFile string
-	RolesConfig     RoleConfig
+	RolesConfigFile    string
+	RolesConfig        RoleConfig
+	PoliciesConfigFile string
+	Policies           []AdminPolicy
 }
 
 // NewAdminAuthZConfig creates a default AdminRoleAuthZConfig which is enabled and uses the production configuration.
 func NewAdminAuthZConfig() *AdminRoleAuthZConfig {
 	return &AdminRoleAuthZConfig{
-		RolesConfigFile: "config/admin-authz-configuration.yaml",
+		RolesConfigFile:    "config/admin-authz-configuration.yaml",
+		PoliciesConfigFile: "config/admin-authz-policies.yaml",
 	}
 }
 
@@ -41,11 +44,16 @@ func NewAdminAuthZConfig() *AdminRoleAuthZConfig {
 func (c *AdminRoleAuthZConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&c.RolesConfigFile, "admin-authz-config-file", c.RolesConfigFile,
 		"Admin API authZ configuration file containing list of required role per API method")
+	fs.StringVar(&c.PoliciesConfigFile, "admin-authz-policies-file", c.PoliciesConfigFile,
+		"Admin API authZ policies file restricting roles to routes, methods, request fields and resources of the admin API")
 }
 
 // ReadFiles will read and validate the contents of the configuration file.
 func (c *AdminRoleAuthZConfig) ReadFiles() error {
-	return readRoleAuthZConfigFile(c.RolesConfigFile, &c.RolesConfig)
+	if err := readRoleAuthZConfigFile(c.RolesConfigFile, &c.RolesConfig); err != nil {
+		return err
+	}
+	return readAdminPoliciesFile(c.PoliciesConfigFile, &c.Policies)
 }
 
 // GetRoleMapping will create a map of the required roles. The key will be the HTTP method and value will be a list of
@@ -60,6 +68,17 @@ func (c *AdminRoleAuthZConfig) GetRoleMapping() map[string][]string {
 	return roleMapping
 }
 
+// GetPolicies returns the rules of the admin policies by role
+func (c *AdminRoleAuthZConfig) GetPolicies() map[string][]AdminPolicyRule {
+	policies := make(map[string][]AdminPolicyRule, len(c.Policies))
+
+	for _, policy := range c.Policies {
+		policies[policy.Role] = policy.Rules
+	}
+
+	return policies
+}
+
 func readRoleAuthZConfigFile(file string, val *RoleConfig) error {
 	fileContents, err := shared.ReadFile(file)
 	if err != nil {
@@ -74,7 +93,10 @@ func readRoleAuthZConfigFile(file string, val *RoleConfig) error {
 }
 
 func (c *AdminRoleAuthZConfig) Validate(env *environments.Env) error {
-	return validateRolesConfiguration(c.RolesConfig)
+	if err := validateRolesConfiguration(c.RolesConfig); err != nil {
+		return err
+	}
+	return validateAdminPolicies(c.Policies)
 }
 
 var allowedHTTPMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
@@ -1,10 +1,13 @@
 package auth
 
 import (
-	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+	"bytes"
+	"io"
 	"net/http"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
 	"github.com/golang/glog"
+	"github.com/gorilla/mux"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
@@ -14,19 +17,34 @@ import (
 type RolesAuthorizationMiddleware interface {
 	// RequireRealmRole will check the given realm role exists in the request token
 	RequireRealmRole(roleName string, code errors.ServiceErrorCode) func(handler http.Handler) http.Handler
-	// RequireRolesForMethods will check that at least one of the realm roles exists in the request token based on the http method in the request
+	// RequireRolesForMethods will check that at least one of the realm roles exists in the request token based on the http method in the request,
+	// roles with an admin policy are instead checked against the route, the method and the body fields of the request
 	RequireRolesForMethods(code errors.ServiceErrorCode) func(handler http.Handler) http.Handler
+	// CheckAdminResources registers the routes whose handlers check the resource selectors of the admin policies with CheckAdminResource,
+	// the policy rules with resource selectors don't grant access to the other routes
+	CheckAdminResources(routes ...*mux.Route)
 }
 
 type rolesAuthMiddleware struct {
 	roleMapping map[string][]string
+	policies    map[string][]AdminPolicyRule
+	// resourceRoutes are the routes whose handlers check the resource selectors, they are registered before the server starts
+	resourceRoutes map[*mux.Route]bool
 }
 
 var _ RolesAuthorizationMiddleware = &rolesAuthMiddleware{}
 
 func NewRolesAuthzMiddleware(config *AdminRoleAuthZConfig) RolesAuthorizationMiddleware {
 	return &rolesAuthMiddleware{
-		roleMapping: config.GetRoleMapping(),
+		roleMapping:    config.GetRoleMapping(),
+		policies:       config.GetPolicies(),
+		resourceRoutes: map[*mux.Route]bool{},
+	}
+}
+
+func (m *rolesAuthMiddleware) CheckAdminResources(routes ...*mux.Route) {
+	for _, route := range routes {
+		m.resourceRoutes[route] = true
 	}
 }
 
@@ -56,8 +74,7 @@ func (m *rolesAuthMiddleware) RequireRolesForMethods(code errors.ServiceErrorCod
 		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
 			serviceErr := errors.New(code, "")
 			method := request.Method
-			allowedRoles, ok := m.roleMapping[method]
-			if !ok {
+			if _, ok := m.roleMapping[method]; !ok && len(m.policies) == 0 {
 				// no allowed roles defined for the given method, deny the request by default to be safer
 				glog.Infof("no allowed roles defined for method %s, deny the request for url %s", method, request.URL)
 				shared.HandleError(request, writer, serviceErr)
@@ -70,24 +87,49 @@ func (m *rolesAuthMiddleware) RequireRolesForMethods(code errors.ServiceErrorCod
 				shared.HandleError(request, writer, serviceErr)
 				return
 			}
-			realmRoles := getRealmRolesClaim(claims)
-			// if the request claim has any realm role that is defined in the `roles` map, the request will be allowed
-			for _, r := range allowedRoles {
-				if hasRole(realmRoles, r) {
-					ctx = SetIsAdminContext(ctx, true)
-					request = request.WithContext(ctx)
-					next.ServeHTTP(writer, request)
+
+			policyRequest := AdminPolicyRequest{
+				Roles:  getRealmRolesClaim(claims),
+				Method: method,
+				Route:  request.URL.Path,
+			}
+			if route := mux.CurrentRoute(request); route != nil {
+				if template, err := route.GetPathTemplate(); err == nil {
+					policyRequest.Route = template
+				}
+				policyRequest.ChecksResources = m.resourceRoutes[route]
+			}
+			// the fields of the request body are only needed by the policies restricting the fields that can be sent
+			if len(m.policies) != 0 && request.Body != nil && method != http.MethodGet && method != http.MethodDelete {
+				body, err := io.ReadAll(request.Body)
+				if err != nil {
+					shared.HandleError(request, writer, errors.New(errors.ErrorBadRequest, "unable to read request body"))
 					return
 				}
+				request.Body = io.NopCloser(bytes.NewReader(body))
+				policyRequest.Fields = bodyFields(body)
 			}
-			// no matching roles found, deny the request
-			shared.HandleError(request, writer, serviceErr)
+
+			// the request is allowed if any realm role of the request claim is mapped to the method,
+			// or has a policy with a rule matching the request
+			grant := EvaluateAdminPolicies(m.roleMapping, m.policies, policyRequest)
+			if grant == nil {
+				// no matching roles found, deny the request
+				shared.HandleError(request, writer, serviceErr)
+				return
+			}
+			ctx = SetIsAdminContext(ctx, true)
+			ctx = SetAdminGrantContext(ctx, grant)
+			request = request.WithContext(ctx)
+			next.ServeHTTP(writer, request)
 		})
 	}
 }
 
+const realmAccessClaim = "realm_access"
+
 func getRealmRolesClaim(claims KFMClaims) []string {
-	if realmRoles, ok := claims["realm_access"]; ok {
+	if realmRoles, ok := claims[realmAccessClaim]; ok {
 		if roles, ok := realmRoles.(map[string]interface{}); ok {
 			if arr, ok := roles["roles"].([]interface{}); ok {
 				return arrays.Map(arr, func(v any) string { return v.(string) })
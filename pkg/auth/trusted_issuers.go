@@ -0,0 +1,178 @@
+package auth
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// TrustedIssuer is an issuer of tokens trusted in addition to the primary token issuer, e.g. an identity provider federated
+// for the partner access
+type TrustedIssuer struct {
+	// Issuer is the iss claim of the tokens of the issuer
+	Issuer string `yaml:"issuer"`
+	// JwksURL is the JSON web key set of the signing keys of the issuer, it must be an https URL
+	JwksURL string `yaml:"jwks_url"`
+	// AllowHTTPJwksURL accepts an http JwksURL, e.g. a local identity provider in development. It must not be set in the
+	// deployed environments, the keys fetched over http can be replaced by anyone on the network path.
+	AllowHTTPJwksURL bool `yaml:"allow_http_jwks_url"`
+	// RefreshInterval is how often the JSON web key set is refreshed, zero uses the default refresh interval
+	RefreshInterval time.Duration `yaml:"refresh_interval"`
+	// Audiences are the accepted aud claims of the tokens, no audiences doesn't check the aud claim
+	Audiences []string `yaml:"audiences"`
+	// Claims are the names of the claims of the tokens of the issuer
+	Claims ClaimMappings `yaml:"claims"`
+	// OrganisationId is the organisation of all the users of the issuer, it replaces the organisation claimed by the tokens
+	OrganisationId string `yaml:"organisation_id"`
+	// AllowedOrganisations are the organisations the tokens of the issuer can claim, the tokens of the other organisations are rejected
+	AllowedOrganisations []string `yaml:"allowed_organisations"`
+}
+
+// trustedIssuerReservedClaims are the claims set by the fleet manager or its primary issuer only, a trusted issuer must
+// not be able to grant the API token scopes or the realm roles of the admin and agent APIs
+var trustedIssuerReservedClaims = []string{apiTokenIdClaim, apiTokenScopesClaim, realmAccessClaim}
+
+// ClaimMappings are the names of the claims of the tokens of an issuer. The tenant claims without a name aren't read from
+// the tokens of the issuer, so that it can't give them under the names of the tenant claims of the ContextConfig.
+type ClaimMappings struct {
+	Username  string `yaml:"username"`
+	OrgId     string `yaml:"org_id"`
+	AccountId string `yaml:"account_id"`
+	OrgAdmin  string `yaml:"org_admin"`
+	Groups    string `yaml:"groups"`
+	ClientId  string `yaml:"client_id"`
+}
+
+func (i *TrustedIssuer) validate() error {
+	if i.Issuer == "" {
+		return fmt.Errorf("issuer is required")
+	}
+	jwksURL, err := url.Parse(i.JwksURL)
+	if err != nil || jwksURL.Host == "" || (jwksURL.Scheme != "https" && (jwksURL.Scheme != "http" || !i.AllowHTTPJwksURL)) {
+		return fmt.Errorf("jwks_url of issuer %q must be an https URL, got %q", i.Issuer, i.JwksURL)
+	}
+	if i.RefreshInterval < 0 {
+		return fmt.Errorf("refresh_interval of issuer %q must not be negative, got %s", i.Issuer, i.RefreshInterval)
+	}
+	if i.Claims.Username == "" {
+		return fmt.Errorf("claims.username of issuer %q is required", i.Issuer)
+	}
+	// an issuer must not be able to give its users the organisation of any tenant
+	if (i.OrganisationId == "") == (len(i.AllowedOrganisations) == 0) {
+		return fmt.Errorf("issuer %q must have either an organisation_id or allowed_organisations", i.Issuer)
+	}
+	if len(i.AllowedOrganisations) > 0 && i.Claims.OrgId == "" {
+		return fmt.Errorf("claims.org_id of issuer %q is required with allowed_organisations", i.Issuer)
+	}
+	return nil
+}
+
+// VerifyAudience checks that the aud claim contains one of the audiences of the issuer
+func (i *TrustedIssuer) VerifyAudience(claims jwt.MapClaims) bool {
+	if len(i.Audiences) == 0 {
+		return true
+	}
+	for _, audience := range i.Audiences {
+		if claims.VerifyAudience(audience, true) {
+			return true
+		}
+	}
+	return false
+}
+
+// MapClaims copies the claims of the issuer to the tenant claims read by KFMClaims. The tenant claims given by the token under
+// their default names are removed, so that only the mapped claims are read, and so are the reserved claims. The organisation is the one of the issuer, or the
+// mapped organisation when it's one of the allowed organisations of the issuer, an error is returned otherwise.
+func (i *TrustedIssuer) MapClaims(claims jwt.MapClaims) error {
+	mappings := []struct {
+		from string
+		to   []string
+	}{
+		{from: i.Claims.Username, to: []string{tenantUsernameClaim, alternateTenantUsernameClaim}},
+		{from: i.Claims.OrgId, to: []string{tenantIdClaim, alternateTenantIdClaim}},
+		{from: i.Claims.AccountId, to: []string{tenantUserIdClaim}},
+		{from: i.Claims.OrgAdmin, to: []string{tenantOrgAdminClaim}},
+		{from: i.Claims.Groups, to: []string{tenantGroupsClaim}},
+		{from: i.Claims.ClientId, to: []string{clientIDclaim}},
+	}
+	for _, claim := range trustedIssuerReservedClaims {
+		delete(claims, claim)
+	}
+	for _, mapping := range mappings {
+		value, ok := claims[mapping.from]
+		for _, to := range mapping.to {
+			delete(claims, to)
+		}
+		if mapping.from != "" && ok {
+			claims[mapping.to[0]] = value
+		}
+	}
+
+	if i.OrganisationId != "" {
+		claims[tenantIdClaim] = i.OrganisationId
+		return nil
+	}
+	orgId, _ := claims[tenantIdClaim].(string)
+	if !arrays.Contains(i.AllowedOrganisations, orgId) {
+		return fmt.Errorf("organisation %q isn't allowed for issuer %q", orgId, i.Issuer)
+	}
+	return nil
+}
+
+// TrustedIssuers is the set of the trusted issuers, it's updated at runtime when the trusted issuers file changes
+type TrustedIssuers struct {
+	mutex   sync.RWMutex
+	issuers map[string]TrustedIssuer
+}
+
+func NewTrustedIssuers(issuers ...TrustedIssuer) (*TrustedIssuers, error) {
+	t := &TrustedIssuers{}
+	if err := t.Set(issuers); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Set validates and replaces the trusted issuers
+func (t *TrustedIssuers) Set(issuers []TrustedIssuer) error {
+	byIssuer := make(map[string]TrustedIssuer, len(issuers))
+	for _, issuer := range issuers {
+		if err := issuer.validate(); err != nil {
+			return err
+		}
+		if _, ok := byIssuer[issuer.Issuer]; ok {
+			return fmt.Errorf("issuer %q is duplicated", issuer.Issuer)
+		}
+		byIssuer[issuer.Issuer] = issuer
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.issuers = byIssuer
+	return nil
+}
+
+// Get returns the trusted issuer of the iss claim
+func (t *TrustedIssuers) Get(issuer string) (TrustedIssuer, bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	trustedIssuer, ok := t.issuers[issuer]
+	return trustedIssuer, ok
+}
+
+// List returns the trusted issuers sorted by issuer
+func (t *TrustedIssuers) List() []TrustedIssuer {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	issuers := make([]TrustedIssuer, 0, len(t.issuers))
+	for _, issuer := range t.issuers {
+		issuers = append(issuers, issuer)
+	}
+	sort.Slice(issuers, func(i, j int) bool { return issuers[i].Issuer < issuers[j].Issuer })
+	return issuers
+}
@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/metrics"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/golang/glog"
+)
+
+var trustedIssuerSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// NewTrustedIssuersAuthenticationHandler authenticates the requests with a bearer token of a trusted issuer: the token is
+// verified with the JSON web key set of its issuer, its audience is checked and its claims are mapped to the tenant claims
+// before calling next. The requests without a valid token of a trusted issuer are passed to the fallback authentication
+// handler, which authenticates the tokens of the primary issuer and rejects the invalid tokens.
+func NewTrustedIssuersAuthenticationHandler(config *TrustedIssuersConfig, jwksManager *JWKSManager, next http.Handler, fallback http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		bearer := strings.TrimSpace(request.Header.Get("Authorization"))
+		if len(bearer) < len("Bearer ") || !strings.EqualFold(bearer[:len("Bearer ")], "Bearer ") {
+			fallback.ServeHTTP(writer, request)
+			return
+		}
+		tokenString := strings.TrimSpace(bearer[len("Bearer "):])
+
+		unverified, _, err := jwt.NewParser().ParseUnverified(tokenString, jwt.MapClaims{})
+		if err != nil {
+			fallback.ServeHTTP(writer, request)
+			return
+		}
+		issuerClaim, _ := unverified.Claims.(jwt.MapClaims)["iss"].(string)
+		issuer, ok := config.Issuers.Get(issuerClaim)
+		if !ok {
+			fallback.ServeHTTP(writer, request)
+			return
+		}
+
+		token, result := validateTrustedIssuerToken(tokenString, issuer, jwksManager)
+		metrics.IncreaseTokenValidationCount(issuer.Issuer, result)
+		if result != TokenValid {
+			// the fallback rejects the token as it isn't signed by the keys of the primary issuer
+			fallback.ServeHTTP(writer, request)
+			return
+		}
+
+		next.ServeHTTP(writer, request.WithContext(SetTokenInContext(request.Context(), token)))
+	})
+}
+
+func validateTrustedIssuerToken(tokenString string, issuer TrustedIssuer, jwksManager *JWKSManager) (*jwt.Token, string) {
+	token, err := jwt.NewParser(jwt.WithValidMethods(trustedIssuerSigningMethods)).
+		ParseWithClaims(tokenString, jwt.MapClaims{}, jwksManager.Keyfunc(issuer))
+	if err != nil {
+		glog.V(5).Infof("invalid token of trusted issuer %q: %v", issuer.Issuer, err)
+		if errors.Is(err, ErrUnknownSigningKey) {
+			return nil, TokenUnknownKey
+		}
+		return nil, TokenInvalid
+	}
+
+	claims := token.Claims.(jwt.MapClaims)
+	if _, ok := claims["exp"]; !ok {
+		glog.V(5).Infof("invalid token of trusted issuer %q: missing exp claim", issuer.Issuer)
+		return nil, TokenInvalid
+	}
+	if !issuer.VerifyAudience(claims) {
+		glog.V(5).Infof("invalid token of trusted issuer %q: audience %v not accepted", issuer.Issuer, claims["aud"])
+		return nil, TokenInvalidAudience
+	}
+	if err := issuer.MapClaims(claims); err != nil {
+		glog.V(5).Infof("invalid token of trusted issuer %q: %v", issuer.Issuer, err)
+		return nil, TokenOrganisationNotAllowed
+	}
+	return token, TokenValid
+}
@@ -0,0 +1,154 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/onsi/gomega"
+)
+
+func Test_TrustedIssuersAuthenticationHandler(t *testing.T) {
+	server := newJWKSServer(t)
+	key := server.rotate(t, "partner-key")
+	otherServer := newJWKSServer(t)
+	otherKey := otherServer.rotate(t, "other-key")
+
+	const partnerIssuer = "https://sso.partner.example.com/realms/partners"
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":       partnerIssuer,
+			"aud":       "kas-fleet-manager",
+			"exp":       time.Now().Add(time.Hour).Unix(),
+			"email":     "user@partner.example.com",
+			"tenant_id": "partner-org",
+		}
+	}
+	withClaims := func(update func(claims jwt.MapClaims)) jwt.MapClaims {
+		claims := validClaims()
+		update(claims)
+		return claims
+	}
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantFallback  bool
+		wantUsername  string
+		wantOrgId     string
+	}{
+		{
+			name:          "should authenticate a valid token of a trusted issuer and map its claims",
+			authorization: "Bearer " + signToken(t, key, "partner-key", validClaims()),
+			wantUsername:  "user@partner.example.com",
+			wantOrgId:     "partner-org",
+		},
+		{
+			name:         "should pass the requests without token to the fallback",
+			wantFallback: true,
+		},
+		{
+			name: "should pass the tokens of the other issuers to the fallback",
+			authorization: "Bearer " + signToken(t, otherKey, "other-key", withClaims(func(claims jwt.MapClaims) {
+				claims["iss"] = "https://sso.redhat.com/auth/realms/redhat-external"
+			})),
+			wantFallback: true,
+		},
+		{
+			name:          "should pass the tokens signed by an unknown key to the fallback",
+			authorization: "Bearer " + signToken(t, otherKey, "other-key", validClaims()),
+			wantFallback:  true,
+		},
+		{
+			name:          "should pass the tokens signed by another key with a known kid to the fallback",
+			authorization: "Bearer " + signToken(t, otherKey, "partner-key", validClaims()),
+			wantFallback:  true,
+		},
+		{
+			name: "should pass the tokens of another audience to the fallback",
+			authorization: "Bearer " + signToken(t, key, "partner-key", withClaims(func(claims jwt.MapClaims) {
+				claims["aud"] = "account"
+			})),
+			wantFallback: true,
+		},
+		{
+			name: "should pass the expired tokens to the fallback",
+			authorization: "Bearer " + signToken(t, key, "partner-key", withClaims(func(claims jwt.MapClaims) {
+				claims["exp"] = time.Now().Add(-time.Minute).Unix()
+			})),
+			wantFallback: true,
+		},
+		{
+			name: "should pass the tokens of an organisation not allowed for the issuer to the fallback",
+			authorization: "Bearer " + signToken(t, key, "partner-key", withClaims(func(claims jwt.MapClaims) {
+				claims["tenant_id"] = "13640203"
+			})),
+			wantFallback: true,
+		},
+		{
+			name: "should pass the tokens claiming an organisation under the default claim name to the fallback",
+			authorization: "Bearer " + signToken(t, key, "partner-key", withClaims(func(claims jwt.MapClaims) {
+				delete(claims, "tenant_id")
+				claims["org_id"] = "partner-org"
+			})),
+			wantFallback: true,
+		},
+		{
+			name: "should pass the tokens without expiry to the fallback",
+			authorization: "Bearer " + signToken(t, key, "partner-key", withClaims(func(claims jwt.MapClaims) {
+				delete(claims, "exp")
+			})),
+			wantFallback: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			config := NewTrustedIssuersConfig()
+			config.TrustedIssuersFile = ""
+			g.Expect(config.Issuers.Set([]TrustedIssuer{{
+				Issuer:               partnerIssuer,
+				JwksURL:              server.URL,
+				AllowHTTPJwksURL:     true,
+				Audiences:            []string{"kas-fleet-manager"},
+				Claims:               ClaimMappings{Username: "email", OrgId: "tenant_id"},
+				AllowedOrganisations: []string{"partner-org"},
+			}})).To(gomega.Succeed())
+
+			var claims KFMClaims
+			next := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				var err error
+				claims, err = GetClaimsFromContext(request.Context())
+				g.Expect(err).ToNot(gomega.HaveOccurred())
+				writer.WriteHeader(http.StatusOK)
+			})
+			fallback := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				writer.WriteHeader(http.StatusUnauthorized)
+			})
+
+			handler := NewTrustedIssuersAuthenticationHandler(config, NewJWKSManager(config), next, fallback)
+			request := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+			if tt.authorization != "" {
+				request.Header.Set("Authorization", tt.authorization)
+			}
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+
+			if tt.wantFallback {
+				g.Expect(recorder.Code).To(gomega.Equal(http.StatusUnauthorized))
+				return
+			}
+			g.Expect(recorder.Code).To(gomega.Equal(http.StatusOK))
+			username, err := claims.GetUsername()
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(username).To(gomega.Equal(tt.wantUsername))
+			orgId, err := claims.GetOrgId()
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(orgId).To(gomega.Equal(tt.wantOrgId))
+		})
+	}
+}
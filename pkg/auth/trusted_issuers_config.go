@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+	pkgErr "github.com/pkg/errors"
+	"github.com/spf13/pflag"
+)
+
+var _ environments.ConfigModule = (*TrustedIssuersConfig)(nil)
+
+// TrustedIssuersConfig is the configuration of the issuers of tokens trusted in addition to the primary token issuer
+type TrustedIssuersConfig struct {
+	// TrustedIssuersFile lists the trusted issuers, an empty file name disables the trusted issuers
+	TrustedIssuersFile string
+	// ReloadInterval is how often the trusted issuers file is reloaded and the due JSON web key sets refreshed
+	ReloadInterval time.Duration
+	// JwksRefreshInterval is how often the JSON web key sets of the issuers without refresh interval are refreshed
+	JwksRefreshInterval time.Duration
+	// JwksMinRefreshInterval is the minimum duration between two refreshes of a JSON web key set triggered by tokens
+	// signed by an unknown key
+	JwksMinRefreshInterval time.Duration
+	// JwksKeyGracePeriod is how long the keys removed from a JSON web key set are still accepted after their rotation, zero
+	// rejects them as soon as they're removed
+	JwksKeyGracePeriod time.Duration
+
+	Issuers *TrustedIssuers
+}
+
+func NewTrustedIssuersConfig() *TrustedIssuersConfig {
+	return &TrustedIssuersConfig{
+		TrustedIssuersFile:     "config/trusted-issuers.yaml",
+		ReloadInterval:         1 * time.Minute,
+		JwksRefreshInterval:    1 * time.Hour,
+		JwksMinRefreshInterval: 1 * time.Minute,
+		Issuers:                &TrustedIssuers{},
+	}
+}
+
+func (c *TrustedIssuersConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&c.TrustedIssuersFile, "trusted-issuers-file", c.TrustedIssuersFile, "File listing the token issuers trusted in addition to the primary token issuer, with their JWKS URL, audiences and claim names")
+	fs.DurationVar(&c.ReloadInterval, "trusted-issuers-reload-interval", c.ReloadInterval, "Interval of the reloads of the trusted issuers file")
+	fs.DurationVar(&c.JwksRefreshInterval, "jwks-refresh-interval", c.JwksRefreshInterval, "Default interval of the refreshes of the JSON web key sets of the trusted issuers")
+	fs.DurationVar(&c.JwksMinRefreshInterval, "jwks-min-refresh-interval", c.JwksMinRefreshInterval, "Minimum interval between two refreshes of a JSON web key set of a trusted issuer triggered by tokens signed by an unknown key")
+	fs.DurationVar(&c.JwksKeyGracePeriod, "jwks-key-grace-period", c.JwksKeyGracePeriod, "Duration the keys removed from the JSON web key set of a trusted issuer are still accepted, 0 rejects them once removed")
+}
+
+func (c *TrustedIssuersConfig) ReadFiles() error {
+	for flag, interval := range map[string]time.Duration{
+		"trusted-issuers-reload-interval": c.ReloadInterval,
+		"jwks-refresh-interval":           c.JwksRefreshInterval,
+		"jwks-min-refresh-interval":       c.JwksMinRefreshInterval,
+	} {
+		if interval <= 0 {
+			return fmt.Errorf("%s must be positive, got %s", flag, interval)
+		}
+	}
+	if c.JwksKeyGracePeriod < 0 {
+		return fmt.Errorf("jwks-key-grace-period must not be negative, got %s", c.JwksKeyGracePeriod)
+	}
+	_, err := c.Reload()
+	return err
+}
+
+// Reload reads the trusted issuers file again and returns whether the trusted issuers changed. The trusted issuers are
+// left unchanged when the file is invalid.
+func (c *TrustedIssuersConfig) Reload() (bool, error) {
+	if c.TrustedIssuersFile == "" {
+		return false, nil
+	}
+
+	var file struct {
+		Issuers []TrustedIssuer `yaml:"issuers"`
+	}
+	if err := shared.ReadYamlFile(c.TrustedIssuersFile, &file); err != nil {
+		return false, pkgErr.Wrap(err, "reading trusted issuers file")
+	}
+	current := c.Issuers.List()
+	if len(current) == 0 && len(file.Issuers) == 0 {
+		return false, nil
+	}
+	if err := c.Issuers.Set(file.Issuers); err != nil {
+		return false, pkgErr.Wrap(err, "invalid trusted issuers file")
+	}
+	return !reflect.DeepEqual(current, c.Issuers.List()), nil
+}
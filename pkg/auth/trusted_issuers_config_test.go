@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func Test_TrustedIssuersConfig_ReadFiles(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	config := NewTrustedIssuersConfig()
+	g.Expect(config.ReadFiles()).To(gomega.Succeed())
+	g.Expect(config.Issuers.List()).To(gomega.BeEmpty())
+
+	config = NewTrustedIssuersConfig()
+	config.JwksRefreshInterval = 0
+	g.Expect(config.ReadFiles()).ToNot(gomega.Succeed())
+
+	config = NewTrustedIssuersConfig()
+	config.JwksKeyGracePeriod = -time.Minute
+	g.Expect(config.ReadFiles()).ToNot(gomega.Succeed())
+}
+
+func Test_TrustedIssuersConfig_Reload(t *testing.T) {
+	g := gomega.NewWithT(t)
+	file := filepath.Join(t.TempDir(), "trusted-issuers.yaml")
+	write := func(content string) {
+		g.Expect(os.WriteFile(file, []byte(content), 0600)).To(gomega.Succeed())
+	}
+
+	write(`
+issuers:
+  - issuer: https://partner.example.com
+    jwks_url: https://partner.example.com/certs
+    refresh_interval: 30m
+    audiences: [kas-fleet-manager]
+    claims:
+      username: email
+      org_id: tenant_id
+    allowed_organisations: [partner-org]
+`)
+	config := NewTrustedIssuersConfig()
+	config.TrustedIssuersFile = file
+	g.Expect(config.ReadFiles()).To(gomega.Succeed())
+	issuer, ok := config.Issuers.Get("https://partner.example.com")
+	g.Expect(ok).To(gomega.BeTrue())
+	g.Expect(issuer).To(gomega.Equal(TrustedIssuer{
+		Issuer:               "https://partner.example.com",
+		JwksURL:              "https://partner.example.com/certs",
+		RefreshInterval:      30 * time.Minute,
+		Audiences:            []string{"kas-fleet-manager"},
+		Claims:               ClaimMappings{Username: "email", OrgId: "tenant_id"},
+		AllowedOrganisations: []string{"partner-org"},
+	}))
+
+	changed, err := config.Reload()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(changed).To(gomega.BeFalse())
+
+	// an invalid file keeps the current issuers
+	write(`
+issuers:
+  - issuer: https://other.example.com
+`)
+	_, err = config.Reload()
+	g.Expect(err).To(gomega.HaveOccurred())
+	_, ok = config.Issuers.Get("https://partner.example.com")
+	g.Expect(ok).To(gomega.BeTrue())
+
+	write(`
+issuers:
+  - issuer: https://other.example.com
+    jwks_url: https://other.example.com/certs
+    claims:
+      username: email
+    organisation_id: other-org
+`)
+	changed, err = config.Reload()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(changed).To(gomega.BeTrue())
+	_, ok = config.Issuers.Get("https://partner.example.com")
+	g.Expect(ok).To(gomega.BeFalse())
+	_, ok = config.Issuers.Get("https://other.example.com")
+	g.Expect(ok).To(gomega.BeTrue())
+}
@@ -0,0 +1,238 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/onsi/gomega"
+)
+
+func Test_TrustedIssuer_MapClaims(t *testing.T) {
+	tests := []struct {
+		name                 string
+		claims               ClaimMappings
+		organisationId       string
+		allowedOrganisations []string
+		token                jwt.MapClaims
+		wantClaims           jwt.MapClaims
+		wantErr              bool
+	}{
+		{
+			name:           "should remove the tenant claims without mappings and set the organisation of the issuer",
+			claims:         ClaimMappings{Username: "email"},
+			organisationId: "partner-org",
+			token:          jwt.MapClaims{"email": "user@partner.example.com", "username": "spoofed", "org_id": "13640203", "is_org_admin": true},
+			wantClaims:     jwt.MapClaims{"email": "user@partner.example.com", "username": "user@partner.example.com", "org_id": "partner-org"},
+		},
+		{
+			name:           "should remove the reserved claims",
+			claims:         ClaimMappings{Username: "email"},
+			organisationId: "partner-org",
+			token: jwt.MapClaims{
+				"email": "user@partner.example.com", "api_token_id": "spoofed", "api_token_scopes": []interface{}{"kafkas:write"},
+				"realm_access": map[string]interface{}{"roles": []interface{}{"kas-fleet-manager-admin-full"}},
+			},
+			wantClaims: jwt.MapClaims{"email": "user@partner.example.com", "username": "user@partner.example.com", "org_id": "partner-org"},
+		},
+		{
+			name:           "should not map a claim to a reserved claim",
+			claims:         ClaimMappings{Username: "email", Groups: "api_token_scopes"},
+			organisationId: "partner-org",
+			token:          jwt.MapClaims{"email": "user@partner.example.com", "api_token_scopes": []interface{}{"kafkas:write"}},
+			wantClaims:     jwt.MapClaims{"email": "user@partner.example.com", "username": "user@partner.example.com", "org_id": "partner-org"},
+		},
+		{
+			name:                 "should copy the mapped claims to the tenant claims",
+			claims:               ClaimMappings{Username: "email", OrgId: "tenant_id", AccountId: "sub", OrgAdmin: "tenant_admin", Groups: "roles", ClientId: "azp"},
+			allowedOrganisations: []string{"partner-org"},
+			token: jwt.MapClaims{
+				"email": "user@partner.example.com", "tenant_id": "partner-org", "sub": "1234",
+				"tenant_admin": true, "roles": []interface{}{"admin"}, "azp": "partner-cli",
+			},
+			wantClaims: jwt.MapClaims{
+				"email": "user@partner.example.com", "tenant_id": "partner-org", "sub": "1234",
+				"tenant_admin": true, "roles": []interface{}{"admin"}, "azp": "partner-cli",
+				"username": "user@partner.example.com", "org_id": "partner-org", "account_id": "1234",
+				"is_org_admin": true, "groups": []interface{}{"admin"}, "clientId": "partner-cli",
+			},
+		},
+		{
+			name:                 "should reject the organisations not allowed for the issuer",
+			claims:               ClaimMappings{Username: "email", OrgId: "tenant_id"},
+			allowedOrganisations: []string{"partner-org"},
+			token:                jwt.MapClaims{"email": "user@partner.example.com", "tenant_id": "13640203"},
+			wantErr:              true,
+		},
+		{
+			name:                 "should reject the tokens without the mapped organisation",
+			claims:               ClaimMappings{Username: "email", OrgId: "tenant_id"},
+			allowedOrganisations: []string{"partner-org"},
+			token:                jwt.MapClaims{"username": "spoofed", "preferred_username": "spoofed", "org_id": "partner-org", "rh-org-id": "partner-org"},
+			wantErr:              true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			issuer := TrustedIssuer{
+				Issuer:               "https://partner.example.com",
+				Claims:               tt.claims,
+				OrganisationId:       tt.organisationId,
+				AllowedOrganisations: tt.allowedOrganisations,
+			}
+			err := issuer.MapClaims(tt.token)
+			if tt.wantErr {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(tt.token).To(gomega.Equal(tt.wantClaims))
+		})
+	}
+}
+
+func Test_TrustedIssuer_VerifyAudience(t *testing.T) {
+	tests := []struct {
+		name      string
+		audiences []string
+		claims    jwt.MapClaims
+		want      bool
+	}{
+		{
+			name:   "should accept any audience when the issuer has no audiences",
+			claims: jwt.MapClaims{},
+			want:   true,
+		},
+		{
+			name:      "should accept a token with one of the audiences",
+			audiences: []string{"kas-fleet-manager", "cos-fleet-manager"},
+			claims:    jwt.MapClaims{"aud": []interface{}{"account", "cos-fleet-manager"}},
+			want:      true,
+		},
+		{
+			name:      "should reject a token with other audiences",
+			audiences: []string{"kas-fleet-manager"},
+			claims:    jwt.MapClaims{"aud": "account"},
+			want:      false,
+		},
+		{
+			name:      "should reject a token without audience",
+			audiences: []string{"kas-fleet-manager"},
+			claims:    jwt.MapClaims{},
+			want:      false,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			issuer := TrustedIssuer{Issuer: "https://partner.example.com", Audiences: tt.audiences}
+			g.Expect(issuer.VerifyAudience(tt.claims)).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_TrustedIssuers_Set(t *testing.T) {
+	valid := TrustedIssuer{
+		Issuer:         "https://partner.example.com",
+		JwksURL:        "https://partner.example.com/certs",
+		Claims:         ClaimMappings{Username: "email"},
+		OrganisationId: "partner-org",
+	}
+	withIssuer := func(update func(issuer *TrustedIssuer)) TrustedIssuer {
+		issuer := valid
+		update(&issuer)
+		return issuer
+	}
+
+	tests := []struct {
+		name    string
+		issuers []TrustedIssuer
+		wantErr bool
+	}{
+		{
+			name: "should accept valid issuers",
+			issuers: []TrustedIssuer{valid, {
+				Issuer:               "https://other.example.com",
+				JwksURL:              "http://localhost:8080/certs",
+				AllowHTTPJwksURL:     true,
+				RefreshInterval:      time.Minute,
+				Claims:               ClaimMappings{Username: "email", OrgId: "tenant_id"},
+				AllowedOrganisations: []string{"other-org"},
+			}},
+		},
+		{
+			name:    "should reject an issuer without iss",
+			issuers: []TrustedIssuer{withIssuer(func(issuer *TrustedIssuer) { issuer.Issuer = "" })},
+			wantErr: true,
+		},
+		{
+			name:    "should reject an issuer without https JWKS URL",
+			issuers: []TrustedIssuer{withIssuer(func(issuer *TrustedIssuer) { issuer.JwksURL = "file:///certs" })},
+			wantErr: true,
+		},
+		{
+			name:    "should reject an http jwks url unless it's allowed",
+			issuers: []TrustedIssuer{withIssuer(func(issuer *TrustedIssuer) { issuer.JwksURL = "http://partner.example.com/certs" })},
+			wantErr: true,
+		},
+		{
+			name:    "should reject a negative refresh interval",
+			issuers: []TrustedIssuer{withIssuer(func(issuer *TrustedIssuer) { issuer.RefreshInterval = -time.Minute })},
+			wantErr: true,
+		},
+		{
+			name:    "should reject an issuer without username claim",
+			issuers: []TrustedIssuer{withIssuer(func(issuer *TrustedIssuer) { issuer.Claims.Username = "" })},
+			wantErr: true,
+		},
+		{
+			name:    "should reject an issuer that can claim any organisation",
+			issuers: []TrustedIssuer{withIssuer(func(issuer *TrustedIssuer) { issuer.OrganisationId = "" })},
+			wantErr: true,
+		},
+		{
+			name: "should reject an issuer with both an organisation and allowed organisations",
+			issuers: []TrustedIssuer{withIssuer(func(issuer *TrustedIssuer) {
+				issuer.Claims.OrgId = "tenant_id"
+				issuer.AllowedOrganisations = []string{"other-org"}
+			})},
+			wantErr: true,
+		},
+		{
+			name: "should reject allowed organisations without organisation claim",
+			issuers: []TrustedIssuer{withIssuer(func(issuer *TrustedIssuer) {
+				issuer.OrganisationId = ""
+				issuer.AllowedOrganisations = []string{"partner-org"}
+			})},
+			wantErr: true,
+		},
+		{
+			name:    "should reject duplicated issuers",
+			issuers: []TrustedIssuer{valid, valid},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			trustedIssuers, err := NewTrustedIssuers(valid)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+
+			err = trustedIssuers.Set(tt.issuers)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
+				// the trusted issuers are left unchanged
+				g.Expect(trustedIssuers.List()).To(gomega.Equal([]TrustedIssuer{valid}))
+			} else {
+				g.Expect(trustedIssuers.List()).To(gomega.HaveLen(len(tt.issuers)))
+			}
+		})
+	}
+}
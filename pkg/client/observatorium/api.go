@@ -13,9 +13,13 @@ import (
 
 const privateTopicFilter string = "topic!~'__redhat_.*|__consumer_offsets|__transaction_state'"
 
+// connectorIdLabel is the metrics label of the connector id pod label set by the connector operators
+const connectorIdLabel string = "cos_bf2_org_connector_id"
+
 type APIObservatoriumService interface {
 	GetKafkaState(name string, namespaceName string) (KafkaState, error)
 	GetMetrics(csMetrics *KafkaMetrics, resourceNamespace string, rq *MetricsReqParams) error
+	GetConnectorMetrics(metrics *ConnectorMetrics, connectorId string, rq *MetricsReqParams) error
 }
 type fetcher struct {
 	metric string
@@ -214,6 +218,62 @@ func (obs *ServiceObservatorium) GetMetrics(metrics *KafkaMetrics, namespace str
 		},
 	}
 
+	results, err := obs.fetchAll(fetchers, rq)
+	*metrics = append(*metrics, results...)
+	return err
+}
+
+func (obs *ServiceObservatorium) GetConnectorMetrics(metrics *ConnectorMetrics, connectorId string, rq *MetricsReqParams) error {
+	labels := fmt.Sprintf(`%s=~'%s'`, connectorIdLabel, connectorId)
+	fetchers := []fetcher{
+		//Check metrics for exchanges processed by camel connectors
+		{
+			`camel_exchanges_total`,
+			labels,
+		},
+		{
+			`camel_exchanges_failed_total`,
+			labels,
+		},
+		{
+			`camel_exchanges_inflight`,
+			labels,
+		},
+		//Check metrics for records processed by debezium connectors
+		{
+			`kafka_connect_source_task_metrics_source_record_poll_total`,
+			labels,
+		},
+		{
+			`kafka_connect_source_task_metrics_source_record_write_total`,
+			labels,
+		},
+		{
+			`kafka_connect_task_error_metrics_total_record_errors`,
+			labels,
+		},
+		//Check metrics for connector pods restarts and resources
+		{
+			`kube_pod_container_status_restarts_total`,
+			labels,
+		},
+		{
+			`container_cpu_usage_seconds_total`,
+			labels,
+		},
+		{
+			`container_memory_working_set_bytes`,
+			labels,
+		},
+	}
+
+	results, err := obs.fetchAll(fetchers, rq)
+	*metrics = append(*metrics, results...)
+	return err
+}
+
+// fetchAll runs the queries of the fetchers concurrently, and returns the results of the successful ones
+func (obs *ServiceObservatorium) fetchAll(fetchers []fetcher, rq *MetricsReqParams) ([]Metric, error) {
 	// build query per label to reduce query count
 	queries := obs.buildQueries(fetchers, rq)
 
@@ -230,6 +290,7 @@ func (obs *ServiceObservatorium) GetMetrics(metrics *KafkaMetrics, namespace str
 	}
 
 	// process the metrics result
+	var results []Metric
 	var failedMetrics []string
 	for i := 1; i <= len(queries); i++ {
 		metricQueryResult := <-resultChan
@@ -240,14 +301,14 @@ func (obs *ServiceObservatorium) GetMetrics(metrics *KafkaMetrics, namespace str
 			continue
 		}
 
-		*metrics = append(*metrics, metricQueryResult.metricResult)
+		results = append(results, metricQueryResult.metricResult)
 	}
 
 	if len(failedMetrics) > 0 {
-		return errors.New(fmt.Sprintf("failed to fetch metrics data [%s]", strings.Join(failedMetrics, ",")))
+		return results, errors.New(fmt.Sprintf("failed to fetch metrics data [%s]", strings.Join(failedMetrics, ",")))
 	}
 
-	return nil
+	return results, nil
 }
 
 func (obs *ServiceObservatorium) fetchMetricsResult(query string, rq *MetricsReqParams) Metric {
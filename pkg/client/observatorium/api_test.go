@@ -163,3 +163,52 @@ func TestServiceObservatorium_GetMetrics(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceObservatorium_GetConnectorMetrics(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	obsClientMock, err := NewClientMock(&Configuration{})
+	g.Expect(err).ToNot(gomega.HaveOccurred(), "failed to create a mock observatorium client")
+
+	tests := []struct {
+		name    string
+		rq      *MetricsReqParams
+		wantErr bool
+	}{
+		{
+			name: "Return metrics successfully for RangeQuery result type",
+			rq: &MetricsReqParams{
+				ResultType: RangeQuery,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Return metrics successfully with specified filters",
+			rq: &MetricsReqParams{
+				Filters:    []string{"camel_exchanges_total"},
+				ResultType: RangeQuery,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Return an error if result type is not supported",
+			rq: &MetricsReqParams{
+				ResultType: "unsupported",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			obs := &ServiceObservatorium{
+				client: obsClientMock,
+			}
+
+			err := obs.GetConnectorMetrics(&ConnectorMetrics{}, "connector-id", tt.rq)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
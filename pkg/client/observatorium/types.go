@@ -23,6 +23,8 @@ type KafkaState struct {
 
 type KafkaMetrics []Metric
 
+type ConnectorMetrics []Metric
+
 // Metric holds the Prometheus Matrix or Vector model, which contains instant vector or range vector with time series (depending on result type)
 type Metric struct {
 	Matrix pModel.Matrix `json:"matrix"`
@@ -0,0 +1,60 @@
+package ocm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/spf13/pflag"
+)
+
+var _ environments.ConfigModule = (*AMSCacheConfig)(nil)
+
+// AMSCacheConfig is the configuration of the caches of the AMS lookups. A zero TTL disables the cache of the lookup.
+type AMSCacheConfig struct {
+	// TermsAcceptanceTTL is how long the terms acceptance of a user is cached once accepted
+	TermsAcceptanceTTL time.Duration
+	// QuotaCostsTTL is how long the quota costs of an organisation for a product are cached
+	QuotaCostsTTL time.Duration
+	// SubscriptionsTTL is how long the subscriptions found by a query are cached
+	SubscriptionsTTL time.Duration
+	// NegativeTTL is how long the errors of the quota costs and subscriptions lookups, and the terms not accepted yet, are cached
+	NegativeTTL time.Duration
+	// MaxEntries is the maximum number of values of each cache
+	MaxEntries int
+}
+
+func NewAMSCacheConfig() *AMSCacheConfig {
+	return &AMSCacheConfig{
+		TermsAcceptanceTTL: 5 * time.Minute,
+		QuotaCostsTTL:      1 * time.Minute,
+		SubscriptionsTTL:   1 * time.Minute,
+		NegativeTTL:        10 * time.Second,
+		MaxEntries:         10000,
+	}
+}
+
+func (c *AMSCacheConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&c.TermsAcceptanceTTL, "ams-cache-terms-acceptance-ttl", c.TermsAcceptanceTTL, "Duration the terms acceptance of a user is cached once accepted, 0 disables the cache")
+	fs.DurationVar(&c.QuotaCostsTTL, "ams-cache-quota-costs-ttl", c.QuotaCostsTTL, "Duration the AMS quota costs of an organisation are cached, 0 disables the cache")
+	fs.DurationVar(&c.SubscriptionsTTL, "ams-cache-subscriptions-ttl", c.SubscriptionsTTL, "Duration the AMS subscriptions are cached, 0 disables the cache")
+	fs.DurationVar(&c.NegativeTTL, "ams-cache-negative-ttl", c.NegativeTTL, "Duration the failed AMS quota costs and subscriptions lookups and the terms not accepted yet are cached, 0 disables their caching")
+	fs.IntVar(&c.MaxEntries, "ams-cache-max-entries", c.MaxEntries, "Maximum number of values of each AMS cache, the least recently used values are evicted beyond it")
+}
+
+func (c *AMSCacheConfig) ReadFiles() error {
+	for flag, ttl := range map[string]time.Duration{
+		"ams-cache-terms-acceptance-ttl": c.TermsAcceptanceTTL,
+		"ams-cache-quota-costs-ttl":      c.QuotaCostsTTL,
+		"ams-cache-subscriptions-ttl":    c.SubscriptionsTTL,
+		"ams-cache-negative-ttl":         c.NegativeTTL,
+	} {
+		if ttl < 0 {
+			return fmt.Errorf("%s must not be negative, got %s", flag, ttl)
+		}
+	}
+	if c.MaxEntries < 0 {
+		return fmt.Errorf("ams-cache-max-entries must not be negative, got %d", c.MaxEntries)
+	}
+	return nil
+}
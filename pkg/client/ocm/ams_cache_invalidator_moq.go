@@ -0,0 +1,155 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package ocm
+
+import (
+	"sync"
+)
+
+// Ensure, that AMSCacheInvalidatorMock does implement AMSCacheInvalidator.
+// If this is not the case, regenerate this file with moq.
+var _ AMSCacheInvalidator = &AMSCacheInvalidatorMock{}
+
+// AMSCacheInvalidatorMock is a mock implementation of AMSCacheInvalidator.
+//
+//	func TestSomethingThatUsesAMSCacheInvalidator(t *testing.T) {
+//
+//		// make and configure a mocked AMSCacheInvalidator
+//		mockedAMSCacheInvalidator := &AMSCacheInvalidatorMock{
+//			InvalidateQuotaCostsFunc: func(organizationID string)  {
+//				panic("mock out the InvalidateQuotaCosts method")
+//			},
+//			InvalidateSubscriptionsFunc: func()  {
+//				panic("mock out the InvalidateSubscriptions method")
+//			},
+//			InvalidateTermsAcceptanceFunc: func(username string)  {
+//				panic("mock out the InvalidateTermsAcceptance method")
+//			},
+//		}
+//
+//		// use mockedAMSCacheInvalidator in code that requires AMSCacheInvalidator
+//		// and then make assertions.
+//
+//	}
+type AMSCacheInvalidatorMock struct {
+	// InvalidateQuotaCostsFunc mocks the InvalidateQuotaCosts method.
+	InvalidateQuotaCostsFunc func(organizationID string)
+
+	// InvalidateSubscriptionsFunc mocks the InvalidateSubscriptions method.
+	InvalidateSubscriptionsFunc func()
+
+	// InvalidateTermsAcceptanceFunc mocks the InvalidateTermsAcceptance method.
+	InvalidateTermsAcceptanceFunc func(username string)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// InvalidateQuotaCosts holds details about calls to the InvalidateQuotaCosts method.
+		InvalidateQuotaCosts []struct {
+			// OrganizationID is the organizationID argument value.
+			OrganizationID string
+		}
+		// InvalidateSubscriptions holds details about calls to the InvalidateSubscriptions method.
+		InvalidateSubscriptions []struct {
+		}
+		// InvalidateTermsAcceptance holds details about calls to the InvalidateTermsAcceptance method.
+		InvalidateTermsAcceptance []struct {
+			// Username is the username argument value.
+			Username string
+		}
+	}
+	lockInvalidateQuotaCosts      sync.RWMutex
+	lockInvalidateSubscriptions   sync.RWMutex
+	lockInvalidateTermsAcceptance sync.RWMutex
+}
+
+// InvalidateQuotaCosts calls InvalidateQuotaCostsFunc.
+func (mock *AMSCacheInvalidatorMock) InvalidateQuotaCosts(organizationID string) {
+	if mock.InvalidateQuotaCostsFunc == nil {
+		panic("AMSCacheInvalidatorMock.InvalidateQuotaCostsFunc: method is nil but AMSCacheInvalidator.InvalidateQuotaCosts was just called")
+	}
+	callInfo := struct {
+		OrganizationID string
+	}{
+		OrganizationID: organizationID,
+	}
+	mock.lockInvalidateQuotaCosts.Lock()
+	mock.calls.InvalidateQuotaCosts = append(mock.calls.InvalidateQuotaCosts, callInfo)
+	mock.lockInvalidateQuotaCosts.Unlock()
+	mock.InvalidateQuotaCostsFunc(organizationID)
+}
+
+// InvalidateQuotaCostsCalls gets all the calls that were made to InvalidateQuotaCosts.
+// Check the length with:
+//
+//	len(mockedAMSCacheInvalidator.InvalidateQuotaCostsCalls())
+func (mock *AMSCacheInvalidatorMock) InvalidateQuotaCostsCalls() []struct {
+	OrganizationID string
+} {
+	var calls []struct {
+		OrganizationID string
+	}
+	mock.lockInvalidateQuotaCosts.RLock()
+	calls = mock.calls.InvalidateQuotaCosts
+	mock.lockInvalidateQuotaCosts.RUnlock()
+	return calls
+}
+
+// InvalidateSubscriptions calls InvalidateSubscriptionsFunc.
+func (mock *AMSCacheInvalidatorMock) InvalidateSubscriptions() {
+	if mock.InvalidateSubscriptionsFunc == nil {
+		panic("AMSCacheInvalidatorMock.InvalidateSubscriptionsFunc: method is nil but AMSCacheInvalidator.InvalidateSubscriptions was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockInvalidateSubscriptions.Lock()
+	mock.calls.InvalidateSubscriptions = append(mock.calls.InvalidateSubscriptions, callInfo)
+	mock.lockInvalidateSubscriptions.Unlock()
+	mock.InvalidateSubscriptionsFunc()
+}
+
+// InvalidateSubscriptionsCalls gets all the calls that were made to InvalidateSubscriptions.
+// Check the length with:
+//
+//	len(mockedAMSCacheInvalidator.InvalidateSubscriptionsCalls())
+func (mock *AMSCacheInvalidatorMock) InvalidateSubscriptionsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockInvalidateSubscriptions.RLock()
+	calls = mock.calls.InvalidateSubscriptions
+	mock.lockInvalidateSubscriptions.RUnlock()
+	return calls
+}
+
+// InvalidateTermsAcceptance calls InvalidateTermsAcceptanceFunc.
+func (mock *AMSCacheInvalidatorMock) InvalidateTermsAcceptance(username string) {
+	if mock.InvalidateTermsAcceptanceFunc == nil {
+		panic("AMSCacheInvalidatorMock.InvalidateTermsAcceptanceFunc: method is nil but AMSCacheInvalidator.InvalidateTermsAcceptance was just called")
+	}
+	callInfo := struct {
+		Username string
+	}{
+		Username: username,
+	}
+	mock.lockInvalidateTermsAcceptance.Lock()
+	mock.calls.InvalidateTermsAcceptance = append(mock.calls.InvalidateTermsAcceptance, callInfo)
+	mock.lockInvalidateTermsAcceptance.Unlock()
+	mock.InvalidateTermsAcceptanceFunc(username)
+}
+
+// InvalidateTermsAcceptanceCalls gets all the calls that were made to InvalidateTermsAcceptance.
+// Check the length with:
+//
+//	len(mockedAMSCacheInvalidator.InvalidateTermsAcceptanceCalls())
+func (mock *AMSCacheInvalidatorMock) InvalidateTermsAcceptanceCalls() []struct {
+	Username string
+} {
+	var calls []struct {
+		Username string
+	}
+	mock.lockInvalidateTermsAcceptance.RLock()
+	calls = mock.calls.InvalidateTermsAcceptance
+	mock.lockInvalidateTermsAcceptance.RUnlock()
+	return calls
+}
@@ -0,0 +1,112 @@
+package ocm
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/cache"
+	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+)
+
+// Names of the caches of the AMS lookups, they're the cache label of the cache metrics
+const (
+	TermsAcceptanceCacheName = "ams_terms_acceptance"
+	QuotaCostsCacheName      = "ams_quota_costs"
+	SubscriptionsCacheName   = "ams_subscriptions"
+)
+
+//go:generate moq -out ams_cache_invalidator_moq.go . AMSCacheInvalidator
+
+// AMSCacheInvalidator invalidates the cached AMS lookups, once the data they returned is known to have changed
+type AMSCacheInvalidator interface {
+	InvalidateTermsAcceptance(username string)
+	InvalidateQuotaCosts(organizationID string)
+	InvalidateSubscriptions()
+}
+
+// CachingAMSClient is an AMS client caching its lookups
+type CachingAMSClient interface {
+	AMSClient
+	AMSCacheInvalidator
+}
+
+// cachingAMSClient caches the AMS lookups called on the hot paths: the terms acceptance checked on every request of the
+// public APIs, and the quota costs and subscriptions checked by the quota services and the reconcilers. The callers
+// changing the quota or the subscriptions invalidate them through the AMSCacheInvalidator.
+type cachingAMSClient struct {
+	Client
+	termsAcceptance *cache.Cache[string, termsAcceptance]
+	quotaCosts      *cache.Cache[quotaCostsKey, []*amsv1.QuotaCost]
+	subscriptions   *cache.Cache[string, []*amsv1.Subscription]
+}
+
+var _ CachingAMSClient = &cachingAMSClient{}
+
+type termsAcceptance struct {
+	termsRequired bool
+	redirectUrl   string
+}
+
+type quotaCostsKey struct {
+	organizationID string
+	resourceName   string
+	product        string
+}
+
+// NewCachingAMSClient returns an AMS client caching the lookups of the given client
+func NewCachingAMSClient(client Client, config *AMSCacheConfig) CachingAMSClient {
+	return &cachingAMSClient{
+		Client: client,
+		termsAcceptance: cache.New[string](TermsAcceptanceCacheName, cache.Options[termsAcceptance]{
+			TTL:         config.TermsAcceptanceTTL,
+			NegativeTTL: config.NegativeTTL,
+			MaxEntries:  config.MaxEntries,
+			// the users who haven't accepted the terms yet must be let in shortly after they accepted them
+			IsNegative: func(value termsAcceptance) bool { return value.termsRequired },
+			// a failed lookup denies every request of the user, it's retried by the next request
+			SkipErrors: true,
+		}),
+		quotaCosts: cache.New[quotaCostsKey](QuotaCostsCacheName, cache.Options[[]*amsv1.QuotaCost]{
+			TTL:         config.QuotaCostsTTL,
+			NegativeTTL: config.NegativeTTL,
+			MaxEntries:  config.MaxEntries,
+		}),
+		subscriptions: cache.New[string](SubscriptionsCacheName, cache.Options[[]*amsv1.Subscription]{
+			TTL:         config.SubscriptionsTTL,
+			NegativeTTL: config.NegativeTTL,
+			MaxEntries:  config.MaxEntries,
+		}),
+	}
+}
+
+func (c *cachingAMSClient) GetRequiresTermsAcceptance(username string) (termsRequired bool, redirectUrl string, err error) {
+	terms, err := c.termsAcceptance.Get(username, func() (termsAcceptance, error) {
+		termsRequired, redirectUrl, err := c.Client.GetRequiresTermsAcceptance(username)
+		return termsAcceptance{termsRequired: termsRequired, redirectUrl: redirectUrl}, err
+	})
+	return terms.termsRequired, terms.redirectUrl, err
+}
+
+func (c *cachingAMSClient) GetQuotaCostsForProduct(organizationID, resourceName, product string) ([]*amsv1.QuotaCost, error) {
+	key := quotaCostsKey{organizationID: organizationID, resourceName: resourceName, product: product}
+	return c.quotaCosts.Get(key, func() ([]*amsv1.QuotaCost, error) {
+		return c.Client.GetQuotaCostsForProduct(organizationID, resourceName, product)
+	})
+}
+
+func (c *cachingAMSClient) FindSubscriptions(query string) ([]*amsv1.Subscription, error) {
+	return c.subscriptions.Get(query, func() ([]*amsv1.Subscription, error) {
+		return c.Client.FindSubscriptions(query)
+	})
+}
+
+func (c *cachingAMSClient) InvalidateTermsAcceptance(username string) {
+	c.termsAcceptance.Invalidate(username)
+}
+
+func (c *cachingAMSClient) InvalidateQuotaCosts(organizationID string) {
+	c.quotaCosts.InvalidateFunc(func(key quotaCostsKey) bool {
+		return key.organizationID == organizationID
+	})
+}
+
+func (c *cachingAMSClient) InvalidateSubscriptions() {
+	c.subscriptions.Purge()
+}
@@ -0,0 +1,140 @@
+package ocm
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+)
+
+func Test_cachingAMSClient_GetRequiresTermsAcceptance(t *testing.T) {
+	tests := []struct {
+		name          string
+		termsRequired bool
+		err           error
+		negativeTTL   time.Duration
+		wantCalls     int
+	}{
+		{
+			name:          "should cache the accepted terms",
+			termsRequired: false,
+			wantCalls:     1,
+		},
+		{
+			name:          "should not cache the terms not accepted yet without negative TTL",
+			termsRequired: true,
+			wantCalls:     2,
+		},
+		{
+			name:          "should cache the terms not accepted yet for the negative TTL",
+			termsRequired: true,
+			negativeTTL:   time.Minute,
+			wantCalls:     1,
+		},
+		{
+			name:      "should not cache the errors without negative TTL",
+			err:       fmt.Errorf("unavailable"),
+			wantCalls: 2,
+		},
+		{
+			name:        "should not cache the errors with a negative TTL",
+			err:         fmt.Errorf("unavailable"),
+			negativeTTL: time.Minute,
+			wantCalls:   2,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			client := &ClientMock{
+				GetRequiresTermsAcceptanceFunc: func(username string) (bool, string, error) {
+					return tt.termsRequired, "https://redirect", tt.err
+				},
+			}
+			config := NewAMSCacheConfig()
+			config.NegativeTTL = tt.negativeTTL
+			cachingClient := NewCachingAMSClient(client, config)
+
+			for i := 0; i < 2; i++ {
+				termsRequired, redirectUrl, err := cachingClient.GetRequiresTermsAcceptance("user")
+				if tt.err != nil {
+					g.Expect(err).To(gomega.Equal(tt.err))
+				} else {
+					g.Expect(err).ToNot(gomega.HaveOccurred())
+					g.Expect(termsRequired).To(gomega.Equal(tt.termsRequired))
+					g.Expect(redirectUrl).To(gomega.Equal("https://redirect"))
+				}
+			}
+			g.Expect(client.GetRequiresTermsAcceptanceCalls()).To(gomega.HaveLen(tt.wantCalls))
+		})
+	}
+}
+
+func Test_cachingAMSClient_Invalidation(t *testing.T) {
+	g := gomega.NewWithT(t)
+	subscription, err := amsv1.NewSubscription().OrganizationID("org-1").Build()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	client := &ClientMock{
+		GetRequiresTermsAcceptanceFunc: func(username string) (bool, string, error) {
+			return false, "", nil
+		},
+		GetQuotaCostsForProductFunc: func(organizationID, resourceName, product string) ([]*amsv1.QuotaCost, error) {
+			return []*amsv1.QuotaCost{}, nil
+		},
+		FindSubscriptionsFunc: func(query string) ([]*amsv1.Subscription, error) {
+			return []*amsv1.Subscription{subscription}, nil
+		},
+	}
+	cachingClient := NewCachingAMSClient(client, NewAMSCacheConfig())
+	lookup := func() {
+		_, _, err := cachingClient.GetRequiresTermsAcceptance("user")
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		_, err = cachingClient.GetQuotaCostsForProduct("org-1", "rhosak", "RHOSAK")
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		_, err = cachingClient.GetQuotaCostsForProduct("org-2", "rhosak", "RHOSAK")
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		_, err = cachingClient.FindSubscriptions("cluster_id='cluster'")
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+	}
+
+	lookup()
+	lookup()
+	g.Expect(client.GetRequiresTermsAcceptanceCalls()).To(gomega.HaveLen(1))
+	g.Expect(client.GetQuotaCostsForProductCalls()).To(gomega.HaveLen(2))
+	g.Expect(client.FindSubscriptionsCalls()).To(gomega.HaveLen(1))
+
+	cachingClient.InvalidateTermsAcceptance("user")
+	lookup()
+	g.Expect(client.GetRequiresTermsAcceptanceCalls()).To(gomega.HaveLen(2))
+	g.Expect(client.GetQuotaCostsForProductCalls()).To(gomega.HaveLen(2))
+
+	// only the quota costs of the organisation are invalidated
+	cachingClient.InvalidateQuotaCosts("org-2")
+	lookup()
+	g.Expect(client.GetQuotaCostsForProductCalls()).To(gomega.HaveLen(3))
+	g.Expect(client.GetQuotaCostsForProductCalls()[2].OrganizationID).To(gomega.Equal("org-2"))
+	g.Expect(client.FindSubscriptionsCalls()).To(gomega.HaveLen(1))
+
+	cachingClient.InvalidateSubscriptions()
+	lookup()
+	g.Expect(client.FindSubscriptionsCalls()).To(gomega.HaveLen(2))
+	g.Expect(client.GetQuotaCostsForProductCalls()).To(gomega.HaveLen(3))
+}
+
+func Test_AMSCacheConfig_ReadFiles(t *testing.T) {
+	g := gomega.NewWithT(t)
+	config := NewAMSCacheConfig()
+	g.Expect(config.ReadFiles()).To(gomega.Succeed())
+
+	config.QuotaCostsTTL = -time.Second
+	g.Expect(config.ReadFiles()).ToNot(gomega.Succeed())
+
+	config = NewAMSCacheConfig()
+	config.MaxEntries = -1
+	g.Expect(config.ReadFiles()).ToNot(gomega.Succeed())
+}
@@ -0,0 +1,72 @@
+package reencrypt
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/encryption"
+	"github.com/golang/glog"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// NewReencryptCommand re-encrypts the sensitive database columns with the current key of the db encryption provider
+func NewReencryptCommand(env *environments.Env) *cobra.Command {
+	var dryRun bool
+	var batchSize int
+	cmd := &cobra.Command{
+		Use:   "reencrypt",
+		Short: "Re-encrypt the sensitive database columns",
+		Long: "Encrypt the sensitive database columns stored in plain text, and re-encrypt the values encrypted with " +
+			"another provider or key version than the current key of the db encryption provider",
+
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			err := env.CreateServices()
+			if err != nil {
+				glog.Fatalf("Unable to initialize environment: %s", err.Error())
+			}
+		},
+
+		Run: func(cmd *cobra.Command, args []string) {
+			env.MustInvoke(func(connectionFactory *db.ConnectionFactory, columns []encryption.EncryptedColumn) {
+				encrypter := encryption.DefaultEncrypter()
+				if encrypter == nil {
+					glog.Fatalf("The db encryption provider must be set with --db-encryption-provider to re-encrypt the database columns")
+				}
+				results, err := encryption.Reencrypt(connectionFactory.New(), encrypter, columns, encryption.ReencryptOptions{
+					DryRun:    dryRun,
+					BatchSize: batchSize,
+				})
+				printResults(results, dryRun)
+				if err != nil {
+					glog.Fatalf("Re-encryption failed: %v", err)
+				}
+			})
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", true, "Only count the values to re-encrypt, use --dry-run=false to re-encrypt them")
+	cmd.Flags().IntVar(&batchSize, "batch-size", 100, "Number of rows read at once")
+	return cmd
+}
+
+func printResults(results []encryption.ReencryptResult, dryRun bool) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Table", "Column", "Scanned", "Outdated", "Re-encrypted", "Failed"})
+	for _, result := range results {
+		table.Append([]string{
+			result.Table,
+			result.Column,
+			strconv.Itoa(result.Scanned),
+			strconv.Itoa(result.Outdated),
+			strconv.Itoa(result.Reencrypted),
+			strconv.Itoa(result.Failed),
+		})
+	}
+	table.Render()
+	if dryRun {
+		fmt.Println("dry run, no value was re-encrypted, use --dry-run=false to re-encrypt the outdated values")
+	}
+}
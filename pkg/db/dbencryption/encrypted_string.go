@@ -0,0 +1,87 @@
+package dbencryption
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// EncryptedPrefix prefixes the encrypted values, the values without it are legacy plain text values
+const EncryptedPrefix = "enc:v1:"
+
+// ValueEncrypter encrypts and decrypts the values of the EncryptedString columns
+type ValueEncrypter interface {
+	Encrypt(plaintext string) (string, error)
+	Decrypt(value string) (string, error)
+}
+
+var (
+	defaultEncrypterMu sync.RWMutex
+	defaultEncrypter   ValueEncrypter
+)
+
+// SetDefaultEncrypter sets the encrypter of the EncryptedString columns, the values are stored in plain text when it's nil
+func SetDefaultEncrypter(encrypter ValueEncrypter) {
+	defaultEncrypterMu.Lock()
+	defer defaultEncrypterMu.Unlock()
+	defaultEncrypter = encrypter
+}
+
+// DefaultEncrypter returns the encrypter of the EncryptedString columns, it's nil when the encryption is disabled
+func DefaultEncrypter() ValueEncrypter {
+	defaultEncrypterMu.RLock()
+	defer defaultEncrypterMu.RUnlock()
+	return defaultEncrypter
+}
+
+// IsEncrypted returns whether the value was encrypted, the values without the EncryptedPrefix are plain text values
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, EncryptedPrefix)
+}
+
+// EncryptedString is a string column encrypted at rest by the default encrypter.
+// The values written before the encryption was enabled are read as plain text, until they are re-encrypted.
+type EncryptedString string
+
+func (s EncryptedString) GormDataType() string {
+	return "text"
+}
+
+func (s EncryptedString) Value() (driver.Value, error) {
+	encrypter := DefaultEncrypter()
+	if encrypter == nil {
+		return string(s), nil
+	}
+	return encrypter.Encrypt(string(s))
+}
+
+func (s *EncryptedString) Scan(value interface{}) error {
+	var stored string
+	switch v := value.(type) {
+	case nil:
+		*s = ""
+		return nil
+	case string:
+		stored = v
+	case []byte:
+		stored = string(v)
+	default:
+		return fmt.Errorf("unsupported type %T for an encrypted string", value)
+	}
+
+	if !IsEncrypted(stored) {
+		*s = EncryptedString(stored)
+		return nil
+	}
+	encrypter := DefaultEncrypter()
+	if encrypter == nil {
+		return fmt.Errorf("found an encrypted value but the db encryption provider isn't configured")
+	}
+	plaintext, err := encrypter.Decrypt(stored)
+	if err != nil {
+		return err
+	}
+	*s = EncryptedString(plaintext)
+	return nil
+}
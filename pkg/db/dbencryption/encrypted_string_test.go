@@ -0,0 +1,50 @@
+package dbencryption
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+// base64Encrypter is a reversible ValueEncrypter for the tests
+type base64Encrypter struct{}
+
+func (base64Encrypter) Encrypt(plaintext string) (string, error) {
+	return EncryptedPrefix + base64.StdEncoding.EncodeToString([]byte(plaintext)), nil
+}
+
+func (base64Encrypter) Decrypt(value string) (string, error) {
+	plaintext, err := base64.StdEncoding.DecodeString(value[len(EncryptedPrefix):])
+	return string(plaintext), err
+}
+
+func Test_EncryptedString(t *testing.T) {
+	g := gomega.NewWithT(t)
+	defer SetDefaultEncrypter(nil)
+
+	// the values are stored in plain text when the encryption is disabled
+	SetDefaultEncrypter(nil)
+	value, err := EncryptedString("client-secret").Value()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(value).To(gomega.Equal("client-secret"))
+
+	SetDefaultEncrypter(base64Encrypter{})
+	value, err = EncryptedString("client-secret").Value()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(IsEncrypted(value.(string))).To(gomega.BeTrue())
+
+	var scanned EncryptedString
+	g.Expect(scanned.Scan(value)).To(gomega.Succeed())
+	g.Expect(scanned).To(gomega.Equal(EncryptedString("client-secret")))
+
+	g.Expect(scanned.Scan([]byte("legacy-plain-text"))).To(gomega.Succeed())
+	g.Expect(scanned).To(gomega.Equal(EncryptedString("legacy-plain-text")))
+
+	g.Expect(scanned.Scan(nil)).To(gomega.Succeed())
+	g.Expect(scanned).To(gomega.BeEmpty())
+
+	// the encrypted values can't be read when the encryption is disabled
+	SetDefaultEncrypter(nil)
+	g.Expect(scanned.Scan(value)).ToNot(gomega.Succeed())
+}
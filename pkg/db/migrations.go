@@ -104,6 +104,19 @@ func (m *Migration) CountMigrationsApplied() int {
 	return count
 }
 
+// IsMigrationApplied returns whether the migration is recorded in the migrations table, e.g. the migration of another
+// service sharing the database and some of its tables
+func IsMigrationApplied(tx *gorm.DB, migrationsTableName string, migrationID string) (bool, error) {
+	if !tx.Migrator().HasTable(migrationsTableName) {
+		return false, nil
+	}
+	var count int64
+	if err := tx.Table(migrationsTableName).Where("id = ?", migrationID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
 // Model represents the base model struct. All entities will have this struct embedded.
 type Model struct {
 	ID        string `gorm:"primary_key"`
@@ -0,0 +1,230 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
+	"github.com/rs/xid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Statuses of the outbox messages
+const (
+	OutboxMessagePending = "pending"
+	OutboxMessageDone    = "done"
+	// OutboxMessageDead is the status of the messages that failed MaxAttempts times, they are only attempted again when retried by an admin
+	OutboxMessageDead = "dead"
+)
+
+// OutboxMessage is an external side effect, i.e. a call to AMS, SSO or Route53, recorded in the transaction changing the state
+// that requires it, and executed by the outbox worker once the transaction is committed
+type OutboxMessage struct {
+	ID        string    `json:"id" gorm:"primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// Type selects the handler executing the message
+	Type string `json:"type" gorm:"index"`
+	// IdempotencyKey identifies the side effect, a message is only enqueued once for a given key
+	IdempotencyKey string `json:"idempotency_key" gorm:"uniqueIndex"`
+	// Payload is the JSON document passed to the handler
+	Payload string `json:"-" gorm:"type:jsonb"`
+
+	Status        string     `json:"status" gorm:"index"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty" gorm:"index"`
+	LastAttemptAt *time.Time `json:"last_attempt_at,omitempty"`
+	LastError     string     `json:"last_error,omitempty"`
+	ProcessedAt   *time.Time `json:"processed_at,omitempty"`
+}
+
+type OutboxMessageList []*OutboxMessage
+
+func (m *OutboxMessage) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == "" {
+		m.ID = xid.New().String()
+	}
+	return nil
+}
+
+// EnqueueOutboxMessage adds a message to the outbox with the given database connection, which should be the transaction changing
+// the state requiring the side effect so that the message is only recorded if the state change is committed.
+// The message is ignored if a message with the same idempotency key was already enqueued.
+func EnqueueOutboxMessage(tx *gorm.DB, messageType string, idempotencyKey string, payload interface{}) error {
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return errors.GeneralError("unable to marshal the payload of the %s outbox message: %v", messageType, err)
+	}
+	now := time.Now()
+	message := &OutboxMessage{
+		Type:           messageType,
+		IdempotencyKey: idempotencyKey,
+		Payload:        string(payloadJSON),
+		Status:         OutboxMessagePending,
+		NextAttemptAt:  &now,
+	}
+	if err := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "idempotency_key"}}, DoNothing: true}).
+		Create(message).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "unable to enqueue the %s outbox message", messageType)
+	}
+	return nil
+}
+
+// OutboxHandler executes the side effect of a message. The handlers must be idempotent: a message is attempted again when its
+// handler fails, or when the outbox worker stops before recording the result of the message.
+type OutboxHandler func(ctx context.Context, payload []byte) error
+
+// Outbox executes the pending outbox messages with the handlers registered for their types
+type Outbox struct {
+	connectionFactory *ConnectionFactory
+	config            *OutboxConfig
+	mu                sync.RWMutex
+	handlers          map[string]OutboxHandler
+}
+
+func NewOutbox(connectionFactory *ConnectionFactory, config *OutboxConfig) *Outbox {
+	return &Outbox{
+		connectionFactory: connectionFactory,
+		config:            config,
+		handlers:          map[string]OutboxHandler{},
+	}
+}
+
+// RegisterHandler sets the handler of the messages of a type, the messages without a registered handler are left pending
+func (o *Outbox) RegisterHandler(messageType string, handler OutboxHandler) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.handlers[messageType] = handler
+}
+
+// Types returns the message types with a registered handler
+func (o *Outbox) Types() []string {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	types := make([]string, 0, len(o.handlers))
+	for messageType := range o.handlers {
+		types = append(types, messageType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func (o *Outbox) handler(messageType string) OutboxHandler {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.handlers[messageType]
+}
+
+// Process attempts the pending messages that are due and have a registered handler, and returns the number of attempted messages
+func (o *Outbox) Process(ctx context.Context) (int, *errors.ServiceError) {
+	types := o.Types()
+	if len(types) == 0 {
+		return 0, nil
+	}
+
+	dbConn := o.connectionFactory.New()
+	var messages OutboxMessageList
+	if err := dbConn.Where("status = ? AND next_attempt_at <= ? AND type IN ?", OutboxMessagePending, time.Now(), types).
+		Order("next_attempt_at").
+		Limit(o.config.BatchSize).
+		Find(&messages).Error; err != nil {
+		return 0, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list the pending outbox messages")
+	}
+
+	ulog := logger.NewUHCLogger(ctx)
+	for _, message := range messages {
+		o.attempt(ctx, message)
+		if err := dbConn.Model(message).
+			Select("status", "attempts", "next_attempt_at", "last_attempt_at", "last_error", "processed_at").
+			Updates(message).Error; err != nil {
+			// the message will be attempted again
+			ulog.Errorf("unable to update outbox message %s: %v", message.ID, err)
+		}
+	}
+	return len(messages), nil
+}
+
+// attempt executes a message and updates its status, a failed message is retried with an exponential backoff until MaxAttempts is reached
+func (o *Outbox) attempt(ctx context.Context, message *OutboxMessage) {
+	now := time.Now()
+	message.Attempts++
+	message.LastAttemptAt = &now
+
+	var err error
+	if handler := o.handler(message.Type); handler == nil {
+		err = fmt.Errorf("no handler is registered for the %s outbox messages", message.Type)
+	} else {
+		err = handler(ctx, []byte(message.Payload))
+	}
+
+	switch {
+	case err == nil:
+		message.Status = OutboxMessageDone
+		message.NextAttemptAt = nil
+		message.LastError = ""
+		message.ProcessedAt = &now
+	case message.Attempts >= o.config.MaxAttempts:
+		logger.NewUHCLogger(ctx).Errorf("outbox message %s of type %s is dead lettered after %d attempts: %v", message.ID, message.Type, message.Attempts, err)
+		message.Status = OutboxMessageDead
+		message.NextAttemptAt = nil
+		message.LastError = err.Error()
+	default:
+		next := now.Add(o.config.Backoff(message.Attempts))
+		message.NextAttemptAt = &next
+		message.LastError = err.Error()
+	}
+}
+
+// List returns a page of the messages, most recent first, with the given status and type when they are set
+func (o *Outbox) List(status string, messageType string, page int, size int) (OutboxMessageList, int64, *errors.ServiceError) {
+	if page < 1 {
+		page = 1
+	}
+	dbConn := o.connectionFactory.New().Model(&OutboxMessage{})
+	if status != "" {
+		dbConn = dbConn.Where("status = ?", status)
+	}
+	if messageType != "" {
+		dbConn = dbConn.Where("type = ?", messageType)
+	}
+
+	var total int64
+	if err := dbConn.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, errors.NewWithCause(errors.ErrorGeneral, err, "unable to count the outbox messages")
+	}
+	var messages OutboxMessageList
+	if err := dbConn.Order("created_at desc").Offset((page - 1) * size).Limit(size).Find(&messages).Error; err != nil {
+		return nil, 0, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list the outbox messages")
+	}
+	return messages, total, nil
+}
+
+// Retry sets a dead lettered message pending again, with its attempts reset
+func (o *Outbox) Retry(id string) (*OutboxMessage, *errors.ServiceError) {
+	dbConn := o.connectionFactory.New()
+	var message OutboxMessage
+	if err := dbConn.Where("id = ?", id).First(&message).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFound("outbox message with id='%s' not found", id)
+		}
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to get the outbox message with id='%s'", id)
+	}
+	if message.Status != OutboxMessageDead {
+		return nil, errors.BadRequest("only the %s outbox messages can be retried, the message with id='%s' is %s", OutboxMessageDead, id, message.Status)
+	}
+
+	now := time.Now()
+	message.Status = OutboxMessagePending
+	message.Attempts = 0
+	message.NextAttemptAt = &now
+	if err := dbConn.Model(&message).Select("status", "attempts", "next_attempt_at").Updates(&message).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to retry the outbox message with id='%s'", id)
+	}
+	return &message, nil
+}
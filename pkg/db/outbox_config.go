@@ -0,0 +1,50 @@
+package db
+
+import (
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// OutboxConfig is the configuration of the processing of the outbox messages
+type OutboxConfig struct {
+	// MaxAttempts is the number of failed attempts after which a message is dead lettered
+	MaxAttempts int
+	// InitialBackoff is the delay before the second attempt of a message, it doubles after each failed attempt up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// BatchSize is the maximum number of messages processed by each run of the outbox worker
+	BatchSize int
+}
+
+func NewOutboxConfig() *OutboxConfig {
+	return &OutboxConfig{
+		MaxAttempts:    20,
+		InitialBackoff: 30 * time.Second,
+		MaxBackoff:     time.Hour,
+		BatchSize:      100,
+	}
+}
+
+func (c *OutboxConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.IntVar(&c.MaxAttempts, "outbox-max-attempts", c.MaxAttempts, "Number of failed attempts after which an outbox message is dead lettered")
+	fs.DurationVar(&c.InitialBackoff, "outbox-initial-backoff", c.InitialBackoff, "Delay before retrying a failed outbox message, doubled after each failed attempt")
+	fs.DurationVar(&c.MaxBackoff, "outbox-max-backoff", c.MaxBackoff, "Maximum delay between two attempts of an outbox message")
+	fs.IntVar(&c.BatchSize, "outbox-batch-size", c.BatchSize, "Maximum number of outbox messages processed at once")
+}
+
+func (c *OutboxConfig) ReadFiles() error {
+	return nil
+}
+
+// Backoff returns the delay before the next attempt of a message that failed the given number of attempts
+func (c *OutboxConfig) Backoff(attempts int) time.Duration {
+	backoff := c.InitialBackoff
+	for i := 1; i < attempts && backoff < c.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > c.MaxBackoff {
+		return c.MaxBackoff
+	}
+	return backoff
+}
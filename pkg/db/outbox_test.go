@@ -0,0 +1,143 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func Test_OutboxConfig_Backoff(t *testing.T) {
+	config := &OutboxConfig{
+		InitialBackoff: 30 * time.Second,
+		MaxBackoff:     5 * time.Minute,
+	}
+	tests := []struct {
+		name     string
+		attempts int
+		want     time.Duration
+	}{
+		{
+			name:     "should return the initial backoff after the first attempt",
+			attempts: 1,
+			want:     30 * time.Second,
+		},
+		{
+			name:     "should double the backoff after each failed attempt",
+			attempts: 3,
+			want:     2 * time.Minute,
+		},
+		{
+			name:     "should cap the backoff to the max backoff",
+			attempts: 10,
+			want:     5 * time.Minute,
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(config.Backoff(tt.attempts)).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_Outbox_attempt(t *testing.T) {
+	config := &OutboxConfig{
+		MaxAttempts:    3,
+		InitialBackoff: time.Minute,
+		MaxBackoff:     time.Hour,
+	}
+	tests := []struct {
+		name          string
+		handler       OutboxHandler
+		attempts      int
+		wantStatus    string
+		wantLastError string
+		wantNext      bool
+	}{
+		{
+			name: "should mark the message as done when the handler succeeds",
+			handler: func(ctx context.Context, payload []byte) error {
+				if string(payload) != `{"id":"1"}` {
+					return fmt.Errorf("unexpected payload %s", payload)
+				}
+				return nil
+			},
+			wantStatus: OutboxMessageDone,
+		},
+		{
+			name: "should retry the message when the handler fails",
+			handler: func(ctx context.Context, payload []byte) error {
+				return fmt.Errorf("unavailable")
+			},
+			attempts:      1,
+			wantStatus:    OutboxMessagePending,
+			wantLastError: "unavailable",
+			wantNext:      true,
+		},
+		{
+			name: "should dead letter the message when the handler fails max attempts times",
+			handler: func(ctx context.Context, payload []byte) error {
+				return fmt.Errorf("unavailable")
+			},
+			attempts:      2,
+			wantStatus:    OutboxMessageDead,
+			wantLastError: "unavailable",
+		},
+		{
+			name:          "should retry the message when no handler is registered for its type",
+			wantStatus:    OutboxMessagePending,
+			wantLastError: "no handler is registered for the test outbox messages",
+			wantNext:      true,
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			outbox := NewOutbox(nil, config)
+			if tt.handler != nil {
+				outbox.RegisterHandler("test", tt.handler)
+			}
+			message := &OutboxMessage{
+				ID:       "message-id",
+				Type:     "test",
+				Payload:  `{"id":"1"}`,
+				Status:   OutboxMessagePending,
+				Attempts: tt.attempts,
+			}
+
+			outbox.attempt(context.Background(), message)
+
+			g.Expect(message.Status).To(gomega.Equal(tt.wantStatus))
+			g.Expect(message.Attempts).To(gomega.Equal(tt.attempts + 1))
+			g.Expect(message.LastAttemptAt).ToNot(gomega.BeNil())
+			g.Expect(message.LastError).To(gomega.Equal(tt.wantLastError))
+			if tt.wantNext {
+				g.Expect(message.NextAttemptAt).ToNot(gomega.BeNil())
+				g.Expect(message.NextAttemptAt.Sub(*message.LastAttemptAt)).To(gomega.Equal(config.Backoff(tt.attempts + 1)))
+			} else {
+				g.Expect(message.NextAttemptAt).To(gomega.BeNil())
+			}
+			if tt.wantStatus == OutboxMessageDone {
+				g.Expect(message.ProcessedAt).ToNot(gomega.BeNil())
+			} else {
+				g.Expect(message.ProcessedAt).To(gomega.BeNil())
+			}
+		})
+	}
+}
+
+func Test_Outbox_Types(t *testing.T) {
+	g := gomega.NewWithT(t)
+	outbox := NewOutbox(nil, NewOutboxConfig())
+	g.Expect(outbox.Types()).To(gomega.BeEmpty())
+
+	handler := func(ctx context.Context, payload []byte) error { return nil }
+	outbox.RegisterHandler("b", handler)
+	outbox.RegisterHandler("a", handler)
+	g.Expect(outbox.Types()).To(gomega.Equal([]string{"a", "b"}))
+}
@@ -0,0 +1,301 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/apitokens"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+	"github.com/gorilla/mux"
+)
+
+const apiTokenNameMaxLength = 64
+
+// ApiToken is the representation of an API token on the public APIs
+type ApiToken struct {
+	Id          string     `json:"id"`
+	Kind        string     `json:"kind"`
+	Href        string     `json:"href"`
+	Name        string     `json:"name"`
+	Type        string     `json:"type"`
+	Scopes      []string   `json:"scopes"`
+	Owner       string     `json:"owner"`
+	TokenPrefix string     `json:"token_prefix"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	// Token is only returned when the token is created
+	Token string `json:"token,omitempty"`
+}
+
+// ApiTokenRequest is the body of the API token create requests
+type ApiTokenRequest struct {
+	Name string `json:"name"`
+	// Type is personal, the default, or organisation
+	Type      string     `json:"type,omitempty"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type ApiTokenList struct {
+	Kind  string      `json:"kind"`
+	Page  int32       `json:"page"`
+	Size  int32       `json:"size"`
+	Total int32       `json:"total"`
+	Items []*ApiToken `json:"items"`
+}
+
+// ApiTokensHandler serves the API tokens of the organisations of the users. The users manage their personal tokens, the
+// organisation admins manage all the tokens of their organisation.
+type ApiTokensHandler struct {
+	basePath        string
+	apiTokenService apitokens.ApiTokenService
+	apiTokenConfig  *apitokens.ApiTokenConfig
+}
+
+// NewApiTokensHandler creates a handler for the API tokens, served under basePath
+func NewApiTokensHandler(basePath string, apiTokenService apitokens.ApiTokenService, apiTokenConfig *apitokens.ApiTokenConfig) *ApiTokensHandler {
+	return &ApiTokensHandler{
+		basePath:        basePath,
+		apiTokenService: apiTokenService,
+		apiTokenConfig:  apiTokenConfig,
+	}
+}
+
+// apiTokenUser is the user managing API tokens
+type apiTokenUser struct {
+	organisationId string
+	username       string
+	accountId      string
+	isOrgAdmin     bool
+}
+
+// owner restricts the tokens to the tokens of the user, unless the user is an organisation admin
+func (u *apiTokenUser) owner() string {
+	if u.isOrgAdmin {
+		return ""
+	}
+	return u.username
+}
+
+func (h *ApiTokensHandler) List(w http.ResponseWriter, r *http.Request) {
+	var user apiTokenUser
+	cfg := &HandlerConfig{
+		Validate: []Validate{
+			h.validateUser(r, &user),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			listArgs := services.NewListArguments(r.URL.Query())
+			tokens, paging, err := h.apiTokenService.List(r.Context(), user.organisationId, user.owner(), listArgs)
+			if err != nil {
+				return nil, err
+			}
+
+			list := ApiTokenList{
+				Kind:  "ApiTokenList",
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: make([]*ApiToken, 0, len(tokens)),
+			}
+			for _, token := range tokens {
+				list.Items = append(list.Items, h.present(token))
+			}
+			return list, nil
+		},
+	}
+
+	HandleList(w, r, cfg)
+}
+
+func (h *ApiTokensHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var request ApiTokenRequest
+	var user apiTokenUser
+	cfg := &HandlerConfig{
+		MarshalInto: &request,
+		Validate: []Validate{
+			h.validateUser(r, &user),
+			h.validateRequest(&request),
+			func() *errors.ServiceError {
+				resource := auth.AuthorizationResource{
+					Type:           apiTokenResourceType(request.Type),
+					OrganisationId: user.organisationId,
+					Owner:          user.username,
+					Attributes:     map[string]interface{}{"scopes": request.Scopes},
+				}
+				return auth.RequireAuthorization(r.Context(), auth.ActionCreate, resource, errors.ErrorForbidden, "only organisation admins can create organisation API tokens")
+			},
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			scopes, err := json.Marshal(request.Scopes)
+			if err != nil {
+				return nil, errors.GeneralError("unable to create API token: %v", err)
+			}
+			token := &api.ApiToken{
+				Name:           request.Name,
+				Kind:           request.Type,
+				OrganisationId: user.organisationId,
+				Owner:          user.username,
+				AccountId:      user.accountId,
+				Scopes:         scopes,
+				ExpiresAt:      *request.ExpiresAt,
+			}
+			value, serviceErr := h.apiTokenService.Create(r.Context(), token)
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+
+			presented := h.present(token)
+			presented.Token = value
+			return presented, nil
+		},
+	}
+
+	Handle(w, r, cfg, http.StatusCreated)
+}
+
+func (h *ApiTokensHandler) Get(w http.ResponseWriter, r *http.Request) {
+	var user apiTokenUser
+	id := mux.Vars(r)["id"]
+	cfg := &HandlerConfig{
+		Validate: []Validate{
+			h.validateUser(r, &user),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			token, err := h.getToken(r, &user, auth.ActionGet, id)
+			if err != nil {
+				return nil, err
+			}
+			return h.present(token), nil
+		},
+	}
+
+	HandleGet(w, r, cfg)
+}
+
+// Delete revokes a token, the requests authenticated with the token are rejected afterwards
+func (h *ApiTokensHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	var user apiTokenUser
+	id := mux.Vars(r)["id"]
+	cfg := &HandlerConfig{
+		Validate: []Validate{
+			h.validateUser(r, &user),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			if _, err := h.getToken(r, &user, auth.ActionDelete, id); err != nil {
+				return nil, err
+			}
+			return nil, h.apiTokenService.Revoke(r.Context(), user.organisationId, user.owner(), id)
+		},
+	}
+
+	HandleDelete(w, r, cfg, http.StatusNoContent)
+}
+
+// getToken returns the token of the user and authorizes the action of the user on it
+func (h *ApiTokensHandler) getToken(r *http.Request, user *apiTokenUser, action string, id string) (*api.ApiToken, *errors.ServiceError) {
+	token, err := h.apiTokenService.Get(r.Context(), user.organisationId, user.owner(), id)
+	if err != nil {
+		return nil, err
+	}
+	resource := auth.AuthorizationResource{
+		Type:           apiTokenResourceType(token.Kind),
+		Id:             token.ID,
+		OrganisationId: token.OrganisationId,
+		Owner:          token.Owner,
+	}
+	if err := auth.RequireAuthorization(r.Context(), action, resource, errors.ErrorForbidden, "user %s not authorized to %s API token %s", user.username, action, id); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// validateUser sets the user from the claims of the request. The API tokens are only managed by the users of an
+// organisation, authenticated with a JWT token, so that an API token can't mint other tokens.
+func (h *ApiTokensHandler) validateUser(r *http.Request, user *apiTokenUser) Validate {
+	return func() *errors.ServiceError {
+		claims, err := auth.GetClaimsFromContext(r.Context())
+		if err != nil {
+			return errors.Unauthenticated("user not authenticated")
+		}
+		if claims.GetApiTokenId() != "" {
+			return errors.Forbidden("API tokens can't be managed with an API token")
+		}
+		orgId, err := claims.GetOrgId()
+		if err != nil || orgId == "" {
+			return errors.Forbidden("API tokens are only available to the users of an organisation")
+		}
+		user.organisationId = orgId
+		user.username, _ = claims.GetUsername()
+		user.accountId, _ = claims.GetAccountId()
+		user.isOrgAdmin = claims.IsOrgAdmin()
+		return nil
+	}
+}
+
+func (h *ApiTokensHandler) validateRequest(request *ApiTokenRequest) Validate {
+	return func() *errors.ServiceError {
+		if request.Name == "" || len(request.Name) > apiTokenNameMaxLength {
+			return errors.BadRequest("name is required and must not exceed %d characters", apiTokenNameMaxLength)
+		}
+		if request.Type == "" {
+			request.Type = api.ApiTokenPersonal
+		}
+		if request.Type != api.ApiTokenPersonal && request.Type != api.ApiTokenOrganisation {
+			return errors.BadRequest("type must be %s or %s", api.ApiTokenPersonal, api.ApiTokenOrganisation)
+		}
+		if len(request.Scopes) == 0 {
+			return errors.BadRequest("scopes must contain at least one scope")
+		}
+		validScopes := auth.ApiTokenScopes()
+		for _, scope := range request.Scopes {
+			if !arrays.Contains(validScopes, scope) {
+				return errors.BadRequest("scope '%s' is not one of %v", scope, validScopes)
+			}
+		}
+
+		now := time.Now()
+		if request.ExpiresAt == nil {
+			expiresAt := now.Add(h.apiTokenConfig.DefaultLifetime)
+			request.ExpiresAt = &expiresAt
+		}
+		if !request.ExpiresAt.After(now) {
+			return errors.BadRequest("expires_at must be in the future")
+		}
+		if request.ExpiresAt.After(now.Add(h.apiTokenConfig.MaxLifetime)) {
+			return errors.BadRequest("expires_at must not be more than %s in the future", h.apiTokenConfig.MaxLifetime)
+		}
+		return nil
+	}
+}
+
+func (h *ApiTokensHandler) present(token *api.ApiToken) *ApiToken {
+	var scopes []string
+	_ = json.Unmarshal(token.Scopes, &scopes)
+	return &ApiToken{
+		Id:          token.ID,
+		Kind:        "ApiToken",
+		Href:        fmt.Sprintf("%s/api_tokens/%s", h.basePath, token.ID),
+		Name:        token.Name,
+		Type:        token.Kind,
+		Scopes:      scopes,
+		Owner:       token.Owner,
+		TokenPrefix: token.TokenPrefix,
+		ExpiresAt:   token.ExpiresAt,
+		LastUsedAt:  token.LastUsedAt,
+		CreatedAt:   token.CreatedAt,
+	}
+}
+
+func apiTokenResourceType(kind string) string {
+	if kind == api.ApiTokenOrganisation {
+		return auth.ResourceOrganisationApiToken
+	}
+	return auth.ResourceApiToken
+}
@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/apitokens"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/gorilla/mux"
+	"github.com/onsi/gomega"
+)
+
+func apiTokensTestContext(claims jwt.MapClaims) context.Context {
+	return auth.SetTokenInContext(context.Background(), &jwt.Token{Claims: claims})
+}
+
+func Test_ApiTokensHandler_Create(t *testing.T) {
+	userClaims := jwt.MapClaims{"username": "test-user", "org_id": "test-org"}
+	adminClaims := jwt.MapClaims{"username": "test-user", "org_id": "test-org", "is_org_admin": true}
+
+	tests := []struct {
+		name           string
+		body           string
+		claims         jwt.MapClaims
+		wantStatusCode int
+		wantType       string
+	}{
+		{
+			name:           "should create a personal token by default",
+			body:           `{"name": "ci", "scopes": ["kafkas:read"]}`,
+			claims:         userClaims,
+			wantStatusCode: http.StatusCreated,
+			wantType:       api.ApiTokenPersonal,
+		},
+		{
+			name:           "should let the organisation admins create organisation tokens",
+			body:           `{"name": "ci", "type": "organisation", "scopes": ["kafkas:write", "connectors:write"]}`,
+			claims:         adminClaims,
+			wantStatusCode: http.StatusCreated,
+			wantType:       api.ApiTokenOrganisation,
+		},
+		{
+			name:           "should only let the organisation admins create organisation tokens",
+			body:           `{"name": "ci", "type": "organisation", "scopes": ["kafkas:write"]}`,
+			claims:         userClaims,
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "should reject the API tokens creating tokens",
+			body:           `{"name": "ci", "scopes": ["kafkas:read"]}`,
+			claims:         jwt.MapClaims{"username": "test-user", "org_id": "test-org", "api_token_id": "token-id"},
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "should require an organisation",
+			body:           `{"name": "ci", "scopes": ["kafkas:read"]}`,
+			claims:         jwt.MapClaims{"username": "test-user"},
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "should reject unknown scopes",
+			body:           `{"name": "ci", "scopes": ["admin"]}`,
+			claims:         userClaims,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should require the scopes",
+			body:           `{"name": "ci"}`,
+			claims:         userClaims,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should require the name",
+			body:           `{"scopes": ["kafkas:read"]}`,
+			claims:         userClaims,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should reject an expiry in the past",
+			body:           fmt.Sprintf(`{"name": "ci", "scopes": ["kafkas:read"], "expires_at": "%s"}`, time.Now().Add(-time.Hour).Format(time.RFC3339)),
+			claims:         userClaims,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should reject an expiry exceeding the maximum lifetime",
+			body:           fmt.Sprintf(`{"name": "ci", "scopes": ["kafkas:read"], "expires_at": "%s"}`, time.Now().Add(100*24*time.Hour).Format(time.RFC3339)),
+			claims:         userClaims,
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			apiTokenService := &apitokens.ApiTokenServiceMock{
+				CreateFunc: func(ctx context.Context, token *api.ApiToken) (string, *errors.ServiceError) {
+					g.Expect(token.OrganisationId).To(gomega.Equal("test-org"))
+					g.Expect(token.Owner).To(gomega.Equal("test-user"))
+					g.Expect(token.Kind).To(gomega.Equal(tt.wantType))
+					token.ID = "token-id"
+					token.TokenPrefix = "kfm_abcdef"
+					return "kfm_abcdefghijklmnop", nil
+				},
+			}
+			req, rw := GetHandlerParams(http.MethodPost, "/api_tokens", strings.NewReader(tt.body), t)
+			req = req.WithContext(apiTokensTestContext(tt.claims))
+			NewApiTokensHandler("/api/kafkas_mgmt/v1", apiTokenService, apitokens.NewApiTokenConfig()).Create(rw, req)
+
+			g.Expect(rw.Code).To(gomega.Equal(tt.wantStatusCode))
+			g.Expect(len(apiTokenService.CreateCalls()) == 1).To(gomega.Equal(tt.wantStatusCode == http.StatusCreated))
+			if tt.wantStatusCode == http.StatusCreated {
+				var token ApiToken
+				g.Expect(json.Unmarshal(rw.Body.Bytes(), &token)).To(gomega.Succeed())
+				g.Expect(token.Href).To(gomega.Equal("/api/kafkas_mgmt/v1/api_tokens/token-id"))
+				g.Expect(token.Token).To(gomega.Equal("kfm_abcdefghijklmnop"))
+				g.Expect(token.ExpiresAt).To(gomega.BeTemporally("~", time.Now().Add(30*24*time.Hour), time.Minute))
+			}
+		})
+	}
+}
+
+func Test_ApiTokensHandler_Get(t *testing.T) {
+	tests := []struct {
+		name      string
+		orgAdmin  bool
+		wantOwner string
+	}{
+		{
+			name:      "should only get the tokens of the user",
+			wantOwner: "test-user",
+		},
+		{
+			name:     "should get any token of the organisation of an organisation admin",
+			orgAdmin: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			apiTokenService := &apitokens.ApiTokenServiceMock{
+				GetFunc: func(ctx context.Context, organisationId string, owner string, id string) (*api.ApiToken, *errors.ServiceError) {
+					g.Expect(organisationId).To(gomega.Equal("test-org"))
+					g.Expect(owner).To(gomega.Equal(tt.wantOwner))
+					return &api.ApiToken{
+						Meta:           api.Meta{ID: id},
+						Kind:           api.ApiTokenPersonal,
+						OrganisationId: organisationId,
+						Owner:          "test-user",
+						Scopes:         api.JSON(`["kafkas:read"]`),
+						TokenHash:      "token-hash",
+					}, nil
+				},
+			}
+			req, rw := GetHandlerParams(http.MethodGet, "/api_tokens/token-id", nil, t)
+			req = mux.SetURLVars(req.WithContext(apiTokensTestContext(jwt.MapClaims{
+				"username": "test-user", "org_id": "test-org", "is_org_admin": tt.orgAdmin,
+			})), map[string]string{"id": "token-id"})
+			NewApiTokensHandler("/api/kafkas_mgmt/v1", apiTokenService, apitokens.NewApiTokenConfig()).Get(rw, req)
+
+			g.Expect(rw.Code).To(gomega.Equal(http.StatusOK))
+			g.Expect(rw.Body.String()).To(gomega.ContainSubstring(`"scopes":["kafkas:read"]`))
+			// neither the token nor its hash are returned once the token is created
+			g.Expect(rw.Body.String()).ToNot(gomega.ContainSubstring("token-hash"))
+			g.Expect(rw.Body.String()).ToNot(gomega.ContainSubstring(`"token"`))
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/audit"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+)
+
+// AuditEventList is a page of the audit events of a service
+type AuditEventList struct {
+	Kind  string            `json:"kind"`
+	Page  int32             `json:"page"`
+	Size  int32             `json:"size"`
+	Total int32             `json:"total"`
+	Items []*api.AuditEvent `json:"items"`
+}
+
+var auditEventCSVHeader = []string{"id", "created_at", "actor", "organisation_id", "method", "route", "path", "resource_id",
+	"status_code", "outcome", "operation_id", "remote_addr", "claims", "request_body", "diff", "authorization"}
+
+// AuditEventsHandler serves the audit events of a service on the admin API
+type AuditEventsHandler struct {
+	service            string
+	auditEventsService audit.AuditEventsService
+}
+
+func NewAuditEventsHandler(service string, auditEventsService audit.AuditEventsService) *AuditEventsHandler {
+	return &AuditEventsHandler{
+		service:            service,
+		auditEventsService: auditEventsService,
+	}
+}
+
+func (h *AuditEventsHandler) List(w http.ResponseWriter, r *http.Request) {
+	var filter audit.AuditEventFilter
+	cfg := &HandlerConfig{
+		Validate: []Validate{
+			h.validateFilter(r, &filter),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			listArgs := services.NewListArguments(r.URL.Query())
+			events, paging, err := h.auditEventsService.List(r.Context(), filter, listArgs)
+			if err != nil {
+				return nil, err
+			}
+
+			return AuditEventList{
+				Kind:  "AuditEventList",
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: events,
+			}, nil
+		},
+	}
+
+	HandleList(w, r, cfg)
+}
+
+// Export streams all the audit events matching the search query as newline delimited JSON, or as CSV when format=csv
+func (h *AuditEventsHandler) Export(w http.ResponseWriter, r *http.Request) {
+	var filter audit.AuditEventFilter
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "ndjson"
+	}
+	for _, validate := range []Validate{
+		h.validateFilter(r, &filter),
+		Validation("format", &format, IsOneOf("ndjson", "csv")),
+	} {
+		if err := validate(); err != nil {
+			shared.HandleError(r, w, err)
+			return
+		}
+	}
+
+	ulog := logger.NewUHCLogger(r.Context())
+	filename := fmt.Sprintf("audit_events_%s_%s.%s", h.service, time.Now().UTC().Format("20060102T150405Z"), format)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	var write func(event *api.AuditEvent) error
+	var flush func() error
+	if format == "csv" {
+		w.Header().Set("Content-Type", "text/csv")
+		csvWriter := csv.NewWriter(w)
+		if err := csvWriter.Write(auditEventCSVHeader); err != nil {
+			ulog.Errorf("failed to export audit events: %v", err)
+			return
+		}
+		write = func(event *api.AuditEvent) error {
+			return csvWriter.Write([]string{event.ID, event.CreatedAt.UTC().Format(time.RFC3339Nano), event.Actor, event.OrganisationId,
+				event.Method, event.Route, event.Path, event.ResourceId, strconv.Itoa(event.StatusCode), event.Outcome,
+				event.OperationId, event.RemoteAddr, string(event.Claims), string(event.RequestBody), string(event.Diff),
+				string(event.Authorization)})
+		}
+		flush = func() error {
+			csvWriter.Flush()
+			return csvWriter.Error()
+		}
+	} else {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		encoder := json.NewEncoder(w)
+		write = func(event *api.AuditEvent) error {
+			return encoder.Encode(event)
+		}
+		flush = func() error { return nil }
+	}
+
+	// the response is streamed, errors can't be returned to the client once the export has started
+	if err := h.auditEventsService.Export(r.Context(), filter, write); err != nil {
+		ulog.Errorf("failed to export audit events: %v", err)
+	}
+	if err := flush(); err != nil {
+		ulog.Errorf("failed to export audit events: %v", err)
+	}
+}
+
+func (h *AuditEventsHandler) validateFilter(r *http.Request, filter *audit.AuditEventFilter) Validate {
+	return func() *errors.ServiceError {
+		query := r.URL.Query()
+		filter.Service = h.service
+		filter.Search = query.Get("search")
+		for name, value := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+			if v := query.Get(name); v != "" {
+				t, err := time.Parse(time.RFC3339, v)
+				if err != nil {
+					return errors.BadRequest("%s must be a RFC 3339 timestamp: %s", name, err)
+				}
+				*value = &t
+			}
+		}
+		return nil
+	}
+}
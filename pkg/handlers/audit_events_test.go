@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/audit"
+	"github.com/onsi/gomega"
+)
+
+var testAuditEvent = &api.AuditEvent{
+	ID:         "event-id",
+	CreatedAt:  time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC),
+	Service:    audit.ServiceKafkas,
+	Actor:      "test user",
+	Method:     http.MethodDelete,
+	Route:      "/api/kafkas_mgmt/v1/admin/kafkas/{id}",
+	Path:       "/api/kafkas_mgmt/v1/admin/kafkas/kafka-id",
+	ResourceId: "kafka-id",
+	StatusCode: http.StatusAccepted,
+	Outcome:    api.AuditOutcomeSuccess,
+}
+
+func Test_AuditEventsHandler_List(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		wantFilter     audit.AuditEventFilter
+		wantStatusCode int
+	}{
+		{
+			name:           "should list the audit events of the service",
+			url:            "/audit_events?search=outcome+%3D+denied",
+			wantFilter:     audit.AuditEventFilter{Service: audit.ServiceKafkas, Search: "outcome = denied"},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "should filter the audit events by creation time",
+			url:  "/audit_events?from=2023-05-01T00:00:00Z",
+			wantFilter: audit.AuditEventFilter{Service: audit.ServiceKafkas,
+				From: func() *time.Time { t := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC); return &t }()},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "should reject timestamps that aren't RFC 3339",
+			url:            "/audit_events?to=yesterday",
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			auditEventsService := &audit.AuditEventsServiceMock{
+				ListFunc: func(ctx context.Context, filter audit.AuditEventFilter, listArgs *services.ListArguments) (api.AuditEventList, *api.PagingMeta, *errors.ServiceError) {
+					g.Expect(filter).To(gomega.Equal(tt.wantFilter))
+					return api.AuditEventList{testAuditEvent}, &api.PagingMeta{Page: 1, Size: 1, Total: 1}, nil
+				},
+			}
+			req, rw := GetHandlerParams(http.MethodGet, tt.url, nil, t)
+			NewAuditEventsHandler(audit.ServiceKafkas, auditEventsService).List(rw, req)
+			g.Expect(rw.Code).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantStatusCode == http.StatusOK {
+				g.Expect(rw.Body.String()).To(gomega.ContainSubstring(`"kind":"AuditEventList"`))
+				g.Expect(rw.Body.String()).To(gomega.ContainSubstring(`"resource_id":"kafka-id"`))
+			}
+		})
+	}
+}
+
+func Test_AuditEventsHandler_Export(t *testing.T) {
+	tests := []struct {
+		name            string
+		url             string
+		wantStatusCode  int
+		wantContentType string
+		wantLines       []string
+	}{
+		{
+			name:            "should export the audit events as newline delimited JSON by default",
+			url:             "/audit_events/export",
+			wantStatusCode:  http.StatusOK,
+			wantContentType: "application/x-ndjson",
+			wantLines:       []string{`{"id":"event-id",`},
+		},
+		{
+			name:            "should export the audit events as CSV",
+			url:             "/audit_events/export?format=csv",
+			wantStatusCode:  http.StatusOK,
+			wantContentType: "text/csv",
+			wantLines: []string{
+				strings.Join(auditEventCSVHeader, ","),
+				"event-id,2023-05-01T00:00:00Z,test user,,DELETE,/api/kafkas_mgmt/v1/admin/kafkas/{id},/api/kafkas_mgmt/v1/admin/kafkas/kafka-id,kafka-id,202,success,,,,,",
+			},
+		},
+		{
+			name:           "should reject unknown formats",
+			url:            "/audit_events/export?format=xml",
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			auditEventsService := &audit.AuditEventsServiceMock{
+				ExportFunc: func(ctx context.Context, filter audit.AuditEventFilter, fn func(event *api.AuditEvent) error) *errors.ServiceError {
+					g.Expect(filter.Service).To(gomega.Equal(audit.ServiceKafkas))
+					if err := fn(testAuditEvent); err != nil {
+						return errors.GeneralError("%v", err)
+					}
+					return nil
+				},
+			}
+			req, rw := GetHandlerParams(http.MethodGet, tt.url, nil, t)
+			NewAuditEventsHandler(audit.ServiceKafkas, auditEventsService).Export(rw, req)
+			g.Expect(rw.Code).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantStatusCode != http.StatusOK {
+				g.Expect(auditEventsService.ExportCalls()).To(gomega.BeEmpty())
+				return
+			}
+			g.Expect(rw.Header().Get("Content-Type")).To(gomega.Equal(tt.wantContentType))
+			g.Expect(rw.Header().Get("Content-Disposition")).To(gomega.HavePrefix(`attachment; filename="audit_events_kafkas_mgmt_`))
+			lines := strings.Split(strings.TrimSpace(rw.Body.String()), "\n")
+			g.Expect(lines).To(gomega.HaveLen(len(tt.wantLines)))
+			for i, line := range tt.wantLines {
+				g.Expect(lines[i]).To(gomega.HavePrefix(line))
+			}
+		})
+	}
+}
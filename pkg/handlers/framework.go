@@ -145,6 +145,36 @@ func HandleGet(w http.ResponseWriter, r *http.Request, cfg *HandlerConfig) {
 	}
 }
 
+// HandleGetCSV is the csv counterpart of HandleGet. The action is expected to return the records of the csv, header included, as a [][]string
+func HandleGetCSV(w http.ResponseWriter, r *http.Request, cfg *HandlerConfig) {
+	if cfg.ErrorHandler == nil {
+		cfg.ErrorHandler = shared.HandleError
+	}
+
+	for _, v := range cfg.Validate {
+		err := v()
+		if err != nil {
+			errorHandler(r, w, cfg, err)
+			return
+		}
+	}
+
+	result, serviceErr := cfg.Action()
+	if serviceErr != nil {
+		errorHandler(r, w, cfg, serviceErr)
+		return
+	}
+
+	records, ok := result.([][]string)
+	if !ok {
+		errorHandler(r, w, cfg, errors.GeneralError("unable to write the result as csv"))
+		return
+	}
+
+	shared.WriteCSVResponse(w, http.StatusOK, records)
+	success(r)
+}
+
 func HandleList(w http.ResponseWriter, r *http.Request, cfg *HandlerConfig) {
 	if cfg.ErrorHandler == nil {
 		cfg.ErrorHandler = shared.HandleError
@@ -319,6 +319,73 @@ func Test_HandleGet(t *testing.T) {
 	}
 }
 
+func Test_HandleGetCSV(t *testing.T) {
+	tests := []struct {
+		name           string
+		cfg            *HandlerConfig
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "should write the records returned by the action as csv",
+			cfg: &HandlerConfig{
+				Action: func() (interface{}, *errors.ServiceError) {
+					return [][]string{{"name", "count"}, {"some-name", "1"}}, nil
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       "name,count\nsome-name,1\n",
+		},
+		{
+			name: "should return an error if the validation fails",
+			cfg: &HandlerConfig{
+				Validate: []Validate{
+					func() *errors.ServiceError {
+						return errors.BadRequest("validation failed")
+					},
+				},
+				Action: func() (interface{}, *errors.ServiceError) {
+					return [][]string{}, nil
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should return an error if an error is returned in the action",
+			cfg: &HandlerConfig{
+				Action: func() (interface{}, *errors.ServiceError) {
+					return nil, errors.NotFound("some action error")
+				},
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "should return an error if the action does not return csv records",
+			cfg: &HandlerConfig{
+				Action: func() (interface{}, *errors.ServiceError) {
+					return "not csv records", nil
+				},
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			req, rw := GetHandlerParams("GET", "/usage", nil, t)
+			HandleGetCSV(rw, req, tt.cfg)
+			g.Expect(rw.Code).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantBody != "" {
+				g.Expect(rw.Header().Get("Content-Type")).To(gomega.Equal("text/csv"))
+				g.Expect(rw.Body.String()).To(gomega.Equal(tt.wantBody))
+			}
+		})
+	}
+}
+
 func Test_HandleList(t *testing.T) {
 	req, rw := GetHandlerParams("GET", "/", nil, t)
 	type args struct {
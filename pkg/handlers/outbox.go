@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/gorilla/mux"
+)
+
+// OutboxMessage is the representation of an outbox message on the admin APIs
+type OutboxMessage struct {
+	Id             string          `json:"id"`
+	Kind           string          `json:"kind"`
+	Type           string          `json:"type"`
+	IdempotencyKey string          `json:"idempotency_key"`
+	Payload        json.RawMessage `json:"payload,omitempty"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	LastAttemptAt  *time.Time      `json:"last_attempt_at,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	ProcessedAt    *time.Time      `json:"processed_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+type OutboxMessageList struct {
+	Kind  string           `json:"kind"`
+	Page  int32            `json:"page"`
+	Size  int32            `json:"size"`
+	Total int32            `json:"total"`
+	Items []*OutboxMessage `json:"items"`
+}
+
+// OutboxHandler serves the outbox messages of the external side effects on the admin API
+type OutboxHandler struct {
+	outbox *db.Outbox
+}
+
+func NewOutboxHandler(outbox *db.Outbox) *OutboxHandler {
+	return &OutboxHandler{
+		outbox: outbox,
+	}
+}
+
+func (h *OutboxHandler) List(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	messageType := r.URL.Query().Get("type")
+	cfg := &HandlerConfig{
+		Validate: []Validate{
+			func() *errors.ServiceError {
+				if status == "" {
+					return nil
+				}
+				return Validation("status", &status, IsOneOf(db.OutboxMessagePending, db.OutboxMessageDone, db.OutboxMessageDead))()
+			},
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			listArgs := services.NewListArguments(r.URL.Query())
+			messages, total, err := h.outbox.List(status, messageType, listArgs.Page, listArgs.Size)
+			if err != nil {
+				return nil, err
+			}
+
+			list := OutboxMessageList{
+				Kind:  "OutboxMessageList",
+				Page:  int32(listArgs.Page),
+				Size:  int32(len(messages)),
+				Total: int32(total),
+				Items: make([]*OutboxMessage, 0, len(messages)),
+			}
+			for _, message := range messages {
+				list.Items = append(list.Items, presentOutboxMessage(message))
+			}
+			return list, nil
+		},
+	}
+
+	HandleList(w, r, cfg)
+}
+
+// Retry sets a dead lettered message pending again, it's executed by the next run of the outbox worker
+func (h *OutboxHandler) Retry(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	cfg := &HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			message, err := h.outbox.Retry(id)
+			if err != nil {
+				return nil, err
+			}
+			return presentOutboxMessage(message), nil
+		},
+	}
+
+	Handle(w, r, cfg, http.StatusAccepted)
+}
+
+func presentOutboxMessage(message *db.OutboxMessage) *OutboxMessage {
+	presented := &OutboxMessage{
+		Id:             message.ID,
+		Kind:           "OutboxMessage",
+		Type:           message.Type,
+		IdempotencyKey: message.IdempotencyKey,
+		Status:         message.Status,
+		Attempts:       message.Attempts,
+		NextAttemptAt:  message.NextAttemptAt,
+		LastAttemptAt:  message.LastAttemptAt,
+		LastError:      message.LastError,
+		ProcessedAt:    message.ProcessedAt,
+		CreatedAt:      message.CreatedAt,
+		UpdatedAt:      message.UpdatedAt,
+	}
+	if message.Payload != "" {
+		presented.Payload = json.RawMessage(message.Payload)
+	}
+	return presented
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/gorilla/mux"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_OutboxHandler_List(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		setupFn        func()
+		wantStatusCode int
+	}{
+		{
+			name: "should list the outbox messages",
+			url:  "/outbox_messages?status=dead",
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				mocket.Catcher.NewMock().WithQuery(`SELECT count(*) FROM "outbox_messages"`).WithReply([]map[string]interface{}{{"count": 1}})
+				mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "outbox_messages"`).WithReply([]map[string]interface{}{{
+					"id":              "message-id",
+					"type":            "kafka.delete_quota",
+					"idempotency_key": "kafka.delete_quota/kafka-id",
+					"payload":         `{"kafka_id":"kafka-id"}`,
+					"status":          db.OutboxMessageDead,
+					"attempts":        20,
+				}})
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "should reject unknown statuses",
+			url:            "/outbox_messages?status=unknown",
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			if tt.setupFn != nil {
+				tt.setupFn()
+			}
+			outbox := db.NewOutbox(db.NewMockConnectionFactory(nil), db.NewOutboxConfig())
+			req, rw := GetHandlerParams(http.MethodGet, tt.url, nil, t)
+			NewOutboxHandler(outbox).List(rw, req)
+			g.Expect(rw.Code).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantStatusCode == http.StatusOK {
+				g.Expect(rw.Body.String()).To(gomega.ContainSubstring(`"kind":"OutboxMessageList"`))
+				g.Expect(rw.Body.String()).To(gomega.ContainSubstring(`"payload":{"kafka_id":"kafka-id"}`))
+			}
+		})
+	}
+}
+
+func Test_OutboxHandler_Retry(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         string
+		wantStatusCode int
+	}{
+		{
+			name:           "should retry a dead lettered message",
+			status:         db.OutboxMessageDead,
+			wantStatusCode: http.StatusAccepted,
+		},
+		{
+			name:           "should not retry a pending message",
+			status:         db.OutboxMessagePending,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should return not found when the message doesn't exist",
+			wantStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			if tt.status != "" {
+				mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "outbox_messages"`).WithReply([]map[string]interface{}{{
+					"id":     "message-id",
+					"type":   "kafka.delete_quota",
+					"status": tt.status,
+				}})
+			}
+			mocket.Catcher.NewMock().WithQuery(`UPDATE "outbox_messages"`)
+			outbox := db.NewOutbox(db.NewMockConnectionFactory(nil), db.NewOutboxConfig())
+
+			req, rw := GetHandlerParams(http.MethodPost, "/outbox_messages/message-id/retry", nil, t)
+			req = mux.SetURLVars(req, map[string]string{"id": "message-id"})
+			NewOutboxHandler(outbox).Retry(rw, req)
+			g.Expect(rw.Code).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantStatusCode == http.StatusAccepted {
+				g.Expect(rw.Body.String()).To(gomega.ContainSubstring(`"status":"pending"`))
+			}
+		})
+	}
+}
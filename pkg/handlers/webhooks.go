@@ -0,0 +1,365 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db/dbencryption"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+	"github.com/gorilla/mux"
+)
+
+const webhookSecretMinLength = 16
+
+// WebhookSubscription is the representation of a webhook subscription on the public APIs
+type WebhookSubscription struct {
+	Id         string    `json:"id"`
+	Kind       string    `json:"kind"`
+	Href       string    `json:"href"`
+	Url        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	Owner      string    `json:"owner"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	// Secret is only returned when the subscription is created
+	Secret string `json:"secret,omitempty"`
+}
+
+// WebhookSubscriptionRequest is the body of the webhook subscription create and update requests,
+// the fields that aren't set are left unchanged by the updates
+type WebhookSubscriptionRequest struct {
+	Url        *string   `json:"url,omitempty"`
+	EventTypes *[]string `json:"event_types,omitempty"`
+	Secret     *string   `json:"secret,omitempty"`
+}
+
+type WebhookSubscriptionList struct {
+	Kind  string                 `json:"kind"`
+	Page  int32                  `json:"page"`
+	Size  int32                  `json:"size"`
+	Total int32                  `json:"total"`
+	Items []*WebhookSubscription `json:"items"`
+}
+
+type WebhookDeliveryList struct {
+	Kind  string                 `json:"kind"`
+	Page  int32                  `json:"page"`
+	Size  int32                  `json:"size"`
+	Total int32                  `json:"total"`
+	Items []*api.WebhookDelivery `json:"items"`
+}
+
+// WebhooksHandler serves the webhook subscriptions of the organisations of the users, which must be organisation admins
+type WebhooksHandler struct {
+	service        string
+	basePath       string
+	webhookService webhooks.WebhookService
+	webhookConfig  *webhooks.WebhookConfig
+}
+
+// NewWebhooksHandler creates a handler for the webhook subscriptions of a service, served under basePath
+func NewWebhooksHandler(service string, basePath string, webhookService webhooks.WebhookService, webhookConfig *webhooks.WebhookConfig) *WebhooksHandler {
+	return &WebhooksHandler{
+		service:        service,
+		basePath:       basePath,
+		webhookService: webhookService,
+		webhookConfig:  webhookConfig,
+	}
+}
+
+func (h *WebhooksHandler) List(w http.ResponseWriter, r *http.Request) {
+	var organisationId string
+	cfg := &HandlerConfig{
+		Validate: []Validate{
+			h.validateOrgAdmin(r, auth.ActionList, "", &organisationId, nil),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			listArgs := services.NewListArguments(r.URL.Query())
+			subscriptions, paging, err := h.webhookService.ListSubscriptions(r.Context(), h.service, organisationId, listArgs)
+			if err != nil {
+				return nil, err
+			}
+
+			list := WebhookSubscriptionList{
+				Kind:  "WebhookSubscriptionList",
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: make([]*WebhookSubscription, 0, len(subscriptions)),
+			}
+			for _, subscription := range subscriptions {
+				list.Items = append(list.Items, h.present(subscription))
+			}
+			return list, nil
+		},
+	}
+
+	HandleList(w, r, cfg)
+}
+
+func (h *WebhooksHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var request WebhookSubscriptionRequest
+	var organisationId, owner string
+	cfg := &HandlerConfig{
+		MarshalInto: &request,
+		Validate: []Validate{
+			h.validateOrgAdmin(r, auth.ActionCreate, "", &organisationId, &owner),
+			func() *errors.ServiceError {
+				if request.Url == nil {
+					return errors.BadRequest("url is required")
+				}
+				if request.EventTypes == nil {
+					return errors.BadRequest("event_types is required")
+				}
+				return nil
+			},
+			h.validateRequest(r, &request),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			secret := ""
+			if request.Secret != nil {
+				secret = *request.Secret
+			} else {
+				generated, err := generateWebhookSecret()
+				if err != nil {
+					return nil, errors.GeneralError("unable to generate the webhook secret: %v", err)
+				}
+				secret = generated
+			}
+			eventTypes, err := json.Marshal(*request.EventTypes)
+			if err != nil {
+				return nil, errors.GeneralError("unable to create webhook subscription: %v", err)
+			}
+
+			subscription := &api.WebhookSubscription{
+				Service:        h.service,
+				OrganisationId: organisationId,
+				Owner:          owner,
+				Url:            *request.Url,
+				EventTypes:     eventTypes,
+				Secret:         dbencryption.EncryptedString(secret),
+			}
+			if err := h.webhookService.CreateSubscription(r.Context(), subscription); err != nil {
+				return nil, err
+			}
+
+			presented := h.present(subscription)
+			presented.Secret = string(subscription.Secret)
+			return presented, nil
+		},
+	}
+
+	Handle(w, r, cfg, http.StatusCreated)
+}
+
+func (h *WebhooksHandler) Get(w http.ResponseWriter, r *http.Request) {
+	var organisationId string
+	id := mux.Vars(r)["id"]
+	cfg := &HandlerConfig{
+		Validate: []Validate{
+			h.validateOrgAdmin(r, auth.ActionGet, id, &organisationId, nil),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			subscription, err := h.webhookService.GetSubscription(r.Context(), h.service, organisationId, id)
+			if err != nil {
+				return nil, err
+			}
+			return h.present(subscription), nil
+		},
+	}
+
+	HandleGet(w, r, cfg)
+}
+
+func (h *WebhooksHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var request WebhookSubscriptionRequest
+	var organisationId string
+	id := mux.Vars(r)["id"]
+	cfg := &HandlerConfig{
+		MarshalInto: &request,
+		Validate: []Validate{
+			h.validateOrgAdmin(r, auth.ActionUpdate, id, &organisationId, nil),
+			h.validateRequest(r, &request),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			subscription, err := h.webhookService.GetSubscription(r.Context(), h.service, organisationId, id)
+			if err != nil {
+				return nil, err
+			}
+			if request.Url != nil {
+				subscription.Url = *request.Url
+			}
+			if request.EventTypes != nil {
+				eventTypes, err := json.Marshal(*request.EventTypes)
+				if err != nil {
+					return nil, errors.GeneralError("unable to update webhook subscription: %v", err)
+				}
+				subscription.EventTypes = eventTypes
+			}
+			if request.Secret != nil {
+				subscription.Secret = dbencryption.EncryptedString(*request.Secret)
+			}
+			if err := h.webhookService.UpdateSubscription(r.Context(), subscription); err != nil {
+				return nil, err
+			}
+			return h.present(subscription), nil
+		},
+	}
+
+	Handle(w, r, cfg, http.StatusOK)
+}
+
+func (h *WebhooksHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	var organisationId string
+	id := mux.Vars(r)["id"]
+	cfg := &HandlerConfig{
+		Validate: []Validate{
+			h.validateOrgAdmin(r, auth.ActionDelete, id, &organisationId, nil),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			return nil, h.webhookService.DeleteSubscription(r.Context(), h.service, organisationId, id)
+		},
+	}
+
+	HandleDelete(w, r, cfg, http.StatusNoContent)
+}
+
+func (h *WebhooksHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	var organisationId string
+	id := mux.Vars(r)["id"]
+	cfg := &HandlerConfig{
+		Validate: []Validate{
+			h.validateOrgAdmin(r, auth.ActionGet, id, &organisationId, nil),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			// the subscription must belong to the organisation of the user
+			if _, err := h.webhookService.GetSubscription(r.Context(), h.service, organisationId, id); err != nil {
+				return nil, err
+			}
+			listArgs := services.NewListArguments(r.URL.Query())
+			deliveries, paging, err := h.webhookService.ListDeliveries(r.Context(), id, listArgs)
+			if err != nil {
+				return nil, err
+			}
+
+			return WebhookDeliveryList{
+				Kind:  "WebhookDeliveryList",
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: deliveries,
+			}, nil
+		},
+	}
+
+	HandleList(w, r, cfg)
+}
+
+func (h *WebhooksHandler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	var organisationId string
+	id := mux.Vars(r)["id"]
+	deliveryId := mux.Vars(r)["delivery_id"]
+	cfg := &HandlerConfig{
+		Validate: []Validate{
+			h.validateOrgAdmin(r, auth.ActionUpdate, id, &organisationId, nil),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			if _, err := h.webhookService.GetSubscription(r.Context(), h.service, organisationId, id); err != nil {
+				return nil, err
+			}
+			return h.webhookService.Redeliver(r.Context(), id, deliveryId)
+		},
+	}
+
+	Handle(w, r, cfg, http.StatusAccepted)
+}
+
+// validateOrgAdmin authorizes the action of the user on the webhook subscription of the id, only the organisation admins are
+// allowed by default. It sets the organisation id and the owner from the claims of the user.
+func (h *WebhooksHandler) validateOrgAdmin(r *http.Request, action string, id string, organisationId *string, owner *string) Validate {
+	return func() *errors.ServiceError {
+		claims, err := auth.GetClaimsFromContext(r.Context())
+		if err != nil {
+			return errors.Unauthenticated("user not authenticated")
+		}
+		orgId, err := claims.GetOrgId()
+		if err != nil || orgId == "" {
+			return errors.Forbidden("webhook subscriptions are only available to the users of an organisation")
+		}
+		resource := auth.AuthorizationResource{Type: auth.ResourceWebhookSubscription, Id: id}
+		if err := auth.RequireAuthorization(r.Context(), action, resource, errors.ErrorForbidden, "only organisation admins can manage webhook subscriptions"); err != nil {
+			return err
+		}
+		*organisationId = orgId
+		if owner != nil {
+			*owner, _ = claims.GetUsername()
+		}
+		return nil
+	}
+}
+
+func (h *WebhooksHandler) validateRequest(r *http.Request, request *WebhookSubscriptionRequest) Validate {
+	return func() *errors.ServiceError {
+		if request.Url != nil {
+			u, err := url.Parse(*request.Url)
+			if err != nil || u.Host == "" {
+				return errors.BadRequest("url must be an absolute URL")
+			}
+			if u.Scheme != "https" && !(u.Scheme == "http" && h.webhookConfig.AllowInsecureURLs) {
+				return errors.BadRequest("url must be an https URL")
+			}
+			// the deliveries check the address again, in case the host is rebound to another address
+			if err := h.webhookService.CheckSubscriptionURL(r.Context(), *request.Url); err != nil {
+				return err
+			}
+		}
+		if request.EventTypes != nil {
+			if len(*request.EventTypes) == 0 {
+				return errors.BadRequest("event_types must contain at least one event type")
+			}
+			validEventTypes := webhooks.EventTypes(h.service)
+			for _, eventType := range *request.EventTypes {
+				if !arrays.Contains(validEventTypes, eventType) {
+					return errors.BadRequest("event type '%s' is not one of %v", eventType, validEventTypes)
+				}
+			}
+		}
+		if request.Secret != nil && len(*request.Secret) < webhookSecretMinLength {
+			return errors.BadRequest("secret must be at least %d characters long", webhookSecretMinLength)
+		}
+		return nil
+	}
+}
+
+func (h *WebhooksHandler) present(subscription *api.WebhookSubscription) *WebhookSubscription {
+	var eventTypes []string
+	_ = json.Unmarshal(subscription.EventTypes, &eventTypes)
+	return &WebhookSubscription{
+		Id:         subscription.ID,
+		Kind:       "WebhookSubscription",
+		Href:       fmt.Sprintf("%s/webhooks/%s", h.basePath, subscription.ID),
+		Url:        subscription.Url,
+		EventTypes: eventTypes,
+		Owner:      subscription.Owner,
+		CreatedAt:  subscription.CreatedAt,
+		UpdatedAt:  subscription.UpdatedAt,
+	}
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
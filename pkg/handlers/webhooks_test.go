@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/gorilla/mux"
+	"github.com/onsi/gomega"
+)
+
+func webhooksTestContext(orgAdmin bool) context.Context {
+	return auth.SetTokenInContext(context.Background(), &jwt.Token{
+		Claims: jwt.MapClaims{
+			"username":     "test-user",
+			"org_id":       "test-org",
+			"is_org_admin": orgAdmin,
+		},
+	})
+}
+
+func Test_WebhooksHandler_Create(t *testing.T) {
+	tests := []struct {
+		name              string
+		body              string
+		orgAdmin          bool
+		allowInsecureURLs bool
+		// nonPublicURL is true if the subscription URL doesn't resolve to public addresses
+		nonPublicURL   bool
+		wantStatusCode int
+		wantSecret     string
+	}{
+		{
+			name:           "should create a subscription with the given secret",
+			body:           `{"url": "https://example.com/hook", "event_types": ["kafka.ready"], "secret": "0123456789abcdef"}`,
+			orgAdmin:       true,
+			wantStatusCode: http.StatusCreated,
+			wantSecret:     "0123456789abcdef",
+		},
+		{
+			name:           "should generate the secret when it isn't given",
+			body:           `{"url": "https://example.com/hook", "event_types": ["kafka.ready", "kafka.failed"]}`,
+			orgAdmin:       true,
+			wantStatusCode: http.StatusCreated,
+		},
+		{
+			name:           "should only allow the organisation admins to create subscriptions",
+			body:           `{"url": "https://example.com/hook", "event_types": ["kafka.ready"]}`,
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "should reject http URLs",
+			body:           `{"url": "http://localhost:8080/hook", "event_types": ["kafka.ready"]}`,
+			orgAdmin:       true,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:              "should accept http URLs when insecure URLs are allowed",
+			body:              `{"url": "http://localhost:8080/hook", "event_types": ["kafka.ready"]}`,
+			orgAdmin:          true,
+			allowInsecureURLs: true,
+			wantStatusCode:    http.StatusCreated,
+		},
+		{
+			name:           "should reject URLs that don't resolve to public addresses",
+			body:           `{"url": "https://kubernetes.default.svc/api", "event_types": ["kafka.ready"]}`,
+			orgAdmin:       true,
+			nonPublicURL:   true,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should reject the event types of other services",
+			body:           `{"url": "https://example.com/hook", "event_types": ["connector.failed"]}`,
+			orgAdmin:       true,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should reject short secrets",
+			body:           `{"url": "https://example.com/hook", "event_types": ["kafka.ready"], "secret": "short"}`,
+			orgAdmin:       true,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should require the event types",
+			body:           `{"url": "https://example.com/hook"}`,
+			orgAdmin:       true,
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			webhookService := &webhooks.WebhookServiceMock{
+				CheckSubscriptionURLFunc: func(ctx context.Context, url string) *errors.ServiceError {
+					if tt.nonPublicURL {
+						return errors.BadRequest("url host isn't a public host name")
+					}
+					return nil
+				},
+				CreateSubscriptionFunc: func(ctx context.Context, subscription *api.WebhookSubscription) *errors.ServiceError {
+					g.Expect(subscription.Service).To(gomega.Equal(webhooks.ServiceKafkas))
+					g.Expect(subscription.OrganisationId).To(gomega.Equal("test-org"))
+					g.Expect(subscription.Owner).To(gomega.Equal("test-user"))
+					subscription.ID = "subscription-id"
+					return nil
+				},
+			}
+			req, rw := GetHandlerParams(http.MethodPost, "/webhooks", strings.NewReader(tt.body), t)
+			req = req.WithContext(webhooksTestContext(tt.orgAdmin))
+			NewWebhooksHandler(webhooks.ServiceKafkas, "/api/kafkas_mgmt/v1", webhookService,
+				&webhooks.WebhookConfig{AllowInsecureURLs: tt.allowInsecureURLs}).Create(rw, req)
+
+			g.Expect(rw.Code).To(gomega.Equal(tt.wantStatusCode))
+			g.Expect(len(webhookService.CreateSubscriptionCalls()) == 1).To(gomega.Equal(tt.wantStatusCode == http.StatusCreated))
+			if tt.wantStatusCode == http.StatusCreated {
+				var subscription WebhookSubscription
+				g.Expect(json.Unmarshal(rw.Body.Bytes(), &subscription)).To(gomega.Succeed())
+				g.Expect(subscription.Href).To(gomega.Equal("/api/kafkas_mgmt/v1/webhooks/subscription-id"))
+				g.Expect(subscription.Secret).ToNot(gomega.BeEmpty())
+				if tt.wantSecret != "" {
+					g.Expect(subscription.Secret).To(gomega.Equal(tt.wantSecret))
+				}
+			}
+		})
+	}
+}
+
+func Test_WebhooksHandler_Get(t *testing.T) {
+	g := gomega.NewWithT(t)
+	webhookService := &webhooks.WebhookServiceMock{
+		GetSubscriptionFunc: func(ctx context.Context, service string, organisationId string, id string) (*api.WebhookSubscription, *errors.ServiceError) {
+			g.Expect(organisationId).To(gomega.Equal("test-org"))
+			return &api.WebhookSubscription{
+				Meta:       api.Meta{ID: id},
+				Url:        "https://example.com/hook",
+				EventTypes: api.JSON(`["kafka.ready"]`),
+				Secret:     "0123456789abcdef",
+			}, nil
+		},
+	}
+	req, rw := GetHandlerParams(http.MethodGet, "/webhooks/subscription-id", nil, t)
+	req = mux.SetURLVars(req.WithContext(webhooksTestContext(true)), map[string]string{"id": "subscription-id"})
+	NewWebhooksHandler(webhooks.ServiceKafkas, "/api/kafkas_mgmt/v1", webhookService, webhooks.NewWebhookConfig()).Get(rw, req)
+
+	g.Expect(rw.Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(rw.Body.String()).To(gomega.ContainSubstring(`"event_types":["kafka.ready"]`))
+	// the secret is only returned when the subscription is created
+	g.Expect(rw.Body.String()).ToNot(gomega.ContainSubstring("secret"))
+}
@@ -80,6 +80,17 @@ const (
 	// ClusterProviderResourceQuotaMaxAllowed - metric name for the maximum allowed resource quota given to a user by a cluster provider (i.e. ocm)
 	ClusterProviderResourceQuotaMaxAllowed = "cluster_provider_resource_quota_max_allowed"
 
+	// CacheRequestCount - metric name for the number of lookups of the caches of the external APIs
+	CacheRequestCount = "cache_request_count"
+	// CacheEvictionCount - metric name for the number of values evicted from the caches once they're full
+	CacheEvictionCount = "cache_eviction_count"
+	// JWKSRefreshCount - metric name for the number of refreshes of the JSON web key sets of the trusted token issuers
+	JWKSRefreshCount = "jwks_refresh_count"
+	// JWKSKeys - metric name for the number of keys of the JSON web key sets of the trusted token issuers
+	JWKSKeys = "jwks_keys"
+	// TokenValidationCount - metric name for the number of tokens of the trusted token issuers validated
+	TokenValidationCount = "token_validation_count"
+
 	// PrewarmingStatusInfoCount - metric name for the total number of prewarmed instances per cluster_id, status and instance type.
 	PrewarmingStatusInfoCount = "prewarmed_kafka_instances"
 
@@ -93,6 +104,12 @@ const (
 	LabelInstanceType        = "instance_type"
 	LabelCloudProvider       = "cloud_provider"
 
+	LabelCache       = "cache"
+	LabelCacheResult = "result"
+
+	LabelIssuer = "issuer"
+	LabelResult = "result"
+
 	LabelQuotaId         = "quota_id"
 	LabelClusterProvider = "cluster_provider"
 
@@ -176,6 +193,11 @@ var DatabaseMetricsLabels = []string{
 	LabelDatabaseQueryType,
 }
 
+var CacheMetricsLabels = []string{
+	LabelCache,
+	LabelCacheResult,
+}
+
 var clusterStatusCapacityLabels = []string{
 	LabelRegion,
 	LabelInstanceType,
@@ -777,6 +799,99 @@ func UpdateDatabaseQueryDurationMetric(status string, queryType string, elapsed
 
 // #### Metrics for Database - End ####
 
+// #### Metrics for Caches ####
+
+// register the cache request count metric
+//
+//	cache_request_count - Number of lookups of a cache partitioned by cache and result
+var cacheRequestCountMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: KasFleetManager,
+	Name:      CacheRequestCount,
+	Help:      "number of lookups of the caches of the external APIs.",
+}, CacheMetricsLabels)
+
+// Increase the cache request count metric with the following labels:
+//   - cache: the name of the cache (i.e. "ams_terms_acceptance")
+//   - result: (i.e. "hit", "negative_hit" or "miss")
+func IncreaseCacheRequestCount(cache string, result string) {
+	labels := prometheus.Labels{
+		LabelCache:       cache,
+		LabelCacheResult: result,
+	}
+	cacheRequestCountMetric.With(labels).Inc()
+}
+
+// register the cache eviction count metric
+//
+//	cache_eviction_count - Number of values evicted from a cache once full, partitioned by cache
+var cacheEvictionCountMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: KasFleetManager,
+	Name:      CacheEvictionCount,
+	Help:      "number of values evicted from the caches of the external APIs once they're full.",
+}, []string{LabelCache})
+
+// Increase the cache eviction count metric of the cache
+func IncreaseCacheEvictionCount(cache string) {
+	cacheEvictionCountMetric.With(prometheus.Labels{LabelCache: cache}).Inc()
+}
+
+// #### Metrics for Caches - End ####
+
+// #### Metrics for Trusted Token Issuers ####
+
+// register the JWKS refresh count metric
+//
+//	jwks_refresh_count - Number of refreshes of the JSON web key set of a trusted issuer partitioned by issuer and result
+var jwksRefreshCountMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: KasFleetManager,
+	Name:      JWKSRefreshCount,
+	Help:      "number of refreshes of the JSON web key sets of the trusted token issuers.",
+}, []string{LabelIssuer, LabelResult})
+
+// Increase the JWKS refresh count metric with the following labels:
+//   - issuer: the issuer of the JSON web key set
+//   - result: "success" or "failure"
+func IncreaseJWKSRefreshCount(issuer string, result string) {
+	jwksRefreshCountMetric.With(prometheus.Labels{LabelIssuer: issuer, LabelResult: result}).Inc()
+}
+
+// register the JWKS keys metric
+//
+//	jwks_keys - Number of keys of the JSON web key set of a trusted issuer, including the rotated keys still accepted
+var jwksKeysMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Subsystem: KasFleetManager,
+	Name:      JWKSKeys,
+	Help:      "number of keys of the JSON web key sets of the trusted token issuers, including the rotated keys still accepted.",
+}, []string{LabelIssuer})
+
+// UpdateJWKSKeysMetric sets the number of keys of the JSON web key set of the issuer
+func UpdateJWKSKeysMetric(issuer string, keys int) {
+	jwksKeysMetric.With(prometheus.Labels{LabelIssuer: issuer}).Set(float64(keys))
+}
+
+// DeleteJWKSKeysMetric removes the number of keys of an issuer no longer trusted
+func DeleteJWKSKeysMetric(issuer string) {
+	jwksKeysMetric.Delete(prometheus.Labels{LabelIssuer: issuer})
+}
+
+// register the token validation count metric
+//
+//	token_validation_count - Number of tokens of a trusted issuer validated partitioned by issuer and result
+var tokenValidationCountMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: KasFleetManager,
+	Name:      TokenValidationCount,
+	Help:      "number of tokens of the trusted token issuers validated.",
+}, []string{LabelIssuer, LabelResult})
+
+// Increase the token validation count metric with the following labels:
+//   - issuer: the issuer of the token
+//   - result: "valid", "unknown_key", "invalid_audience" or "invalid"
+func IncreaseTokenValidationCount(issuer string, result string) {
+	tokenValidationCountMetric.With(prometheus.Labels{LabelIssuer: issuer, LabelResult: result}).Inc()
+}
+
+// #### Metrics for Trusted Token Issuers - End ####
+
 // create a new gaugeVec for the prewarming status info count per cluster_id, instance_type and status.
 var prewarmingStatusInfoCountMetric = prometheus.NewGaugeVec(
 	prometheus.GaugeOpts{
@@ -847,6 +962,15 @@ func init() {
 	// metrics for database
 	prometheus.MustRegister(databaseRequestCountMetric)
 	prometheus.MustRegister(databaseQueryDurationMetric)
+
+	// metrics for caches
+	prometheus.MustRegister(cacheRequestCountMetric)
+	prometheus.MustRegister(cacheEvictionCountMetric)
+
+	// metrics for trusted token issuers
+	prometheus.MustRegister(jwksRefreshCountMetric)
+	prometheus.MustRegister(jwksKeysMetric)
+	prometheus.MustRegister(tokenValidationCountMetric)
 }
 
 // ResetMetricsForKafkaManagers will reset the metrics for the KafkaManager background reconciler
@@ -916,4 +1040,10 @@ func Reset() {
 
 	databaseRequestCountMetric.Reset()
 	databaseQueryDurationMetric.Reset()
+
+	cacheRequestCountMetric.Reset()
+	cacheEvictionCountMetric.Reset()
+	jwksRefreshCountMetric.Reset()
+	jwksKeysMetric.Reset()
+	tokenValidationCountMetric.Reset()
 }
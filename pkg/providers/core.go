@@ -8,6 +8,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/observatorium"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/ocm"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/cmd/migrate"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/cmd/reencrypt"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/cmd/serve"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
@@ -15,10 +16,14 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/server"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/account"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/apitokens"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/audit"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/authorization"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/encryption"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sentry"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
 	"github.com/goava/di"
 )
@@ -32,24 +37,33 @@ func CoreConfigProviders() di.Option {
 		// Add config types
 		di.Provide(server.NewHealthCheckConfig, di.As(new(environments.ConfigModule))),
 		di.Provide(db.NewDatabaseConfig, di.As(new(environments.ConfigModule))),
+		di.Provide(db.NewOutboxConfig, di.As(new(environments.ConfigModule))),
 		di.Provide(server.NewServerConfig, di.As(new(environments.ConfigModule))),
 		di.Provide(ocm.NewOCMConfig, di.As(new(environments.ConfigModule))),
+		di.Provide(ocm.NewAMSCacheConfig, di.As(new(environments.ConfigModule))),
 		di.Provide(keycloak.NewKeycloakConfig, di.As(new(environments.ConfigModule)), di.As(new(environments.ServiceValidator))),
 		di.Provide(acl.NewAccessControlListConfig, di.As(new(environments.ConfigModule))),
 		di.Provide(server.NewMetricsConfig, di.As(new(environments.ConfigModule))),
 		di.Provide(workers.NewReconcilerConfig, di.As(new(environments.ConfigModule))),
 		di.Provide(auth.NewContextConfig, di.As(new(environments.ConfigModule))),
 		di.Provide(auth.NewAdminAuthZConfig, di.As(new(environments.ConfigModule)), di.As(new(environments.ServiceValidator))),
+		di.Provide(auth.NewAuthorizationConfig, di.As(new(environments.ConfigModule))),
+		di.Provide(auth.NewTrustedIssuersConfig, di.As(new(environments.ConfigModule))),
 
 		// Add common CLI sub commands
 		di.Provide(serve.NewServeCommand),
 		di.Provide(migrate.NewMigrateCommand),
+		di.Provide(reencrypt.NewReencryptCommand),
 
 		// Add other core config providers..
 		sentry.ConfigProviders(),
 		signalbus.ConfigProviders(),
 		authorization.ConfigProviders(),
 		account.ConfigProviders(),
+		audit.ConfigProviders(),
+		webhooks.ConfigProviders(),
+		apitokens.ConfigProviders(),
+		encryption.ConfigProviders(),
 
 		di.Provide(environments.Func(ServiceProviders)),
 	)
@@ -60,6 +74,7 @@ func ServiceProviders() di.Option {
 
 		// provide the service constructors
 		di.Provide(db.NewConnectionFactory),
+		di.Provide(db.NewOutbox),
 		di.Provide(observatorium.NewObservatoriumClient),
 
 		di.Provide(func(config *ocm.OCMConfig) ocm.ClusterManagementClient {
@@ -70,13 +85,15 @@ func ServiceProviders() di.Option {
 			return ocm.NewClient(conn)
 		}),
 
-		di.Provide(func(config *ocm.OCMConfig) ocm.AMSClient {
+		di.Provide(func(config *ocm.OCMConfig, cacheConfig *ocm.AMSCacheConfig) ocm.CachingAMSClient {
 			conn, _, err := ocm.NewOCMConnection(config, config.AmsUrl)
 			if err != nil {
 				logger.Logger.Error(err)
 			}
-			return ocm.NewClient(conn)
+			return ocm.NewCachingAMSClient(ocm.NewClient(conn), cacheConfig)
 		}),
+		di.Provide(func(client ocm.CachingAMSClient) ocm.AMSClient { return client }),
+		di.Provide(func(client ocm.CachingAMSClient) ocm.AMSCacheInvalidator { return client }),
 
 		di.Provide(aws.NewDefaultClientFactory, di.As(new(aws.ClientFactory))),
 
@@ -101,5 +118,6 @@ func ServiceProviders() di.Option {
 		di.Provide(server.NewMetricsServer, di.As(new(environments.BootService))),
 		di.Provide(server.NewHealthCheckServer, di.As(new(environments.BootService))),
 		di.Provide(workers.NewLeaderElectionManager, di.As(new(environments.BootService))),
+		di.Provide(auth.NewJWKSManager, di.As(new(environments.BootService))),
 	)
 }
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/keycloak"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
 
@@ -47,6 +48,9 @@ type ServerOptions struct {
 	RouteLoaders    []environments.RouteLoader
 	Env             *environments.Env
 	ReadyConditions []ApiServerReadyCondition `di:"optional"`
+	TrustedIssuers  *auth.TrustedIssuersConfig
+	JWKSManager     *auth.JWKSManager
+	ApiTokens       auth.ApiTokenResolver
 }
 
 func NewAPIServer(options ServerOptions) *ApiServer {
@@ -95,6 +99,11 @@ func NewAPIServer(options ServerOptions) *ApiServer {
 	mainHandler, err = builder.Next(mainHandler).Build()
 	check(err, "unable to create authentication handler", options.SentryConfig.Timeout)
 
+	// the tokens of the trusted issuers are authenticated before the tokens of the primary issuer
+	mainHandler = auth.NewTrustedIssuersAuthenticationHandler(options.TrustedIssuers, options.JWKSManager, mainRouter, mainHandler)
+	// the API tokens minted by the fleet manager aren't JWT tokens, they're resolved before the JWT tokens are validated
+	mainHandler = auth.NewApiTokenAuthenticationHandler(options.ApiTokens, mainRouter, mainHandler)
+
 	mainHandler = gorillahandlers.CORS(
 		gorillahandlers.AllowedMethods([]string{
 			http.MethodDelete,
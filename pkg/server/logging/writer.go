@@ -24,7 +24,8 @@ func (writer *loggingWriter) Flush() {
 }
 
 func (writer *loggingWriter) Write(body []byte) (int, error) {
-	writer.responseBody = body
+	// the body is copied, the caller may reuse its buffer once written
+	writer.responseBody = append([]byte(nil), body...)
 	return writer.ResponseWriter.Write(body)
 }
 
@@ -56,6 +57,10 @@ func (writer *loggingWriter) GetResponseStatusCode() int {
 	return writer.responseStatus
 }
 
+func (writer *loggingWriter) GetResponseBody() []byte {
+	return writer.responseBody
+}
+
 func (writer *loggingWriter) prepareRequestLog() (string, error) {
 	return writer.formatter.FormatRequestLog(writer.request)
 }
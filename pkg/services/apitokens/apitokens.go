@@ -0,0 +1,230 @@
+package apitokens
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/queryparser"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/cache"
+	"gorm.io/gorm"
+)
+
+// ApiTokensCacheName is the name of the cache of the resolved API tokens, it's the cache label of the cache metrics
+const ApiTokensCacheName = "api_tokens"
+
+// apiTokenPrefixLength is the length of the beginning of the tokens stored to let the owners recognize their tokens
+const apiTokenPrefixLength = len(auth.ApiTokenPrefix) + 6
+
+//go:generate moq -out apitokens_moq.go . ApiTokenService
+
+// ApiTokenService mints, lists and revokes the API tokens of the organisations, and resolves the tokens of the requests
+type ApiTokenService interface {
+	auth.ApiTokenResolver
+
+	// List returns the tokens of the organisation, only the tokens of the owner when the owner is given
+	List(ctx context.Context, organisationId string, owner string, listArgs *services.ListArguments) (api.ApiTokenList, *api.PagingMeta, *errors.ServiceError)
+	// Get returns the token of the organisation, it must be owned by the owner when the owner is given
+	Get(ctx context.Context, organisationId string, owner string, id string) (*api.ApiToken, *errors.ServiceError)
+	// Create mints a token and stores its hash, it returns the token which can't be retrieved anymore afterwards
+	Create(ctx context.Context, token *api.ApiToken) (string, *errors.ServiceError)
+	// Revoke deletes the token of the organisation, it must be owned by the owner when the owner is given
+	Revoke(ctx context.Context, organisationId string, owner string, id string) *errors.ServiceError
+}
+
+var _ ApiTokenService = &apiTokenService{}
+
+type apiTokenService struct {
+	connectionFactory *db.ConnectionFactory
+	apiTokenConfig    *ApiTokenConfig
+	tokens            *cache.Cache[string, *api.ApiToken]
+	now               func() time.Time
+}
+
+func NewApiTokenService(connectionFactory *db.ConnectionFactory, apiTokenConfig *ApiTokenConfig) *apiTokenService {
+	return &apiTokenService{
+		connectionFactory: connectionFactory,
+		apiTokenConfig:    apiTokenConfig,
+		tokens: cache.New[string](ApiTokensCacheName, cache.Options[*api.ApiToken]{
+			TTL:         apiTokenConfig.CacheTTL,
+			NegativeTTL: apiTokenConfig.NegativeCacheTTL,
+			// the unknown tokens are negative values, the lookup errors aren't cached so that the next request retries them
+			IsNegative: func(token *api.ApiToken) bool { return token == nil },
+			SkipErrors: true,
+		}),
+		now: time.Now,
+	}
+}
+
+func GetValidApiTokenColumns() []string {
+	return []string{"name", "kind", "owner", "expires_at", "last_used_at", "created_at"}
+}
+
+func (s *apiTokenService) List(ctx context.Context, organisationId string, owner string, listArgs *services.ListArguments) (api.ApiTokenList, *api.PagingMeta, *errors.ServiceError) {
+	var resourceList api.ApiTokenList
+	dbConn := s.connectionFactory.New().Where(s.scope(organisationId, owner))
+	pagingMeta, serr := list(dbConn, listArgs, GetValidApiTokenColumns(), "API tokens", &resourceList)
+	return resourceList, pagingMeta, serr
+}
+
+func (s *apiTokenService) Get(ctx context.Context, organisationId string, owner string, id string) (*api.ApiToken, *errors.ServiceError) {
+	var token api.ApiToken
+	if err := s.connectionFactory.New().Where("id = ?", id).Where(s.scope(organisationId, owner)).First(&token).Error; err != nil {
+		return nil, services.HandleGetError("API token", "id", id, err)
+	}
+	return &token, nil
+}
+
+func (s *apiTokenService) Create(ctx context.Context, token *api.ApiToken) (string, *errors.ServiceError) {
+	dbConn := s.connectionFactory.New()
+	var count int64
+	if err := dbConn.Model(&api.ApiToken{}).Where("organisation_id = ?", token.OrganisationId).Count(&count).Error; err != nil {
+		return "", services.HandleCreateError("API token", err)
+	}
+	if count >= int64(s.apiTokenConfig.MaxTokensPerOrganisation) {
+		return "", errors.Forbidden("the organisation reached the maximum of %d API tokens, revoke unused tokens to create new ones", s.apiTokenConfig.MaxTokensPerOrganisation)
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", errors.GeneralError("unable to generate the API token: %v", err)
+	}
+	value := auth.ApiTokenPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	token.TokenHash = hashApiToken(value)
+	token.TokenPrefix = value[:apiTokenPrefixLength]
+	if err := dbConn.Create(token).Error; err != nil {
+		return "", services.HandleCreateError("API token", err)
+	}
+	return value, nil
+}
+
+func (s *apiTokenService) Revoke(ctx context.Context, organisationId string, owner string, id string) *errors.ServiceError {
+	token, serviceErr := s.Get(ctx, organisationId, owner, id)
+	if serviceErr != nil {
+		return serviceErr
+	}
+	if err := s.connectionFactory.New().Delete(token).Error; err != nil {
+		return services.HandleDeleteError("API token", "id", id, err)
+	}
+	s.tokens.Invalidate(token.TokenHash)
+	return nil
+}
+
+func (s *apiTokenService) ResolveApiToken(ctx context.Context, value string) (*api.ApiToken, *errors.ServiceError) {
+	hash := hashApiToken(value)
+	loaded := false
+	token, err := s.tokens.Get(hash, func() (*api.ApiToken, error) {
+		loaded = true
+		var token api.ApiToken
+		if err := s.connectionFactory.New().Where("token_hash = ?", hash).First(&token).Error; err != nil {
+			if services.IsRecordNotFoundError(err) {
+				return nil, nil
+			}
+			return nil, services.HandleGetError("API token", "token", "<redacted>", err)
+		}
+		return &token, nil
+	})
+	if err != nil {
+		return nil, errors.ToServiceError(err)
+	}
+	if token == nil {
+		return nil, errors.Unauthenticated("unknown or revoked API token")
+	}
+	// the tokens revoked by the other replicas are still cached by this one, the revocation is checked in the database
+	if !loaded {
+		revoked, serviceErr := s.isRevoked(token)
+		if serviceErr != nil {
+			return nil, serviceErr
+		}
+		if revoked {
+			s.tokens.Invalidate(hash)
+			return nil, errors.Unauthenticated("unknown or revoked API token")
+		}
+	}
+
+	now := s.now()
+	if !now.Before(token.ExpiresAt) {
+		return nil, errors.Unauthenticated("API token %s expired at %s", token.ID, token.ExpiresAt.Format(time.RFC3339))
+	}
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= s.apiTokenConfig.LastUsedUpdateInterval {
+		if err := s.connectionFactory.New().Model(&api.ApiToken{}).Where("id = ?", token.ID).
+			UpdateColumn("last_used_at", now).Error; err != nil {
+			logger.NewUHCLogger(ctx).Errorf("unable to update the last used timestamp of API token %s: %v", token.ID, err)
+		}
+		// the cached token is reloaded with its new timestamp, so that the next requests don't update it again
+		s.tokens.Invalidate(hash)
+	}
+	return token, nil
+}
+
+// isRevoked returns whether the token was deleted since it was cached
+func (s *apiTokenService) isRevoked(token *api.ApiToken) (bool, *errors.ServiceError) {
+	var count int64
+	if err := s.connectionFactory.New().Model(&api.ApiToken{}).Where("id = ?", token.ID).Count(&count).Error; err != nil {
+		return false, errors.NewWithCause(errors.ErrorGeneral, err, "unable to check the revocation of API token %s", token.ID)
+	}
+	return count == 0, nil
+}
+
+// scope restricts the tokens to the organisation, and to the owner when it's given
+func (s *apiTokenService) scope(organisationId string, owner string) *gorm.DB {
+	dbConn := s.connectionFactory.New().Where("organisation_id = ?", organisationId)
+	if owner != "" {
+		dbConn = dbConn.Where("owner = ?", owner)
+	}
+	return dbConn
+}
+
+func hashApiToken(value string) string {
+	hash := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(hash[:])
+}
+
+// list returns a page of the resources matching the search query of the list arguments, most recent first by default
+func list(dbConn *gorm.DB, listArgs *services.ListArguments, columns []string, resourceType string, resourceList interface{}) (*api.PagingMeta, *errors.ServiceError) {
+	pagingMeta := &api.PagingMeta{
+		Page: listArgs.Page,
+		Size: listArgs.Size,
+	}
+	if err := listArgs.Validate(columns); err != nil {
+		return pagingMeta, errors.NewWithCause(errors.ErrorMalformedRequest, err, "unable to list %s: %s", resourceType, err.Error())
+	}
+	if len(listArgs.Search) > 0 {
+		queryParser := queryparser.NewQueryParser(columns...)
+		searchDbQuery, err := queryParser.Parse(listArgs.Search)
+		if err != nil {
+			return pagingMeta, errors.NewWithCause(errors.ErrorFailedToParseSearch, err, "unable to list %s: %s", resourceType, err.Error())
+		}
+		dbConn = dbConn.Where(searchDbQuery.Query, searchDbQuery.Values...)
+	}
+
+	total := int64(pagingMeta.Total)
+	dbConn.Session(&gorm.Session{}).Model(resourceList).Count(&total)
+	pagingMeta.Total = int(total)
+	if pagingMeta.Size > pagingMeta.Total {
+		pagingMeta.Size = pagingMeta.Total
+	}
+	dbConn = dbConn.Offset((pagingMeta.Page - 1) * pagingMeta.Size).Limit(pagingMeta.Size)
+
+	if len(listArgs.OrderBy) == 0 {
+		dbConn = dbConn.Order("created_at desc")
+	}
+	for _, orderByArg := range listArgs.OrderBy {
+		dbConn = dbConn.Order(orderByArg)
+	}
+
+	if err := dbConn.Find(resourceList).Error; err != nil {
+		return pagingMeta, errors.GeneralError("unable to list %s: %s", resourceType, fmt.Sprint(err))
+	}
+	return pagingMeta, nil
+}
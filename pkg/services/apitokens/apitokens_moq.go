@@ -0,0 +1,320 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package apitokens
+
+import (
+	"context"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"sync"
+)
+
+// Ensure, that ApiTokenServiceMock does implement ApiTokenService.
+// If this is not the case, regenerate this file with moq.
+var _ ApiTokenService = &ApiTokenServiceMock{}
+
+// ApiTokenServiceMock is a mock implementation of ApiTokenService.
+//
+//	func TestSomethingThatUsesApiTokenService(t *testing.T) {
+//
+//		// make and configure a mocked ApiTokenService
+//		mockedApiTokenService := &ApiTokenServiceMock{
+//			CreateFunc: func(ctx context.Context, token *api.ApiToken) (string, *errors.ServiceError) {
+//				panic("mock out the Create method")
+//			},
+//			GetFunc: func(ctx context.Context, organisationId string, owner string, id string) (*api.ApiToken, *errors.ServiceError) {
+//				panic("mock out the Get method")
+//			},
+//			ListFunc: func(ctx context.Context, organisationId string, owner string, listArgs *services.ListArguments) (api.ApiTokenList, *api.PagingMeta, *errors.ServiceError) {
+//				panic("mock out the List method")
+//			},
+//			ResolveApiTokenFunc: func(ctx context.Context, token string) (*api.ApiToken, *errors.ServiceError) {
+//				panic("mock out the ResolveApiToken method")
+//			},
+//			RevokeFunc: func(ctx context.Context, organisationId string, owner string, id string) *errors.ServiceError {
+//				panic("mock out the Revoke method")
+//			},
+//		}
+//
+//		// use mockedApiTokenService in code that requires ApiTokenService
+//		// and then make assertions.
+//
+//	}
+type ApiTokenServiceMock struct {
+	// CreateFunc mocks the Create method.
+	CreateFunc func(ctx context.Context, token *api.ApiToken) (string, *errors.ServiceError)
+
+	// GetFunc mocks the Get method.
+	GetFunc func(ctx context.Context, organisationId string, owner string, id string) (*api.ApiToken, *errors.ServiceError)
+
+	// ListFunc mocks the List method.
+	ListFunc func(ctx context.Context, organisationId string, owner string, listArgs *services.ListArguments) (api.ApiTokenList, *api.PagingMeta, *errors.ServiceError)
+
+	// ResolveApiTokenFunc mocks the ResolveApiToken method.
+	ResolveApiTokenFunc func(ctx context.Context, token string) (*api.ApiToken, *errors.ServiceError)
+
+	// RevokeFunc mocks the Revoke method.
+	RevokeFunc func(ctx context.Context, organisationId string, owner string, id string) *errors.ServiceError
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Create holds details about calls to the Create method.
+		Create []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Token is the token argument value.
+			Token *api.ApiToken
+		}
+		// Get holds details about calls to the Get method.
+		Get []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrganisationId is the organisationId argument value.
+			OrganisationId string
+			// Owner is the owner argument value.
+			Owner string
+			// ID is the id argument value.
+			ID string
+		}
+		// List holds details about calls to the List method.
+		List []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrganisationId is the organisationId argument value.
+			OrganisationId string
+			// Owner is the owner argument value.
+			Owner string
+			// ListArgs is the listArgs argument value.
+			ListArgs *services.ListArguments
+		}
+		// ResolveApiToken holds details about calls to the ResolveApiToken method.
+		ResolveApiToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Token is the token argument value.
+			Token string
+		}
+		// Revoke holds details about calls to the Revoke method.
+		Revoke []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrganisationId is the organisationId argument value.
+			OrganisationId string
+			// Owner is the owner argument value.
+			Owner string
+			// ID is the id argument value.
+			ID string
+		}
+	}
+	lockCreate          sync.RWMutex
+	lockGet             sync.RWMutex
+	lockList            sync.RWMutex
+	lockResolveApiToken sync.RWMutex
+	lockRevoke          sync.RWMutex
+}
+
+// Create calls CreateFunc.
+func (mock *ApiTokenServiceMock) Create(ctx context.Context, token *api.ApiToken) (string, *errors.ServiceError) {
+	if mock.CreateFunc == nil {
+		panic("ApiTokenServiceMock.CreateFunc: method is nil but ApiTokenService.Create was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Token *api.ApiToken
+	}{
+		Ctx:   ctx,
+		Token: token,
+	}
+	mock.lockCreate.Lock()
+	mock.calls.Create = append(mock.calls.Create, callInfo)
+	mock.lockCreate.Unlock()
+	return mock.CreateFunc(ctx, token)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedApiTokenService.CreateCalls())
+func (mock *ApiTokenServiceMock) CreateCalls() []struct {
+	Ctx   context.Context
+	Token *api.ApiToken
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Token *api.ApiToken
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
+
+// Get calls GetFunc.
+func (mock *ApiTokenServiceMock) Get(ctx context.Context, organisationId string, owner string, id string) (*api.ApiToken, *errors.ServiceError) {
+	if mock.GetFunc == nil {
+		panic("ApiTokenServiceMock.GetFunc: method is nil but ApiTokenService.Get was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		OrganisationId string
+		Owner          string
+		ID             string
+	}{
+		Ctx:            ctx,
+		OrganisationId: organisationId,
+		Owner:          owner,
+		ID:             id,
+	}
+	mock.lockGet.Lock()
+	mock.calls.Get = append(mock.calls.Get, callInfo)
+	mock.lockGet.Unlock()
+	return mock.GetFunc(ctx, organisationId, owner, id)
+}
+
+// GetCalls gets all the calls that were made to Get.
+// Check the length with:
+//
+//	len(mockedApiTokenService.GetCalls())
+func (mock *ApiTokenServiceMock) GetCalls() []struct {
+	Ctx            context.Context
+	OrganisationId string
+	Owner          string
+	ID             string
+} {
+	var calls []struct {
+		Ctx            context.Context
+		OrganisationId string
+		Owner          string
+		ID             string
+	}
+	mock.lockGet.RLock()
+	calls = mock.calls.Get
+	mock.lockGet.RUnlock()
+	return calls
+}
+
+// List calls ListFunc.
+func (mock *ApiTokenServiceMock) List(ctx context.Context, organisationId string, owner string, listArgs *services.ListArguments) (api.ApiTokenList, *api.PagingMeta, *errors.ServiceError) {
+	if mock.ListFunc == nil {
+		panic("ApiTokenServiceMock.ListFunc: method is nil but ApiTokenService.List was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		OrganisationId string
+		Owner          string
+		ListArgs       *services.ListArguments
+	}{
+		Ctx:            ctx,
+		OrganisationId: organisationId,
+		Owner:          owner,
+		ListArgs:       listArgs,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc(ctx, organisationId, owner, listArgs)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedApiTokenService.ListCalls())
+func (mock *ApiTokenServiceMock) ListCalls() []struct {
+	Ctx            context.Context
+	OrganisationId string
+	Owner          string
+	ListArgs       *services.ListArguments
+} {
+	var calls []struct {
+		Ctx            context.Context
+		OrganisationId string
+		Owner          string
+		ListArgs       *services.ListArguments
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// ResolveApiToken calls ResolveApiTokenFunc.
+func (mock *ApiTokenServiceMock) ResolveApiToken(ctx context.Context, token string) (*api.ApiToken, *errors.ServiceError) {
+	if mock.ResolveApiTokenFunc == nil {
+		panic("ApiTokenServiceMock.ResolveApiTokenFunc: method is nil but ApiTokenService.ResolveApiToken was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Token string
+	}{
+		Ctx:   ctx,
+		Token: token,
+	}
+	mock.lockResolveApiToken.Lock()
+	mock.calls.ResolveApiToken = append(mock.calls.ResolveApiToken, callInfo)
+	mock.lockResolveApiToken.Unlock()
+	return mock.ResolveApiTokenFunc(ctx, token)
+}
+
+// ResolveApiTokenCalls gets all the calls that were made to ResolveApiToken.
+// Check the length with:
+//
+//	len(mockedApiTokenService.ResolveApiTokenCalls())
+func (mock *ApiTokenServiceMock) ResolveApiTokenCalls() []struct {
+	Ctx   context.Context
+	Token string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Token string
+	}
+	mock.lockResolveApiToken.RLock()
+	calls = mock.calls.ResolveApiToken
+	mock.lockResolveApiToken.RUnlock()
+	return calls
+}
+
+// Revoke calls RevokeFunc.
+func (mock *ApiTokenServiceMock) Revoke(ctx context.Context, organisationId string, owner string, id string) *errors.ServiceError {
+	if mock.RevokeFunc == nil {
+		panic("ApiTokenServiceMock.RevokeFunc: method is nil but ApiTokenService.Revoke was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		OrganisationId string
+		Owner          string
+		ID             string
+	}{
+		Ctx:            ctx,
+		OrganisationId: organisationId,
+		Owner:          owner,
+		ID:             id,
+	}
+	mock.lockRevoke.Lock()
+	mock.calls.Revoke = append(mock.calls.Revoke, callInfo)
+	mock.lockRevoke.Unlock()
+	return mock.RevokeFunc(ctx, organisationId, owner, id)
+}
+
+// RevokeCalls gets all the calls that were made to Revoke.
+// Check the length with:
+//
+//	len(mockedApiTokenService.RevokeCalls())
+func (mock *ApiTokenServiceMock) RevokeCalls() []struct {
+	Ctx            context.Context
+	OrganisationId string
+	Owner          string
+	ID             string
+} {
+	var calls []struct {
+		Ctx            context.Context
+		OrganisationId string
+		Owner          string
+		ID             string
+	}
+	mock.lockRevoke.RLock()
+	calls = mock.calls.Revoke
+	mock.lockRevoke.RUnlock()
+	return calls
+}
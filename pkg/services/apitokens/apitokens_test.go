@@ -0,0 +1,216 @@
+package apitokens
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func TestApiTokenConfig_ReadFiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(config *ApiTokenConfig)
+		wantErr bool
+	}{
+		{
+			name:   "should accept the default configuration",
+			modify: func(config *ApiTokenConfig) {},
+		},
+		{
+			name: "should reject a default lifetime exceeding the maximum lifetime",
+			modify: func(config *ApiTokenConfig) {
+				config.DefaultLifetime = config.MaxLifetime + time.Hour
+			},
+			wantErr: true,
+		},
+		{
+			name: "should reject a zero lifetime",
+			modify: func(config *ApiTokenConfig) {
+				config.DefaultLifetime = 0
+			},
+			wantErr: true,
+		},
+		{
+			name: "should reject a negative cache TTL",
+			modify: func(config *ApiTokenConfig) {
+				config.CacheTTL = -time.Second
+			},
+			wantErr: true,
+		},
+		{
+			name: "should reject a negative negative cache TTL",
+			modify: func(config *ApiTokenConfig) {
+				config.NegativeCacheTTL = -time.Second
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			config := NewApiTokenConfig()
+			tt.modify(config)
+			g.Expect(config.ReadFiles() != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
+
+func TestApiTokenService_ResolveApiToken(t *testing.T) {
+	now := time.Date(2023, 6, 12, 10, 0, 0, 0, time.UTC)
+	recentlyUsed := now.Add(-time.Minute)
+
+	tests := []struct {
+		name           string
+		token          map[string]interface{}
+		wantCode       int
+		wantLastUsedAt bool
+	}{
+		{
+			name: "should resolve a valid token",
+			token: map[string]interface{}{
+				"id": "token-id", "organisation_id": "org-id", "expires_at": now.Add(time.Hour), "last_used_at": recentlyUsed,
+			},
+		},
+		{
+			name: "should update the last used timestamp of a token not used recently",
+			token: map[string]interface{}{
+				"id": "token-id", "organisation_id": "org-id", "expires_at": now.Add(time.Hour),
+			},
+			wantLastUsedAt: true,
+		},
+		{
+			name: "should reject an expired token",
+			token: map[string]interface{}{
+				"id": "token-id", "organisation_id": "org-id", "expires_at": now.Add(-time.Second), "last_used_at": recentlyUsed,
+			},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "should reject an unknown or revoked token",
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			var reply []map[string]interface{}
+			if tt.token != nil {
+				reply = []map[string]interface{}{tt.token}
+			}
+			mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "api_tokens" WHERE token_hash = $1`).WithReply(reply)
+			update := mocket.Catcher.NewMock().WithQuery(`UPDATE "api_tokens" SET "last_used_at"`).WithRowsNum(1)
+
+			service := NewApiTokenService(db.NewMockConnectionFactory(nil), NewApiTokenConfig())
+			service.now = func() time.Time { return now }
+			token, err := service.ResolveApiToken(context.Background(), "kfm_token")
+
+			g.Expect(update.Triggered).To(gomega.Equal(tt.wantLastUsedAt))
+			if tt.wantCode != 0 {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.HttpCode).To(gomega.Equal(tt.wantCode))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(token.ID).To(gomega.Equal("token-id"))
+		})
+	}
+}
+
+func TestApiTokenService_ResolveApiToken_Revocation(t *testing.T) {
+	g := gomega.NewWithT(t)
+	now := time.Date(2023, 6, 12, 10, 0, 0, 0, time.UTC)
+	token := map[string]interface{}{
+		"id": "token-id", "organisation_id": "org-id", "expires_at": now.Add(time.Hour), "last_used_at": now.Add(-time.Minute),
+	}
+	service := NewApiTokenService(db.NewMockConnectionFactory(nil), NewApiTokenConfig())
+	service.now = func() time.Time { return now }
+
+	mocket.Catcher.Reset()
+	load := mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "api_tokens" WHERE token_hash = $1`).WithReply([]map[string]interface{}{token})
+	_, err := service.ResolveApiToken(context.Background(), "kfm_token")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(load.Triggered).To(gomega.BeTrue())
+
+	// the cached token is accepted while it's not revoked
+	mocket.Catcher.Reset()
+	load = mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "api_tokens" WHERE token_hash = $1`).WithReply([]map[string]interface{}{token})
+	check := mocket.Catcher.NewMock().WithQuery(`SELECT count(1) FROM "api_tokens" WHERE id = $1`).WithReply([]map[string]interface{}{{"count": 1}})
+	_, err = service.ResolveApiToken(context.Background(), "kfm_token")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(load.Triggered).To(gomega.BeFalse())
+	g.Expect(check.Triggered).To(gomega.BeTrue())
+
+	// the cached token is rejected once revoked by another replica
+	mocket.Catcher.Reset()
+	check = mocket.Catcher.NewMock().WithQuery(`SELECT count(1) FROM "api_tokens" WHERE id = $1`).WithReply([]map[string]interface{}{{"count": 0}})
+	_, err = service.ResolveApiToken(context.Background(), "kfm_token")
+	g.Expect(err).ToNot(gomega.BeNil())
+	g.Expect(err.HttpCode).To(gomega.Equal(http.StatusUnauthorized))
+	g.Expect(check.Triggered).To(gomega.BeTrue())
+
+	// and it's no longer cached
+	mocket.Catcher.Reset()
+	load = mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "api_tokens" WHERE token_hash = $1`).WithReply([]map[string]interface{}{})
+	_, err = service.ResolveApiToken(context.Background(), "kfm_token")
+	g.Expect(err).ToNot(gomega.BeNil())
+	g.Expect(err.HttpCode).To(gomega.Equal(http.StatusUnauthorized))
+	g.Expect(load.Triggered).To(gomega.BeTrue())
+}
+
+func TestApiTokenService_Create(t *testing.T) {
+	tests := []struct {
+		name     string
+		count    int
+		wantCode int
+	}{
+		{
+			name:  "should mint a token and store its hash",
+			count: 1,
+		},
+		{
+			name:     "should reject a token once the organisation reached its maximum",
+			count:    2,
+			wantCode: http.StatusForbidden,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().WithQuery(`SELECT count(1) FROM "api_tokens"`).WithReply([]map[string]interface{}{{"count": tt.count}})
+			insert := mocket.Catcher.NewMock().WithQuery(`INSERT INTO "api_tokens"`).WithRowsNum(1)
+
+			config := NewApiTokenConfig()
+			config.MaxTokensPerOrganisation = 2
+			service := NewApiTokenService(db.NewMockConnectionFactory(nil), config)
+			token := &api.ApiToken{OrganisationId: "org-id", Owner: "user", Kind: api.ApiTokenPersonal}
+			value, err := service.Create(context.Background(), token)
+
+			if tt.wantCode != 0 {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.HttpCode).To(gomega.Equal(tt.wantCode))
+				g.Expect(insert.Triggered).To(gomega.BeFalse())
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(insert.Triggered).To(gomega.BeTrue())
+			g.Expect(value).To(gomega.HavePrefix("kfm_"))
+			g.Expect(token.TokenHash).To(gomega.Equal(hashApiToken(value)))
+			g.Expect(token.TokenHash).ToNot(gomega.ContainSubstring(value))
+			g.Expect(value).To(gomega.HavePrefix(token.TokenPrefix))
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package apitokens
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/spf13/pflag"
+)
+
+var _ environments.ConfigModule = (*ApiTokenConfig)(nil)
+
+// ApiTokenConfig is the configuration of the API tokens minted by the fleet manager
+type ApiTokenConfig struct {
+	// DefaultLifetime is the lifetime of the tokens created without expiry
+	DefaultLifetime time.Duration
+	// MaxLifetime is the maximum lifetime of the tokens
+	MaxLifetime time.Duration
+	// MaxTokensPerOrganisation is the maximum number of tokens of an organisation, including the expired tokens not revoked yet
+	MaxTokensPerOrganisation int
+	// CacheTTL is how long the resolved tokens are cached, their revocation is still checked in the database by every request
+	CacheTTL time.Duration
+	// NegativeCacheTTL is how long the unknown tokens are cached
+	NegativeCacheTTL time.Duration
+	// LastUsedUpdateInterval is the minimum delay between two updates of the last used timestamp of a token
+	LastUsedUpdateInterval time.Duration
+}
+
+func NewApiTokenConfig() *ApiTokenConfig {
+	return &ApiTokenConfig{
+		DefaultLifetime:          30 * 24 * time.Hour,
+		MaxLifetime:              90 * 24 * time.Hour,
+		MaxTokensPerOrganisation: 50,
+		CacheTTL:                 10 * time.Second,
+		NegativeCacheTTL:         1 * time.Second,
+		LastUsedUpdateInterval:   5 * time.Minute,
+	}
+}
+
+func (c *ApiTokenConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&c.DefaultLifetime, "api-token-default-lifetime", c.DefaultLifetime, "Lifetime of the API tokens created without expiry")
+	fs.DurationVar(&c.MaxLifetime, "api-token-max-lifetime", c.MaxLifetime, "Maximum lifetime of the API tokens")
+	fs.IntVar(&c.MaxTokensPerOrganisation, "api-token-max-per-organisation", c.MaxTokensPerOrganisation, "Maximum number of API tokens of an organisation")
+	fs.DurationVar(&c.CacheTTL, "api-token-cache-ttl", c.CacheTTL, "Duration the resolved API tokens are cached, their revocation is still checked by every request")
+	fs.DurationVar(&c.NegativeCacheTTL, "api-token-negative-cache-ttl", c.NegativeCacheTTL, "Duration the unknown API tokens are cached")
+	fs.DurationVar(&c.LastUsedUpdateInterval, "api-token-last-used-update-interval", c.LastUsedUpdateInterval, "Minimum delay between two updates of the last used timestamp of an API token")
+}
+
+func (c *ApiTokenConfig) ReadFiles() error {
+	if c.DefaultLifetime <= 0 || c.MaxLifetime <= 0 {
+		return fmt.Errorf("api-token-default-lifetime and api-token-max-lifetime must be positive")
+	}
+	if c.DefaultLifetime > c.MaxLifetime {
+		return fmt.Errorf("api-token-default-lifetime %s must not exceed api-token-max-lifetime %s", c.DefaultLifetime, c.MaxLifetime)
+	}
+	if c.CacheTTL < 0 || c.NegativeCacheTTL < 0 {
+		return fmt.Errorf("api-token-cache-ttl and api-token-negative-cache-ttl must not be negative, got %s and %s", c.CacheTTL, c.NegativeCacheTTL)
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package apitokens
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/goava/di"
+)
+
+func ConfigProviders() di.Option {
+	return di.Options(
+		di.Provide(NewApiTokenConfig, di.As(new(environments.ConfigModule))),
+		di.Provide(environments.Func(ServiceProviders)),
+	)
+}
+
+func ServiceProviders() di.Option {
+	return di.Options(
+		di.Provide(NewApiTokenService, di.As(new(ApiTokenService)), di.As(new(auth.ApiTokenResolver))),
+	)
+}
@@ -0,0 +1,151 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/queryparser"
+	"gorm.io/gorm"
+)
+
+// Services recording audit events
+const (
+	ServiceKafkas     = "kafkas_mgmt"
+	ServiceConnectors = "connector_mgmt"
+)
+
+// AuditEventFilter selects the audit events of a service
+type AuditEventFilter struct {
+	Service string
+	// Search is a search query on the columns returned by GetValidAuditEventColumns
+	Search string
+	From   *time.Time
+	To     *time.Time
+}
+
+//go:generate moq -out audit_events_moq.go . AuditEventsService
+
+// AuditEventsService records the audit events of the audit log middleware in the append only audit_events table
+type AuditEventsService interface {
+	auth.AuditEventRecorder
+	List(ctx context.Context, filter AuditEventFilter, listArgs *services.ListArguments) (api.AuditEventList, *api.PagingMeta, *errors.ServiceError)
+	// Export calls the given function with every audit event matching the filter, from the oldest to the newest
+	Export(ctx context.Context, filter AuditEventFilter, fn func(event *api.AuditEvent) error) *errors.ServiceError
+	// DeleteExpired deletes the audit events of a service created before the given time
+	DeleteExpired(ctx context.Context, service string, before time.Time) (int64, *errors.ServiceError)
+}
+
+var _ AuditEventsService = &auditEventsService{}
+
+type auditEventsService struct {
+	connectionFactory *db.ConnectionFactory
+}
+
+func NewAuditEventsService(connectionFactory *db.ConnectionFactory) *auditEventsService {
+	return &auditEventsService{
+		connectionFactory: connectionFactory,
+	}
+}
+
+func GetValidAuditEventColumns() []string {
+	return []string{"actor", "organisation_id", "method", "route", "path", "resource_id", "status_code", "outcome", "operation_id"}
+}
+
+func (a *auditEventsService) RecordAuditEvent(ctx context.Context, event *api.AuditEvent) error {
+	// audit events are recorded outside of the transaction of the request, so that failed requests are recorded too
+	return a.connectionFactory.New().Create(event).Error
+}
+
+func (a *auditEventsService) filter(filter AuditEventFilter) (*gorm.DB, *errors.ServiceError) {
+	dbConn := a.connectionFactory.New().Where("service = ?", filter.Service)
+	if len(filter.Search) > 0 {
+		queryParser := queryparser.NewQueryParser(GetValidAuditEventColumns()...)
+		searchDbQuery, err := queryParser.Parse(filter.Search)
+		if err != nil {
+			return nil, errors.NewWithCause(errors.ErrorFailedToParseSearch, err, "unable to list audit events: %s", err.Error())
+		}
+		dbConn = dbConn.Where(searchDbQuery.Query, searchDbQuery.Values...)
+	}
+	if filter.From != nil {
+		dbConn = dbConn.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		dbConn = dbConn.Where("created_at < ?", *filter.To)
+	}
+	return dbConn, nil
+}
+
+func (a *auditEventsService) List(ctx context.Context, filter AuditEventFilter, listArgs *services.ListArguments) (api.AuditEventList, *api.PagingMeta, *errors.ServiceError) {
+	var resourceList api.AuditEventList
+	pagingMeta := &api.PagingMeta{
+		Page: listArgs.Page,
+		Size: listArgs.Size,
+	}
+	if err := listArgs.Validate(append(GetValidAuditEventColumns(), "created_at")); err != nil {
+		return resourceList, pagingMeta, errors.NewWithCause(errors.ErrorMalformedRequest, err, "unable to list audit events: %s", err.Error())
+	}
+
+	dbConn, serr := a.filter(filter)
+	if serr != nil {
+		return resourceList, pagingMeta, serr
+	}
+
+	total := int64(pagingMeta.Total)
+	dbConn.Session(&gorm.Session{}).Model(&resourceList).Count(&total)
+	pagingMeta.Total = int(total)
+	if pagingMeta.Size > pagingMeta.Total {
+		pagingMeta.Size = pagingMeta.Total
+	}
+	dbConn = dbConn.Offset((pagingMeta.Page - 1) * pagingMeta.Size).Limit(pagingMeta.Size)
+
+	if len(listArgs.OrderBy) == 0 {
+		dbConn = dbConn.Order("created_at desc")
+	}
+	for _, orderByArg := range listArgs.OrderBy {
+		dbConn = dbConn.Order(orderByArg)
+	}
+
+	if err := dbConn.Find(&resourceList).Error; err != nil {
+		return resourceList, pagingMeta, errors.GeneralError("unable to list audit events: %v", err)
+	}
+	return resourceList, pagingMeta, nil
+}
+
+func (a *auditEventsService) Export(ctx context.Context, filter AuditEventFilter, fn func(event *api.AuditEvent) error) *errors.ServiceError {
+	dbConn, serr := a.filter(filter)
+	if serr != nil {
+		return serr
+	}
+	rows, err := dbConn.Model(&api.AuditEvent{}).Order("created_at, id").Rows()
+	if err != nil {
+		return errors.GeneralError("unable to export audit events: %v", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var event api.AuditEvent
+		if err := dbConn.ScanRows(rows, &event); err != nil {
+			return errors.GeneralError("unable to export audit events: %v", err)
+		}
+		if err := fn(&event); err != nil {
+			return errors.GeneralError("unable to export audit events: %v", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return errors.GeneralError("unable to export audit events: %v", err)
+	}
+	return nil
+}
+
+func (a *auditEventsService) DeleteExpired(ctx context.Context, service string, before time.Time) (int64, *errors.ServiceError) {
+	result := a.connectionFactory.New().Where("service = ? AND created_at < ?", service, before).Delete(&api.AuditEvent{})
+	if result.Error != nil {
+		return 0, errors.GeneralError("unable to delete expired audit events of %s: %v", service, result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,253 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package audit
+
+import (
+	"context"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"sync"
+	"time"
+)
+
+// Ensure, that AuditEventsServiceMock does implement AuditEventsService.
+// If this is not the case, regenerate this file with moq.
+var _ AuditEventsService = &AuditEventsServiceMock{}
+
+// AuditEventsServiceMock is a mock implementation of AuditEventsService.
+//
+//	func TestSomethingThatUsesAuditEventsService(t *testing.T) {
+//
+//		// make and configure a mocked AuditEventsService
+//		mockedAuditEventsService := &AuditEventsServiceMock{
+//			DeleteExpiredFunc: func(ctx context.Context, service string, before time.Time) (int64, *errors.ServiceError) {
+//				panic("mock out the DeleteExpired method")
+//			},
+//			ExportFunc: func(ctx context.Context, filter AuditEventFilter, fn func(event *api.AuditEvent) error) *errors.ServiceError {
+//				panic("mock out the Export method")
+//			},
+//			ListFunc: func(ctx context.Context, filter AuditEventFilter, listArgs *services.ListArguments) (api.AuditEventList, *api.PagingMeta, *errors.ServiceError) {
+//				panic("mock out the List method")
+//			},
+//			RecordAuditEventFunc: func(ctx context.Context, event *api.AuditEvent) error {
+//				panic("mock out the RecordAuditEvent method")
+//			},
+//		}
+//
+//		// use mockedAuditEventsService in code that requires AuditEventsService
+//		// and then make assertions.
+//
+//	}
+type AuditEventsServiceMock struct {
+	// DeleteExpiredFunc mocks the DeleteExpired method.
+	DeleteExpiredFunc func(ctx context.Context, service string, before time.Time) (int64, *errors.ServiceError)
+
+	// ExportFunc mocks the Export method.
+	ExportFunc func(ctx context.Context, filter AuditEventFilter, fn func(event *api.AuditEvent) error) *errors.ServiceError
+
+	// ListFunc mocks the List method.
+	ListFunc func(ctx context.Context, filter AuditEventFilter, listArgs *services.ListArguments) (api.AuditEventList, *api.PagingMeta, *errors.ServiceError)
+
+	// RecordAuditEventFunc mocks the RecordAuditEvent method.
+	RecordAuditEventFunc func(ctx context.Context, event *api.AuditEvent) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// DeleteExpired holds details about calls to the DeleteExpired method.
+		DeleteExpired []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Service is the service argument value.
+			Service string
+			// Before is the before argument value.
+			Before time.Time
+		}
+		// Export holds details about calls to the Export method.
+		Export []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter AuditEventFilter
+			// Fn is the fn argument value.
+			Fn func(event *api.AuditEvent) error
+		}
+		// List holds details about calls to the List method.
+		List []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter AuditEventFilter
+			// ListArgs is the listArgs argument value.
+			ListArgs *services.ListArguments
+		}
+		// RecordAuditEvent holds details about calls to the RecordAuditEvent method.
+		RecordAuditEvent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Event is the event argument value.
+			Event *api.AuditEvent
+		}
+	}
+	lockDeleteExpired    sync.RWMutex
+	lockExport           sync.RWMutex
+	lockList             sync.RWMutex
+	lockRecordAuditEvent sync.RWMutex
+}
+
+// DeleteExpired calls DeleteExpiredFunc.
+func (mock *AuditEventsServiceMock) DeleteExpired(ctx context.Context, service string, before time.Time) (int64, *errors.ServiceError) {
+	if mock.DeleteExpiredFunc == nil {
+		panic("AuditEventsServiceMock.DeleteExpiredFunc: method is nil but AuditEventsService.DeleteExpired was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Service string
+		Before  time.Time
+	}{
+		Ctx:     ctx,
+		Service: service,
+		Before:  before,
+	}
+	mock.lockDeleteExpired.Lock()
+	mock.calls.DeleteExpired = append(mock.calls.DeleteExpired, callInfo)
+	mock.lockDeleteExpired.Unlock()
+	return mock.DeleteExpiredFunc(ctx, service, before)
+}
+
+// DeleteExpiredCalls gets all the calls that were made to DeleteExpired.
+// Check the length with:
+//
+//	len(mockedAuditEventsService.DeleteExpiredCalls())
+func (mock *AuditEventsServiceMock) DeleteExpiredCalls() []struct {
+	Ctx     context.Context
+	Service string
+	Before  time.Time
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Service string
+		Before  time.Time
+	}
+	mock.lockDeleteExpired.RLock()
+	calls = mock.calls.DeleteExpired
+	mock.lockDeleteExpired.RUnlock()
+	return calls
+}
+
+// Export calls ExportFunc.
+func (mock *AuditEventsServiceMock) Export(ctx context.Context, filter AuditEventFilter, fn func(event *api.AuditEvent) error) *errors.ServiceError {
+	if mock.ExportFunc == nil {
+		panic("AuditEventsServiceMock.ExportFunc: method is nil but AuditEventsService.Export was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Filter AuditEventFilter
+		Fn     func(event *api.AuditEvent) error
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+		Fn:     fn,
+	}
+	mock.lockExport.Lock()
+	mock.calls.Export = append(mock.calls.Export, callInfo)
+	mock.lockExport.Unlock()
+	return mock.ExportFunc(ctx, filter, fn)
+}
+
+// ExportCalls gets all the calls that were made to Export.
+// Check the length with:
+//
+//	len(mockedAuditEventsService.ExportCalls())
+func (mock *AuditEventsServiceMock) ExportCalls() []struct {
+	Ctx    context.Context
+	Filter AuditEventFilter
+	Fn     func(event *api.AuditEvent) error
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Filter AuditEventFilter
+		Fn     func(event *api.AuditEvent) error
+	}
+	mock.lockExport.RLock()
+	calls = mock.calls.Export
+	mock.lockExport.RUnlock()
+	return calls
+}
+
+// List calls ListFunc.
+func (mock *AuditEventsServiceMock) List(ctx context.Context, filter AuditEventFilter, listArgs *services.ListArguments) (api.AuditEventList, *api.PagingMeta, *errors.ServiceError) {
+	if mock.ListFunc == nil {
+		panic("AuditEventsServiceMock.ListFunc: method is nil but AuditEventsService.List was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Filter   AuditEventFilter
+		ListArgs *services.ListArguments
+	}{
+		Ctx:      ctx,
+		Filter:   filter,
+		ListArgs: listArgs,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc(ctx, filter, listArgs)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedAuditEventsService.ListCalls())
+func (mock *AuditEventsServiceMock) ListCalls() []struct {
+	Ctx      context.Context
+	Filter   AuditEventFilter
+	ListArgs *services.ListArguments
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Filter   AuditEventFilter
+		ListArgs *services.ListArguments
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// RecordAuditEvent calls RecordAuditEventFunc.
+func (mock *AuditEventsServiceMock) RecordAuditEvent(ctx context.Context, event *api.AuditEvent) error {
+	if mock.RecordAuditEventFunc == nil {
+		panic("AuditEventsServiceMock.RecordAuditEventFunc: method is nil but AuditEventsService.RecordAuditEvent was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Event *api.AuditEvent
+	}{
+		Ctx:   ctx,
+		Event: event,
+	}
+	mock.lockRecordAuditEvent.Lock()
+	mock.calls.RecordAuditEvent = append(mock.calls.RecordAuditEvent, callInfo)
+	mock.lockRecordAuditEvent.Unlock()
+	return mock.RecordAuditEventFunc(ctx, event)
+}
+
+// RecordAuditEventCalls gets all the calls that were made to RecordAuditEvent.
+// Check the length with:
+//
+//	len(mockedAuditEventsService.RecordAuditEventCalls())
+func (mock *AuditEventsServiceMock) RecordAuditEventCalls() []struct {
+	Ctx   context.Context
+	Event *api.AuditEvent
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Event *api.AuditEvent
+	}
+	mock.lockRecordAuditEvent.RLock()
+	calls = mock.calls.RecordAuditEvent
+	mock.lockRecordAuditEvent.RUnlock()
+	return calls
+}
@@ -0,0 +1,30 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/spf13/pflag"
+)
+
+var _ environments.ConfigModule = (*AuditConfig)(nil)
+
+// AuditConfig is the configuration of the audit trail
+type AuditConfig struct {
+	// RetentionPeriod is how long the audit events are kept for, 0 keeps them forever
+	RetentionPeriod time.Duration
+}
+
+func NewAuditConfig() *AuditConfig {
+	return &AuditConfig{
+		RetentionPeriod: 365 * 24 * time.Hour,
+	}
+}
+
+func (c *AuditConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&c.RetentionPeriod, "audit-events-retention-period", c.RetentionPeriod, "How long audit events are kept for before being deleted, 0 keeps them forever")
+}
+
+func (c *AuditConfig) ReadFiles() error {
+	return nil
+}
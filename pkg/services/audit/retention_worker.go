@@ -0,0 +1,58 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+)
+
+var _ workers.Worker = &RetentionWorker{}
+
+// RetentionWorker periodically deletes the audit events of a service older than the retention period
+type RetentionWorker struct {
+	workers.BaseWorker
+	service            string
+	auditEventsService AuditEventsService
+	auditConfig        *AuditConfig
+}
+
+// NewRetentionWorker creates a worker deleting the expired audit events of a service,
+// the worker type must match a leader lease type created by the migrations of the service
+func NewRetentionWorker(workerType string, service string, auditEventsService AuditEventsService, auditConfig *AuditConfig,
+	reconciler workers.Reconciler) *RetentionWorker {
+	return &RetentionWorker{
+		BaseWorker: workers.BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: workerType,
+			Reconciler: reconciler,
+		},
+		service:            service,
+		auditEventsService: auditEventsService,
+		auditConfig:        auditConfig,
+	}
+}
+
+func (w *RetentionWorker) Start() {
+	w.StartWorker(w)
+}
+
+func (w *RetentionWorker) Stop() {
+	w.StopWorker(w)
+}
+
+func (w *RetentionWorker) Reconcile() []error {
+	if w.auditConfig.RetentionPeriod <= 0 {
+		return nil
+	}
+	deleted, err := w.auditEventsService.DeleteExpired(context.Background(), w.service, time.Now().Add(-w.auditConfig.RetentionPeriod))
+	if err != nil {
+		return []error{err}
+	}
+	if deleted > 0 {
+		glog.Infof("Deleted %d audit events of %s older than %s", deleted, w.service, w.auditConfig.RetentionPeriod)
+	}
+	return nil
+}
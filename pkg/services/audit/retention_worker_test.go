@@ -0,0 +1,62 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/onsi/gomega"
+)
+
+func TestRetentionWorker_Reconcile(t *testing.T) {
+	tests := []struct {
+		name            string
+		retentionPeriod time.Duration
+		deleteErr       *errors.ServiceError
+		wantDeleted     bool
+		wantErr         bool
+	}{
+		{
+			name:            "should delete the audit events older than the retention period",
+			retentionPeriod: 24 * time.Hour,
+			wantDeleted:     true,
+		},
+		{
+			name:            "should keep the audit events forever when the retention period is 0",
+			retentionPeriod: 0,
+			wantDeleted:     false,
+		},
+		{
+			name:            "should return the deletion error",
+			retentionPeriod: 24 * time.Hour,
+			deleteErr:       errors.GeneralError("test"),
+			wantDeleted:     true,
+			wantErr:         true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			auditEventsService := &AuditEventsServiceMock{
+				DeleteExpiredFunc: func(ctx context.Context, service string, before time.Time) (int64, *errors.ServiceError) {
+					g.Expect(service).To(gomega.Equal(ServiceKafkas))
+					g.Expect(before).To(gomega.BeTemporally("~", time.Now().Add(-tt.retentionPeriod), time.Minute))
+					if tt.deleteErr != nil {
+						return 0, tt.deleteErr
+					}
+					return 1, nil
+				},
+			}
+			worker := NewRetentionWorker("audit_events_retention", ServiceKafkas, auditEventsService,
+				&AuditConfig{RetentionPeriod: tt.retentionPeriod}, workers.Reconciler{})
+
+			errs := worker.Reconcile()
+			g.Expect(len(errs) != 0).To(gomega.Equal(tt.wantErr))
+			g.Expect(len(auditEventsService.DeleteExpiredCalls()) != 0).To(gomega.Equal(tt.wantDeleted))
+		})
+	}
+}
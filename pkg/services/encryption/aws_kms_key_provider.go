@@ -0,0 +1,67 @@
+package encryption
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+)
+
+var _ KeyProvider = &awsKMSKeyProvider{}
+
+// awsKMSKeyProvider wraps the data keys with an AWS KMS key. The rotation of the key material is handled by KMS, the version of
+// the wrapped keys is the id of the KMS key, so that the values can be re-encrypted when the configured key is changed.
+type awsKMSKeyProvider struct {
+	client kmsiface.KMSAPI
+	keyID  string
+}
+
+func newAWSKMSKeyProvider(config *EncryptionConfig) (*awsKMSKeyProvider, error) {
+	awsConfig := &aws.Config{
+		Region:  aws.String(config.AWSRegion),
+		Retryer: client.DefaultRetryer{NumMaxRetries: 2},
+	}
+	if config.AWSAccessKey != "" {
+		awsConfig.Credentials = credentials.NewStaticCredentials(config.AWSAccessKey, config.AWSSecretAccessKey, "")
+	}
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &awsKMSKeyProvider{
+		client: kms.New(sess),
+		keyID:  config.AWSKMSKeyID,
+	}, nil
+}
+
+func (p *awsKMSKeyProvider) Name() string {
+	return ProviderAWSKMS
+}
+
+func (p *awsKMSKeyProvider) CurrentKeyVersion() (string, error) {
+	return p.keyID, nil
+}
+
+func (p *awsKMSKeyProvider) WrapKey(dataKey []byte) ([]byte, string, error) {
+	output, err := p.client.Encrypt(&kms.EncryptInput{
+		KeyId:     aws.String(p.keyID),
+		Plaintext: dataKey,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return output.CiphertextBlob, p.keyID, nil
+}
+
+func (p *awsKMSKeyProvider) UnwrapKey(wrapped []byte, keyVersion string) ([]byte, error) {
+	output, err := p.client.Decrypt(&kms.DecryptInput{
+		KeyId:          aws.String(keyVersion),
+		CiphertextBlob: wrapped,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.Plaintext, nil
+}
@@ -0,0 +1,107 @@
+package encryption
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+	"github.com/spf13/pflag"
+)
+
+var _ environments.ConfigModule = (*EncryptionConfig)(nil)
+
+// Providers of the key encryption keys
+const (
+	ProviderNone         = "none"
+	ProviderLocal        = "local"
+	ProviderAWSKMS       = "aws-kms"
+	ProviderVaultTransit = "vault-transit"
+)
+
+// EncryptionConfig is the configuration of the encryption at rest of the sensitive database columns
+type EncryptionConfig struct {
+	// Provider of the key encryption keys, the sensitive columns are stored in plain text with the "none" provider
+	Provider string
+	// DataKeyTTL is the duration a data encryption key is used to encrypt values before a new one is generated
+	DataKeyTTL time.Duration
+
+	// LocalKeysFile is the YAML file with the versioned keys of the local provider, only meant for development and tests
+	LocalKeysFile string
+	LocalKeys     LocalKeys
+
+	AWSKMSKeyID            string
+	AWSRegion              string
+	AWSAccessKeyFile       string
+	AWSAccessKey           string
+	AWSSecretAccessKeyFile string
+	AWSSecretAccessKey     string
+
+	VaultAddress      string
+	VaultTokenFile    string
+	VaultToken        string
+	VaultTransitMount string
+	VaultTransitKey   string
+}
+
+// LocalKeys are the versioned keys of the local provider, the values are the base64 encoded 32 bytes AES keys
+type LocalKeys struct {
+	CurrentVersion string            `yaml:"current_version"`
+	Keys           map[string]string `yaml:"keys"`
+}
+
+func NewEncryptionConfig() *EncryptionConfig {
+	return &EncryptionConfig{
+		Provider:               ProviderNone,
+		DataKeyTTL:             time.Hour,
+		LocalKeysFile:          "secrets/db-encryption-keys.yaml",
+		AWSRegion:              "us-east-1",
+		AWSAccessKeyFile:       "secrets/aws-kms.accesskey",
+		AWSSecretAccessKeyFile: "secrets/aws-kms.secretaccesskey",
+		VaultTokenFile:         "secrets/vault-transit.token",
+		VaultTransitMount:      "transit",
+	}
+}
+
+func (c *EncryptionConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&c.Provider, "db-encryption-provider", c.Provider, "Provider of the keys encrypting the sensitive database columns: none, local, aws-kms or vault-transit")
+	fs.DurationVar(&c.DataKeyTTL, "db-encryption-data-key-ttl", c.DataKeyTTL, "Duration a data encryption key is used to encrypt values before a new one is generated")
+	fs.StringVar(&c.LocalKeysFile, "db-encryption-local-keys-file", c.LocalKeysFile, "File containing the versioned keys of the local provider, only meant for development and tests")
+	fs.StringVar(&c.AWSKMSKeyID, "db-encryption-aws-kms-key-id", c.AWSKMSKeyID, "ID or ARN of the AWS KMS key of the aws-kms provider")
+	fs.StringVar(&c.AWSRegion, "db-encryption-aws-region", c.AWSRegion, "AWS region of the KMS key of the aws-kms provider")
+	fs.StringVar(&c.AWSAccessKeyFile, "db-encryption-aws-access-key-file", c.AWSAccessKeyFile, "File containing the AWS access key of the aws-kms provider, the default AWS credentials are used when it doesn't exist")
+	fs.StringVar(&c.AWSSecretAccessKeyFile, "db-encryption-aws-secret-access-key-file", c.AWSSecretAccessKeyFile, "File containing the AWS secret access key of the aws-kms provider")
+	fs.StringVar(&c.VaultAddress, "db-encryption-vault-address", c.VaultAddress, "Address of the vault server of the vault-transit provider")
+	fs.StringVar(&c.VaultTokenFile, "db-encryption-vault-token-file", c.VaultTokenFile, "File containing the vault token of the vault-transit provider")
+	fs.StringVar(&c.VaultTransitMount, "db-encryption-vault-transit-mount", c.VaultTransitMount, "Mount path of the transit secrets engine of the vault-transit provider")
+	fs.StringVar(&c.VaultTransitKey, "db-encryption-vault-transit-key", c.VaultTransitKey, "Name of the transit key of the vault-transit provider")
+}
+
+func (c *EncryptionConfig) ReadFiles() error {
+	switch c.Provider {
+	case ProviderNone:
+		return nil
+	case ProviderLocal:
+		return shared.ReadYamlFile(c.LocalKeysFile, &c.LocalKeys)
+	case ProviderAWSKMS:
+		if c.AWSKMSKeyID == "" {
+			return fmt.Errorf("--db-encryption-aws-kms-key-id is required by the %s provider", ProviderAWSKMS)
+		}
+		// the credentials are optional, the default credentials of the AWS SDK are used when they aren't set
+		if _, err := os.Stat(shared.BuildFullFilePath(c.AWSAccessKeyFile)); err == nil {
+			if err := shared.ReadFileValueString(c.AWSAccessKeyFile, &c.AWSAccessKey); err != nil {
+				return err
+			}
+			return shared.ReadFileValueString(c.AWSSecretAccessKeyFile, &c.AWSSecretAccessKey)
+		}
+		return nil
+	case ProviderVaultTransit:
+		if c.VaultAddress == "" || c.VaultTransitKey == "" {
+			return fmt.Errorf("--db-encryption-vault-address and --db-encryption-vault-transit-key are required by the %s provider", ProviderVaultTransit)
+		}
+		return shared.ReadFileValueString(c.VaultTokenFile, &c.VaultToken)
+	default:
+		return fmt.Errorf("unknown db encryption provider %q", c.Provider)
+	}
+}
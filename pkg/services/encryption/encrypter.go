@@ -0,0 +1,193 @@
+package encryption
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db/dbencryption"
+)
+
+// maxUnwrappedDataKeys bounds the cache of the unwrapped data keys, avoiding a call to the key provider for each decrypted value
+const maxUnwrappedDataKeys = 1000
+
+// envelope is an encrypted value with the data key encrypting it, wrapped by the key provider
+type envelope struct {
+	Provider   string `json:"p"`
+	KeyVersion string `json:"v"`
+	WrappedKey []byte `json:"k"`
+	Ciphertext []byte `json:"c"`
+}
+
+var _ dbencryption.ValueEncrypter = &Encrypter{}
+
+type dataKey struct {
+	plaintext  []byte
+	wrapped    []byte
+	keyVersion string
+	expires    time.Time
+}
+
+// Encrypter encrypts values with AES-256-GCM data keys, wrapped by the key encryption keys of a key provider.
+// A data key is used to encrypt the values for the configured TTL, then a new one is generated.
+type Encrypter struct {
+	provider   KeyProvider
+	dataKeyTTL time.Duration
+
+	mu        sync.Mutex
+	dataKey   *dataKey
+	unwrapped map[string][]byte
+}
+
+func NewEncrypter(provider KeyProvider, dataKeyTTL time.Duration) *Encrypter {
+	return &Encrypter{
+		provider:   provider,
+		dataKeyTTL: dataKeyTTL,
+		unwrapped:  map[string][]byte{},
+	}
+}
+
+// Provider returns the key provider wrapping the data keys
+func (e *Encrypter) Provider() KeyProvider {
+	return e.provider
+}
+
+// Encrypt encrypts the plain text, the empty values are left empty
+func (e *Encrypter) Encrypt(plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+	key, err := e.currentDataKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to get a data encryption key: %w", err)
+	}
+	aead, err := newAEAD(key.plaintext)
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := seal(aead, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+	content, err := json.Marshal(envelope{
+		Provider:   e.provider.Name(),
+		KeyVersion: key.keyVersion,
+		WrappedKey: key.wrapped,
+		Ciphertext: ciphertext,
+	})
+	if err != nil {
+		return "", err
+	}
+	return dbencryption.EncryptedPrefix + base64.StdEncoding.EncodeToString(content), nil
+}
+
+// Decrypt decrypts a value returned by Encrypt, the plain text values are returned as is
+func (e *Encrypter) Decrypt(value string) (string, error) {
+	if !dbencryption.IsEncrypted(value) {
+		return value, nil
+	}
+	env, err := parseEnvelope(value)
+	if err != nil {
+		return "", err
+	}
+	if env.Provider != e.provider.Name() {
+		return "", fmt.Errorf("value encrypted by the %s provider can't be decrypted by the %s provider", env.Provider, e.provider.Name())
+	}
+	key, err := e.unwrapDataKey(env)
+	if err != nil {
+		return "", fmt.Errorf("failed to unwrap the data encryption key: %w", err)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(aead, env.Ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// NeedsReencryption returns whether a value is stored in plain text, or was encrypted with another version of the key
+// encryption key than the given current version. The values encrypted by another key provider can't be decrypted, so an
+// error is returned for them instead of re-encrypting them.
+func (e *Encrypter) NeedsReencryption(value string, currentKeyVersion string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	if !dbencryption.IsEncrypted(value) {
+		return true, nil
+	}
+	env, err := parseEnvelope(value)
+	if err != nil {
+		return false, err
+	}
+	if env.Provider != e.provider.Name() {
+		return false, fmt.Errorf("value encrypted by the %s provider can't be re-encrypted by the %s provider", env.Provider, e.provider.Name())
+	}
+	return env.KeyVersion != currentKeyVersion, nil
+}
+
+func (e *Encrypter) currentDataKey() (*dataKey, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.dataKey != nil && time.Now().Before(e.dataKey.expires) {
+		return e.dataKey, nil
+	}
+
+	plaintext := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, plaintext); err != nil {
+		return nil, err
+	}
+	wrapped, keyVersion, err := e.provider.WrapKey(plaintext)
+	if err != nil {
+		return nil, err
+	}
+	e.dataKey = &dataKey{
+		plaintext:  plaintext,
+		wrapped:    wrapped,
+		keyVersion: keyVersion,
+		expires:    time.Now().Add(e.dataKeyTTL),
+	}
+	return e.dataKey, nil
+}
+
+func (e *Encrypter) unwrapDataKey(env *envelope) ([]byte, error) {
+	cacheKey := env.KeyVersion + "/" + base64.StdEncoding.EncodeToString(env.WrappedKey)
+	e.mu.Lock()
+	key, ok := e.unwrapped[cacheKey]
+	e.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+
+	key, err := e.provider.UnwrapKey(env.WrappedKey, env.KeyVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.unwrapped) >= maxUnwrappedDataKeys {
+		e.unwrapped = map[string][]byte{}
+	}
+	e.unwrapped[cacheKey] = key
+	return key, nil
+}
+
+func parseEnvelope(value string) (*envelope, error) {
+	content, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, dbencryption.EncryptedPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("invalid encrypted value: %w", err)
+	}
+	var env envelope
+	if err := json.Unmarshal(content, &env); err != nil {
+		return nil, fmt.Errorf("invalid encrypted value: %w", err)
+	}
+	return &env, nil
+}
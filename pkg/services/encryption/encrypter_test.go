@@ -0,0 +1,186 @@
+package encryption
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db/dbencryption"
+	"github.com/onsi/gomega"
+)
+
+func newTestLocalKeys(versions ...string) LocalKeys {
+	keys := LocalKeys{Keys: map[string]string{}}
+	for i, version := range versions {
+		keys.Keys[version] = base64.StdEncoding.EncodeToString([]byte(strings.Repeat(string(rune('a'+i)), 32)))
+		keys.CurrentVersion = version
+	}
+	return keys
+}
+
+func newTestEncrypter(t *testing.T, versions ...string) *Encrypter {
+	provider, err := newLocalKeyProvider(newTestLocalKeys(versions...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewEncrypter(provider, time.Hour)
+}
+
+func Test_newLocalKeyProvider(t *testing.T) {
+	tests := []struct {
+		name    string
+		keys    LocalKeys
+		wantErr bool
+	}{
+		{
+			name: "should accept the 32 bytes keys",
+			keys: newTestLocalKeys("1", "2"),
+		},
+		{
+			name:    "should fail when the current version has no key",
+			keys:    LocalKeys{CurrentVersion: "2", Keys: newTestLocalKeys("1").Keys},
+			wantErr: true,
+		},
+		{
+			name:    "should fail when a key isn't base64 encoded",
+			keys:    LocalKeys{CurrentVersion: "1", Keys: map[string]string{"1": "not base64!"}},
+			wantErr: true,
+		},
+		{
+			name:    "should fail when a key isn't 32 bytes long",
+			keys:    LocalKeys{CurrentVersion: "1", Keys: map[string]string{"1": base64.StdEncoding.EncodeToString([]byte("short"))}},
+			wantErr: true,
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			_, err := newLocalKeyProvider(tt.keys)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
+
+func Test_Encrypter_EncryptDecrypt(t *testing.T) {
+	g := gomega.NewWithT(t)
+	encrypter := newTestEncrypter(t, "1")
+
+	encrypted, err := encrypter.Encrypt("client-secret")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(dbencryption.IsEncrypted(encrypted)).To(gomega.BeTrue())
+	g.Expect(encrypted).ToNot(gomega.ContainSubstring("client-secret"))
+
+	decrypted, err := encrypter.Decrypt(encrypted)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(decrypted).To(gomega.Equal("client-secret"))
+
+	// the values are encrypted with random nonces
+	encryptedAgain, err := encrypter.Encrypt("client-secret")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(encryptedAgain).ToNot(gomega.Equal(encrypted))
+
+	empty, err := encrypter.Encrypt("")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(empty).To(gomega.BeEmpty())
+
+	plaintext, err := encrypter.Decrypt("legacy-plain-text")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(plaintext).To(gomega.Equal("legacy-plain-text"))
+}
+
+func Test_Encrypter_KeyRotation(t *testing.T) {
+	g := gomega.NewWithT(t)
+	encrypted, err := newTestEncrypter(t, "1").Encrypt("client-secret")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	// the values encrypted with the previous version of the key can still be decrypted after the rotation
+	rotated := newTestEncrypter(t, "1", "2")
+	decrypted, err := rotated.Decrypt(encrypted)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(decrypted).To(gomega.Equal("client-secret"))
+
+	needsReencryption, err := rotated.NeedsReencryption(encrypted, "2")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(needsReencryption).To(gomega.BeTrue())
+
+	reencrypted, err := rotated.Encrypt(decrypted)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	needsReencryption, err = rotated.NeedsReencryption(reencrypted, "2")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(needsReencryption).To(gomega.BeFalse())
+
+	// the values can't be decrypted once the previous version of the key is removed
+	_, err = newTestEncrypter(t, "2").Decrypt(encrypted)
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func Test_Encrypter_NeedsReencryption(t *testing.T) {
+	encrypter := newTestEncrypter(t, "1")
+	encrypted, err := encrypter.Encrypt("client-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		value   string
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:  "should not re-encrypt the empty values",
+			value: "",
+			want:  false,
+		},
+		{
+			name:  "should encrypt the plain text values",
+			value: "client-secret",
+			want:  true,
+		},
+		{
+			name:  "should not re-encrypt the values encrypted with the current key",
+			value: encrypted,
+			want:  false,
+		},
+		{
+			name:    "should fail on invalid encrypted values",
+			value:   dbencryption.EncryptedPrefix + "invalid",
+			wantErr: true,
+		},
+		{
+			name:    "should fail on the values encrypted by another provider, that can't be decrypted",
+			value:   dbencryption.EncryptedPrefix + base64.StdEncoding.EncodeToString([]byte(`{"p":"aws-kms","v":"1"}`)),
+			wantErr: true,
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			got, err := encrypter.NeedsReencryption(tt.value, "1")
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_Encrypter_DefaultEncrypter(t *testing.T) {
+	g := gomega.NewWithT(t)
+	defer dbencryption.SetDefaultEncrypter(nil)
+
+	g.Expect(DefaultEncrypter()).To(gomega.BeNil())
+
+	encrypter := newTestEncrypter(t, "1")
+	dbencryption.SetDefaultEncrypter(encrypter)
+	g.Expect(DefaultEncrypter()).To(gomega.BeIdenticalTo(encrypter))
+
+	value, err := dbencryption.EncryptedString("client-secret").Value()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(dbencryption.IsEncrypted(value.(string))).To(gomega.BeTrue())
+
+	var scanned dbencryption.EncryptedString
+	g.Expect(scanned.Scan(value)).To(gomega.Succeed())
+	g.Expect(scanned).To(gomega.Equal(dbencryption.EncryptedString("client-secret")))
+}
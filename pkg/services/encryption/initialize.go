@@ -0,0 +1,34 @@
+package encryption
+
+import (
+	"fmt"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db/dbencryption"
+	"github.com/golang/glog"
+)
+
+// DefaultEncrypter returns the encrypter of the EncryptedString columns, it's nil when the encryption is disabled
+func DefaultEncrypter() *Encrypter {
+	encrypter, _ := dbencryption.DefaultEncrypter().(*Encrypter)
+	return encrypter
+}
+
+// Initialize sets the default encrypter of the configured key provider, checking that the current key can be retrieved
+func Initialize(config *EncryptionConfig) error {
+	provider, err := NewKeyProvider(config)
+	if err != nil {
+		return err
+	}
+	if provider == nil {
+		glog.Infof("Encryption at rest of the sensitive database columns is disabled")
+		dbencryption.SetDefaultEncrypter(nil)
+		return nil
+	}
+	keyVersion, err := provider.CurrentKeyVersion()
+	if err != nil {
+		return fmt.Errorf("failed to get the current key version of the %s db encryption provider: %w", provider.Name(), err)
+	}
+	glog.Infof("Encryption at rest of the sensitive database columns enabled with the %s provider, key version %s", provider.Name(), keyVersion)
+	dbencryption.SetDefaultEncrypter(NewEncrypter(provider, config.DataKeyTTL))
+	return nil
+}
@@ -0,0 +1,121 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+)
+
+// KeyProvider wraps the data encryption keys with the versioned key encryption keys of a key management service
+type KeyProvider interface {
+	// Name of the provider, stored with the encrypted values
+	Name() string
+	// CurrentKeyVersion returns the version of the key encryption key used to wrap the new data keys
+	CurrentKeyVersion() (string, error)
+	// WrapKey encrypts a data key with the current key encryption key, it returns the version of the key used
+	WrapKey(dataKey []byte) (wrapped []byte, keyVersion string, err error)
+	// UnwrapKey decrypts a data key with the given version of the key encryption key
+	UnwrapKey(wrapped []byte, keyVersion string) ([]byte, error)
+}
+
+// NewKeyProvider returns the key provider of the configuration, or nil when the encryption is disabled
+func NewKeyProvider(config *EncryptionConfig) (KeyProvider, error) {
+	switch config.Provider {
+	case ProviderNone:
+		return nil, nil
+	case ProviderLocal:
+		return newLocalKeyProvider(config.LocalKeys)
+	case ProviderAWSKMS:
+		return newAWSKMSKeyProvider(config)
+	case ProviderVaultTransit:
+		return newVaultTransitKeyProvider(config), nil
+	default:
+		return nil, fmt.Errorf("unknown db encryption provider %q", config.Provider)
+	}
+}
+
+var _ KeyProvider = &localKeyProvider{}
+
+// localKeyProvider wraps the data keys with AES-GCM keys read from a file, it's only meant for development and tests
+type localKeyProvider struct {
+	currentVersion string
+	keys           map[string]cipher.AEAD
+}
+
+func newLocalKeyProvider(localKeys LocalKeys) (*localKeyProvider, error) {
+	provider := &localKeyProvider{
+		currentVersion: localKeys.CurrentVersion,
+		keys:           map[string]cipher.AEAD{},
+	}
+	for version, encodedKey := range localKeys.Keys {
+		key, err := base64.StdEncoding.DecodeString(encodedKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid local encryption key %s: %v", version, err)
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid local encryption key %s: %v", version, err)
+		}
+		provider.keys[version] = aead
+	}
+	if _, ok := provider.keys[provider.currentVersion]; !ok {
+		return nil, fmt.Errorf("current local encryption key version %q not found in the keys file", provider.currentVersion)
+	}
+	return provider, nil
+}
+
+func (p *localKeyProvider) Name() string {
+	return ProviderLocal
+}
+
+func (p *localKeyProvider) CurrentKeyVersion() (string, error) {
+	return p.currentVersion, nil
+}
+
+func (p *localKeyProvider) WrapKey(dataKey []byte) ([]byte, string, error) {
+	wrapped, err := seal(p.keys[p.currentVersion], dataKey)
+	if err != nil {
+		return nil, "", err
+	}
+	return wrapped, p.currentVersion, nil
+}
+
+func (p *localKeyProvider) UnwrapKey(wrapped []byte, keyVersion string) ([]byte, error) {
+	aead, ok := p.keys[keyVersion]
+	if !ok {
+		return nil, fmt.Errorf("local encryption key version %q not found", keyVersion)
+	}
+	return open(aead, wrapped)
+}
+
+// newAEAD returns the AES-256-GCM cipher of the given key
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("expected a 32 bytes key, got %d bytes", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts the plain text with a random nonce, which is prepended to the cipher text
+func seal(aead cipher.AEAD, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(aead cipher.AEAD, sealed []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("encrypted value is too short")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, nil)
+}
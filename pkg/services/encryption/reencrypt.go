@@ -0,0 +1,116 @@
+package encryption
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// EncryptedColumn is a column of EncryptedString values, the modules provide the columns of their tables to re-encrypt
+// them when the encryption is enabled or the key encryption key is rotated. The table must have an "id" primary key.
+type EncryptedColumn struct {
+	Table  string
+	Column string
+}
+
+type ReencryptOptions struct {
+	// DryRun only counts the values that need to be re-encrypted
+	DryRun    bool
+	BatchSize int
+}
+
+type ReencryptResult struct {
+	EncryptedColumn
+	Scanned     int
+	Outdated    int
+	Reencrypted int
+	Failed      int
+}
+
+type encryptedRow struct {
+	ID    string
+	Value string
+}
+
+// Reencrypt encrypts the plain text values of the columns, and the values encrypted with another version of the key
+// encryption key than the current one. The values encrypted by another provider are counted as failed.
+// The soft deleted rows are re-encrypted too.
+func Reencrypt(dbConn *gorm.DB, encrypter *Encrypter, columns []EncryptedColumn, options ReencryptOptions) ([]ReencryptResult, error) {
+	keyVersion, err := encrypter.Provider().CurrentKeyVersion()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the current key version: %w", err)
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = 100
+	}
+
+	results := make([]ReencryptResult, 0, len(columns))
+	for _, column := range columns {
+		result, err := reencryptColumn(dbConn, encrypter, column, keyVersion, options)
+		if err != nil {
+			return results, fmt.Errorf("failed to re-encrypt %s.%s: %w", column.Table, column.Column, err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func reencryptColumn(dbConn *gorm.DB, encrypter *Encrypter, column EncryptedColumn, keyVersion string, options ReencryptOptions) (ReencryptResult, error) {
+	result := ReencryptResult{EncryptedColumn: column}
+	quotedColumn := fmt.Sprintf("%q", column.Column)
+
+	lastID := ""
+	for {
+		var rows []encryptedRow
+		if err := dbConn.Table(column.Table).
+			Select(fmt.Sprintf("id, %s AS value", quotedColumn)).
+			Where(fmt.Sprintf("id > ? AND %s IS NOT NULL AND %s <> ''", quotedColumn, quotedColumn), lastID).
+			Order("id").
+			Limit(options.BatchSize).
+			Find(&rows).Error; err != nil {
+			return result, err
+		}
+		if len(rows) == 0 {
+			return result, nil
+		}
+		lastID = rows[len(rows)-1].ID
+
+		for _, row := range rows {
+			result.Scanned++
+			outdated, err := encrypter.NeedsReencryption(row.Value, keyVersion)
+			if err != nil {
+				result.Failed++
+				continue
+			}
+			if !outdated {
+				continue
+			}
+			result.Outdated++
+			if options.DryRun {
+				continue
+			}
+
+			plaintext, err := encrypter.Decrypt(row.Value)
+			if err != nil {
+				result.Failed++
+				continue
+			}
+			encrypted, err := encrypter.Encrypt(plaintext)
+			if err != nil {
+				result.Failed++
+				continue
+			}
+			// the value is only replaced if it wasn't changed since it was read
+			update := dbConn.Table(column.Table).
+				Where(fmt.Sprintf("id = ? AND %s = ?", quotedColumn), row.ID, row.Value).
+				Update(column.Column, encrypted)
+			if update.Error != nil {
+				result.Failed++
+				continue
+			}
+			if update.RowsAffected == 1 {
+				result.Reencrypted++
+			}
+		}
+	}
+}
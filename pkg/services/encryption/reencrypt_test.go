@@ -0,0 +1,72 @@
+package encryption
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// newMockDB returns a mocket connection, pkg/db can't be imported as it depends on the encrypted columns of pkg/api
+func newMockDB(t *testing.T) *gorm.DB {
+	mocket.Catcher.Register()
+	sqlDB, err := sql.Open(mocket.DriverName, "connection_string")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dbConn, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dbConn
+}
+
+func Test_Reencrypt(t *testing.T) {
+	encrypter := newTestEncrypter(t, "1")
+	current, err := encrypter.Encrypt("current-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name            string
+		dryRun          bool
+		wantReencrypted int
+		wantUpdate      bool
+	}{
+		{
+			name:            "should encrypt the plain text values",
+			wantReencrypted: 1,
+			wantUpdate:      true,
+		},
+		{
+			name:   "should only count the outdated values on dry runs",
+			dryRun: true,
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().WithQuery(`SELECT id, "client_secret" AS value FROM "clusters"`).OneTime().WithReply([]map[string]interface{}{
+				{"id": "cluster-1", "value": "plain-text-secret"},
+				{"id": "cluster-2", "value": current},
+			})
+			mocket.Catcher.NewMock().WithQuery(`SELECT id, "client_secret" AS value FROM "clusters"`).WithReply(nil)
+			update := mocket.Catcher.NewMock().WithQuery(`UPDATE "clusters" SET "client_secret"`).WithRowsNum(1)
+
+			results, err := Reencrypt(newMockDB(t), encrypter,
+				[]EncryptedColumn{{Table: "clusters", Column: "client_secret"}}, ReencryptOptions{DryRun: tt.dryRun})
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(results).To(gomega.HaveLen(1))
+			g.Expect(results[0].Scanned).To(gomega.Equal(2))
+			g.Expect(results[0].Outdated).To(gomega.Equal(1))
+			g.Expect(results[0].Reencrypted).To(gomega.Equal(tt.wantReencrypted))
+			g.Expect(update.Triggered).To(gomega.Equal(tt.wantUpdate))
+		})
+	}
+}
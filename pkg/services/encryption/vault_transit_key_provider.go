@@ -0,0 +1,121 @@
+package encryption
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+)
+
+var _ KeyProvider = &vaultTransitKeyProvider{}
+
+// vaultTransitKeyProvider wraps the data keys with a key of a HashiCorp vault transit secrets engine,
+// the version of the wrapped keys is the version of the transit key
+type vaultTransitKeyProvider struct {
+	client  *http.Client
+	address string
+	token   string
+	mount   string
+	key     string
+}
+
+type vaultTransitResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []string        `json:"errors"`
+}
+
+func newVaultTransitKeyProvider(config *EncryptionConfig) *vaultTransitKeyProvider {
+	return &vaultTransitKeyProvider{
+		client:  &http.Client{Timeout: 30 * time.Second},
+		address: strings.TrimSuffix(config.VaultAddress, "/"),
+		token:   config.VaultToken,
+		mount:   strings.Trim(config.VaultTransitMount, "/"),
+		key:     config.VaultTransitKey,
+	}
+}
+
+func (p *vaultTransitKeyProvider) Name() string {
+	return ProviderVaultTransit
+}
+
+func (p *vaultTransitKeyProvider) CurrentKeyVersion() (string, error) {
+	var data struct {
+		LatestVersion int `json:"latest_version"`
+	}
+	if err := p.do(http.MethodGet, fmt.Sprintf("%s/keys/%s", p.mount, p.key), nil, &data); err != nil {
+		return "", err
+	}
+	return strconv.Itoa(data.LatestVersion), nil
+}
+
+func (p *vaultTransitKeyProvider) WrapKey(dataKey []byte) ([]byte, string, error) {
+	var data struct {
+		Ciphertext string `json:"ciphertext"`
+	}
+	body := map[string]string{"plaintext": base64.StdEncoding.EncodeToString(dataKey)}
+	if err := p.do(http.MethodPost, fmt.Sprintf("%s/encrypt/%s", p.mount, p.key), body, &data); err != nil {
+		return nil, "", err
+	}
+	// the cipher text of the transit engine is "vault:v<version>:<base64 cipher text>"
+	parts := strings.SplitN(data.Ciphertext, ":", 3)
+	if len(parts) != 3 || !strings.HasPrefix(parts[1], "v") {
+		return nil, "", fmt.Errorf("invalid vault transit cipher text")
+	}
+	return []byte(data.Ciphertext), strings.TrimPrefix(parts[1], "v"), nil
+}
+
+func (p *vaultTransitKeyProvider) UnwrapKey(wrapped []byte, keyVersion string) ([]byte, error) {
+	var data struct {
+		Plaintext string `json:"plaintext"`
+	}
+	body := map[string]string{"ciphertext": string(wrapped)}
+	if err := p.do(http.MethodPost, fmt.Sprintf("%s/decrypt/%s", p.mount, p.key), body, &data); err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(data.Plaintext)
+}
+
+func (p *vaultTransitKeyProvider) do(method string, path string, body interface{}, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		content, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(content)
+	}
+	req, err := http.NewRequest(method, fmt.Sprintf("%s/v1/%s", p.address, path), reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", p.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("vault transit request %s %s failed: %w", method, path, err)
+	}
+	defer shared.CloseQuietly(resp.Body)()
+
+	var response vaultTransitResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil && err != io.EOF {
+		return fmt.Errorf("invalid vault transit response to %s %s: %w", method, path, err)
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("vault transit request %s %s failed with status %d: %s",
+			method, path, resp.StatusCode, strings.Join(response.Errors, ", "))
+	}
+	if err := json.Unmarshal(response.Data, result); err != nil {
+		return fmt.Errorf("invalid vault transit response data to %s %s: %w", method, path, err)
+	}
+	return nil
+}
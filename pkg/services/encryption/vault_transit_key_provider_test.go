@@ -0,0 +1,71 @@
+package encryption
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+// newFakeTransitServer returns a vault transit engine "encrypting" the values by prefixing them with the key version
+func newFakeTransitServer(t *testing.T, latestVersion string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		var data map[string]interface{}
+		switch r.URL.Path {
+		case "/v1/transit/keys/kfm":
+			data = map[string]interface{}{"latest_version": json.Number(latestVersion)}
+		case "/v1/transit/encrypt/kfm":
+			data = map[string]interface{}{"ciphertext": "vault:v" + latestVersion + ":" + body["plaintext"]}
+		case "/v1/transit/decrypt/kfm":
+			parts := strings.SplitN(body["ciphertext"], ":", 3)
+			data = map[string]interface{}{"plaintext": parts[2]}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewEncoder(w).Encode(map[string]interface{}{"data": data}); err != nil {
+			t.Error(err)
+		}
+	}))
+}
+
+func Test_vaultTransitKeyProvider(t *testing.T) {
+	g := gomega.NewWithT(t)
+	server := newFakeTransitServer(t, "3")
+	defer server.Close()
+
+	provider := newVaultTransitKeyProvider(&EncryptionConfig{
+		VaultAddress:      server.URL + "/",
+		VaultToken:        "token",
+		VaultTransitMount: "/transit/",
+		VaultTransitKey:   "kfm",
+	})
+
+	version, err := provider.CurrentKeyVersion()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(version).To(gomega.Equal("3"))
+
+	wrapped, version, err := provider.WrapKey([]byte("data-key"))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(version).To(gomega.Equal("3"))
+	g.Expect(string(wrapped)).To(gomega.Equal("vault:v3:" + base64.StdEncoding.EncodeToString([]byte("data-key"))))
+
+	unwrapped, err := provider.UnwrapKey(wrapped, version)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(unwrapped).To(gomega.Equal([]byte("data-key")))
+
+	provider.token = "invalid"
+	_, err = provider.CurrentKeyVersion()
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("permission denied")))
+}
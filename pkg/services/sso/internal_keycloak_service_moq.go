@@ -63,6 +63,9 @@ var _ keycloakServiceInternal = &keycloakServiceInternalMock{}
 //			ListServiceAccFunc: func(accessToken string, ctx context.Context, first int, max int) ([]api.ServiceAccount, *errors.ServiceError) {
 //				panic("mock out the ListServiceAcc method")
 //			},
+//			ListServiceAccountsInternalFunc: func(accessToken string, first int, max int) ([]api.ServiceAccount, *errors.ServiceError) {
+//				panic("mock out the ListServiceAccountsInternal method")
+//			},
 //			RegisterClientInSSOFunc: func(accessToken string, clusterId string, clusterOathCallbackURI string) (string, *errors.ServiceError) {
 //				panic("mock out the RegisterClientInSSO method")
 //			},
@@ -75,6 +78,9 @@ var _ keycloakServiceInternal = &keycloakServiceInternalMock{}
 //			ResetServiceAccountCredentialsFunc: func(accessToken string, ctx context.Context, clientId string) (*api.ServiceAccount, *errors.ServiceError) {
 //				panic("mock out the ResetServiceAccountCredentials method")
 //			},
+//			ResetServiceAccountCredentialsInternalFunc: func(accessToken string, id string) (*api.ServiceAccount, *errors.ServiceError) {
+//				panic("mock out the ResetServiceAccountCredentialsInternal method")
+//			},
 //		}
 //
 //		// use mockedkeycloakServiceInternal in code that requires keycloakServiceInternal
@@ -124,6 +130,9 @@ type keycloakServiceInternalMock struct {
 	// ListServiceAccFunc mocks the ListServiceAcc method.
 	ListServiceAccFunc func(accessToken string, ctx context.Context, first int, max int) ([]api.ServiceAccount, *errors.ServiceError)
 
+	// ListServiceAccountsInternalFunc mocks the ListServiceAccountsInternal method.
+	ListServiceAccountsInternalFunc func(accessToken string, first int, max int) ([]api.ServiceAccount, *errors.ServiceError)
+
 	// RegisterClientInSSOFunc mocks the RegisterClientInSSO method.
 	RegisterClientInSSOFunc func(accessToken string, clusterId string, clusterOathCallbackURI string) (string, *errors.ServiceError)
 
@@ -136,6 +145,9 @@ type keycloakServiceInternalMock struct {
 	// ResetServiceAccountCredentialsFunc mocks the ResetServiceAccountCredentials method.
 	ResetServiceAccountCredentialsFunc func(accessToken string, ctx context.Context, clientId string) (*api.ServiceAccount, *errors.ServiceError)
 
+	// ResetServiceAccountCredentialsInternalFunc mocks the ResetServiceAccountCredentialsInternal method.
+	ResetServiceAccountCredentialsInternalFunc func(accessToken string, id string) (*api.ServiceAccount, *errors.ServiceError)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// CreateServiceAccount holds details about calls to the CreateServiceAccount method.
@@ -240,6 +252,15 @@ type keycloakServiceInternalMock struct {
 			// Max is the max argument value.
 			Max int
 		}
+		// ListServiceAccountsInternal holds details about calls to the ListServiceAccountsInternal method.
+		ListServiceAccountsInternal []struct {
+			// AccessToken is the accessToken argument value.
+			AccessToken string
+			// First is the first argument value.
+			First int
+			// Max is the max argument value.
+			Max int
+		}
 		// RegisterClientInSSO holds details about calls to the RegisterClientInSSO method.
 		RegisterClientInSSO []struct {
 			// AccessToken is the accessToken argument value.
@@ -272,6 +293,13 @@ type keycloakServiceInternalMock struct {
 			// ClientId is the clientId argument value.
 			ClientId string
 		}
+		// ResetServiceAccountCredentialsInternal holds details about calls to the ResetServiceAccountCredentialsInternal method.
+		ResetServiceAccountCredentialsInternal []struct {
+			// AccessToken is the accessToken argument value.
+			AccessToken string
+			// ID is the id argument value.
+			ID string
+		}
 	}
 	lockCreateServiceAccount                                sync.RWMutex
 	lockCreateServiceAccountInternal                        sync.RWMutex
@@ -287,10 +315,12 @@ type keycloakServiceInternalMock struct {
 	lockGetServiceAccountById                               sync.RWMutex
 	lockIsKafkaClientExist                                  sync.RWMutex
 	lockListServiceAcc                                      sync.RWMutex
+	lockListServiceAccountsInternal                         sync.RWMutex
 	lockRegisterClientInSSO                                 sync.RWMutex
 	lockRegisterConnectorFleetshardOperatorServiceAccount   sync.RWMutex
 	lockRegisterKasFleetshardOperatorServiceAccount         sync.RWMutex
 	lockResetServiceAccountCredentials                      sync.RWMutex
+	lockResetServiceAccountCredentialsInternal              sync.RWMutex
 }
 
 // CreateServiceAccount calls CreateServiceAccountFunc.
@@ -803,6 +833,46 @@ func (mock *keycloakServiceInternalMock) ListServiceAccCalls() []struct {
 	return calls
 }
 
+// ListServiceAccountsInternal calls ListServiceAccountsInternalFunc.
+func (mock *keycloakServiceInternalMock) ListServiceAccountsInternal(accessToken string, first int, max int) ([]api.ServiceAccount, *errors.ServiceError) {
+	if mock.ListServiceAccountsInternalFunc == nil {
+		panic("keycloakServiceInternalMock.ListServiceAccountsInternalFunc: method is nil but keycloakServiceInternal.ListServiceAccountsInternal was just called")
+	}
+	callInfo := struct {
+		AccessToken string
+		First       int
+		Max         int
+	}{
+		AccessToken: accessToken,
+		First:       first,
+		Max:         max,
+	}
+	mock.lockListServiceAccountsInternal.Lock()
+	mock.calls.ListServiceAccountsInternal = append(mock.calls.ListServiceAccountsInternal, callInfo)
+	mock.lockListServiceAccountsInternal.Unlock()
+	return mock.ListServiceAccountsInternalFunc(accessToken, first, max)
+}
+
+// ListServiceAccountsInternalCalls gets all the calls that were made to ListServiceAccountsInternal.
+// Check the length with:
+//
+//	len(mockedkeycloakServiceInternal.ListServiceAccountsInternalCalls())
+func (mock *keycloakServiceInternalMock) ListServiceAccountsInternalCalls() []struct {
+	AccessToken string
+	First       int
+	Max         int
+} {
+	var calls []struct {
+		AccessToken string
+		First       int
+		Max         int
+	}
+	mock.lockListServiceAccountsInternal.RLock()
+	calls = mock.calls.ListServiceAccountsInternal
+	mock.lockListServiceAccountsInternal.RUnlock()
+	return calls
+}
+
 // RegisterClientInSSO calls RegisterClientInSSOFunc.
 func (mock *keycloakServiceInternalMock) RegisterClientInSSO(accessToken string, clusterId string, clusterOathCallbackURI string) (string, *errors.ServiceError) {
 	if mock.RegisterClientInSSOFunc == nil {
@@ -954,3 +1024,39 @@ func (mock *keycloakServiceInternalMock) ResetServiceAccountCredentialsCalls() [
 	mock.lockResetServiceAccountCredentials.RUnlock()
 	return calls
 }
+
+// ResetServiceAccountCredentialsInternal calls ResetServiceAccountCredentialsInternalFunc.
+func (mock *keycloakServiceInternalMock) ResetServiceAccountCredentialsInternal(accessToken string, id string) (*api.ServiceAccount, *errors.ServiceError) {
+	if mock.ResetServiceAccountCredentialsInternalFunc == nil {
+		panic("keycloakServiceInternalMock.ResetServiceAccountCredentialsInternalFunc: method is nil but keycloakServiceInternal.ResetServiceAccountCredentialsInternal was just called")
+	}
+	callInfo := struct {
+		AccessToken string
+		ID          string
+	}{
+		AccessToken: accessToken,
+		ID:          id,
+	}
+	mock.lockResetServiceAccountCredentialsInternal.Lock()
+	mock.calls.ResetServiceAccountCredentialsInternal = append(mock.calls.ResetServiceAccountCredentialsInternal, callInfo)
+	mock.lockResetServiceAccountCredentialsInternal.Unlock()
+	return mock.ResetServiceAccountCredentialsInternalFunc(accessToken, id)
+}
+
+// ResetServiceAccountCredentialsInternalCalls gets all the calls that were made to ResetServiceAccountCredentialsInternal.
+// Check the length with:
+//
+//	len(mockedkeycloakServiceInternal.ResetServiceAccountCredentialsInternalCalls())
+func (mock *keycloakServiceInternalMock) ResetServiceAccountCredentialsInternalCalls() []struct {
+	AccessToken string
+	ID          string
+} {
+	var calls []struct {
+		AccessToken string
+		ID          string
+	}
+	mock.lockResetServiceAccountCredentialsInternal.RLock()
+	calls = mock.calls.ResetServiceAccountCredentialsInternal
+	mock.lockResetServiceAccountCredentialsInternal.RUnlock()
+	return calls
+}
@@ -36,6 +36,12 @@ type KeycloakService interface {
 	GetKafkaClientSecret(clientId string) (string, *errors.ServiceError)
 	CreateServiceAccountInternal(request CompleteServiceAccountRequest) (*api.ServiceAccount, *errors.ServiceError)
 	DeleteServiceAccountInternal(clientId string) *errors.ServiceError
+	// ResetServiceAccountCredentialsInternal regenerates the secret of a service account on behalf of the fleet manager,
+	// i.e. to revoke the expired credentials
+	ResetServiceAccountCredentialsInternal(id string) (*api.ServiceAccount, *errors.ServiceError)
+	// ListServiceAccountsInternal lists the service accounts of all the organisations on behalf of the fleet manager, with their
+	// organisation, i.e. to track their lifecycle
+	ListServiceAccountsInternal(first int, max int) ([]api.ServiceAccount, *errors.ServiceError)
 }
 
 //go:generate moq -out osd_keycloak_service_moq.go . OSDKeycloakService
@@ -65,6 +71,8 @@ type keycloakServiceInternal interface {
 	GetKafkaClientSecret(accessToken string, clientId string) (string, *errors.ServiceError)
 	CreateServiceAccountInternal(accessToken string, request CompleteServiceAccountRequest) (*api.ServiceAccount, *errors.ServiceError)
 	DeleteServiceAccountInternal(accessToken string, clientId string) *errors.ServiceError
+	ResetServiceAccountCredentialsInternal(accessToken string, id string) (*api.ServiceAccount, *errors.ServiceError)
+	ListServiceAccountsInternal(accessToken string, first int, max int) ([]api.ServiceAccount, *errors.ServiceError)
 }
 
 func NewKeycloakServiceBuilder() KeycloakServiceBuilderSelector {
@@ -331,6 +331,57 @@ func (kc *masService) ResetServiceAccountCredentials(accessToken string, ctx con
 	}
 }
 
+func (kc *masService) ResetServiceAccountCredentialsInternal(accessToken string, id string) (*api.ServiceAccount, *errors.ServiceError) {
+	c, err := kc.kcClient.GetClientById(id, accessToken)
+	if err != nil { //5xx or 4xx
+		return nil, handleKeyCloakGetClientError(err, id)
+	}
+	if !strings.HasPrefix(shared.SafeString(c.ClientID), UserServiceAccountPrefix) {
+		return nil, errors.NewWithCause(errors.ErrorServiceAccountNotFound, err, "service account not found %s", id)
+	}
+	credRep, err := kc.kcClient.RegenerateClientSecret(accessToken, id)
+	if err != nil { //5xx
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to reset service account credentials")
+	}
+	glog.V(5).Infof("Credentials of client %s with internal id = %s regenerated", shared.SafeString(c.ClientID), id)
+	return &api.ServiceAccount{
+		ID:           id,
+		ClientID:     shared.SafeString(c.ClientID),
+		ClientSecret: shared.SafeString(credRep.Value),
+		Name:         shared.SafeString(c.Name),
+		Description:  shared.SafeString(c.Description),
+	}, nil
+}
+
+func (kc *masService) ListServiceAccountsInternal(accessToken string, first int, max int) ([]api.ServiceAccount, *errors.ServiceError) {
+	clients, err := kc.kcClient.GetClients(accessToken, first, max, "")
+	if err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to collect service accounts")
+	}
+
+	var sa []api.ServiceAccount
+	for _, client := range clients {
+		if !strings.HasPrefix(shared.SafeString(client.ClientID), UserServiceAccountPrefix) || client.Attributes == nil {
+			continue
+		}
+		att := *client.Attributes
+		createdAt, err := time.Parse(time.RFC3339, att["created_at"])
+		if err != nil {
+			createdAt = time.Time{}
+		}
+		sa = append(sa, api.ServiceAccount{
+			ID:          shared.SafeString(client.ID),
+			ClientID:    shared.SafeString(client.ClientID),
+			Name:        shared.SafeString(client.Name),
+			Description: shared.SafeString(client.Description),
+			CreatedBy:   att["username"],
+			CreatedAt:   createdAt,
+			OrgId:       att[rhOrgId],
+		})
+	}
+	return sa, nil
+}
+
 // return error object for API caller facing funcs: 5xx or 4xx
 func handleKeyCloakGetClientError(err error, id string) *errors.ServiceError {
 	if keyErr, ok := err.(*gocloak.APIError); ok {
@@ -166,6 +166,22 @@ func (r *keycloakServiceProxy) DeleteServiceAccountInternal(clientId string) *er
 	}
 }
 
+func (r *keycloakServiceProxy) ResetServiceAccountCredentialsInternal(id string) (*api.ServiceAccount, *errors.ServiceError) {
+	if token, err := r.retrieveToken(); err != nil {
+		return nil, err
+	} else {
+		return r.service.ResetServiceAccountCredentialsInternal(token, id)
+	}
+}
+
+func (r *keycloakServiceProxy) ListServiceAccountsInternal(first int, max int) ([]api.ServiceAccount, *errors.ServiceError) {
+	if token, err := r.retrieveToken(); err != nil {
+		return nil, err
+	} else {
+		return r.service.ListServiceAccountsInternal(token, first, max)
+	}
+}
+
 // Utility functions
 
 func (r *keycloakServiceProxy) retrieveToken() (string, *errors.ServiceError) {
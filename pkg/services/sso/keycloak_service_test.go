@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/Nerzal/gocloak/v11"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
@@ -1533,6 +1534,63 @@ func Test_masService_ListServiceAcc(t *testing.T) {
 	}
 }
 
+func Test_masService_ListServiceAccountsInternal(t *testing.T) {
+	id := "id"
+	createdAt := "2023-01-02T03:04:05Z"
+	tests := []struct {
+		name     string
+		kcClient keycloak.KcClient
+		want     []api.ServiceAccount
+		wantErr  bool
+	}{
+		{
+			name: "should return the service accounts of all the organisations with their organisation",
+			kcClient: &keycloak.KcClientMock{
+				GetClientsFunc: func(accessToken string, first, max int, attribute string) ([]*gocloak.Client, error) {
+					userAccount := "srvc-acct-1"
+					canary := "canary-1"
+					return []*gocloak.Client{
+						{ID: &id, ClientID: &userAccount, Attributes: &map[string]string{rhOrgId: "org-id", "username": "owner", "created_at": createdAt}},
+						{ID: &id, ClientID: &canary, Attributes: &map[string]string{rhOrgId: "org-id"}},
+					}, nil
+				},
+			},
+			want: []api.ServiceAccount{
+				{
+					ID:        "id",
+					ClientID:  "srvc-acct-1",
+					CreatedBy: "owner",
+					CreatedAt: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+					OrgId:     "org-id",
+				},
+			},
+		},
+		{
+			name: "should return an error when it fails to collect service accounts",
+			kcClient: &keycloak.KcClientMock{
+				GetClientsFunc: func(accessToken string, first, max int, attribute string) ([]*gocloak.Client, error) {
+					return nil, errors.GeneralError("failed to collect service accounts")
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			kc := &masService{
+				kcClient: tt.kcClient,
+			}
+			got, err := kc.ListServiceAccountsInternal(token, 0, 10)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
 func Test_masService_DeleteServiceAccount(t *testing.T) {
 	type fields struct {
 		kcClient keycloak.KcClient
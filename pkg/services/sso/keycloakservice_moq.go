@@ -60,6 +60,9 @@ var _ KeycloakService = &KeycloakServiceMock{}
 //			ListServiceAccFunc: func(ctx context.Context, first int, max int) ([]api.ServiceAccount, *errors.ServiceError) {
 //				panic("mock out the ListServiceAcc method")
 //			},
+//			ListServiceAccountsInternalFunc: func(first int, max int) ([]api.ServiceAccount, *errors.ServiceError) {
+//				panic("mock out the ListServiceAccountsInternal method")
+//			},
 //			RegisterConnectorFleetshardOperatorServiceAccountFunc: func(agentClusterId string) (*api.ServiceAccount, *errors.ServiceError) {
 //				panic("mock out the RegisterConnectorFleetshardOperatorServiceAccount method")
 //			},
@@ -69,6 +72,9 @@ var _ KeycloakService = &KeycloakServiceMock{}
 //			ResetServiceAccountCredentialsFunc: func(ctx context.Context, clientId string) (*api.ServiceAccount, *errors.ServiceError) {
 //				panic("mock out the ResetServiceAccountCredentials method")
 //			},
+//			ResetServiceAccountCredentialsInternalFunc: func(id string) (*api.ServiceAccount, *errors.ServiceError) {
+//				panic("mock out the ResetServiceAccountCredentialsInternal method")
+//			},
 //		}
 //
 //		// use mockedKeycloakService in code that requires KeycloakService
@@ -115,6 +121,9 @@ type KeycloakServiceMock struct {
 	// ListServiceAccFunc mocks the ListServiceAcc method.
 	ListServiceAccFunc func(ctx context.Context, first int, max int) ([]api.ServiceAccount, *errors.ServiceError)
 
+	// ListServiceAccountsInternalFunc mocks the ListServiceAccountsInternal method.
+	ListServiceAccountsInternalFunc func(first int, max int) ([]api.ServiceAccount, *errors.ServiceError)
+
 	// RegisterConnectorFleetshardOperatorServiceAccountFunc mocks the RegisterConnectorFleetshardOperatorServiceAccount method.
 	RegisterConnectorFleetshardOperatorServiceAccountFunc func(agentClusterId string) (*api.ServiceAccount, *errors.ServiceError)
 
@@ -124,6 +133,9 @@ type KeycloakServiceMock struct {
 	// ResetServiceAccountCredentialsFunc mocks the ResetServiceAccountCredentials method.
 	ResetServiceAccountCredentialsFunc func(ctx context.Context, clientId string) (*api.ServiceAccount, *errors.ServiceError)
 
+	// ResetServiceAccountCredentialsInternalFunc mocks the ResetServiceAccountCredentialsInternal method.
+	ResetServiceAccountCredentialsInternalFunc func(id string) (*api.ServiceAccount, *errors.ServiceError)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// CreateServiceAccount holds details about calls to the CreateServiceAccount method.
@@ -199,6 +211,13 @@ type KeycloakServiceMock struct {
 			// Max is the max argument value.
 			Max int
 		}
+		// ListServiceAccountsInternal holds details about calls to the ListServiceAccountsInternal method.
+		ListServiceAccountsInternal []struct {
+			// First is the first argument value.
+			First int
+			// Max is the max argument value.
+			Max int
+		}
 		// RegisterConnectorFleetshardOperatorServiceAccount holds details about calls to the RegisterConnectorFleetshardOperatorServiceAccount method.
 		RegisterConnectorFleetshardOperatorServiceAccount []struct {
 			// AgentClusterId is the agentClusterId argument value.
@@ -216,6 +235,11 @@ type KeycloakServiceMock struct {
 			// ClientId is the clientId argument value.
 			ClientId string
 		}
+		// ResetServiceAccountCredentialsInternal holds details about calls to the ResetServiceAccountCredentialsInternal method.
+		ResetServiceAccountCredentialsInternal []struct {
+			// ID is the id argument value.
+			ID string
+		}
 	}
 	lockCreateServiceAccount                                sync.RWMutex
 	lockCreateServiceAccountInternal                        sync.RWMutex
@@ -230,9 +254,11 @@ type KeycloakServiceMock struct {
 	lockGetServiceAccountById                               sync.RWMutex
 	lockIsKafkaClientExist                                  sync.RWMutex
 	lockListServiceAcc                                      sync.RWMutex
+	lockListServiceAccountsInternal                         sync.RWMutex
 	lockRegisterConnectorFleetshardOperatorServiceAccount   sync.RWMutex
 	lockRegisterKasFleetshardOperatorServiceAccount         sync.RWMutex
 	lockResetServiceAccountCredentials                      sync.RWMutex
+	lockResetServiceAccountCredentialsInternal              sync.RWMutex
 }
 
 // CreateServiceAccount calls CreateServiceAccountFunc.
@@ -665,6 +691,42 @@ func (mock *KeycloakServiceMock) ListServiceAccCalls() []struct {
 	return calls
 }
 
+// ListServiceAccountsInternal calls ListServiceAccountsInternalFunc.
+func (mock *KeycloakServiceMock) ListServiceAccountsInternal(first int, max int) ([]api.ServiceAccount, *errors.ServiceError) {
+	if mock.ListServiceAccountsInternalFunc == nil {
+		panic("KeycloakServiceMock.ListServiceAccountsInternalFunc: method is nil but KeycloakService.ListServiceAccountsInternal was just called")
+	}
+	callInfo := struct {
+		First int
+		Max   int
+	}{
+		First: first,
+		Max:   max,
+	}
+	mock.lockListServiceAccountsInternal.Lock()
+	mock.calls.ListServiceAccountsInternal = append(mock.calls.ListServiceAccountsInternal, callInfo)
+	mock.lockListServiceAccountsInternal.Unlock()
+	return mock.ListServiceAccountsInternalFunc(first, max)
+}
+
+// ListServiceAccountsInternalCalls gets all the calls that were made to ListServiceAccountsInternal.
+// Check the length with:
+//
+//	len(mockedKeycloakService.ListServiceAccountsInternalCalls())
+func (mock *KeycloakServiceMock) ListServiceAccountsInternalCalls() []struct {
+	First int
+	Max   int
+} {
+	var calls []struct {
+		First int
+		Max   int
+	}
+	mock.lockListServiceAccountsInternal.RLock()
+	calls = mock.calls.ListServiceAccountsInternal
+	mock.lockListServiceAccountsInternal.RUnlock()
+	return calls
+}
+
 // RegisterConnectorFleetshardOperatorServiceAccount calls RegisterConnectorFleetshardOperatorServiceAccountFunc.
 func (mock *KeycloakServiceMock) RegisterConnectorFleetshardOperatorServiceAccount(agentClusterId string) (*api.ServiceAccount, *errors.ServiceError) {
 	if mock.RegisterConnectorFleetshardOperatorServiceAccountFunc == nil {
@@ -764,3 +826,35 @@ func (mock *KeycloakServiceMock) ResetServiceAccountCredentialsCalls() []struct
 	mock.lockResetServiceAccountCredentials.RUnlock()
 	return calls
 }
+
+// ResetServiceAccountCredentialsInternal calls ResetServiceAccountCredentialsInternalFunc.
+func (mock *KeycloakServiceMock) ResetServiceAccountCredentialsInternal(id string) (*api.ServiceAccount, *errors.ServiceError) {
+	if mock.ResetServiceAccountCredentialsInternalFunc == nil {
+		panic("KeycloakServiceMock.ResetServiceAccountCredentialsInternalFunc: method is nil but KeycloakService.ResetServiceAccountCredentialsInternal was just called")
+	}
+	callInfo := struct {
+		ID string
+	}{
+		ID: id,
+	}
+	mock.lockResetServiceAccountCredentialsInternal.Lock()
+	mock.calls.ResetServiceAccountCredentialsInternal = append(mock.calls.ResetServiceAccountCredentialsInternal, callInfo)
+	mock.lockResetServiceAccountCredentialsInternal.Unlock()
+	return mock.ResetServiceAccountCredentialsInternalFunc(id)
+}
+
+// ResetServiceAccountCredentialsInternalCalls gets all the calls that were made to ResetServiceAccountCredentialsInternal.
+// Check the length with:
+//
+//	len(mockedKeycloakService.ResetServiceAccountCredentialsInternalCalls())
+func (mock *KeycloakServiceMock) ResetServiceAccountCredentialsInternalCalls() []struct {
+	ID string
+} {
+	var calls []struct {
+		ID string
+	}
+	mock.lockResetServiceAccountCredentialsInternal.RLock()
+	calls = mock.calls.ResetServiceAccountCredentialsInternal
+	mock.lockResetServiceAccountCredentialsInternal.RUnlock()
+	return calls
+}
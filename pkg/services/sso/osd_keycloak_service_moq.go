@@ -63,6 +63,9 @@ var _ OSDKeycloakService = &OSDKeycloakServiceMock{}
 //			ListServiceAccFunc: func(ctx context.Context, first int, max int) ([]api.ServiceAccount, *errors.ServiceError) {
 //				panic("mock out the ListServiceAcc method")
 //			},
+//			ListServiceAccountsInternalFunc: func(first int, max int) ([]api.ServiceAccount, *errors.ServiceError) {
+//				panic("mock out the ListServiceAccountsInternal method")
+//			},
 //			RegisterClientInSSOFunc: func(clusterId string, clusterOathCallbackURI string) (string, *errors.ServiceError) {
 //				panic("mock out the RegisterClientInSSO method")
 //			},
@@ -75,6 +78,9 @@ var _ OSDKeycloakService = &OSDKeycloakServiceMock{}
 //			ResetServiceAccountCredentialsFunc: func(ctx context.Context, clientId string) (*api.ServiceAccount, *errors.ServiceError) {
 //				panic("mock out the ResetServiceAccountCredentials method")
 //			},
+//			ResetServiceAccountCredentialsInternalFunc: func(id string) (*api.ServiceAccount, *errors.ServiceError) {
+//				panic("mock out the ResetServiceAccountCredentialsInternal method")
+//			},
 //		}
 //
 //		// use mockedOSDKeycloakService in code that requires OSDKeycloakService
@@ -124,6 +130,9 @@ type OSDKeycloakServiceMock struct {
 	// ListServiceAccFunc mocks the ListServiceAcc method.
 	ListServiceAccFunc func(ctx context.Context, first int, max int) ([]api.ServiceAccount, *errors.ServiceError)
 
+	// ListServiceAccountsInternalFunc mocks the ListServiceAccountsInternal method.
+	ListServiceAccountsInternalFunc func(first int, max int) ([]api.ServiceAccount, *errors.ServiceError)
+
 	// RegisterClientInSSOFunc mocks the RegisterClientInSSO method.
 	RegisterClientInSSOFunc func(clusterId string, clusterOathCallbackURI string) (string, *errors.ServiceError)
 
@@ -136,6 +145,9 @@ type OSDKeycloakServiceMock struct {
 	// ResetServiceAccountCredentialsFunc mocks the ResetServiceAccountCredentials method.
 	ResetServiceAccountCredentialsFunc func(ctx context.Context, clientId string) (*api.ServiceAccount, *errors.ServiceError)
 
+	// ResetServiceAccountCredentialsInternalFunc mocks the ResetServiceAccountCredentialsInternal method.
+	ResetServiceAccountCredentialsInternalFunc func(id string) (*api.ServiceAccount, *errors.ServiceError)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// CreateServiceAccount holds details about calls to the CreateServiceAccount method.
@@ -216,6 +228,13 @@ type OSDKeycloakServiceMock struct {
 			// Max is the max argument value.
 			Max int
 		}
+		// ListServiceAccountsInternal holds details about calls to the ListServiceAccountsInternal method.
+		ListServiceAccountsInternal []struct {
+			// First is the first argument value.
+			First int
+			// Max is the max argument value.
+			Max int
+		}
 		// RegisterClientInSSO holds details about calls to the RegisterClientInSSO method.
 		RegisterClientInSSO []struct {
 			// ClusterId is the clusterId argument value.
@@ -240,6 +259,11 @@ type OSDKeycloakServiceMock struct {
 			// ClientId is the clientId argument value.
 			ClientId string
 		}
+		// ResetServiceAccountCredentialsInternal holds details about calls to the ResetServiceAccountCredentialsInternal method.
+		ResetServiceAccountCredentialsInternal []struct {
+			// ID is the id argument value.
+			ID string
+		}
 	}
 	lockCreateServiceAccount                                sync.RWMutex
 	lockCreateServiceAccountInternal                        sync.RWMutex
@@ -255,10 +279,12 @@ type OSDKeycloakServiceMock struct {
 	lockGetServiceAccountById                               sync.RWMutex
 	lockIsKafkaClientExist                                  sync.RWMutex
 	lockListServiceAcc                                      sync.RWMutex
+	lockListServiceAccountsInternal                         sync.RWMutex
 	lockRegisterClientInSSO                                 sync.RWMutex
 	lockRegisterConnectorFleetshardOperatorServiceAccount   sync.RWMutex
 	lockRegisterKasFleetshardOperatorServiceAccount         sync.RWMutex
 	lockResetServiceAccountCredentials                      sync.RWMutex
+	lockResetServiceAccountCredentialsInternal              sync.RWMutex
 }
 
 // CreateServiceAccount calls CreateServiceAccountFunc.
@@ -723,6 +749,42 @@ func (mock *OSDKeycloakServiceMock) ListServiceAccCalls() []struct {
 	return calls
 }
 
+// ListServiceAccountsInternal calls ListServiceAccountsInternalFunc.
+func (mock *OSDKeycloakServiceMock) ListServiceAccountsInternal(first int, max int) ([]api.ServiceAccount, *errors.ServiceError) {
+	if mock.ListServiceAccountsInternalFunc == nil {
+		panic("OSDKeycloakServiceMock.ListServiceAccountsInternalFunc: method is nil but OSDKeycloakService.ListServiceAccountsInternal was just called")
+	}
+	callInfo := struct {
+		First int
+		Max   int
+	}{
+		First: first,
+		Max:   max,
+	}
+	mock.lockListServiceAccountsInternal.Lock()
+	mock.calls.ListServiceAccountsInternal = append(mock.calls.ListServiceAccountsInternal, callInfo)
+	mock.lockListServiceAccountsInternal.Unlock()
+	return mock.ListServiceAccountsInternalFunc(first, max)
+}
+
+// ListServiceAccountsInternalCalls gets all the calls that were made to ListServiceAccountsInternal.
+// Check the length with:
+//
+//	len(mockedOSDKeycloakService.ListServiceAccountsInternalCalls())
+func (mock *OSDKeycloakServiceMock) ListServiceAccountsInternalCalls() []struct {
+	First int
+	Max   int
+} {
+	var calls []struct {
+		First int
+		Max   int
+	}
+	mock.lockListServiceAccountsInternal.RLock()
+	calls = mock.calls.ListServiceAccountsInternal
+	mock.lockListServiceAccountsInternal.RUnlock()
+	return calls
+}
+
 // RegisterClientInSSO calls RegisterClientInSSOFunc.
 func (mock *OSDKeycloakServiceMock) RegisterClientInSSO(clusterId string, clusterOathCallbackURI string) (string, *errors.ServiceError) {
 	if mock.RegisterClientInSSOFunc == nil {
@@ -858,3 +920,35 @@ func (mock *OSDKeycloakServiceMock) ResetServiceAccountCredentialsCalls() []stru
 	mock.lockResetServiceAccountCredentials.RUnlock()
 	return calls
 }
+
+// ResetServiceAccountCredentialsInternal calls ResetServiceAccountCredentialsInternalFunc.
+func (mock *OSDKeycloakServiceMock) ResetServiceAccountCredentialsInternal(id string) (*api.ServiceAccount, *errors.ServiceError) {
+	if mock.ResetServiceAccountCredentialsInternalFunc == nil {
+		panic("OSDKeycloakServiceMock.ResetServiceAccountCredentialsInternalFunc: method is nil but OSDKeycloakService.ResetServiceAccountCredentialsInternal was just called")
+	}
+	callInfo := struct {
+		ID string
+	}{
+		ID: id,
+	}
+	mock.lockResetServiceAccountCredentialsInternal.Lock()
+	mock.calls.ResetServiceAccountCredentialsInternal = append(mock.calls.ResetServiceAccountCredentialsInternal, callInfo)
+	mock.lockResetServiceAccountCredentialsInternal.Unlock()
+	return mock.ResetServiceAccountCredentialsInternalFunc(id)
+}
+
+// ResetServiceAccountCredentialsInternalCalls gets all the calls that were made to ResetServiceAccountCredentialsInternal.
+// Check the length with:
+//
+//	len(mockedOSDKeycloakService.ResetServiceAccountCredentialsInternalCalls())
+func (mock *OSDKeycloakServiceMock) ResetServiceAccountCredentialsInternalCalls() []struct {
+	ID string
+} {
+	var calls []struct {
+		ID string
+	}
+	mock.lockResetServiceAccountCredentialsInternal.RLock()
+	calls = mock.calls.ResetServiceAccountCredentialsInternal
+	mock.lockResetServiceAccountCredentialsInternal.RUnlock()
+	return calls
+}
@@ -169,6 +169,18 @@ func (r *redhatssoService) ResetServiceAccountCredentials(accessToken string, ct
 	return convertServiceAccountDataToAPIServiceAccount(&serviceAccount), nil
 }
 
+// ResetServiceAccountCredentialsInternal regenerates the secret with the token of the fleet manager, which must be allowed to
+// manage the service accounts of the organisations
+func (r *redhatssoService) ResetServiceAccountCredentialsInternal(accessToken string, id string) (*api.ServiceAccount, *errors.ServiceError) {
+	return r.ResetServiceAccountCredentials(accessToken, context.Background(), id)
+}
+
+// ListServiceAccountsInternal isn't supported: the service accounts of the organisations are created with the tokens of their users,
+// the fleet manager can't list them with its own token
+func (r *redhatssoService) ListServiceAccountsInternal(accessToken string, first int, max int) ([]api.ServiceAccount, *errors.ServiceError) {
+	return nil, errors.NotImplemented("listing the service accounts of all the organisations isn't supported by the redhat sso provider")
+}
+
 func (r *redhatssoService) ListServiceAcc(accessToken string, ctx context.Context, first int, max int) ([]api.ServiceAccount, *errors.ServiceError) {
 	glog.V(5).Infof("Listing service accounts")
 	accounts, err := r.client.GetServiceAccounts(accessToken, first, max)
@@ -282,7 +294,21 @@ func (r *redhatssoService) GetKafkaClientSecret(accessToken string, clientId str
 	return "", errors.NewWithCause(errors.ErrorFailedToGetSSOClientSecret, err, "failed to get sso client secret")
 }
 
+// CreateServiceAccountInternal creates a service account named after the client id of the request. The redhat sso generates the
+// client ids, so the service account of a previous attempt is looked up by its name and has its secret regenerated instead of
+// creating another one: retrying the creation doesn't leak service accounts.
 func (r *redhatssoService) CreateServiceAccountInternal(accessToken string, request CompleteServiceAccountRequest) (*api.ServiceAccount, *errors.ServiceError) {
+	existing, err := r.findInternalServiceAccount(accessToken, func(account serviceaccountsclient.ServiceAccountData) bool {
+		return request.ClientId == shared.SafeString(account.Name)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		glog.V(5).Infof("Existing service account found with name %s, regenerating its secret", request.ClientId)
+		return r.ResetServiceAccountCredentials(accessToken, context.Background(), shared.SafeString(existing.ClientId))
+	}
+
 	req := api.ServiceAccountRequest{
 		Name:        request.ClientId,
 		Description: request.Description,
@@ -294,27 +320,38 @@ func (r *redhatssoService) DeleteServiceAccountInternal(accessToken string, clie
 	// This is code can be removed once the migrated canary & kas-fleetshard are removed. Performance impact would be less as the number of canary & kas-fleetshard are low.
 	// Once the existing canary or kas-fleetshard are deleted. This logic can be removed.
 	if strings.HasPrefix(clientId, "canary") || strings.HasPrefix(clientId, "kas-fleetshard-agent") || strings.HasPrefix(clientId, "connector-fleetshard-agent") {
-		first := 0
-		max := 100
-		for {
-			accounts, err := r.client.GetServiceAccounts(accessToken, first, max)
-			if err != nil {
-				return errors.NewWithCause(errors.ErrorGeneral, err, "failed to collect internal service accounts")
-			}
-			if len(accounts) == 0 {
-				return nil
-			}
-			for _, account := range accounts {
-				if clientId == shared.SafeString(account.ClientId) {
-					return r.DeleteServiceAccount(accessToken, context.Background(), shared.SafeString(account.Id))
-				}
-			}
-			first = first + max
+		account, err := r.findInternalServiceAccount(accessToken, func(account serviceaccountsclient.ServiceAccountData) bool {
+			return clientId == shared.SafeString(account.ClientId)
+		})
+		if err != nil || account == nil {
+			return err
 		}
+		return r.DeleteServiceAccount(accessToken, context.Background(), shared.SafeString(account.Id))
 	}
 	return r.DeleteServiceAccount(accessToken, context.Background(), clientId)
 }
 
+// findInternalServiceAccount returns the first service account of the fleet manager matching the given function, nil if none does
+func (r *redhatssoService) findInternalServiceAccount(accessToken string, matches func(account serviceaccountsclient.ServiceAccountData) bool) (*serviceaccountsclient.ServiceAccountData, *errors.ServiceError) {
+	first := 0
+	max := 100
+	for {
+		accounts, err := r.client.GetServiceAccounts(accessToken, first, max)
+		if err != nil {
+			return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to collect internal service accounts")
+		}
+		if len(accounts) == 0 {
+			return nil, nil
+		}
+		for i := range accounts {
+			if matches(accounts[i]) {
+				return &accounts[i], nil
+			}
+		}
+		first = first + max
+	}
+}
+
 // // utility functions
 func convertServiceAccountDataToAPIServiceAccount(data *serviceaccountsclient.ServiceAccountData) *api.ServiceAccount {
 	return &api.ServiceAccount{
@@ -907,6 +907,9 @@ func TestRedhatSSOService_CreateServiceAccountInternal(t *testing.T) {
 					GetTokenFunc: func() (string, error) {
 						return "", nil
 					},
+					GetServiceAccountsFunc: func(accessToken string, first, max int) ([]serviceaccountsclient.ServiceAccountData, error) {
+						return []serviceaccountsclient.ServiceAccountData{}, nil
+					},
 					CreateServiceAccountFunc: func(accessToken, name, description string) (serviceaccountsclient.ServiceAccountData, error) {
 						return serviceaccountsclient.ServiceAccountData{}, errors.New(errors.ErrorFailedToCreateServiceAccount, "failed to create service account")
 					},
@@ -922,6 +925,9 @@ func TestRedhatSSOService_CreateServiceAccountInternal(t *testing.T) {
 					GetTokenFunc: func() (string, error) {
 						return "", nil
 					},
+					GetServiceAccountsFunc: func(accessToken string, first, max int) ([]serviceaccountsclient.ServiceAccountData, error) {
+						return []serviceaccountsclient.ServiceAccountData{}, nil
+					},
 					CreateServiceAccountFunc: func(accessToken, name, description string) (serviceaccountsclient.ServiceAccountData, error) {
 						return serviceaccountsclient.ServiceAccountData{
 							Id:       &id,
@@ -934,6 +940,50 @@ func TestRedhatSSOService_CreateServiceAccountInternal(t *testing.T) {
 			wantErr:               false,
 			serviceAccountCreated: true,
 		},
+		{
+			name: "returns error when failed to look up the service account of a previous attempt",
+			fields: fields{
+				kcClient: &redhatsso.SSOClientMock{
+					GetTokenFunc: func() (string, error) {
+						return "", nil
+					},
+					GetServiceAccountsFunc: func(accessToken string, first, max int) ([]serviceaccountsclient.ServiceAccountData, error) {
+						return nil, pkgErr.New("failed to get service accounts")
+					},
+				},
+			},
+			wantErr:               true,
+			serviceAccountCreated: false,
+		},
+		{
+			name: "regenerates the secret of the service account of a previous attempt instead of creating another one",
+			fields: fields{
+				kcClient: &redhatsso.SSOClientMock{
+					GetTokenFunc: func() (string, error) {
+						return "", nil
+					},
+					GetServiceAccountsFunc: func(accessToken string, first, max int) ([]serviceaccountsclient.ServiceAccountData, error) {
+						if first > 0 {
+							return []serviceaccountsclient.ServiceAccountData{}, nil
+						}
+						otherName := "other-name"
+						return []serviceaccountsclient.ServiceAccountData{
+							{Name: &otherName},
+							{Id: &id, ClientId: &request.ClientId, Name: &request.ClientId},
+						}, nil
+					},
+					RegenerateClientSecretFunc: func(accessToken, clientId string) (serviceaccountsclient.ServiceAccountData, error) {
+						return serviceaccountsclient.ServiceAccountData{
+							Id:       &id,
+							ClientId: &request.ClientId,
+							Secret:   &clientSecret,
+						}, nil
+					},
+				},
+			},
+			wantErr:               false,
+			serviceAccountCreated: true,
+		},
 	}
 
 	for _, testcase := range tests {
@@ -953,6 +1003,10 @@ func TestRedhatSSOService_CreateServiceAccountInternal(t *testing.T) {
 				g.Expect(serviceAccount.ClientID).To(gomega.Equal(request.ClientId))
 				g.Expect(serviceAccount.ID).To(gomega.Equal("dsd"))
 			}
+			if mock, ok := tt.fields.kcClient.(*redhatsso.SSOClientMock); ok && len(mock.RegenerateClientSecretCalls()) > 0 {
+				g.Expect(mock.CreateServiceAccountCalls()).To(gomega.BeEmpty())
+				g.Expect(mock.RegenerateClientSecretCalls()[0].ID).To(gomega.Equal(request.ClientId))
+			}
 		})
 	}
 
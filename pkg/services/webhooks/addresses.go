@@ -0,0 +1,113 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+)
+
+// internalHostSuffixes are the suffixes of the host names that only resolve inside a cluster or a private network
+var internalHostSuffixes = []string{"localhost", ".local", ".localdomain", ".internal", ".svc", ".cluster.local"}
+
+// nonPublicNetworks are the networks that aren't covered by the net.IP predicates used by IsPublicAddress
+var nonPublicNetworks = mustParseCIDRs(
+	"0.0.0.0/8",     // current network
+	"100.64.0.0/10", // carrier grade NAT
+	"192.0.0.0/24",  // IETF protocol assignments
+	"198.18.0.0/15", // benchmarking
+	"64:ff9b::/96",  // IPv4/IPv6 translation
+)
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = network
+	}
+	return networks
+}
+
+// IsPublicAddress returns false for the loopback, private, link-local (including the cloud metadata endpoints),
+// multicast and other reserved addresses, that webhooks must never be delivered to
+func IsPublicAddress(ip net.IP) bool {
+	if ip == nil || ip.IsUnspecified() || ip.IsLoopback() || ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// isInternalHost returns true for the host names that aren't fully qualified public names, i.e. kubernetes.default.svc
+func isInternalHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if net.ParseIP(host) != nil {
+		return false
+	}
+	if !strings.Contains(host, ".") {
+		return true
+	}
+	for _, suffix := range internalHostSuffixes {
+		if host == strings.TrimPrefix(suffix, ".") || strings.HasSuffix(host, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSubscriptionURL resolves the host of a subscription URL and checks that it only resolves to public addresses
+func checkSubscriptionURL(ctx context.Context, lookupIPAddr func(ctx context.Context, host string) ([]net.IPAddr, error), rawURL string) *errors.ServiceError {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Hostname() == "" {
+		return errors.BadRequest("url must be an absolute URL")
+	}
+	host := u.Hostname()
+	if isInternalHost(host) {
+		return errors.BadRequest("url host %s isn't a public host name", host)
+	}
+
+	var addresses []net.IPAddr
+	if ip := net.ParseIP(host); ip != nil {
+		addresses = []net.IPAddr{{IP: ip}}
+	} else if addresses, err = lookupIPAddr(ctx, host); err != nil || len(addresses) == 0 {
+		return errors.BadRequest("url host %s can't be resolved", host)
+	}
+	for _, address := range addresses {
+		if !IsPublicAddress(address.IP) {
+			return errors.BadRequest("url host %s resolves to the non public address %s", host, address.IP)
+		}
+	}
+	return nil
+}
+
+// newPublicDialer returns a dialer that refuses to connect to non public addresses. The address is checked after
+// the host is resolved, so that a host resolving to a public address when the subscription is saved can't be
+// rebound to an internal address when the deliveries are sent.
+func newPublicDialer(timeout time.Duration) *net.Dialer {
+	return &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); !IsPublicAddress(ip) {
+				return fmt.Errorf("webhook deliveries to the non public address %s aren't allowed", host)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,62 @@
+package webhooks
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/spf13/pflag"
+)
+
+var _ environments.ConfigModule = (*WebhookConfig)(nil)
+
+// WebhookConfig is the configuration of the webhook deliveries
+type WebhookConfig struct {
+	// MaxAttempts is the number of attempts after which a delivery is failed
+	MaxAttempts int
+	// InitialBackoff is the delay before the second attempt of a delivery, it doubles after each failed attempt up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	RequestTimeout time.Duration
+	// BatchSize is the maximum number of deliveries attempted by each run of the delivery workers
+	BatchSize int
+	// AllowInsecureURLs allows http:// subscription URLs, i.e. to test the deliveries with a local HTTP receiver
+	AllowInsecureURLs bool
+	// AllowPrivateNetworks allows subscription URLs resolving to loopback, private and link-local addresses, i.e. a local HTTP receiver
+	AllowPrivateNetworks bool
+}
+
+func NewWebhookConfig() *WebhookConfig {
+	return &WebhookConfig{
+		MaxAttempts:    10,
+		InitialBackoff: 30 * time.Second,
+		MaxBackoff:     time.Hour,
+		RequestTimeout: 10 * time.Second,
+		BatchSize:      100,
+	}
+}
+
+func (c *WebhookConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.IntVar(&c.MaxAttempts, "webhook-delivery-max-attempts", c.MaxAttempts, "Number of attempts after which a webhook delivery is failed")
+	fs.DurationVar(&c.InitialBackoff, "webhook-delivery-initial-backoff", c.InitialBackoff, "Delay before retrying a failed webhook delivery, doubled after each failed attempt")
+	fs.DurationVar(&c.MaxBackoff, "webhook-delivery-max-backoff", c.MaxBackoff, "Maximum delay between two attempts of a webhook delivery")
+	fs.DurationVar(&c.RequestTimeout, "webhook-delivery-timeout", c.RequestTimeout, "Timeout of the webhook delivery requests")
+	fs.IntVar(&c.BatchSize, "webhook-delivery-batch-size", c.BatchSize, "Maximum number of webhook deliveries attempted at once")
+	fs.BoolVar(&c.AllowInsecureURLs, "webhook-allow-insecure-urls", c.AllowInsecureURLs, "Allow http:// webhook subscription URLs, only meant for development")
+	fs.BoolVar(&c.AllowPrivateNetworks, "webhook-allow-private-networks", c.AllowPrivateNetworks, "Allow webhook subscription URLs resolving to loopback, private and link-local addresses, only meant for development")
+}
+
+func (c *WebhookConfig) ReadFiles() error {
+	return nil
+}
+
+// Backoff returns the delay before the next attempt of a delivery that failed the given number of attempts
+func (c *WebhookConfig) Backoff(attempts int) time.Duration {
+	backoff := c.InitialBackoff
+	for i := 1; i < attempts && backoff < c.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > c.MaxBackoff {
+		return c.MaxBackoff
+	}
+	return backoff
+}
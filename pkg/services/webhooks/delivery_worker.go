@@ -0,0 +1,51 @@
+package webhooks
+
+import (
+	"context"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+)
+
+var _ workers.Worker = &DeliveryWorker{}
+
+// DeliveryWorker periodically sends the pending webhook deliveries of a service
+type DeliveryWorker struct {
+	workers.BaseWorker
+	service        string
+	webhookService WebhookService
+}
+
+// NewDeliveryWorker creates a worker sending the webhook deliveries of a service,
+// the worker type must match a leader lease type created by the migrations of the service
+func NewDeliveryWorker(workerType string, service string, webhookService WebhookService, reconciler workers.Reconciler) *DeliveryWorker {
+	return &DeliveryWorker{
+		BaseWorker: workers.BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: workerType,
+			Reconciler: reconciler,
+		},
+		service:        service,
+		webhookService: webhookService,
+	}
+}
+
+func (w *DeliveryWorker) Start() {
+	w.StartWorker(w)
+}
+
+func (w *DeliveryWorker) Stop() {
+	w.StopWorker(w)
+}
+
+func (w *DeliveryWorker) Reconcile() []error {
+	attempted, err := w.webhookService.Deliver(context.Background(), w.service)
+	if err != nil {
+		return []error{err}
+	}
+	if attempted > 0 {
+		glog.V(5).Infof("Attempted %d webhook deliveries of %s", attempted, w.service)
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package webhooks
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/encryption"
+	"github.com/goava/di"
+)
+
+func ConfigProviders() di.Option {
+	return di.Options(
+		di.Provide(NewWebhookConfig, di.As(new(environments.ConfigModule))),
+		di.Provide(environments.Func(ServiceProviders)),
+		// the subscriptions table is shared by the kafka and connector services, its secrets are registered once here
+		di.ProvideValue(encryption.EncryptedColumn{Table: "webhook_subscriptions", Column: "secret"}),
+	)
+}
+
+func ServiceProviders() di.Option {
+	return di.Options(
+		di.Provide(NewWebhookService, di.As(new(WebhookService))),
+	)
+}
@@ -0,0 +1,91 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+)
+
+// Headers of the webhook delivery requests
+const (
+	HeaderDeliveryId = "X-Webhook-Delivery"
+	HeaderEventId    = "X-Webhook-Event-Id"
+	HeaderEventType  = "X-Webhook-Event"
+	HeaderTimestamp  = "X-Webhook-Timestamp"
+	// HeaderSignature is the hex encoded HMAC-SHA256 of the timestamp header, a '.' and the request body, prefixed with sha256=
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Sign returns the signature of a delivery sent at the given unix timestamp, see HeaderSignature
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	_, _ = mac.Write([]byte("."))
+	_, _ = mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks the signature of a delivery received by a webhook receiver
+func VerifySignature(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// sender posts the deliveries to the subscriptions URL
+type sender struct {
+	client *http.Client
+}
+
+func newSender(webhookConfig *WebhookConfig) *sender {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !webhookConfig.AllowPrivateNetworks {
+		// deliveries go directly to the receivers, so that the dialer checks the addresses of the receivers and not of a proxy
+		transport.Proxy = nil
+		transport.DialContext = newPublicDialer(30 * time.Second).DialContext
+	}
+	return &sender{
+		client: &http.Client{
+			Timeout:   webhookConfig.RequestTimeout,
+			Transport: transport,
+			// redirects aren't followed, the subscription URL must be the final one
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// send posts a delivery and returns the response status code, an error is returned if the delivery wasn't accepted with a 2xx status code
+func (s *sender) send(ctx context.Context, subscription *api.WebhookSubscription, delivery *api.WebhookDelivery) (int, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := time.Now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(HeaderDeliveryId, delivery.ID)
+	request.Header.Set(HeaderEventId, delivery.EventId)
+	request.Header.Set(HeaderEventType, delivery.EventType)
+	request.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(HeaderSignature, Sign(string(subscription.Secret), timestamp, delivery.Payload))
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = response.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 64*1024))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("unexpected response status %s", response.Status)
+	}
+	return response.StatusCode, nil
+}
@@ -0,0 +1,199 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/onsi/gomega"
+)
+
+func TestSign(t *testing.T) {
+	g := gomega.NewWithT(t)
+	body := []byte(`{"type":"kafka.ready"}`)
+
+	signature := Sign("secret", 1684800000, body)
+	g.Expect(signature).To(gomega.HavePrefix("sha256="))
+	g.Expect(VerifySignature("secret", 1684800000, body, signature)).To(gomega.BeTrue())
+	g.Expect(VerifySignature("other-secret", 1684800000, body, signature)).To(gomega.BeFalse())
+	g.Expect(VerifySignature("secret", 1684800001, body, signature)).To(gomega.BeFalse())
+	g.Expect(VerifySignature("secret", 1684800000, []byte(`{"type":"kafka.failed"}`), signature)).To(gomega.BeFalse())
+}
+
+func TestSender_send(t *testing.T) {
+	subscription := &api.WebhookSubscription{Secret: "a-webhook-secret"}
+	delivery := &api.WebhookDelivery{
+		ID:        "delivery-id",
+		EventId:   "event-id",
+		EventType: EventKafkaReady,
+		Payload:   api.JSON(`{"id":"event-id","type":"kafka.ready"}`),
+	}
+
+	tests := []struct {
+		name               string
+		responseStatus     int
+		publicNetworksOnly bool
+		wantStatusCode     int
+		wantErr            bool
+	}{
+		{
+			name:           "should send a signed delivery accepted by the receiver",
+			responseStatus: http.StatusNoContent,
+			wantStatusCode: http.StatusNoContent,
+		},
+		{
+			name:           "should return an error when the receiver fails",
+			responseStatus: http.StatusInternalServerError,
+			wantStatusCode: http.StatusInternalServerError,
+			wantErr:        true,
+		},
+		{
+			name:           "should not follow the redirects",
+			responseStatus: http.StatusFound,
+			wantStatusCode: http.StatusFound,
+			wantErr:        true,
+		},
+		{
+			name:               "should not connect to non public addresses",
+			responseStatus:     http.StatusNoContent,
+			publicNetworksOnly: true,
+			wantErr:            true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				g.Expect(err).ToNot(gomega.HaveOccurred())
+				g.Expect(body).To(gomega.MatchJSON([]byte(delivery.Payload)))
+				g.Expect(r.Header.Get(HeaderDeliveryId)).To(gomega.Equal(delivery.ID))
+				g.Expect(r.Header.Get(HeaderEventId)).To(gomega.Equal(delivery.EventId))
+				g.Expect(r.Header.Get(HeaderEventType)).To(gomega.Equal(delivery.EventType))
+				timestamp, err := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+				g.Expect(err).ToNot(gomega.HaveOccurred())
+				g.Expect(VerifySignature(string(subscription.Secret), timestamp, body, r.Header.Get(HeaderSignature))).To(gomega.BeTrue())
+				if tt.responseStatus == http.StatusFound {
+					w.Header().Set("Location", "/elsewhere")
+				}
+				w.WriteHeader(tt.responseStatus)
+			}))
+			defer receiver.Close()
+			subscription.Url = receiver.URL
+
+			webhookConfig := &WebhookConfig{RequestTimeout: time.Second, AllowPrivateNetworks: !tt.publicNetworksOnly}
+			statusCode, err := newSender(webhookConfig).send(context.Background(), subscription, delivery)
+			g.Expect(statusCode).To(gomega.Equal(tt.wantStatusCode))
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
+
+func Test_checkSubscriptionURL(t *testing.T) {
+	lookupIPAddr := func(ctx context.Context, host string) ([]net.IPAddr, error) {
+		addresses := map[string][]string{
+			"example.com":          {"93.184.216.34", "2606:2800:220:1:248:1893:25c8:1946"},
+			"internal.example.com": {"93.184.216.34", "10.0.0.1"},
+			"metadata.example.com": {"169.254.169.254"},
+			"nat.example.com":      {"100.64.0.1"},
+		}[host]
+		if addresses == nil {
+			return nil, fmt.Errorf("no such host %s", host)
+		}
+		var result []net.IPAddr
+		for _, address := range addresses {
+			result = append(result, net.IPAddr{IP: net.ParseIP(address)})
+		}
+		return result, nil
+	}
+
+	tests := []struct {
+		name    string
+		url     string
+		wantErr string
+	}{
+		{
+			name: "should accept a host resolving to public addresses",
+			url:  "https://example.com/hook",
+		},
+		{
+			name:    "should reject a host resolving to a private address",
+			url:     "https://internal.example.com/hook",
+			wantErr: "resolves to the non public address 10.0.0.1",
+		},
+		{
+			name:    "should reject a host resolving to the metadata endpoint",
+			url:     "https://metadata.example.com/hook",
+			wantErr: "resolves to the non public address 169.254.169.254",
+		},
+		{
+			name:    "should reject a host resolving to a carrier grade NAT address",
+			url:     "https://nat.example.com/hook",
+			wantErr: "resolves to the non public address 100.64.0.1",
+		},
+		{
+			name:    "should reject a loopback address",
+			url:     "https://127.0.0.1:8443/hook",
+			wantErr: "resolves to the non public address 127.0.0.1",
+		},
+		{
+			name:    "should reject an IPv6 loopback address",
+			url:     "https://[::1]/hook",
+			wantErr: "resolves to the non public address ::1",
+		},
+		{
+			name:    "should reject the in-cluster service names",
+			url:     "https://kubernetes.default.svc/api",
+			wantErr: "isn't a public host name",
+		},
+		{
+			name:    "should reject the hosts that aren't fully qualified",
+			url:     "https://kubernetes/api",
+			wantErr: "isn't a public host name",
+		},
+		{
+			name:    "should reject localhost",
+			url:     "https://localhost/hook",
+			wantErr: "isn't a public host name",
+		},
+		{
+			name:    "should reject the hosts that can't be resolved",
+			url:     "https://unknown.example.com/hook",
+			wantErr: "can't be resolved",
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			err := checkSubscriptionURL(context.Background(), lookupIPAddr, tt.url)
+			if tt.wantErr == "" {
+				g.Expect(err).To(gomega.BeNil())
+			} else {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Reason).To(gomega.ContainSubstring(tt.wantErr))
+			}
+		})
+	}
+}
+
+func Test_IsPublicAddress(t *testing.T) {
+	g := gomega.NewWithT(t)
+	for _, address := range []string{"93.184.216.34", "8.8.8.8", "2606:2800:220:1:248:1893:25c8:1946"} {
+		g.Expect(IsPublicAddress(net.ParseIP(address))).To(gomega.BeTrue(), address)
+	}
+	for _, address := range []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "0.0.0.0",
+		"100.64.0.1", "224.0.0.1", "::1", "fe80::1", "fd00::1", "::ffff:127.0.0.1", "::ffff:169.254.169.254"} {
+		g.Expect(IsPublicAddress(net.ParseIP(address))).To(gomega.BeFalse(), address)
+	}
+}
@@ -0,0 +1,386 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/queryparser"
+	"gorm.io/gorm"
+)
+
+// Services managing webhook subscriptions, same as the services recording audit events
+const (
+	ServiceKafkas     = "kafkas_mgmt"
+	ServiceConnectors = "connector_mgmt"
+)
+
+// Event types delivered to the webhook subscriptions
+const (
+	EventKafkaReady                = "kafka.ready"
+	EventKafkaFailed               = "kafka.failed"
+	EventKafkaSuspended            = "kafka.suspended"
+	EventConnectorFailed           = "connector.failed"
+	EventConnectorNamespaceExpired = "connector_namespace.expired"
+
+	EventServiceAccountCredentialsExpiring = "service_account.credentials_expiring"
+	EventServiceAccountCredentialsRotated  = "service_account.credentials_rotated"
+	EventServiceAccountCredentialsRevoked  = "service_account.credentials_revoked"
+)
+
+var serviceEventTypes = map[string][]string{
+	ServiceKafkas: {EventKafkaReady, EventKafkaFailed, EventKafkaSuspended,
+		EventServiceAccountCredentialsExpiring, EventServiceAccountCredentialsRotated, EventServiceAccountCredentialsRevoked},
+	ServiceConnectors: {EventConnectorFailed, EventConnectorNamespaceExpired},
+}
+
+// EventTypes returns the event types the subscriptions of a service can subscribe to
+func EventTypes(service string) []string {
+	return serviceEventTypes[service]
+}
+
+func eventService(eventType string) string {
+	for service, eventTypes := range serviceEventTypes {
+		for _, t := range eventTypes {
+			if t == eventType {
+				return service
+			}
+		}
+	}
+	return ""
+}
+
+// Event is a state change of a resource of an organisation
+type Event struct {
+	Type           string
+	OrganisationId string
+	ResourceId     string
+	// Data is the resource, as serialized in the delivered payload
+	Data interface{}
+}
+
+// Payload is the body of the webhook delivery requests
+type Payload struct {
+	Id             string      `json:"id"`
+	Type           string      `json:"type"`
+	CreatedAt      time.Time   `json:"created_at"`
+	OrganisationId string      `json:"organisation_id"`
+	ResourceId     string      `json:"resource_id"`
+	Data           interface{} `json:"data"`
+}
+
+//go:generate moq -out webhooks_moq.go . WebhookService
+
+// WebhookService manages the webhook subscriptions of the organisations and delivers them the state changes of their resources
+type WebhookService interface {
+	ListSubscriptions(ctx context.Context, service string, organisationId string, listArgs *services.ListArguments) (api.WebhookSubscriptionList, *api.PagingMeta, *errors.ServiceError)
+	GetSubscription(ctx context.Context, service string, organisationId string, id string) (*api.WebhookSubscription, *errors.ServiceError)
+	CreateSubscription(ctx context.Context, subscription *api.WebhookSubscription) *errors.ServiceError
+	UpdateSubscription(ctx context.Context, subscription *api.WebhookSubscription) *errors.ServiceError
+	// DeleteSubscription deletes a subscription and fails its pending deliveries
+	DeleteSubscription(ctx context.Context, service string, organisationId string, id string) *errors.ServiceError
+	// CheckSubscriptionURL checks that the host of a subscription URL only resolves to public addresses,
+	// unless private networks are allowed by the configuration
+	CheckSubscriptionURL(ctx context.Context, url string) *errors.ServiceError
+
+	// Enqueue adds a delivery of the events to the outbox of every subscription of the organisation to the event type.
+	// The deliveries are added with the given connection, so that they're only sent if its transaction is committed.
+	Enqueue(dbConn *gorm.DB, events ...Event) *errors.ServiceError
+	ListDeliveries(ctx context.Context, subscriptionId string, listArgs *services.ListArguments) (api.WebhookDeliveryList, *api.PagingMeta, *errors.ServiceError)
+	// Redeliver adds a new delivery of the event of a previous delivery to the outbox
+	Redeliver(ctx context.Context, subscriptionId string, deliveryId string) (*api.WebhookDelivery, *errors.ServiceError)
+	// Deliver attempts the pending deliveries of a service that are due, and returns the number of attempted deliveries
+	Deliver(ctx context.Context, service string) (int, *errors.ServiceError)
+}
+
+var _ WebhookService = &webhookService{}
+
+type webhookService struct {
+	connectionFactory *db.ConnectionFactory
+	webhookConfig     *WebhookConfig
+	sender            *sender
+	lookupIPAddr      func(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+func NewWebhookService(connectionFactory *db.ConnectionFactory, webhookConfig *WebhookConfig) *webhookService {
+	return &webhookService{
+		connectionFactory: connectionFactory,
+		webhookConfig:     webhookConfig,
+		sender:            newSender(webhookConfig),
+		lookupIPAddr:      net.DefaultResolver.LookupIPAddr,
+	}
+}
+
+func GetValidWebhookSubscriptionColumns() []string {
+	return []string{"url", "owner", "created_at", "updated_at"}
+}
+
+func GetValidWebhookDeliveryColumns() []string {
+	return []string{"event_id", "event_type", "resource_id", "status", "attempts", "created_at"}
+}
+
+func (w *webhookService) ListSubscriptions(ctx context.Context, service string, organisationId string, listArgs *services.ListArguments) (api.WebhookSubscriptionList, *api.PagingMeta, *errors.ServiceError) {
+	var resourceList api.WebhookSubscriptionList
+	dbConn := w.connectionFactory.New().Where("service = ? AND organisation_id = ?", service, organisationId)
+	pagingMeta, serr := list(dbConn, listArgs, GetValidWebhookSubscriptionColumns(), "webhook subscriptions", &resourceList)
+	return resourceList, pagingMeta, serr
+}
+
+func (w *webhookService) GetSubscription(ctx context.Context, service string, organisationId string, id string) (*api.WebhookSubscription, *errors.ServiceError) {
+	var subscription api.WebhookSubscription
+	if err := w.connectionFactory.New().
+		Where("id = ? AND service = ? AND organisation_id = ?", id, service, organisationId).
+		First(&subscription).Error; err != nil {
+		return nil, services.HandleGetError("Webhook subscription", "id", id, err)
+	}
+	return &subscription, nil
+}
+
+func (w *webhookService) CreateSubscription(ctx context.Context, subscription *api.WebhookSubscription) *errors.ServiceError {
+	if err := w.connectionFactory.New().Create(subscription).Error; err != nil {
+		return services.HandleCreateError("Webhook subscription", err)
+	}
+	return nil
+}
+
+func (w *webhookService) UpdateSubscription(ctx context.Context, subscription *api.WebhookSubscription) *errors.ServiceError {
+	if err := w.connectionFactory.New().Model(subscription).
+		Select("url", "event_types", "secret").
+		Updates(subscription).Error; err != nil {
+		return services.HandleUpdateError("Webhook subscription", err)
+	}
+	return nil
+}
+
+func (w *webhookService) CheckSubscriptionURL(ctx context.Context, url string) *errors.ServiceError {
+	if w.webhookConfig.AllowPrivateNetworks {
+		return nil
+	}
+	return checkSubscriptionURL(ctx, w.lookupIPAddr, url)
+}
+
+func (w *webhookService) DeleteSubscription(ctx context.Context, service string, organisationId string, id string) *errors.ServiceError {
+	if err := w.connectionFactory.New().Transaction(func(dbConn *gorm.DB) error {
+		result := dbConn.Where("id = ? AND service = ? AND organisation_id = ?", id, service, organisationId).
+			Delete(&api.WebhookSubscription{})
+		if result.Error != nil {
+			return services.HandleDeleteError("Webhook subscription", "id", id, result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return errors.NotFound("Webhook subscription with id='%s' not found", id)
+		}
+		if err := dbConn.Model(&api.WebhookDelivery{}).
+			Where("subscription_id = ? AND status = ?", id, api.WebhookDeliveryPending).
+			Updates(map[string]interface{}{
+				"status":          api.WebhookDeliveryFailed,
+				"next_attempt_at": nil,
+				"last_error":      "the subscription was deleted",
+			}).Error; err != nil {
+			return services.HandleUpdateError("Webhook delivery", err)
+		}
+		return nil
+	}); err != nil {
+		return errors.ToServiceError(err)
+	}
+	return nil
+}
+
+func (w *webhookService) Enqueue(dbConn *gorm.DB, events ...Event) *errors.ServiceError {
+	for _, event := range events {
+		service := eventService(event.Type)
+		if service == "" || event.OrganisationId == "" {
+			continue
+		}
+		eventTypes, err := json.Marshal([]string{event.Type})
+		if err != nil {
+			return errors.GeneralError("unable to enqueue %s event of %s: %v", event.Type, event.ResourceId, err)
+		}
+		var subscriptionIds []string
+		if err := dbConn.Model(&api.WebhookSubscription{}).
+			Where("service = ? AND organisation_id = ? AND event_types @> ?", service, event.OrganisationId, string(eventTypes)).
+			Pluck("id", &subscriptionIds).Error; err != nil {
+			return services.HandleGetError("Webhook subscription", "organisation_id", event.OrganisationId, err)
+		}
+		if len(subscriptionIds) == 0 {
+			continue
+		}
+
+		payload := Payload{
+			Id:             api.NewID(),
+			Type:           event.Type,
+			CreatedAt:      time.Now().UTC(),
+			OrganisationId: event.OrganisationId,
+			ResourceId:     event.ResourceId,
+			Data:           event.Data,
+		}
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return errors.GeneralError("unable to enqueue %s event of %s: %v", event.Type, event.ResourceId, err)
+		}
+		deliveries := make(api.WebhookDeliveryList, len(subscriptionIds))
+		for i, subscriptionId := range subscriptionIds {
+			deliveries[i] = &api.WebhookDelivery{
+				Service:        service,
+				SubscriptionId: subscriptionId,
+				EventId:        payload.Id,
+				EventType:      event.Type,
+				ResourceId:     event.ResourceId,
+				Payload:        body,
+				Status:         api.WebhookDeliveryPending,
+				NextAttemptAt:  &payload.CreatedAt,
+			}
+		}
+		if err := dbConn.Create(&deliveries).Error; err != nil {
+			return services.HandleCreateError("Webhook delivery", err)
+		}
+	}
+	return nil
+}
+
+func (w *webhookService) ListDeliveries(ctx context.Context, subscriptionId string, listArgs *services.ListArguments) (api.WebhookDeliveryList, *api.PagingMeta, *errors.ServiceError) {
+	var resourceList api.WebhookDeliveryList
+	dbConn := w.connectionFactory.New().Where("subscription_id = ?", subscriptionId)
+	pagingMeta, serr := list(dbConn, listArgs, GetValidWebhookDeliveryColumns(), "webhook deliveries", &resourceList)
+	return resourceList, pagingMeta, serr
+}
+
+func (w *webhookService) Redeliver(ctx context.Context, subscriptionId string, deliveryId string) (*api.WebhookDelivery, *errors.ServiceError) {
+	dbConn := w.connectionFactory.New()
+	var delivery api.WebhookDelivery
+	if err := dbConn.Where("id = ? AND subscription_id = ?", deliveryId, subscriptionId).First(&delivery).Error; err != nil {
+		return nil, services.HandleGetError("Webhook delivery", "id", deliveryId, err)
+	}
+
+	now := time.Now()
+	redelivery := &api.WebhookDelivery{
+		Service:        delivery.Service,
+		SubscriptionId: delivery.SubscriptionId,
+		EventId:        delivery.EventId,
+		EventType:      delivery.EventType,
+		ResourceId:     delivery.ResourceId,
+		Payload:        delivery.Payload,
+		Status:         api.WebhookDeliveryPending,
+		NextAttemptAt:  &now,
+		RedeliveryOf:   delivery.ID,
+	}
+	if err := dbConn.Create(redelivery).Error; err != nil {
+		return nil, services.HandleCreateError("Webhook delivery", err)
+	}
+	return redelivery, nil
+}
+
+func (w *webhookService) Deliver(ctx context.Context, service string) (int, *errors.ServiceError) {
+	dbConn := w.connectionFactory.New()
+	var deliveries api.WebhookDeliveryList
+	if err := dbConn.Where("service = ? AND status = ? AND next_attempt_at <= ?", service, api.WebhookDeliveryPending, time.Now()).
+		Order("next_attempt_at").
+		Limit(w.webhookConfig.BatchSize).
+		Find(&deliveries).Error; err != nil {
+		return 0, services.HandleGetError("Webhook delivery", "status", api.WebhookDeliveryPending, err)
+	}
+	if len(deliveries) == 0 {
+		return 0, nil
+	}
+
+	subscriptionIds := make([]string, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		subscriptionIds = append(subscriptionIds, delivery.SubscriptionId)
+	}
+	var subscriptions api.WebhookSubscriptionList
+	if err := dbConn.Where("id IN ?", subscriptionIds).Find(&subscriptions).Error; err != nil {
+		return 0, services.HandleGetError("Webhook subscription", "id", subscriptionIds, err)
+	}
+	subscriptionsById := make(map[string]*api.WebhookSubscription, len(subscriptions))
+	for _, subscription := range subscriptions {
+		subscriptionsById[subscription.ID] = subscription
+	}
+
+	ulog := logger.NewUHCLogger(ctx)
+	for _, delivery := range deliveries {
+		w.attempt(ctx, subscriptionsById[delivery.SubscriptionId], delivery)
+		if err := dbConn.Model(delivery).
+			Select("status", "attempts", "next_attempt_at", "last_attempt_at", "last_status_code", "last_error", "delivered_at").
+			Updates(delivery).Error; err != nil {
+			// the delivery will be attempted again
+			ulog.Errorf("unable to update webhook delivery %s: %v", delivery.ID, err)
+		}
+	}
+	return len(deliveries), nil
+}
+
+// attempt sends a delivery and updates its status, a failed delivery is retried with an exponential backoff until MaxAttempts is reached
+func (w *webhookService) attempt(ctx context.Context, subscription *api.WebhookSubscription, delivery *api.WebhookDelivery) {
+	now := time.Now()
+	if subscription == nil {
+		delivery.Status = api.WebhookDeliveryFailed
+		delivery.NextAttemptAt = nil
+		delivery.LastError = "the subscription was deleted"
+		return
+	}
+
+	statusCode, err := w.sender.send(ctx, subscription, delivery)
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.LastStatusCode = statusCode
+	switch {
+	case err == nil:
+		delivery.Status = api.WebhookDeliverySucceeded
+		delivery.NextAttemptAt = nil
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= w.webhookConfig.MaxAttempts:
+		delivery.Status = api.WebhookDeliveryFailed
+		delivery.NextAttemptAt = nil
+		delivery.LastError = err.Error()
+	default:
+		next := now.Add(w.webhookConfig.Backoff(delivery.Attempts))
+		delivery.NextAttemptAt = &next
+		delivery.LastError = err.Error()
+	}
+}
+
+// list returns a page of the resources matching the search query of the list arguments, most recent first by default
+func list(dbConn *gorm.DB, listArgs *services.ListArguments, columns []string, resourceType string, resourceList interface{}) (*api.PagingMeta, *errors.ServiceError) {
+	pagingMeta := &api.PagingMeta{
+		Page: listArgs.Page,
+		Size: listArgs.Size,
+	}
+	if err := listArgs.Validate(columns); err != nil {
+		return pagingMeta, errors.NewWithCause(errors.ErrorMalformedRequest, err, "unable to list %s: %s", resourceType, err.Error())
+	}
+	if len(listArgs.Search) > 0 {
+		queryParser := queryparser.NewQueryParser(columns...)
+		searchDbQuery, err := queryParser.Parse(listArgs.Search)
+		if err != nil {
+			return pagingMeta, errors.NewWithCause(errors.ErrorFailedToParseSearch, err, "unable to list %s: %s", resourceType, err.Error())
+		}
+		dbConn = dbConn.Where(searchDbQuery.Query, searchDbQuery.Values...)
+	}
+
+	total := int64(pagingMeta.Total)
+	dbConn.Session(&gorm.Session{}).Model(resourceList).Count(&total)
+	pagingMeta.Total = int(total)
+	if pagingMeta.Size > pagingMeta.Total {
+		pagingMeta.Size = pagingMeta.Total
+	}
+	dbConn = dbConn.Offset((pagingMeta.Page - 1) * pagingMeta.Size).Limit(pagingMeta.Size)
+
+	if len(listArgs.OrderBy) == 0 {
+		dbConn = dbConn.Order("created_at desc")
+	}
+	for _, orderByArg := range listArgs.OrderBy {
+		dbConn = dbConn.Order(orderByArg)
+	}
+
+	if err := dbConn.Find(resourceList).Error; err != nil {
+		return pagingMeta, errors.GeneralError("unable to list %s: %s", resourceType, fmt.Sprint(err))
+	}
+	return pagingMeta, nil
+}
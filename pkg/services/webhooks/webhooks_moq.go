@@ -0,0 +1,583 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package webhooks
+
+import (
+	"context"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"gorm.io/gorm"
+	"sync"
+)
+
+// Ensure, that WebhookServiceMock does implement WebhookService.
+// If this is not the case, regenerate this file with moq.
+var _ WebhookService = &WebhookServiceMock{}
+
+// WebhookServiceMock is a mock implementation of WebhookService.
+//
+//	func TestSomethingThatUsesWebhookService(t *testing.T) {
+//
+//		// make and configure a mocked WebhookService
+//		mockedWebhookService := &WebhookServiceMock{
+//			CheckSubscriptionURLFunc: func(ctx context.Context, url string) *errors.ServiceError {
+//				panic("mock out the CheckSubscriptionURL method")
+//			},
+//			CreateSubscriptionFunc: func(ctx context.Context, subscription *api.WebhookSubscription) *errors.ServiceError {
+//				panic("mock out the CreateSubscription method")
+//			},
+//			DeleteSubscriptionFunc: func(ctx context.Context, service string, organisationId string, id string) *errors.ServiceError {
+//				panic("mock out the DeleteSubscription method")
+//			},
+//			DeliverFunc: func(ctx context.Context, service string) (int, *errors.ServiceError) {
+//				panic("mock out the Deliver method")
+//			},
+//			EnqueueFunc: func(dbConn *gorm.DB, events ...Event) *errors.ServiceError {
+//				panic("mock out the Enqueue method")
+//			},
+//			GetSubscriptionFunc: func(ctx context.Context, service string, organisationId string, id string) (*api.WebhookSubscription, *errors.ServiceError) {
+//				panic("mock out the GetSubscription method")
+//			},
+//			ListDeliveriesFunc: func(ctx context.Context, subscriptionId string, listArgs *services.ListArguments) (api.WebhookDeliveryList, *api.PagingMeta, *errors.ServiceError) {
+//				panic("mock out the ListDeliveries method")
+//			},
+//			ListSubscriptionsFunc: func(ctx context.Context, service string, organisationId string, listArgs *services.ListArguments) (api.WebhookSubscriptionList, *api.PagingMeta, *errors.ServiceError) {
+//				panic("mock out the ListSubscriptions method")
+//			},
+//			RedeliverFunc: func(ctx context.Context, subscriptionId string, deliveryId string) (*api.WebhookDelivery, *errors.ServiceError) {
+//				panic("mock out the Redeliver method")
+//			},
+//			UpdateSubscriptionFunc: func(ctx context.Context, subscription *api.WebhookSubscription) *errors.ServiceError {
+//				panic("mock out the UpdateSubscription method")
+//			},
+//		}
+//
+//		// use mockedWebhookService in code that requires WebhookService
+//		// and then make assertions.
+//
+//	}
+type WebhookServiceMock struct {
+	// CheckSubscriptionURLFunc mocks the CheckSubscriptionURL method.
+	CheckSubscriptionURLFunc func(ctx context.Context, url string) *errors.ServiceError
+
+	// CreateSubscriptionFunc mocks the CreateSubscription method.
+	CreateSubscriptionFunc func(ctx context.Context, subscription *api.WebhookSubscription) *errors.ServiceError
+
+	// DeleteSubscriptionFunc mocks the DeleteSubscription method.
+	DeleteSubscriptionFunc func(ctx context.Context, service string, organisationId string, id string) *errors.ServiceError
+
+	// DeliverFunc mocks the Deliver method.
+	DeliverFunc func(ctx context.Context, service string) (int, *errors.ServiceError)
+
+	// EnqueueFunc mocks the Enqueue method.
+	EnqueueFunc func(dbConn *gorm.DB, events ...Event) *errors.ServiceError
+
+	// GetSubscriptionFunc mocks the GetSubscription method.
+	GetSubscriptionFunc func(ctx context.Context, service string, organisationId string, id string) (*api.WebhookSubscription, *errors.ServiceError)
+
+	// ListDeliveriesFunc mocks the ListDeliveries method.
+	ListDeliveriesFunc func(ctx context.Context, subscriptionId string, listArgs *services.ListArguments) (api.WebhookDeliveryList, *api.PagingMeta, *errors.ServiceError)
+
+	// ListSubscriptionsFunc mocks the ListSubscriptions method.
+	ListSubscriptionsFunc func(ctx context.Context, service string, organisationId string, listArgs *services.ListArguments) (api.WebhookSubscriptionList, *api.PagingMeta, *errors.ServiceError)
+
+	// RedeliverFunc mocks the Redeliver method.
+	RedeliverFunc func(ctx context.Context, subscriptionId string, deliveryId string) (*api.WebhookDelivery, *errors.ServiceError)
+
+	// UpdateSubscriptionFunc mocks the UpdateSubscription method.
+	UpdateSubscriptionFunc func(ctx context.Context, subscription *api.WebhookSubscription) *errors.ServiceError
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CheckSubscriptionURL holds details about calls to the CheckSubscriptionURL method.
+		CheckSubscriptionURL []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// URL is the url argument value.
+			URL string
+		}
+		// CreateSubscription holds details about calls to the CreateSubscription method.
+		CreateSubscription []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Subscription is the subscription argument value.
+			Subscription *api.WebhookSubscription
+		}
+		// DeleteSubscription holds details about calls to the DeleteSubscription method.
+		DeleteSubscription []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Service is the service argument value.
+			Service string
+			// OrganisationId is the organisationId argument value.
+			OrganisationId string
+			// ID is the id argument value.
+			ID string
+		}
+		// Deliver holds details about calls to the Deliver method.
+		Deliver []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Service is the service argument value.
+			Service string
+		}
+		// Enqueue holds details about calls to the Enqueue method.
+		Enqueue []struct {
+			// DbConn is the dbConn argument value.
+			DbConn *gorm.DB
+			// Events is the events argument value.
+			Events []Event
+		}
+		// GetSubscription holds details about calls to the GetSubscription method.
+		GetSubscription []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Service is the service argument value.
+			Service string
+			// OrganisationId is the organisationId argument value.
+			OrganisationId string
+			// ID is the id argument value.
+			ID string
+		}
+		// ListDeliveries holds details about calls to the ListDeliveries method.
+		ListDeliveries []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// SubscriptionId is the subscriptionId argument value.
+			SubscriptionId string
+			// ListArgs is the listArgs argument value.
+			ListArgs *services.ListArguments
+		}
+		// ListSubscriptions holds details about calls to the ListSubscriptions method.
+		ListSubscriptions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Service is the service argument value.
+			Service string
+			// OrganisationId is the organisationId argument value.
+			OrganisationId string
+			// ListArgs is the listArgs argument value.
+			ListArgs *services.ListArguments
+		}
+		// Redeliver holds details about calls to the Redeliver method.
+		Redeliver []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// SubscriptionId is the subscriptionId argument value.
+			SubscriptionId string
+			// DeliveryId is the deliveryId argument value.
+			DeliveryId string
+		}
+		// UpdateSubscription holds details about calls to the UpdateSubscription method.
+		UpdateSubscription []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Subscription is the subscription argument value.
+			Subscription *api.WebhookSubscription
+		}
+	}
+	lockCheckSubscriptionURL sync.RWMutex
+	lockCreateSubscription   sync.RWMutex
+	lockDeleteSubscription   sync.RWMutex
+	lockDeliver              sync.RWMutex
+	lockEnqueue              sync.RWMutex
+	lockGetSubscription      sync.RWMutex
+	lockListDeliveries       sync.RWMutex
+	lockListSubscriptions    sync.RWMutex
+	lockRedeliver            sync.RWMutex
+	lockUpdateSubscription   sync.RWMutex
+}
+
+// CheckSubscriptionURL calls CheckSubscriptionURLFunc.
+func (mock *WebhookServiceMock) CheckSubscriptionURL(ctx context.Context, url string) *errors.ServiceError {
+	if mock.CheckSubscriptionURLFunc == nil {
+		panic("WebhookServiceMock.CheckSubscriptionURLFunc: method is nil but WebhookService.CheckSubscriptionURL was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		URL string
+	}{
+		Ctx: ctx,
+		URL: url,
+	}
+	mock.lockCheckSubscriptionURL.Lock()
+	mock.calls.CheckSubscriptionURL = append(mock.calls.CheckSubscriptionURL, callInfo)
+	mock.lockCheckSubscriptionURL.Unlock()
+	return mock.CheckSubscriptionURLFunc(ctx, url)
+}
+
+// CheckSubscriptionURLCalls gets all the calls that were made to CheckSubscriptionURL.
+// Check the length with:
+//
+//	len(mockedWebhookService.CheckSubscriptionURLCalls())
+func (mock *WebhookServiceMock) CheckSubscriptionURLCalls() []struct {
+	Ctx context.Context
+	URL string
+} {
+	var calls []struct {
+		Ctx context.Context
+		URL string
+	}
+	mock.lockCheckSubscriptionURL.RLock()
+	calls = mock.calls.CheckSubscriptionURL
+	mock.lockCheckSubscriptionURL.RUnlock()
+	return calls
+}
+
+// CreateSubscription calls CreateSubscriptionFunc.
+func (mock *WebhookServiceMock) CreateSubscription(ctx context.Context, subscription *api.WebhookSubscription) *errors.ServiceError {
+	if mock.CreateSubscriptionFunc == nil {
+		panic("WebhookServiceMock.CreateSubscriptionFunc: method is nil but WebhookService.CreateSubscription was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		Subscription *api.WebhookSubscription
+	}{
+		Ctx:          ctx,
+		Subscription: subscription,
+	}
+	mock.lockCreateSubscription.Lock()
+	mock.calls.CreateSubscription = append(mock.calls.CreateSubscription, callInfo)
+	mock.lockCreateSubscription.Unlock()
+	return mock.CreateSubscriptionFunc(ctx, subscription)
+}
+
+// CreateSubscriptionCalls gets all the calls that were made to CreateSubscription.
+// Check the length with:
+//
+//	len(mockedWebhookService.CreateSubscriptionCalls())
+func (mock *WebhookServiceMock) CreateSubscriptionCalls() []struct {
+	Ctx          context.Context
+	Subscription *api.WebhookSubscription
+} {
+	var calls []struct {
+		Ctx          context.Context
+		Subscription *api.WebhookSubscription
+	}
+	mock.lockCreateSubscription.RLock()
+	calls = mock.calls.CreateSubscription
+	mock.lockCreateSubscription.RUnlock()
+	return calls
+}
+
+// DeleteSubscription calls DeleteSubscriptionFunc.
+func (mock *WebhookServiceMock) DeleteSubscription(ctx context.Context, service string, organisationId string, id string) *errors.ServiceError {
+	if mock.DeleteSubscriptionFunc == nil {
+		panic("WebhookServiceMock.DeleteSubscriptionFunc: method is nil but WebhookService.DeleteSubscription was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		Service        string
+		OrganisationId string
+		ID             string
+	}{
+		Ctx:            ctx,
+		Service:        service,
+		OrganisationId: organisationId,
+		ID:             id,
+	}
+	mock.lockDeleteSubscription.Lock()
+	mock.calls.DeleteSubscription = append(mock.calls.DeleteSubscription, callInfo)
+	mock.lockDeleteSubscription.Unlock()
+	return mock.DeleteSubscriptionFunc(ctx, service, organisationId, id)
+}
+
+// DeleteSubscriptionCalls gets all the calls that were made to DeleteSubscription.
+// Check the length with:
+//
+//	len(mockedWebhookService.DeleteSubscriptionCalls())
+func (mock *WebhookServiceMock) DeleteSubscriptionCalls() []struct {
+	Ctx            context.Context
+	Service        string
+	OrganisationId string
+	ID             string
+} {
+	var calls []struct {
+		Ctx            context.Context
+		Service        string
+		OrganisationId string
+		ID             string
+	}
+	mock.lockDeleteSubscription.RLock()
+	calls = mock.calls.DeleteSubscription
+	mock.lockDeleteSubscription.RUnlock()
+	return calls
+}
+
+// Deliver calls DeliverFunc.
+func (mock *WebhookServiceMock) Deliver(ctx context.Context, service string) (int, *errors.ServiceError) {
+	if mock.DeliverFunc == nil {
+		panic("WebhookServiceMock.DeliverFunc: method is nil but WebhookService.Deliver was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Service string
+	}{
+		Ctx:     ctx,
+		Service: service,
+	}
+	mock.lockDeliver.Lock()
+	mock.calls.Deliver = append(mock.calls.Deliver, callInfo)
+	mock.lockDeliver.Unlock()
+	return mock.DeliverFunc(ctx, service)
+}
+
+// DeliverCalls gets all the calls that were made to Deliver.
+// Check the length with:
+//
+//	len(mockedWebhookService.DeliverCalls())
+func (mock *WebhookServiceMock) DeliverCalls() []struct {
+	Ctx     context.Context
+	Service string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Service string
+	}
+	mock.lockDeliver.RLock()
+	calls = mock.calls.Deliver
+	mock.lockDeliver.RUnlock()
+	return calls
+}
+
+// Enqueue calls EnqueueFunc.
+func (mock *WebhookServiceMock) Enqueue(dbConn *gorm.DB, events ...Event) *errors.ServiceError {
+	if mock.EnqueueFunc == nil {
+		panic("WebhookServiceMock.EnqueueFunc: method is nil but WebhookService.Enqueue was just called")
+	}
+	callInfo := struct {
+		DbConn *gorm.DB
+		Events []Event
+	}{
+		DbConn: dbConn,
+		Events: events,
+	}
+	mock.lockEnqueue.Lock()
+	mock.calls.Enqueue = append(mock.calls.Enqueue, callInfo)
+	mock.lockEnqueue.Unlock()
+	return mock.EnqueueFunc(dbConn, events...)
+}
+
+// EnqueueCalls gets all the calls that were made to Enqueue.
+// Check the length with:
+//
+//	len(mockedWebhookService.EnqueueCalls())
+func (mock *WebhookServiceMock) EnqueueCalls() []struct {
+	DbConn *gorm.DB
+	Events []Event
+} {
+	var calls []struct {
+		DbConn *gorm.DB
+		Events []Event
+	}
+	mock.lockEnqueue.RLock()
+	calls = mock.calls.Enqueue
+	mock.lockEnqueue.RUnlock()
+	return calls
+}
+
+// GetSubscription calls GetSubscriptionFunc.
+func (mock *WebhookServiceMock) GetSubscription(ctx context.Context, service string, organisationId string, id string) (*api.WebhookSubscription, *errors.ServiceError) {
+	if mock.GetSubscriptionFunc == nil {
+		panic("WebhookServiceMock.GetSubscriptionFunc: method is nil but WebhookService.GetSubscription was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		Service        string
+		OrganisationId string
+		ID             string
+	}{
+		Ctx:            ctx,
+		Service:        service,
+		OrganisationId: organisationId,
+		ID:             id,
+	}
+	mock.lockGetSubscription.Lock()
+	mock.calls.GetSubscription = append(mock.calls.GetSubscription, callInfo)
+	mock.lockGetSubscription.Unlock()
+	return mock.GetSubscriptionFunc(ctx, service, organisationId, id)
+}
+
+// GetSubscriptionCalls gets all the calls that were made to GetSubscription.
+// Check the length with:
+//
+//	len(mockedWebhookService.GetSubscriptionCalls())
+func (mock *WebhookServiceMock) GetSubscriptionCalls() []struct {
+	Ctx            context.Context
+	Service        string
+	OrganisationId string
+	ID             string
+} {
+	var calls []struct {
+		Ctx            context.Context
+		Service        string
+		OrganisationId string
+		ID             string
+	}
+	mock.lockGetSubscription.RLock()
+	calls = mock.calls.GetSubscription
+	mock.lockGetSubscription.RUnlock()
+	return calls
+}
+
+// ListDeliveries calls ListDeliveriesFunc.
+func (mock *WebhookServiceMock) ListDeliveries(ctx context.Context, subscriptionId string, listArgs *services.ListArguments) (api.WebhookDeliveryList, *api.PagingMeta, *errors.ServiceError) {
+	if mock.ListDeliveriesFunc == nil {
+		panic("WebhookServiceMock.ListDeliveriesFunc: method is nil but WebhookService.ListDeliveries was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		SubscriptionId string
+		ListArgs       *services.ListArguments
+	}{
+		Ctx:            ctx,
+		SubscriptionId: subscriptionId,
+		ListArgs:       listArgs,
+	}
+	mock.lockListDeliveries.Lock()
+	mock.calls.ListDeliveries = append(mock.calls.ListDeliveries, callInfo)
+	mock.lockListDeliveries.Unlock()
+	return mock.ListDeliveriesFunc(ctx, subscriptionId, listArgs)
+}
+
+// ListDeliveriesCalls gets all the calls that were made to ListDeliveries.
+// Check the length with:
+//
+//	len(mockedWebhookService.ListDeliveriesCalls())
+func (mock *WebhookServiceMock) ListDeliveriesCalls() []struct {
+	Ctx            context.Context
+	SubscriptionId string
+	ListArgs       *services.ListArguments
+} {
+	var calls []struct {
+		Ctx            context.Context
+		SubscriptionId string
+		ListArgs       *services.ListArguments
+	}
+	mock.lockListDeliveries.RLock()
+	calls = mock.calls.ListDeliveries
+	mock.lockListDeliveries.RUnlock()
+	return calls
+}
+
+// ListSubscriptions calls ListSubscriptionsFunc.
+func (mock *WebhookServiceMock) ListSubscriptions(ctx context.Context, service string, organisationId string, listArgs *services.ListArguments) (api.WebhookSubscriptionList, *api.PagingMeta, *errors.ServiceError) {
+	if mock.ListSubscriptionsFunc == nil {
+		panic("WebhookServiceMock.ListSubscriptionsFunc: method is nil but WebhookService.ListSubscriptions was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		Service        string
+		OrganisationId string
+		ListArgs       *services.ListArguments
+	}{
+		Ctx:            ctx,
+		Service:        service,
+		OrganisationId: organisationId,
+		ListArgs:       listArgs,
+	}
+	mock.lockListSubscriptions.Lock()
+	mock.calls.ListSubscriptions = append(mock.calls.ListSubscriptions, callInfo)
+	mock.lockListSubscriptions.Unlock()
+	return mock.ListSubscriptionsFunc(ctx, service, organisationId, listArgs)
+}
+
+// ListSubscriptionsCalls gets all the calls that were made to ListSubscriptions.
+// Check the length with:
+//
+//	len(mockedWebhookService.ListSubscriptionsCalls())
+func (mock *WebhookServiceMock) ListSubscriptionsCalls() []struct {
+	Ctx            context.Context
+	Service        string
+	OrganisationId string
+	ListArgs       *services.ListArguments
+} {
+	var calls []struct {
+		Ctx            context.Context
+		Service        string
+		OrganisationId string
+		ListArgs       *services.ListArguments
+	}
+	mock.lockListSubscriptions.RLock()
+	calls = mock.calls.ListSubscriptions
+	mock.lockListSubscriptions.RUnlock()
+	return calls
+}
+
+// Redeliver calls RedeliverFunc.
+func (mock *WebhookServiceMock) Redeliver(ctx context.Context, subscriptionId string, deliveryId string) (*api.WebhookDelivery, *errors.ServiceError) {
+	if mock.RedeliverFunc == nil {
+		panic("WebhookServiceMock.RedeliverFunc: method is nil but WebhookService.Redeliver was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		SubscriptionId string
+		DeliveryId     string
+	}{
+		Ctx:            ctx,
+		SubscriptionId: subscriptionId,
+		DeliveryId:     deliveryId,
+	}
+	mock.lockRedeliver.Lock()
+	mock.calls.Redeliver = append(mock.calls.Redeliver, callInfo)
+	mock.lockRedeliver.Unlock()
+	return mock.RedeliverFunc(ctx, subscriptionId, deliveryId)
+}
+
+// RedeliverCalls gets all the calls that were made to Redeliver.
+// Check the length with:
+//
+//	len(mockedWebhookService.RedeliverCalls())
+func (mock *WebhookServiceMock) RedeliverCalls() []struct {
+	Ctx            context.Context
+	SubscriptionId string
+	DeliveryId     string
+} {
+	var calls []struct {
+		Ctx            context.Context
+		SubscriptionId string
+		DeliveryId     string
+	}
+	mock.lockRedeliver.RLock()
+	calls = mock.calls.Redeliver
+	mock.lockRedeliver.RUnlock()
+	return calls
+}
+
+// UpdateSubscription calls UpdateSubscriptionFunc.
+func (mock *WebhookServiceMock) UpdateSubscription(ctx context.Context, subscription *api.WebhookSubscription) *errors.ServiceError {
+	if mock.UpdateSubscriptionFunc == nil {
+		panic("WebhookServiceMock.UpdateSubscriptionFunc: method is nil but WebhookService.UpdateSubscription was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		Subscription *api.WebhookSubscription
+	}{
+		Ctx:          ctx,
+		Subscription: subscription,
+	}
+	mock.lockUpdateSubscription.Lock()
+	mock.calls.UpdateSubscription = append(mock.calls.UpdateSubscription, callInfo)
+	mock.lockUpdateSubscription.Unlock()
+	return mock.UpdateSubscriptionFunc(ctx, subscription)
+}
+
+// UpdateSubscriptionCalls gets all the calls that were made to UpdateSubscription.
+// Check the length with:
+//
+//	len(mockedWebhookService.UpdateSubscriptionCalls())
+func (mock *WebhookServiceMock) UpdateSubscriptionCalls() []struct {
+	Ctx          context.Context
+	Subscription *api.WebhookSubscription
+} {
+	var calls []struct {
+		Ctx          context.Context
+		Subscription *api.WebhookSubscription
+	}
+	mock.lockUpdateSubscription.RLock()
+	calls = mock.calls.UpdateSubscription
+	mock.lockUpdateSubscription.RUnlock()
+	return calls
+}
@@ -0,0 +1,103 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/onsi/gomega"
+)
+
+func TestWebhookConfig_Backoff(t *testing.T) {
+	g := gomega.NewWithT(t)
+	config := &WebhookConfig{InitialBackoff: 30 * time.Second, MaxBackoff: 5 * time.Minute}
+
+	g.Expect(config.Backoff(1)).To(gomega.Equal(30 * time.Second))
+	g.Expect(config.Backoff(2)).To(gomega.Equal(time.Minute))
+	g.Expect(config.Backoff(3)).To(gomega.Equal(2 * time.Minute))
+	g.Expect(config.Backoff(4)).To(gomega.Equal(4 * time.Minute))
+	g.Expect(config.Backoff(5)).To(gomega.Equal(5 * time.Minute))
+	g.Expect(config.Backoff(100)).To(gomega.Equal(5 * time.Minute))
+}
+
+func TestWebhookService_attempt(t *testing.T) {
+	// the receiver listens on a loopback address
+	config := &WebhookConfig{MaxAttempts: 3, InitialBackoff: time.Minute, MaxBackoff: time.Hour, RequestTimeout: time.Second, AllowPrivateNetworks: true}
+
+	tests := []struct {
+		name            string
+		responseStatus  int
+		noSubscription  bool
+		attempts        int
+		wantStatus      string
+		wantNextAttempt bool
+		wantError       bool
+	}{
+		{
+			name:           "should succeed when the receiver accepts the delivery",
+			responseStatus: http.StatusOK,
+			wantStatus:     api.WebhookDeliverySucceeded,
+		},
+		{
+			name:            "should retry with a backoff when the receiver fails",
+			responseStatus:  http.StatusServiceUnavailable,
+			attempts:        1,
+			wantStatus:      api.WebhookDeliveryPending,
+			wantNextAttempt: true,
+			wantError:       true,
+		},
+		{
+			name:           "should fail once the maximum number of attempts is reached",
+			responseStatus: http.StatusServiceUnavailable,
+			attempts:       2,
+			wantStatus:     api.WebhookDeliveryFailed,
+			wantError:      true,
+		},
+		{
+			name:           "should fail the deliveries of deleted subscriptions",
+			noSubscription: true,
+			wantStatus:     api.WebhookDeliveryFailed,
+			wantError:      true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.responseStatus)
+			}))
+			defer receiver.Close()
+
+			service := NewWebhookService(nil, config)
+			var subscription *api.WebhookSubscription
+			if !tt.noSubscription {
+				subscription = &api.WebhookSubscription{Url: receiver.URL, Secret: "a-webhook-secret"}
+			}
+			delivery := &api.WebhookDelivery{
+				Payload:  api.JSON(`{}`),
+				Status:   api.WebhookDeliveryPending,
+				Attempts: tt.attempts,
+			}
+
+			service.attempt(context.Background(), subscription, delivery)
+			g.Expect(delivery.Status).To(gomega.Equal(tt.wantStatus))
+			g.Expect(delivery.NextAttemptAt != nil).To(gomega.Equal(tt.wantNextAttempt))
+			g.Expect(delivery.LastError != "").To(gomega.Equal(tt.wantError))
+			if tt.wantNextAttempt {
+				g.Expect(*delivery.NextAttemptAt).To(gomega.BeTemporally("~", time.Now().Add(config.Backoff(tt.attempts+1)), 5*time.Second))
+			}
+			if !tt.noSubscription {
+				g.Expect(delivery.Attempts).To(gomega.Equal(tt.attempts + 1))
+				g.Expect(delivery.LastStatusCode).To(gomega.Equal(tt.responseStatus))
+			}
+			if tt.wantStatus == api.WebhookDeliverySucceeded {
+				g.Expect(delivery.DeliveredAt).ToNot(gomega.BeNil())
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package cache
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/metrics"
+	"golang.org/x/sync/singleflight"
+)
+
+// Results of the lookups of a cache, reported in the cache metrics
+const (
+	ResultHit         = "hit"
+	ResultNegativeHit = "negative_hit"
+	ResultMiss        = "miss"
+)
+
+// Options of a Cache
+type Options[V any] struct {
+	// TTL is how long the loaded values are cached, a zero TTL disables the cache
+	TTL time.Duration
+	// NegativeTTL is how long the load errors and the negative values are cached, a zero NegativeTTL disables their caching
+	NegativeTTL time.Duration
+	// MaxEntries is the maximum number of cached entries, the least recently used entries are evicted beyond it. Zero means no limit.
+	MaxEntries int
+	// IsNegative returns whether a loaded value is negative, negative values are cached for NegativeTTL
+	IsNegative func(value V) bool
+	// SkipErrors disables the caching of the load errors, so that the next lookups load the value again
+	SkipErrors bool
+}
+
+// Cache is a TTL and LRU cache of the values of a lookup, e.g. a call to an external API.
+// The concurrent loads of the same key are deduplicated, so that a single call is in flight for a key.
+type Cache[K comparable, V any] struct {
+	name    string
+	options Options[V]
+
+	mutex   sync.Mutex
+	entries map[K]*list.Element
+	lru     *list.List
+	// generation is incremented by the invalidations, so that the values loaded before an invalidation aren't cached
+	generation uint64
+	loads      singleflight.Group
+	now        func() time.Time
+}
+
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	err       error
+	expiresAt time.Time
+}
+
+// New returns a cache, the name is the cache label of its metrics
+func New[K comparable, V any](name string, options Options[V]) *Cache[K, V] {
+	return &Cache[K, V]{
+		name:    name,
+		options: options,
+		entries: map[K]*list.Element{},
+		lru:     list.New(),
+		now:     time.Now,
+	}
+}
+
+// Get returns the cached value of the key, or the value returned by load. The errors of load are cached for NegativeTTL,
+// unless SkipErrors is set.
+func (c *Cache[K, V]) Get(key K, load func() (V, error)) (V, error) {
+	if c.options.TTL <= 0 {
+		return load()
+	}
+
+	if value, err, ok := c.get(key); ok {
+		if err != nil || c.isNegative(value) {
+			metrics.IncreaseCacheRequestCount(c.name, ResultNegativeHit)
+		} else {
+			metrics.IncreaseCacheRequestCount(c.name, ResultHit)
+		}
+		return value, err
+	}
+	metrics.IncreaseCacheRequestCount(c.name, ResultMiss)
+
+	loaded, err, _ := c.loads.Do(fmt.Sprintf("%#v", key), func() (interface{}, error) {
+		generation := c.currentGeneration()
+		value, err := load()
+		c.set(key, value, err, generation)
+		return value, err
+	})
+	value, _ := loaded.(V)
+	return value, err
+}
+
+// Invalidate removes the cached value of the key
+func (c *Cache[K, V]) Invalidate(key K) {
+	c.InvalidateFunc(func(k K) bool { return k == key })
+}
+
+// InvalidateFunc removes the cached values of the keys matching the predicate
+func (c *Cache[K, V]) InvalidateFunc(predicate func(key K) bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.generation++
+	for key, element := range c.entries {
+		if predicate(key) {
+			c.remove(element)
+		}
+	}
+}
+
+// Purge removes all the cached values
+func (c *Cache[K, V]) Purge() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.generation++
+	c.entries = map[K]*list.Element{}
+	c.lru.Init()
+}
+
+// Len returns the number of cached values, including the expired values not evicted yet
+func (c *Cache[K, V]) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.lru.Len()
+}
+
+func (c *Cache[K, V]) get(key K) (V, error, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var zero V
+	element, ok := c.entries[key]
+	if !ok {
+		return zero, nil, false
+	}
+	cached := element.Value.(*entry[K, V])
+	if !c.now().Before(cached.expiresAt) {
+		c.remove(element)
+		return zero, nil, false
+	}
+	c.lru.MoveToFront(element)
+	return cached.value, cached.err, true
+}
+
+func (c *Cache[K, V]) set(key K, value V, err error, generation uint64) {
+	if err != nil && c.options.SkipErrors {
+		return
+	}
+	ttl := c.options.TTL
+	if err != nil || c.isNegative(value) {
+		ttl = c.options.NegativeTTL
+	}
+	if ttl <= 0 {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+	c.entries[key] = c.lru.PushFront(&entry[K, V]{
+		key:       key,
+		value:     value,
+		err:       err,
+		expiresAt: c.now().Add(ttl),
+	})
+
+	for c.options.MaxEntries > 0 && c.lru.Len() > c.options.MaxEntries {
+		c.remove(c.lru.Back())
+		metrics.IncreaseCacheEvictionCount(c.name)
+	}
+}
+
+func (c *Cache[K, V]) currentGeneration() uint64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.generation
+}
+
+func (c *Cache[K, V]) remove(element *list.Element) {
+	c.lru.Remove(element)
+	delete(c.entries, element.Value.(*entry[K, V]).key)
+}
+
+func (c *Cache[K, V]) isNegative(value V) bool {
+	return c.options.IsNegative != nil && c.options.IsNegative(value)
+}
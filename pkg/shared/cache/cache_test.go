@@ -0,0 +1,207 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func Test_Cache_Get(t *testing.T) {
+	errLoad := fmt.Errorf("load failed")
+
+	tests := []struct {
+		name      string
+		options   Options[int]
+		loads     []func() (int, error)
+		elapsed   time.Duration
+		wantValue int
+		wantErr   error
+		wantCalls int
+	}{
+		{
+			name:      "should return the cached value before the TTL",
+			options:   Options[int]{TTL: time.Minute},
+			loads:     []func() (int, error){func() (int, error) { return 1, nil }, func() (int, error) { return 2, nil }},
+			elapsed:   30 * time.Second,
+			wantValue: 1,
+			wantCalls: 1,
+		},
+		{
+			name:      "should load the value again after the TTL",
+			options:   Options[int]{TTL: time.Minute},
+			loads:     []func() (int, error){func() (int, error) { return 1, nil }, func() (int, error) { return 2, nil }},
+			elapsed:   time.Minute,
+			wantValue: 2,
+			wantCalls: 2,
+		},
+		{
+			name:      "should not cache the values when the TTL is zero",
+			options:   Options[int]{},
+			loads:     []func() (int, error){func() (int, error) { return 1, nil }, func() (int, error) { return 2, nil }},
+			wantValue: 2,
+			wantCalls: 2,
+		},
+		{
+			name:      "should cache the errors for the negative TTL",
+			options:   Options[int]{TTL: time.Minute, NegativeTTL: 10 * time.Second},
+			loads:     []func() (int, error){func() (int, error) { return 0, errLoad }, func() (int, error) { return 2, nil }},
+			elapsed:   5 * time.Second,
+			wantErr:   errLoad,
+			wantCalls: 1,
+		},
+		{
+			name:      "should load the value again once the negative TTL of an error expired",
+			options:   Options[int]{TTL: time.Minute, NegativeTTL: 10 * time.Second},
+			loads:     []func() (int, error){func() (int, error) { return 0, errLoad }, func() (int, error) { return 2, nil }},
+			elapsed:   10 * time.Second,
+			wantValue: 2,
+			wantCalls: 2,
+		},
+		{
+			name:      "should not cache the errors when the negative TTL is zero",
+			options:   Options[int]{TTL: time.Minute},
+			loads:     []func() (int, error){func() (int, error) { return 0, errLoad }, func() (int, error) { return 2, nil }},
+			wantValue: 2,
+			wantCalls: 2,
+		},
+		{
+			name:      "should not cache the errors when they're skipped",
+			options:   Options[int]{TTL: time.Minute, NegativeTTL: 10 * time.Second, SkipErrors: true},
+			loads:     []func() (int, error){func() (int, error) { return 0, errLoad }, func() (int, error) { return 2, nil }},
+			elapsed:   5 * time.Second,
+			wantValue: 2,
+			wantCalls: 2,
+		},
+		{
+			name: "should cache the negative values for the negative TTL",
+			options: Options[int]{TTL: time.Minute, NegativeTTL: 10 * time.Second, IsNegative: func(value int) bool {
+				return value < 0
+			}},
+			loads:     []func() (int, error){func() (int, error) { return -1, nil }, func() (int, error) { return 2, nil }},
+			elapsed:   20 * time.Second,
+			wantValue: 2,
+			wantCalls: 2,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			now := time.Now()
+			c := New[string]("test", tt.options)
+			c.now = func() time.Time { return now }
+
+			calls := 0
+			var value int
+			var err error
+			for i, load := range tt.loads {
+				if i > 0 {
+					now = now.Add(tt.elapsed)
+				}
+				load := load
+				value, err = c.Get("key", func() (int, error) {
+					calls++
+					return load()
+				})
+			}
+			g.Expect(calls).To(gomega.Equal(tt.wantCalls))
+			if tt.wantErr != nil {
+				g.Expect(err).To(gomega.Equal(tt.wantErr))
+			} else {
+				g.Expect(err).ToNot(gomega.HaveOccurred())
+				g.Expect(value).To(gomega.Equal(tt.wantValue))
+			}
+		})
+	}
+}
+
+func Test_Cache_LRU(t *testing.T) {
+	g := gomega.NewWithT(t)
+	c := New[string]("test", Options[string]{TTL: time.Minute, MaxEntries: 2})
+	load := func(value string) func() (string, error) {
+		return func() (string, error) { return value, nil }
+	}
+
+	_, _ = c.Get("a", load("a"))
+	_, _ = c.Get("b", load("b"))
+	// a is the most recently used, b is evicted by c
+	_, _ = c.Get("a", load("a2"))
+	_, _ = c.Get("c", load("c"))
+	g.Expect(c.Len()).To(gomega.Equal(2))
+
+	value, _ := c.Get("a", load("a3"))
+	g.Expect(value).To(gomega.Equal("a"))
+	value, _ = c.Get("b", load("b2"))
+	g.Expect(value).To(gomega.Equal("b2"))
+}
+
+func Test_Cache_Invalidate(t *testing.T) {
+	g := gomega.NewWithT(t)
+	c := New[string]("test", Options[string]{TTL: time.Minute})
+	load := func(value string) func() (string, error) {
+		return func() (string, error) { return value, nil }
+	}
+
+	_, _ = c.Get("org-1/a", load("a"))
+	_, _ = c.Get("org-1/b", load("b"))
+	_, _ = c.Get("org-2/c", load("c"))
+
+	c.Invalidate("org-1/a")
+	value, _ := c.Get("org-1/a", load("a2"))
+	g.Expect(value).To(gomega.Equal("a2"))
+
+	c.InvalidateFunc(func(key string) bool { return key[:5] == "org-1" })
+	value, _ = c.Get("org-1/b", load("b2"))
+	g.Expect(value).To(gomega.Equal("b2"))
+	value, _ = c.Get("org-2/c", load("c2"))
+	g.Expect(value).To(gomega.Equal("c"))
+
+	c.Purge()
+	g.Expect(c.Len()).To(gomega.Equal(0))
+}
+
+func Test_Cache_InvalidateDuringLoad(t *testing.T) {
+	g := gomega.NewWithT(t)
+	c := New[string]("test", Options[string]{TTL: time.Minute})
+
+	// the value loaded before the invalidation may be stale, it must not be cached
+	value, _ := c.Get("key", func() (string, error) {
+		c.Invalidate("key")
+		return "stale", nil
+	})
+	g.Expect(value).To(gomega.Equal("stale"))
+	value, _ = c.Get("key", func() (string, error) { return "fresh", nil })
+	g.Expect(value).To(gomega.Equal("fresh"))
+}
+
+func Test_Cache_DeduplicatesConcurrentLoads(t *testing.T) {
+	g := gomega.NewWithT(t)
+	c := New[string]("test", Options[int]{TTL: time.Minute})
+
+	var calls int32
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := c.Get("key", func() (int, error) {
+				atomic.AddInt32(&calls, 1)
+				<-release
+				return 1, nil
+			})
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(value).To(gomega.Equal(1))
+		}()
+	}
+	// let the goroutines wait on the load in flight
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	g.Expect(atomic.LoadInt32(&calls)).To(gomega.Equal(int32(1)))
+}
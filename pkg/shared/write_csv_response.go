@@ -0,0 +1,17 @@
+package shared
+
+import (
+	"encoding/csv"
+	"net/http"
+)
+
+// WriteCSVResponse writes a csv HTTP response of the given HTTP status code and records.
+// The first record is expected to be the header of the columns
+func WriteCSVResponse(w http.ResponseWriter, code int, records [][]string) {
+	w.Header().Set("Content-Type", "text/csv")
+	// By default, decide whether or not a cache is usable based on the matching of the JWT
+	w.Header().Set("Vary", "Authorization")
+	w.WriteHeader(code)
+
+	_ = csv.NewWriter(w).WriteAll(records)
+}
@@ -0,0 +1,49 @@
+package workers
+
+import (
+	"context"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+)
+
+var _ Worker = &OutboxWorker{}
+
+// OutboxWorker periodically executes the pending outbox messages that have a registered handler
+type OutboxWorker struct {
+	BaseWorker
+	outbox *db.Outbox
+}
+
+// NewOutboxWorker creates a worker executing the outbox messages,
+// the worker type must match a leader lease type created by the migrations of the service
+func NewOutboxWorker(workerType string, outbox *db.Outbox, reconciler Reconciler) *OutboxWorker {
+	return &OutboxWorker{
+		BaseWorker: BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: workerType,
+			Reconciler: reconciler,
+		},
+		outbox: outbox,
+	}
+}
+
+func (w *OutboxWorker) Start() {
+	w.StartWorker(w)
+}
+
+func (w *OutboxWorker) Stop() {
+	w.StopWorker(w)
+}
+
+func (w *OutboxWorker) Reconcile() []error {
+	attempted, err := w.outbox.Process(context.Background())
+	if err != nil {
+		return []error{err}
+	}
+	if attempted > 0 {
+		glog.V(5).Infof("Attempted %d outbox messages", attempted)
+	}
+	return nil
+}